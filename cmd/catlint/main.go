@@ -0,0 +1,187 @@
+// Package main provides a CLI tool that lints categories.yaml against live
+// Wikidata. It resolves every configured QID and reports QIDs that no longer
+// exist, labels that drifted from the configured names, and root QIDs that
+// make classification ambiguous. It exits non-zero on hard errors.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/request"
+	"phileasgo/pkg/tracker"
+	"phileasgo/pkg/wikidata"
+)
+
+// ignoredOwner marks QIDs from the ignored_categories section.
+const ignoredOwner = "(ignored)"
+
+// EntityFetcher resolves labels and claims for a batch of QIDs.
+type EntityFetcher interface {
+	GetEntitiesBatch(ctx context.Context, ids []string) (map[string]wikidata.EntityMetadata, error)
+}
+
+// Report collects the lint findings. Errors fail the run; warnings do not.
+type Report struct {
+	Errors   []string
+	Warnings []string
+}
+
+// rootQID is one configured QID with its category and configured name.
+type rootQID struct {
+	Owner string
+	Name  string
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run() error {
+	catPath := flag.String("categories", "configs/categories.yaml", "Path to the categories config")
+	timeout := flag.Duration("timeout", 2*time.Minute, "Overall timeout for the Wikidata lookups")
+	flag.Parse()
+
+	catCfg, err := config.LoadCategories(*catPath)
+	if err != nil {
+		return fmt.Errorf("failed to load categories config: %w", err)
+	}
+
+	// No cache: the point is to compare against Wikidata as it is today
+	reqClient := request.New(nil, tracker.New(), request.ClientConfig{
+		Retries:   2,
+		Timeout:   30 * time.Second,
+		BaseDelay: 500 * time.Millisecond,
+		MaxDelay:  5 * time.Second,
+	})
+	wdClient := wikidata.NewClient(reqClient, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn})))
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	report, err := lint(ctx, catCfg, wdClient)
+	if err != nil {
+		return err
+	}
+
+	printReport(report)
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// lint checks every QID of the categories config against Wikidata.
+func lint(ctx context.Context, cfg *config.CategoriesConfig, wd EntityFetcher) (*Report, error) {
+	report := &Report{}
+	roots := collectRoots(cfg, report)
+
+	qids := make([]string, 0, len(roots))
+	for qid := range roots {
+		qids = append(qids, qid)
+	}
+	sort.Strings(qids)
+
+	meta, err := wd.GetEntitiesBatch(ctx, qids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch entities: %w", err)
+	}
+
+	for _, qid := range qids {
+		owners := roots[qid]
+		m, ok := meta[qid]
+		// Merged items come back under their new ID, deleted ones empty
+		if !ok || (len(m.Labels) == 0 && len(m.Claims) == 0) {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s (%s, %q) no longer exists on Wikidata", qid, owners[0].Owner, owners[0].Name))
+			continue
+		}
+
+		label := m.Labels["en"]
+		for _, o := range owners {
+			switch {
+			case label == "":
+				report.Warnings = append(report.Warnings, fmt.Sprintf("%s (%s) has no English label; configured as %q", qid, o.Owner, o.Name))
+			case !strings.EqualFold(strings.TrimSpace(label), strings.TrimSpace(o.Name)):
+				report.Warnings = append(report.Warnings, fmt.Sprintf("%s (%s) label drifted: configured %q, Wikidata %q", qid, o.Owner, o.Name, label))
+			}
+		}
+
+		if len(m.Claims["P279"]) == 0 && len(m.Claims["P31"]) > 0 {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("%s (%s) looks like an instance, not a class: it has P31 but no P279", qid, owners[0].Owner))
+		}
+
+		// A root that is a direct subclass of another category's root
+		// matches both categories when the classifier walks up P279
+		for _, parent := range m.Claims["P279"] {
+			for _, po := range roots[parent] {
+				for _, o := range owners {
+					if po.Owner != o.Owner {
+						report.Warnings = append(report.Warnings, fmt.Sprintf("%s (%s) is a subclass of %s (%s): classification is ambiguous", qid, o.Owner, parent, po.Owner))
+					}
+				}
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// collectRoots maps every configured QID to its owners and reports QIDs
+// listed more than once as errors.
+func collectRoots(cfg *config.CategoriesConfig, report *Report) map[string][]rootQID {
+	roots := make(map[string][]rootQID)
+
+	names := make([]string, 0, len(cfg.Categories))
+	for name := range cfg.Categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for qid, label := range cfg.Categories[name].QIDs {
+			roots[qid] = append(roots[qid], rootQID{Owner: name, Name: label})
+		}
+	}
+	for qid, label := range cfg.IgnoredCategories {
+		roots[qid] = append(roots[qid], rootQID{Owner: ignoredOwner, Name: label})
+	}
+
+	for qid, owners := range roots {
+		if len(owners) < 2 {
+			continue
+		}
+		list := make([]string, len(owners))
+		for i, o := range owners {
+			list[i] = o.Owner
+		}
+		report.Errors = append(report.Errors, fmt.Sprintf("%s is listed in several categories: %s", qid, strings.Join(list, ", ")))
+	}
+	return roots
+}
+
+func printReport(r *Report) {
+	sort.Strings(r.Errors)
+	sort.Strings(r.Warnings)
+
+	fmt.Printf("Errors (%d)\n", len(r.Errors))
+	for _, e := range r.Errors {
+		fmt.Printf("  ERROR %s\n", e)
+	}
+	fmt.Printf("\nWarnings (%d)\n", len(r.Warnings))
+	for _, w := range r.Warnings {
+		fmt.Printf("  WARN  %s\n", w)
+	}
+
+	if len(r.Errors) == 0 && len(r.Warnings) == 0 {
+		fmt.Println("\ncategories.yaml is clean.")
+	}
+}
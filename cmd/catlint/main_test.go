@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/wikidata"
+)
+
+type fakeFetcher struct {
+	meta map[string]wikidata.EntityMetadata
+	err  error
+}
+
+func (f *fakeFetcher) GetEntitiesBatch(ctx context.Context, ids []string) (map[string]wikidata.EntityMetadata, error) {
+	return f.meta, f.err
+}
+
+func entity(label string, claims map[string][]string) wikidata.EntityMetadata {
+	return wikidata.EntityMetadata{Labels: map[string]string{"en": label}, Claims: claims}
+}
+
+func TestLint(t *testing.T) {
+	cfg := &config.CategoriesConfig{
+		Categories: map[string]config.Category{
+			"Castle":    {QIDs: map[string]string{"Q23413": "castle", "Q1": "gone"}},
+			"Religious": {QIDs: map[string]string{"Q16970": "church", "Q2": "chapel"}},
+			"Tower":     {QIDs: map[string]string{"Q12518": "tower", "Q3": "lookout"}},
+		},
+		IgnoredCategories: map[string]string{"Q3": "lookout"},
+	}
+	fetcher := &fakeFetcher{meta: map[string]wikidata.EntityMetadata{
+		"Q23413": entity("castle", map[string][]string{"P279": {"Q57821"}}),
+		"Q16970": entity("church building", map[string][]string{"P279": {"Q24398318"}}),
+		"Q2":     entity("chapel", map[string][]string{"P279": {"Q16970"}}),
+		"Q12518": entity("tower", map[string][]string{"P279": {"Q811979"}}),
+		"Q3":     entity("lookout", map[string][]string{"P31": {"Q5"}}),
+		// Q1 is missing
+	}}
+
+	report, err := lint(context.Background(), cfg, fetcher)
+	if err != nil {
+		t.Fatalf("lint failed: %v", err)
+	}
+
+	wantErrors := []string{"Q1 (Castle", "Q3 is listed in several categories"}
+	wantWarnings := []string{
+		`Q16970 (Religious) label drifted`,
+		"Q3 (Tower) looks like an instance",
+	}
+	assertFindings(t, "error", report.Errors, wantErrors)
+	assertFindings(t, "warning", report.Warnings, wantWarnings)
+
+	// Q2 and Q16970 share a category, so their subclass link is not ambiguous
+	for _, w := range report.Warnings {
+		if strings.Contains(w, "ambiguous") {
+			t.Errorf("unexpected ambiguity warning: %s", w)
+		}
+	}
+}
+
+func TestLint_CrossCategorySubclass(t *testing.T) {
+	cfg := &config.CategoriesConfig{Categories: map[string]config.Category{
+		"Religious": {QIDs: map[string]string{"Q16970": "church building"}},
+		"Castle":    {QIDs: map[string]string{"Q9": "castle church"}},
+	}}
+	fetcher := &fakeFetcher{meta: map[string]wikidata.EntityMetadata{
+		"Q16970": entity("church building", nil),
+		"Q9":     entity("castle church", map[string][]string{"P279": {"Q16970"}}),
+	}}
+
+	report, err := lint(context.Background(), cfg, fetcher)
+	if err != nil {
+		t.Fatalf("lint failed: %v", err)
+	}
+	assertFindings(t, "warning", report.Warnings, []string{"Q9 (Castle) is a subclass of Q16970 (Religious)"})
+	if len(report.Errors) != 0 {
+		t.Errorf("expected no errors, got %v", report.Errors)
+	}
+}
+
+func TestLint_FetchError(t *testing.T) {
+	cfg := &config.CategoriesConfig{Categories: map[string]config.Category{"Castle": {QIDs: map[string]string{"Q23413": "castle"}}}}
+	if _, err := lint(context.Background(), cfg, &fakeFetcher{err: errors.New("offline")}); err == nil {
+		t.Error("expected the fetch error to be returned")
+	}
+}
+
+func assertFindings(t *testing.T, kind string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Errorf("expected %d %ss, got %d: %v", len(want), kind, len(got), got)
+	}
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if strings.Contains(g, w) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("expected a %s containing %q in %v", kind, w, got)
+		}
+	}
+}
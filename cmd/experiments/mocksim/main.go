@@ -0,0 +1,57 @@
+// Command mocksim replays a scripted route through the mock simulator and
+// prints the telemetry, for checking routes before using them in demos.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"time"
+
+	"phileasgo/pkg/sim/mocksim"
+)
+
+func main() {
+	routePath := flag.String("route", "cmd/experiments/mocksim/sightseeing_loop.yaml", "YAML waypoint file")
+	startup := flag.Bool("startup", false, "start parked and go through taxi and hold first")
+	interval := flag.Duration("interval", 5*time.Second, "telemetry print interval")
+	flag.Parse()
+
+	waypoints, err := mocksim.LoadRoute(*routePath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var prefix *mocksim.Config
+	if *startup {
+		prefix = &mocksim.Config{
+			DurationParked: 10 * time.Second,
+			DurationTaxi:   20 * time.Second,
+			DurationHold:   10 * time.Second,
+		}
+	}
+	client, err := mocksim.NewClientFromRoute(waypoints, prefix)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			tel, _ := client.GetTelemetry(ctx)
+			fmt.Printf("%-9s %9.5f %10.5f  hdg %5.1f  gs %5.1f kt  alt %6.0f ft  agl %6.0f ft  ground=%v\n",
+				tel.FlightStage, tel.Latitude, tel.Longitude, tel.Heading, tel.GroundSpeed, tel.AltitudeMSL, tel.AltitudeAGL, tel.IsOnGround)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"phileasgo/pkg/narrator"
 	"phileasgo/pkg/playback"
 	"phileasgo/pkg/poi"
+	"phileasgo/pkg/poi/ocean"
 	"phileasgo/pkg/poi/rivers"
 	"phileasgo/pkg/probe"
 	"phileasgo/pkg/request"
@@ -39,6 +41,7 @@ import (
 	"phileasgo/pkg/sim/simconnect"
 	"phileasgo/pkg/store"
 	"phileasgo/pkg/terrain"
+	"phileasgo/pkg/tour"
 	"phileasgo/pkg/tracker"
 	"phileasgo/pkg/tts"
 	"phileasgo/pkg/version"
@@ -99,7 +102,7 @@ func run(ctx context.Context, configPath string) error {
 	// Initialize Unified Config Provider
 	cfgProv := config.NewProvider(appCfg, st)
 
-	if err := maintenance.Run(ctx, st, dbConn, "data/Master.csv"); err != nil {
+	if err := maintenance.Run(ctx, st, dbConn, "data/Master.csv", time.Duration(appCfg.Wikidata.SeenPruneAfter)); err != nil {
 		slog.Error("Maintenance tasks failed", "error", err)
 	}
 
@@ -110,6 +113,8 @@ func run(ctx context.Context, configPath string) error {
 	defer simClient.Close()
 
 	tr := tracker.New()
+	inPerMTok, outPerMTok := cfgProv.AppConfig().LLM.TokenPrices()
+	tr.SetCosts(inPerMTok, outPerMTok, cfgProv.AppConfig().TTS.CostPerMChar)
 	catCfg, err := config.LoadCategories("configs/categories.yaml")
 	if err != nil {
 		return fmt.Errorf("failed to load categories config: %w", err)
@@ -146,6 +151,7 @@ func run(ctx context.Context, configPath string) error {
 	annMgr := comps.AnnManager
 	promptMgr := comps.PromptManager
 	sessionMgr := comps.SessionManager
+	narratorSvc.SetShutdownGrace(time.Duration(appCfg.Narrator.ShutdownGrace))
 	narratorSvc.Start()
 	defer narratorSvc.Stop()
 
@@ -154,12 +160,23 @@ func run(ctx context.Context, configPath string) error {
 
 	// Telemetry Handler (must be created before scheduler to receive updates)
 	telH := api.NewTelemetryHandler()
+	narratorSvc.SetOnStateChange(func(e narrator.PlaybackEvent) {
+		qid := ""
+		if e.POI != nil {
+			qid = e.POI.WikidataID
+		}
+		telH.PublishNarration(e.State, e.Title, string(e.Type), qid)
+	})
 
 	// Visibility
 	visCalc := initVisibility(st)
 
 	// Scheduler
-	sched := setupScheduler(cfgProv, simClient, st, narratorSvc, annMgr, promptMgr, wdValidator, svcs, telH, losChecker, visCalc, sessionMgr)
+	var valley *terrain.ValleyDetector
+	if elProv != nil && appCfg.Terrain.Valley.Enabled {
+		valley = terrain.NewValleyDetector(elProv, appCfg.Terrain.Valley.SampleRadiusKM, appCfg.Terrain.Valley.MinWallHeightM)
+	}
+	sched := setupScheduler(cfgProv, simClient, st, narratorSvc, annMgr, promptMgr, wdValidator, svcs, telH, losChecker, valley, visCalc, sessionMgr, tr)
 	go sched.Start(ctx)
 
 	// Session Persistence
@@ -167,20 +184,25 @@ func run(ctx context.Context, configPath string) error {
 	persistenceJob.Start(ctx)
 
 	// Scorer
-	// Use elProv, or if nil (missing file), use a nil interface (Scorer must handle or we wrap)
-	// Ideally Scorer should handle nil, but for now we pass it.
-	// We need to cast *ElevationProvider to ElevationGetter.
+	// Use elProv, or if nil (missing file), a nil interface rather than a typed nil.
 	var elevGetter terrain.ElevationGetter
 	if elProv != nil {
 		elevGetter = elProv
 	}
-	// If elevGetter is nil, NewSession might crash.
-	// Let's rely on Scorer handling nil optionally or just let it be nil for now.
-	// The previous code verified startup files.
+	// A nil elevGetter is fine: the scorer then assumes a sea-level valley floor.
 	poiScorer := scorer.NewScorer(&appCfg.Scorer, catCfg, visCalc, elevGetter, densityMgr, narratorSvc.LLMProvider().HasProfile("pregrounding"))
+	poiScorer.SetTuning(cfgProv)
+	if losChecker != nil {
+		poiScorer.SetLineOfSight(losChecker)
+	}
+	if appCfg.Scorer.RegionNoveltyWeight > 0 {
+		regionJob := core.NewRegionVisitJob(st, simClient, svcs.WikiSvc.GeoService(), time.Minute)
+		sched.AddJob(regionJob)
+		poiScorer.SetRegionNovelty(svcs.WikiSvc.GeoService(), regionJob)
+	}
 
 	// [NEW] Scoring Job
-	scoringJob := poi.NewScoringJob("POIScoring", svcs.PoiMgr, simClient, poiScorer, cfgProv, narratorSvc.IsPOIBusy, slog.Default())
+	scoringJob := poi.NewScoringJob(config.JobPOIScoring, svcs.PoiMgr, simClient, poiScorer, cfgProv, narratorSvc.IsPOIBusy, slog.Default())
 	sched.AddJob(scoringJob)
 
 	// Startup Probes
@@ -209,8 +231,67 @@ func run(ctx context.Context, configPath string) error {
 	// Reset stats to ignore startup/validation calls
 	tr.Reset()
 
+	// Health probes run again on demand for /health?verbose=true
+	health := probe.NewMonitor(healthProbes(dbConn, simClient, narratorSvc, svcs.ReqClient, elevGetter), 30*time.Second)
+
 	// Server
-	return runServer(ctx, cfgProv, svcs, narratorSvc, simClient, visCalc, tr, st, telH, elevGetter, promptMgr, sessionMgr, catCfg)
+	return runServer(ctx, cfgProv, svcs, narratorSvc, simClient, visCalc, tr, st, telH, elevGetter, promptMgr, sessionMgr, catCfg, health)
+}
+
+// healthProbes checks each subsystem the narration pipeline depends on. Only
+// the LLM and the database are critical; without the others narration
+// degrades but the app stays useful.
+func healthProbes(dbConn *db.DB, simClient sim.Client, ns narrator.Service, reqClient *request.Client, elevGetter terrain.ElevationGetter) []probe.Probe {
+	probes := []probe.Probe{
+		{
+			Name: "Simulator",
+			Check: func(context.Context) error {
+				if simClient.GetState() == sim.StateDisconnected {
+					return fmt.Errorf("not connected")
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "LLM",
+			Check:    ns.LLMProvider().ValidateModels,
+			Critical: true,
+		},
+		{
+			Name: "Elevation Data",
+			Check: func(context.Context) error {
+				if elevGetter == nil {
+					return fmt.Errorf("ETOPO1 file not found or invalid")
+				}
+				return nil
+			},
+		},
+		{
+			// Passive: an open breaker means recent requests failed, without
+			// adding load to the query service
+			Name: "Wikidata",
+			Check: func(context.Context) error {
+				for host, st := range reqClient.BreakerStates() {
+					if strings.HasSuffix(host, "wikidata.org") && st.State == request.BreakerOpen {
+						return fmt.Errorf("%s unreachable after %d failures", host, st.Failures)
+					}
+				}
+				return nil
+			},
+		},
+		{
+			Name:     "Database",
+			Check:    dbConn.PingContext,
+			Critical: true,
+		},
+	}
+	if th, ok := ns.(interface{ TTSHealth() error }); ok {
+		probes = append(probes, probe.Probe{
+			Name:  "TTS",
+			Check: func(context.Context) error { return th.TTSHealth() },
+		})
+	}
+	return probes
 }
 
 func initDB(appCfg *config.Config) (*db.DB, store.Store, error) {
@@ -218,14 +299,18 @@ func initDB(appCfg *config.Config) (*db.DB, store.Store, error) {
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to initialize database: %w", err)
 	}
-	return dbConn, store.NewSQLiteStore(dbConn), nil
+	st := store.NewSQLiteStore(dbConn)
+	st.SetSeenTTL(time.Duration(appCfg.Wikidata.SeenTTL))
+	return dbConn, st, nil
 }
 
 func initCoreServices(st store.Store, cfg config.Provider, tr *tracker.Tracker, simClient sim.Client, catCfg *config.CategoriesConfig) (*CoreServices, *wikidata.DensityManager, error) {
 	appCfg := cfg.AppConfig()
 	geoSvc, err := geo.NewServiceEmbedded(geodata.GeoData)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to initialize geo service: %w", err)
+		// Countries can still be told apart without the city index
+		slog.Warn("City data unavailable, running without city names", "error", err)
+		geoSvc = geo.NewDegradedService()
 	}
 
 	// Initialize CountryService for accurate country boundary detection (embedded data)
@@ -240,12 +325,19 @@ func initCoreServices(st store.Store, cfg config.Provider, tr *tracker.Tracker,
 		Timeout:   time.Duration(appCfg.Request.Timeout),
 		BaseDelay: time.Duration(appCfg.Request.Backoff.BaseDelay),
 		MaxDelay:  time.Duration(appCfg.Request.Backoff.MaxDelay),
+
+		BreakerThreshold: appCfg.Request.Breaker.Threshold,
+		BreakerCooldown:  time.Duration(appCfg.Request.Breaker.Cooldown),
 	})
 
 	poiMgr := poi.NewManager(cfg, st, catCfg)
 	wikiClient := wikidata.NewClient(reqClient, slog.With("component", "wikidata_client"))
-	smartClassifier := classifier.NewClassifier(st, wikiClient, catCfg, tr)
+	smartClassifier := classifier.NewClassifier(st, wikiClient, catCfg, tr, classifier.Limits{
+		MaxDepth:        appCfg.Wikidata.Classifier.MaxDepth,
+		MaxNodesVisited: appCfg.Wikidata.Classifier.MaxNodesVisited,
+	})
 	wpClient := wikipedia.NewClient(reqClient)
+	wpClient.SetMissingCache(st, time.Duration(appCfg.Narrator.WikipediaExtract.MissingTTL))
 
 	tr.SetFreeTier("wikidata", true)
 	tr.SetFreeTier("wikipedia", true)
@@ -279,6 +371,8 @@ func initCoreServices(st store.Store, cfg config.Provider, tr *tracker.Tracker,
 		WikiClient:      wikiClient,
 		WikipediaClient: wpClient,
 		SpatialFeature:  spatialSvc,
+		Tour:            initTour(&appCfg.Narrator.Tour),
+		NarrationGap:    core.NewNarrationGap(appCfg.Narrator.Gap),
 	}, densityMgr, nil
 }
 
@@ -313,6 +407,10 @@ func initNarrator(ctx context.Context, cfg config.Provider, svcs *CoreServices,
 	}
 
 	sessionMgr := session.NewManager(simClient)
+	svcs.PoiMgr.SetSuppressionCheck(sessionMgr.IsSuppressed)
+	if _, err := svcs.PoiMgr.LoadUserPOIs(ctx); err != nil {
+		slog.Warn("Failed to load user POIs", "error", err)
+	}
 
 	var beaconSvc *beacon.Service
 	// Initialize Beacon Service if enabled in config
@@ -342,9 +440,16 @@ func initNarrator(ctx context.Context, cfg config.Provider, svcs *CoreServices,
 
 	pbQ := playback.NewManager()
 	gen := createAIService(cfg, llmProv, ttsProv, promptMgr, svcs.PoiMgr, svcs.WikiSvc, simClient, st, tr, catCfg, sessionMgr, densityMgr)
+	gen.SetFactSource(svcs.WikiClient)
 
 	orch := narrator.NewOrchestrator(gen, audio.New(&appCfg.Narrator), pbQ, sessionMgr, beaconProvider, simClient, beaconReg, beaconOrder)
+	orch.SetMarkQueuedBeacons(appCfg.Beacon.MarkQueued)
+	orch.SetChimeResolver(catCfg.ChimePath)
+	orch.SetPacer(svcs.NarrationGap)
+	orch.SetMaxHold(time.Duration(appCfg.Narrator.Comms.MaxHold))
 	gen.SetOnPlayback(orch.EnqueuePlayback)
+	// Keep POIs that are playing, queued or being generated through the tracked cap
+	svcs.PoiMgr.SetEvictionGuard(orch.IsPOIBusy)
 
 	// Restore Volume
 	volStr, _ := st.GetState(ctx, "volume")
@@ -361,6 +466,10 @@ func initNarrator(ctx context.Context, cfg config.Provider, svcs *CoreServices,
 	annMgr.Register(announcement.NewBriefing(appCfg, orch, sessionMgr))
 	annMgr.Register(announcement.NewDebriefing(appCfg, orch, sessionMgr))
 	annMgr.Register(announcement.NewBorder(appCfg, svcs.WikiSvc.GeoService(), orch, sessionMgr))
+	if appCfg.Narrator.OpenWater.Enabled {
+		oceanSentinel := ocean.NewSentinelEmbedded(slog.With("component", "ocean_sentinel"))
+		annMgr.Register(announcement.NewOpenWater(appCfg, oceanSentinel, orch, sessionMgr))
+	}
 
 	return &NarratorComponents{
 		Orchestrator:   orch,
@@ -384,6 +493,23 @@ func verifyStartup(ctx context.Context, catCfg *config.CategoriesConfig, v *wiki
 	_ = v.VerifyStartupConfig(verifyCtx, catQIDs)
 }
 
+// initTour starts the tour configured at Narrator.Tour.Path, if any. A tour
+// that fails to load is logged and skipped; normal narration is unaffected.
+func initTour(cfg *config.TourConfig) *tour.Tour {
+	t := tour.New()
+	if cfg.Path == "" {
+		return t
+	}
+	stops, err := tour.LoadFile(cfg.Path)
+	if err == nil {
+		err = t.Start(stops)
+	}
+	if err != nil {
+		slog.Warn("Failed to start configured tour", "path", cfg.Path, "error", err)
+	}
+	return t
+}
+
 func initVisibility(st store.Store) *visibility.Calculator {
 	visManager, err := visibility.NewManager("configs/visibility.yaml")
 	if err != nil {
@@ -408,29 +534,41 @@ func initElevation(cfg *config.Config) (*terrain.ElevationProvider, *terrain.LOS
 	return provider, terrain.NewLOSChecker(provider)
 }
 
-func runServer(ctx context.Context, cfg config.Provider, svcs *CoreServices, ns narrator.Service, simClient sim.Client, vis *visibility.Calculator, tr *tracker.Tracker, st store.Store, telH *api.TelemetryHandler, elevGetter terrain.ElevationGetter, promptMgr *prompts.Manager, sessionMgr *session.Manager, catCfg *config.CategoriesConfig) error {
+func runServer(ctx context.Context, cfg config.Provider, svcs *CoreServices, ns narrator.Service, simClient sim.Client, vis *visibility.Calculator, tr *tracker.Tracker, st store.Store, telH *api.TelemetryHandler, elevGetter terrain.ElevationGetter, promptMgr *prompts.Manager, sessionMgr *session.Manager, catCfg *config.CategoriesConfig, health *probe.Monitor) error {
 	appCfg := cfg.AppConfig()
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 	shutdownFunc := func() { quit <- syscall.SIGTERM }
 
-	statsH := api.NewStatsHandler(tr, svcs.PoiMgr, appCfg.LLM.Fallback)
+	statsH := api.NewStatsHandler(tr, svcs.PoiMgr, simClient, cfg, svcs.ReqClient, appCfg.LLM.Fallback)
+	if hr, ok := ns.LLMProvider().(api.LLMHealthReporter); ok {
+		statsH.SetLLMHealth(hr)
+	}
 	configH := api.NewConfigHandler(st, cfg, catCfg)
+	if pw, ok := ns.(api.PredictionWindowApplier); ok {
+		configH.SetPredictionWindowApplier(pw)
+	}
 	geoH := api.NewGeographyHandler(svcs.WikiSvc.GeoService())
 	labelMgr := labels.NewManager(svcs.WikiSvc.GeoService(), svcs.PoiMgr, cfg)
 	labelH := api.NewMapLabelsHandler(labelMgr)
 	simH := api.NewSimCommandHandler(simClient)
 	regionalH := api.NewRegionalCategoriesHandler(svcs.Classifier, st)
+	narratorH := api.NewNarratorHandler(ns.AudioService(), ns, st)
+	narratorH.SetPacer(svcs.NarrationGap)
+	var metricsH *api.MetricsHandler
+	if appCfg.Server.Metrics {
+		metricsH = api.NewMetricsHandler(tr, ns)
+	}
 
 	srv := api.NewServer(appCfg.Server.Address,
 		telH,
 		configH,
 		statsH,
-		api.NewCacheHandler(svcs.WikiSvc),
+		api.NewCacheHandler(svcs.WikiSvc, svcs.PoiMgr),
 		api.NewPOIHandler(svcs.PoiMgr, svcs.WikipediaClient, st, cfg, ns.LLMProvider(), promptMgr),
 		api.NewVisibilityHandler(vis, simClient, elevGetter, st, svcs.WikiSvc),
 		api.NewAudioHandler(ns.AudioService(), ns, st),
-		api.NewNarratorHandler(ns.AudioService(), ns, st),
+		narratorH,
 		api.NewImageHandler(appCfg),
 		geoH,
 		api.NewTripHandler(sessionMgr, st),
@@ -438,6 +576,10 @@ func runServer(ctx context.Context, cfg config.Provider, svcs *CoreServices, ns
 		simH,
 		regionalH,
 		api.NewFeaturesHandler(svcs.SpatialFeature, telH),
+		api.NewTerrainHandler(elevGetter),
+		api.NewTourHandler(svcs.Tour),
+		metricsH,
+		api.NewHealthHandler(health),
 		shutdownFunc,
 	)
 
@@ -445,7 +587,7 @@ func runServer(ctx context.Context, cfg config.Provider, svcs *CoreServices, ns
 	return runServerLifecycle(ctx, srv, quit)
 }
 
-func setupScheduler(cfg config.Provider, simClient sim.Client, st store.Store, narratorSvc narrator.Service, annMgr *announcement.Manager, pm *prompts.Manager, v *wikidata.Validator, svcs *CoreServices, apiHandler *api.TelemetryHandler, los *terrain.LOSChecker, vis *visibility.Calculator, sessionMgr *session.Manager) *core.Scheduler {
+func setupScheduler(cfg config.Provider, simClient sim.Client, st store.Store, narratorSvc narrator.Service, annMgr *announcement.Manager, pm *prompts.Manager, v *wikidata.Validator, svcs *CoreServices, apiHandler *api.TelemetryHandler, los *terrain.LOSChecker, valley *terrain.ValleyDetector, vis *visibility.Calculator, sessionMgr *session.Manager, tr *tracker.Tracker) *core.Scheduler {
 	appCfg := cfg.AppConfig()
 	sched := core.NewScheduler(cfg, simClient, apiHandler, svcs.WikiSvc.GeoService())
 	// Session Restoration (Restores session state on startup)
@@ -455,24 +597,35 @@ func setupScheduler(cfg config.Provider, simClient sim.Client, st store.Store, n
 		_ = st.MarkEntitiesSeen(c, map[string][]string{})
 	}))
 
+	// Track the distance flown for the debrief
+	sched.AddJob(core.NewDistanceJob("SessionDistance", 500, func(c context.Context, t sim.Telemetry) {
+		sessionMgr.AddTrackPoint(t.Latitude, t.Longitude)
+	}))
+
+	// Flight recorder
+	if rec := appCfg.Sim.Recorder; rec.Enabled && rec.MaxSamples > 0 {
+		sched.AddJob(core.NewTelemetryRecorderJob(st, simClient, time.Duration(rec.Interval), rec.MaxSamples))
+	}
+
 	// Register Resettables for Teleport Detection
 	sched.AddResettable(narratorSvc)
 	sched.AddResettable(svcs.PoiMgr)
 	sched.AddResettable(annMgr)
 	sched.AddResettable(sessionMgr)
+	sched.AddResettable(tr)
 
 	// Register Cleanup Job (runs every 10s)
-	sched.AddJob(core.NewTimeJob("CacheCleanup", 10*time.Second, func(c context.Context, t sim.Telemetry) {
+	sched.AddJob(core.NewTimeJob(config.JobCacheCleanup, appCfg.Scheduler.Interval(config.JobCacheCleanup), func(c context.Context, t sim.Telemetry) {
 		// Clean up old cache entries if needed
 	}))
 
 	// Register Announcement Jobs (Standard) - 1Hz
-	sched.AddJob(core.NewTimeJob("Announcements", 1*time.Second, func(c context.Context, t sim.Telemetry) {
+	sched.AddJob(core.NewTimeJob(config.JobAnnouncements, appCfg.Scheduler.Interval(config.JobAnnouncements), func(c context.Context, t sim.Telemetry) {
 		annMgr.Tick(c, &t)
 	}))
 
 	// Register River Job (runs every 15s, detects nearby rivers)
-	sched.AddJob(core.NewRiverJob(svcs.PoiMgr))
+	sched.AddJob(core.NewRiverJob(svcs.PoiMgr, appCfg.Scheduler.Interval(config.JobRiver)))
 
 	// Register Debrief Job (implicitly added by NewScheduler via debriefer arg)
 
@@ -492,6 +645,11 @@ func setupScheduler(cfg config.Provider, simClient sim.Client, st store.Store, n
 
 	// Hook NarrationJob into POI Manager's scoring loop (every 5s) instead of Scheduler
 	narrationJob := core.NewNarrationJob(cfg, narratorSvc, narratorSvc.POIManager(), simClient, st, los)
+	narrationJob.SetValleyDetector(valley, appCfg.Terrain.Valley.PeakBoost)
+	narrationJob.SetCostTracker(tr)
+	narrationJob.SetTour(svcs.Tour)
+	narrationJob.SetGap(svcs.NarrationGap)
+	narrationJob.SetNarratedPOILocator(sessionMgr)
 	svcs.PoiMgr.SetScoringCallback(func(c context.Context, t *sim.Telemetry) {
 		// 1. Process Sync Priority Queue (Manual Overrides)
 		if narratorSvc.HasPendingGeneration() {
@@ -525,6 +683,13 @@ func setupScheduler(cfg config.Provider, simClient sim.Client, st store.Store, n
 		sched.AddJob(core.NewTransponderWatcherJob(cfg, narratorSvc, st, vis))
 	}
 
+	// Hold narration during radio calls, where the sim reports push-to-talk
+	if appCfg.Narrator.Comms.AutoDetect {
+		if holder, ok := narratorSvc.(core.CommsHolder); ok {
+			sched.AddJob(core.NewCommsWatcherJob(holder))
+		}
+	}
+
 	return sched
 }
 
@@ -565,6 +730,8 @@ type CoreServices struct {
 	WikiClient      *wikidata.Client
 	WikipediaClient *wikipedia.Client
 	SpatialFeature  *geo.FeatureService
+	Tour            *tour.Tour
+	NarrationGap    *core.NarrationGap
 }
 
 func createAIService(cfg config.Provider, llmProv llm.Provider, ttsProv tts.Provider, promptMgr *prompts.Manager, poiMgr narrator.POIProvider, wikiSvc *wikidata.Service, simClient sim.Client, st store.Store, tr *tracker.Tracker, catCfg *config.CategoriesConfig, sessionMgr *session.Manager, densityMgr *wikidata.DensityManager) *narrator.AIService {
@@ -582,7 +749,7 @@ func createAIService(cfg config.Provider, llmProv llm.Provider, ttsProv tts.Prov
 		avoid = interestsCfg.Avoid
 	}
 
-	return narrator.NewAIService(
+	svc := narrator.NewAIService(
 		cfg,
 		llmProv,
 		ttsProv,
@@ -602,4 +769,12 @@ func createAIService(cfg config.Provider, llmProv llm.Provider, ttsProv tts.Prov
 		densityMgr,
 		wikiSvc,
 	)
+
+	voices, err := config.LoadVoices("configs/voices.yaml")
+	if err != nil {
+		slog.Warn("Failed to load voices config, using engine voices", "error", err)
+	} else {
+		svc.SetVoices(voices)
+	}
+	return svc
 }
@@ -25,6 +25,7 @@ func initializeSimClient(ctx context.Context, cfgProv config.Provider) (sim.Clie
 			StartLon:       cfgProv.MockStartLon(ctx),
 			StartAlt:       cfgProv.MockStartAlt(ctx),
 			StartHeading:   cfgProv.MockStartHeading(ctx),
+			SimRate:        cfgProv.AppConfig().Sim.Mock.SimRate,
 		}), nil
 	}
 
@@ -42,6 +43,7 @@ func initializeSimClient(ctx context.Context, cfgProv config.Provider) (sim.Clie
 			StartLon:       cfgProv.MockStartLon(ctx),
 			StartAlt:       cfgProv.MockStartAlt(ctx),
 			StartHeading:   cfgProv.MockStartHeading(ctx),
+			SimRate:        cfgProv.AppConfig().Sim.Mock.SimRate,
 		}), nil
 	}
 	return sc, nil
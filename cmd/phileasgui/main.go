@@ -73,7 +73,8 @@ func main() {
 	// Check GetLastError for ERROR_ALREADY_EXISTS (183)
 	errCode, _, _ := procGetLastError.Call()
 	if errCode == 183 {
-		// Already running.
+		// Already running, possibly hidden in the tray: bring it up instead.
+		showExistingWindow("PhileasGUI")
 		return
 	}
 	defer func() {
@@ -117,7 +118,33 @@ func main() {
 	// Set initial size, but we might override it with placement
 	w.SetSize(guiCfg.Window.Width, guiCfg.Window.Height, webview.HintNone)
 
-	// GUI Maintenance (Icon, Restore, Hook)
+	// Go bindings calling JS functions
+	logProxy := func(msg string) {
+		w.Dispatch(func() {
+			w.Eval("window.addLogLine(" + escapeJS(msg) + ")")
+		})
+	}
+
+	termProxy := func(name string) {
+		w.Dispatch(func() {
+			w.Eval("window.setTerminalTitle(" + escapeJS(name) + ")")
+		})
+	}
+
+	appProxy := func(url string) {
+		w.Dispatch(func() {
+			w.Eval("window.enableApp(" + escapeJS(url) + ")")
+		})
+	}
+
+	mgr := NewManager(logProxy, termProxy, appProxy, mainCfg.Server.Address)
+
+	var tray *Tray
+	if guiCfg.Tray.Enabled {
+		tray = NewTray(mgr, guiCfg.Tray.CloseToTray)
+	}
+
+	// GUI Maintenance (Icon, Restore, Hook, Tray)
 	go func() {
 		iconSet := false
 		var hwnd uintptr
@@ -132,8 +159,11 @@ func main() {
 		// Initial Restore
 		restoreWindowPlacement(hwnd, guiCfg)
 
-		// Native Hook for "Save on Close"
-		subclassWindow(hwnd, guiCfg)
+		// Native Hook for "Save on Close" and tray messages
+		subclassWindow(hwnd, guiCfg, tray)
+		if tray != nil && !tray.Add(hwnd) {
+			mgr.log("> Could not add the tray icon.")
+		}
 
 		// Icon Maintenance
 		for {
@@ -150,27 +180,6 @@ func main() {
 		}
 	}()
 
-	// Go bindings calling JS functions
-	logProxy := func(msg string) {
-		w.Dispatch(func() {
-			w.Eval("window.addLogLine(" + escapeJS(msg) + ")")
-		})
-	}
-
-	termProxy := func(name string) {
-		w.Dispatch(func() {
-			w.Eval("window.setTerminalTitle(" + escapeJS(name) + ")")
-		})
-	}
-
-	appProxy := func(url string) {
-		w.Dispatch(func() {
-			w.Eval("window.enableApp(" + escapeJS(url) + ")")
-		})
-	}
-
-	mgr := NewManager(logProxy, termProxy, appProxy, mainCfg.Server.Address)
-
 	_ = w.Bind("appReady", func() {
 		// Callback from JS if needed
 	})
@@ -197,6 +206,9 @@ func main() {
 	mgr.Start()
 
 	w.Run()
+	if tray != nil {
+		tray.Remove()
+	}
 	mgr.Stop()
 }
 
@@ -232,12 +244,17 @@ func restoreWindowPlacement(hwnd uintptr, cfg *config.GUIConfig) {
 	_, _, _ = procSetWindowPlacement.Call(hwnd, uintptr(unsafe.Pointer(&wp)))
 }
 
-func subclassWindow(hwnd uintptr, cfg *config.GUIConfig) {
-	// WM_CLOSE is 0x0010
+func subclassWindow(hwnd uintptr, cfg *config.GUIConfig, tray *Tray) {
 	// GWLP_WNDPROC is -4
 	callback := syscall.NewCallback(func(hwnd uintptr, msg uint32, wParam, lParam uintptr) uintptr {
-		if msg == 0x0010 { // WM_CLOSE
+		if msg == WM_CLOSE {
 			saveWindowPlacement(hwnd, cfg)
+			if tray != nil && tray.interceptClose(hwnd) {
+				return 0
+			}
+		}
+		if tray != nil && tray.handleMessage(msg, lParam) {
+			return 0
 		}
 		ret, _, _ := procCallWindowProc.Call(originalWndProc, hwnd, uintptr(msg), wParam, lParam)
 		return ret
@@ -286,6 +303,20 @@ func setWindowIcon(title string) bool {
 		return false
 	}
 
+	hIcon := loadAppIcon()
+	if hIcon == 0 {
+		return false
+	}
+
+	// Send WM_SETICON for both small and big icons
+	_, _, _ = procSendMessage.Call(hwnd, WM_SETICON, ICON_SMALL, hIcon)
+	_, _, _ = procSendMessage.Call(hwnd, WM_SETICON, ICON_BIG, hIcon)
+
+	return true
+}
+
+// loadAppIcon loads the embedded "APP" icon, or returns 0.
+func loadAppIcon() uintptr {
 	// Get module handle (NULL = current exe)
 	hInstance, _, _ := procGetModuleHandle.Call(0)
 
@@ -297,13 +328,16 @@ func setWindowIcon(title string) bool {
 		// Try with ordinal 1 as fallback
 		hIcon, _, _ = procLoadIcon.Call(hInstance, uintptr(1))
 	}
-	if hIcon == 0 {
-		return false
-	}
-
-	// Send WM_SETICON for both small and big icons
-	_, _, _ = procSendMessage.Call(hwnd, WM_SETICON, ICON_SMALL, hIcon)
-	_, _, _ = procSendMessage.Call(hwnd, WM_SETICON, ICON_BIG, hIcon)
+	return hIcon
+}
 
-	return true
+// showExistingWindow restores and focuses the running instance's window.
+func showExistingWindow(title string) {
+	titlePtr, _ := syscall.UTF16PtrFromString(title)
+	hwnd, _, _ := procFindWindow.Call(0, uintptr(unsafe.Pointer(titlePtr)))
+	if hwnd == 0 {
+		return
+	}
+	_, _, _ = procShowWindow.Call(hwnd, SW_RESTORE)
+	_, _, _ = procSetForegroundWindow.Call(hwnd)
 }
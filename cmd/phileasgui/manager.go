@@ -2,7 +2,9 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -211,3 +213,51 @@ func (m *Manager) isServerReady() bool {
 	defer resp.Body.Close()
 	return resp.StatusCode == 200
 }
+
+// AudioControl sends a playback action ("pause", "resume", "skip", "replay")
+// to the server.
+func (m *Manager) AudioControl(action string) error {
+	return m.postJSON("/api/audio/control", map[string]string{"action": action})
+}
+
+// SetVolume sets the narration volume (0-1) on the server.
+func (m *Manager) SetVolume(vol float64) error {
+	return m.postJSON("/api/audio/volume", map[string]float64{"volume": vol})
+}
+
+// AudioStatus reports whether the user paused narration and the current
+// volume.
+func (m *Manager) AudioStatus() (paused bool, volume float64, err error) {
+	client := http.Client{Timeout: 500 * time.Millisecond}
+	resp, err := client.Get(fmt.Sprintf("http://%s/api/audio/status", m.resolveAddr()))
+	if err != nil {
+		return false, 0, err
+	}
+	defer resp.Body.Close()
+
+	var status struct {
+		IsUserPaused bool    `json:"is_user_paused"`
+		Volume       float64 `json:"volume"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return false, 0, fmt.Errorf("invalid audio status: %w", err)
+	}
+	return status.IsUserPaused, status.Volume, nil
+}
+
+func (m *Manager) postJSON(path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	client := http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Post(fmt.Sprintf("http://%s%s", m.resolveAddr(), path), "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned status %d", path, resp.StatusCode)
+	}
+	return nil
+}
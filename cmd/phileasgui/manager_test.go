@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -219,3 +221,48 @@ func TestManager_ServerStatus(t *testing.T) {
 		t.Error("isServerReady() should be false for invalid port")
 	}
 }
+
+func TestManager_AudioControls(t *testing.T) {
+	var mu sync.Mutex
+	var got []string
+
+	handler := http.NewServeMux()
+	record := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		got = append(got, r.URL.Path+" "+strings.TrimSpace(string(body)))
+		mu.Unlock()
+	}
+	handler.HandleFunc("/api/audio/control", record)
+	handler.HandleFunc("/api/audio/volume", record)
+	handler.HandleFunc("/api/audio/status", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"is_user_paused":true,"volume":0.5}`))
+	})
+	server := &http.Server{Handler: handler}
+	ln, _ := net.Listen("tcp", "127.0.0.1:0")
+	go server.Serve(ln)
+	defer server.Shutdown(context.Background())
+
+	m := &Manager{serverAddr: ln.Addr().String()}
+
+	if err := m.AudioControl("skip"); err != nil {
+		t.Errorf("AudioControl() error = %v", err)
+	}
+	if err := m.SetVolume(0.75); err != nil {
+		t.Errorf("SetVolume() error = %v", err)
+	}
+	want := []string{
+		`/api/audio/control {"action":"skip"}`,
+		`/api/audio/volume {"volume":0.75}`,
+	}
+	mu.Lock()
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("requests = %q, want %q", got, want)
+	}
+	mu.Unlock()
+
+	paused, vol, err := m.AudioStatus()
+	if err != nil || !paused || vol != 0.5 {
+		t.Errorf("AudioStatus() = %v, %v, %v; want true, 0.5, nil", paused, vol, err)
+	}
+}
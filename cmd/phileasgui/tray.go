@@ -0,0 +1,244 @@
+package main
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	shell32                   = syscall.NewLazyDLL("shell32.dll")
+	procShellNotifyIcon       = shell32.NewProc("Shell_NotifyIconW")
+	procCreatePopupMenu       = user32.NewProc("CreatePopupMenu")
+	procAppendMenu            = user32.NewProc("AppendMenuW")
+	procTrackPopupMenu        = user32.NewProc("TrackPopupMenu")
+	procDestroyMenu           = user32.NewProc("DestroyMenu")
+	procGetCursorPos          = user32.NewProc("GetCursorPos")
+	procSetForegroundWindow   = user32.NewProc("SetForegroundWindow")
+	procShowWindow            = user32.NewProc("ShowWindow")
+	procPostMessage           = user32.NewProc("PostMessageW")
+	procRegisterWindowMessage = user32.NewProc("RegisterWindowMessageW")
+)
+
+const (
+	WM_CLOSE         = 0x0010
+	WM_NULL          = 0x0000
+	WM_LBUTTONUP     = 0x0202
+	WM_LBUTTONDBLCLK = 0x0203
+	WM_RBUTTONUP     = 0x0205
+	WM_APP           = 0x8000
+	WM_TRAYICON      = WM_APP + 1
+
+	NIM_ADD     = 0x0
+	NIM_DELETE  = 0x2
+	NIF_MESSAGE = 0x1
+	NIF_ICON    = 0x2
+	NIF_TIP     = 0x4
+
+	MF_STRING    = 0x0000
+	MF_CHECKED   = 0x0008
+	MF_POPUP     = 0x0010
+	MF_SEPARATOR = 0x0800
+
+	TPM_RIGHTBUTTON = 0x0002
+	TPM_NONOTIFY    = 0x0080
+	TPM_RETURNCMD   = 0x0100
+
+	SW_HIDE    = 0
+	SW_RESTORE = 9
+)
+
+// Tray menu command IDs. Volume steps are cmdVolume + percent/25.
+const (
+	cmdShow = iota + 1
+	cmdPauseResume
+	cmdSkip
+	cmdReplay
+	cmdExit
+	cmdVolume = 100
+)
+
+type NOTIFYICONDATA struct {
+	CbSize           uint32
+	HWnd             uintptr
+	UID              uint32
+	UFlags           uint32
+	UCallbackMessage uint32
+	HIcon            uintptr
+	SzTip            [128]uint16
+	DwState          uint32
+	DwStateMask      uint32
+	SzInfo           [256]uint16
+	UVersion         uint32
+	SzInfoTitle      [64]uint16
+	DwInfoFlags      uint32
+	GuidItem         [16]byte
+	HBalloonIcon     uintptr
+}
+
+// Tray is the notification area icon with quick narration controls. Its
+// messages arrive through the subclassed main window, so all menu handling
+// runs on the UI thread; server calls are made in the background.
+type Tray struct {
+	mgr         *Manager
+	closeToTray bool
+	exiting     bool
+	hwnd        uintptr
+	data        NOTIFYICONDATA
+	// Explorer broadcasts this after a restart, when tray icons must be re-added
+	taskbarCreated uint32
+}
+
+// NewTray creates the tray for the window; call Add once the window exists.
+func NewTray(mgr *Manager, closeToTray bool) *Tray {
+	name, _ := syscall.UTF16PtrFromString("TaskbarCreated")
+	msg, _, _ := procRegisterWindowMessage.Call(uintptr(unsafe.Pointer(name)))
+	return &Tray{mgr: mgr, closeToTray: closeToTray, taskbarCreated: uint32(msg)}
+}
+
+// Add shows the icon, using the same embedded "APP" icon as the window.
+func (t *Tray) Add(hwnd uintptr) bool {
+	hIcon := loadAppIcon()
+	if hIcon == 0 {
+		return false
+	}
+	t.hwnd = hwnd
+	t.data = NOTIFYICONDATA{
+		HWnd:             hwnd,
+		UID:              1,
+		UFlags:           NIF_MESSAGE | NIF_ICON | NIF_TIP,
+		UCallbackMessage: WM_TRAYICON,
+		HIcon:            hIcon,
+	}
+	t.data.CbSize = uint32(unsafe.Sizeof(t.data))
+	tip, _ := syscall.UTF16FromString("PhileasGUI")
+	copy(t.data.SzTip[:len(t.data.SzTip)-1], tip)
+
+	ret, _, _ := procShellNotifyIcon.Call(NIM_ADD, uintptr(unsafe.Pointer(&t.data)))
+	return ret != 0
+}
+
+// Remove takes the icon out of the notification area.
+func (t *Tray) Remove() {
+	if t.hwnd == 0 {
+		return
+	}
+	_, _, _ = procShellNotifyIcon.Call(NIM_DELETE, uintptr(unsafe.Pointer(&t.data)))
+}
+
+// interceptClose reports whether closing the window should only hide it.
+func (t *Tray) interceptClose(hwnd uintptr) bool {
+	if !t.closeToTray || t.exiting {
+		return false
+	}
+	_, _, _ = procShowWindow.Call(hwnd, SW_HIDE)
+	return true
+}
+
+// handleMessage processes tray messages for the window and reports whether
+// msg was one.
+func (t *Tray) handleMessage(msg uint32, lParam uintptr) bool {
+	switch {
+	case msg == WM_TRAYICON:
+		switch lParam {
+		case WM_LBUTTONUP, WM_LBUTTONDBLCLK:
+			t.showWindow()
+		case WM_RBUTTONUP:
+			t.showMenu()
+		}
+		return true
+	case t.taskbarCreated != 0 && msg == t.taskbarCreated:
+		_, _, _ = procShellNotifyIcon.Call(NIM_ADD, uintptr(unsafe.Pointer(&t.data)))
+		return true
+	}
+	return false
+}
+
+func (t *Tray) showWindow() {
+	_, _, _ = procShowWindow.Call(t.hwnd, SW_RESTORE)
+	_, _, _ = procSetForegroundWindow.Call(t.hwnd)
+}
+
+func (t *Tray) showMenu() {
+	// Without a reachable server the menu still offers Show and Exit
+	paused, volume, statusErr := t.mgr.AudioStatus()
+
+	menu, _, _ := procCreatePopupMenu.Call()
+	defer func() { _, _, _ = procDestroyMenu.Call(menu) }()
+	volMenu, _, _ := procCreatePopupMenu.Call()
+
+	appendMenu(menu, MF_STRING, cmdShow, "Show PhileasGUI")
+	appendMenu(menu, MF_SEPARATOR, 0, "")
+	if statusErr == nil {
+		label := "Pause"
+		if paused {
+			label = "Resume"
+		}
+		appendMenu(menu, MF_STRING, cmdPauseResume, label)
+		appendMenu(menu, MF_STRING, cmdSkip, "Skip")
+		appendMenu(menu, MF_STRING, cmdReplay, "Replay")
+		for pct := 0; pct <= 100; pct += 25 {
+			flags := uintptr(MF_STRING)
+			if int(volume*100+0.5) == pct {
+				flags |= MF_CHECKED
+			}
+			appendMenu(volMenu, flags, uintptr(cmdVolume+pct/25), fmt.Sprintf("%d%%", pct))
+		}
+		// The menu owns the submenu from here and destroys it with itself
+		appendMenu(menu, MF_POPUP, volMenu, "Volume")
+		appendMenu(menu, MF_SEPARATOR, 0, "")
+	} else {
+		_, _, _ = procDestroyMenu.Call(volMenu)
+	}
+	appendMenu(menu, MF_STRING, cmdExit, "Exit")
+
+	var pt POINT
+	_, _, _ = procGetCursorPos.Call(uintptr(unsafe.Pointer(&pt)))
+	// Required for the menu to close when the user clicks elsewhere
+	_, _, _ = procSetForegroundWindow.Call(t.hwnd)
+	cmd, _, _ := procTrackPopupMenu.Call(menu, TPM_RETURNCMD|TPM_NONOTIFY|TPM_RIGHTBUTTON,
+		uintptr(pt.X), uintptr(pt.Y), 0, t.hwnd, 0)
+	_, _, _ = procPostMessage.Call(t.hwnd, WM_NULL, 0, 0)
+
+	t.run(cmd, paused)
+}
+
+func (t *Tray) run(cmd uintptr, paused bool) {
+	switch {
+	case cmd == cmdShow:
+		t.showWindow()
+	case cmd == cmdExit:
+		t.exiting = true
+		_, _, _ = procPostMessage.Call(t.hwnd, WM_CLOSE, 0, 0)
+	case cmd == cmdPauseResume:
+		action := "pause"
+		if paused {
+			action = "resume"
+		}
+		t.send(func() error { return t.mgr.AudioControl(action) })
+	case cmd == cmdSkip:
+		t.send(func() error { return t.mgr.AudioControl("skip") })
+	case cmd == cmdReplay:
+		t.send(func() error { return t.mgr.AudioControl("replay") })
+	case cmd >= cmdVolume && cmd <= cmdVolume+4:
+		vol := float64(cmd-cmdVolume) * 0.25
+		t.send(func() error { return t.mgr.SetVolume(vol) })
+	}
+}
+
+// send runs a server call off the UI thread.
+func (t *Tray) send(call func() error) {
+	go func() {
+		if err := call(); err != nil {
+			t.mgr.log(fmt.Sprintf("> Tray action failed: %v", err))
+		}
+	}()
+}
+
+func appendMenu(menu, flags, id uintptr, label string) {
+	var ptr *uint16
+	if label != "" {
+		ptr, _ = syscall.UTF16PtrFromString(label)
+	}
+	_, _, _ = procAppendMenu.Call(menu, flags, id, uintptr(unsafe.Pointer(ptr)))
+}
@@ -1,18 +1,49 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
 	"sync"
 	"time"
 
+	"phileasgo/pkg/store"
 	"phileasgo/pkg/wikidata"
 )
 
-// CacheHandler handles tile cache visualization requests.
+// maxPurgeRadius keeps a mistyped radius from wiping a whole region.
+const maxPurgeRadius = 200000.0
+
+const (
+	defaultTileListLimit = 100
+	maxTileListLimit     = 1000
+)
+
+// TilePurger deletes cached Wikidata tiles around a point.
+type TilePurger interface {
+	PurgeTiles(ctx context.Context, lat, lon, radiusKm float64) (int, error)
+}
+
+// POIPurger deletes stored and tracked POIs around a point.
+type POIPurger interface {
+	PurgeArea(ctx context.Context, lat, lon, radius float64) (int, error)
+}
+
+// TileLister pages through the cached tiles for inspection.
+type TileLister interface {
+	ListCachedTiles(ctx context.Context, q store.GeodataQuery) ([]wikidata.CachedTileInfo, int, error)
+}
+
+// CacheHandler handles tile cache visualization, inspection and purge requests.
 type CacheHandler struct {
 	service *wikidata.Service
+	tiles   TilePurger
+	lister  TileLister
+	pois    POIPurger
 
 	// API Cache (15s TTL)
 	mu         sync.RWMutex
@@ -21,33 +52,35 @@ type CacheHandler struct {
 }
 
 // NewCacheHandler creates a new CacheHandler.
-func NewCacheHandler(s *wikidata.Service) *CacheHandler {
+func NewCacheHandler(s *wikidata.Service, pois POIPurger) *CacheHandler {
 	return &CacheHandler{
 		service: s,
+		tiles:   s,
+		lister:  s,
+		pois:    pois,
 	}
 }
 
+// ServeHTTP handles GET /api/wikidata/cache. With limit, offset or sort it
+// pages through the cache for inspection (see serveListing); otherwise it
+// returns all tiles in the required bounding box for the map layer.
 func (h *CacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// Parse params
-	minLatStr := r.URL.Query().Get("min_lat")
-	maxLatStr := r.URL.Query().Get("max_lat")
-	minLonStr := r.URL.Query().Get("min_lon")
-	maxLonStr := r.URL.Query().Get("max_lon")
-
-	if minLatStr == "" || maxLatStr == "" || minLonStr == "" || maxLonStr == "" {
-		http.Error(w, "min_lat, max_lat, min_lon, max_lon are required", http.StatusBadRequest)
+	q := r.URL.Query()
+	if q.Has("limit") || q.Has("offset") || q.Has("sort") {
+		h.serveListing(w, r)
 		return
 	}
 
-	minLat, err1 := strconv.ParseFloat(minLatStr, 64)
-	maxLat, err2 := strconv.ParseFloat(maxLatStr, 64)
-	minLon, err3 := strconv.ParseFloat(minLonStr, 64)
-	maxLon, err4 := strconv.ParseFloat(maxLonStr, 64)
-
-	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
-		http.Error(w, "invalid bounds", http.StatusBadRequest)
+	bounds, err := parseBounds(q)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	if bounds == nil {
+		http.Error(w, "min_lat, max_lat, min_lon, max_lon are required", http.StatusBadRequest)
+		return
+	}
+	minLat, maxLat, minLon, maxLon := bounds.MinLat, bounds.MaxLat, bounds.MinLon, bounds.MaxLon
 
 	// Check API Cache
 	h.mu.RLock()
@@ -81,3 +114,140 @@ func (h *CacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	_, _ = w.Write(resp)
 }
+
+// serveListing pages through the cached tiles, newest first or nearest to
+// lat/lon first with sort=distance. The bounding box is optional here. The
+// total number of matching tiles is returned in X-Total-Count.
+func (h *CacheHandler) serveListing(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := store.GeodataQuery{Sort: store.GeodataSortRecent, Limit: defaultTileListLimit}
+
+	if s := q.Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxTileListLimit {
+			http.Error(w, "limit must be between 1 and 1000", http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
+	if s := q.Get("offset"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			http.Error(w, "offset must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+		query.Offset = n
+	}
+
+	var err error
+	if query.Bounds, err = parseBounds(q); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	switch q.Get("sort") {
+	case "", store.GeodataSortRecent:
+	case store.GeodataSortDistance:
+		lat, err1 := strconv.ParseFloat(q.Get("lat"), 64)
+		lon, err2 := strconv.ParseFloat(q.Get("lon"), 64)
+		if err1 != nil || err2 != nil {
+			http.Error(w, "sort=distance requires lat and lon", http.StatusBadRequest)
+			return
+		}
+		query.Sort, query.NearLat, query.NearLon = store.GeodataSortDistance, lat, lon
+	default:
+		http.Error(w, "sort must be recent or distance", http.StatusBadRequest)
+		return
+	}
+
+	tiles, total, err := h.lister.ListCachedTiles(r.Context(), query)
+	if err != nil {
+		slog.Error("Cache listing failed", "error", err)
+		http.Error(w, "failed to list tiles", http.StatusInternalServerError)
+		return
+	}
+	if tiles == nil {
+		tiles = []wikidata.CachedTileInfo{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if err := json.NewEncoder(w).Encode(tiles); err != nil {
+		slog.Error("Failed to encode cache listing", "error", err)
+	}
+}
+
+// parseBounds reads min_lat, max_lat, min_lon and max_lon. It returns nil
+// when none are given and an error when only some are, or any is invalid.
+func parseBounds(q url.Values) (*store.Bounds, error) {
+	keys := []string{"min_lat", "max_lat", "min_lon", "max_lon"}
+	vals := make([]float64, len(keys))
+	given := 0
+	for i, k := range keys {
+		s := q.Get(k)
+		if s == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, errors.New("invalid bounds")
+		}
+		vals[i] = v
+		given++
+	}
+	switch given {
+	case 0:
+		return nil, nil
+	case len(keys):
+		return &store.Bounds{MinLat: vals[0], MaxLat: vals[1], MinLon: vals[2], MaxLon: vals[3]}, nil
+	default:
+		return nil, errors.New("min_lat, max_lat, min_lon, max_lon must be given together")
+	}
+}
+
+// PurgeResponse reports what POST /api/cache/purge removed.
+type PurgeResponse struct {
+	TilesRemoved int `json:"tiles_removed"`
+	POIsRemoved  int `json:"pois_removed"`
+}
+
+// HandlePurge handles POST /api/cache/purge. It deletes the cached tiles and
+// POIs within radius meters of lat/lon so they are fetched again.
+func (h *CacheHandler) HandlePurge(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Lat    float64 `json:"lat"`
+		Lon    float64 `json:"lon"`
+		Radius float64 `json:"radius"` // Meters
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Radius <= 0 || req.Radius > maxPurgeRadius {
+		http.Error(w, "radius must be between 0 and 200000 meters", http.StatusBadRequest)
+		return
+	}
+
+	// POIs go first: once the tiles are evicted the scheduler may re-fetch
+	// them, and those POIs must not be deleted again.
+	var resp PurgeResponse
+	var err error
+	if resp.POIsRemoved, err = h.pois.PurgeArea(r.Context(), req.Lat, req.Lon, req.Radius); err != nil {
+		slog.Error("Cache purge: POI deletion failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	if resp.TilesRemoved, err = h.tiles.PurgeTiles(r.Context(), req.Lat, req.Lon, req.Radius/1000.0); err != nil {
+		slog.Error("Cache purge: tile deletion failed", "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Drop the visualization cache so the map shows the purge at once
+	h.mu.Lock()
+	h.cachedResp = nil
+	h.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
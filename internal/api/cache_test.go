@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"phileasgo/pkg/store"
+	"phileasgo/pkg/wikidata"
+)
+
+type mockPurger struct {
+	removed int
+	err     error
+	radius  float64
+	called  bool
+}
+
+func (m *mockPurger) PurgeTiles(ctx context.Context, lat, lon, radiusKm float64) (int, error) {
+	m.called, m.radius = true, radiusKm
+	return m.removed, m.err
+}
+
+func (m *mockPurger) PurgeArea(ctx context.Context, lat, lon, radius float64) (int, error) {
+	m.called, m.radius = true, radius
+	return m.removed, m.err
+}
+
+func TestCacheHandler_HandlePurge(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		poiErr     error
+		wantStatus int
+		wantTiles  bool
+	}{
+		{"Purges tiles and POIs", `{"lat":50,"lon":10,"radius":5000}`, nil, http.StatusOK, true},
+		{"Missing radius", `{"lat":50,"lon":10}`, nil, http.StatusBadRequest, false},
+		{"Radius too large", `{"lat":50,"lon":10,"radius":1000000}`, nil, http.StatusBadRequest, false},
+		{"Invalid body", `{`, nil, http.StatusBadRequest, false},
+		{"POI failure keeps the tiles", `{"lat":50,"lon":10,"radius":5000}`, errors.New("db"), http.StatusInternalServerError, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tiles := &mockPurger{removed: 3}
+			pois := &mockPurger{removed: 7, err: tt.poiErr}
+			h := &CacheHandler{tiles: tiles, pois: pois, cachedResp: []byte("stale")}
+
+			req := httptest.NewRequest("POST", "/api/cache/purge", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			h.HandlePurge(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tiles.called != tt.wantTiles {
+				t.Errorf("expected tile purge called=%v, got %v", tt.wantTiles, tiles.called)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp PurgeResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.TilesRemoved != 3 || resp.POIsRemoved != 7 {
+				t.Errorf("expected 3 tiles and 7 POIs, got %+v", resp)
+			}
+			if tiles.radius != 5 || pois.radius != 5000 {
+				t.Errorf("expected 5km for tiles and 5000m for POIs, got %v and %v", tiles.radius, pois.radius)
+			}
+			if h.cachedResp != nil {
+				t.Error("expected the tile visualization cache to be dropped")
+			}
+		})
+	}
+}
+
+type mockLister struct {
+	query store.GeodataQuery
+	tiles []wikidata.CachedTileInfo
+	total int
+}
+
+func (m *mockLister) ListCachedTiles(ctx context.Context, q store.GeodataQuery) ([]wikidata.CachedTileInfo, int, error) {
+	m.query = q
+	return m.tiles, m.total, nil
+}
+
+func TestCacheHandler_Listing(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		want       store.GeodataQuery
+	}{
+		{"Defaults", "limit=", http.StatusOK, store.GeodataQuery{Sort: store.GeodataSortRecent, Limit: 100}},
+		{"Page", "limit=20&offset=40", http.StatusOK, store.GeodataQuery{Sort: store.GeodataSortRecent, Limit: 20, Offset: 40}},
+		{"Bounds", "sort=recent&min_lat=1&max_lat=2&min_lon=3&max_lon=4", http.StatusOK,
+			store.GeodataQuery{Sort: store.GeodataSortRecent, Limit: 100, Bounds: &store.Bounds{MinLat: 1, MaxLat: 2, MinLon: 3, MaxLon: 4}}},
+		{"Distance", "sort=distance&lat=50&lon=10", http.StatusOK,
+			store.GeodataQuery{Sort: store.GeodataSortDistance, Limit: 100, NearLat: 50, NearLon: 10}},
+		{"Distance without point", "sort=distance", http.StatusBadRequest, store.GeodataQuery{}},
+		{"Unknown sort", "sort=size", http.StatusBadRequest, store.GeodataQuery{}},
+		{"Limit too large", "limit=5000", http.StatusBadRequest, store.GeodataQuery{}},
+		{"Negative offset", "offset=-1", http.StatusBadRequest, store.GeodataQuery{}},
+		{"Partial bounds", "limit=10&min_lat=1", http.StatusBadRequest, store.GeodataQuery{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lister := &mockLister{total: 250}
+			h := &CacheHandler{lister: lister}
+
+			req := httptest.NewRequest("GET", "/api/wikidata/cache?"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if !reflect.DeepEqual(lister.query, tt.want) {
+				t.Errorf("expected query %+v, got %+v", tt.want, lister.query)
+			}
+			if got := w.Header().Get("X-Total-Count"); got != "250" {
+				t.Errorf("expected X-Total-Count 250, got %q", got)
+			}
+			if body := strings.TrimSpace(w.Body.String()); body != "[]" {
+				t.Errorf("expected an empty array, got %s", body)
+			}
+		})
+	}
+}
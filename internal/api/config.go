@@ -19,6 +19,14 @@ type ConfigHandler struct {
 	cfgProv config.Provider
 	appCfg  *config.Config
 	catCfg  *config.CategoriesConfig
+
+	predWindow PredictionWindowApplier
+}
+
+// PredictionWindowApplier re-applies the sim lookahead; the narrator
+// implements it.
+type PredictionWindowApplier interface {
+	ApplyPredictionWindow()
 }
 
 // NewConfigHandler creates a new ConfigHandler.
@@ -31,6 +39,12 @@ func NewConfigHandler(st store.Store, cfg config.Provider, catCfg *config.Catego
 	}
 }
 
+// SetPredictionWindowApplier makes prediction_window changes take effect
+// immediately instead of at the next LLM latency sample.
+func (h *ConfigHandler) SetPredictionWindowApplier(a PredictionWindowApplier) {
+	h.predWindow = a
+}
+
 // ConfigResponse represents the config API response.
 type ConfigResponse struct {
 	SimSource                   string   `json:"sim_source"`
@@ -41,6 +55,7 @@ type ConfigResponse struct {
 	ShowVisibilityLayer         bool     `json:"show_visibility_layer"`
 	SettlementLabelLimit        int      `json:"settlement_label_limit"`
 	SettlementTier              int      `json:"settlement_tier"`
+	IconSet                     string   `json:"icon_set"`
 	MinPOIScore                 float64  `json:"min_poi_score"`
 	Volume                      float64  `json:"volume"`
 	FilterMode                  string   `json:"filter_mode"`
@@ -69,7 +84,11 @@ type ConfigResponse struct {
 	ActiveTargetLanguage        string   `json:"active_target_language"`
 	DeferralThreshold           float64  `json:"deferral_threshold"`
 	DeferralProximityBoostPower float64  `json:"deferral_proximity_boost_power"`
+	InterestKeywords            []string `json:"interest_keywords"`
+	InterestBoost               float64  `json:"interest_boost"`
+	PriorityQIDs                []string `json:"priority_qids"`
 	TwoPassScriptGeneration     bool     `json:"two_pass_script_generation"`
+	VehicleMode                 string   `json:"vehicle_mode"`
 	// Beacon
 	BeaconEnabled              bool     `json:"beacon_enabled"`
 	BeaconFormationEnabled     bool     `json:"beacon_formation_enabled"`
@@ -87,6 +106,17 @@ type ConfigResponse struct {
 	NarrationLengthShort       int      `json:"narration_length_short_words"`
 	NarrationLengthLong        int      `json:"narration_length_long_words"`
 	SettlementCategories       []string `json:"settlement_categories"`
+	// Display names by lowercase category key in the narration language
+	CategoryLabels map[string]string `json:"category_labels"`
+	// Tuning
+	PredictionWindow    int     `json:"prediction_window"` // Seconds
+	VarietyPenaltyFirst float64 `json:"variety_penalty_first"`
+	VarietyPenaltyLast  float64 `json:"variety_penalty_last"`
+	NoveltyBoost        float64 `json:"novelty_boost"`
+	GroupPenalty        float64 `json:"group_penalty"`
+	VisibilityWeight    float64 `json:"visibility_weight"`
+	RegionNoveltyWeight float64 `json:"region_novelty_weight"`
+	DeferralMultiplier  float64 `json:"deferral_multiplier"`
 	// Aircraft
 	AircraftIcon        string `json:"aircraft_icon"`
 	AircraftSize        int    `json:"aircraft_size"`
@@ -107,6 +137,7 @@ type ConfigRequest struct {
 	ShowVisibilityLayer         *bool    `json:"show_visibility_layer,omitempty"` // Pointer to detect false vs missing
 	SettlementLabelLimit        *int     `json:"settlement_label_limit,omitempty"`
 	SettlementTier              *int     `json:"settlement_tier,omitempty"`
+	IconSet                     string   `json:"icon_set,omitempty"`
 	MinPOIScore                 *float64 `json:"min_poi_score,omitempty"`
 	FilterMode                  string   `json:"filter_mode,omitempty"`
 	TargetPOICount              *int     `json:"target_poi_count,omitempty"`
@@ -129,7 +160,11 @@ type ConfigRequest struct {
 	ActiveTargetLanguage        *string  `json:"active_target_language,omitempty"`
 	DeferralThreshold           *float64 `json:"deferral_threshold,omitempty"`
 	DeferralProximityBoostPower *float64 `json:"deferral_proximity_boost_power,omitempty"`
+	InterestKeywords            []string `json:"interest_keywords,omitempty"`
+	InterestBoost               *float64 `json:"interest_boost,omitempty"`
+	PriorityQIDs                []string `json:"priority_qids,omitempty"`
 	TwoPassScriptGeneration     *bool    `json:"two_pass_script_generation,omitempty"`
+	VehicleMode                 string   `json:"vehicle_mode,omitempty"`
 	// Beacon
 	BeaconEnabled              *bool    `json:"beacon_enabled,omitempty"`
 	BeaconFormationEnabled     *bool    `json:"beacon_formation_enabled,omitempty"`
@@ -146,6 +181,15 @@ type ConfigRequest struct {
 	RepeatTTL                  *int     `json:"repeat_ttl,omitempty"`
 	NarrationLengthShort       *int     `json:"narration_length_short_words,omitempty"`
 	NarrationLengthLong        *int     `json:"narration_length_long_words,omitempty"`
+	// Tuning
+	PredictionWindow    *int     `json:"prediction_window,omitempty"` // Seconds
+	VarietyPenaltyFirst *float64 `json:"variety_penalty_first,omitempty"`
+	VarietyPenaltyLast  *float64 `json:"variety_penalty_last,omitempty"`
+	NoveltyBoost        *float64 `json:"novelty_boost,omitempty"`
+	GroupPenalty        *float64 `json:"group_penalty,omitempty"`
+	VisibilityWeight    *float64 `json:"visibility_weight,omitempty"`
+	RegionNoveltyWeight *float64 `json:"region_novelty_weight,omitempty"`
+	DeferralMultiplier  *float64 `json:"deferral_multiplier,omitempty"`
 	// Aircraft
 	AircraftIcon        *string `json:"aircraft_icon,omitempty"`
 	AircraftSize        *int    `json:"aircraft_size,omitempty"`
@@ -192,6 +236,7 @@ func (h *ConfigHandler) HandleGetConfig(w http.ResponseWriter, r *http.Request)
 }
 
 func (h *ConfigHandler) getConfigResponse(ctx context.Context) ConfigResponse {
+	tuning := h.cfgProv.ScorerTuning(ctx)
 	return ConfigResponse{
 		SimSource:                   h.cfgProv.SimProvider(ctx),
 		Units:                       h.cfgProv.Units(ctx),          // Prompt template units (imperial/hybrid/metric) - backend only
@@ -201,6 +246,7 @@ func (h *ConfigHandler) getConfigResponse(ctx context.Context) ConfigResponse {
 		ShowVisibilityLayer:         h.cfgProv.ShowVisibilityLayer(ctx),
 		SettlementLabelLimit:        h.cfgProv.SettlementLabelLimit(ctx),
 		SettlementTier:              h.cfgProv.SettlementTier(ctx),
+		IconSet:                     h.cfgProv.IconSet(ctx),
 		MinPOIScore:                 h.cfgProv.MinScoreThreshold(ctx),
 		Volume:                      h.cfgProv.Volume(ctx),
 		FilterMode:                  h.cfgProv.FilterMode(ctx),
@@ -229,7 +275,11 @@ func (h *ConfigHandler) getConfigResponse(ctx context.Context) ConfigResponse {
 		ActiveTargetLanguage:        h.cfgProv.ActiveTargetLanguage(ctx),
 		DeferralThreshold:           h.cfgProv.DeferralThreshold(ctx),
 		DeferralProximityBoostPower: h.cfgProv.DeferralProximityBoostPower(ctx),
+		InterestKeywords:            h.cfgProv.InterestKeywords(ctx),
+		InterestBoost:               h.cfgProv.InterestBoost(ctx),
+		PriorityQIDs:                h.cfgProv.PriorityQIDs(ctx),
 		TwoPassScriptGeneration:     h.cfgProv.TwoPassScriptGeneration(ctx),
+		VehicleMode:                 h.cfgProv.VehicleMode(ctx),
 		BeaconEnabled:               h.cfgProv.BeaconEnabled(ctx),
 		BeaconFormationEnabled:      h.cfgProv.BeaconFormationEnabled(ctx),
 		BeaconFormationDistance:     float64(h.cfgProv.BeaconFormationDistance(ctx)),
@@ -246,6 +296,16 @@ func (h *ConfigHandler) getConfigResponse(ctx context.Context) ConfigResponse {
 		NarrationLengthShort:        h.cfgProv.NarrationLengthShort(ctx),
 		NarrationLengthLong:         h.cfgProv.NarrationLengthLong(ctx),
 		SettlementCategories:        h.settlementCategories(),
+		CategoryLabels:              h.categoryLabels(h.cfgProv.ActiveTargetLanguage(ctx)),
+		// Tuning
+		PredictionWindow:    int(h.cfgProv.PredictionWindow(ctx).Seconds()),
+		VarietyPenaltyFirst: tuning.VarietyPenaltyFirst,
+		VarietyPenaltyLast:  tuning.VarietyPenaltyLast,
+		NoveltyBoost:        tuning.NoveltyBoost,
+		GroupPenalty:        tuning.GroupPenalty,
+		VisibilityWeight:    tuning.VisibilityWeight,
+		RegionNoveltyWeight: tuning.RegionNoveltyWeight,
+		DeferralMultiplier:  tuning.DeferralMultiplier,
 		// Aircraft
 		AircraftIcon:        h.cfgProv.AircraftIcon(ctx),
 		AircraftSize:        h.cfgProv.AircraftSize(ctx),
@@ -275,6 +335,12 @@ func (h *ConfigHandler) HandleSetConfig(w http.ResponseWriter, r *http.Request)
 
 	ctx := context.Background()
 
+	// Tuning is rejected as a whole before anything else is saved
+	if err := h.applyTuningUpdates(ctx, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Core updates (return error to client if they fail)
 	if err := h.applyCoreUpdates(ctx, &req); err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
@@ -314,6 +380,55 @@ func (h *ConfigHandler) applyCoreUpdates(ctx context.Context, req *ConfigRequest
 		h.updateFilterMode(ctx, req.FilterMode)
 	}
 
+	if req.VehicleMode != "" {
+		if err := h.updateVehicleMode(ctx, req.VehicleMode); err != nil {
+			slog.Error("Failed to save vehicle_mode", "error", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tuningRange bounds a runtime tuning knob. Values outside are rejected
+// rather than clamped, so a typo can't quietly skew scoring.
+type tuningRange struct {
+	name     string
+	key      string
+	val      *float64
+	min, max float64
+}
+
+func (h *ConfigHandler) applyTuningUpdates(ctx context.Context, req *ConfigRequest) error {
+	if req.PredictionWindow != nil && (*req.PredictionWindow < 10 || *req.PredictionWindow > 600) {
+		return fmt.Errorf("prediction_window must be between 10 and 600 seconds, got %d", *req.PredictionWindow)
+	}
+	ranges := []tuningRange{
+		{"variety_penalty_first", config.KeyVarietyPenaltyFirst, req.VarietyPenaltyFirst, 0, 1},
+		{"variety_penalty_last", config.KeyVarietyPenaltyLast, req.VarietyPenaltyLast, 0, 1},
+		{"novelty_boost", config.KeyNoveltyBoost, req.NoveltyBoost, 1, 5},
+		{"group_penalty", config.KeyGroupPenalty, req.GroupPenalty, 0, 1},
+		{"visibility_weight", config.KeyVisibilityWeight, req.VisibilityWeight, 0, 1},
+		{"region_novelty_weight", config.KeyRegionNoveltyWeight, req.RegionNoveltyWeight, 0, 1},
+		{"deferral_multiplier", config.KeyDeferralMultiplier, req.DeferralMultiplier, 0, 1},
+	}
+	for _, r := range ranges {
+		if r.val != nil && (*r.val < r.min || *r.val > r.max) {
+			return fmt.Errorf("%s must be between %g and %g, got %g", r.name, r.min, r.max, *r.val)
+		}
+	}
+
+	if req.PredictionWindow != nil {
+		h.updateIntState(ctx, config.KeyPredictionWindow, *req.PredictionWindow)
+		if h.predWindow != nil {
+			h.predWindow.ApplyPredictionWindow()
+		}
+	}
+	for _, r := range ranges {
+		if r.val != nil {
+			h.updateFloatState(ctx, r.key, *r.val)
+		}
+	}
 	return nil
 }
 
@@ -331,6 +446,10 @@ func (h *ConfigHandler) applyUIUpdates(ctx context.Context, req *ConfigRequest)
 	if req.SettlementTier != nil {
 		h.updateIntState(ctx, config.KeySettlementTier, *req.SettlementTier)
 	}
+	if req.IconSet != "" && h.hasIconSet(req.IconSet) {
+		_ = h.store.SetState(ctx, config.KeyIconSet, req.IconSet)
+		slog.Debug("Config updated", "icon_set", req.IconSet)
+	}
 
 	if req.RangeRingUnits != "" && (req.RangeRingUnits == "km" || req.RangeRingUnits == "nm") {
 		_ = h.store.SetState(ctx, config.KeyRangeRingUnits, req.RangeRingUnits)
@@ -360,6 +479,21 @@ func (h *ConfigHandler) applyThresholdUpdates(ctx context.Context, req *ConfigRe
 	if req.DeferralProximityBoostPower != nil {
 		h.updateFloatState(ctx, config.KeyDeferralProximityBoostPower, *req.DeferralProximityBoostPower)
 	}
+	if req.InterestKeywords != nil {
+		if jsonBytes, err := json.Marshal(req.InterestKeywords); err == nil {
+			_ = h.store.SetState(ctx, config.KeyInterestKeywords, string(jsonBytes))
+			slog.Debug("Config updated", config.KeyInterestKeywords, string(jsonBytes))
+		}
+	}
+	if req.InterestBoost != nil {
+		h.updateFloatState(ctx, config.KeyInterestBoost, *req.InterestBoost)
+	}
+	if req.PriorityQIDs != nil {
+		if jsonBytes, err := json.Marshal(req.PriorityQIDs); err == nil {
+			_ = h.store.SetState(ctx, config.KeyPriorityQIDs, string(jsonBytes))
+			slog.Debug("Config updated", config.KeyPriorityQIDs, string(jsonBytes))
+		}
+	}
 	if req.TwoPassScriptGeneration != nil {
 		h.updateBoolState(ctx, config.KeyTwoPassScriptGeneration, *req.TwoPassScriptGeneration)
 	}
@@ -539,6 +673,17 @@ func (h *ConfigHandler) updateUnits(ctx context.Context, val string) error {
 	return nil
 }
 
+func (h *ConfigHandler) updateVehicleMode(ctx context.Context, val string) error {
+	if val != config.VehicleModeAircraft && val != config.VehicleModeGround && val != config.VehicleModeMarine {
+		return fmt.Errorf("invalid vehicle_mode %q", val)
+	}
+	if err := h.store.SetState(ctx, config.KeyVehicleMode, val); err != nil {
+		return err
+	}
+	slog.Debug("Config updated", "vehicle_mode", val)
+	return nil
+}
+
 func (h *ConfigHandler) updateBoolState(ctx context.Context, key string, val bool) {
 	strVal := "false"
 	if val {
@@ -597,9 +742,36 @@ func (h *ConfigHandler) settlementCategories() []string {
 	return nil
 }
 
+// categoryLabels lists only the categories localized for lang; the UI shows
+// the key for the rest.
+func (h *ConfigHandler) categoryLabels(lang string) map[string]string {
+	labels := make(map[string]string)
+	if h.catCfg == nil {
+		return labels
+	}
+	for key := range h.catCfg.Categories {
+		if label := h.catCfg.Label(key, lang); label != key {
+			labels[key] = label
+		}
+	}
+	return labels
+}
+
 func (h *ConfigHandler) getPrimaryLLMProvider() string {
 	if len(h.appCfg.LLM.Fallback) > 0 {
 		return h.appCfg.LLM.Fallback[0]
 	}
 	return "none"
 }
+
+// hasIconSet reports whether set is the default or defined in categories.yaml.
+func (h *ConfigHandler) hasIconSet(set string) bool {
+	if set == config.DefaultIconSet {
+		return true
+	}
+	if h.catCfg == nil {
+		return false
+	}
+	_, ok := h.catCfg.IconSets[set]
+	return ok
+}
@@ -4,12 +4,17 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/scorer"
+	"phileasgo/pkg/sim"
 	"phileasgo/pkg/store"
+	"phileasgo/pkg/visibility"
 )
 
 type mockStore struct {
@@ -189,6 +194,12 @@ func TestHandleSetConfig(t *testing.T) {
 			wantKey: "range_ring_units",
 			wantVal: "nm",
 		},
+		{
+			name:    "Update Icon Set",
+			req:     ConfigRequest{IconSet: "default"},
+			wantKey: "icon_set",
+			wantVal: "default",
+		},
 		{
 			name:    "Update Active Target Language",
 			req:     ConfigRequest{ActiveTargetLanguage: ptrString("de-DE")},
@@ -255,6 +266,18 @@ func TestHandleSetConfig(t *testing.T) {
 			wantKey: "narrator.pause_between_narrations",
 			wantVal: "45",
 		},
+		{
+			name:    "Update Prediction Window",
+			req:     ConfigRequest{PredictionWindow: ptrInt(90)},
+			wantKey: "narrator.prediction_window",
+			wantVal: "90",
+		},
+		{
+			name:    "Update Novelty Boost",
+			req:     ConfigRequest{NoveltyBoost: ptrFloat(1.5)},
+			wantKey: "scorer.novelty_boost",
+			wantVal: "1.50",
+		},
 	}
 
 	for _, tt := range tests {
@@ -306,6 +329,44 @@ func TestHandleSetConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("Out-of-range tuning", func(t *testing.T) {
+		for _, req := range []ConfigRequest{
+			{PredictionWindow: ptrInt(5)},
+			{NoveltyBoost: ptrFloat(0.5)},
+			{VisibilityWeight: ptrFloat(1.5), SimSource: "mock"},
+		} {
+			delete(st.state, "scorer.visibility_weight")
+			body, _ := json.Marshal(req)
+			w := httptest.NewRecorder()
+			h.HandleConfig(w, httptest.NewRequest("POST", "/api/config", bytes.NewBuffer(body)))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("%s: expected 400 Bad Request, got %d", body, w.Code)
+			}
+			if _, ok := st.state["scorer.visibility_weight"]; ok {
+				t.Errorf("%s: expected nothing saved", body)
+			}
+		}
+	})
+
+	t.Run("Prediction window applies immediately", func(t *testing.T) {
+		applier := &countingApplier{}
+		h.SetPredictionWindowApplier(applier)
+		defer h.SetPredictionWindowApplier(nil)
+
+		body, _ := json.Marshal(ConfigRequest{PredictionWindow: ptrInt(120)})
+		w := httptest.NewRecorder()
+		h.HandleConfig(w, httptest.NewRequest("POST", "/api/config", bytes.NewBuffer(body)))
+		if w.Code != http.StatusOK || applier.calls != 1 {
+			t.Errorf("expected one apply after a window change, got code %d, calls %d", w.Code, applier.calls)
+		}
+
+		body, _ = json.Marshal(ConfigRequest{NoveltyBoost: ptrFloat(1.2)})
+		h.HandleConfig(httptest.NewRecorder(), httptest.NewRequest("POST", "/api/config", bytes.NewBuffer(body)))
+		if applier.calls != 1 {
+			t.Errorf("unrelated update re-applied the window (calls %d)", applier.calls)
+		}
+	})
+
 	t.Run("Invalid Units", func(t *testing.T) {
 		body, _ := json.Marshal(ConfigRequest{Units: "km"}) // km is now invalid for Units (must be imperial/hybrid/metric)
 		req := httptest.NewRequest("POST", "/api/config", bytes.NewBuffer(body))
@@ -316,3 +377,43 @@ func TestHandleSetConfig(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleSetConfig_ScorerTuning(t *testing.T) {
+	st := &mockStore{state: make(map[string]string)}
+	prov := config.NewProvider(&config.Config{Scorer: config.ScorerConfig{NoveltyBoost: 1.3}}, st)
+	h := NewConfigHandler(st, prov, nil)
+
+	visMgr := visibility.NewManagerForTest([]visibility.AltitudeRow{
+		{AltAGL: 0, Distances: map[visibility.SizeType]float64{visibility.SizeM: 5.0, visibility.SizeXL: 15.0}},
+	})
+	s := scorer.NewScorer(&config.ScorerConfig{NoveltyBoost: 1.3}, &config.CategoriesConfig{}, visibility.NewCalculator(visMgr, nil), nil, nil, false)
+	s.SetTuning(prov)
+
+	score := func() float64 {
+		p := &model.POI{WikidataID: "Q1", Lat: 0, Lon: 0}
+		sess := s.NewSession(&scorer.ScoringInput{
+			Telemetry: sim.Telemetry{Latitude: -0.04, AltitudeMSL: 1000, AltitudeAGL: 1000},
+		})
+		sess.Calculate(p)
+		return p.Score
+	}
+
+	if got := score(); math.Abs(got-1.3) > 0.01 {
+		t.Fatalf("expected the configured novelty boost 1.3, got %.2f", got)
+	}
+
+	body, _ := json.Marshal(map[string]any{"novelty_boost": 2.0})
+	w := httptest.NewRecorder()
+	h.HandleConfig(w, httptest.NewRequest("POST", "/api/config", bytes.NewBuffer(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200 OK, got %d: %s", w.Code, w.Body.String())
+	}
+
+	if got := score(); math.Abs(got-2.0) > 0.01 {
+		t.Errorf("expected the tuned novelty boost 2.0 on the next pass, got %.2f", got)
+	}
+}
+
+type countingApplier struct{ calls int }
+
+func (a *countingApplier) ApplyPredictionWindow() { a.calls++ }
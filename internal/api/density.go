@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+
+	"phileasgo/pkg/wikidata"
+)
+
+// DensityProvider is implemented by coverage providers that can count POIs per tile.
+type DensityProvider interface {
+	GetDensityCells(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]wikidata.DensityCell, error)
+}
+
+// HandleDensity handles GET /api/map/density.
+// It returns a GeoJSON FeatureCollection of hex cells weighted by POI count.
+func (h *VisibilityHandler) HandleDensity(w http.ResponseWriter, r *http.Request) {
+	dp, ok := h.coverage.(DensityProvider)
+	if !ok {
+		http.Error(w, "Density provider not configured", http.StatusNotImplemented)
+		return
+	}
+
+	q := r.URL.Query()
+	if q.Get("min_lat") == "" || q.Get("max_lat") == "" || q.Get("min_lon") == "" || q.Get("max_lon") == "" {
+		http.Error(w, "min_lat, max_lat, min_lon, max_lon are required", http.StatusBadRequest)
+		return
+	}
+	minLat, err1 := strconv.ParseFloat(q.Get("min_lat"), 64)
+	maxLat, err2 := strconv.ParseFloat(q.Get("max_lat"), 64)
+	minLon, err3 := strconv.ParseFloat(q.Get("min_lon"), 64)
+	maxLon, err4 := strconv.ParseFloat(q.Get("max_lon"), 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		http.Error(w, "invalid bounds", http.StatusBadRequest)
+		return
+	}
+
+	cells, err := dp.GetDensityCells(r.Context(), minLat, maxLat, minLon, maxLon)
+	if err != nil {
+		http.Error(w, "Failed to get density", http.StatusInternalServerError)
+		return
+	}
+
+	fc := geojson.NewFeatureCollection()
+	for _, c := range cells {
+		var geom orb.Geometry = orb.Point{c.Lon, c.Lat}
+		if len(c.Corners) > 0 {
+			ring := make(orb.Ring, 0, len(c.Corners)+1)
+			for _, p := range c.Corners {
+				ring = append(ring, orb.Point{p.Lon, p.Lat})
+			}
+			ring = append(ring, ring[0]) // GeoJSON rings are closed
+			geom = orb.Polygon{ring}
+		}
+		f := geojson.NewFeature(geom)
+		f.Properties["weight"] = c.Weight
+		f.Properties["index"] = c.Index
+		fc.Append(f)
+	}
+
+	data, err := fc.MarshalJSON()
+	if err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/geo+json")
+	_, _ = w.Write(data)
+}
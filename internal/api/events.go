@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+const (
+	// eventBufferSize is how many events a slow client may lag behind before
+	// further events are dropped for it.
+	eventBufferSize = 32
+	// eventKeepAlive keeps proxies from closing idle streams and lets us notice
+	// disconnected clients even when nothing is published.
+	eventKeepAlive = 15 * time.Second
+)
+
+// Event is a single Server-Sent Event.
+type Event struct {
+	Name string
+	Data any
+}
+
+// NarrationEvent is the payload of "narration" events.
+type NarrationEvent struct {
+	State string `json:"state"` // start, stop, skip
+	Title string `json:"title,omitempty"`
+	Type  string `json:"type,omitempty"`
+	QID   string `json:"qid,omitempty"`
+}
+
+// subscribe registers a new event listener. The returned cancel function must
+// be called once the listener is gone.
+func (h *TelemetryHandler) subscribe() (events <-chan Event, cancel func()) {
+	ch := make(chan Event, eventBufferSize)
+
+	h.subsMu.Lock()
+	if h.subs == nil {
+		h.subs = make(map[chan Event]struct{})
+	}
+	h.subs[ch] = struct{}{}
+	h.subsMu.Unlock()
+
+	return ch, func() {
+		h.subsMu.Lock()
+		delete(h.subs, ch)
+		h.subsMu.Unlock()
+	}
+}
+
+// publish sends an event to all listeners without blocking the publisher;
+// listeners that cannot keep up miss the event.
+func (h *TelemetryHandler) publish(e Event) {
+	h.subsMu.RLock()
+	defer h.subsMu.RUnlock()
+	for ch := range h.subs {
+		select {
+		case ch <- e:
+		default:
+		}
+	}
+}
+
+// PublishNarration pushes a narration state change to event stream clients.
+func (h *TelemetryHandler) PublishNarration(state, title, narrativeType, qid string) {
+	h.publish(Event{Name: "narration", Data: NarrationEvent{
+		State: state,
+		Title: title,
+		Type:  narrativeType,
+		QID:   qid,
+	}})
+}
+
+// HandleEvents handles GET /api/events as a Server-Sent Events stream of
+// telemetry updates and narration state changes.
+func (h *TelemetryHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
+	rc := http.NewResponseController(w)
+	// The server's write timeout is meant for regular requests, not streams
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.Debug("Events: Could not clear write deadline", "error", err)
+	}
+
+	events, cancel := h.subscribe()
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	// Start with the current state so clients need not wait for the next tick
+	if err := writeEvent(w, Event{Name: "telemetry", Data: h.snapshot()}); err != nil {
+		return
+	}
+	if err := rc.Flush(); err != nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(eventKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-events:
+			if err := writeEvent(w, e); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, e Event) error {
+	data, err := json.Marshal(e.Data)
+	if err != nil {
+		slog.Error("Events: Failed to encode event", "event", e.Name, "error", err)
+		return nil
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Name, data)
+	return err
+}
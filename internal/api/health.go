@@ -0,0 +1,87 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"phileasgo/pkg/probe"
+)
+
+// HealthMonitor runs the component probes, caching their results briefly.
+type HealthMonitor interface {
+	Results(ctx context.Context) ([]probe.Result, time.Time)
+}
+
+// HealthHandler serves /health. The plain endpoint stays a cheap liveness
+// check; ?verbose=true reports the readiness of each component.
+type HealthHandler struct {
+	monitor HealthMonitor
+}
+
+// NewHealthHandler creates a new HealthHandler. monitor may be nil, in which
+// case the verbose report lists no components.
+func NewHealthHandler(monitor HealthMonitor) *HealthHandler {
+	return &HealthHandler{monitor: monitor}
+}
+
+// ComponentHealth is the state of one component in the verbose report.
+type ComponentHealth struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// HealthReport is the response of GET /health?verbose=true.
+type HealthReport struct {
+	Status     string            `json:"status"`
+	CheckedAt  time.Time         `json:"checked_at"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// HandleHealth handles GET /health.
+func (h *HealthHandler) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("verbose") != "true" {
+		handleHealth(w, r)
+		return
+	}
+
+	report := h.report(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	// Only a critical failure is an outage; uptime monitors key off the code
+	if report.Status == probe.StatusDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		slog.Error("Failed to write health response", "error", err)
+	}
+}
+
+func (h *HealthHandler) report(ctx context.Context) HealthReport {
+	report := HealthReport{Status: probe.StatusOK, Components: []ComponentHealth{}}
+	if h.monitor == nil {
+		report.CheckedAt = time.Now()
+		return report
+	}
+
+	results, checked := h.monitor.Results(ctx)
+	report.Status = probe.Summarize(results)
+	report.CheckedAt = checked
+	for _, res := range results {
+		c := ComponentHealth{
+			Name:       res.Probe.Name,
+			Status:     probe.ComponentStatus(res),
+			Critical:   res.Probe.Critical,
+			DurationMs: res.Duration.Milliseconds(),
+		}
+		if res.Error != nil {
+			c.Error = res.Error.Error()
+		}
+		report.Components = append(report.Components, c)
+	}
+	return report
+}
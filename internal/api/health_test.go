@@ -0,0 +1,94 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/probe"
+)
+
+func TestHealthHandler_HandleHealth(t *testing.T) {
+	pass := func(context.Context) error { return nil }
+	fail := func(context.Context) error { return errors.New("unreachable") }
+
+	tests := []struct {
+		name       string
+		query      string
+		probes     []probe.Probe
+		wantStatus int
+		wantHealth string
+		wantComps  map[string]string
+	}{
+		{
+			name:       "Plain liveness ignores probes",
+			probes:     []probe.Probe{{Name: "LLM", Check: fail, Critical: true}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "All components up",
+			query:      "?verbose=true",
+			probes:     []probe.Probe{{Name: "LLM", Check: pass, Critical: true}, {Name: "TTS", Check: pass}},
+			wantStatus: http.StatusOK,
+			wantHealth: probe.StatusOK,
+			wantComps:  map[string]string{"LLM": probe.StatusOK, "TTS": probe.StatusOK},
+		},
+		{
+			name:       "Non-critical failure is degraded",
+			query:      "?verbose=true",
+			probes:     []probe.Probe{{Name: "LLM", Check: pass, Critical: true}, {Name: "Simulator", Check: fail}},
+			wantStatus: http.StatusOK,
+			wantHealth: probe.StatusDegraded,
+			wantComps:  map[string]string{"LLM": probe.StatusOK, "Simulator": probe.StatusDegraded},
+		},
+		{
+			name:       "Critical failure is down",
+			query:      "?verbose=true",
+			probes:     []probe.Probe{{Name: "Database", Check: fail, Critical: true}},
+			wantStatus: http.StatusServiceUnavailable,
+			wantHealth: probe.StatusDown,
+			wantComps:  map[string]string{"Database": probe.StatusDown},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHealthHandler(probe.NewMonitor(tt.probes, time.Minute))
+			rec := httptest.NewRecorder()
+			h.HandleHealth(rec, httptest.NewRequest("GET", "/health"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.query == "" {
+				if rec.Body.String() != "OK" {
+					t.Errorf("body = %q, want OK", rec.Body.String())
+				}
+				return
+			}
+
+			var report HealthReport
+			if err := json.NewDecoder(rec.Body).Decode(&report); err != nil {
+				t.Fatalf("failed to decode report: %v", err)
+			}
+			if report.Status != tt.wantHealth {
+				t.Errorf("overall status = %q, want %q", report.Status, tt.wantHealth)
+			}
+			if len(report.Components) != len(tt.wantComps) {
+				t.Fatalf("got %d components, want %d", len(report.Components), len(tt.wantComps))
+			}
+			for _, c := range report.Components {
+				if c.Status != tt.wantComps[c.Name] {
+					t.Errorf("%s status = %q, want %q", c.Name, c.Status, tt.wantComps[c.Name])
+				}
+				if c.Status != probe.StatusOK && c.Error == "" {
+					t.Errorf("%s: expected an error message", c.Name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"phileasgo/pkg/tracker"
+)
+
+// NarrationStats is the narrator state exported as metrics.
+type NarrationStats interface {
+	NarratedCount() int
+	Stats() map[string]any
+}
+
+// MetricsHandler serves the tracker and narrator counters in the Prometheus
+// text exposition format. It is scrape-only; nothing is pushed.
+type MetricsHandler struct {
+	tracker  *tracker.Tracker
+	narrator NarrationStats
+}
+
+// NewMetricsHandler creates a new MetricsHandler. narrator may be nil.
+func NewMetricsHandler(t *tracker.Tracker, narrator NarrationStats) *MetricsHandler {
+	return &MetricsHandler{
+		tracker:  t,
+		narrator: narrator,
+	}
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// metricWriter writes metric families in the text exposition format.
+type metricWriter struct {
+	w io.Writer
+}
+
+func (m metricWriter) family(name, typ, help string) {
+	fmt.Fprintf(m.w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one sample. labels alternate between names and values.
+func (m metricWriter) sample(name string, value float64, labels ...string) {
+	var sb strings.Builder
+	sb.WriteString(name)
+	if len(labels) > 0 {
+		sb.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			fmt.Fprintf(&sb, `%s="%s"`, labels[i], labelEscaper.Replace(labels[i+1]))
+		}
+		sb.WriteByte('}')
+	}
+	fmt.Fprintf(m.w, "%s %s\n", sb.String(), strconv.FormatFloat(value, 'g', -1, 64))
+}
+
+func (h *MetricsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	m := metricWriter{w: w}
+
+	if h.tracker != nil {
+		h.writeProviders(m)
+		h.writeLatency(m)
+		h.writeEvents(m)
+	}
+	if h.narrator != nil {
+		h.writeNarrator(m)
+	}
+}
+
+func (h *MetricsHandler) writeProviders(m metricWriter) {
+	snapshot := h.tracker.Snapshot()
+	names := sortedKeys(snapshot)
+
+	m.family("phileas_provider_requests_total", "counter", "API requests per external service.")
+	for _, p := range names {
+		s := snapshot[p]
+		m.sample("phileas_provider_requests_total", float64(s.APISuccess), "provider", p, "result", "success")
+		m.sample("phileas_provider_requests_total", float64(s.APIFailures), "provider", p, "result", "failure")
+		m.sample("phileas_provider_requests_total", float64(s.APIZeroResult), "provider", p, "result", "zero")
+	}
+
+	m.family("phileas_provider_cache_lookups_total", "counter", "Response cache lookups per external service.")
+	for _, p := range names {
+		s := snapshot[p]
+		m.sample("phileas_provider_cache_lookups_total", float64(s.CacheHits), "provider", p, "result", "hit")
+		m.sample("phileas_provider_cache_lookups_total", float64(s.CacheMisses), "provider", p, "result", "miss")
+	}
+
+	m.family("phileas_llm_tokens_total", "counter", "LLM tokens reported by the provider.")
+	for _, p := range names {
+		s := snapshot[p]
+		if s.PromptTokens == 0 && s.CompletionTokens == 0 {
+			continue
+		}
+		m.sample("phileas_llm_tokens_total", float64(s.PromptTokens), "provider", p, "kind", "prompt")
+		m.sample("phileas_llm_tokens_total", float64(s.CompletionTokens), "provider", p, "kind", "completion")
+	}
+
+	m.family("phileas_tts_characters_total", "counter", "Characters sent for speech synthesis.")
+	for _, p := range names {
+		if c := snapshot[p].Characters; c > 0 {
+			m.sample("phileas_tts_characters_total", float64(c), "provider", p)
+		}
+	}
+}
+
+func (h *MetricsHandler) writeLatency(m metricWriter) {
+	hists := h.tracker.LatencySnapshot()
+	const name = "phileas_llm_request_duration_seconds"
+	m.family(name, "histogram", "LLM call latency per provider, failed calls included.")
+	for _, p := range sortedKeys(hists) {
+		hist := hists[p]
+		var cumulative int64
+		for i, le := range tracker.LatencyBuckets {
+			cumulative += hist.Counts[i]
+			m.sample(name+"_bucket", float64(cumulative), "provider", p, "le", strconv.FormatFloat(le, 'g', -1, 64))
+		}
+		m.sample(name+"_bucket", float64(hist.Count), "provider", p, "le", "+Inf")
+		m.sample(name+"_sum", hist.Sum, "provider", p)
+		m.sample(name+"_count", float64(hist.Count), "provider", p)
+	}
+}
+
+func (h *MetricsHandler) writeEvents(m metricWriter) {
+	events := h.tracker.Events()
+
+	m.family("phileas_sparql_fetches_total", "counter", "Wikidata SPARQL tile fetches.")
+	m.sample("phileas_sparql_fetches_total", float64(events[tracker.EventTilesCached]), "result", "success")
+	m.sample("phileas_sparql_fetches_total", float64(events[tracker.EventSPARQLFailures]), "result", "failure")
+
+	m.family("phileas_tiles_cached_total", "counter", "Wikidata tiles fetched and written to the cache.")
+	m.sample("phileas_tiles_cached_total", float64(events[tracker.EventTilesCached]))
+
+	m.family("phileas_tile_cache_hits_total", "counter", "Wikidata tiles served from the cache.")
+	m.sample("phileas_tile_cache_hits_total", float64(events[tracker.EventTileCacheHits]))
+}
+
+func (h *MetricsHandler) writeNarrator(m metricWriter) {
+	// A gauge, not a counter: the count starts over with every session
+	m.family("phileas_session_narrations_played", "gauge", "POI narrations played in the current session.")
+	m.sample("phileas_session_narrations_played", float64(h.narrator.NarratedCount()))
+
+	if n, ok := h.narrator.Stats()["playback_queue_len"].(int); ok {
+		m.family("phileas_narration_queue_length", "gauge", "Narrations waiting for playback.")
+		m.sample("phileas_narration_queue_length", float64(n))
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
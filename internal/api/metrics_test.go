@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/tracker"
+)
+
+type metricsNarrator struct{}
+
+func (metricsNarrator) NarratedCount() int { return 7 }
+func (metricsNarrator) Stats() map[string]any {
+	return map[string]any{"playback_queue_len": 2}
+}
+
+func TestMetricsHandler(t *testing.T) {
+	tr := tracker.New()
+	tr.TrackAPISuccess("wikidata")
+	tr.TrackCacheHit("wikipedia")
+	tr.TrackTokens("gemini", 100, 20)
+	tr.TrackLatency("gemini", 1500*time.Millisecond)
+	tr.TrackCharacters("edge-tts", 321)
+	tr.TrackEvent(tracker.EventTilesCached)
+	tr.TrackEvent(tracker.EventTileCacheHits)
+	tr.TrackEvent(tracker.EventTileCacheHits)
+
+	rec := httptest.NewRecorder()
+	NewMetricsHandler(tr, metricsNarrator{}).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/plain") {
+		t.Fatalf("unexpected response: %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE phileas_provider_requests_total counter",
+		`phileas_provider_requests_total{provider="wikidata",result="success"} 1`,
+		`phileas_provider_cache_lookups_total{provider="wikipedia",result="hit"} 1`,
+		`phileas_llm_tokens_total{provider="gemini",kind="prompt"} 100`,
+		`phileas_llm_request_duration_seconds_bucket{provider="gemini",le="1"} 0`,
+		`phileas_llm_request_duration_seconds_bucket{provider="gemini",le="2"} 1`,
+		`phileas_llm_request_duration_seconds_bucket{provider="gemini",le="+Inf"} 1`,
+		`phileas_llm_request_duration_seconds_sum{provider="gemini"} 1.5`,
+		`phileas_tts_characters_total{provider="edge-tts"} 321`,
+		`phileas_sparql_fetches_total{result="success"} 1`,
+		"phileas_tiles_cached_total 1",
+		"phileas_tile_cache_hits_total 2",
+		"# TYPE phileas_session_narrations_played gauge",
+		"phileas_session_narrations_played 7",
+		"phileas_narration_queue_length 2",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, `phileas_tts_characters_total{provider="gemini"}`) {
+		t.Error("non-TTS provider reported characters")
+	}
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context" // Added
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"reflect"
@@ -12,6 +13,7 @@ import (
 	"net/url"
 	"phileasgo/pkg/logging"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/narrator"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/store"
 	"strconv"
@@ -47,11 +49,38 @@ type NarratorController interface {
 	Stats() map[string]any
 }
 
+// NarrationSkipper skips narrations, optionally keeping their POI from coming
+// back this session.
+type NarrationSkipper interface {
+	Skip()
+	SkipAndSuppress(poiID string)
+}
+
+// NarrationHolder holds narration for a reason, e.g. while the pilot is on
+// the radio.
+type NarrationHolder interface {
+	Hold(ctx context.Context, reason string, on bool)
+	HoldReason() string
+}
+
+// NarrationPacer reports the silence currently enforced between
+// auto-narrations.
+type NarrationPacer interface {
+	Current() time.Duration
+}
+
+// EssayTopicPlayer is implemented by narrators that can be asked for an
+// essay on a specific topic.
+type EssayTopicPlayer interface {
+	PlayEssayTopic(ctx context.Context, topicID string, tel *sim.Telemetry, enqueue bool) error
+}
+
 // NarratorHandler handles narrator control endpoints.
 type NarratorHandler struct {
 	audio    AudioController
 	narrator NarratorController
 	store    store.Store
+	pacer    NarrationPacer
 
 	statusMu           sync.Mutex
 	lastStatusResponse *NarratorStatusResponse
@@ -66,6 +95,11 @@ func NewNarratorHandler(audioMgr AudioController, narratorSvc NarratorController
 	}
 }
 
+// SetPacer adds the narration gap to the status. p may be nil.
+func (h *NarratorHandler) SetPacer(p NarrationPacer) {
+	h.pacer = p
+}
+
 // PlayRequest represents a manual narration play request.
 type PlayRequest struct {
 	POIID    string `json:"poi_id"`
@@ -101,6 +135,96 @@ type NarratorStatusResponse struct {
 	ShowInfoPanel      bool           `json:"show_info_panel"`
 	CurrentDurationMs  int64          `json:"current_duration_ms"` // Added
 	IsUserPaused       bool           `json:"is_user_paused"`      // Added
+	HoldReason         string         `json:"hold_reason,omitempty"`
+	NarrationGapMs     int64          `json:"narration_gap_ms"` // Silence currently enforced between auto-narrations
+}
+
+const (
+	defaultNarrationLogLimit = 50
+	maxNarrationLogLimit     = 200
+)
+
+// NarrationLogItem is one entry of the persisted narration history.
+type NarrationLogItem struct {
+	Title      string    `json:"title"`
+	Type       string    `json:"type"`
+	Timestamp  time.Time `json:"timestamp"`
+	DurationMs int64     `json:"duration_ms"`
+	WordCount  int       `json:"word_count"`
+}
+
+// HandleLog handles GET /api/narrator/log?limit=N. It returns the most recent
+// narrations, newest first, so the GUI can repopulate its history after the
+// window was closed.
+func (h *NarratorHandler) HandleLog(w http.ResponseWriter, r *http.Request) {
+	limit := defaultNarrationLogLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+			return
+		}
+		limit = min(n, maxNarrationLogLimit)
+	}
+
+	entries, err := h.store.ListNarrationLog(r.Context(), limit)
+	if err != nil {
+		slog.Error("API: Failed to read narration log", "error", err)
+		http.Error(w, "failed to read narration log", http.StatusInternalServerError)
+		return
+	}
+
+	items := make([]NarrationLogItem, 0, len(entries))
+	for _, e := range entries {
+		items = append(items, NarrationLogItem{
+			Title:      e.Title,
+			Type:       e.Type,
+			Timestamp:  e.CreatedAt,
+			DurationMs: e.Duration.Milliseconds(),
+			WordCount:  e.WordCount,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(items); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// HandleSkip handles POST /api/narrator/skip. With suppress=true the POI,
+// poi_id or else the one playing, is not narrated again this session.
+func (h *NarratorHandler) HandleSkip(w http.ResponseWriter, r *http.Request) {
+	skipper, ok := h.narrator.(NarrationSkipper)
+	if !ok {
+		http.Error(w, "skipping not supported", http.StatusNotImplemented)
+		return
+	}
+
+	q := r.URL.Query()
+	suppress := false
+	if s := q.Get("suppress"); s != "" {
+		var err error
+		if suppress, err = strconv.ParseBool(s); err != nil {
+			http.Error(w, "suppress must be true or false", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if suppress {
+		skipper.SkipAndSuppress(q.Get("poi_id"))
+	} else {
+		skipper.Skip()
+	}
+	// A skip while paused would otherwise leave the next narration waiting
+	h.audio.ResetUserPause()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"status":     "skipped",
+		"suppressed": suppress,
+	}); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
 }
 
 // HandlePlay handles POST /api/narrator/play
@@ -205,6 +329,93 @@ func (h *NarratorHandler) HandlePlayFeature(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// HandleEssay handles POST /api/narrator/essay?topic=<id>, narrating an
+// essay on a topic from essays.yaml. If the narrator is busy the request is
+// rejected with 409, or queued behind the current work with enqueue=true.
+func (h *NarratorHandler) HandleEssay(w http.ResponseWriter, r *http.Request) {
+	player, ok := h.narrator.(EssayTopicPlayer)
+	if !ok {
+		http.Error(w, "essay topics not supported", http.StatusNotImplemented)
+		return
+	}
+
+	q := r.URL.Query()
+	topic := strings.TrimSpace(q.Get("topic"))
+	if topic == "" {
+		http.Error(w, "topic is required", http.StatusBadRequest)
+		return
+	}
+	enqueue, _ := strconv.ParseBool(q.Get("enqueue"))
+
+	slog.Info("API: HandleEssay received request", "topic", topic, "enqueue", enqueue)
+
+	// Telemetry is read when the essay is generated
+	err := player.PlayEssayTopic(context.Background(), topic, nil, enqueue)
+	switch {
+	case errors.Is(err, narrator.ErrUnknownEssayTopic):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.Is(err, narrator.ErrNarratorBusy):
+		http.Error(w, "narrator is busy; retry or pass enqueue=true", http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if h.audio.IsUserPaused() {
+		h.audio.ResetUserPause()
+		h.audio.Resume()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(map[string]string{
+		"status":  "accepted",
+		"message": "Queueing essay on " + topic,
+	}); err != nil {
+		slog.Error("API: HandleEssay encode error", "error", err)
+	}
+}
+
+// defaultHoldReason is used when a pause request names no reason.
+const defaultHoldReason = "comms"
+
+// HandlePause handles POST /api/narrator/pause?reason=comms. Narration is
+// ducked or paused (see narrator.comms.mode) until a resume with the same
+// reason; holds for different reasons stack.
+func (h *NarratorHandler) HandlePause(w http.ResponseWriter, r *http.Request) {
+	h.handleHold(w, r, true)
+}
+
+// HandleResume handles POST /api/narrator/resume?reason=comms.
+func (h *NarratorHandler) HandleResume(w http.ResponseWriter, r *http.Request) {
+	h.handleHold(w, r, false)
+}
+
+func (h *NarratorHandler) handleHold(w http.ResponseWriter, r *http.Request, on bool) {
+	holder, ok := h.narrator.(NarrationHolder)
+	if !ok {
+		http.Error(w, "narration hold not supported", http.StatusNotImplemented)
+		return
+	}
+
+	reason := strings.TrimSpace(r.URL.Query().Get("reason"))
+	if reason == "" {
+		reason = defaultHoldReason
+	}
+	// Detached from the request: a release restarts queue processing
+	holder.Hold(context.Background(), reason, on)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"status":      "ok",
+		"hold_reason": holder.HoldReason(),
+	}); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
 // HandleStatus handles GET /api/narrator/status
 func (h *NarratorHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
 	status := h.getPlaybackStatus()
@@ -243,6 +454,12 @@ func (h *NarratorHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
 		CurrentDurationMs:  h.narrator.CurrentDuration().Milliseconds(),
 		IsUserPaused:       h.audio.IsUserPaused(),
 	}
+	if holder, ok := h.narrator.(NarrationHolder); ok {
+		resp.HoldReason = holder.HoldReason()
+	}
+	if h.pacer != nil {
+		resp.NarrationGapMs = h.pacer.Current().Milliseconds()
+	}
 
 	// Check if state changed
 	h.statusMu.Lock()
@@ -3,6 +3,7 @@ package api
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,7 @@ import (
 
 	"phileasgo/pkg/logging"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/narrator"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/store"
 )
@@ -142,3 +144,238 @@ func TestNarratorHandler_HandleStatus_Logging(t *testing.T) {
 		t.Errorf("Expected log message for state change to idle, got: %s", logBuf.String())
 	}
 }
+
+type mockSkipNarrator struct {
+	MockNarratorService
+	skipped    bool
+	suppressed *string
+}
+
+func (m *mockSkipNarrator) Skip() { m.skipped = true }
+func (m *mockSkipNarrator) SkipAndSuppress(poiID string) {
+	m.suppressed = &poiID
+}
+
+func TestNarratorHandler_HandleSkip(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		wantStatus   int
+		wantSkip     bool
+		wantSuppress string
+	}{
+		{"Plain skip", "", http.StatusOK, true, ""},
+		{"Suppress current", "?suppress=true", http.StatusOK, false, ""},
+		{"Suppress by ID", "?suppress=true&poi_id=Q42", http.StatusOK, false, "Q42"},
+		{"Explicit no suppress", "?suppress=false", http.StatusOK, true, ""},
+		{"Invalid suppress", "?suppress=maybe", http.StatusBadRequest, false, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &mockSkipNarrator{}
+			h := NewNarratorHandler(&MockAudioService{}, n, &MockStore{})
+
+			req := httptest.NewRequest("POST", "/api/narrator/skip"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+			h.HandleSkip(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if n.skipped != tt.wantSkip {
+				t.Errorf("expected skip=%v, got %v", tt.wantSkip, n.skipped)
+			}
+			wantSuppressCall := strings.Contains(tt.query, "suppress=true")
+			if (n.suppressed != nil) != wantSuppressCall {
+				t.Fatalf("expected SkipAndSuppress called=%v", wantSuppressCall)
+			}
+			if n.suppressed != nil && *n.suppressed != tt.wantSuppress {
+				t.Errorf("expected suppressed POI %q, got %q", tt.wantSuppress, *n.suppressed)
+			}
+		})
+	}
+
+	t.Run("Unsupported narrator", func(t *testing.T) {
+		h := NewNarratorHandler(&MockAudioService{}, &MockNarratorService{}, &MockStore{})
+		w := httptest.NewRecorder()
+		h.HandleSkip(w, httptest.NewRequest("POST", "/api/narrator/skip", http.NoBody))
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", w.Code)
+		}
+	})
+}
+
+type mockHoldNarrator struct {
+	MockNarratorService
+	holds map[string]bool
+}
+
+func (m *mockHoldNarrator) Hold(ctx context.Context, reason string, on bool) {
+	if on {
+		m.holds[reason] = true
+	} else {
+		delete(m.holds, reason)
+	}
+}
+
+func (m *mockHoldNarrator) HoldReason() string {
+	reasons := make([]string, 0, len(m.holds))
+	for r := range m.holds {
+		reasons = append(reasons, r)
+	}
+	return strings.Join(reasons, ",")
+}
+
+func TestNarratorHandler_HandlePauseResume(t *testing.T) {
+	n := &mockHoldNarrator{holds: map[string]bool{}}
+	h := NewNarratorHandler(&MockAudioService{}, n, &MockStore{})
+
+	w := httptest.NewRecorder()
+	h.HandlePause(w, httptest.NewRequest("POST", "/api/narrator/pause", http.NoBody))
+	if w.Code != http.StatusOK || !n.holds["comms"] {
+		t.Fatalf("expected a comms hold by default, status %d, holds %v", w.Code, n.holds)
+	}
+
+	w = httptest.NewRecorder()
+	h.HandleStatus(w, httptest.NewRequest("GET", "/api/narrator/status", http.NoBody))
+	var status NarratorStatusResponse
+	if err := json.NewDecoder(w.Body).Decode(&status); err != nil {
+		t.Fatalf("failed to decode status: %v", err)
+	}
+	if status.HoldReason != "comms" {
+		t.Errorf("expected hold reason in status, got %q", status.HoldReason)
+	}
+
+	w = httptest.NewRecorder()
+	h.HandleResume(w, httptest.NewRequest("POST", "/api/narrator/resume?reason=comms", http.NoBody))
+	if w.Code != http.StatusOK || len(n.holds) != 0 {
+		t.Errorf("expected the hold released, status %d, holds %v", w.Code, n.holds)
+	}
+
+	t.Run("Unsupported narrator", func(t *testing.T) {
+		h := NewNarratorHandler(&MockAudioService{}, &MockNarratorService{}, &MockStore{})
+		w := httptest.NewRecorder()
+		h.HandlePause(w, httptest.NewRequest("POST", "/api/narrator/pause", http.NoBody))
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", w.Code)
+		}
+	})
+}
+
+type mockEssayNarrator struct {
+	MockNarratorService
+	busy  bool
+	topic string
+}
+
+func (m *mockEssayNarrator) PlayEssayTopic(ctx context.Context, topicID string, tel *sim.Telemetry, enqueue bool) error {
+	if topicID != "cuisine" {
+		return narrator.ErrUnknownEssayTopic
+	}
+	if m.busy && !enqueue {
+		return narrator.ErrNarratorBusy
+	}
+	m.topic = topicID
+	return nil
+}
+
+func TestNarratorHandler_HandleEssay(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		busy       bool
+		wantStatus int
+		wantTopic  string
+	}{
+		{"Known topic", "?topic=cuisine", false, http.StatusAccepted, "cuisine"},
+		{"Unknown topic", "?topic=volcanoes", false, http.StatusNotFound, ""},
+		{"Missing topic", "", false, http.StatusBadRequest, ""},
+		{"Busy", "?topic=cuisine", true, http.StatusConflict, ""},
+		{"Busy with enqueue", "?topic=cuisine&enqueue=true", true, http.StatusAccepted, "cuisine"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := &mockEssayNarrator{busy: tt.busy}
+			h := NewNarratorHandler(&MockAudioService{}, n, &MockStore{})
+			w := httptest.NewRecorder()
+			h.HandleEssay(w, httptest.NewRequest("POST", "/api/narrator/essay"+tt.query, http.NoBody))
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d (%s)", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if n.topic != tt.wantTopic {
+				t.Errorf("expected topic %q requested, got %q", tt.wantTopic, n.topic)
+			}
+		})
+	}
+
+	t.Run("Unsupported narrator", func(t *testing.T) {
+		h := NewNarratorHandler(&MockAudioService{}, &MockNarratorService{}, &MockStore{})
+		w := httptest.NewRecorder()
+		h.HandleEssay(w, httptest.NewRequest("POST", "/api/narrator/essay?topic=cuisine", http.NoBody))
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected 501, got %d", w.Code)
+		}
+	})
+}
+
+type narrationLogStore struct {
+	MockStore
+	entries []store.NarrationLogEntry
+	limit   int
+}
+
+func (m *narrationLogStore) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	m.limit = limit
+	return m.entries[:min(limit, len(m.entries))], nil
+}
+
+func TestNarratorHandler_HandleLog(t *testing.T) {
+	ts := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	entries := []store.NarrationLogEntry{
+		{Title: "Castle", Type: "poi", CreatedAt: ts, Duration: 45 * time.Second, WordCount: 110},
+		{Title: "Geology", Type: "essay", CreatedAt: ts.Add(-time.Hour), Duration: 90 * time.Second, WordCount: 300},
+	}
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+		wantLimit  int
+		wantItems  int
+	}{
+		{"Default limit", "", http.StatusOK, 50, 2},
+		{"Explicit limit", "?limit=1", http.StatusOK, 1, 1},
+		{"Capped limit", "?limit=5000", http.StatusOK, 200, 2},
+		{"Invalid limit", "?limit=0", http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &narrationLogStore{entries: entries}
+			h := NewNarratorHandler(&MockAudioService{}, &MockNarratorService{}, st)
+
+			w := httptest.NewRecorder()
+			h.HandleLog(w, httptest.NewRequest("GET", "/api/narrator/log"+tt.query, http.NoBody))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if st.limit != tt.wantLimit {
+				t.Errorf("expected limit %d, got %d", tt.wantLimit, st.limit)
+			}
+			var items []NarrationLogItem
+			if err := json.NewDecoder(w.Body).Decode(&items); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(items) != tt.wantItems {
+				t.Fatalf("expected %d items, got %d", tt.wantItems, len(items))
+			}
+			if items[0].Title != "Castle" || items[0].DurationMs != 45000 || items[0].WordCount != 110 || !items[0].Timestamp.Equal(ts) {
+				t.Errorf("unexpected first item %+v", items[0])
+			}
+		})
+	}
+}
@@ -1,15 +1,18 @@
 package api
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/llm"
@@ -18,6 +21,9 @@ import (
 	"phileasgo/pkg/poi"
 	"phileasgo/pkg/store"
 	"phileasgo/pkg/wikipedia"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
 )
 
 // thumbnailFlight holds an in-flight thumbnail fetch for request coalescing.
@@ -57,7 +63,10 @@ func NewPOIHandler(mgr *poi.Manager, wp *wikipedia.Client, st store.Store, cfg c
 
 // ... existing handler methods ...
 
-// HandleTracked handles GET /api/pois/tracked.
+// HandleTracked handles GET /api/pois/tracked[?minScore=].
+// minScore hides POIs scoring below it from the map, defaulting to
+// Overlay.MapMinScore; the value applied is returned in the
+// X-Phileas-Map-Min-Score header.
 func (h *POIHandler) HandleTracked(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -66,6 +75,16 @@ func (h *POIHandler) HandleTracked(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
+	mapMinScore := h.cfg.MapMinScore(ctx)
+	if v := r.URL.Query().Get("minScore"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			http.Error(w, "invalid minScore", http.StatusBadRequest)
+			return
+		}
+		mapMinScore = parsed
+	}
+
 	// 1. Fetch filter settings from store
 	filterMode, _ := h.store.GetState(ctx, "filter_mode")
 	if filterMode == "" {
@@ -90,9 +109,19 @@ func (h *POIHandler) HandleTracked(w http.ResponseWriter, r *http.Request) {
 
 	// 2. Get filtered POIs (API always uses airborne mode to show all POIs)
 	pois, threshold := h.mgr.GetPOIsForUI(filterMode, targetCount, minScore)
+	if mapMinScore > 0 {
+		shown := pois[:0]
+		for _, p := range pois {
+			if p.Score >= mapMinScore {
+				shown = append(shown, p)
+			}
+		}
+		pois = shown
+	}
 
 	// 3. Optional: Custom response header for threshold
 	w.Header().Set("X-Phileas-Effective-Threshold", fmt.Sprintf("%.2f", threshold))
+	w.Header().Set("X-Phileas-Map-Min-Score", fmt.Sprintf("%.2f", mapMinScore))
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(pois); err != nil {
@@ -100,6 +129,74 @@ func (h *POIHandler) HandleTracked(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// NearestPOIResponse is a tracked POI with its distance from the query point.
+type NearestPOIResponse struct {
+	QID         string    `json:"qid"`
+	DisplayName string    `json:"display_name"`
+	Category    string    `json:"category"`
+	Icon        string    `json:"icon"`
+	Score       float64   `json:"score"`
+	Visibility  float64   `json:"visibility"`
+	Lat         float64   `json:"lat"`
+	Lon         float64   `json:"lon"`
+	DistanceM   float64   `json:"distance_m"`
+	LastPlayed  time.Time `json:"last_played"`
+}
+
+// HandleNearest handles GET /api/pois/nearest?lat=&lon=&radius=&limit=.
+// Radius is in meters (default 10 km); limit defaults to 20.
+func (h *POIHandler) HandleNearest(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	lat, err1 := strconv.ParseFloat(q.Get("lat"), 64)
+	lon, err2 := strconv.ParseFloat(q.Get("lon"), 64)
+	if err1 != nil || err2 != nil {
+		http.Error(w, "lat and lon are required", http.StatusBadRequest)
+		return
+	}
+
+	radius := 10000.0
+	if v := q.Get("radius"); v != "" {
+		parsed, err := strconv.ParseFloat(v, 64)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "invalid radius", http.StatusBadRequest)
+			return
+		}
+		radius = parsed
+	}
+
+	limit := 20
+	if v := q.Get("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	nearby := h.mgr.GetNearestTracked(r.Context(), lat, lon, radius, limit)
+	resp := make([]NearestPOIResponse, len(nearby))
+	for i, n := range nearby {
+		resp[i] = NearestPOIResponse{
+			QID:         n.POI.WikidataID,
+			DisplayName: n.POI.DisplayName(),
+			Category:    n.POI.Category,
+			Icon:        n.POI.Icon,
+			Score:       n.POI.Score,
+			Visibility:  n.POI.Visibility,
+			Lat:         n.POI.Lat,
+			Lon:         n.POI.Lon,
+			DistanceM:   n.DistanceM,
+			LastPlayed:  n.POI.LastPlayed,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		slog.Error("Failed to encode nearest POIs", "error", err)
+	}
+}
+
 // HandleThumbnail handles GET /api/pois/{id}/thumbnail.
 // Fetches thumbnail from Wikipedia if not cached, persists it, and returns it.
 // Uses singleflight pattern to coalesce concurrent requests for the same POI.
@@ -328,3 +425,215 @@ func (h *POIHandler) HandleResetLastPlayed(w http.ResponseWriter, r *http.Reques
 	slog.Info("Reset last_played timestamp for POIs", "lat", req.Lat, "lon", req.Lon, "radius_m", 100000)
 	w.WriteHeader(http.StatusOK)
 }
+
+// HandleBlock handles POST /api/pois/{qid}/block.
+func (h *POIHandler) HandleBlock(w http.ResponseWriter, r *http.Request) {
+	h.handleBlockToggle(w, r, true)
+}
+
+// HandleUnblock handles DELETE /api/pois/{qid}/block.
+func (h *POIHandler) HandleUnblock(w http.ResponseWriter, r *http.Request) {
+	h.handleBlockToggle(w, r, false)
+}
+
+func (h *POIHandler) handleBlockToggle(w http.ResponseWriter, r *http.Request, blocked bool) {
+	qid := r.PathValue("qid")
+	if qid == "" {
+		http.Error(w, "missing POI id", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	if blocked {
+		err = h.mgr.BlockPOI(r.Context(), qid)
+	} else {
+		err = h.mgr.UnblockPOI(r.Context(), qid)
+	}
+	if err != nil {
+		slog.Error("Failed to update POI blocklist", "qid", qid, "blocked", blocked, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"qid": qid, "blocked": blocked}); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// maxImportBytes caps POI import uploads; a hand-kept list is far smaller.
+const maxImportBytes = 10 << 20
+
+// HandleImport handles POST /api/pois/import. It takes a GeoJSON or CSV file,
+// either as the "file" field of a multipart form or as the raw body.
+func (h *POIHandler) HandleImport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBytes)
+
+	var src io.Reader = r.Body
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		src = file
+	}
+
+	pois, err := poi.ParseUserPOIs(src)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(pois) == 0 {
+		http.Error(w, "no POIs in file", http.StatusBadRequest)
+		return
+	}
+
+	count, err := h.mgr.ImportUserPOIs(r.Context(), pois)
+	if err != nil {
+		slog.Error("Failed to import user POIs", "imported", count, "error", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(map[string]any{"imported": count}); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
+
+// poiBoundsSource is implemented by stores that can stream their POIs in a
+// bounding box.
+type poiBoundsSource interface {
+	ForEachPOIInBounds(ctx context.Context, b store.Bounds, fn func(*model.POI) error) error
+}
+
+// HandleExport handles GET /api/pois/export.geojson[?bbox=minLon,minLat,maxLon,maxLat].
+// It writes the stored POIs as a GeoJSON FeatureCollection for GIS tools,
+// with the live score of those currently tracked. Without bbox everything is
+// exported; features are streamed, so a large area never sits in memory.
+func (h *POIHandler) HandleExport(w http.ResponseWriter, r *http.Request) {
+	src, ok := h.store.(poiBoundsSource)
+	if !ok {
+		http.Error(w, "POI export not supported", http.StatusNotImplemented)
+		return
+	}
+	b, err := parseBBox(r.URL.Query().Get("bbox"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Tracked POIs carry the score, and may not be stored yet
+	tracked := make(map[string]*model.POI)
+	for _, p := range h.mgr.GetTrackedPOIs() {
+		if inBounds(b, p.Lat, p.Lon) {
+			tracked[p.WikidataID] = p
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/geo+json")
+	w.Header().Set("Content-Disposition", `attachment; filename="phileas-pois.geojson"`)
+	bw := bufio.NewWriter(w)
+	_, _ = bw.WriteString(`{"type":"FeatureCollection","features":[`)
+	count := 0
+	write := func(p *model.POI, isTracked bool) error {
+		data, err := json.Marshal(exportFeature(p, isTracked))
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			_ = bw.WriteByte(',')
+		}
+		count++
+		_, err = bw.Write(data)
+		return err
+	}
+
+	err = src.ForEachPOIInBounds(r.Context(), b, func(p *model.POI) error {
+		if t, ok := tracked[p.WikidataID]; ok {
+			delete(tracked, p.WikidataID)
+			return write(t, true)
+		}
+		return write(p, false)
+	})
+	for _, p := range tracked {
+		if err != nil {
+			break
+		}
+		err = write(p, true)
+	}
+	if err != nil {
+		// The status is already sent; leaving the document unterminated is
+		// what tells the client the export is incomplete
+		slog.Error("POI export failed", "written", count, "error", err)
+		_ = bw.Flush()
+		return
+	}
+	_, _ = bw.WriteString("]}\n")
+	_ = bw.Flush()
+}
+
+// exportFeature describes a POI for GIS tools. Only tracked POIs have a
+// score; narrated tells what has been heard.
+func exportFeature(p *model.POI, tracked bool) *geojson.Feature {
+	f := geojson.NewFeature(orb.Point{p.Lon, p.Lat})
+	f.Properties["qid"] = p.WikidataID
+	f.Properties["name"] = p.DisplayName()
+	f.Properties["category"] = p.Category
+	f.Properties["sitelinks"] = p.Sitelinks
+	f.Properties["source"] = p.Source
+	f.Properties["tracked"] = tracked
+	f.Properties["narrated"] = !p.LastPlayed.IsZero()
+	if tracked {
+		f.Properties["score"] = p.Score
+	}
+	if !p.LastPlayed.IsZero() {
+		f.Properties["last_played"] = p.LastPlayed.UTC().Format(time.RFC3339)
+	}
+	if p.WPURL != "" {
+		f.Properties["wp_url"] = p.WPURL
+	}
+	return f
+}
+
+// parseBBox reads a GeoJSON-order bounding box, "minLon,minLat,maxLon,maxLat".
+// An empty value covers the world; minLon > maxLon crosses the antimeridian.
+func parseBBox(s string) (store.Bounds, error) {
+	if s == "" {
+		return store.Bounds{MinLat: -90, MaxLat: 90, MinLon: -180, MaxLon: 180}, nil
+	}
+	parts := strings.Split(s, ",")
+	if len(parts) != 4 {
+		return store.Bounds{}, fmt.Errorf("bbox must be minLon,minLat,maxLon,maxLat")
+	}
+	var v [4]float64
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return store.Bounds{}, fmt.Errorf("invalid bbox value %q", part)
+		}
+		v[i] = f
+	}
+	b := store.Bounds{MinLon: v[0], MinLat: v[1], MaxLon: v[2], MaxLat: v[3]}
+	if b.MinLat > b.MaxLat || b.MinLat < -90 || b.MaxLat > 90 || b.MinLon < -180 || b.MaxLon > 180 {
+		return store.Bounds{}, fmt.Errorf("bbox out of range")
+	}
+	return b, nil
+}
+
+// inBounds applies b the way the store does, including across the antimeridian.
+func inBounds(b store.Bounds, lat, lon float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.MinLon > b.MaxLon {
+		return lon >= b.MinLon || lon <= b.MaxLon
+	}
+	return lon >= b.MinLon && lon <= b.MaxLon
+}
@@ -4,8 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"sort"
+	"strings"
 	"testing"
 
 	"phileasgo/pkg/config"
@@ -19,6 +22,7 @@ import (
 type apiMockStore struct {
 	ResetCalled bool
 	ResetRadius float64
+	State       map[string]string
 }
 
 func (m *apiMockStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error {
@@ -29,6 +33,9 @@ func (m *apiMockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius flo
 	m.ResetRadius = radius
 	return nil
 }
+func (m *apiMockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	return nil, nil
+}
 
 // Stubs for other interface methods...
 func (m *apiMockStore) GetPOI(ctx context.Context, id string) (*model.POI, error) { return nil, nil }
@@ -68,10 +75,43 @@ func (m *apiMockStore) SetGeodataCache(ctx context.Context, key string, val []by
 func (m *apiMockStore) GetGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]store.GeodataRecord, error) {
 	return nil, nil
 }
+func (m *apiMockStore) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	return 0, nil
+}
+func (m *apiMockStore) ListGeodata(ctx context.Context, q store.GeodataQuery) ([]store.GeodataRecord, int, error) {
+	return nil, 0, nil
+}
+
+func (m *apiMockStore) AddNarrationLog(ctx context.Context, e store.NarrationLogEntry, keep int) error {
+	return nil
+}
+
+func (m *apiMockStore) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	return nil, nil
+}
+
+func (m *apiMockStore) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	return nil
+}
+
+func (m *apiMockStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return nil, nil
+}
+
+func (m *apiMockStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	return nil
+}
+
+func (m *apiMockStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return nil, nil
+}
 func (m *apiMockStore) ListGeodataCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
 func (m *apiMockStore) GetState(ctx context.Context, key string) (string, bool) {
+	if val, ok := m.State[key]; ok {
+		return val, true
+	}
 	if key == "filter_mode" {
 		return "adaptive", true
 	}
@@ -80,8 +120,17 @@ func (m *apiMockStore) GetState(ctx context.Context, key string) (string, bool)
 	}
 	return "", false
 }
-func (m *apiMockStore) SetState(ctx context.Context, key, val string) error { return nil }
-func (m *apiMockStore) DeleteState(ctx context.Context, key string) error   { return nil }
+func (m *apiMockStore) SetState(ctx context.Context, key, val string) error {
+	if m.State == nil {
+		m.State = make(map[string]string)
+	}
+	m.State[key] = val
+	return nil
+}
+func (m *apiMockStore) DeleteState(ctx context.Context, key string) error {
+	delete(m.State, key)
+	return nil
+}
 func (m *apiMockStore) GetClassification(ctx context.Context, qid string) (category string, found bool, err error) {
 	return "", false, nil
 }
@@ -198,3 +247,346 @@ func TestHandleTracked(t *testing.T) {
 		}
 	})
 }
+
+func TestHandleTracked_MapMinScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		cfgMin     float64
+		query      string
+		wantCode   int
+		wantQIDs   []string
+		wantHeader string
+	}{
+		{name: "No minimum", wantCode: http.StatusOK, wantQIDs: []string{"P1", "P2", "P3"}, wantHeader: "0.00"},
+		{name: "Config default", cfgMin: 8.0, wantCode: http.StatusOK, wantQIDs: []string{"P1", "P2"}, wantHeader: "8.00"},
+		{name: "Query overrides config", cfgMin: 8.0, query: "?minScore=9.5", wantCode: http.StatusOK, wantQIDs: []string{"P1"}, wantHeader: "9.50"},
+		{name: "Invalid minScore", query: "?minScore=high", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := &apiMockStore{}
+			appCfg := config.DefaultConfig()
+			appCfg.Overlay.MapMinScore = tt.cfgMin
+			cfg := config.NewProvider(appCfg, nil)
+			mgr := poi.NewManager(cfg, mockStore, nil)
+			mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "P1", NameEn: "POI 1", Score: 10.0, Visibility: 1, IsVisible: true})
+			mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "P2", NameEn: "POI 2", Score: 8.0, Visibility: 1, IsVisible: true})
+			mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "P3", NameEn: "POI 3", Score: 2.0, Visibility: 1, IsVisible: true})
+			handler := NewPOIHandler(mgr, nil, mockStore, cfg, nil, nil)
+
+			w := httptest.NewRecorder()
+			handler.HandleTracked(w, httptest.NewRequest(http.MethodGet, "/api/pois/tracked"+tt.query, nil))
+			if w.Code != tt.wantCode {
+				t.Fatalf("Expected %d, got %d", tt.wantCode, w.Code)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if got := w.Header().Get("X-Phileas-Map-Min-Score"); got != tt.wantHeader {
+				t.Errorf("X-Phileas-Map-Min-Score = %q, want %q", got, tt.wantHeader)
+			}
+
+			var resp []*model.POI
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			var got []string
+			for _, p := range resp {
+				got = append(got, p.WikidataID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantQIDs, ",") {
+				t.Errorf("Expected %v, got %v", tt.wantQIDs, got)
+			}
+		})
+	}
+}
+
+func TestHandleBlock(t *testing.T) {
+	mockStore := &apiMockStore{}
+	cfg := config.NewProvider(config.DefaultConfig(), nil)
+	mgr := poi.NewManager(cfg, mockStore, nil)
+	mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q1", NameEn: "POI 1", Score: 10.0, IsVisible: true})
+	mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q2", NameEn: "POI 2", Score: 8.0, IsVisible: true})
+	handler := NewPOIHandler(mgr, nil, mockStore, cfg, nil, nil)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/pois/tracked", handler.HandleTracked)
+	mux.HandleFunc("POST /api/pois/{qid}/block", handler.HandleBlock)
+	mux.HandleFunc("DELETE /api/pois/{qid}/block", handler.HandleUnblock)
+
+	tracked := func() int {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/pois/tracked", nil))
+		var resp []*model.POI
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return len(resp)
+	}
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/pois/Q1/block", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK on block, got %d", w.Code)
+	}
+	if _, ok := mockStore.State[poi.BlocklistKey("Q1")]; !ok {
+		t.Error("Expected blocklist entry in store")
+	}
+	if n := tracked(); n != 1 {
+		t.Errorf("Expected blocked POI to be hidden, got %d POIs", n)
+	}
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/api/pois/Q1/block", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 OK on unblock, got %d", w.Code)
+	}
+	if n := tracked(); n != 2 {
+		t.Errorf("Expected unblocked POI to be shown again, got %d POIs", n)
+	}
+}
+
+func TestHandleNearest(t *testing.T) {
+	mockStore := &apiMockStore{}
+	cfg := config.NewProvider(config.DefaultConfig(), nil)
+	mgr := poi.NewManager(cfg, mockStore, nil)
+	played := time.Now().Add(-time.Minute).Truncate(time.Second)
+	mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q_FAR", NameEn: "Far", Lat: 10.05, Lon: 20.0, Score: 9.0})
+	mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q_NEAR", NameEn: "Near", Category: "castle", Lat: 10.01, Lon: 20.0, Score: 3.0, LastPlayed: played})
+	mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q_OUT", NameEn: "Out", Lat: 11.0, Lon: 20.0, Score: 5.0})
+	handler := NewPOIHandler(mgr, nil, mockStore, cfg, nil, nil)
+
+	get := func(query string) (int, []NearestPOIResponse) {
+		w := httptest.NewRecorder()
+		handler.HandleNearest(w, httptest.NewRequest(http.MethodGet, "/api/pois/nearest?"+query, nil))
+		var resp []NearestPOIResponse
+		if w.Code == http.StatusOK {
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+		}
+		return w.Code, resp
+	}
+
+	t.Run("Sorted by distance within radius", func(t *testing.T) {
+		code, resp := get("lat=10&lon=20&radius=20000")
+		if code != http.StatusOK {
+			t.Fatalf("Expected 200 OK, got %d", code)
+		}
+		if len(resp) != 2 || resp[0].QID != "Q_NEAR" || resp[1].QID != "Q_FAR" {
+			t.Fatalf("Unexpected result: %+v", resp)
+		}
+		if resp[0].Category != "castle" || resp[0].DisplayName != "Near" || !resp[0].LastPlayed.Equal(played) {
+			t.Errorf("Missing POI details: %+v", resp[0])
+		}
+		if resp[0].Icon != config.GenericIcon {
+			t.Errorf("Expected the generic icon without category config, got %q", resp[0].Icon)
+		}
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		_, resp := get("lat=10&lon=20&radius=20000&limit=1")
+		if len(resp) != 1 || resp[0].QID != "Q_NEAR" {
+			t.Errorf("Expected only the nearest POI, got %+v", resp)
+		}
+	})
+
+	t.Run("Nothing near returns empty array", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.HandleNearest(w, httptest.NewRequest(http.MethodGet, "/api/pois/nearest?lat=-40&lon=100", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected 200 OK, got %d", w.Code)
+		}
+		if body := bytes.TrimSpace(w.Body.Bytes()); string(body) != "[]" {
+			t.Errorf("Expected empty array, got %s", body)
+		}
+	})
+
+	t.Run("Missing coordinates", func(t *testing.T) {
+		if code, _ := get("lat=10"); code != http.StatusBadRequest {
+			t.Errorf("Expected 400 Bad Request, got %d", code)
+		}
+	})
+}
+
+func TestHandleImport(t *testing.T) {
+	cfg := config.NewProvider(config.DefaultConfig(), nil)
+
+	tests := []struct {
+		name      string
+		body      func() (*bytes.Buffer, string)
+		wantCode  int
+		wantCount int
+	}{
+		{
+			name: "Raw CSV",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString("name,lat,lon,description\nFamily Cabin,59.9,10.7,Built in 1962\n"), "text/csv"
+			},
+			wantCode:  http.StatusOK,
+			wantCount: 1,
+		},
+		{
+			name: "Multipart GeoJSON",
+			body: func() (*bytes.Buffer, string) {
+				var buf bytes.Buffer
+				mw := multipart.NewWriter(&buf)
+				fw, _ := mw.CreateFormFile("file", "spots.geojson")
+				_, _ = fw.Write([]byte(`{"type":"FeatureCollection","features":[
+					{"type":"Feature","properties":{"name":"Old Bridge"},"geometry":{"type":"Point","coordinates":[8.7,47.5]}},
+					{"type":"Feature","properties":{"name":"Lake Spot"},"geometry":{"type":"Point","coordinates":[8.8,47.6]}}]}`))
+				_ = mw.Close()
+				return &buf, mw.FormDataContentType()
+			},
+			wantCode:  http.StatusOK,
+			wantCount: 2,
+		},
+		{
+			name: "Invalid coordinates",
+			body: func() (*bytes.Buffer, string) {
+				return bytes.NewBufferString("name,lat,lon\nCabin,123,10\n"), "text/csv"
+			},
+			wantCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockStore := &apiMockStore{}
+			mgr := poi.NewManager(cfg, mockStore, nil)
+			handler := NewPOIHandler(mgr, nil, mockStore, cfg, nil, nil)
+
+			body, contentType := tt.body()
+			req := httptest.NewRequest(http.MethodPost, "/api/pois/import", body)
+			req.Header.Set("Content-Type", contentType)
+			w := httptest.NewRecorder()
+
+			handler.HandleImport(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d: %s", tt.wantCode, w.Code, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Imported int `json:"imported"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Imported != tt.wantCount || len(mgr.GetTrackedPOIs()) != tt.wantCount {
+				t.Errorf("expected %d imported and tracked, got %d and %d", tt.wantCount, resp.Imported, len(mgr.GetTrackedPOIs()))
+			}
+		})
+	}
+}
+
+// exportMockStore streams its POIs for the GeoJSON export.
+type exportMockStore struct {
+	apiMockStore
+	pois []*model.POI
+}
+
+func (m *exportMockStore) ForEachPOIInBounds(ctx context.Context, b store.Bounds, fn func(*model.POI) error) error {
+	for _, p := range m.pois {
+		if inBounds(b, p.Lat, p.Lon) {
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func TestHandleExport(t *testing.T) {
+	played := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	st := &exportMockStore{pois: []*model.POI{
+		{WikidataID: "Q1", NameEn: "Heard", Category: "Castle", Lat: 48.0, Lon: 8.0, Sitelinks: 12, LastPlayed: played},
+		{WikidataID: "Q2", NameEn: "Stored", Category: "Tower", Lat: 48.1, Lon: 8.1},
+		{WikidataID: "Q3", NameEn: "Far", Category: "Peak", Lat: 10.0, Lon: 10.0},
+	}}
+	cfg := config.NewProvider(config.DefaultConfig(), nil)
+	mgr := poi.NewManager(cfg, st, nil)
+	mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q2", NameEn: "Stored", Category: "Tower", Lat: 48.1, Lon: 8.1, Score: 7.5})
+	mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q4", NameEn: "New", Category: "Dam", Lat: 48.2, Lon: 8.2, Score: 3})
+	handler := NewPOIHandler(mgr, nil, st, cfg, nil, nil)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+		wantQIDs []string
+	}{
+		{name: "Bounding box", query: "?bbox=7.5,47.5,8.5,48.5", wantCode: http.StatusOK, wantQIDs: []string{"Q1", "Q2", "Q4"}},
+		{name: "Everything", query: "", wantCode: http.StatusOK, wantQIDs: []string{"Q1", "Q2", "Q3", "Q4"}},
+		{name: "Malformed bbox", query: "?bbox=7.5,47.5,8.5", wantCode: http.StatusBadRequest},
+		{name: "Latitude out of range", query: "?bbox=7.5,47.5,8.5,95", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/pois/export.geojson"+tt.query, nil)
+			w := httptest.NewRecorder()
+			handler.HandleExport(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if ct := w.Header().Get("Content-Type"); ct != "application/geo+json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+
+			var fc struct {
+				Type     string `json:"type"`
+				Features []struct {
+					Geometry struct {
+						Coordinates []float64 `json:"coordinates"`
+					} `json:"geometry"`
+					Properties map[string]any `json:"properties"`
+				} `json:"features"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &fc); err != nil {
+				t.Fatalf("invalid GeoJSON: %v\n%s", err, w.Body.String())
+			}
+			if fc.Type != "FeatureCollection" {
+				t.Errorf("type = %q", fc.Type)
+			}
+
+			props := map[string]map[string]any{}
+			var qids []string
+			for _, f := range fc.Features {
+				qid := f.Properties["qid"].(string)
+				qids = append(qids, qid)
+				props[qid] = f.Properties
+			}
+			sort.Strings(qids)
+			if strings.Join(qids, ",") != strings.Join(tt.wantQIDs, ",") {
+				t.Fatalf("features = %v, want %v", qids, tt.wantQIDs)
+			}
+
+			if p := props["Q1"]; p["narrated"] != true || p["last_played"] != "2026-05-01T12:00:00Z" || p["sitelinks"] != 12.0 || p["tracked"] != false {
+				t.Errorf("narrated POI properties = %v", p)
+			}
+			if _, ok := props["Q1"]["score"]; ok {
+				t.Error("untracked POI has a score")
+			}
+			if p := props["Q2"]; p["narrated"] != false || p["score"] != 7.5 || p["tracked"] != true {
+				t.Errorf("tracked POI properties = %v", p)
+			}
+		})
+	}
+
+	t.Run("Store without export", func(t *testing.T) {
+		h := NewPOIHandler(mgr, nil, &apiMockStore{}, cfg, nil, nil)
+		w := httptest.NewRecorder()
+		h.HandleExport(w, httptest.NewRequest(http.MethodGet, "/api/pois/export.geojson", nil))
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("status = %d, want 501", w.Code)
+		}
+	})
+}
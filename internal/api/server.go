@@ -14,14 +14,19 @@ import (
 
 // NewServer creates and configures the HTTP server.
 // It accepts handlers for all API endpoints and a shutdownFunc for graceful shutdown.
-func NewServer(addr string, tel *TelemetryHandler, cfg *ConfigHandler, stats *StatsHandler, cache *CacheHandler, pois *POIHandler, vis *VisibilityHandler, audioH *AudioHandler, narratorH *NarratorHandler, imageH *ImageHandler, geo *GeographyHandler, tripH *TripHandler, labelH *MapLabelsHandler, simH *SimCommandHandler, regionalH *RegionalCategoriesHandler, featuresH *FeaturesHandler, shutdown func()) *http.Server {
+func NewServer(addr string, tel *TelemetryHandler, cfg *ConfigHandler, stats *StatsHandler, cache *CacheHandler, pois *POIHandler, vis *VisibilityHandler, audioH *AudioHandler, narratorH *NarratorHandler, imageH *ImageHandler, geo *GeographyHandler, tripH *TripHandler, labelH *MapLabelsHandler, simH *SimCommandHandler, regionalH *RegionalCategoriesHandler, featuresH *FeaturesHandler, terrainH *TerrainHandler, tourH *TourHandler, metricsH *MetricsHandler, healthH *HealthHandler, shutdown func()) *http.Server {
 	mux := http.NewServeMux()
 
 	// 1. Health Endpoint
-	mux.HandleFunc("GET /health", handleHealth)
+	if healthH != nil {
+		mux.HandleFunc("GET /health", healthH.HandleHealth)
+	} else {
+		mux.HandleFunc("GET /health", handleHealth)
+	}
 
 	// 2. Telemetry Endpoint
 	mux.HandleFunc("GET /api/telemetry", tel.handleTelemetry)
+	mux.HandleFunc("GET /api/events", tel.HandleEvents)
 
 	// 2b. Version Endpoint
 	mux.HandleFunc("GET /api/version", handleVersion)
@@ -37,16 +42,23 @@ func NewServer(addr string, tel *TelemetryHandler, cfg *ConfigHandler, stats *St
 
 	// 2e. Cache Endpoint
 	mux.Handle("GET /api/wikidata/cache", cache)
+	mux.HandleFunc("POST /api/cache/purge", cache.HandlePurge)
 
 	// 2f. POI Endpoints
 	mux.HandleFunc("GET /api/pois/tracked", pois.HandleTracked)
+	mux.HandleFunc("GET /api/pois/nearest", pois.HandleNearest)
 	mux.HandleFunc("GET /api/pois/{id}/thumbnail", pois.HandleThumbnail)
 	mux.HandleFunc("POST /api/pois/reset-last-played", pois.HandleResetLastPlayed)
+	mux.HandleFunc("POST /api/pois/import", pois.HandleImport)
+	mux.HandleFunc("GET /api/pois/export.geojson", pois.HandleExport)
+	mux.HandleFunc("POST /api/pois/{qid}/block", pois.HandleBlock)
+	mux.HandleFunc("DELETE /api/pois/{qid}/block", pois.HandleUnblock)
 
 	// 2g. Visibility Endpoint
 	mux.HandleFunc("GET /api/map/visibility", vis.Handler)
 	mux.HandleFunc("GET /api/map/visibility-mask", vis.HandleMask)
 	mux.HandleFunc("GET /api/map/coverage", vis.HandleGetCoverage)
+	mux.HandleFunc("GET /api/map/density", vis.HandleDensity)
 
 	// 2h. Geography Endpoint
 	mux.HandleFunc("GET /api/geography", geo.Handle)
@@ -63,6 +75,11 @@ func NewServer(addr string, tel *TelemetryHandler, cfg *ConfigHandler, stats *St
 		mux.HandleFunc("POST /api/narrator/play", narratorH.HandlePlay)
 		mux.HandleFunc("POST /api/narrator/play-city", narratorH.HandlePlayCity)
 		mux.HandleFunc("POST /api/narrator/play-feature", narratorH.HandlePlayFeature)
+		mux.HandleFunc("POST /api/narrator/essay", narratorH.HandleEssay)
+		mux.HandleFunc("POST /api/narrator/skip", narratorH.HandleSkip)
+		mux.HandleFunc("POST /api/narrator/pause", narratorH.HandlePause)
+		mux.HandleFunc("POST /api/narrator/resume", narratorH.HandleResume)
+		mux.HandleFunc("GET /api/narrator/log", narratorH.HandleLog)
 		mux.HandleFunc("GET /api/narrator/status", narratorH.HandleStatus)
 		mux.HandleFunc("POST /api/narrator/clear-image", narratorH.HandleClearImage)
 	}
@@ -75,6 +92,7 @@ func NewServer(addr string, tel *TelemetryHandler, cfg *ConfigHandler, stats *St
 	// 2k. Trip Endpoint
 	if tripH != nil {
 		mux.HandleFunc("GET /api/trip/events", tripH.HandleEvents)
+		mux.HandleFunc("GET /api/session/current", tripH.HandleCurrent)
 	}
 
 	// 2l. Label Endpoint (New)
@@ -97,6 +115,24 @@ func NewServer(addr string, tel *TelemetryHandler, cfg *ConfigHandler, stats *St
 		mux.HandleFunc("GET /api/features", featuresH.HandleGet)
 	}
 
+	// 2r. Terrain Profile Endpoint
+	if terrainH != nil {
+		mux.HandleFunc("GET /api/terrain/profile", terrainH.HandleProfile)
+	}
+
+	// 2s. Guided Tour Endpoints
+	if tourH != nil {
+		mux.HandleFunc("GET /api/tour", tourH.HandleStatus)
+		mux.HandleFunc("POST /api/tour", tourH.HandleStart)
+		mux.HandleFunc("POST /api/tour/skip", tourH.HandleSkip)
+		mux.HandleFunc("DELETE /api/tour", tourH.HandleClear)
+	}
+
+	// 2q. Prometheus Metrics (opt-in)
+	if metricsH != nil {
+		mux.Handle("GET /metrics", metricsH)
+	}
+
 	// 2m. Profiling Endpoints (pprof)
 	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
 	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
@@ -1,10 +1,15 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"os"
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/llm/failover"
 	"phileasgo/pkg/poi"
+	"phileasgo/pkg/request"
+	"phileasgo/pkg/sim"
 	"phileasgo/pkg/tracker"
 	"runtime"
 	"sync"
@@ -21,20 +26,44 @@ type componentState struct {
 type StatsHandler struct {
 	tracker     *tracker.Tracker
 	poiMgr      *poi.Manager
+	sim         sim.Client
+	cfgProv     config.Provider
+	breakers    BreakerReporter
 	llmFallback []string
+	llmHealth   LLMHealthReporter
 	mu          sync.Mutex
 	states      map[string]*componentState
 }
 
-func NewStatsHandler(t *tracker.Tracker, pm *poi.Manager, fallback []string) *StatsHandler {
+// BreakerReporter exposes the request client's per-host circuit breakers.
+type BreakerReporter interface {
+	BreakerStates() map[string]request.BreakerStatus
+}
+
+// LLMHealthReporter exposes the health of the LLM fallback chain.
+type LLMHealthReporter interface {
+	Health() failover.Health
+}
+
+// NewStatsHandler creates a new StatsHandler. breakers may be nil.
+func NewStatsHandler(t *tracker.Tracker, pm *poi.Manager, simClient sim.Client, cfgProv config.Provider, breakers BreakerReporter, fallback []string) *StatsHandler {
 	return &StatsHandler{
 		tracker:     t,
 		poiMgr:      pm,
+		sim:         simClient,
+		cfgProv:     cfgProv,
+		breakers:    breakers,
 		llmFallback: fallback,
 		states:      make(map[string]*componentState),
 	}
 }
 
+// SetLLMHealth reports the LLM fallback chain's health and which provider
+// served the last request.
+func (h *StatsHandler) SetLLMHealth(r LLMHealthReporter) {
+	h.llmHealth = r
+}
+
 type ProviderStatsDTO struct {
 	CacheHits     int64 `json:"cache_hits"`
 	CacheMisses   int64 `json:"cache_misses"`
@@ -43,6 +72,9 @@ type ProviderStatsDTO struct {
 	APIFailures   int64 `json:"api_errors"`
 	HitRate       int64 `json:"hit_rate"`
 	FreeTier      bool  `json:"free_tier"`
+
+	PromptTokens     int64 `json:"prompt_tokens,omitempty"`
+	CompletionTokens int64 `json:"completion_tokens,omitempty"`
 }
 
 type ComponentStats struct {
@@ -74,12 +106,37 @@ type GoMemStats struct {
 	HeapObjects   uint64  `json:"heap_objects"`    // Live heap object count
 }
 
+// SimConnectionStats reports the simulator connection lifecycle. The
+// timestamps are only available from clients that reconnect on their own.
+type SimConnectionStats struct {
+	State            sim.State  `json:"state"`
+	Connected        bool       `json:"connected"`
+	Reconnects       int        `json:"reconnects"`
+	LastConnected    *time.Time `json:"last_connected,omitempty"`
+	LastDisconnected *time.Time `json:"last_disconnected,omitempty"`
+	NextAttempt      *time.Time `json:"next_attempt,omitempty"`
+	LastError        string     `json:"last_error,omitempty"`
+}
+
+// CostStats is the estimated API spend of the current session. Once it
+// reaches the budget, auto-narration is suspended until the session resets.
+type CostStats struct {
+	SessionUSD float64 `json:"session_usd"`
+	BudgetUSD  float64 `json:"budget_usd,omitempty"`
+	OverBudget bool    `json:"over_budget"`
+}
+
 type StatsResponse struct {
 	Diagnostics []ComponentStats            `json:"diagnostics"`
 	GoMem       GoMemStats                  `json:"go_mem"`
 	Tracking    TrackingStats               `json:"tracking"`
 	Providers   map[string]ProviderStatsDTO `json:"providers"`
 	LLMFallback []string                    `json:"llm_fallback"`
+	LLMHealth   *failover.Health            `json:"llm_health,omitempty"`
+	Sim         *SimConnectionStats         `json:"sim,omitempty"`
+	Cost        *CostStats                  `json:"cost,omitempty"`
+	// Breakers lists hosts that failed since their last success, keyed by host.
+	Breakers map[string]request.BreakerStatus `json:"breakers,omitempty"`
 }
 
 func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -118,6 +175,15 @@ func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		},
 		Providers:   make(map[string]ProviderStatsDTO),
 		LLMFallback: h.llmFallback,
+		Sim:         h.simStats(),
+		Cost:        h.costStats(r.Context()),
+	}
+	if h.breakers != nil {
+		resp.Breakers = h.breakers.BreakerStates()
+	}
+	if h.llmHealth != nil {
+		health := h.llmHealth.Health()
+		resp.LLMHealth = &health
 	}
 
 	for provider, stats := range snapshot {
@@ -134,6 +200,9 @@ func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			APIFailures:   stats.APIFailures,
 			HitRate:       hitRate,
 			FreeTier:      stats.FreeTier,
+
+			PromptTokens:     stats.PromptTokens,
+			CompletionTokens: stats.CompletionTokens,
 		}
 	}
 
@@ -141,6 +210,50 @@ func (h *StatsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
+func (h *StatsHandler) simStats() *SimConnectionStats {
+	if h.sim == nil {
+		return nil
+	}
+	state := h.sim.GetState()
+	stats := &SimConnectionStats{
+		State:     state,
+		Connected: state != sim.StateDisconnected,
+	}
+	reporter, ok := h.sim.(sim.ConnectionReporter)
+	if !ok {
+		return stats
+	}
+	cs := reporter.ConnectionStatus()
+	stats.Connected = cs.Connected
+	stats.Reconnects = cs.Reconnects
+	stats.LastConnected = optionalTime(cs.LastConnected)
+	stats.LastDisconnected = optionalTime(cs.LastDisconnected)
+	if !cs.Connected {
+		stats.NextAttempt = optionalTime(cs.NextAttempt)
+		stats.LastError = cs.LastError
+	}
+	return stats
+}
+
+func (h *StatsHandler) costStats(ctx context.Context) *CostStats {
+	if h.cfgProv == nil {
+		return nil
+	}
+	stats := &CostStats{
+		SessionUSD: h.tracker.SessionCost(),
+		BudgetUSD:  h.cfgProv.SessionBudgetUSD(ctx),
+	}
+	stats.OverBudget = stats.BudgetUSD > 0 && stats.SessionUSD >= stats.BudgetUSD
+	return stats
+}
+
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
 func (h *StatsHandler) gatherDiagnostics() []ComponentStats {
 	now := time.Now()
 	var results []ComponentStats
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"testing"
+	"time"
+
+	"phileasgo/pkg/sim"
+)
+
+type reconnectingSimClient struct {
+	mockSimClient
+	status sim.ConnectionStatus
+}
+
+func (m *reconnectingSimClient) GetState() sim.State {
+	if m.status.Connected {
+		return sim.StateActive
+	}
+	return sim.StateDisconnected
+}
+
+func (m *reconnectingSimClient) ConnectionStatus() sim.ConnectionStatus { return m.status }
+
+func TestStatsHandler_SimStats(t *testing.T) {
+	lastUp := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	retry := lastUp.Add(time.Hour)
+
+	t.Run("No client", func(t *testing.T) {
+		h := &StatsHandler{}
+		if got := h.simStats(); got != nil {
+			t.Errorf("expected nil, got %+v", got)
+		}
+	})
+
+	t.Run("Plain client reports state only", func(t *testing.T) {
+		h := &StatsHandler{sim: &mockSimClient{}}
+		got := h.simStats()
+		if got.State != sim.StateActive || !got.Connected || got.LastConnected != nil {
+			t.Errorf("unexpected stats: %+v", got)
+		}
+	})
+
+	t.Run("Disconnected reporter exposes retry", func(t *testing.T) {
+		h := &StatsHandler{sim: &reconnectingSimClient{status: sim.ConnectionStatus{
+			Reconnects:       2,
+			LastConnected:    lastUp,
+			LastDisconnected: lastUp.Add(time.Minute),
+			NextAttempt:      retry,
+			LastError:        "not running",
+		}}}
+		got := h.simStats()
+		if got.Connected || got.State != sim.StateDisconnected || got.Reconnects != 2 {
+			t.Errorf("unexpected stats: %+v", got)
+		}
+		if got.NextAttempt == nil || !got.NextAttempt.Equal(retry) || got.LastError != "not running" {
+			t.Errorf("expected retry details, got %+v", got)
+		}
+	})
+
+	t.Run("Connected reporter hides stale retry", func(t *testing.T) {
+		h := &StatsHandler{sim: &reconnectingSimClient{status: sim.ConnectionStatus{
+			Connected:     true,
+			LastConnected: lastUp,
+			NextAttempt:   retry,
+			LastError:     "old",
+		}}}
+		got := h.simStats()
+		if !got.Connected || got.NextAttempt != nil || got.LastError != "" {
+			t.Errorf("unexpected stats: %+v", got)
+		}
+	})
+}
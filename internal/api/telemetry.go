@@ -23,6 +23,10 @@ type TelemetryHandler struct {
 	simState       sim.State
 	valleyAltitude float64
 	hasReceived    bool
+
+	// Event stream subscribers (GET /api/events)
+	subsMu sync.RWMutex
+	subs   map[chan Event]struct{}
 }
 
 func NewTelemetryHandler() *TelemetryHandler {
@@ -32,9 +36,11 @@ func NewTelemetryHandler() *TelemetryHandler {
 // Update implements core.TelemetrySink.
 func (h *TelemetryHandler) Update(t *sim.Telemetry) {
 	h.mu.Lock()
-	defer h.mu.Unlock()
 	h.telemetry = *t
 	h.hasReceived = true
+	h.mu.Unlock()
+
+	h.publish(Event{Name: "telemetry", Data: h.snapshot()})
 }
 
 // UpdateState updates the simulator state.
@@ -62,15 +68,19 @@ func (h *TelemetryHandler) GetTelemetry() (sim.Telemetry, bool) {
 	return h.telemetry, h.hasReceived
 }
 
-func (h *TelemetryHandler) handleTelemetry(w http.ResponseWriter, r *http.Request) {
+func (h *TelemetryHandler) snapshot() TelemetryResponse {
 	h.mu.RLock()
-	resp := TelemetryResponse{
+	defer h.mu.RUnlock()
+	return TelemetryResponse{
 		Telemetry:      h.telemetry,
 		SimState:       string(h.simState),
 		ValleyAltitude: h.valleyAltitude,
 		Valid:          h.hasReceived,
 	}
-	h.mu.RUnlock()
+}
+
+func (h *TelemetryHandler) handleTelemetry(w http.ResponseWriter, r *http.Request) {
+	resp := h.snapshot()
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
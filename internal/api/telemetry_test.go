@@ -1,10 +1,14 @@
 package api
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"phileasgo/pkg/sim"
 )
@@ -84,3 +88,85 @@ func TestTelemetryHandler_HandleTelemetry(t *testing.T) {
 		})
 	}
 }
+
+func TestTelemetryHandler_HandleEvents(t *testing.T) {
+	h := NewTelemetryHandler()
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleEvents))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL, http.NoBody)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+
+	lines := make(chan string, 64)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			lines <- sc.Text()
+		}
+		close(lines)
+	}()
+	nextEvent := func() (name, data string) {
+		timeout := time.After(2 * time.Second)
+		for {
+			select {
+			case l, ok := <-lines:
+				if !ok {
+					t.Fatal("stream closed")
+				}
+				switch {
+				case strings.HasPrefix(l, "event: "):
+					name = strings.TrimPrefix(l, "event: ")
+				case strings.HasPrefix(l, "data: "):
+					data = strings.TrimPrefix(l, "data: ")
+				case l == "" && name != "":
+					return name, data
+				}
+			case <-timeout:
+				t.Fatal("timed out waiting for event")
+			}
+		}
+	}
+
+	// Initial snapshot
+	if name, _ := nextEvent(); name != "telemetry" {
+		t.Fatalf("first event = %q, want telemetry", name)
+	}
+
+	h.Update(&sim.Telemetry{Latitude: 47.1})
+	name, data := nextEvent()
+	var tel TelemetryResponse
+	if err := json.Unmarshal([]byte(data), &tel); name != "telemetry" || err != nil || tel.Latitude != 47.1 || !tel.Valid {
+		t.Errorf("unexpected telemetry event %q: %s", name, data)
+	}
+
+	h.PublishNarration("start", "Eiffel Tower", "poi", "Q243")
+	name, data = nextEvent()
+	var n NarrationEvent
+	if err := json.Unmarshal([]byte(data), &n); name != "narration" || err != nil || n.State != "start" || n.QID != "Q243" {
+		t.Errorf("unexpected narration event %q: %s", name, data)
+	}
+
+	// Disconnect: the handler must unsubscribe
+	cancel()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		h.subsMu.RLock()
+		n := len(h.subs)
+		h.subsMu.RUnlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("subscriber leaked after disconnect (%d left)", n)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"phileasgo/pkg/geo"
+	"phileasgo/pkg/terrain"
+)
+
+const (
+	defaultProfileSamples = 100
+	// maxProfileSamples bounds the elevation lookups per request, however
+	// long the path is.
+	maxProfileSamples = 500
+	maxProfilePoints  = 100
+)
+
+// TerrainHandler serves terrain queries from the elevation data loaded for
+// line-of-sight checks.
+type TerrainHandler struct {
+	elevation terrain.ElevationGetter
+}
+
+// NewTerrainHandler creates a new TerrainHandler. elev may be nil when no
+// elevation data is installed.
+func NewTerrainHandler(elev terrain.ElevationGetter) *TerrainHandler {
+	return &TerrainHandler{elevation: elev}
+}
+
+// ProfileSample is one elevation sample along a path.
+type ProfileSample struct {
+	Lat        float64 `json:"lat"`
+	Lon        float64 `json:"lon"`
+	DistanceM  float64 `json:"distance_m"`  // From the start of the path
+	ElevationM *int16  `json:"elevation_m"` // Null where the lookup failed
+}
+
+// ProfileResponse is the result of GET /api/terrain/profile.
+type ProfileResponse struct {
+	DistanceM float64         `json:"distance_m"`
+	Samples   []ProfileSample `json:"samples"`
+}
+
+// HandleProfile handles GET /api/terrain/profile. The path is given either as
+// from=lat,lon&to=lat,lon or as repeated point=lat,lon parameters; samples
+// sets the number of evenly spaced points (default 100, capped at 500).
+func (h *TerrainHandler) HandleProfile(w http.ResponseWriter, r *http.Request) {
+	if h.elevation == nil {
+		http.Error(w, "Elevation data not loaded", http.StatusServiceUnavailable)
+		return
+	}
+
+	path, err := parseProfilePath(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	samples := defaultProfileSamples
+	if s := r.URL.Query().Get("samples"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 2 {
+			http.Error(w, "samples must be an integer of at least 2", http.StatusBadRequest)
+			return
+		}
+		samples = min(n, maxProfileSamples)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.profile(path, samples)); err != nil {
+		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+	}
+}
+
+// profile samples the path at evenly spaced distances, following the great
+// circle on each leg.
+func (h *TerrainHandler) profile(path []geo.Point, samples int) ProfileResponse {
+	cumulative := make([]float64, len(path))
+	for i := 1; i < len(path); i++ {
+		cumulative[i] = cumulative[i-1] + geo.Distance(path[i-1], path[i])
+	}
+	total := cumulative[len(path)-1]
+
+	resp := ProfileResponse{DistanceM: total, Samples: make([]ProfileSample, 0, samples)}
+	leg := 0
+	for i := range samples {
+		d := total * float64(i) / float64(samples-1)
+		for leg < len(path)-2 && d > cumulative[leg+1] {
+			leg++
+		}
+		a, b := path[leg], path[leg+1]
+		p := b
+		if i < samples-1 {
+			p = geo.DestinationPoint(a, d-cumulative[leg], geo.Bearing(a, b))
+		}
+
+		sample := ProfileSample{Lat: p.Lat, Lon: p.Lon, DistanceM: d}
+		if elev, err := h.elevation.GetElevation(p.Lat, p.Lon); err == nil {
+			sample.ElevationM = &elev
+		}
+		resp.Samples = append(resp.Samples, sample)
+	}
+	return resp
+}
+
+func parseProfilePath(r *http.Request) ([]geo.Point, error) {
+	q := r.URL.Query()
+	parts := q["point"]
+	if len(parts) == 0 {
+		parts = []string{q.Get("from"), q.Get("to")}
+	}
+	if len(parts) < 2 {
+		return nil, errors.New("path needs at least two points")
+	}
+	if len(parts) > maxProfilePoints {
+		return nil, fmt.Errorf("path is limited to %d points", maxProfilePoints)
+	}
+
+	points := make([]geo.Point, 0, len(parts))
+	for _, part := range parts {
+		pt, err := parseLatLon(part)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+	}
+	return points, nil
+}
+
+func parseLatLon(s string) (geo.Point, error) {
+	lat, lon, ok := strings.Cut(s, ",")
+	if !ok {
+		return geo.Point{}, fmt.Errorf("invalid point %q, want lat,lon", s)
+	}
+	la, err1 := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	lo, err2 := strconv.ParseFloat(strings.TrimSpace(lon), 64)
+	if err1 != nil || err2 != nil || la < -90 || la > 90 || lo < -180 || lo > 180 {
+		return geo.Point{}, fmt.Errorf("invalid point %q, want lat,lon", s)
+	}
+	return geo.Point{Lat: la, Lon: lo}, nil
+}
@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"phileasgo/pkg/geo"
+)
+
+// slopeElevation rises one meter per 0.001° of longitude and has no data
+// west of the prime meridian.
+type slopeElevation struct {
+	calls int
+}
+
+func (m *slopeElevation) GetElevation(lat, lon float64) (int16, error) {
+	m.calls++
+	if lon < 0 {
+		return 0, errors.New("no data")
+	}
+	return int16(lon * 1000), nil
+}
+
+func (m *slopeElevation) GetLowestElevation(lat, lon, radius float64) (int16, error) {
+	return m.GetElevation(lat, lon)
+}
+
+func TestTerrainHandler_HandleProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		nilElev     bool
+		query       string
+		wantStatus  int
+		wantSamples int
+	}{
+		{"No elevation data", true, "from=0,0&to=0,1", http.StatusServiceUnavailable, 0},
+		{"Missing points", false, "", http.StatusBadRequest, 0},
+		{"Invalid point", false, "from=0,0&to=95,1", http.StatusBadRequest, 0},
+		{"Single point path", false, "point=0,0", http.StatusBadRequest, 0},
+		{"Bad sample count", false, "from=0,0&to=0,1&samples=1", http.StatusBadRequest, 0},
+		{"Default samples", false, "from=0,0&to=0,1", http.StatusOK, defaultProfileSamples},
+		{"Explicit samples", false, "from=0,0&to=0,1&samples=11", http.StatusOK, 11},
+		{"Capped samples", false, "from=0,0&to=0,1&samples=100000", http.StatusOK, maxProfileSamples},
+		{"Multi-leg path", false, "point=0,0&point=0,0.5&point=0.5,0.5&samples=5", http.StatusOK, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elev := &slopeElevation{}
+			h := NewTerrainHandler(elev)
+			if tt.nilElev {
+				h = NewTerrainHandler(nil)
+			}
+
+			req := httptest.NewRequest("GET", "/api/terrain/profile?"+tt.query, http.NoBody)
+			w := httptest.NewRecorder()
+			h.HandleProfile(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, w.Code, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var resp ProfileResponse
+			if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Samples) != tt.wantSamples {
+				t.Errorf("expected %d samples, got %d", tt.wantSamples, len(resp.Samples))
+			}
+			if elev.calls != tt.wantSamples {
+				t.Errorf("expected %d elevation lookups, got %d", tt.wantSamples, elev.calls)
+			}
+		})
+	}
+}
+
+func TestTerrainHandler_ProfileSampling(t *testing.T) {
+	h := NewTerrainHandler(&slopeElevation{})
+
+	t.Run("Evenly spaced along the great circle", func(t *testing.T) {
+		resp := h.profile(mustPath(t, "0,0", "0,1"), 11)
+		if resp.DistanceM < 111000 || resp.DistanceM > 111400 {
+			t.Errorf("expected ~111.2km, got %.0fm", resp.DistanceM)
+		}
+		for i, s := range resp.Samples {
+			want := resp.DistanceM * float64(i) / 10
+			if diff := s.DistanceM - want; diff > 1 || diff < -1 {
+				t.Errorf("sample %d: expected distance %.0f, got %.0f", i, want, s.DistanceM)
+			}
+			if s.ElevationM == nil {
+				t.Fatalf("sample %d: expected an elevation", i)
+			}
+			if wantElev := int16(s.Lon * 1000); *s.ElevationM != wantElev {
+				t.Errorf("sample %d: expected elevation %d, got %d", i, wantElev, *s.ElevationM)
+			}
+		}
+		last := resp.Samples[len(resp.Samples)-1]
+		if last.Lat != 0 || last.Lon != 1 {
+			t.Errorf("expected the last sample on the end point, got %.4f,%.4f", last.Lat, last.Lon)
+		}
+	})
+
+	t.Run("Follows each leg of a path", func(t *testing.T) {
+		resp := h.profile(mustPath(t, "0,0", "0,0.5", "0.5,0.5"), 3)
+		mid := resp.Samples[1]
+		if mid.Lat > 0.001 || mid.Lon < 0.499 || mid.Lon > 0.501 {
+			t.Errorf("expected the midpoint on the corner, got %.4f,%.4f", mid.Lat, mid.Lon)
+		}
+	})
+
+	t.Run("Failed lookups are null", func(t *testing.T) {
+		resp := h.profile(mustPath(t, "0,-0.1", "0,0.1"), 3)
+		if resp.Samples[0].ElevationM != nil {
+			t.Error("expected no elevation where the lookup failed")
+		}
+		if resp.Samples[2].ElevationM == nil {
+			t.Error("expected an elevation where data exists")
+		}
+	})
+}
+
+func mustPath(t *testing.T, points ...string) []geo.Point {
+	t.Helper()
+	path := make([]geo.Point, 0, len(points))
+	for _, s := range points {
+		p, err := parseLatLon(s)
+		if err != nil {
+			t.Fatalf("bad test point %q: %v", s, err)
+		}
+		path = append(path, p)
+	}
+	return path
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"phileasgo/pkg/tour"
+)
+
+// TourHandler serves the guided tour endpoints.
+type TourHandler struct {
+	tour *tour.Tour
+}
+
+// NewTourHandler creates a new TourHandler.
+func NewTourHandler(t *tour.Tour) *TourHandler {
+	return &TourHandler{tour: t}
+}
+
+// TourRequest starts a tour with the given stops, in order.
+type TourRequest struct {
+	Stops []string `json:"stops"` // Wikidata QIDs
+}
+
+// HandleStatus handles GET /api/tour.
+func (h *TourHandler) HandleStatus(w http.ResponseWriter, r *http.Request) {
+	h.writeStatus(w)
+}
+
+// HandleStart handles POST /api/tour, replacing any running tour.
+func (h *TourHandler) HandleStart(w http.ResponseWriter, r *http.Request) {
+	var req TourRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.tour.Start(req.Stops); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.writeStatus(w)
+}
+
+// HandleSkip handles POST /api/tour/skip, moving on to the next stop.
+func (h *TourHandler) HandleSkip(w http.ResponseWriter, r *http.Request) {
+	h.tour.Skip()
+	h.writeStatus(w)
+}
+
+// HandleClear handles DELETE /api/tour; normal narration resumes.
+func (h *TourHandler) HandleClear(w http.ResponseWriter, r *http.Request) {
+	h.tour.Clear()
+	h.writeStatus(w)
+}
+
+func (h *TourHandler) writeStatus(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.tour.Status()); err != nil {
+		slog.Error("Failed to encode response", "error", err)
+	}
+}
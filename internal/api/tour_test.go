@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"phileasgo/pkg/tour"
+)
+
+func TestTourHandler(t *testing.T) {
+	h := NewTourHandler(tour.New())
+
+	status := func(w *httptest.ResponseRecorder) tour.Status {
+		t.Helper()
+		var st tour.Status
+		if err := json.NewDecoder(w.Body).Decode(&st); err != nil {
+			t.Fatalf("failed to decode status: %v", err)
+		}
+		return st
+	}
+
+	w := httptest.NewRecorder()
+	h.HandleStart(w, httptest.NewRequest("POST", "/api/tour", strings.NewReader(`{"stops":["Q1","Q2"]}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if st := status(w); !st.Active || st.Current != "Q1" || st.Remaining != 2 {
+		t.Errorf("unexpected status after start: %+v", st)
+	}
+
+	w = httptest.NewRecorder()
+	h.HandleSkip(w, httptest.NewRequest("POST", "/api/tour/skip", http.NoBody))
+	if st := status(w); st.Current != "Q2" || st.Index != 1 || st.Remaining != 1 {
+		t.Errorf("unexpected status after skip: %+v", st)
+	}
+
+	w = httptest.NewRecorder()
+	h.HandleClear(w, httptest.NewRequest("DELETE", "/api/tour", http.NoBody))
+	if st := status(w); st.Active {
+		t.Errorf("expected no tour after clear, got %+v", st)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"Malformed body", `{"stops":`},
+		{"No stops", `{"stops":[]}`},
+		{"Not a QID", `{"stops":["Q1","Eiffel Tower"]}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.HandleStart(w, httptest.NewRequest("POST", "/api/tour", strings.NewReader(tt.body)))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("expected 400, got %d", w.Code)
+			}
+		})
+	}
+}
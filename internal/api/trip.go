@@ -1,17 +1,20 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/session"
 	"phileasgo/pkg/store"
 )
 
 // SessionProvider provides access to session state.
 type SessionProvider interface {
 	GetEvents() []model.TripEvent
+	Current(ctx context.Context) session.Snapshot
 }
 
 // persistedSession mirrors the session.PersistentState for JSON unmarshalling.
@@ -61,3 +64,14 @@ func (h *TripHandler) HandleEvents(w http.ResponseWriter, r *http.Request) {
 		slog.Error("Failed to encode trip events", "error", err)
 	}
 }
+
+// HandleCurrent returns the live session state, including a session
+// restored after a restart. Before the flight it reports an inactive
+// session rather than an error.
+// GET /api/session/current
+func (h *TripHandler) HandleCurrent(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.session.Current(r.Context())); err != nil {
+		slog.Error("Failed to encode current session", "error", err)
+	}
+}
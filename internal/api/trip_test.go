@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/session"
+)
+
+type mockSessionProvider struct {
+	snap session.Snapshot
+}
+
+func (m *mockSessionProvider) GetEvents() []model.TripEvent { return nil }
+
+func (m *mockSessionProvider) Current(ctx context.Context) session.Snapshot { return m.snap }
+
+func TestTripHandler_HandleCurrent(t *testing.T) {
+	tests := []struct {
+		name       string
+		snap       session.Snapshot
+		wantActive bool
+	}{
+		{"No session before the flight", session.Snapshot{Message: "no active session", Stage: "parked"}, false},
+		{"Active session", session.Snapshot{Active: true, Airborne: true, Stage: "cruise", NarratedCount: 3}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &TripHandler{session: &mockSessionProvider{snap: tt.snap}}
+			rec := httptest.NewRecorder()
+			h.HandleCurrent(rec, httptest.NewRequest(http.MethodGet, "/api/session/current", http.NoBody))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rec.Code)
+			}
+			var got map[string]any
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got["active"] != tt.wantActive || got["stage"] != tt.snap.Stage {
+				t.Errorf("unexpected payload: %v", got)
+			}
+		})
+	}
+}
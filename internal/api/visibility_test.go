@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/store"
 	"phileasgo/pkg/terrain"
@@ -63,12 +64,18 @@ func (m *visMockStore) GetState(ctx context.Context, key string) (string, bool)
 	return "", false
 }
 
-type visMockCoverage struct{}
+type visMockCoverage struct {
+	cells []wikidata.DensityCell
+}
 
 func (m *visMockCoverage) GetGlobalCoverage(ctx context.Context) ([]wikidata.CachedTile, error) {
 	return nil, nil
 }
 
+func (m *visMockCoverage) GetDensityCells(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]wikidata.DensityCell, error) {
+	return m.cells, nil
+}
+
 func TestHandleMask(t *testing.T) {
 	// Setup Calculator with Test Manager
 	mgr := visibility.NewManagerForTest([]visibility.AltitudeRow{
@@ -159,3 +166,63 @@ func TestCalculateVisibilityPolygon_RefactorCheck(t *testing.T) {
 	// 	}
 	// }
 }
+
+func TestHandleDensity(t *testing.T) {
+	cov := &visMockCoverage{cells: []wikidata.DensityCell{
+		{Index: "a", Lat: 10, Lon: 20, Weight: 7, Corners: []geo.Point{{Lat: 10, Lon: 20}, {Lat: 10.1, Lon: 20}, {Lat: 10.1, Lon: 20.1}}},
+		{Index: "b", Lat: 11, Lon: 21, Weight: 2},
+	}}
+	h := NewVisibilityHandler(nil, nil, nil, nil, cov)
+
+	tests := []struct {
+		name       string
+		query      string
+		wantStatus int
+	}{
+		{name: "Valid bounds", query: "?min_lat=9&max_lat=12&min_lon=19&max_lon=22", wantStatus: http.StatusOK},
+		{name: "Missing bounds", query: "?min_lat=9", wantStatus: http.StatusBadRequest},
+		{name: "Invalid bounds", query: "?min_lat=x&max_lat=12&min_lon=19&max_lon=22", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.HandleDensity(rec, httptest.NewRequest("GET", "/api/map/density"+tt.query, http.NoBody))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var fc struct {
+				Type     string `json:"type"`
+				Features []struct {
+					Geometry struct {
+						Type string `json:"type"`
+					} `json:"geometry"`
+					Properties map[string]any `json:"properties"`
+				} `json:"features"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &fc); err != nil {
+				t.Fatalf("invalid GeoJSON: %v", err)
+			}
+			if fc.Type != "FeatureCollection" || len(fc.Features) != 2 {
+				t.Fatalf("unexpected collection: %+v", fc)
+			}
+			if fc.Features[0].Geometry.Type != "Polygon" || fc.Features[1].Geometry.Type != "Point" {
+				t.Errorf("unexpected geometries: %s, %s", fc.Features[0].Geometry.Type, fc.Features[1].Geometry.Type)
+			}
+			if w, _ := fc.Features[0].Properties["weight"].(float64); w != 7 {
+				t.Errorf("weight = %v, want 7", fc.Features[0].Properties["weight"])
+			}
+		})
+	}
+
+	t.Run("Provider without density support", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		NewVisibilityHandler(nil, nil, nil, nil, nil).HandleDensity(rec, httptest.NewRequest("GET", "/api/map/density", http.NoBody))
+		if rec.Code != http.StatusNotImplemented {
+			t.Errorf("status = %d, want %d", rec.Code, http.StatusNotImplemented)
+		}
+	})
+}
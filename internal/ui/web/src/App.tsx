@@ -52,6 +52,7 @@ function App() {
   const [settlementLabelLimit, setSettlementLabelLimit] = useState(5);
   const [settlementTier, setSettlementTier] = useState(3);
   const [settlementCategories, setSettlementCategories] = useState<string[]>([]);
+  const [categoryLabels, setCategoryLabels] = useState<Record<string, string>>({});
   const [narrationFrequency, setNarrationFrequency] = useState(3);
   const [textLength, setTextLength] = useState(3);
   const [autoNarrate, setAutoNarrate] = useState(true);
@@ -265,6 +266,7 @@ function App() {
           setSettlementLabelLimit(data.settlement_label_limit ?? 5);
           setSettlementTier(data.settlement_tier ?? 3);
           if (data.settlement_categories) setSettlementCategories(data.settlement_categories);
+          if (data.category_labels) setCategoryLabels(data.category_labels);
           if (data.beacon_max_targets !== undefined) setBeaconMaxTargets(data.beacon_max_targets);
           if (data.paper_opacity_clear !== undefined) setPaperOpacityClear(data.paper_opacity_clear);
           if (data.paper_opacity_fog !== undefined) setPaperOpacityFog(data.paper_opacity_fog);
@@ -485,6 +487,7 @@ function App() {
             pois={pois}
             currentTitle={narratorStatus?.current_title}
             currentType={narratorStatus?.current_type}
+            categoryLabels={categoryLabels}
           />
         )}
 
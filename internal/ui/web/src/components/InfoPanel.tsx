@@ -228,6 +228,16 @@ export const InfoPanel = ({
                                         </div>
                                     ))}
                                     {activeDataProviders.map(([key, data]) => renderProvider(key, data))}
+                                    {stats.cost && (stats.cost.session_usd > 0 || !!stats.cost.budget_usd) && (
+                                        <div className="flex-card stat-card" key="session-cost">
+                                            <div className="role-header">SESSION COST</div>
+                                            <div className="role-num-lg" style={{ color: stats.cost.over_budget ? 'var(--error)' : undefined }}>
+                                                ${stats.cost.session_usd.toFixed(2)}
+                                                {stats.cost.budget_usd ? <span style={{ color: 'var(--muted)', fontSize: '0.6em' }}> / ${stats.cost.budget_usd.toFixed(2)}</span> : null}
+                                            </div>
+                                            {stats.cost.over_budget && <span className="role-label" style={{ color: 'var(--error)' }}>Budget reached: auto-narration paused</span>}
+                                        </div>
+                                    )}
                                 </div>
                             );
                         })()}
@@ -11,6 +11,7 @@ interface POIInfoPanelProps {
     pois: POI[];  // Fresh POI list from polling
     currentTitle?: string;
     currentType?: string;
+    categoryLabels?: Record<string, string>;  // Localized names by lowercase category key
 }
 
 const getColor = (score: number) => {
@@ -44,7 +45,7 @@ const formatTimeAgo = (dateStr: string) => {
 };
 
 export const POIInfoPanel = ({
-    poi, pois, currentTitle, currentType,
+    poi, pois, currentTitle, currentType, categoryLabels = {},
 }: POIInfoPanelProps) => {
     const [thumbnailUrl, setThumbnailUrl] = useState<string | null>(null);
     const [strategy, setStrategy] = useState<'min_skew' | 'uniform' | 'max_skew'>('min_skew');
@@ -230,7 +231,7 @@ export const POIInfoPanel = ({
                         </div>
                     )}
                     <div className="role-label" style={{ marginBottom: '8px' }}>
-                        {poi.category}
+                        {categoryLabels[poi.category?.toLowerCase()] || poi.category}
                         {poi.specific_category && poi.specific_category !== poi.category && (
                             <span className="role-text-sm" style={{ opacity: 0.7 }}> ({poi.specific_category})</span>
                         )}
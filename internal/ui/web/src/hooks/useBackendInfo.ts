@@ -11,6 +11,11 @@ export interface BackendStats {
         hit_rate?: number;
     }>;
     llm_fallback?: string[];
+    cost?: {
+        session_usd: number;
+        budget_usd?: number;
+        over_budget: boolean;
+    };
     tracking?: {
         active_pois: number;
     };
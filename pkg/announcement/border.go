@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"math"
 	"time"
 
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/sim"
@@ -29,11 +31,25 @@ type Border struct {
 	repeatCooldowns map[string]time.Time
 	checkCooldown   time.Duration
 
+	// Country announced ahead of the crossing; the crossing itself is then
+	// only logged so the narration doesn't fire twice.
+	preannouncedTo string
+	preannouncedAt time.Time
+
 	// Transient state for the current generation
-	pendingFrom string
-	pendingTo   string
+	pendingFrom       string
+	pendingTo         string
+	pendingDistanceKM int
 }
 
+const (
+	// preAnnounceStepM is the sampling interval along the heading.
+	preAnnounceStepM = 1000.0
+	// preAnnounceConfirmM is how far past the boundary the track must stay
+	// in the new country; a path that only clips a corner is not announced.
+	preAnnounceConfirmM = 2000.0
+)
+
 func NewBorder(cfg *config.Config, geo LocationProvider, dp DataProvider, events EventRecorder) *Border {
 	b := &Border{
 		Base:            NewBase("border", model.NarrativeTypeBorder, true, dp, events), // BY DESIGN: repeatable: true
@@ -67,9 +83,20 @@ func (b *Border) ShouldGenerate(t *sim.Telemetry) bool {
 	from, to, triggered := b.checkCrossing(&curr)
 
 	if !triggered {
+		b.lastLocation = curr
+		return b.checkAhead(t, &curr)
+	}
+
+	if b.wasPreannounced(&curr) {
+		slog.Debug("Border: Crossing already announced ahead", "from", from, "to", to)
+		b.preannouncedTo = ""
+		b.logCrossing(from, to)
 		b.lastLocation = curr
 		return false
 	}
+	if curr.CountryCode != b.lastLocation.CountryCode {
+		b.preannouncedTo = ""
+	}
 
 	// 5. Trigger Logic & Cooldowns
 	if from == "XZ" {
@@ -87,18 +114,11 @@ func (b *Border) ShouldGenerate(t *sim.Telemetry) bool {
 	slog.Info("Border: Crossing detected", "from", from, "to", to)
 	b.pendingFrom = from
 	b.pendingTo = to
+	b.pendingDistanceKM = 0
 	b.lastAnnounce = time.Now()
 	b.repeatCooldowns[fmt.Sprintf("%s->%s", from, to)] = time.Now()
 
-	// Direct log to event history (Phase 3)
-	if b.Events != nil {
-		b.Events.AddEvent(&model.TripEvent{
-			Timestamp: time.Now(),
-			Type:      "activity",
-			Title:     "Border Crossing",
-			Summary:   fmt.Sprintf("Moved from %s to %s", from, to),
-		})
-	}
+	b.logCrossing(from, to)
 
 	// [NEW] If user is paused, we ONLY log. We don't queue or create a script/audio.
 	if b.provider.IsUserPaused() {
@@ -145,6 +165,89 @@ func (b *Border) checkCrossing(curr *model.LocationInfo) (from, to string, trigg
 	return "", "", false
 }
 
+// logCrossing records the crossing in the trip event history.
+func (b *Border) logCrossing(from, to string) {
+	if b.Events == nil {
+		return
+	}
+	b.Events.AddEvent(&model.TripEvent{
+		Timestamp: time.Now(),
+		Type:      "activity",
+		Title:     "Border Crossing",
+		Summary:   fmt.Sprintf("Moved from %s to %s", from, to),
+	})
+}
+
+// wasPreannounced reports whether the country just entered was announced
+// ahead of time. The claim expires with the repeat cooldown, so a pre-announced
+// crossing that never happened doesn't silence a much later one.
+func (b *Border) wasPreannounced(curr *model.LocationInfo) bool {
+	if b.preannouncedTo == "" || curr.CountryCode != b.preannouncedTo {
+		return false
+	}
+	return time.Since(b.preannouncedAt) < time.Duration(b.cfg.Narrator.Border.CooldownRepeat)
+}
+
+// checkAhead looks for a country boundary along the current heading and
+// queues a pre-announcement when one is within the configured lead distance.
+func (b *Border) checkAhead(t *sim.Telemetry, curr *model.LocationInfo) bool {
+	bc := b.cfg.Narrator.Border
+	if !bc.PreAnnounce || bc.PreAnnounceAt <= 0 || t.IsOnGround || curr.CountryCode == "" {
+		return false
+	}
+
+	to, distM, found := b.findCrossingAhead(t, curr.CountryCode, float64(bc.PreAnnounceAt))
+	if !found || to == b.preannouncedTo {
+		return false
+	}
+
+	from := curr.CountryCode
+	if from == "XZ" {
+		from = "International Waters"
+	}
+	if b.isCooldownActive(from, to) || b.provider.IsUserPaused() {
+		return false
+	}
+
+	distKM := max(1, int(math.Round(distM/1000.0)))
+	slog.Info("Border: Crossing ahead", "from", from, "to", to, "distance_km", distKM)
+	b.pendingFrom = from
+	b.pendingTo = to
+	b.pendingDistanceKM = distKM
+	b.preannouncedTo = to
+	b.preannouncedAt = time.Now()
+	b.lastAnnounce = time.Now()
+	b.repeatCooldowns[fmt.Sprintf("%s->%s", from, to)] = time.Now()
+
+	b.Reset()
+	return true
+}
+
+// findCrossingAhead samples the track ahead for the first point outside the
+// current country. The crossing only counts if the track is still in the new
+// country preAnnounceConfirmM further on, which rules out grazing a border.
+// International waters are never announced ahead.
+func (b *Border) findCrossingAhead(t *sim.Telemetry, country string, leadM float64) (to string, distM float64, found bool) {
+	pos := geo.Point{Lat: t.Latitude, Lon: t.Longitude}
+	for d := preAnnounceStepM; d <= leadM; d += preAnnounceStepM {
+		p := geo.DestinationPoint(pos, d, t.Heading)
+		next := b.geo.GetLocation(p.Lat, p.Lon).CountryCode
+		if next == country {
+			continue
+		}
+		if next == "" || next == "XZ" {
+			return "", 0, false
+		}
+		q := geo.DestinationPoint(pos, d+preAnnounceConfirmM, t.Heading)
+		if b.geo.GetLocation(q.Lat, q.Lon).CountryCode != next {
+			slog.Debug("Border: Track grazes border ahead, not announcing", "country", next, "distance_m", d)
+			return "", 0, false
+		}
+		return next, d, true
+	}
+	return "", 0, false
+}
+
 func (b *Border) isCooldownActive(from, to string) bool {
 	// Global Cooldown
 	cooldownAny := time.Duration(b.cfg.Narrator.Border.CooldownAny)
@@ -174,6 +277,7 @@ func (b *Border) GetPromptData(t *sim.Telemetry) (any, error) {
 
 	pd["From"] = b.pendingFrom
 	pd["To"] = b.pendingTo
+	pd["DistanceKM"] = b.pendingDistanceKM // 0 for a crossing that just happened
 	pd["Type"] = "border"
 	pd["MaxWords"] = 30 // Narrative should be concise
 
@@ -190,4 +294,6 @@ func (b *Border) ResetSession(ctx context.Context) {
 	b.lastCheck = time.Time{}
 	b.lastAnnounce = time.Time{}
 	b.repeatCooldowns = make(map[string]time.Time)
+	b.preannouncedTo = ""
+	b.preannouncedAt = time.Time{}
 }
@@ -215,3 +215,75 @@ func TestBorder_RegionSuppression_NoCity(t *testing.T) {
 		t.Error("Expected no trigger returning to original region if coming from suppressed region")
 	}
 }
+
+// lonBorderGeo places the FR/DE border at a meridian. A non-zero bumpEnd
+// turns DE into a narrow strip [border, bumpEnd) with FR beyond it.
+type lonBorderGeo struct {
+	border  float64
+	bumpEnd float64
+}
+
+func (m *lonBorderGeo) GetLocation(lat, lon float64) model.LocationInfo {
+	if lon < m.border || (m.bumpEnd > 0 && lon >= m.bumpEnd) {
+		return model.LocationInfo{CountryCode: "FR", Zone: "land"}
+	}
+	return model.LocationInfo{CountryCode: "DE", Zone: "land"}
+}
+
+func TestBorder_PreAnnounce(t *testing.T) {
+	newBorder := func(enabled bool, g LocationProvider) (*Border, *mockDP) {
+		cfg := config.DefaultConfig()
+		cfg.Narrator.Border.PreAnnounce = enabled
+		cfg.Narrator.Border.PreAnnounceAt = config.Distance(10000)
+		cfg.Narrator.Border.CooldownAny = 0
+		dp := &mockDP{}
+		b := NewBorder(cfg, g, dp, dp)
+		b.checkCooldown = 0
+		b.lastLocation = model.LocationInfo{CountryCode: "FR", Zone: "land"}
+		return b, dp
+	}
+	// On the equator 0.01° of longitude is ~1.1 km; heading east.
+	at := func(lon float64) *sim.Telemetry {
+		return &sim.Telemetry{Latitude: 0, Longitude: lon, Heading: 90}
+	}
+
+	t.Run("Announces ahead and not again at the crossing", func(t *testing.T) {
+		b, dp := newBorder(true, &lonBorderGeo{border: 1.0})
+
+		if !b.ShouldGenerate(at(0.95)) {
+			t.Fatal("expected pre-announcement ~5.5 km before the border")
+		}
+		if b.pendingTo != "DE" || b.pendingDistanceKM != 6 {
+			t.Errorf("got to=%q distance=%d km, want DE at 6 km", b.pendingTo, b.pendingDistanceKM)
+		}
+		if b.ShouldGenerate(at(0.97)) {
+			t.Error("expected no second pre-announcement for the same country")
+		}
+		if b.ShouldGenerate(at(1.01)) {
+			t.Error("expected the crossing itself to stay silent after a pre-announcement")
+		}
+		if len(dp.events) != 1 {
+			t.Errorf("expected the crossing to be logged once, got %d events", len(dp.events))
+		}
+	})
+
+	t.Run("Grazing a border is not announced", func(t *testing.T) {
+		b, _ := newBorder(true, &lonBorderGeo{border: 1.0, bumpEnd: 1.01})
+		if b.ShouldGenerate(at(0.95)) {
+			t.Error("expected no pre-announcement when the track only clips the border")
+		}
+	})
+
+	t.Run("Disabled announces only at the crossing", func(t *testing.T) {
+		b, _ := newBorder(false, &lonBorderGeo{border: 1.0})
+		if b.ShouldGenerate(at(0.95)) {
+			t.Error("expected no pre-announcement when disabled")
+		}
+		if !b.ShouldGenerate(at(1.01)) {
+			t.Fatal("expected the crossing announcement")
+		}
+		if b.pendingDistanceKM != 0 {
+			t.Errorf("expected no distance for a crossing, got %d", b.pendingDistanceKM)
+		}
+	})
+}
@@ -2,17 +2,33 @@ package announcement
 
 import (
 	"context"
+	"math"
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/sim"
 	"time"
 )
 
+// FlightStats provides the session totals quoted in the debrief. The session
+// manager implements it.
+type FlightStats interface {
+	NarratedCount() int
+	DistanceFlown() float64 // Meters
+}
+
 type Debriefing struct {
 	*Base
 	cfg             *config.Config
 	dp              DataProvider
+	stats           FlightStats
 	lastGeneratedAt time.Time
+
+	// Start of the current continuous stay on the ground at the home
+	// airport; zero while airborne or elsewhere.
+	homeGroundSince time.Time
+	homeSettled     bool
+	homeDebriefed   bool // The current home stay was debriefed; cleared when it ends
 }
 
 func NewDebriefing(cfg *config.Config, dp DataProvider, events EventRecorder) *Debriefing {
@@ -21,6 +37,9 @@ func NewDebriefing(cfg *config.Config, dp DataProvider, events EventRecorder) *D
 		cfg:  cfg,
 		dp:   dp,
 	}
+	if stats, ok := events.(FlightStats); ok {
+		d.stats = stats
+	}
 	d.SetTwoPass(true)
 	return d
 }
@@ -32,19 +51,24 @@ func (a *Debriefing) Title() string {
 // ShouldGenerate returns true if we are on the ground (landed, taxi, hold)
 // AND we have been airborne for at least 5 minutes in this session
 // AND we haven't already generated a debriefing for this specific flight leg.
+// With a home airport configured, settling inside its radius also counts as
+// being on the ground, once per stay, whatever the detected flight stage.
 func (a *Debriefing) ShouldGenerate(t *sim.Telemetry) bool {
+	// Track the ground dwell on every tick, so it is current once we go idle.
+	atHome := a.updateHomeDwell(t)
+
 	if a.Status() != StatusIdle {
 		return false
 	}
 
-	// 1. Check if we are in a "post-flight" stage
-	isLanded := t.FlightStage == sim.StageLanded ||
-		t.FlightStage == sim.StageTaxi ||
-		t.FlightStage == sim.StageHold ||
-		t.FlightStage == sim.StageParked
-
-	if !isLanded {
-		return false
+	// 1. Check if we are in a "post-flight" stage, or settled at home
+	if !isPostFlightStage(t.FlightStage) {
+		a.mu.Lock()
+		homeDue := atHome && !a.homeDebriefed
+		a.mu.Unlock()
+		if !homeDue {
+			return false
+		}
 	}
 
 	// 2. Check if we actually flew (TakeOff or Climb started > 5 mins ago)
@@ -76,19 +100,76 @@ func (a *Debriefing) ShouldGenerate(t *sim.Telemetry) bool {
 	return true
 }
 
+func isPostFlightStage(stage string) bool {
+	return stage == sim.StageLanded ||
+		stage == sim.StageTaxi ||
+		stage == sim.StageHold ||
+		stage == sim.StageParked
+}
+
+// updateHomeDwell reports whether the aircraft has been on the ground inside
+// the home airport radius for the configured dwell. Leaving the ground or the
+// radius restarts the dwell, so a touch-and-go never qualifies.
+func (a *Debriefing) updateHomeDwell(t *sim.Telemetry) bool {
+	home := a.cfg.Session.HomeAirport
+	if !home.Enabled {
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	dist := geo.Distance(geo.Point{Lat: t.Latitude, Lon: t.Longitude}, geo.Point{Lat: home.Lat, Lon: home.Lon})
+	if !t.IsOnGround || dist > float64(home.Radius) {
+		a.homeGroundSince = time.Time{}
+		a.homeSettled = false
+		a.homeDebriefed = false
+		return false
+	}
+	if a.homeGroundSince.IsZero() {
+		a.homeGroundSince = time.Now()
+	}
+	a.homeSettled = time.Since(a.homeGroundSince) >= time.Duration(home.Dwell)
+	return a.homeSettled
+}
+
 // ShouldPlay returns true once we are settled (Taxi or Hold) or Parked.
 // We don't want to play right during the high-workload Landed stage.
+// A settled home arrival has already waited out the landing roll.
 func (a *Debriefing) ShouldPlay(t *sim.Telemetry) bool {
-	return t.FlightStage == sim.StageTaxi || t.FlightStage == sim.StageHold || t.FlightStage == sim.StageParked
+	if t.FlightStage == sim.StageTaxi || t.FlightStage == sim.StageHold || t.FlightStage == sim.StageParked {
+		return true
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.homeSettled && t.IsOnGround
+}
+
+// PromptTemplate implements PromptTemplater: the debrief recaps the flight
+// from its own prompt.
+func (a *Debriefing) PromptTemplate() string {
+	return "announcement/debrief.tmpl"
 }
 
 func (a *Debriefing) GetPromptData(t *sim.Telemetry) (any, error) {
 	// Mark as generated now to prevent immediate re-triggering if generation takes time
 	a.mu.Lock()
 	a.lastGeneratedAt = time.Now()
+	a.homeDebriefed = a.homeSettled
 	a.mu.Unlock()
 
 	// Use AssembleGeneric to get standard context (Language, TripSummary, etc.)
 	data := a.dp.AssembleGeneric(context.Background(), t)
+	if data != nil {
+		var narrated int
+		var distM float64
+		if a.stats != nil {
+			narrated = a.stats.NarratedCount()
+			distM = a.stats.DistanceFlown()
+		}
+		data["NarratedCount"] = narrated
+		data["DistanceFlownKm"] = int(math.Round(distM / 1000.0))
+		data["DistanceFlownNm"] = int(math.Round(distM / 1852.0))
+	}
 	return data, nil
 }
@@ -1,7 +1,9 @@
 package announcement
 
 import (
+	"context"
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/sim"
 	"testing"
 	"time"
@@ -99,3 +101,137 @@ func TestDebriefing_Triggers(t *testing.T) {
 		// For now, only new takeoff or deep reset should clear it.
 	})
 }
+
+func TestDebriefing_HomeAirport(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Session.HomeAirport = config.HomeAirportConfig{
+		Enabled: true,
+		Lat:     48.35,
+		Lon:     11.78,
+		Radius:  config.Distance(5000),
+		Dwell:   config.Duration(30 * time.Second),
+	}
+
+	newDebrief := func() *Debriefing {
+		dp := &mockDP{}
+		dp.GetLastTransitionFunc = func(s string) time.Time {
+			if s == sim.StageTakeOff {
+				return time.Now().Add(-1 * time.Hour)
+			}
+			return time.Time{}
+		}
+		return NewDebriefing(cfg, dp, dp)
+	}
+	// The generic on-ground stage is not a post-flight stage, so only the
+	// home dwell can trigger the debrief there
+	home := func(onGround bool) *sim.Telemetry {
+		return &sim.Telemetry{Latitude: 48.36, Longitude: 11.79, IsOnGround: onGround, FlightStage: sim.StageOnGround}
+	}
+	settle := func(a *Debriefing) {
+		a.mu.Lock()
+		a.homeGroundSince = time.Now().Add(-time.Minute)
+		a.mu.Unlock()
+	}
+
+	t.Run("Landing elsewhere keeps the post-flight debrief", func(t *testing.T) {
+		a := newDebrief()
+		away := &sim.Telemetry{Latitude: 50.03, Longitude: 8.57, IsOnGround: true, FlightStage: sim.StageTaxi}
+		if !a.ShouldGenerate(away) {
+			t.Error("expected the usual debrief away from the home airport")
+		}
+		away.FlightStage = sim.StageOnGround
+		if a.ShouldGenerate(away) {
+			t.Error("expected no home debrief away from the home airport")
+		}
+	})
+
+	t.Run("Fires once after the ground dwell", func(t *testing.T) {
+		a := newDebrief()
+		if a.ShouldGenerate(home(true)) {
+			t.Fatal("expected no debrief before the dwell elapsed")
+		}
+		settle(a)
+		if !a.ShouldGenerate(home(true)) {
+			t.Fatal("expected a debrief after settling at home")
+		}
+		if !a.ShouldPlay(&sim.Telemetry{IsOnGround: true, FlightStage: sim.StageLanded}) {
+			t.Error("expected a settled home arrival to be playable")
+		}
+		a.GetPromptData(home(true))
+		if a.ShouldGenerate(home(true)) {
+			t.Error("expected a single debrief per leg")
+		}
+	})
+
+	t.Run("Home stay is debriefed once even after a new take-off time", func(t *testing.T) {
+		a := newDebrief()
+		a.ShouldGenerate(home(true))
+		settle(a)
+		if !a.ShouldGenerate(home(true)) {
+			t.Fatal("expected a debrief after settling at home")
+		}
+		a.GetPromptData(home(true))
+		// A stage flicker records a take-off without leaving the ground
+		a.mu.Lock()
+		a.lastGeneratedAt = time.Now().Add(-2 * time.Hour)
+		a.mu.Unlock()
+		if a.ShouldGenerate(home(true)) {
+			t.Error("expected no second debrief during the same home stay")
+		}
+	})
+
+	t.Run("Touch-and-go restarts the dwell", func(t *testing.T) {
+		a := newDebrief()
+		a.ShouldGenerate(home(true))
+		settle(a)
+		a.ShouldGenerate(home(false)) // Airborne again
+		if a.ShouldGenerate(home(true)) {
+			t.Error("expected the dwell to restart after a touch-and-go")
+		}
+	})
+}
+
+type mockFlightStats struct {
+	mockDP
+	narrated int
+	distance float64
+}
+
+func (m *mockFlightStats) NarratedCount() int     { return m.narrated }
+func (m *mockFlightStats) DistanceFlown() float64 { return m.distance }
+
+func TestDebriefing_GetPromptData(t *testing.T) {
+	dp := &mockDP{
+		AssembleGenericFunc: func(ctx context.Context, t *sim.Telemetry) prompt.Data {
+			return prompt.Data{"TripSummary": "* [10:00] CITY: Zurich - Old town"}
+		},
+	}
+
+	tests := []struct {
+		name         string
+		events       EventRecorder
+		wantNarrated int
+		wantKm       int
+		wantNm       int
+	}{
+		{"Session stats", &mockFlightStats{narrated: 7, distance: 185200}, 7, 185, 100},
+		{"No stats", dp, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewDebriefing(config.DefaultConfig(), dp, tt.events)
+			raw, err := a.GetPromptData(&sim.Telemetry{FlightStage: sim.StageParked})
+			if err != nil {
+				t.Fatalf("GetPromptData failed: %v", err)
+			}
+			data := raw.(prompt.Data)
+			if data["TripSummary"] == "" {
+				t.Error("expected the trip summary to be kept")
+			}
+			if data["NarratedCount"] != tt.wantNarrated || data["DistanceFlownKm"] != tt.wantKm || data["DistanceFlownNm"] != tt.wantNm {
+				t.Errorf("got narrated=%v km=%v nm=%v, want %d/%d/%d",
+					data["NarratedCount"], data["DistanceFlownKm"], data["DistanceFlownNm"], tt.wantNarrated, tt.wantKm, tt.wantNm)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package announcement
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/poi/ocean"
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/sim"
+)
+
+// WaterDetector reports the open water below a position; the ocean sentinel
+// implements it.
+type WaterDetector interface {
+	Check(lat, lon, minDistM float64) *ocean.Water
+}
+
+// OpenWater fills the silence over open sea, where there are no POIs, with a
+// remark about the water below.
+type OpenWater struct {
+	*Base
+	cfg      *config.Config
+	detector WaterDetector
+	provider DataProvider
+
+	lastCheck     time.Time
+	lastAnnounce  time.Time
+	checkCooldown time.Duration
+
+	// Transient state for the current generation
+	pending ocean.Water
+}
+
+func NewOpenWater(cfg *config.Config, detector WaterDetector, dp DataProvider, events EventRecorder) *OpenWater {
+	o := &OpenWater{
+		Base:          NewBase("open_water", model.NarrativeTypeOpenWater, true, dp, events), // BY DESIGN: repeatable: true
+		cfg:           cfg,
+		detector:      detector,
+		provider:      dp,
+		checkCooldown: 30 * time.Second, // Coastlines are far apart at cruise speed
+	}
+	o.SetUIMetadata("Open Water", "", "")
+	return o
+}
+
+func (o *OpenWater) ShouldGenerate(t *sim.Telemetry) bool {
+	owc := o.cfg.Narrator.OpenWater
+	if t.IsOnGround {
+		return false
+	}
+	if time.Since(o.lastCheck) < o.checkCooldown {
+		return false
+	}
+	o.lastCheck = time.Now()
+
+	if !o.lastAnnounce.IsZero() && time.Since(o.lastAnnounce) < time.Duration(owc.Cooldown) {
+		return false
+	}
+
+	w := o.detector.Check(t.Latitude, t.Longitude, float64(owc.MinDistance))
+	if w == nil {
+		return false
+	}
+	if w.Lake && !owc.Lakes {
+		slog.Debug("OpenWater: Over inland water, lakes disabled")
+		return false
+	}
+	if o.provider.IsUserPaused() {
+		return false
+	}
+
+	slog.Info("OpenWater: Over open water", "name", w.Name, "lake", w.Lake)
+	o.pending = *w
+	o.lastAnnounce = time.Now()
+	o.Reset()
+	return true
+}
+
+func (o *OpenWater) GetPromptData(t *sim.Telemetry) (any, error) {
+	pd := o.provider.AssembleGeneric(context.Background(), t)
+	if pd == nil {
+		pd = make(prompt.Data)
+	}
+
+	pd["WaterName"] = o.pending.Name
+	pd["Lake"] = o.pending.Lake
+	pd["MinDistanceKM"] = int(math.Round(float64(o.cfg.Narrator.OpenWater.MinDistance) / 1000.0))
+	pd["Type"] = "open_water"
+	pd["MaxWords"] = 60
+
+	return pd, nil
+}
+
+func (o *OpenWater) ShouldPlay(t *sim.Telemetry) bool {
+	return true
+}
+
+func (o *OpenWater) ResetSession(ctx context.Context) {
+	o.Base.Reset()
+	o.lastCheck = time.Time{}
+	o.lastAnnounce = time.Time{}
+	o.pending = ocean.Water{}
+}
@@ -0,0 +1,91 @@
+package announcement
+
+import (
+	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/poi/ocean"
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/sim"
+)
+
+type mockWaterDetector struct {
+	water   *ocean.Water
+	minDist float64
+}
+
+func (m *mockWaterDetector) Check(lat, lon, minDistM float64) *ocean.Water {
+	m.minDist = minDistM
+	return m.water
+}
+
+func TestOpenWater_ShouldGenerate(t *testing.T) {
+	tests := []struct {
+		name   string
+		water  *ocean.Water
+		lakes  bool
+		ground bool
+		paused bool
+		want   bool
+	}{
+		{name: "Open sea", water: &ocean.Water{Name: "North Sea"}, want: true},
+		{name: "Near land", water: nil, want: false},
+		{name: "On the ground", water: &ocean.Water{Name: "North Sea"}, ground: true, want: false},
+		{name: "Lake with lakes disabled", water: &ocean.Water{Lake: true}, want: false},
+		{name: "Lake with lakes enabled", water: &ocean.Water{Lake: true}, lakes: true, want: true},
+		{name: "User paused", water: &ocean.Water{Name: "North Sea"}, paused: true, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.OpenWater.Lakes = tt.lakes
+			det := &mockWaterDetector{water: tt.water}
+			dp := &mockDP{UserPaused: tt.paused}
+			o := NewOpenWater(cfg, det, dp, dp)
+
+			if got := o.ShouldGenerate(&sim.Telemetry{IsOnGround: tt.ground}); got != tt.want {
+				t.Errorf("ShouldGenerate() = %v, want %v", got, tt.want)
+			}
+			if !tt.ground && det.minDist != float64(cfg.Narrator.OpenWater.MinDistance) {
+				t.Errorf("expected the configured distance from land, got %v", det.minDist)
+			}
+		})
+	}
+}
+
+func TestOpenWater_Cooldown(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.OpenWater.Cooldown = config.Duration(time.Hour)
+	dp := &mockDP{}
+	o := NewOpenWater(cfg, &mockWaterDetector{water: &ocean.Water{Name: "North Sea"}}, dp, dp)
+	o.checkCooldown = 0
+
+	if !o.ShouldGenerate(&sim.Telemetry{}) {
+		t.Fatal("expected the first remark")
+	}
+	if o.ShouldGenerate(&sim.Telemetry{}) {
+		t.Error("expected the cooldown to suppress a second remark")
+	}
+
+	o.lastAnnounce = time.Now().Add(-2 * time.Hour)
+	if !o.ShouldGenerate(&sim.Telemetry{}) {
+		t.Error("expected a remark once the cooldown has passed")
+	}
+}
+
+func TestOpenWater_GetPromptData(t *testing.T) {
+	dp := &mockDP{}
+	o := NewOpenWater(config.DefaultConfig(), &mockWaterDetector{water: &ocean.Water{Name: "North Sea"}}, dp, dp)
+	o.ShouldGenerate(&sim.Telemetry{})
+
+	data, err := o.GetPromptData(&sim.Telemetry{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pd := data.(prompt.Data)
+	if pd["WaterName"] != "North Sea" || pd["Lake"] != false || pd["MinDistanceKM"] != 50 {
+		t.Errorf("unexpected prompt data: %v", pd)
+	}
+}
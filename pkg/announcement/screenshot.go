@@ -6,7 +6,9 @@ import (
 	"log/slog"
 
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/watcher"
 )
@@ -17,8 +19,14 @@ type Screenshot struct {
 	watcher     *watcher.Service
 	provider    DataProvider
 	currentPath string
+
+	// Scope of the current screenshot, resolved from the configured mode
+	mode string
 }
 
+// screenshotViewRadius bounds the POIs considered "in view" in POI mode.
+const screenshotViewRadius = 10000.0
+
 func NewScreenshot(cfg *config.Config, watcher *watcher.Service, dp DataProvider, events EventRecorder) *Screenshot {
 	return &Screenshot{
 		Base:     NewBase("screenshot", model.NarrativeTypeScreenshot, true, dp, events), // BY DESIGN: repeatable: true
@@ -51,28 +59,80 @@ func (s *Screenshot) GetPromptData(t *sim.Telemetry) (any, error) {
 	}
 
 	ctx := context.Background()
-	data := s.provider.AssembleGeneric(ctx, t)
+	var p *model.POI
+	s.mode, p = s.resolveMode(t)
+	s.SetPOI(nil)
+
+	var data prompt.Data
+	if s.mode == config.ScreenshotModePOI {
+		data = s.provider.AssemblePOI(ctx, p, t, prompt.StrategyUniform)
+		s.SetPOI(p)
+	} else {
+		data = s.provider.AssembleGeneric(ctx, t)
+	}
+	if data == nil {
+		data = make(prompt.Data)
+	}
 
 	// Location Context
 	loc := s.provider.GetLocation(t.Latitude, t.Longitude)
 	data["City"] = loc.CityName
 	data["Region"] = loc.Admin1Name
 	data["Country"] = loc.CountryCode
-
-	// Formatted Telemetry
+	data["ScreenshotMode"] = s.mode
 
 	// Length: Use Short Words setting
 	// Ideally we would apply the multiplier here, but we'll use the base config for simplicity
 	// until we expose the multiplier logic in DataProvider.
-	shortLimit := s.cfg.Narrator.NarrationLengthShortWords
-	if shortLimit <= 0 {
-		shortLimit = 50
+	limit := s.cfg.Narrator.NarrationLengthShortWords
+	if limit <= 0 {
+		limit = 50
+	}
+	if s.mode == config.ScreenshotModeEssay && s.cfg.Narrator.NarrationLengthLongWords > 0 {
+		limit = s.cfg.Narrator.NarrationLengthLongWords
 	}
-	data["MaxWords"] = shortLimit
+	data["MaxWords"] = limit
 
 	return data, nil
 }
 
+// resolveMode falls back to describing the image whenever the configured
+// scope can't be honoured for this screenshot. In POI mode it also returns
+// the POI it found, so the nearby POIs are only searched once.
+func (s *Screenshot) resolveMode(t *sim.Telemetry) (string, *model.POI) {
+	switch mode := s.cfg.Narrator.Screenshot.Mode; mode {
+	case config.ScreenshotModePOI:
+		if p := s.bestPOIInView(t); p != nil {
+			return mode, p
+		}
+		slog.Debug("Screenshot: No POI in view, describing the image instead")
+	case config.ScreenshotModeHeading:
+		if t.HasValidData {
+			return mode, nil
+		}
+		slog.Debug("Screenshot: Heading unknown, describing the image instead")
+	case config.ScreenshotModeEssay:
+		return mode, nil
+	}
+	return config.ScreenshotModeDescribe, nil
+}
+
+// bestPOIInView returns the highest scoring POI near the aircraft, preferring
+// those ahead of it when the heading is known.
+func (s *Screenshot) bestPOIInView(t *sim.Telemetry) *model.POI {
+	pos := geo.Point{Lat: t.Latitude, Lon: t.Longitude}
+	var best *model.POI
+	for _, p := range s.provider.GetPOIsNear(t.Latitude, t.Longitude, screenshotViewRadius) {
+		if t.HasValidData && !geo.IsAhead(pos, geo.Point{Lat: p.Lat, Lon: p.Lon}, t.Heading) {
+			continue
+		}
+		if best == nil || p.Score > best.Score {
+			best = p
+		}
+	}
+	return best
+}
+
 func (s *Screenshot) ShouldPlay(t *sim.Telemetry) bool {
 	return true
 }
@@ -85,8 +145,12 @@ func (s *Screenshot) ImagePath() string {
 	return s.currentPath
 }
 
-// RawPath returns the raw file system path for LLM image analysis.
+// RawPath returns the raw file system path for LLM image analysis. POI and
+// essay narrations are written from text alone, so they get no image.
 func (s *Screenshot) RawPath() string {
+	if s.mode == config.ScreenshotModePOI || s.mode == config.ScreenshotModeEssay {
+		return ""
+	}
 	return s.currentPath
 }
 
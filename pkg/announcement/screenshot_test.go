@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
 	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/watcher"
@@ -99,3 +100,96 @@ func TestScreenshot_Lifecycle(t *testing.T) {
 		}
 	})
 }
+
+func TestScreenshot_Modes(t *testing.T) {
+	tel := &sim.Telemetry{Latitude: 10, Longitude: 20, Heading: 90, HasValidData: true}
+	ahead := &model.POI{WikidataID: "Q1", Lat: 10, Lon: 20.05, Score: 5}
+	behind := &model.POI{WikidataID: "Q2", Lat: 10, Lon: 19.95, Score: 9}
+
+	tests := []struct {
+		name      string
+		mode      string
+		pois      []*model.POI
+		noHeading bool
+		wantMode  string
+		wantPOI   string
+		wantImage bool
+		wantWords int
+	}{
+		{name: "Describe", mode: config.ScreenshotModeDescribe, wantMode: "describe", wantImage: true, wantWords: 50},
+		{name: "Best POI ahead", mode: config.ScreenshotModePOI, pois: []*model.POI{ahead, behind}, wantMode: "poi", wantPOI: "Q1", wantWords: 50},
+		{name: "POI mode without POIs", mode: config.ScreenshotModePOI, wantMode: "describe", wantImage: true, wantWords: 50},
+		{name: "Region essay", mode: config.ScreenshotModeEssay, wantMode: "essay", wantWords: 200},
+		{name: "Heading", mode: config.ScreenshotModeHeading, wantMode: "heading", wantImage: true, wantWords: 50},
+		{name: "Heading unknown", mode: config.ScreenshotModeHeading, noHeading: true, wantMode: "describe", wantImage: true, wantWords: 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			w, _ := watcher.NewService([]string{tmpDir})
+			cfg := config.DefaultConfig()
+			cfg.Narrator.Screenshot.Mode = tt.mode
+
+			var assembledPOI *model.POI
+			lookups := 0
+			dp := &mockDP{
+				GetPOIsNearFunc: func(lat, lon, radius float64) []*model.POI {
+					lookups++
+					return tt.pois
+				},
+				AssemblePOIFunc: func(ctx context.Context, p *model.POI, tel *sim.Telemetry, strategy string) prompt.Data {
+					assembledPOI = p
+					return prompt.Data{}
+				},
+				AssembleGenericFunc: func(ctx context.Context, tel *sim.Telemetry) prompt.Data {
+					return prompt.Data{}
+				},
+			}
+			s := NewScreenshot(cfg, w, dp, dp)
+
+			imgPath := filepath.Join(tmpDir, "shot.png")
+			if err := os.WriteFile(imgPath, []byte("fake image"), 0644); err != nil {
+				t.Fatalf("Failed to create mock image: %v", err)
+			}
+			future := time.Now().Add(1 * time.Second)
+			if err := os.Chtimes(imgPath, future, future); err != nil {
+				t.Fatalf("Failed to change modTime: %v", err)
+			}
+			if !s.ShouldGenerate(tel) {
+				t.Fatal("expected the screenshot to trigger a narration")
+			}
+
+			st := *tel
+			st.HasValidData = !tt.noHeading
+			data, err := s.GetPromptData(&st)
+			if err != nil {
+				t.Fatalf("GetPromptData failed: %v", err)
+			}
+			pd := data.(prompt.Data)
+
+			if pd["ScreenshotMode"] != tt.wantMode {
+				t.Errorf("ScreenshotMode = %v, want %s", pd["ScreenshotMode"], tt.wantMode)
+			}
+			if pd["MaxWords"] != tt.wantWords {
+				t.Errorf("MaxWords = %v, want %d", pd["MaxWords"], tt.wantWords)
+			}
+			if tt.wantPOI != "" {
+				if assembledPOI == nil || assembledPOI.WikidataID != tt.wantPOI {
+					t.Errorf("expected POI %s to be assembled, got %+v", tt.wantPOI, assembledPOI)
+				}
+				if s.POI() != assembledPOI {
+					t.Error("expected the narrated POI to be attached to the announcement")
+				}
+			} else if assembledPOI != nil || s.POI() != nil {
+				t.Errorf("expected no POI narration, got %+v", assembledPOI)
+			}
+			if lookups > 1 {
+				t.Errorf("nearby POIs searched %d times, want at most once", lookups)
+			}
+			if got := s.RawPath() != ""; got != tt.wantImage {
+				t.Errorf("image attached = %v, want %v", got, tt.wantImage)
+			}
+		})
+	}
+}
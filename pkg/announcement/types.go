@@ -56,6 +56,12 @@ type SessionResettable interface {
 	ResetSession(ctx context.Context)
 }
 
+// PromptTemplater is an optional interface for Items whose prompt template is
+// not named after their narrative type.
+type PromptTemplater interface {
+	PromptTemplate() string
+}
+
 // EventRecorder defines the interface for logging trip events.
 type EventRecorder interface {
 	AddEvent(event *model.TripEvent)
@@ -0,0 +1,63 @@
+package audio
+
+import (
+	"log/slog"
+
+	"github.com/gopxl/beep/v2"
+)
+
+// SetNextChime replaces the configured chime for the next clip started by
+// Play, so the cue can tell the listener what kind of place follows.
+// "" keeps the default.
+func (m *Manager) SetNextChime(path string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextChime = path
+}
+
+// prependChimeLocked queues the pre-narration chime ahead of clip: cue if
+// set, else the configured one. skip is set for replays and for clips
+// crossfaded into a running narration, where a chime would interrupt rather
+// than announce.
+func (m *Manager) prependChimeLocked(clip beep.Streamer, cue string, skip bool) beep.Streamer {
+	if skip || m.config == nil {
+		return clip
+	}
+	c := m.config.AudioEffects.PreNarrationChime
+	if !c.Enabled {
+		return clip
+	}
+
+	track, format, ok := openChime(cue, c.Path)
+	if !ok {
+		return clip
+	}
+	m.closeChimeLocked()
+	m.chimeTrack = track
+
+	chime := NewSmoothVolume(beep.Resample(3, format.SampleRate, m.currentSampleRate, track), m.volume*c.Volume*m.clipLevelLocked())
+	return beep.Seq(chime, clip)
+}
+
+// openChime decodes the category cue, falling back to the default chime when
+// the cue is unset or unreadable: a broken cue still deserves an announcement.
+func openChime(cue, fallback string) (beep.StreamSeekCloser, beep.Format, bool) {
+	for _, path := range []string{cue, fallback} {
+		if path == "" {
+			continue
+		}
+		track, format, err := DecodeMedia(path)
+		if err == nil {
+			return track, format, true
+		}
+		slog.Warn("Audio: Pre-narration chime unavailable", "path", path, "error", err)
+	}
+	return nil, beep.Format{}, false
+}
+
+func (m *Manager) closeChimeLocked() {
+	if m.chimeTrack != nil {
+		m.chimeTrack.Close()
+		m.chimeTrack = nil
+	}
+}
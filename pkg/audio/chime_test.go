@@ -0,0 +1,94 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"phileasgo/pkg/config"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/wav"
+)
+
+// constant streams n samples at a fixed level.
+func constant(level float64, n int) beep.Streamer {
+	return beep.Take(n, beep.StreamerFunc(func(samples [][2]float64) (int, bool) {
+		for i := range samples {
+			samples[i] = [2]float64{level, level}
+		}
+		return len(samples), true
+	}))
+}
+
+func writeChime(t *testing.T, level float64, n int) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), fmt.Sprintf("chime_%.1f.wav", level))
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create chime: %v", err)
+	}
+	format := beep.Format{SampleRate: 48000, NumChannels: 2, Precision: 2}
+	if err := wav.Encode(f, constant(level, n), format); err != nil {
+		t.Fatalf("failed to encode chime: %v", err)
+	}
+	return path
+}
+
+func TestPrependChime(t *testing.T) {
+	const chimeLen, clipLen = 4800, 4800
+	path := writeChime(t, 0.8, chimeLen)
+	castle := writeChime(t, 0.6, chimeLen)
+	missing := filepath.Join(t.TempDir(), "missing.wav")
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		path      string
+		cue       string
+		skip      bool
+		wantChime float64 // Expected chime sample at half volume; 0 for no chime
+	}{
+		{name: "Enabled", enabled: true, path: path, wantChime: 0.4},
+		{name: "Disabled", path: path},
+		{name: "Replay skips the chime", enabled: true, path: path, skip: true},
+		{name: "Missing file plays the clip alone", enabled: true, path: missing},
+		{name: "Category cue replaces the default", enabled: true, path: path, cue: castle, wantChime: 0.3},
+		{name: "Category cue without a default", enabled: true, cue: castle, wantChime: 0.3},
+		{name: "Missing category cue falls back to the default", enabled: true, path: path, cue: missing, wantChime: 0.4},
+		{name: "Disabled ignores the category cue", path: path, cue: castle},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.NarratorConfig{}
+			cfg.AudioEffects.PreNarrationChime = config.ChimeConfig{Enabled: tt.enabled, Path: tt.path, Volume: 0.5}
+			m := New(cfg)
+			m.currentSampleRate = 48000
+
+			out := m.prependChimeLocked(constant(0.1, clipLen), tt.cue, tt.skip)
+			samples := make([][2]float64, chimeLen+clipLen+100)
+			n, _ := out.Stream(samples)
+			defer m.closeChimeLocked()
+
+			if tt.wantChime == 0 {
+				if n != clipLen || math.Abs(samples[0][0]-0.1) > 0.01 {
+					t.Errorf("expected the narration only, got %d samples starting at %.3f", n, samples[0][0])
+				}
+				return
+			}
+			if n != chimeLen+clipLen {
+				t.Fatalf("expected chime and narration (%d samples), got %d", chimeLen+clipLen, n)
+			}
+			// The chime plays first, at half the narration volume
+			if got := samples[chimeLen/2][0]; math.Abs(got-tt.wantChime) > 0.01 {
+				t.Errorf("chime sample = %.3f, want %.1f", got, tt.wantChime)
+			}
+			if got := samples[chimeLen+clipLen/2][0]; math.Abs(got-0.1) > 0.01 {
+				t.Errorf("narration sample = %.3f, want 0.1", got)
+			}
+		})
+	}
+}
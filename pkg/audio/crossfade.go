@@ -0,0 +1,169 @@
+package audio
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/speaker"
+)
+
+// duckFade is how quickly the ambience track dips under and recovers from a
+// narration. Slower than the narration fades so the change is not noticed.
+const duckFade = 300 * time.Millisecond
+
+// CrossfadeDuration returns how long the tail of a narration overlaps the
+// head of the next one. Zero means crossfading is disabled.
+func (m *Manager) CrossfadeDuration() time.Duration {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.crossfadeDuration()
+}
+
+func (m *Manager) crossfadeDuration() time.Duration {
+	if m.config == nil || m.config.AudioEffects.CrossfadeMs <= 0 {
+		return 0
+	}
+	return time.Duration(m.config.AudioEffects.CrossfadeMs) * time.Millisecond
+}
+
+// fadeOutLocked hands the current clip over to a crossfade: it keeps playing
+// on the speaker while fading to silence and is cut off once the fade is done.
+// Its onComplete is dropped because the incoming clip takes over the playback.
+func (m *Manager) fadeOutLocked(fade time.Duration) {
+	ctrl := m.ctrl
+	if m.streamer != nil {
+		speaker.Lock()
+		m.streamer.FadeTo(0, float64(m.currentSampleRate), fade)
+		speaker.Unlock()
+	}
+
+	if m.trackStreamer != nil {
+		m.outgoing = append(m.outgoing, m.trackStreamer)
+	}
+	m.ctrl = nil
+	m.streamer = nil
+	m.trackStreamer = nil
+	m.onComplete = nil
+
+	// A nil Streamer ends the Ctrl, which runs the clip's completion callback
+	// and releases its decoder rather than decoding silence until the end.
+	time.AfterFunc(fade, func() {
+		speaker.Lock()
+		ctrl.Streamer = nil
+		speaker.Unlock()
+	})
+}
+
+// finishClip runs when a clip has played out. Clips superseded by a later
+// Play only release their decoder; the current clip honours the pause between
+// narrations and then reports completion.
+func (m *Manager) finishClip(gen uint64, streamer beep.StreamSeekCloser) {
+	m.mu.Lock()
+	if gen != m.playGen {
+		owned := m.releaseOutgoingLocked(streamer)
+		m.mu.Unlock()
+		if owned {
+			streamer.Close()
+		}
+		return
+	}
+	m.duckAmbienceLocked(false)
+	m.mu.Unlock()
+
+	// Enforce pause_between_narrations if configured
+	if m.config != nil && m.config.PauseDuration > 0 {
+		time.Sleep(time.Duration(m.config.PauseDuration))
+	}
+
+	m.mu.Lock()
+	if gen != m.playGen {
+		// A new Play during the pause already stopped this clip and fired its callback
+		m.mu.Unlock()
+		return
+	}
+	m.ctrl = nil
+	m.isPaused = false
+	m.closeChimeLocked()
+	callback := m.onComplete
+	m.onComplete = nil
+	m.mu.Unlock()
+	streamer.Close()
+
+	if callback != nil {
+		callback()
+	}
+}
+
+// releaseOutgoingLocked removes a faded-out clip from the outgoing list and
+// reports whether it was still there, i.e. not already closed by Stop.
+func (m *Manager) releaseOutgoingLocked(s beep.StreamSeekCloser) bool {
+	for i, o := range m.outgoing {
+		if o == s {
+			m.outgoing = append(m.outgoing[:i], m.outgoing[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// startAmbienceLocked starts the looped ambience track on first use.
+// It needs the speaker, so it only runs once a narration initialised it.
+func (m *Manager) startAmbienceLocked() {
+	if m.ambienceTried || m.config == nil || m.config.AudioEffects.AmbiencePath == "" || !m.speakerInitialized {
+		return
+	}
+	m.ambienceTried = true // A broken file is reported once, not on every narration
+
+	path := m.config.AudioEffects.AmbiencePath
+	track, format, err := DecodeMedia(path)
+	if err != nil {
+		slog.Warn("Audio: Ambience track unavailable", "path", path, "error", err)
+		return
+	}
+	looped, err := beep.Loop2(track)
+	if err != nil {
+		track.Close()
+		slog.Warn("Audio: Ambience track cannot be looped", "path", path, "error", err)
+		return
+	}
+	resampled := beep.Resample(3, format.SampleRate, m.currentSampleRate, looped)
+
+	m.ambience = NewSmoothVolume(resampled, m.volume*m.config.AudioEffects.AmbienceVolume)
+	m.ambienceTrack = track
+	speaker.Play(m.ambience)
+	slog.Debug("Audio: Ambience track started", "path", path)
+}
+
+// duckAmbienceLocked lowers the ambience under a narration or restores it.
+func (m *Manager) duckAmbienceLocked(duck bool) {
+	if m.ambience == nil {
+		return
+	}
+	level := 1.0
+	if duck {
+		level = m.config.AudioEffects.DuckLevel
+	}
+	speaker.Lock()
+	m.ambience.FadeTo(level, float64(m.currentSampleRate), duckFade)
+	speaker.Unlock()
+}
+
+// resumeAmbienceLocked puts the ambience back after speaker.Clear removed it.
+func (m *Manager) resumeAmbienceLocked() {
+	if m.ambience == nil {
+		return
+	}
+	speaker.Play(m.ambience)
+	m.duckAmbienceLocked(false)
+}
+
+func (m *Manager) stopAmbienceLocked() {
+	if m.ambience == nil {
+		return
+	}
+	speaker.Clear()
+	m.ambienceTrack.Close()
+	m.ambience = nil
+	m.ambienceTrack = nil
+}
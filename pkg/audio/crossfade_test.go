@@ -0,0 +1,125 @@
+package audio
+
+import (
+	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
+
+	"github.com/gopxl/beep/v2"
+	"github.com/gopxl/beep/v2/speaker"
+)
+
+// fakeTrack is a silent decoder that records whether it was released.
+type fakeTrack struct {
+	pos, length int
+	closed      int
+}
+
+func (f *fakeTrack) Stream(samples [][2]float64) (int, bool) { return 0, false }
+func (f *fakeTrack) Err() error                              { return nil }
+func (f *fakeTrack) Len() int                                { return f.length }
+func (f *fakeTrack) Position() int                           { return f.pos }
+func (f *fakeTrack) Seek(p int) error                        { f.pos = p; return nil }
+func (f *fakeTrack) Close() error                            { f.closed++; return nil }
+
+func newPlayingManager(crossfadeMs int, track *fakeTrack) *Manager {
+	cfg := &config.NarratorConfig{}
+	cfg.AudioEffects.CrossfadeMs = crossfadeMs
+	m := New(cfg)
+	m.ctrl = &beep.Ctrl{Streamer: beep.Silence(-1)}
+	m.streamer = NewSmoothVolume(nil, 1.0)
+	m.trackStreamer = track
+	m.trackFormat = beep.Format{SampleRate: 48000}
+	m.currentSampleRate = 48000
+	m.playGen = 1
+	return m
+}
+
+func TestCrossfadeDuration(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  *config.NarratorConfig
+		want time.Duration
+	}{
+		{"No config", nil, 0},
+		{"Disabled", &config.NarratorConfig{}, 0},
+		{"Configured", &config.NarratorConfig{AudioEffects: config.AudioEffectsConfig{CrossfadeMs: 750}}, 750 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := New(tt.cfg).CrossfadeDuration(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFadeOutHandsOverClip(t *testing.T) {
+	track := &fakeTrack{length: 48000, pos: 40000}
+	m := newPlayingManager(100, track)
+	called := false
+	m.onComplete = func() { called = true }
+	ctrl := m.ctrl
+
+	m.mu.Lock()
+	m.fadeOutLocked(20 * time.Millisecond)
+	m.playGen++ // As Play does for the incoming clip
+	m.mu.Unlock()
+
+	if m.ctrl != nil || m.trackStreamer != nil || m.onComplete != nil {
+		t.Fatal("outgoing clip still registered as current")
+	}
+	if len(m.outgoing) != 1 {
+		t.Fatalf("got %d outgoing clips, want 1", len(m.outgoing))
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	speaker.Lock()
+	cut := ctrl.Streamer == nil
+	speaker.Unlock()
+	if !cut {
+		t.Error("outgoing clip was not cut off after the fade")
+	}
+
+	// The superseded clip's completion only releases its decoder
+	m.finishClip(1, track)
+	if track.closed != 1 || len(m.outgoing) != 0 {
+		t.Errorf("closed=%d outgoing=%d, want 1 and 0", track.closed, len(m.outgoing))
+	}
+	if called {
+		t.Error("superseded onComplete was called")
+	}
+}
+
+func TestStopCutsOutgoingClips(t *testing.T) {
+	track := &fakeTrack{length: 48000, pos: 40000}
+	m := newPlayingManager(100, track)
+
+	m.mu.Lock()
+	m.fadeOutLocked(time.Second)
+	m.playGen++
+	m.mu.Unlock()
+	m.Stop()
+
+	if track.closed != 1 || len(m.outgoing) != 0 {
+		t.Errorf("closed=%d outgoing=%d, want 1 and 0", track.closed, len(m.outgoing))
+	}
+
+	// A completion callback racing the Stop must not close the decoder twice
+	m.finishClip(1, track)
+	if track.closed != 1 {
+		t.Errorf("decoder closed %d times", track.closed)
+	}
+}
+
+func TestFinishClipIgnoresSupersededGeneration(t *testing.T) {
+	track := &fakeTrack{length: 48000, pos: 48000}
+	m := newPlayingManager(0, track)
+	m.playGen = 2 // A newer clip is playing
+
+	m.finishClip(1, track)
+	if m.ctrl == nil {
+		t.Error("stale completion cleared the current clip")
+	}
+}
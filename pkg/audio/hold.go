@@ -0,0 +1,112 @@
+package audio
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gopxl/beep/v2/speaker"
+
+	"phileasgo/pkg/config"
+)
+
+// holdFade is quick enough that the first words on the radio aren't covered.
+const holdFade = 150 * time.Millisecond
+
+// defaultHoldDuckLevel applies when no duck level is configured.
+const defaultHoldDuckLevel = 0.2
+
+// SetHold starts or ends a hold for reason. Holds for several reasons stack;
+// the narration comes back once the last one ends. A clip paused by a hold
+// stays paused if the user paused it in the meantime.
+func (m *Manager) SetHold(reason string, on bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	wasHeld := len(m.holds) > 0
+	if on {
+		if m.holds == nil {
+			m.holds = make(map[string]bool)
+		}
+		m.holds[reason] = true
+	} else {
+		delete(m.holds, reason)
+	}
+	held := len(m.holds) > 0
+	if held == wasHeld {
+		return
+	}
+
+	slog.Info("Audio: Narration hold", "reason", reason, "active", held, "mode", m.holdModeLocked())
+	if held {
+		m.applyHoldLocked()
+	} else {
+		m.releaseHoldLocked()
+	}
+}
+
+// HoldReason returns the active hold reasons, comma-separated, or "".
+func (m *Manager) HoldReason() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	reasons := make([]string, 0, len(m.holds))
+	for r := range m.holds {
+		reasons = append(reasons, r)
+	}
+	sort.Strings(reasons)
+	return strings.Join(reasons, ",")
+}
+
+func (m *Manager) holdModeLocked() string {
+	if m.config != nil && m.config.Comms.Mode == config.HoldModePause {
+		return config.HoldModePause
+	}
+	return config.HoldModeDuck
+}
+
+// clipLevelLocked is the fade level a playing clip should sit at: ducked
+// under a hold, full otherwise.
+func (m *Manager) clipLevelLocked() float64 {
+	if len(m.holds) == 0 || m.holdModeLocked() != config.HoldModeDuck {
+		return 1.0
+	}
+	if m.config == nil || m.config.Comms.DuckLevel <= 0 {
+		return defaultHoldDuckLevel
+	}
+	return min(m.config.Comms.DuckLevel, 1.0)
+}
+
+func (m *Manager) applyHoldLocked() {
+	if m.ctrl == nil || m.isPaused {
+		return
+	}
+	if m.holdModeLocked() == config.HoldModePause {
+		m.pauseLocked()
+		m.heldPaused = true
+		return
+	}
+	speaker.Lock()
+	m.streamer.FadeTo(m.clipLevelLocked(), float64(m.currentSampleRate), holdFade)
+	speaker.Unlock()
+}
+
+func (m *Manager) releaseHoldLocked() {
+	if m.ctrl == nil {
+		m.heldPaused = false
+		return
+	}
+	if m.heldPaused {
+		m.heldPaused = false
+		if !m.userPaused {
+			m.resumeLocked()
+		}
+		return
+	}
+	if !m.isPaused {
+		speaker.Lock()
+		m.streamer.FadeTo(1.0, float64(m.currentSampleRate), holdFade)
+		speaker.Unlock()
+	}
+}
@@ -0,0 +1,65 @@
+package audio
+
+import (
+	"testing"
+
+	"phileasgo/pkg/config"
+)
+
+func TestSetHold(t *testing.T) {
+	t.Run("Duck lowers and restores the clip", func(t *testing.T) {
+		m := newPlayingManager(0, &fakeTrack{length: 48000})
+		m.config.Comms = config.CommsConfig{Mode: config.HoldModeDuck, DuckLevel: 0.3}
+
+		m.SetHold("comms", true)
+		if m.isPaused {
+			t.Fatal("expected ducking to keep playing")
+		}
+		if m.streamer.fadeLevel != 0.3 {
+			t.Errorf("expected fade level 0.3, got %v", m.streamer.fadeLevel)
+		}
+
+		m.SetHold("comms", false)
+		if m.streamer.fadeLevel != 1.0 {
+			t.Errorf("expected fade level restored, got %v", m.streamer.fadeLevel)
+		}
+	})
+
+	t.Run("Pause holds until the last reason ends", func(t *testing.T) {
+		m := newPlayingManager(0, &fakeTrack{length: 48000})
+		m.config.Comms = config.CommsConfig{Mode: config.HoldModePause}
+
+		m.SetHold("comms", true)
+		m.SetHold("ptt", true)
+		if !m.isPaused {
+			t.Fatal("expected the clip paused")
+		}
+		if got := m.HoldReason(); got != "comms,ptt" {
+			t.Errorf("expected both reasons, got %q", got)
+		}
+
+		m.SetHold("ptt", false)
+		if !m.isPaused {
+			t.Error("expected the clip still paused while a hold remains")
+		}
+		m.SetHold("comms", false)
+		if m.isPaused {
+			t.Error("expected the clip resumed")
+		}
+		if m.HoldReason() != "" {
+			t.Errorf("expected no hold reason, got %q", m.HoldReason())
+		}
+	})
+
+	t.Run("User pause survives a pausing hold", func(t *testing.T) {
+		m := newPlayingManager(0, &fakeTrack{length: 48000})
+		m.config.Comms = config.CommsConfig{Mode: config.HoldModePause}
+
+		m.SetHold("comms", true)
+		m.SetUserPaused(true)
+		m.SetHold("comms", false)
+		if !m.isPaused {
+			t.Error("expected the clip to stay paused for the user")
+		}
+	})
+}
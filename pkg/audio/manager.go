@@ -19,6 +19,8 @@ import (
 type Service interface {
 	// Play starts playback of an audio file. If startPaused is true, loads but pauses immediately.
 	// onComplete is called when playback finishes (not when stopped/paused manually).
+	// A clip that is still playing is crossfaded into the new one when a crossfade is
+	// configured; its onComplete is then dropped, as the new clip continues its playback.
 	Play(filepath string, startPaused bool, onComplete func()) error
 	// Pause pauses current playback.
 	Pause()
@@ -55,6 +57,11 @@ type Service interface {
 	Duration() time.Duration
 	// Remaining returns the remaining time of the current playback.
 	Remaining() time.Duration
+	// SetHold starts or ends a hold for reason, e.g. while the pilot is on
+	// the radio. A held narration is ducked or paused, depending on config.
+	SetHold(reason string, on bool)
+	// HoldReason returns the active hold reasons, comma-separated, or "".
+	HoldReason() string
 }
 
 // Manager implements the Service interface using gopxl/beep.
@@ -64,6 +71,8 @@ type Manager struct {
 	volume             float64
 	isPaused           bool
 	userPaused         bool
+	holds              map[string]bool
+	heldPaused         bool // The current clip was paused by a hold, not the user
 	lastNarrationFile  string
 	speakerInitialized bool
 	currentSampleRate  beep.SampleRate
@@ -72,6 +81,19 @@ type Manager struct {
 	trackFormat        beep.Format
 	config             *config.NarratorConfig
 	onComplete         func()
+
+	// playGen identifies the clip started by the latest Play so completion
+	// callbacks of superseded clips do not tear down their successor.
+	playGen uint64
+	// outgoing holds clips still fading out under a crossfade.
+	outgoing []beep.StreamSeekCloser
+
+	ambience      *SmoothVolume
+	ambienceTrack beep.StreamSeekCloser
+	ambienceTried bool
+
+	chimeTrack beep.StreamSeekCloser
+	nextChime  string // Chime for the next clip, set by SetNextChime
 }
 
 // New creates a new Manager instance.
@@ -84,11 +106,32 @@ func New(cfg *config.NarratorConfig) *Manager {
 
 // Play starts playback of an audio file.
 func (m *Manager) Play(filepath string, startPaused bool, onComplete func()) error {
+	return m.play(filepath, startPaused, false, onComplete)
+}
+
+// play starts a clip; replay marks a repeat of the last narration, which the
+// listener asked for and needs no chime to announce it.
+func (m *Manager) play(filepath string, startPaused, replay bool, onComplete func()) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Stop any current playback and close the file handle
-	m.stopLocked()
+	// The cue belongs to this clip only, even if it fails to start
+	cue := m.nextChime
+	m.nextChime = ""
+
+	// A clip started during a pausing hold waits for the hold to end
+	heldPaused := !startPaused && len(m.holds) > 0 && m.holdModeLocked() == config.HoldModePause
+	startPaused = startPaused || heldPaused
+
+	// Blend into the new clip if one is audibly playing; otherwise stop any
+	// current playback and close the file handle
+	fade := m.crossfadeDuration()
+	crossfade := fade > 0 && !startPaused && m.ctrl != nil && !m.isPaused && m.remainingLocked() > 0
+	if crossfade {
+		m.fadeOutLocked(fade)
+	} else {
+		m.stopLocked()
+	}
 
 	// Open and decode audio file
 	streamer, format, err := DecodeMedia(filepath)
@@ -101,8 +144,20 @@ func (m *Manager) Play(filepath string, startPaused bool, onComplete func()) err
 		return err
 	}
 
+	// Level the clip before resampling; the scan needs the seekable decoder
+	var source beep.Streamer = streamer
+	if target := m.normalizeTarget(); target < 0 {
+		normalized, gainDb, err := NormalizePeak(streamer, target)
+		if err != nil {
+			_ = streamer.Close()
+			return err
+		}
+		source = normalized
+		slog.Debug("Audio: Clip normalized", "target_db", target, "gain_db", gainDb)
+	}
+
 	// Resample streamer to target rate
-	resampled := beep.Resample(3, format.SampleRate, m.currentSampleRate, streamer)
+	resampled := beep.Resample(3, format.SampleRate, m.currentSampleRate, source)
 
 	// Apply Audio Effects (if enabled)
 	var finalStreamer beep.Streamer = resampled
@@ -115,37 +170,37 @@ func (m *Manager) Play(filepath string, startPaused bool, onComplete func()) err
 
 	// Wrap in SmoothVolume control for click-free adjustments and fading
 	volStreamer := NewSmoothVolume(finalStreamer, m.volume)
+	if level := m.clipLevelLocked(); level < 1 {
+		volStreamer.fadeLevel = level
+		volStreamer.currentGain = m.volume * level
+	}
+	if crossfade {
+		speaker.Lock()
+		volStreamer.currentGain = 0
+		volStreamer.FadeTo(m.clipLevelLocked(), float64(m.currentSampleRate), fade)
+		speaker.Unlock()
+	}
 
 	m.streamer = volStreamer
 	m.trackStreamer = streamer
 	m.trackFormat = format
 
-	// Wrap in control for pause/resume
-	m.ctrl = &beep.Ctrl{Streamer: volStreamer, Paused: startPaused}
+	// Wrap in control for pause/resume; the chime shares it so skipping and
+	// pausing during the chime act on the whole narration
+	m.ctrl = &beep.Ctrl{Streamer: m.prependChimeLocked(volStreamer, cue, replay || crossfade), Paused: startPaused}
 	m.isPaused = startPaused
+	m.heldPaused = heldPaused
+
+	m.startAmbienceLocked()
+	m.duckAmbienceLocked(true)
 
 	// Play with callback to clean up when done
 	m.onComplete = onComplete
+	m.playGen++
+	gen := m.playGen
 	speaker.Play(beep.Seq(m.ctrl, beep.Callback(func() {
 		// Launch goroutine to handle pause and cleanup without blocking the speaker thread
-		go func() {
-			// Enforce pause_between_narrations if configured
-			if m.config != nil && m.config.PauseDuration > 0 {
-				time.Sleep(time.Duration(m.config.PauseDuration))
-			}
-
-			m.mu.Lock()
-			m.ctrl = nil
-			m.isPaused = false
-			callback := m.onComplete
-			m.onComplete = nil
-			m.mu.Unlock()
-			streamer.Close()
-
-			if callback != nil {
-				callback()
-			}
-		}()
+		go m.finishClip(gen, streamer)
 	})))
 
 	// Check if this is a new file or replay
@@ -175,7 +230,10 @@ func (m *Manager) Play(filepath string, startPaused bool, onComplete func()) err
 func (m *Manager) Pause() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.pauseLocked()
+}
 
+func (m *Manager) pauseLocked() {
 	if m.ctrl != nil && !m.isPaused {
 		// Initiate fade out
 		fadeDuration := 50 * time.Millisecond
@@ -204,14 +262,18 @@ func (m *Manager) Pause() {
 func (m *Manager) Resume() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
+	m.resumeLocked()
+}
 
+func (m *Manager) resumeLocked() {
 	if m.ctrl != nil && m.isPaused {
 		speaker.Lock()
 		m.ctrl.Paused = false
-		// Fade in back to 1.0 (multiplier for targetVolume)
-		m.streamer.FadeTo(1.0, float64(m.currentSampleRate), 100*time.Millisecond)
+		// Fade in back to the clip level (multiplier for targetVolume)
+		m.streamer.FadeTo(m.clipLevelLocked(), float64(m.currentSampleRate), 100*time.Millisecond)
 		speaker.Unlock()
 		m.isPaused = false
+		m.heldPaused = false
 	}
 }
 
@@ -234,12 +296,18 @@ func (m *Manager) stopLocked() {
 		time.Sleep(fadeDuration + 10*time.Millisecond)
 	}
 
-	// Now clear the speaker before closing the streamer
-	if m.ctrl != nil {
+	// Now clear the speaker before closing the streamer. Stop always cuts
+	// immediately, including clips still fading out under a crossfade.
+	if m.ctrl != nil || len(m.outgoing) > 0 {
 		speaker.Clear()
 		m.ctrl = nil
+		m.resumeAmbienceLocked()
 	}
 	m.isPaused = false
+	for _, s := range m.outgoing {
+		s.Close()
+	}
+	m.outgoing = nil
 
 	// If we have a pending callback, call it now as we've stopped playback
 	if m.onComplete != nil {
@@ -253,6 +321,7 @@ func (m *Manager) stopLocked() {
 		m.trackStreamer.Close()
 		m.trackStreamer = nil
 	}
+	m.closeChimeLocked()
 }
 
 func (m *Manager) ensureSpeakerInitialized(streamer beep.StreamSeekCloser) error {
@@ -277,6 +346,8 @@ func (m *Manager) Shutdown() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	m.stopAmbienceLocked()
+
 	if m.lastNarrationFile != "" {
 		if err := os.Remove(m.lastNarrationFile); err == nil {
 			slog.Debug("Audio: Shutdown cleanup of residual artifact", "path", m.lastNarrationFile)
@@ -327,6 +398,11 @@ func (m *Manager) SetVolume(vol float64) {
 		m.streamer.SetTargetVolume(vol, float64(m.currentSampleRate), 20*time.Millisecond)
 		speaker.Unlock()
 	}
+	if m.ambience != nil {
+		speaker.Lock()
+		m.ambience.SetTargetVolume(vol*m.config.AudioEffects.AmbienceVolume, float64(m.currentSampleRate), 20*time.Millisecond)
+		speaker.Unlock()
+	}
 }
 
 // Volume returns current volume level.
@@ -383,7 +459,7 @@ func (m *Manager) ReplayLastNarration(onComplete func()) bool {
 		return false
 	}
 
-	return m.Play(lastFile, false, onComplete) == nil
+	return m.play(lastFile, false, true, onComplete) == nil
 }
 
 // Position returns the current playback position.
@@ -410,6 +486,10 @@ func (m *Manager) Duration() time.Duration {
 func (m *Manager) Remaining() time.Duration {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
+	return m.remainingLocked()
+}
+
+func (m *Manager) remainingLocked() time.Duration {
 	if m.trackStreamer == nil || m.trackFormat.SampleRate == 0 {
 		return 0
 	}
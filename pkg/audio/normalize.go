@@ -0,0 +1,86 @@
+package audio
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/gopxl/beep/v2"
+)
+
+const (
+	// maxNormalizeGainDb caps the boost so a quiet clip, such as a soft
+	// ambient essay, is not dragged up into its own noise floor.
+	maxNormalizeGainDb = 12.0
+	// normalizeSilenceDb is the peak below which a clip is treated as
+	// silence and left alone.
+	normalizeSilenceDb = -60.0
+)
+
+// normalizeTarget returns the configured peak target in dBFS, or 0 when
+// normalization is off. Targets above full scale would clip and are ignored.
+func (m *Manager) normalizeTarget() float64 {
+	if m.config == nil || m.config.AudioEffects.NormalizeTargetDb >= 0 {
+		return 0
+	}
+	return m.config.AudioEffects.NormalizeTargetDb
+}
+
+// NormalizePeak scans s for its peak sample, rewinds it and returns a
+// streamer scaled so the peak sits at targetDb dBFS, along with the applied
+// gain in dB. Loud clips are attenuated freely, quiet ones boosted by at most
+// maxNormalizeGainDb; silent clips are returned unchanged.
+func NormalizePeak(s beep.StreamSeeker, targetDb float64) (beep.Streamer, float64, error) {
+	peak := peakLevel(s)
+	if err := s.Seek(0); err != nil {
+		return nil, 0, fmt.Errorf("failed to rewind clip: %w", err)
+	}
+
+	gainDb := NormalizeGainDb(peak, targetDb)
+	if gainDb == 0 {
+		return s, 0, nil
+	}
+	return &gainStreamer{Streamer: s, gain: math.Pow(10, gainDb/20)}, gainDb, nil
+}
+
+// NormalizeGainDb returns the gain in dB that brings peak (linear, 0-1) to
+// targetDb dBFS, capped at maxNormalizeGainDb. Silence needs no gain.
+func NormalizeGainDb(peak, targetDb float64) float64 {
+	if peak <= 0 {
+		return 0
+	}
+	peakDb := 20 * math.Log10(peak)
+	if peakDb < normalizeSilenceDb {
+		return 0
+	}
+	return math.Min(targetDb-peakDb, maxNormalizeGainDb)
+}
+
+// peakLevel streams s to its end and returns the largest absolute sample.
+func peakLevel(s beep.Streamer) float64 {
+	buf := make([][2]float64, 4096)
+	var peak float64
+	for {
+		n, ok := s.Stream(buf)
+		for _, sample := range buf[:n] {
+			peak = math.Max(peak, math.Max(math.Abs(sample[0]), math.Abs(sample[1])))
+		}
+		if !ok {
+			return peak
+		}
+	}
+}
+
+// gainStreamer applies a fixed linear gain.
+type gainStreamer struct {
+	beep.Streamer
+	gain float64
+}
+
+func (g *gainStreamer) Stream(samples [][2]float64) (n int, ok bool) {
+	n, ok = g.Streamer.Stream(samples)
+	for i := range samples[:n] {
+		samples[i][0] *= g.gain
+		samples[i][1] *= g.gain
+	}
+	return n, ok
+}
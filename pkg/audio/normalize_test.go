@@ -0,0 +1,69 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gopxl/beep/v2"
+
+	"phileasgo/pkg/config"
+)
+
+// sineClip returns a seekable stereo sine clip with the given peak amplitude.
+func sineClip(peak float64, n int) beep.StreamSeeker {
+	buf := beep.NewBuffer(beep.Format{SampleRate: 48000, NumChannels: 2, Precision: 2})
+	samples := make([][2]float64, n)
+	for i := range samples {
+		v := peak * math.Sin(2*math.Pi*440*float64(i)/48000)
+		samples[i] = [2]float64{v, v}
+	}
+	buf.Append(&dummyStreamer{samples: samples})
+	return buf.Streamer(0, buf.Len())
+}
+
+func TestNormalizePeak(t *testing.T) {
+	tests := []struct {
+		name     string
+		peak     float64
+		targetDb float64
+		wantPeak float64
+	}{
+		{"Boosts a quiet clip", 0.25, -6, math.Pow(10, -6.0/20)},
+		{"Attenuates a hot clip", 0.99, -3, math.Pow(10, -3.0/20)},
+		{"Caps the boost of a very quiet clip", 0.01, -1, 0.01 * math.Pow(10, maxNormalizeGainDb/20)},
+		{"Leaves silence alone", 0.0005, -1, 0.0005},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clip := sineClip(tt.peak, 4800)
+			s, _, err := NormalizePeak(clip, tt.targetDb)
+			if err != nil {
+				t.Fatalf("NormalizePeak failed: %v", err)
+			}
+			if got := peakLevel(s); math.Abs(got-tt.wantPeak) > 0.005 {
+				t.Errorf("expected peak %.4f, got %.4f", tt.wantPeak, got)
+			}
+		})
+	}
+}
+
+func TestManager_NormalizeTarget(t *testing.T) {
+	tests := []struct {
+		name   string
+		target float64
+		want   float64
+	}{
+		{"Disabled", 0, 0},
+		{"Peak target", -3, -3},
+		{"Above full scale is ignored", 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := New(&config.NarratorConfig{AudioEffects: config.AudioEffectsConfig{NormalizeTargetDb: tt.target}})
+			if got := m.normalizeTarget(); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"unsafe"
 
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/sim/simconnect"
 	"phileasgo/pkg/terrain"
@@ -27,8 +28,17 @@ const (
 	DefIDTelemetry = 0
 	DefIDObjectPos = 1
 
+	// evtIDObjectRemoved tells us about objects the sim dropped on its own;
+	// setting their position doesn't fail, so this is the only way to know
+	evtIDObjectRemoved = 0
+
 	// Configuration (Defaults / Fallbacks)
 	UpdateInterval = 50 * time.Millisecond // ~20Hz
+
+	// parkAltitudeFt is where pooled balloons wait for reuse. Well above
+	// anything flown and too far away to be seen; below ground the sim may
+	// destroy them on terrain contact.
+	parkAltitudeFt = 60000.0
 )
 
 // ObjectClient combines the needed interfaces for this service
@@ -58,6 +68,10 @@ type Service struct {
 	spawnedBeacons  []SpawnedBeacon
 	formationActive bool
 
+	// Parked objects reused before spawning new ones, sparing the sim the
+	// create/remove churn of every target change
+	pool []pooledBeacon
+
 	elev terrain.ElevationGetter
 }
 
@@ -68,6 +82,15 @@ type SpawnedBeacon struct {
 	Lat       float64 // POI Latitude
 	Lon       float64 // POI Longitude
 	BaseAlt   float64 // POI Base Altitude
+	Title     string  // SimObject title and livery, matched when reusing pooled objects
+	Livery    string
+}
+
+// pooledBeacon is a spawned balloon parked out of sight.
+type pooledBeacon struct {
+	ID     uint32
+	Title  string
+	Livery string
 }
 
 // NewService creates a new Beacon Service.
@@ -164,13 +187,108 @@ func (s *Service) SetTarget(ctx context.Context, lat, lon float64, title, livery
 	return nil
 }
 
+// TargetObject names the SimObject (title + livery) used to mark one target.
+type TargetObject struct {
+	Title  string
+	Livery string
+}
+
+// SetTargets replaces all target beacons with one balloon per point.
+// The first point is the primary target: it receives the formation and drives
+// the altitude logic, so callers pass the currently narrated POI first.
+// Each point uses the object at the same index; points beyond the available
+// objects or beyond Beacon.MaxTargets are skipped rather than failing.
+func (s *Service) SetTargets(ctx context.Context, points []geo.Point, objects []TargetObject) error {
+	if s == nil {
+		return fmt.Errorf("beacon service is nil")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.prov.BeaconEnabled(ctx) {
+		s.clearLocked()
+		return nil
+	}
+
+	limit := min(len(points), len(objects), s.prov.BeaconMaxTargets(ctx))
+	if limit < len(points) {
+		s.logger.Debug("Not enough beacon slots, dropping targets", "requested", len(points), "spawning", max(limit, 0))
+	}
+	if limit <= 0 {
+		s.clearLocked()
+		return nil
+	}
+	points = points[:limit]
+
+	// 1. Keep balloons already marking a requested point (avoids respawn flicker), remove the rest
+	const threshold = 0.0001
+	kept := []SpawnedBeacon{}
+	present := make([]bool, limit)
+	for _, b := range s.spawnedBeacons {
+		match := -1
+		if b.IsTarget {
+			for i, p := range points {
+				if !present[i] && math.Abs(b.Lat-p.Lat) < threshold && math.Abs(b.Lon-p.Lon) < threshold {
+					match = i
+					break
+				}
+			}
+		}
+		if match < 0 {
+			s.release(b)
+			continue
+		}
+		present[match] = true
+		kept = append(kept, b)
+	}
+	s.spawnedBeacons = kept
+	s.formationActive = false
+
+	s.targetLat = points[0].Lat
+	s.targetLon = points[0].Lon
+
+	tel, err := s.client.GetTelemetry(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get telemetry for spawn: %w", err)
+	}
+
+	if tel.IsOnGround {
+		s.active = true
+		return nil
+	}
+
+	// 2. Setup altitude and spawn missing balloons
+	spawnFormation := s.setupTargetAltitude(ctx, &tel)
+	for i, p := range points {
+		if present[i] {
+			continue
+		}
+		obj := objects[i]
+		if obj.Title == "" || obj.Livery == "" {
+			s.logger.Debug("Skipping beacon target without title/livery", "index", i)
+			continue
+		}
+		s.spawnTargetBalloon(obj.Title, obj.Livery, p.Lat, p.Lon)
+	}
+
+	if spawnFormation && objects[0].Title != "" && objects[0].Livery != "" {
+		s.formationSpawnTime = time.Now()
+		s.spawnFormationBalloons(ctx, objects[0].Title, objects[0].Livery, &tel)
+	}
+
+	s.active = true
+	s.logger.Info("Beacon system SetTargets complete", "targets", limit, "active_beacons", len(s.spawnedBeacons))
+	return nil
+}
+
 func (s *Service) clearFormationAndDuplicates(lat, lon float64) {
 	const threshold = 0.0001
 	newSpawned := []SpawnedBeacon{}
 	for _, b := range s.spawnedBeacons {
 		isSamePOI := math.Abs(b.Lat-lat) < threshold && math.Abs(b.Lon-lon) < threshold
 		if !b.IsTarget || isSamePOI {
-			_ = s.client.RemoveObject(b.ID, reqIDRemove)
+			s.release(b)
 		} else {
 			newSpawned = append(newSpawned, b)
 		}
@@ -197,12 +315,12 @@ func (s *Service) setupTargetAltitude(ctx context.Context, tel *sim.Telemetry) b
 
 func (s *Service) spawnTargetBalloon(title, livery string, lat, lon float64) {
 	slog.Info("Spawning target beacon", "title", title, "livery", livery, "lat", lat, "lon", lon)
-	objID, err := s.SpawnAirTraffic(title, livery, "", lat, lon, s.targetAlt, reqIDSpawnTarget)
+	objID, err := s.acquire(title, livery, lat, lon, s.targetAlt, reqIDSpawnTarget)
 	if err != nil {
 		slog.Error("Failed to spawn target beacon", "error", err)
 		return
 	}
-	s.spawnedBeacons = append(s.spawnedBeacons, SpawnedBeacon{ID: objID, IsTarget: true, Lat: lat, Lon: lon, BaseAlt: s.targetAlt})
+	s.spawnedBeacons = append(s.spawnedBeacons, SpawnedBeacon{ID: objID, IsTarget: true, Lat: lat, Lon: lon, BaseAlt: s.targetAlt, Title: title, Livery: livery})
 }
 
 func (s *Service) enforceTargetQuota(ctx context.Context) {
@@ -216,7 +334,7 @@ func (s *Service) enforceTargetQuota(ctx context.Context) {
 	for targetCount > maxTargets {
 		for i, b := range s.spawnedBeacons {
 			if b.IsTarget {
-				_ = s.client.RemoveObject(b.ID, reqIDRemove)
+				s.release(b)
 				s.spawnedBeacons = append(s.spawnedBeacons[:i], s.spawnedBeacons[i+1:]...)
 				targetCount--
 				break
@@ -241,12 +359,12 @@ func (s *Service) spawnFormationBalloons(ctx context.Context, title, livery stri
 		reqID := uint32(200 + i)
 		absAlt := s.targetAlt + altOffset
 
-		objID, err := s.SpawnAirTraffic(title, livery, "", fLat, fLon, absAlt, reqID)
+		objID, err := s.acquire(title, livery, fLat, fLon, absAlt, reqID)
 		if err != nil {
 			slog.Error("Failed to spawn formation beacon", "index", i, "error", err)
 			continue
 		}
-		s.spawnedBeacons = append(s.spawnedBeacons, SpawnedBeacon{ID: objID, IsTarget: false, AltOffset: altOffset, Lat: fLat, Lon: fLon, BaseAlt: s.targetAlt})
+		s.spawnedBeacons = append(s.spawnedBeacons, SpawnedBeacon{ID: objID, IsTarget: false, AltOffset: altOffset, Lat: fLat, Lon: fLon, BaseAlt: s.targetAlt, Title: title, Livery: livery})
 	}
 	s.formationActive = true
 }
@@ -257,6 +375,78 @@ func (s *Service) SpawnAirTraffic(title, livery, tail string, lat, lon, alt floa
 	return s.client.SpawnAirTraffic(reqID, title, livery, tail, lat, lon, alt, 0)
 }
 
+// acquire moves a parked balloon of the same title and livery into place, or
+// spawns a new one when none is parked or the sim has dropped them.
+func (s *Service) acquire(title, livery string, lat, lon, alt float64, reqID uint32) (uint32, error) {
+	for i := 0; i < len(s.pool); i++ {
+		p := s.pool[i]
+		if p.Title != title || p.Livery != livery {
+			continue
+		}
+		s.pool = append(s.pool[:i], s.pool[i+1:]...)
+		if s.updateObjectOnSim(p.ID, lat, lon, alt) {
+			return p.ID, nil
+		}
+		i--
+	}
+	return s.SpawnAirTraffic(title, livery, "", lat, lon, alt, reqID)
+}
+
+// release parks a balloon for reuse while the pool has room and removes it
+// otherwise.
+func (s *Service) release(b SpawnedBeacon) {
+	if len(s.pool) >= s.poolSize() || b.Title == "" {
+		_ = s.client.RemoveObject(b.ID, reqIDRemove)
+		return
+	}
+	// A failed move means the sim already dropped the object
+	if s.updateObjectOnSim(b.ID, b.Lat, b.Lon, parkAltitudeFt) {
+		s.pool = append(s.pool, pooledBeacon{ID: b.ID, Title: b.Title, Livery: b.Livery})
+	}
+}
+
+// objectRemoved forgets an object the sim has removed, whether parked or in
+// use. A target lost this way is spawned afresh by the next SetTargets.
+func (s *Service) objectRemoved(id uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, p := range s.pool {
+		if p.ID == id {
+			s.pool = append(s.pool[:i], s.pool[i+1:]...)
+			s.logger.Debug("Parked beacon removed by the sim", "id", id)
+			return
+		}
+	}
+	for i, b := range s.spawnedBeacons {
+		if b.ID == id {
+			s.spawnedBeacons = append(s.spawnedBeacons[:i], s.spawnedBeacons[i+1:]...)
+			s.logger.Info("Beacon removed by the sim", "id", id, "target", b.IsTarget)
+			return
+		}
+	}
+}
+
+// poolSize is Beacon.PoolSize, or 0 while beacons are disabled so that
+// nothing stays behind in the sim.
+func (s *Service) poolSize() int {
+	ctx := context.Background()
+	if !s.prov.BeaconEnabled(ctx) {
+		return 0
+	}
+	return max(s.prov.BeaconPoolSize(ctx), 0)
+}
+
+// trimPool removes parked balloons beyond the pool size.
+func (s *Service) trimPool() {
+	size := s.poolSize()
+	for len(s.pool) > size {
+		last := s.pool[len(s.pool)-1]
+		_ = s.client.RemoveObject(last.ID, reqIDRemove)
+		s.pool = s.pool[:len(s.pool)-1]
+	}
+}
+
 // Clear removes all beacons.
 func (s *Service) Clear() {
 	if s == nil {
@@ -268,18 +458,20 @@ func (s *Service) Clear() {
 }
 
 func (s *Service) clearLocked() {
+	// The pool may have shrunk, or beacons been disabled, since objects were parked
+	s.trimPool()
 	if !s.active {
 		return
 	}
 
 	for _, b := range s.spawnedBeacons {
-		// Best effort remove
-		_ = s.client.RemoveObject(b.ID, reqIDRemove)
+		// Best effort park or remove
+		s.release(b)
 	}
 	s.spawnedBeacons = []SpawnedBeacon{}
 	s.active = false
 	s.formationActive = false
-	s.logger.Info("Cleared all beacons")
+	s.logger.Info("Cleared all beacons", "parked", len(s.pool))
 }
 
 // SetDLLPath provides the path to SimConnect.dll for the independent connection.
@@ -322,6 +514,11 @@ func (s *Service) Connect() error {
 	_ = simconnect.AddToDataDefinition(h, DefIDObjectPos, "SIM ON GROUND", "Bool", simconnect.DATATYPE_INT32)
 	_ = simconnect.AddToDataDefinition(h, DefIDObjectPos, "AIRSPEED TRUE", "knots", simconnect.DATATYPE_INT32)
 
+	// Keeps the pool from handing out objects that no longer exist
+	if err := simconnect.SubscribeToSystemEvent(h, evtIDObjectRemoved, "ObjectRemoved"); err != nil {
+		s.logger.Error("Failed to subscribe to ObjectRemoved", "error", err)
+	}
+
 	// 2. Request Data
 	// PERIOD_VISUAL_FRAME with interval=1 (update every frame for max smoothness)
 	// TESTED: Perfect signal smoothness. DO NOT CHANGE.
@@ -360,6 +557,8 @@ func (s *Service) StartIndependentLoop(ctx context.Context) {
 				s.mu.Lock()
 				_ = simconnect.Close(s.handle)
 				s.handle = 0
+				// Parked objects don't outlive the sim session
+				s.pool = nil
 				s.mu.Unlock()
 				s.logger.Warn("SimConnect handle lost, returning to retry loop")
 			}
@@ -446,6 +645,12 @@ func (s *Service) runDispatchIteration() bool {
 			s.updateStep(context.Background(), tel)
 		}
 	}
+	if recv.ID == simconnect.RECV_ID_EVENT_OBJECT_ADDREMOVE {
+		evt := (*simconnect.RecvEvent)(ppData)
+		if evt.UEventID == evtIDObjectRemoved {
+			s.objectRemoved(evt.Data)
+		}
+	}
 	if recv.ID == simconnect.RECV_ID_QUIT {
 		s.logger.Info("Simulator quit detected in beacon loop")
 		return false
@@ -496,7 +701,7 @@ func (s *Service) checkFormationCleanup(ctx context.Context, distKm float64) {
 		var kept []SpawnedBeacon
 		for _, b := range s.spawnedBeacons {
 			if !b.IsTarget {
-				_ = s.client.RemoveObject(b.ID, reqIDRemove)
+				s.release(b)
 			} else {
 				kept = append(kept, b)
 			}
@@ -516,7 +721,7 @@ func (s *Service) updateAllBeacons(ctx context.Context, tel *simconnect.Telemetr
 		bBearingRad, bDistKm := s.calculateBearing(tel.Latitude, tel.Longitude, b.Lat, b.Lon)
 		if b.IsTarget && s.isBeaconStale(tel, bBearingRad, bDistKm) {
 			s.logger.Info("Despawning stale target balloon", "id", b.ID, "dist", bDistKm)
-			_ = s.client.RemoveObject(b.ID, reqIDRemove)
+			s.release(b)
 			continue
 		}
 
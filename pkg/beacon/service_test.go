@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 	"math"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/sim/simconnect"
 )
@@ -63,6 +65,9 @@ type MockClient struct {
 	Moves   []MoveCall
 	Removes []uint32
 
+	// Objects the sim has dropped; moving them fails
+	Dropped map[uint32]bool
+
 	// ID counter
 	nextID uint32
 }
@@ -117,6 +122,9 @@ func (m *MockClient) RemoveObject(objectID, reqID uint32) error {
 }
 
 func (m *MockClient) SetObjectPosition(objectID uint32, lat, lon, alt, pitch, bank, hdg float64) error {
+	if m.Dropped[objectID] {
+		return fmt.Errorf("unknown object %d", objectID)
+	}
 	m.Moves = append(m.Moves, MoveCall{objectID, lat, lon, alt})
 	return nil
 }
@@ -592,3 +600,217 @@ func TestSetTarget_OnGround(t *testing.T) {
 		t.Error("Service should be active even if spawns are suppressed")
 	}
 }
+
+func TestSetTargets_MultipleTargets(t *testing.T) {
+	cfg := &config.BeaconConfig{
+		Enabled:          true,
+		FormationEnabled: false,
+		MinSpawnAltitude: config.Distance(304.8),
+		MaxTargets:       3,
+	}
+	objects := []TargetObject{{"Balloon", "RED"}, {"Balloon", "BLUE"}, {"Balloon", "GREEN"}}
+	points := []geo.Point{{Lat: 45.0, Lon: -72.0}, {Lat: 45.1, Lon: -72.1}, {Lat: 45.2, Lon: -72.2}, {Lat: 45.3, Lon: -72.3}}
+
+	tests := []struct {
+		name       string
+		points     []geo.Point
+		objects    []TargetObject
+		wantSpawns int
+	}{
+		{"All within limits", points[:2], objects[:2], 2},
+		{"Capped by MaxTargets", points, append(objects, TargetObject{"Balloon", "CYAN"}), 3},
+		{"Capped by available objects", points, objects[:1], 1},
+		{"Empty clears", nil, objects, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockClient{
+				Tel: sim.Telemetry{Latitude: 44.0, Longitude: -73.0, AltitudeMSL: 3000, AltitudeAGL: 3000, Heading: 45},
+			}
+			svc := NewService(mock, slog.New(slog.NewTextHandler(io.Discard, nil)), testProv(cfg))
+
+			if err := svc.SetTargets(context.Background(), tt.points, tt.objects); err != nil {
+				t.Fatalf("SetTargets failed: %v", err)
+			}
+			if len(mock.Spawns) != tt.wantSpawns {
+				t.Fatalf("expected %d spawns, got %d", tt.wantSpawns, len(mock.Spawns))
+			}
+			for i, sp := range mock.Spawns {
+				if sp.Livery != tt.objects[i].Livery || sp.Lat != tt.points[i].Lat {
+					t.Errorf("spawn %d: got %s at %.1f, want %s at %.1f", i, sp.Livery, sp.Lat, tt.objects[i].Livery, tt.points[i].Lat)
+				}
+			}
+		})
+	}
+}
+
+func TestSetTargets_KeepsExistingAndClears(t *testing.T) {
+	mock := &MockClient{
+		Tel: sim.Telemetry{Latitude: 44.0, Longitude: -73.0, AltitudeMSL: 3000, AltitudeAGL: 3000, Heading: 45},
+	}
+	cfg := &config.BeaconConfig{Enabled: true, MinSpawnAltitude: config.Distance(304.8), MaxTargets: 5}
+	svc := NewService(mock, slog.New(slog.NewTextHandler(io.Discard, nil)), testProv(cfg))
+	ctx := context.Background()
+	a, b, c := geo.Point{Lat: 45.0, Lon: -72.0}, geo.Point{Lat: 45.1, Lon: -72.1}, geo.Point{Lat: 45.2, Lon: -72.2}
+	obj := []TargetObject{{"Balloon", "RED"}, {"Balloon", "BLUE"}}
+
+	_ = svc.SetTargets(ctx, []geo.Point{a, b}, obj)
+	// Re-targeting to (b, c): b is kept, a is removed, c is spawned
+	_ = svc.SetTargets(ctx, []geo.Point{b, c}, obj)
+
+	if len(mock.Spawns) != 3 {
+		t.Errorf("expected 3 spawns in total, got %d", len(mock.Spawns))
+	}
+	if len(mock.Removes) != 1 || mock.Removes[0] != 1 {
+		t.Errorf("expected only the first balloon to be removed, got %v", mock.Removes)
+	}
+	if svc.targetLat != b.Lat {
+		t.Errorf("expected primary target at %.1f, got %.1f", b.Lat, svc.targetLat)
+	}
+
+	svc.Clear()
+	if len(svc.spawnedBeacons) != 0 || len(mock.Removes) != 3 {
+		t.Errorf("expected all beacons removed on Clear, got %d left, %d removes", len(svc.spawnedBeacons), len(mock.Removes))
+	}
+}
+
+func TestPool_ReusesObjects(t *testing.T) {
+	objects := []TargetObject{{Title: "Balloon", Livery: "Red"}}
+	a := []geo.Point{{Lat: 45.0, Lon: -72.0}}
+	b := []geo.Point{{Lat: 45.1, Lon: -72.1}}
+
+	tests := []struct {
+		name       string
+		poolSize   int
+		dropped    bool
+		wantSpawns int
+		wantReused bool
+	}{
+		{name: "Pooled object repositioned", poolSize: 4, wantSpawns: 1, wantReused: true},
+		{name: "Without a pool objects are respawned", poolSize: 0, wantSpawns: 2},
+		{name: "Dropped object recreated", poolSize: 4, dropped: true, wantSpawns: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockClient{
+				Tel:     sim.Telemetry{Latitude: 45.0, Longitude: -73.0, AltitudeMSL: 3000, AltitudeAGL: 3000, Heading: 90},
+				Dropped: map[uint32]bool{},
+			}
+			cfg := config.DefaultConfig().Beacon
+			cfg.FormationEnabled = false
+			cfg.PoolSize = tt.poolSize
+			svc := NewService(mock, slog.New(slog.NewTextHandler(io.Discard, nil)), testProv(&cfg))
+			ctx := context.Background()
+
+			if err := svc.SetTargets(ctx, a, objects); err != nil {
+				t.Fatalf("SetTargets failed: %v", err)
+			}
+			firstID := svc.spawnedBeacons[0].ID
+
+			// Clearing parks the balloon instead of removing it
+			svc.Clear()
+			if tt.poolSize > 0 {
+				if len(mock.Removes) != 0 || len(svc.pool) != 1 {
+					t.Fatalf("expected the balloon parked, removes %v pool %v", mock.Removes, svc.pool)
+				}
+				if last := mock.Moves[len(mock.Moves)-1]; last.ID != firstID || last.Alt != parkAltitudeFt {
+					t.Errorf("expected the balloon moved to the park altitude, got %+v", last)
+				}
+			}
+			if tt.dropped {
+				mock.Dropped[firstID] = true
+			}
+
+			if err := svc.SetTargets(ctx, b, objects); err != nil {
+				t.Fatalf("SetTargets failed: %v", err)
+			}
+			if len(mock.Spawns) != tt.wantSpawns {
+				t.Errorf("spawns = %d, want %d", len(mock.Spawns), tt.wantSpawns)
+			}
+			gotID := svc.spawnedBeacons[0].ID
+			if (gotID == firstID) != tt.wantReused {
+				t.Errorf("object %d reused = %v, want %v", firstID, gotID == firstID, tt.wantReused)
+			}
+			if tt.wantReused {
+				if last := mock.Moves[len(mock.Moves)-1]; last.ID != firstID || last.Lat != b[0].Lat || last.Lon != b[0].Lon {
+					t.Errorf("expected the pooled balloon moved to the new target, got %+v", last)
+				}
+			}
+		})
+	}
+}
+
+func TestPool_DrainedWhenDisabled(t *testing.T) {
+	mock := &MockClient{Tel: sim.Telemetry{Latitude: 45.0, Longitude: -73.0, AltitudeMSL: 3000, AltitudeAGL: 3000, Heading: 90}}
+	full := config.DefaultConfig()
+	full.Beacon.FormationEnabled = false
+	st := newMockStateStore()
+	svc := NewService(mock, slog.New(slog.NewTextHandler(io.Discard, nil)), config.NewProvider(full, st))
+	ctx := context.Background()
+
+	if err := svc.SetTargets(ctx, []geo.Point{{Lat: 45.0, Lon: -72.0}}, []TargetObject{{Title: "Balloon", Livery: "Red"}}); err != nil {
+		t.Fatalf("SetTargets failed: %v", err)
+	}
+	svc.Clear()
+	if len(svc.pool) != 1 {
+		t.Fatalf("expected one parked balloon, got %d", len(svc.pool))
+	}
+
+	// Disabling beacons must leave nothing behind in the sim
+	_ = st.SetState(ctx, config.KeyBeaconEnabled, "false")
+	svc.Clear()
+	if len(svc.pool) != 0 || len(mock.Removes) != 1 {
+		t.Errorf("expected the pool drained, pool %v removes %v", svc.pool, mock.Removes)
+	}
+}
+
+func TestObjectRemoved_ForgetsDroppedObjects(t *testing.T) {
+	objects := []TargetObject{{Title: "Balloon", Livery: "Red"}}
+	a := []geo.Point{{Lat: 45.0, Lon: -72.0}}
+	b := []geo.Point{{Lat: 45.1, Lon: -72.1}}
+
+	tests := []struct {
+		name   string
+		parked bool // Removed while parked rather than in use
+	}{
+		{name: "Parked object", parked: true},
+		{name: "Active object", parked: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &MockClient{Tel: sim.Telemetry{Latitude: 45.0, Longitude: -73.0, AltitudeMSL: 3000, AltitudeAGL: 3000, Heading: 90}}
+			cfg := config.DefaultConfig().Beacon
+			cfg.FormationEnabled = false
+			cfg.PoolSize = 4
+			svc := NewService(mock, slog.New(slog.NewTextHandler(io.Discard, nil)), testProv(&cfg))
+			ctx := context.Background()
+
+			if err := svc.SetTargets(ctx, a, objects); err != nil {
+				t.Fatalf("SetTargets failed: %v", err)
+			}
+			firstID := svc.spawnedBeacons[0].ID
+			if tt.parked {
+				svc.Clear()
+			}
+
+			// The sim drops the object without the position updates failing
+			svc.objectRemoved(firstID)
+			if len(svc.pool) != 0 || len(svc.spawnedBeacons) != 0 {
+				t.Fatalf("expected the object forgotten, pool %v spawned %v", svc.pool, svc.spawnedBeacons)
+			}
+
+			if err := svc.SetTargets(ctx, b, objects); err != nil {
+				t.Fatalf("SetTargets failed: %v", err)
+			}
+			if len(mock.Spawns) != 2 {
+				t.Errorf("spawns = %d, want 2", len(mock.Spawns))
+			}
+			if got := svc.spawnedBeacons[0].ID; got == firstID {
+				t.Errorf("expected a new object, got the removed %d again", got)
+			}
+		})
+	}
+}
@@ -19,6 +19,17 @@ const (
 	catDeadEnd = "__DEADEND__"
 )
 
+// DefaultMaxDepth is how many P279 layers the hierarchy search climbs above
+// an instance's direct parents.
+const DefaultMaxDepth = 4
+
+// Limits bound the hierarchy search, trading thoroughness for Wikidata
+// calls on exotic entities.
+type Limits struct {
+	MaxDepth        int // P279 layers to climb; 0 uses DefaultMaxDepth
+	MaxNodesVisited int // Classes visited per search; 0 is unlimited
+}
+
 // WikidataClient defines the interface for interacting with Wikidata
 type WikidataClient interface {
 	GetEntityClaims(ctx context.Context, id, property string) ([]string, string, error)
@@ -35,11 +46,21 @@ type Classifier struct {
 	tracker            *tracker.Tracker
 	regionalCategories config.CategoryLookup
 	regionalLabels     map[string]string
+	limits             Limits
 	mu                 sync.RWMutex
 }
 
-// NewClassifier creates a new classifier
-func NewClassifier(s store.HierarchyStore, c WikidataClient, cfg *config.CategoriesConfig, tr *tracker.Tracker) *Classifier {
+// NewClassifier creates a new classifier. Optional limits bound the
+// hierarchy search; without them it uses DefaultMaxDepth and visits any
+// number of classes.
+func NewClassifier(s store.HierarchyStore, c WikidataClient, cfg *config.CategoriesConfig, tr *tracker.Tracker, limits ...Limits) *Classifier {
+	var l Limits
+	if len(limits) > 0 {
+		l = limits[0]
+	}
+	if l.MaxDepth <= 0 {
+		l.MaxDepth = DefaultMaxDepth
+	}
 	return &Classifier{
 		store:          s,
 		client:         c,
@@ -47,6 +68,7 @@ func NewClassifier(s store.HierarchyStore, c WikidataClient, cfg *config.Categor
 		lookup:         cfg.BuildLookup(),
 		tracker:        tr,
 		regionalLabels: make(map[string]string),
+		limits:         l,
 	}
 }
 
@@ -102,10 +124,31 @@ type ExplanationResult struct {
 	Reason       string
 	MatchedQID   string
 	SitelinksMin int
+	// Abandoned names the limit that cut a hierarchy search short, if any
+	Abandoned string
+}
+
+// explainTraceKey carries an *explainTrace through the context of Explain,
+// so the hierarchy search can report why it gave up.
+type explainTraceKey struct{}
+
+type explainTrace struct {
+	abandoned string
+}
+
+// recordAbandoned notes in the Explain trace, if any, that a search hit a
+// limit. The first limit hit is kept.
+func recordAbandoned(ctx context.Context, reason string) {
+	if tr, ok := ctx.Value(explainTraceKey{}).(*explainTrace); ok && tr.abandoned == "" {
+		tr.abandoned = reason
+	}
 }
 
 // Explain analyzes a QID and returns details on why it was classified (or not).
 func (c *Classifier) Explain(ctx context.Context, qid string) (*ExplanationResult, error) {
+	trace := &explainTrace{}
+	ctx = context.WithValue(ctx, explainTraceKey{}, trace)
+
 	// 1. Instances (P31)
 	targets, _, err := c.client.GetEntityClaims(ctx, qid, "P31")
 	if err != nil {
@@ -156,6 +199,12 @@ func (c *Classifier) Explain(ctx context.Context, qid string) (*ExplanationResul
 		}, nil
 	}
 
+	if trace.abandoned != "" {
+		return &ExplanationResult{
+			Reason:    fmt.Sprintf("Traversed %d instances, search abandoned: %s", len(targets), trace.abandoned),
+			Abandoned: trace.abandoned,
+		}, nil
+	}
 	return &ExplanationResult{Reason: fmt.Sprintf("Traversed %d instances, no category match found", len(targets))}, nil
 }
 
@@ -201,6 +250,43 @@ func (c *Classifier) ClassifyBatch(ctx context.Context, entities map[string]wiki
 	return results
 }
 
+// Warm classifies instance types (classes) ahead of the articles that use
+// them, so their classification later hits the hierarchy cache. It stops
+// when ctx is done and returns how many of the types resolved without a
+// hierarchy walk before and after warming.
+func (c *Classifier) Warm(ctx context.Context, types []string) (cachedBefore, cachedAfter int) {
+	for _, qid := range types {
+		if c.isCached(ctx, qid) {
+			cachedBefore++
+		}
+	}
+	for _, qid := range types {
+		if ctx.Err() != nil {
+			break
+		}
+		if _, err := c.classifyHierarchyNode(ctx, qid); err != nil {
+			logging.TraceDefault("Warmup classification failed", "qid", qid, "err", err)
+		}
+	}
+	// Count with a fresh context: the warmup may have run out of time
+	for _, qid := range types {
+		if c.isCached(context.Background(), qid) {
+			cachedAfter++
+		}
+	}
+	return cachedBefore, cachedAfter
+}
+
+// isCached reports whether a class resolves from the config or the stored
+// classifications, without walking the hierarchy.
+func (c *Classifier) isCached(ctx context.Context, qid string) bool {
+	if _, _, ok := c.getLookupMatch(qid); ok {
+		return true
+	}
+	cat, found, err := c.store.GetClassification(ctx, qid)
+	return err == nil && found && cat != ""
+}
+
 // classifyHierarchyNode determines the category for a taxonomy QID (a class).
 // Results for these nodes ARE cached in the wikidata_hierarchy table.
 func (c *Classifier) classifyHierarchyNode(ctx context.Context, qid string) (*model.ClassificationResult, error) {
@@ -320,10 +406,19 @@ func (c *Classifier) searchHierarchyInternal(ctx context.Context, qid string, su
 	allTraversed = append(allTraversed, subclasses...)
 
 	queue := subclasses
-	maxDepth := 4
 	currentDepth := 1
 
-	for len(queue) > 0 && currentDepth <= maxDepth {
+	for len(queue) > 0 {
+		if reason := c.limitReached(currentDepth, len(visited)); reason != "" {
+			logging.TraceDefault("Hierarchy search abandoned", "qid", qid, "reason", reason)
+			recordAbandoned(ctx, reason)
+			// Not a dead end: the class may well match above the limit, and
+			// the limits can be raised. Keep the parents but no verdict, so
+			// the next lookup searches again from the cached structure.
+			_ = c.store.SaveClassification(ctx, qid, "", subclasses, label)
+			return nil, nil
+		}
+
 		// 1. Filter & Layer Scan: Check cache for matches/ignores
 		toFetch, parentsFromCache, layerMatch, layerIgnore, layerMatchRegional := c.scanLayerCacheInternal(ctx, queue, includeRegional)
 
@@ -373,7 +468,17 @@ func (c *Classifier) searchHierarchyInternal(ctx context.Context, qid string, su
 	return nil, nil
 }
 
-// propagateIgnored marks all nodes in the BFS path as __IGNORED__ to prevent
+// limitReached returns which search limit stops the next layer, if any.
+func (c *Classifier) limitReached(depth, visited int) string {
+	if depth > c.limits.MaxDepth {
+		return fmt.Sprintf("max depth %d reached", c.limits.MaxDepth)
+	}
+	if c.limits.MaxNodesVisited > 0 && visited > c.limits.MaxNodesVisited {
+		return fmt.Sprintf("max nodes visited %d reached", c.limits.MaxNodesVisited)
+	}
+	return ""
+}
+
 func (c *Classifier) scanLayerCacheInternal(ctx context.Context, queue []string, includeRegional bool) (toFetch []string, parentsFromCache map[string][]string, layerMatch, layerIgnore string, layerMatchRegional bool) {
 	toFetch = make([]string, 0, len(queue))
 	parentsFromCache = make(map[string][]string)
@@ -427,6 +532,7 @@ func (c *Classifier) checkFetchedForMatchesInternal(id string, parents []string,
 	return
 }
 
+// propagateIgnored marks all nodes in the BFS path as __IGNORED__ to prevent
 // future traversals from having to re-discover the same ignored chain.
 func (c *Classifier) propagateIgnored(ctx context.Context, nodes []string) {
 	for _, node := range nodes {
@@ -562,6 +668,11 @@ func (c *Classifier) finalizeMatch(ctx context.Context, qid, catName string, par
 }
 
 func (c *Classifier) resultFor(catName string) *model.ClassificationResult {
+	// Blocked categories are applied here rather than cached as ignored, so
+	// removing one from the config restores its entities without a DB reset.
+	if c.config.IsBlockedCategory(catName) {
+		return &model.ClassificationResult{Ignored: true}
+	}
 	cat, ok := c.config.Categories[catName]
 	size := "M" // Default
 	if ok {
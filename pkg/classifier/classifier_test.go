@@ -191,6 +191,9 @@ func (m *MockStore) SetGeodataCache(ctx context.Context, key string, val []byte,
 }
 func (m *MockStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error { return nil }
 func (m *MockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius float64) error { return nil }
+func (m *MockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	return nil, nil
+}
 func (m *MockStore) GetArticle(ctx context.Context, uuid string) (*model.Article, error) {
 	return nil, nil
 }
@@ -568,6 +571,88 @@ func TestClassifier_Explain(t *testing.T) {
 	}
 }
 
+func TestClassifier_Limits(t *testing.T) {
+	tests := []struct {
+		name          string
+		limits        classifier.Limits
+		wantCat       string
+		wantAbandoned string
+		wantBatches   int
+	}{
+		{name: "Configured depth stops early", limits: classifier.Limits{MaxDepth: 2}, wantAbandoned: "max depth 2 reached", wantBatches: 2},
+		{name: "Default depth is too shallow", wantAbandoned: "max depth 4 reached", wantBatches: 4},
+		{name: "Deeper limit finds the match", limits: classifier.Limits{MaxDepth: 10}, wantCat: "Match"},
+		{name: "Node limit stops early", limits: classifier.Limits{MaxNodesVisited: 3}, wantAbandoned: "max nodes visited 3 reached", wantBatches: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.CategoriesConfig{
+				Categories: map[string]config.Category{
+					"Match": {QIDs: map[string]string{"Q_MATCH_ROOT": "Match"}, Weight: 100},
+				},
+			}
+			st := &MockStore{Classifications: make(map[string]string)}
+			cl := &MockClient{Claims: make(map[string]map[string][]string)}
+			// Q_INST -> Q_C0 -> Q_C1 -> ... -> Q_C8 -> Q_MATCH_ROOT
+			cl.Claims["Q_INST"] = map[string][]string{"P31": {"Q_C0"}}
+			for i := 0; i < 8; i++ {
+				cl.Claims[fmt.Sprintf("Q_C%d", i)] = map[string][]string{"P279": {fmt.Sprintf("Q_C%d", i+1)}}
+			}
+			cl.Claims["Q_C8"] = map[string][]string{"P279": {"Q_MATCH_ROOT"}}
+
+			clf := classifier.NewClassifier(st, cl, cfg, tracker.New(), tt.limits)
+			exp, err := clf.Explain(context.Background(), "Q_INST")
+			if err != nil {
+				t.Fatalf("Explain failed: %v", err)
+			}
+			if exp.Category != tt.wantCat || exp.Abandoned != tt.wantAbandoned {
+				t.Errorf("got category %q, abandoned %q; want %q, %q", exp.Category, exp.Abandoned, tt.wantCat, tt.wantAbandoned)
+			}
+			if tt.wantAbandoned != "" && cl.BatchCalls != tt.wantBatches {
+				t.Errorf("expected %d layer fetches, got %d", tt.wantBatches, cl.BatchCalls)
+			}
+			// A search cut short proves nothing; it must not be cached as a dead end
+			for qid, cat := range st.Classifications {
+				if cat == "__DEADEND__" {
+					t.Errorf("%s cached as a dead end after an abandoned search", qid)
+				}
+			}
+		})
+	}
+}
+
+func TestClassifier_Warm(t *testing.T) {
+	cfg := &config.CategoriesConfig{
+		Categories: map[string]config.Category{
+			"Match": {QIDs: map[string]string{"Q_MATCH_ROOT": "Match"}, Weight: 100},
+		},
+	}
+	st := &MockStore{Classifications: make(map[string]string)}
+	cl := &MockClient{Claims: make(map[string]map[string][]string)}
+	cl.Claims["Q_TYPE_A"] = map[string][]string{"P279": {"Q_MID"}}
+	cl.Claims["Q_MID"] = map[string][]string{"P279": {"Q_MATCH_ROOT"}}
+	cl.Claims["Q_TYPE_B"] = map[string][]string{"P279": {"Q_NOWHERE"}}
+	clf := classifier.NewClassifier(st, cl, cfg, tracker.New())
+
+	// The configured root resolves without a walk from the start
+	before, after := clf.Warm(context.Background(), []string{"Q_MATCH_ROOT", "Q_TYPE_A", "Q_TYPE_B"})
+	if before != 1 || after != 3 {
+		t.Errorf("expected 1 -> 3 cached types, got %d -> %d", before, after)
+	}
+
+	// Articles of a warmed type classify without further API calls
+	cl.Claims["Q_ARTICLE"] = map[string][]string{"P31": {"Q_TYPE_A"}}
+	cl.SingleCalls, cl.BatchCalls = 0, 0
+	res, err := clf.Classify(context.Background(), "Q_ARTICLE")
+	if err != nil || res == nil || res.Category != "Match" {
+		t.Fatalf("expected Match, got %+v (err %v)", res, err)
+	}
+	if cl.SingleCalls != 1 || cl.BatchCalls != 0 {
+		t.Errorf("expected only the P31 lookup, got %d single and %d batch calls", cl.SingleCalls, cl.BatchCalls)
+	}
+}
+
 func TestClassifier_ClassifyBatch(t *testing.T) {
 	cfg := &config.CategoriesConfig{
 		Categories: map[string]config.Category{
@@ -845,3 +930,32 @@ func TestClassifier_IsCoveredByStaticConfig(t *testing.T) {
 		t.Errorf("Case 4: Expected static match to be found, got %v, %v", cat, covered)
 	}
 }
+
+func TestClassifier_BlockedCategory(t *testing.T) {
+	cfg := &config.CategoriesConfig{
+		Categories: map[string]config.Category{
+			"castle":    {QIDs: map[string]string{"Q_CASTLE": "Castle"}},
+			"aerodrome": {QIDs: map[string]string{"Q_AERO": "Aerodrome"}},
+		},
+		BlockedCategories: []string{"Aerodrome"},
+	}
+	st := &MockStore{
+		Classifications: make(map[string]string),
+		Hierarchies:     make(map[string]*model.WikidataHierarchy),
+	}
+	cl := &MockClient{Claims: map[string]map[string][]string{
+		"Q_ARTICLE_CASTLE": {"P31": {"Q_CASTLE"}},
+		"Q_ARTICLE_AERO":   {"P31": {"Q_AERO"}},
+	}}
+	clf := classifier.NewClassifier(st, cl, cfg, tracker.New())
+
+	res, err := clf.Classify(context.Background(), "Q_ARTICLE_CASTLE")
+	if err != nil || res == nil || res.Ignored || res.Category != "castle" {
+		t.Errorf("expected castle match, got %+v (err %v)", res, err)
+	}
+
+	res, err = clf.Classify(context.Background(), "Q_ARTICLE_AERO")
+	if err != nil || res == nil || !res.Ignored {
+		t.Errorf("expected blocked category to be ignored, got %+v (err %v)", res, err)
+	}
+}
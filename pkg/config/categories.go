@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"math"
 	"os"
 	"strings"
 
@@ -13,12 +14,26 @@ type CategoriesConfig struct {
 	Categories        map[string]Category `json:"categories" yaml:"categories"`
 	IgnoredCategories map[string]string   `json:"ignored_categories" yaml:"ignored_categories"`
 	MergeDistance     map[string]float64  `json:"merge_distance" yaml:"merge_distance"`
-	CategoryGroups    map[string][]string `json:"category_groups" yaml:"category_groups"`
+	// DefaultMergeRadiusKm is the global merge radius used when a size has no merge_distance entry.
+	DefaultMergeRadiusKm float64             `json:"default_merge_radius_km" yaml:"default_merge_radius_km"`
+	CategoryGroups       map[string][]string `json:"category_groups" yaml:"category_groups"`
+	// BlockedCategories never become POIs, regardless of score.
+	BlockedCategories []string `json:"blocked_categories" yaml:"blocked_categories"`
+	// IconSets are alternative icon themes: set name -> category or group name -> icon.
+	// The "default" set is each category's own icon.
+	IconSets map[string]map[string]string `json:"icon_sets" yaml:"icon_sets"`
 
 	// Internal lookup for O(1) group checking
 	GroupLookup map[string]string
 }
 
+const (
+	// DefaultIconSet selects each category's own icon.
+	DefaultIconSet = "default"
+	// GenericIcon is shown for POIs whose category has no icon.
+	GenericIcon = "marker"
+)
+
 // CategoryLookup maps QIDs to Category Names.
 type CategoryLookup map[string]string
 
@@ -31,6 +46,12 @@ type Category struct {
 	SitelinksMin int               `json:"sitelinks_min" yaml:"sitelinks_min"`
 	QIDs         map[string]string `json:"qids" yaml:"qids"`
 	Preground    bool              `json:"preground" yaml:"preground"` // Enable Sonar pregrounding for this category
+	Facts        []string          `json:"facts" yaml:"facts"`         // Wikidata properties (e.g. "P571") quoted as facts in narration prompts
+	Chime        string            `json:"chime" yaml:"chime"`         // Pre-narration chime file replacing the default for this category
+	// MergeRadiusKm overrides the size-based merge distance. nil means "use size", 0 means "never merge".
+	MergeRadiusKm *float64 `json:"merge_radius_km" yaml:"merge_radius_km"`
+	// Labels are display names keyed by language ("de") or locale ("de-CH").
+	Labels map[string]string `json:"labels" yaml:"labels"`
 }
 
 // BuildLookup creates a map of QID -> Category Name for fast lookups.
@@ -66,6 +87,14 @@ func LoadCategories(path string) (*CategoriesConfig, error) {
 	}
 	cfg.Categories = normalizedCats
 
+	for set, icons := range cfg.IconSets {
+		normalized := make(map[string]string, len(icons))
+		for k, v := range icons {
+			normalized[strings.ToLower(k)] = v
+		}
+		cfg.IconSets[set] = normalized
+	}
+
 	// Build Group Lookup
 	cfg.GroupLookup = make(map[string]string)
 	for groupName, cats := range cfg.CategoryGroups {
@@ -88,6 +117,50 @@ func (c *CategoriesConfig) GetWeight(category string) float64 {
 	return 1.0
 }
 
+// IconFor returns the icon for a category in the given icon set. A set may name
+// a category or its group; anything it leaves out uses the category's default
+// icon. Returns "" for categories without any icon, so callers can apply their
+// own fallbacks before GenericIcon.
+func (c *CategoriesConfig) IconFor(category, set string) string {
+	key := strings.ToLower(category)
+	if icons, ok := c.IconSets[set]; ok {
+		if icon := icons[key]; icon != "" {
+			return icon
+		}
+		if group, ok := c.GroupLookup[key]; ok {
+			if icon := icons[strings.ToLower(group)]; icon != "" {
+				return icon
+			}
+		}
+	}
+	if cat, ok := c.Categories[key]; ok {
+		return cat.Icon
+	}
+	return ""
+}
+
+// Label returns the display name of a category in lang, a language code or
+// locale such as "de-DE". The locale wins over its base language so regional
+// wording can differ; without a label the category key itself is used.
+func (c *CategoriesConfig) Label(category, lang string) string {
+	cat, ok := c.Categories[strings.ToLower(category)]
+	if !ok || len(cat.Labels) == 0 || lang == "" {
+		return category
+	}
+	for k, v := range cat.Labels {
+		if v != "" && strings.EqualFold(k, lang) {
+			return v
+		}
+	}
+	base, _, _ := strings.Cut(lang, "-")
+	for k, v := range cat.Labels {
+		if v != "" && strings.EqualFold(k, base) {
+			return v
+		}
+	}
+	return category
+}
+
 // GetSize returns the size for a category (default "M").
 func (c *CategoriesConfig) GetSize(category string) string {
 	if cat, ok := c.Categories[strings.ToLower(category)]; ok {
@@ -99,14 +172,28 @@ func (c *CategoriesConfig) GetSize(category string) string {
 	return "M"
 }
 
-// GetMergeDistance returns the merge distance in meters for a given size (default 500m).
+// GetMergeDistance returns the merge distance in meters for a given size.
+// Falls back to default_merge_radius_km, then 500m.
 func (c *CategoriesConfig) GetMergeDistance(size string) float64 {
 	if dist, ok := c.MergeDistance[size]; ok {
 		return dist
 	}
+	if c.DefaultMergeRadiusKm > 0 {
+		return c.DefaultMergeRadiusKm * 1000.0
+	}
 	return 500.0 // Default fallback
 }
 
+// GetMergeRadius returns the merge distance in meters for a category.
+// A per-category merge_radius_km takes precedence over the size-based distance,
+// so e.g. peaks can stay distinct while large districts still absorb their neighbours.
+func (c *CategoriesConfig) GetMergeRadius(category string) float64 {
+	if cat, ok := c.Categories[strings.ToLower(category)]; ok && cat.MergeRadiusKm != nil {
+		return math.Max(0, *cat.MergeRadiusKm) * 1000.0
+	}
+	return c.GetMergeDistance(c.GetSize(category))
+}
+
 // GetGroup returns the group name for a category, or empty string if none.
 func (c *CategoriesConfig) GetGroup(category string) string {
 	if group, ok := c.GroupLookup[strings.ToLower(category)]; ok {
@@ -115,6 +202,37 @@ func (c *CategoriesConfig) GetGroup(category string) string {
 	return ""
 }
 
+// IsBlockedCategory returns true if the category is on the permanent blocklist.
+func (c *CategoriesConfig) IsBlockedCategory(category string) bool {
+	if category == "" {
+		return false
+	}
+	for _, b := range c.BlockedCategories {
+		if strings.EqualFold(b, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// FactProperties returns the Wikidata properties to quote as facts for the
+// category, if any.
+func (c *CategoriesConfig) FactProperties(category string) []string {
+	if cat, ok := c.Categories[strings.ToLower(category)]; ok {
+		return cat.Facts
+	}
+	return nil
+}
+
+// ChimePath returns the pre-narration chime configured for the category, or
+// "" to use the default chime.
+func (c *CategoriesConfig) ChimePath(category string) string {
+	if cat, ok := c.Categories[strings.ToLower(category)]; ok {
+		return cat.Chime
+	}
+	return ""
+}
+
 // ShouldPreground returns true if the category has pregrounding enabled.
 func (c *CategoriesConfig) ShouldPreground(category string) bool {
 	if cat, ok := c.Categories[strings.ToLower(category)]; ok {
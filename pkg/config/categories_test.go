@@ -93,6 +93,87 @@ merge_distance:
 	}
 }
 
+func TestLoadCategories_IconSets(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "categories.yaml")
+	yamlContent := `
+category_groups:
+  Settlements: ["City", "Town"]
+categories:
+  City:
+    icon: "city"
+  Town:
+    icon: "town"
+  Peak:
+    icon: "mountain"
+icon_sets:
+  minimal:
+    Settlements: "circle"
+    Town: "square"
+`
+	if err := os.WriteFile(configPath, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("failed to create temp config: %v", err)
+	}
+	cfg, err := LoadCategories(configPath)
+	if err != nil {
+		t.Fatalf("LoadCategories failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		category string
+		set      string
+		want     string
+	}{
+		{name: "Default set", category: "City", set: DefaultIconSet, want: "city"},
+		{name: "Group entry", category: "city", set: "minimal", want: "circle"},
+		{name: "Category entry beats group", category: "Town", set: "minimal", want: "square"},
+		{name: "Missing entry uses default icon", category: "Peak", set: "minimal", want: "mountain"},
+		{name: "Unknown set", category: "Peak", set: "emoji", want: "mountain"},
+		{name: "Unknown category", category: "Shop", set: "minimal", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.IconFor(tt.category, tt.set); got != tt.want {
+				t.Errorf("IconFor(%q, %q) = %q, want %q", tt.category, tt.set, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetMergeRadius(t *testing.T) {
+	radius := func(km float64) *float64 { return &km }
+	cfg := &CategoriesConfig{
+		Categories: map[string]Category{
+			"peak":    {Size: "XL", MergeRadiusKm: radius(0.2)},
+			"volcano": {Size: "XL", MergeRadiusKm: radius(0)},
+			"city":    {Size: "XL"},
+			"shop":    {Size: "XS"},
+		},
+		MergeDistance:        map[string]float64{"XL": 5000},
+		DefaultMergeRadiusKm: 0.75,
+	}
+
+	tests := []struct {
+		name     string
+		category string
+		want     float64
+	}{
+		{name: "Per-category override", category: "Peak", want: 200},
+		{name: "Zero means never merge", category: "volcano", want: 0},
+		{name: "Size-based distance", category: "city", want: 5000},
+		{name: "Global default for unmapped size", category: "shop", want: 750},
+		{name: "Unknown category uses default size", category: "unknown", want: 750},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.GetMergeRadius(tt.category); got != tt.want {
+				t.Errorf("GetMergeRadius(%q) = %v, want %v", tt.category, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestShouldPreground(t *testing.T) {
 	cfg := &CategoriesConfig{
 		Categories: map[string]Category{
@@ -143,3 +224,36 @@ func TestShouldPreground(t *testing.T) {
 		})
 	}
 }
+
+func TestLabel(t *testing.T) {
+	cfg := &CategoriesConfig{
+		Categories: map[string]Category{
+			"aerodrome": {Labels: map[string]string{"de": "Flugplatz", "de-CH": "Flugfeld", "fr": "Aérodrome"}},
+			"castle":    {},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		category string
+		lang     string
+		want     string
+	}{
+		{name: "Base language", category: "Aerodrome", lang: "de", want: "Flugplatz"},
+		{name: "Locale falls back to language", category: "Aerodrome", lang: "de-DE", want: "Flugplatz"},
+		{name: "Locale wins over language", category: "Aerodrome", lang: "de-CH", want: "Flugfeld"},
+		{name: "Locale is case-insensitive", category: "Aerodrome", lang: "fr-fr", want: "Aérodrome"},
+		{name: "Missing language uses key", category: "Aerodrome", lang: "it-IT", want: "Aerodrome"},
+		{name: "Category without labels", category: "Castle", lang: "de-DE", want: "Castle"},
+		{name: "Unknown category", category: "Spaceport", lang: "de", want: "Spaceport"},
+		{name: "No language", category: "Aerodrome", lang: "", want: "Aerodrome"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.Label(tt.category, tt.lang); got != tt.want {
+				t.Errorf("Label(%q, %q) = %q, want %q", tt.category, tt.lang, got, tt.want)
+			}
+		})
+	}
+}
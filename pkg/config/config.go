@@ -15,6 +15,15 @@ import (
 // GUIConfig holds settings for the graphical user interface.
 type GUIConfig struct {
 	Window WindowConfig `yaml:"window"`
+	Tray   TrayConfig   `yaml:"tray"`
+}
+
+// TrayConfig holds settings for the GUI's notification area icon.
+type TrayConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// CloseToTray hides the window on close instead of exiting; the tray
+	// menu's Exit quits. Ignored while the tray icon is disabled.
+	CloseToTray bool `yaml:"close_to_tray"`
 }
 
 // WindowConfig holds initial window dimensions.
@@ -36,6 +45,7 @@ type Config struct {
 	DB          DBConfig          `yaml:"db"`
 	Server      ServerConfig      `yaml:"server"`
 	Ticker      TickerConfig      `yaml:"ticker"`
+	Scheduler   SchedulerConfig   `yaml:"scheduler"`
 	Triggers    TriggersConfig    `yaml:"triggers"`
 	Wikidata    WikidataConfig    `yaml:"wikidata"`
 	Terrain     TerrainConfig     `yaml:"terrain"`
@@ -46,16 +56,45 @@ type Config struct {
 	Transponder TransponderConfig `yaml:"transponder"`
 	Beacon      BeaconConfig      `yaml:"beacon"`
 	Overlay     OverlayConfig     `yaml:"overlay"`
+	Session     SessionConfig     `yaml:"session"`
+	POI         POIConfig         `yaml:"poi"`
+}
+
+// POIConfig holds settings for the in-memory POI tracking.
+type POIConfig struct {
+	// MaxTracked caps the POIs held in memory; beyond it the least relevant
+	// are dropped, independent of the periodic eviction. 0 disables the cap.
+	MaxTracked int `yaml:"max_tracked"`
+}
+
+// SessionConfig holds settings for the flight session lifecycle.
+type SessionConfig struct {
+	HomeAirport HomeAirportConfig `yaml:"home_airport"`
+}
+
+// HomeAirportConfig marks the airport a flight is expected to end at. When
+// enabled, the debriefing waits for a settled landing inside Radius instead
+// of following any landing.
+type HomeAirportConfig struct {
+	Enabled bool     `yaml:"enabled"`
+	Lat     float64  `yaml:"lat"`
+	Lon     float64  `yaml:"lon"`
+	Radius  Distance `yaml:"radius"`
+	Dwell   Duration `yaml:"dwell"` // Time on the ground inside Radius before the debrief; filters touch-and-goes
 }
 
 // OverlayConfig holds settings for the overlay UI.
 type OverlayConfig struct {
-	MapBox               bool `yaml:"map_box"`
-	POIInfo              bool `yaml:"poi_info"`
-	InfoBar              bool `yaml:"info_bar"`
-	LogLine              bool `yaml:"log_line"`
-	SettlementLabelLimit int  `yaml:"settlement_label_limit"`
-	SettlementTier       int  `yaml:"settlement_tier"`
+	MapBox               bool   `yaml:"map_box"`
+	POIInfo              bool   `yaml:"poi_info"`
+	InfoBar              bool   `yaml:"info_bar"`
+	LogLine              bool   `yaml:"log_line"`
+	SettlementLabelLimit int    `yaml:"settlement_label_limit"`
+	SettlementTier       int    `yaml:"settlement_tier"`
+	IconSet              string `yaml:"icon_set"` // POI icon set from categories.yaml icon_sets ("default" uses each category's icon)
+	// MapMinScore hides tracked POIs scoring below it from the map. It only
+	// declutters the display; narration still considers them. 0 shows all.
+	MapMinScore float64 `yaml:"map_min_score"`
 }
 
 // RequestConfig holds HTTP request settings.
@@ -63,6 +102,7 @@ type RequestConfig struct {
 	Retries int           `yaml:"retries"`
 	Timeout Duration      `yaml:"timeout"`
 	Backoff BackoffConfig `yaml:"backoff"`
+	Breaker BreakerConfig `yaml:"breaker"`
 }
 
 // BackoffConfig holds exponential backoff settings.
@@ -71,13 +111,28 @@ type BackoffConfig struct {
 	MaxDelay  Duration `yaml:"max_delay"`
 }
 
+// BreakerConfig holds the per-host circuit breaker settings.
+type BreakerConfig struct {
+	Threshold int      `yaml:"threshold"` // Consecutive failures that open the breaker
+	Cooldown  Duration `yaml:"cooldown"`  // How long an open breaker fails fast
+}
+
 // SimConfig holds settings for the simulation connection.
 type SimConfig struct {
-	Provider          string        `yaml:"provider"` // "simconnect", "mock"
-	ProcessName       string        `yaml:"process_name"`
-	ReconnectInterval Duration      `yaml:"reconnect_interval"`
-	TeleportThreshold Distance      `yaml:"teleport_distance"`
-	Mock              MockSimConfig `yaml:"mock"`
+	Provider          string         `yaml:"provider"` // "simconnect", "mock"
+	ProcessName       string         `yaml:"process_name"`
+	ReconnectInterval Duration       `yaml:"reconnect_interval"`
+	TeleportThreshold Distance       `yaml:"teleport_distance"`
+	Mock              MockSimConfig  `yaml:"mock"`
+	Recorder          RecorderConfig `yaml:"recorder"`
+}
+
+// RecorderConfig holds settings for the flight recorder, which keeps a dense
+// position track for replay, GPX export and log analysis.
+type RecorderConfig struct {
+	Enabled    bool     `yaml:"enabled"`
+	Interval   Duration `yaml:"interval"`    // Time between samples
+	MaxSamples int      `yaml:"max_samples"` // Oldest samples are dropped beyond this; 0 records nothing
 }
 
 // MockSimConfig holds settings for the mock simulation.
@@ -89,6 +144,7 @@ type MockSimConfig struct {
 	DurationParked Duration `yaml:"duration_parked"`
 	DurationTaxi   Duration `yaml:"duration_taxi"`
 	DurationHold   Duration `yaml:"duration_hold"`
+	SimRate        float64  `yaml:"sim_rate"` // Time acceleration, for testing pacing at 2x or 4x; 0 is real time
 }
 
 // BeaconConfig holds settings for the beacon guidance system.
@@ -104,15 +160,50 @@ type BeaconConfig struct {
 	TargetSinkDistanceClose Distance       `yaml:"target_sink_distance_close"`
 	TargetFloorAGL          Distance       `yaml:"target_floor_agl"`
 	MaxTargets              int            `yaml:"max_targets"`
+	PoolSize                int            `yaml:"pool_size"`   // Removed balloons parked for reuse instead of despawned; 0 disables
+	MarkQueued              bool           `yaml:"mark_queued"` // Also mark queued POIs, highlighting the current one
 	RegistryPath            string         `yaml:"registry_path"`
 	Registry                BeaconRegistry `yaml:"-"` // Loaded on startup
 	RegistryOrder           []string       `yaml:"-"` // Color rotation order
 }
 
+// TokenPrice is an LLM provider's price in USD per million tokens. Output
+// tokens are typically several times the price of input tokens.
+type TokenPrice struct {
+	Input  float64 `yaml:"input"`
+	Output float64 `yaml:"output"`
+}
+
+// TokenPrices splits CostPerMTok into input and output rate tables.
+func (c *LLMConfig) TokenPrices() (input, output map[string]float64) {
+	input = make(map[string]float64, len(c.CostPerMTok))
+	output = make(map[string]float64, len(c.CostPerMTok))
+	for name, p := range c.CostPerMTok {
+		input[name] = p.Input
+		output[name] = p.Output
+	}
+	return input, output
+}
+
 // LLMConfig holds settings for the Large Language Model providers.
 type LLMConfig struct {
-	Providers map[string]ProviderConfig `yaml:"providers"` // Map of named providers
-	Fallback  []string                  `yaml:"fallback"`  // Ordered list of providers for failover
+	Providers        map[string]ProviderConfig `yaml:"providers"`         // Map of named providers
+	Fallback         []string                  `yaml:"fallback"`          // Ordered list of providers for failover
+	GenerateTimeout  Duration                  `yaml:"generate_timeout"`  // Deadline for a POI script generation; 0 disables
+	TemplateFallback bool                      `yaml:"template_fallback"` // Speak a template blurb when script generation fails
+	CostPerMTok      map[string]TokenPrice     `yaml:"cost_per_mtok"`     // USD per million tokens, by provider name
+	DegradedCooldown Duration                  `yaml:"degraded_cooldown"` // How long a repeatedly failing provider is tried last; 0 disables
+
+	// OnEmptyScript is what happens when a script comes back empty, shorter
+	// than MinScriptWords or as a refusal: retry, template or skip.
+	OnEmptyScript  string `yaml:"on_empty_script"`
+	MinScriptWords int    `yaml:"min_script_words"` // 0 only rejects empty scripts
+
+	// PromptTokenBudget caps the estimated size of a script prompt. Larger
+	// prompts give up the PromptTrimOrder sections, first to last, until
+	// they fit, rather than failing against the model's context limit.
+	PromptTokenBudget int      `yaml:"prompt_token_budget"` // 0 disables trimming
+	PromptTrimOrder   []string `yaml:"prompt_trim_order"`   // Prompt data keys, least important first
 }
 
 // ProviderConfig holds configuration for a single LLM provider.
@@ -124,12 +215,14 @@ type ProviderConfig struct {
 	FreeTier        bool              `yaml:"free_tier"`        // Whether this is a free tier (usually shared)
 	Timeout         Duration          `yaml:"timeout"`          // Request timeout
 	ProviderBackoff bool              `yaml:"provider_backoff"` // Whether to backoff the whole provider on error
+	SafetySettings  map[string]string `yaml:"safety_settings"`  // Gemini only: harm category -> block threshold
 }
 
 // EdgeTTSConfig holds settings for Edge TTS.
 type EdgeTTSConfig struct {
-	VoiceID  string `yaml:"voice"`     // e.g. "en-US-AvaMultilingualNeural"
-	FreeTier bool   `yaml:"free_tier"` // Default true for Edge
+	VoiceID          string `yaml:"voice"`           // e.g. "en-US-AvaMultilingualNeural"
+	PassengerVoiceID string `yaml:"passenger_voice"` // Second voice for dialogue scripts
+	FreeTier         bool   `yaml:"free_tier"`       // Default true for Edge
 }
 
 // FishAudioConfig holds settings for Fish Audio TTS.
@@ -142,10 +235,11 @@ type FishAudioConfig struct {
 
 // AzureSpeechConfig holds settings for Azure Speech TTS.
 type AzureSpeechConfig struct {
-	Key      string `yaml:"-"`
-	Region   string `yaml:"-"`
-	VoiceID  string `yaml:"voice"`
-	FreeTier bool   `yaml:"free_tier"`
+	Key              string `yaml:"-"`
+	Region           string `yaml:"-"`
+	VoiceID          string `yaml:"voice"`
+	PassengerVoiceID string `yaml:"passenger_voice"` // Second voice for dialogue scripts
+	FreeTier         bool   `yaml:"free_tier"`
 }
 
 // TTSConfig holds Text-To-Speech settings.
@@ -154,6 +248,12 @@ type TTSConfig struct {
 	EdgeTTS     EdgeTTSConfig     `yaml:"edge_tts"`
 	FishAudio   FishAudioConfig   `yaml:"fish_audio"`
 	AzureSpeech AzureSpeechConfig `yaml:"azure_speech"`
+	// MaxConcurrent caps the syntheses running at once; further calls queue.
+	// 0 removes the cap.
+	MaxConcurrent int `yaml:"max_concurrent"`
+	// CostPerMChar is the price in USD per million characters, keyed by
+	// tracker name ("azure-speech", "fish-audio", "edge-tts", "sapi").
+	CostPerMChar map[string]float64 `yaml:"cost_per_mchar"`
 }
 
 // EssayConfig holds settings for essay narration.
@@ -162,20 +262,62 @@ type EssayConfig struct {
 	DelayBetweenEssays Duration `yaml:"delay_between_essays"`
 	DelayBeforeEssay   Duration `yaml:"delay_before_essay"`
 	ScoreThreshold     float64  `yaml:"score_threshold"`
+	RegionalTopics     bool     `yaml:"regional_topics"`    // Prefer topics tagged for the region below
+	ThemedSessions     bool     `yaml:"themed_sessions"`    // Prefer the topics of a few themes chosen per session
+	ThemesPerSession   int      `yaml:"themes_per_session"` // Number of themes chosen per session
+	// Explorer fills featureless stretches (desert, tundra) with essays
+	// sooner: with no POI within ExplorerRadius, ExplorerDelay replaces the
+	// usual silence before an essay.
+	Explorer       bool     `yaml:"explorer"`
+	ExplorerRadius Distance `yaml:"explorer_radius"`
+	ExplorerDelay  Duration `yaml:"explorer_delay"`
+	// MinAGL keeps essays, which don't point at anything outside, for when
+	// the aircraft is high enough for a wide view.
+	MinAGL Distance `yaml:"min_agl"`
+	// OnTopicsExhausted decides what happens once every topic has been played
+	// this session: "reset" starts another round, "stop" ends the essays.
+	OnTopicsExhausted string `yaml:"on_topics_exhausted"`
 }
 
+// Essay topic exhaustion policies.
+const (
+	EssayExhaustedReset = "reset" // Start another round of topics
+	EssayExhaustedStop  = "stop"  // No more essays this session
+)
+
 // AudioEffectsConfig holds settings for audio post-processing.
 type AudioEffectsConfig struct {
 	Headset    bool    `yaml:"headset"`
 	LowCutoff  float64 `yaml:"low_cutoff"`
 	HighCutoff float64 `yaml:"high_cutoff"`
+	// CrossfadeMs blends the tail of a narration into the next queued one.
+	// 0 plays queued narrations back-to-back without overlap.
+	CrossfadeMs    int     `yaml:"crossfade_ms"`
+	AmbiencePath   string  `yaml:"ambience_path"`   // Looped background track; empty disables it
+	AmbienceVolume float64 `yaml:"ambience_volume"` // Ambience level relative to the narration volume
+	DuckLevel      float64 `yaml:"duck_level"`      // Ambience multiplier while a narration plays
+	// NormalizeTargetDb scales each narration clip so its peak sits at this
+	// level in dBFS (e.g. -3). 0 plays clips as the TTS engine delivered them.
+	NormalizeTargetDb float64 `yaml:"normalize_target_db"`
+	// PreNarrationChime is a short cue that announces a narration is about to start.
+	// Categories may name their own chime file in categories.yaml.
+	PreNarrationChime ChimeConfig `yaml:"pre_narration_chime"`
+}
+
+// ChimeConfig holds settings for the cue played before each narration.
+type ChimeConfig struct {
+	Enabled bool    `yaml:"enabled"`
+	Path    string  `yaml:"path"`   // MP3 or WAV file
+	Volume  float64 `yaml:"volume"` // Chime level relative to the narration volume
 }
 
 // NarratorConfig holds settings for the AI narrator.
 type NarratorConfig struct {
 	AutoNarrate               bool               `yaml:"auto_narrate"`
 	MinScoreThreshold         float64            `yaml:"min_score_threshold"`
-	Frequency                 int                `yaml:"frequency"` // 1=Rarely, 2=Normal, 3=Active, 4=Hyperactive
+	AdaptiveMargin            float64            `yaml:"adaptive_margin"` // Fraction above target_poi_count before the adaptive threshold rises
+	AdaptiveWindow            int                `yaml:"adaptive_window"` // Scoring passes below target before the adaptive threshold drops
+	Frequency                 int                `yaml:"frequency"`       // 1=Rarely, 2=Normal, 3=Active, 4=Hyperactive
 	PauseDuration             Duration           `yaml:"pause_between_narrations"`
 	RepeatTTL                 Duration           `yaml:"repeat_ttl"`
 	TakeoffDelay              Duration           `yaml:"delay_after_takeoff"`
@@ -183,6 +325,7 @@ type NarratorConfig struct {
 	ActiveTargetLanguage      string             `yaml:"active_target_language"`
 	TargetLanguageLibrary     []string           `yaml:"target_language_library"`
 	Units                     string             `yaml:"units"`
+	UnitDimensions            UnitDimensions     `yaml:"unit_dimensions"`              // Per-dimension overrides of Units
 	NarrationLengthShortWords int                `yaml:"narration_length_short_words"` // Target for short narrations (default 50)
 	NarrationLengthLongWords  int                `yaml:"narration_length_long_words"`  // Target for long narrations (default 200)
 	SummaryMaxWords           int                `yaml:"summary_max_words"`            // Max words for the trip summary (default 500)
@@ -194,19 +337,180 @@ type NarratorConfig struct {
 	Screenshot                ScreenshotConfig   `yaml:"screenshot"`
 	AudioEffects              AudioEffectsConfig `yaml:"audio_effects"`
 	Border                    BorderConfig       `yaml:"border"`
+	OpenWater                 OpenWaterConfig    `yaml:"open_water"`
 	StyleLibrary              []string           `yaml:"style_library"`
 	ActiveStyle               string             `yaml:"active_style"`
 	SecretWordLibrary         []string           `yaml:"secret_word_library"`
 	ActiveSecretWord          string             `yaml:"active_secret_word"`
 	ActiveMapStyle            string             `yaml:"active_map_style"`
 	TwoPassScriptGeneration   bool               `yaml:"two_pass_script_generation"`
+	VehicleMode               string             `yaml:"vehicle_mode"`         // aircraft, ground, marine
+	MinGroundSpeedKts         float64            `yaml:"min_ground_speed_kts"` // Auto-narration gate; 0 disables
+	POIMinAGL                 Distance           `yaml:"poi_min_agl"`          // Aircraft height below which POIs aren't auto-narrated; 0 disables
+	MinArticleLength          int                `yaml:"min_article_length"`   // Auto-narration gate on WP article chars; 0 disables
+	NoArticle                 string             `yaml:"no_article"`           // POIs without a WP article: narrate, skip or dimensions
+	StreamScripts             bool               `yaml:"stream_scripts"`       // Stream POI scripts and start TTS per sentence
+	WikidataFacts             bool               `yaml:"wikidata_facts"`       // Add the category's Wikidata facts (categories.yaml) to POI prompts
+	CacheScripts              bool               `yaml:"cache_scripts"`        // Reuse generated POI scripts for identical prompts
+	ScriptCacheTTL            Duration           `yaml:"script_cache_ttl"`     // Age after which a cached script is regenerated
+	Approach                  ApproachConfig     `yaml:"approach"`
+	ForwardArc                ForwardArcConfig   `yaml:"forward_arc"`
+	MinDistanceBetweenPOIsKm  float64            `yaml:"min_distance_between_pois_km"` // Auto-selection spacing from the last narrated POI; 0 disables
+	MinDistanceMaxSilence     Duration           `yaml:"min_distance_max_silence"`     // Silence after which the spacing is waived
+	PredictionWindow          Duration           `yaml:"prediction_window"`            // Minimum lookahead of the predicted position; grows with LLM latency
+	ScaleWithSimRate          bool               `yaml:"scale_with_sim_rate"`          // Shorten auto-narration waits under sim time acceleration
+	WikipediaExtract          WPExtractConfig    `yaml:"wikipedia_extract"`
+	Translation               TranslationConfig  `yaml:"translation"`
+	Comms                     CommsConfig        `yaml:"comms"`
+	Tour                      TourConfig         `yaml:"tour"`
+	Priority                  PriorityConfig     `yaml:"priority"`
+	Gap                       GapConfig          `yaml:"gap"`
+	PaceLookahead             Duration           `yaml:"pace_lookahead"`     // Time to the next candidate at which narration length is unscaled; 0 disables
+	SessionBudgetUSD          float64            `yaml:"session_budget_usd"` // Estimated API spend per session after which auto-narration stops; 0 disables
+	ShutdownGrace             Duration           `yaml:"shutdown_grace"`     // How long shutdown waits for the playing narration to finish; 0 cuts it off
+	// A POI is kept short when more than DominanceRivalCount POIs (itself
+	// included) score above DominanceRivalFraction of its score.
+	DominanceRivalFraction float64 `yaml:"dominance_rival_fraction"`
+	DominanceRivalCount    int     `yaml:"dominance_rival_count"`
+	// DialogueMode writes scripts as a guide/passenger conversation. Engines
+	// with a passenger voice configured speak each turn in its own voice.
+	DialogueMode bool `yaml:"dialogue_mode"`
+	// AutoFollowCountryLanguage narrates in the primary language of the
+	// country below each POI instead of ActiveTargetLanguage.
+	AutoFollowCountryLanguage bool `yaml:"auto_follow_country_language"`
+	// NightToning lets prompts adapt to darkness (city lights, stars, a
+	// quieter delivery), judged from the sun's position at the sim's time.
+	NightToning bool `yaml:"night_toning"`
+	// AvoidRepetition lists the recently narrated nearby POIs in the prompt
+	// with a directive not to repeat what was said about them, on top of
+	// the reference context.
+	AvoidRepetition bool `yaml:"avoid_repetition"`
+	// RelevanceFit shortens, or skips, automated POI narrations that would
+	// still be playing after the aircraft has left the POI behind.
+	RelevanceFit RelevanceFitConfig `yaml:"relevance_fit"`
 }
 
+// UnitDimensions picks metric or imperial units for one dimension at a time,
+// e.g. feet for altitude with kilometres for ground distances. An empty
+// dimension follows Narrator.Units, where hybrid means metric distances and
+// imperial altitudes.
+type UnitDimensions struct {
+	Distance string `yaml:"distance"` // metric, imperial
+	Altitude string `yaml:"altitude"` // metric, imperial
+}
+
+// RelevanceFitConfig sizes POI narrations to the time the aircraft has left
+// within Radius of the POI, estimated from groundspeed, track and the
+// average generation latency.
+type RelevanceFitConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Radius   Distance `yaml:"radius"`    // Range within which a narration about the POI stays relevant
+	MinWords int      `yaml:"min_words"` // Narrations that would have to be shorter are skipped
+}
+
+// WPExtractConfig caps the Wikipedia article text placed into prompts.
+// Languages overrides MaxChars per Wikipedia language code (as in
+// languages.yaml), since dense scripts cost more tokens per character.
+type WPExtractConfig struct {
+	MaxChars  int            `yaml:"max_chars"` // 0 disables truncation
+	Languages map[string]int `yaml:"languages"`
+	// MissingTTL is how long an article found missing isn't requested again
+	MissingTTL Duration `yaml:"missing_ttl"`
+}
+
+// TranslationConfig holds settings for translating the Wikipedia extract
+// into the narration language before prompt assembly, so the narration LLM
+// doesn't have to translate inline.
+type TranslationConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Profile string `yaml:"profile"` // LLM profile doing the translation
+}
+
+// TourConfig holds settings for guided tours, which narrate a curated list
+// of POIs in order instead of the best-scoring ones.
+type TourConfig struct {
+	Path   string   `yaml:"path"`   // YAML list of QIDs started at launch; empty starts none
+	Radius Distance `yaml:"radius"` // Range at which the current stop is narrated
+}
+
+// PriorityConfig lists landmarks that are always narrated once in range,
+// whatever their score.
+type PriorityConfig struct {
+	QIDs   []string `yaml:"qids"`
+	Radius Distance `yaml:"radius"` // Range at which a priority landmark jumps the queue
+}
+
+// GapConfig sets the silence enforced between auto-narrations. The gap grows
+// by FatigueStep for every narration beyond the first that is still "recent",
+// with each narration's weight halving every HalfLife, so a burst of
+// narrations is followed by a longer break that relaxes again after quiet.
+type GapConfig struct {
+	Min         Duration `yaml:"min"`          // Silence after every narration; 0 allows back-to-back
+	FatigueStep Duration `yaml:"fatigue_step"` // Extra silence per recent narration; 0 disables the ramp
+	Max         Duration `yaml:"max"`          // Upper bound of the gap; 0 is unbounded
+	HalfLife    Duration `yaml:"half_life"`    // How fast a narration stops counting as recent
+}
+
+// CommsConfig holds settings for getting narration out of the way of
+// radio calls, either on request through the API or, with AutoDetect, while
+// the sim reports the pilot transmitting.
+type CommsConfig struct {
+	Mode       string   `yaml:"mode"`        // duck or pause
+	DuckLevel  float64  `yaml:"duck_level"`  // Narration volume while ducked (0-1)
+	AutoDetect bool     `yaml:"auto_detect"` // Follow the sim's push-to-talk state
+	MaxHold    Duration `yaml:"max_hold"`    // A hold not ended by then is released, in case the resume never comes; 0 waits for it
+}
+
+// Hold modes for CommsConfig.Mode.
+const (
+	HoldModeDuck  = "duck"
+	HoldModePause = "pause"
+)
+
+// ApproachConfig holds settings for two-phase landmark narration: a short
+// "coming up" alert on approach, then the full narration once abeam.
+type ApproachConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Radius   Distance `yaml:"radius"`    // Range at which the alert is spoken
+	MinScore float64  `yaml:"min_score"` // Lower-scoring POIs keep the single narration
+}
+
+// ForwardArcConfig keeps automatic narration to what lies ahead and abeam.
+type ForwardArcConfig struct {
+	Enabled            bool    `yaml:"enabled"`
+	MaxRelativeBearing float64 `yaml:"max_relative_bearing"` // Degrees either side of the nose; POIs further aft are not picked
+}
+
+// Vehicle modes for NarratorConfig.VehicleMode.
+const (
+	VehicleModeAircraft = "aircraft"
+	VehicleModeGround   = "ground"
+	VehicleModeMarine   = "marine"
+)
+
+// Unit systems for NarratorConfig.Units and UnitDimensions.
+const (
+	UnitsMetric   = "metric"
+	UnitsImperial = "imperial"
+	UnitsHybrid   = "hybrid"
+)
+
 // BorderConfig holds settings for border crossing announcements.
 type BorderConfig struct {
 	Enabled        bool     `yaml:"enabled"`
 	CooldownAny    Duration `yaml:"cooldown_any"`
 	CooldownRepeat Duration `yaml:"cooldown_repeat"`
+	PreAnnounce    bool     `yaml:"pre_announce"`    // Announce an upcoming country crossing ahead of time
+	PreAnnounceAt  Distance `yaml:"pre_announce_at"` // Look-ahead along the current heading
+}
+
+// OpenWaterConfig holds settings for the remark made over open sea, where
+// there are no POIs to narrate.
+type OpenWaterConfig struct {
+	Enabled     bool     `yaml:"enabled"`
+	MinDistance Distance `yaml:"min_distance"` // Distance from the nearest land before the sea counts as open
+	Cooldown    Duration `yaml:"cooldown"`     // Minimum time between remarks
+	Lakes       bool     `yaml:"lakes"`        // Also remark over large inland waters such as the Caspian
 }
 
 // DebriefingConfig holds settings for landing debriefs.
@@ -218,8 +522,32 @@ type DebriefingConfig struct {
 type ScreenshotConfig struct {
 	Enabled bool     `yaml:"enabled"`
 	Paths   []string `yaml:"paths"` // Multi-path support (e.g. MSFS, Steam, ReShade)
+	Mode    string   `yaml:"mode"`  // What a screenshot narrates: describe, poi, essay or heading
 }
 
+// Treatments for NarratorConfig.NoArticle, which applies to POIs rescued for
+// their physical size that have no WP article to narrate from.
+const (
+	NoArticleNarrate    = "narrate"    // The regular script, from whatever the model knows
+	NoArticleSkip       = "skip"       // Leave them out of auto-narration
+	NoArticleDimensions = "dimensions" // A short remark built on their Wikidata dimensions
+)
+
+// Actions for LLMConfig.OnEmptyScript.
+const (
+	EmptyScriptRetry    = "retry"    // Ask once more with a simplified prompt, then skip
+	EmptyScriptTemplate = "template" // Speak the template blurb
+	EmptyScriptSkip     = "skip"     // Drop the narration and put the POI on cooldown
+)
+
+// Narration scopes for ScreenshotConfig.Mode.
+const (
+	ScreenshotModeDescribe = "describe" // Identify the subject at the center of the image
+	ScreenshotModePOI      = "poi"      // Narrate the best POI in view
+	ScreenshotModeEssay    = "essay"    // An essay about the region
+	ScreenshotModeHeading  = "heading"  // Describe the view in the direction of travel
+)
+
 // TransponderConfig holds settings for transponder-based control.
 type TransponderConfig struct {
 	Enabled     bool   `yaml:"enabled"`
@@ -247,6 +575,7 @@ type DBConfig struct {
 // ServerConfig holds HTTP server settings.
 type ServerConfig struct {
 	Address string `yaml:"address"`
+	Metrics bool   `yaml:"metrics"` // Serve GET /metrics for Prometheus scraping
 }
 
 // TickerConfig holds ticker settings.
@@ -254,6 +583,58 @@ type TickerConfig struct {
 	TelemetryLoop Duration `yaml:"telemetry_loop"`
 }
 
+// SchedulerConfig holds the tick intervals of periodic jobs, keyed by job
+// name. Slowing jobs down helps on low-power machines; jobs left out run at
+// their default interval.
+type SchedulerConfig struct {
+	Intervals map[string]Duration `yaml:"intervals"`
+}
+
+// Job names for SchedulerConfig.Intervals.
+const (
+	JobPOIScoring    = "POIScoring"
+	JobAnnouncements = "Announcements"
+	JobCacheCleanup  = "CacheCleanup"
+	JobRiver         = "RiverJob"
+)
+
+var defaultJobIntervals = map[string]time.Duration{
+	JobPOIScoring:    5 * time.Second,
+	JobAnnouncements: 1 * time.Second,
+	JobCacheCleanup:  10 * time.Second,
+	JobRiver:         15 * time.Second,
+}
+
+// Interval returns the configured interval of job, falling back to its
+// default. It returns 0 for a job that is neither configured nor known.
+func (c SchedulerConfig) Interval(job string) time.Duration {
+	if d, ok := c.Intervals[job]; ok {
+		return time.Duration(d)
+	}
+	return defaultJobIntervals[job]
+}
+
+func defaultIntervals() map[string]Duration {
+	m := make(map[string]Duration, len(defaultJobIntervals))
+	for job, d := range defaultJobIntervals {
+		m[job] = Duration(d)
+	}
+	return m
+}
+
+func (c SchedulerConfig) validate() error {
+	for job, d := range c.Intervals {
+		// A misspelt name would otherwise be ignored without a word
+		if _, ok := defaultJobIntervals[job]; !ok {
+			return fmt.Errorf("scheduler interval for unknown job %q", job)
+		}
+		if d <= 0 {
+			return fmt.Errorf("scheduler interval for %s must be positive, got %s", job, time.Duration(d))
+		}
+	}
+	return nil
+}
+
 // TriggersConfig holds job scheduling thresholds.
 type TriggersConfig struct {
 	Distance Distance `yaml:"distance"`
@@ -262,9 +643,65 @@ type TriggersConfig struct {
 
 // WikidataConfig holds Wikidata-specific settings.
 type WikidataConfig struct {
-	Area          AreaConfig   `yaml:"area"`
-	FetchInterval Duration     `yaml:"fetch_interval"`
-	Rescue        RescueConfig `yaml:"rescue"`
+	Area          AreaConfig          `yaml:"area"`
+	FetchInterval Duration            `yaml:"fetch_interval"`
+	Rescue        RescueConfig        `yaml:"rescue"`
+	ArticleLength ArticleLengthConfig `yaml:"article_length"`
+	HeadingCone   HeadingConeConfig   `yaml:"heading_cone"`
+	// DefaultSitelinksMin applies to categories without a sitelinks_min of
+	// their own, so obscure entities don't slip through unconfigured ones.
+	DefaultSitelinksMin int `yaml:"default_sitelinks_min"`
+	// MaxSitelinksMin caps any category's sitelinks_min; 0 disables the cap.
+	MaxSitelinksMin int `yaml:"max_sitelinks_min"`
+	// TimeoutRetries is how often a timed-out tile query is retried with
+	// half the previous LIMIT (starting from area.max_articles).
+	TimeoutRetries int `yaml:"timeout_retries"`
+	// TileRadiusKm sizes the hex tiles fetched per SPARQL query, from center
+	// to corner: larger suits sparse areas, smaller dense cities. It picks
+	// the nearest H3 resolution (5-8); 0 keeps resolution 6 (~3.2 km).
+	TileRadiusKm float64 `yaml:"tile_radius_km"`
+	// Classifier bounds the P279 hierarchy search behind POI categories.
+	Classifier ClassifierConfig `yaml:"classifier"`
+	// Warmup pre-classifies the instance types most common around the first
+	// position of a run, so per-POI classification mostly hits the cache.
+	Warmup WarmupConfig `yaml:"warmup"`
+	// SeenTTL is how long an entity rejected by the classifier stays
+	// filtered; after that it is classified again, so category changes and
+	// new sitelinks get a chance. 0 keeps seen entities forever.
+	SeenTTL Duration `yaml:"seen_ttl"`
+	// SeenPruneAfter deletes seen entities this old at startup, keeping the
+	// table from growing with every flight. 0 disables pruning.
+	SeenPruneAfter Duration `yaml:"seen_prune_after"`
+}
+
+// WarmupConfig bounds the cold start classification warmup.
+type WarmupConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Radius   Distance `yaml:"radius"`    // Area whose instance types are counted
+	MaxTypes int      `yaml:"max_types"` // Most common types to classify
+	Timeout  Duration `yaml:"timeout"`   // Upper bound on the whole warmup
+}
+
+// ClassifierConfig limits how far the classifier searches the Wikidata class
+// hierarchy, trading thoroughness for API calls on exotic entities.
+type ClassifierConfig struct {
+	MaxDepth        int `yaml:"max_depth"`         // P279 layers to climb above an instance's parents
+	MaxNodesVisited int `yaml:"max_nodes_visited"` // Classes visited per search; 0 is unlimited
+}
+
+// HeadingConeConfig shapes the cone ahead of the aircraft in which the tile
+// scheduler looks for tiles to fetch while airborne.
+type HeadingConeConfig struct {
+	HalfAngle float64  `yaml:"half_angle"` // Degrees either side of the heading; 0 keeps the default
+	LookAhead Distance `yaml:"look_ahead"` // Reach of the cone; 0 uses area.max_dist
+	SlowSpeed float64  `yaml:"slow_speed"` // Knots; below this the cone widens towards slow_angle, 0 disables
+	SlowAngle float64  `yaml:"slow_angle"` // Half-angle when (nearly) stationary, e.g. in a tight orbit
+}
+
+// ArticleLengthConfig bounds the Wikipedia article length lookups made while
+// enriching a tile. Each language is one batch against its own wiki.
+type ArticleLengthConfig struct {
+	Timeout Duration `yaml:"timeout"` // Per request, counted from when it leaves the queue; 0 disables
 }
 
 // RescueConfig holds settings for rescuing unclassified POIs.
@@ -283,8 +720,17 @@ type PromoteByDimensionConfig struct {
 
 // TerrainConfig holds terrain and line-of-sight settings.
 type TerrainConfig struct {
-	LineOfSight   bool   `yaml:"line_of_sight"`
-	ElevationFile string `yaml:"elevation_file"`
+	LineOfSight   bool         `yaml:"line_of_sight"`
+	ElevationFile string       `yaml:"elevation_file"`
+	Valley        ValleyConfig `yaml:"valley"`
+}
+
+// ValleyConfig holds settings for detecting low flight between high terrain.
+type ValleyConfig struct {
+	Enabled        bool    `yaml:"enabled"`
+	SampleRadiusKM float64 `yaml:"sample_radius_km"`  // Lateral distance of the wall samples
+	MinWallHeightM float64 `yaml:"min_wall_height_m"` // Both walls must rise this far above the aircraft
+	PeakBoost      float64 `yaml:"peak_boost"`        // Selection multiplier for Peak/Volcano POIs while in a valley
 }
 
 // AreaConfig holds settings for area-based Wikidata queries.
@@ -300,6 +746,18 @@ type ScorerConfig struct {
 	VarietyPenaltyNum   int     `yaml:"variety_penalty_num"`
 	NoveltyBoost        float64 `yaml:"novelty_boost"`
 	GroupPenalty        float64 `yaml:"group_penalty"`
+	// VisibilityWeight scales visibility by 1+w for POIs in terrain line of sight
+	// and 1-w for occluded ones. 0 disables the check. Off by default: it
+	// traces a terrain profile for every POI on every scoring pass.
+	VisibilityWeight float64 `yaml:"visibility_weight"`
+	// RegionNoveltyWeight scales scores by 1+w in regions (admin1, else
+	// country) not flown over recently and 1-w in one flown over just before.
+	// 0 disables the term. Off by default: it reverse-geocodes every POI in
+	// range and needs a non-zero weight at startup to track region visits.
+	RegionNoveltyWeight float64 `yaml:"region_novelty_weight"`
+	// RegionNoveltyHalfLife is how long after a visit a region scores neutral;
+	// it keeps freshening from there.
+	RegionNoveltyHalfLife Duration `yaml:"region_novelty_half_life"`
 	// Aircraft settings
 	AircraftIcon        string `yaml:"aircraft_icon"`         // balloon, prop, twin_prop, jet, airliner, helicopter
 	AircraftSize        int    `yaml:"aircraft_size"`         // 16-64px
@@ -312,6 +770,12 @@ type ScorerConfig struct {
 	DeferralProximityBoostPower float64      `yaml:"deferral_proximity_boost_power"`
 	PregroundBoost              int          `yaml:"preground_boost"` // Virtual article length boost for pregrounding categories (default 4000)
 	Badges                      BadgesConfig `yaml:"badges"`
+	// Interest boost: nudge matching POIs up without reprocessing tiles
+	InterestKeywords []string `yaml:"interest_keywords"` // Matched against category and name (case-insensitive)
+	InterestBoost    float64  `yaml:"interest_boost"`    // Score multiplier for matching POIs (default 1.5)
+	// TieBreak orders narration candidates with equal scores: "sitelinks",
+	// "article_length" and "distance", most significant first.
+	TieBreak []string `yaml:"tie_break"`
 }
 
 // BadgesConfig holds settings for badge triggers.
@@ -332,10 +796,20 @@ type DeepDiveBadgeConfig struct {
 
 // LogSettings holds settings for a specific logger.
 type LogSettings struct {
-	Path  string `yaml:"path"`
-	Level string `yaml:"level"`
+	Path   string `yaml:"path"`
+	Level  string `yaml:"level"`
+	Format string `yaml:"format"` // "text" (default) or "json", for log shippers; the console stays text
+	// Components overrides Level per component, e.g. wikidata: DEBUG. The
+	// component is the "component" attribute of the logger.
+	Components map[string]string `yaml:"components"`
 }
 
+// Log file formats.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
 // HistorySettings holds settings for interaction history logs.
 type HistorySettings struct {
 	Path    string `yaml:"path"`
@@ -352,6 +826,10 @@ func DefaultConfig() *Config {
 				BaseDelay: Duration(1 * time.Second),
 				MaxDelay:  Duration(60 * time.Second),
 			},
+			Breaker: BreakerConfig{
+				Threshold: 5,
+				Cooldown:  Duration(30 * time.Second),
+			},
 		},
 		GUI: GUIConfig{
 			Window: WindowConfig{
@@ -361,9 +839,13 @@ func DefaultConfig() *Config {
 				Y:         -1,
 				Maximized: false,
 			},
+			Tray: TrayConfig{
+				Enabled: true,
+			},
 		},
 		TTS: TTSConfig{
-			Engine: "windows-sapi",
+			Engine:        "windows-sapi",
+			MaxConcurrent: 1,
 			EdgeTTS: EdgeTTSConfig{
 				VoiceID:  "en-US-AvaMultilingualNeural",
 				FreeTier: true,
@@ -404,10 +886,14 @@ func DefaultConfig() *Config {
 		},
 		Server: ServerConfig{
 			Address: "localhost:1920",
+			Metrics: false,
 		},
 		Ticker: TickerConfig{
 			TelemetryLoop: Duration(1 * time.Second),
 		},
+		Scheduler: SchedulerConfig{
+			Intervals: defaultIntervals(),
+		},
 		Triggers: TriggersConfig{
 			Distance: Distance(5000), // 5km
 			Time:     Duration(30 * time.Second),
@@ -417,7 +903,27 @@ func DefaultConfig() *Config {
 				MaxArticles: 500,
 				MaxDist:     Distance(80000), // 80km
 			},
-			FetchInterval: Duration(5 * time.Second),
+			FetchInterval:  Duration(5 * time.Second),
+			TimeoutRetries: 2,
+			SeenTTL:        Duration(90 * 24 * time.Hour),
+			SeenPruneAfter: Duration(365 * 24 * time.Hour),
+			Classifier: ClassifierConfig{
+				MaxDepth:        4,
+				MaxNodesVisited: 0,
+			},
+			Warmup: WarmupConfig{
+				Enabled:  false,
+				Radius:   Distance(50000),
+				MaxTypes: 100,
+				Timeout:  Duration(2 * time.Minute),
+			},
+			ArticleLength: ArticleLengthConfig{
+				Timeout: Duration(20 * time.Second),
+			},
+			HeadingCone: HeadingConeConfig{
+				HalfAngle: 60,
+				SlowAngle: 120,
+			},
 			Rescue: RescueConfig{
 				PromoteByDimension: PromoteByDimensionConfig{
 					Enabled:   true,
@@ -431,6 +937,12 @@ func DefaultConfig() *Config {
 		Terrain: TerrainConfig{
 			LineOfSight:   true,
 			ElevationFile: "data/etopo1/etopo1_ice_g_i2.bin",
+			Valley: ValleyConfig{
+				Enabled:        true,
+				SampleRadiusKM: 3.0,
+				MinWallHeightM: 300.0,
+				PeakBoost:      1.5,
+			},
 		},
 		Scorer: ScorerConfig{
 			VarietyPenaltyFirst:         0.1,
@@ -438,6 +950,9 @@ func DefaultConfig() *Config {
 			VarietyPenaltyNum:           3,
 			NoveltyBoost:                1.3,
 			GroupPenalty:                0.5,
+			VisibilityWeight:            0,
+			RegionNoveltyWeight:         0,
+			RegionNoveltyHalfLife:       Duration(30 * 24 * time.Hour),
 			AircraftIcon:                "balloon",
 			AircraftSize:                32,
 			AircraftColorMain:           "#e63946",
@@ -446,6 +961,8 @@ func DefaultConfig() *Config {
 			DeferralThreshold:           1.05, // Defer if max future visibility > threshold * current (default 1.05 = 5%)
 			DeferralMultiplier:          0.1,  // 10% score when deferred
 			DeferralProximityBoostPower: 1.0,
+			InterestBoost:               1.5,
+			TieBreak:                    []string{"sitelinks", "article_length", "distance"},
 			Badges: BadgesConfig{
 				DeepDive: DeepDiveBadgeConfig{
 					ArticleLenMin: 20000,
@@ -456,12 +973,20 @@ func DefaultConfig() *Config {
 			},
 		},
 		LLM: LLMConfig{
-			Providers: map[string]ProviderConfig{},
-			Fallback:  []string{},
+			Providers:         map[string]ProviderConfig{},
+			Fallback:          []string{},
+			GenerateTimeout:   Duration(60 * time.Second),
+			TemplateFallback:  true,
+			DegradedCooldown:  Duration(5 * time.Minute),
+			OnEmptyScript:     EmptyScriptRetry,
+			PromptTokenBudget: 32000,
+			PromptTrimOrder:   []string{"RecentContext", "WikipediaText"},
 		},
 		Narrator: NarratorConfig{
 			AutoNarrate:               true,
 			MinScoreThreshold:         0.5,
+			AdaptiveMargin:            0.25,
+			AdaptiveWindow:            3,
 			Frequency:                 3, // Active
 			PauseDuration:             Duration(4 * time.Second),
 			RepeatTTL:                 Duration(30 * 24 * time.Hour), // 30d
@@ -470,6 +995,11 @@ func DefaultConfig() *Config {
 			ActiveTargetLanguage:      "en-US",
 			TargetLanguageLibrary:     []string{"en-US", "en-GB", "de-DE", "fr-FR", "es-ES", "pl-PL"},
 			Units:                     "hybrid",
+			VehicleMode:               VehicleModeAircraft,
+			CacheScripts:              false,
+			NoArticle:                 NoArticleNarrate,
+			NightToning:               false,
+			ScriptCacheTTL:            Duration(7 * 24 * time.Hour), // 7d
 			NarrationLengthShortWords: 50,
 			NarrationLengthLongWords:  200,
 			SummaryMaxWords:           500,
@@ -481,6 +1011,13 @@ func DefaultConfig() *Config {
 				DelayBetweenEssays: Duration(10 * time.Minute),
 				DelayBeforeEssay:   Duration(2 * time.Minute),
 				ScoreThreshold:     2.0,
+				RegionalTopics:     true,
+				ThemedSessions:     false,
+				ThemesPerSession:   2,
+				ExplorerRadius:     Distance(30000),
+				ExplorerDelay:      Duration(30 * time.Second),
+				OnTopicsExhausted:  EssayExhaustedReset,
+				MinAGL:             Distance(609.6), // 2000ft
 			},
 			Debriefing: DebriefingConfig{
 				Enabled: true,
@@ -488,16 +1025,83 @@ func DefaultConfig() *Config {
 			Screenshot: ScreenshotConfig{
 				Enabled: true,
 				Paths:   []string{}, // Auto-detect in main if empty
+				Mode:    ScreenshotModeDescribe,
 			},
 			AudioEffects: AudioEffectsConfig{
-				Headset:    false,
-				LowCutoff:  400.0,
-				HighCutoff: 3500.0,
+				Headset:        false,
+				LowCutoff:      400.0,
+				HighCutoff:     3500.0,
+				CrossfadeMs:    0,
+				AmbienceVolume: 0.3,
+				DuckLevel:      0.3,
+				PreNarrationChime: ChimeConfig{
+					Enabled: false,
+					Volume:  0.5,
+				},
+			},
+			Approach: ApproachConfig{
+				Enabled:  false,
+				Radius:   Distance(9260), // 5nm
+				MinScore: 10.0,
+			},
+			ForwardArc: ForwardArcConfig{
+				Enabled:            false,
+				MaxRelativeBearing: 120,
+			},
+			MinDistanceMaxSilence: Duration(5 * time.Minute),
+			PredictionWindow:      Duration(60 * time.Second),
+			ScaleWithSimRate:      true,
+			RelevanceFit: RelevanceFitConfig{
+				Enabled:  false,
+				Radius:   Distance(15000),
+				MinWords: 40,
+			},
+			PaceLookahead:          Duration(3 * time.Minute),
+			DominanceRivalFraction: 0.2,
+			DominanceRivalCount:    1,
+			WikipediaExtract: WPExtractConfig{
+				MaxChars: 15000,
+				Languages: map[string]int{
+					"zh": 5000,
+					"ja": 6000,
+					"ko": 8000,
+				},
+				MissingTTL: Duration(7 * 24 * time.Hour),
+			},
+			Translation: TranslationConfig{
+				Enabled: false,
+				Profile: "translation",
+			},
+			Tour: TourConfig{
+				Radius: Distance(9260), // 5nm
+			},
+			Priority: PriorityConfig{
+				QIDs:   []string{},
+				Radius: Distance(18520), // 10nm
+			},
+			Gap: GapConfig{
+				FatigueStep: Duration(20 * time.Second),
+				Max:         Duration(2 * time.Minute),
+				HalfLife:    Duration(10 * time.Minute),
+			},
+			Comms: CommsConfig{
+				Mode:       HoldModeDuck,
+				DuckLevel:  0.2,
+				AutoDetect: false,
+				MaxHold:    Duration(2 * time.Minute),
 			},
 			Border: BorderConfig{
 				Enabled:        true,
 				CooldownAny:    Duration(4 * time.Minute),
 				CooldownRepeat: Duration(15 * time.Minute),
+				PreAnnounce:    false,
+				PreAnnounceAt:  Distance(10000), // 10km
+			},
+			OpenWater: OpenWaterConfig{
+				Enabled:     false,
+				MinDistance: Distance(50000), // 50km
+				Cooldown:    Duration(45 * time.Minute),
+				Lakes:       false,
 			},
 			StyleLibrary:      []string{"Ernest Hemingway", "Truman Capote", "Douglas Adams", "Hunter S. Thompson", "J.R.R. Tolkien", "Jane Austen"},
 			ActiveStyle:       "",
@@ -518,6 +1122,11 @@ func DefaultConfig() *Config {
 				DurationTaxi:   Duration(120 * time.Second),
 				DurationHold:   Duration(30 * time.Second),
 			},
+			Recorder: RecorderConfig{
+				Enabled:    false,
+				Interval:   Duration(5 * time.Second),
+				MaxSamples: 50000, // About 70 hours at 5s
+			},
 		},
 		Beacon: BeaconConfig{
 			Enabled:                 true,
@@ -531,6 +1140,7 @@ func DefaultConfig() *Config {
 			TargetSinkDistanceClose: Distance(2000),  // 2km
 			TargetFloorAGL:          Distance(30.48), // 100ft
 			MaxTargets:              5,               // Matches design spec for balloons
+			PoolSize:                4,
 			RegistryPath:            "configs/beacons.yaml",
 			Registry:                make(BeaconRegistry),
 		},
@@ -544,6 +1154,16 @@ func DefaultConfig() *Config {
 			InfoBar:              true,
 			LogLine:              true,
 			SettlementLabelLimit: 5,
+			IconSet:              DefaultIconSet,
+		},
+		Session: SessionConfig{
+			HomeAirport: HomeAirportConfig{
+				Radius: Distance(5000), // 5km
+				Dwell:  Duration(30 * time.Second),
+			},
+		},
+		POI: POIConfig{
+			MaxTracked: 5000,
 		},
 	}
 }
@@ -616,6 +1236,10 @@ func Load(path string) (*Config, error) {
 		return nil, fmt.Errorf("invalid active_target_language format '%s': must be 'xx-YY' (e.g. 'en-US', 'de-DE')", cfg.Narrator.ActiveTargetLanguage)
 	}
 
+	if err := cfg.Scheduler.validate(); err != nil {
+		return nil, err
+	}
+
 	// Load Beacon Registry
 	if cfg.Beacon.RegistryPath != "" {
 		if reg, order, err := LoadBeacons(cfg.Beacon.RegistryPath); err == nil {
@@ -670,6 +1294,10 @@ func Save(path string, cfg *Config) error {
 	reIdent := regexp.MustCompile(`(?m)^(\s+)ident_action:`)
 	data = reIdent.ReplaceAll(data, []byte("${1}# Options: toggle_pause, stop, skip, toggle_beacon\n${1}ident_action:"))
 
+	// Vehicle Mode Options
+	reVehicle := regexp.MustCompile(`(?m)^(\s+)vehicle_mode:`)
+	data = reVehicle.ReplaceAll(data, []byte("${1}# Options: aircraft, ground, marine\n${1}vehicle_mode:"))
+
 	// Aircraft Icon Options
 	reAircraftIcon := regexp.MustCompile(`(?m)^(\s+)aircraft_icon:`)
 	data = reAircraftIcon.ReplaceAll(data, []byte("${1}# Options: balloon, prop, twin_prop, jet, airliner, helicopter\n${1}aircraft_icon:"))
@@ -813,6 +1441,9 @@ func LoadGUIConfig(path string) (*GUIConfig, error) {
 			Y:         -1,
 			Maximized: false,
 		},
+		Tray: TrayConfig{
+			Enabled: true,
+		},
 	}
 
 	// Ensure directory exists
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestLoad(t *testing.T) {
@@ -244,6 +245,46 @@ func TestLoad(t *testing.T) {
 			},
 			expectedError: true,
 		},
+		{
+			name: "Scheduler_Interval_Override",
+			setup: func() {
+				err := os.WriteFile(configPath, []byte("scheduler:\n  intervals:\n    RiverJob: 1m\n"), 0o644)
+				if err != nil {
+					t.Fatalf("failed to setup test file: %v", err)
+				}
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				if got := cfg.Scheduler.Interval(JobRiver); got != time.Minute {
+					t.Errorf("expected configured river interval 1m, got %v", got)
+				}
+				if got := cfg.Scheduler.Interval(JobAnnouncements); got != time.Second {
+					t.Errorf("expected default announcements interval 1s, got %v", got)
+				}
+			},
+			checkFile: func(t *testing.T) {},
+		},
+		{
+			name: "Scheduler_Interval_Invalid",
+			setup: func() {
+				err := os.WriteFile(configPath, []byte("scheduler:\n  intervals:\n    CacheCleanup: 0s\n"), 0o644)
+				if err != nil {
+					t.Fatalf("failed to setup test file: %v", err)
+				}
+			},
+			validate:      func(t *testing.T, cfg *Config) {},
+			expectedError: true,
+		},
+		{
+			name: "Scheduler_Interval_Unknown_Job",
+			setup: func() {
+				err := os.WriteFile(configPath, []byte("scheduler:\n  intervals:\n    RiverJobs: 1m\n"), 0o644)
+				if err != nil {
+					t.Fatalf("failed to setup test file: %v", err)
+				}
+			},
+			validate:      func(t *testing.T, cfg *Config) {},
+			expectedError: true,
+		},
 		{
 			name: "Secrets_Env_Override",
 			setup: func() {
@@ -335,4 +376,17 @@ func TestGUIConfig(t *testing.T) {
 	if loadedCfg.Window.Width != 1024 {
 		t.Errorf("expected loaded config to have width 1024, got %d", loadedCfg.Window.Width)
 	}
+
+	// A file without a tray section keeps the tray defaults
+	legacyPath := filepath.Join(tempDir, "gui-legacy.yaml")
+	if err := os.WriteFile(legacyPath, []byte("window:\n  width: 800\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	legacy, err := LoadGUIConfig(legacyPath)
+	if err != nil {
+		t.Fatalf("LoadGUIConfig() error = %v", err)
+	}
+	if !legacy.Tray.Enabled || legacy.Tray.CloseToTray {
+		t.Errorf("expected the tray enabled and close-to-tray off by default, got %+v", legacy.Tray)
+	}
 }
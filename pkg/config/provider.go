@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,6 +19,8 @@ type Provider interface {
 	TeleportDistance(ctx context.Context) float64
 	Units(ctx context.Context) string          // Prompt template units (imperial/hybrid/metric)
 	RangeRingUnits(ctx context.Context) string // Map display units (km/nm)
+	DistanceUnits(ctx context.Context) string  // Prompt distance units (metric/imperial)
+	AltitudeUnits(ctx context.Context) string  // Prompt altitude units (metric/imperial)
 	TelemetryLoop(ctx context.Context) time.Duration
 
 	// Narrator
@@ -33,6 +36,36 @@ type Provider interface {
 	NarrationLengthLong(ctx context.Context) int
 	TextLengthScale(ctx context.Context) int
 	TwoPassScriptGeneration(ctx context.Context) bool
+	VehicleMode(ctx context.Context) string
+	MinGroundSpeedKts(ctx context.Context) float64
+	POIMinAGL(ctx context.Context) Distance
+	MinArticleLength(ctx context.Context) int
+	NoArticle(ctx context.Context) string
+	StreamScripts(ctx context.Context) bool
+	CacheScripts(ctx context.Context) bool
+	ScriptCacheTTL(ctx context.Context) time.Duration
+	ApproachEnabled(ctx context.Context) bool
+	ApproachRadius(ctx context.Context) Distance
+	ApproachMinScore(ctx context.Context) float64
+	ForwardArcEnabled(ctx context.Context) bool
+	ForwardArcMaxBearing(ctx context.Context) float64
+	PriorityQIDs(ctx context.Context) []string
+	PriorityRadius(ctx context.Context) Distance
+	MinDistanceBetweenPOIsKm(ctx context.Context) float64
+	MinDistanceMaxSilence(ctx context.Context) time.Duration
+	PredictionWindow(ctx context.Context) time.Duration
+	ScaleWithSimRate(ctx context.Context) bool
+	WPExtractMaxChars(ctx context.Context, lang string) int
+	PaceLookahead(ctx context.Context) time.Duration
+	SessionBudgetUSD(ctx context.Context) float64
+	DominanceRivalFraction(ctx context.Context) float64
+	DominanceRivalCount(ctx context.Context) int
+	DialogueMode(ctx context.Context) bool
+	AutoFollowCountryLanguage(ctx context.Context) bool
+
+	// LLM
+	LLMGenerateTimeout(ctx context.Context) time.Duration
+	LLMTemplateFallback(ctx context.Context) bool
 
 	// Mock Sim
 	MockStartLat(ctx context.Context) float64
@@ -48,17 +81,32 @@ type Provider interface {
 	ShowVisibilityLayer(ctx context.Context) bool
 	SettlementLabelLimit(ctx context.Context) int
 	SettlementTier(ctx context.Context) int
+	IconSet(ctx context.Context) string
+	MapMinScore(ctx context.Context) float64
 	FilterMode(ctx context.Context) string
 	TargetPOICount(ctx context.Context) int
+	AdaptiveMargin(ctx context.Context) float64
+	AdaptiveWindow(ctx context.Context) int
 	PauseDuration(ctx context.Context) time.Duration
 	LineOfSight(ctx context.Context) bool
 	DeferralProximityBoostPower(ctx context.Context) float64
 	DeferralThreshold(ctx context.Context) float64
+	InterestKeywords(ctx context.Context) []string
+	InterestBoost(ctx context.Context) float64
+	ScorerTuning(ctx context.Context) ScorerConfig
 
 	// Essay
 	EssayEnabled(ctx context.Context) bool
 	EssayDelayBetweenEssays(ctx context.Context) time.Duration
 	EssayDelayBeforeEssay(ctx context.Context) time.Duration
+	EssayRegionalTopics(ctx context.Context) bool
+	EssayThemedSessions(ctx context.Context) bool
+	EssayThemesPerSession(ctx context.Context) int
+	EssayExplorer(ctx context.Context) bool
+	EssayExplorerRadius(ctx context.Context) Distance
+	EssayExplorerDelay(ctx context.Context) time.Duration
+	EssayStopWhenExhausted(ctx context.Context) bool
+	EssayMinAGL(ctx context.Context) Distance
 
 	// Style Library
 	StyleLibrary(ctx context.Context) []string
@@ -81,6 +129,7 @@ type Provider interface {
 	BeaconSinkDistanceClose(ctx context.Context) Distance
 	BeaconTargetFloorAGL(ctx context.Context) Distance
 	BeaconMaxTargets(ctx context.Context) int
+	BeaconPoolSize(ctx context.Context) int
 
 	// Aircraft
 	AircraftIcon(ctx context.Context) string
@@ -134,6 +183,34 @@ func (p *UnifiedProvider) Units(ctx context.Context) string {
 	return p.getString(ctx, KeyUnits, p.base.Narrator.Units)
 }
 
+// DistanceUnits returns the units for ground distances in prompts: the
+// per-dimension override if set, else what Units implies.
+func (p *UnifiedProvider) DistanceUnits(ctx context.Context) string {
+	return p.getString(ctx, KeyDistanceUnits, dimensionUnits(p.base.Narrator.UnitDimensions.Distance, p.Units(ctx), UnitsMetric))
+}
+
+// AltitudeUnits returns the units for altitudes in prompts: the
+// per-dimension override if set, else what Units implies.
+func (p *UnifiedProvider) AltitudeUnits(ctx context.Context) string {
+	return p.getString(ctx, KeyAltitudeUnits, dimensionUnits(p.base.Narrator.UnitDimensions.Altitude, p.Units(ctx), UnitsImperial))
+}
+
+// dimensionUnits resolves one dimension; hybrid is the units the hybrid
+// system uses for it. An empty or unknown system is imperial, the default of
+// the units instruction, so both always describe the same system.
+func dimensionUnits(override, system, hybrid string) string {
+	if override != "" {
+		return strings.ToLower(override)
+	}
+	switch s := strings.ToLower(system); s {
+	case UnitsMetric, UnitsImperial:
+		return s
+	case UnitsHybrid:
+		return hybrid
+	}
+	return UnitsImperial
+}
+
 // RangeRingUnits returns the map display units (km or nm) for the frontend.
 // This is stored separately from the prompt template units.
 func (p *UnifiedProvider) RangeRingUnits(ctx context.Context) string {
@@ -192,6 +269,128 @@ func (p *UnifiedProvider) TwoPassScriptGeneration(ctx context.Context) bool {
 	return p.getBool(ctx, KeyTwoPassScriptGeneration, p.base.Narrator.TwoPassScriptGeneration)
 }
 
+// VehicleMode returns the vehicle mode (aircraft, ground, marine), defaulting to aircraft.
+func (p *UnifiedProvider) VehicleMode(ctx context.Context) string {
+	fallback := p.base.Narrator.VehicleMode
+	if fallback == "" {
+		fallback = VehicleModeAircraft
+	}
+	return p.getString(ctx, KeyVehicleMode, fallback)
+}
+
+func (p *UnifiedProvider) MinGroundSpeedKts(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyMinGroundSpeedKts, p.base.Narrator.MinGroundSpeedKts)
+}
+
+func (p *UnifiedProvider) POIMinAGL(ctx context.Context) Distance {
+	return p.getDistance(ctx, KeyPOIMinAGL, p.base.Narrator.POIMinAGL)
+}
+
+func (p *UnifiedProvider) MinArticleLength(ctx context.Context) int {
+	return p.getInt(ctx, KeyMinArticleLength, p.base.Narrator.MinArticleLength)
+}
+
+func (p *UnifiedProvider) NoArticle(ctx context.Context) string {
+	return p.getString(ctx, KeyNoArticle, p.base.Narrator.NoArticle)
+}
+
+func (p *UnifiedProvider) StreamScripts(ctx context.Context) bool {
+	return p.getBool(ctx, KeyStreamScripts, p.base.Narrator.StreamScripts)
+}
+
+func (p *UnifiedProvider) CacheScripts(ctx context.Context) bool {
+	return p.getBool(ctx, KeyCacheScripts, p.base.Narrator.CacheScripts)
+}
+
+func (p *UnifiedProvider) ScriptCacheTTL(ctx context.Context) time.Duration {
+	return p.getDuration(ctx, KeyScriptCacheTTL, time.Duration(p.base.Narrator.ScriptCacheTTL))
+}
+
+func (p *UnifiedProvider) ApproachEnabled(ctx context.Context) bool {
+	return p.getBool(ctx, KeyApproachEnabled, p.base.Narrator.Approach.Enabled)
+}
+
+func (p *UnifiedProvider) ApproachRadius(ctx context.Context) Distance {
+	return p.getDistance(ctx, KeyApproachRadius, p.base.Narrator.Approach.Radius)
+}
+
+func (p *UnifiedProvider) ApproachMinScore(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyApproachMinScore, p.base.Narrator.Approach.MinScore)
+}
+
+func (p *UnifiedProvider) PriorityQIDs(ctx context.Context) []string {
+	return p.getStringSlice(ctx, KeyPriorityQIDs, p.base.Narrator.Priority.QIDs)
+}
+
+func (p *UnifiedProvider) PriorityRadius(ctx context.Context) Distance {
+	return p.getDistance(ctx, KeyPriorityRadius, p.base.Narrator.Priority.Radius)
+}
+
+func (p *UnifiedProvider) ForwardArcEnabled(ctx context.Context) bool {
+	return p.getBool(ctx, KeyForwardArcEnabled, p.base.Narrator.ForwardArc.Enabled)
+}
+
+func (p *UnifiedProvider) ForwardArcMaxBearing(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyForwardArcMaxBearing, p.base.Narrator.ForwardArc.MaxRelativeBearing)
+}
+
+func (p *UnifiedProvider) MinDistanceBetweenPOIsKm(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyMinDistanceBetweenPOIs, p.base.Narrator.MinDistanceBetweenPOIsKm)
+}
+
+func (p *UnifiedProvider) MinDistanceMaxSilence(ctx context.Context) time.Duration {
+	return p.getDuration(ctx, KeyMinDistanceMaxSilence, time.Duration(p.base.Narrator.MinDistanceMaxSilence))
+}
+
+func (p *UnifiedProvider) PredictionWindow(ctx context.Context) time.Duration {
+	return p.getDuration(ctx, KeyPredictionWindow, time.Duration(p.base.Narrator.PredictionWindow))
+}
+
+func (p *UnifiedProvider) ScaleWithSimRate(ctx context.Context) bool {
+	return p.getBool(ctx, KeyScaleWithSimRate, p.base.Narrator.ScaleWithSimRate)
+}
+
+// WPExtractMaxChars returns the Wikipedia extract limit for an article
+// language. A per-language entry takes precedence over the global limit.
+func (p *UnifiedProvider) WPExtractMaxChars(ctx context.Context, lang string) int {
+	if n, ok := p.base.Narrator.WikipediaExtract.Languages[lang]; ok {
+		return n
+	}
+	return p.getInt(ctx, KeyWPExtractMaxChars, p.base.Narrator.WikipediaExtract.MaxChars)
+}
+
+func (p *UnifiedProvider) PaceLookahead(ctx context.Context) time.Duration {
+	return p.getDuration(ctx, KeyPaceLookahead, time.Duration(p.base.Narrator.PaceLookahead))
+}
+
+func (p *UnifiedProvider) SessionBudgetUSD(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeySessionBudgetUSD, p.base.Narrator.SessionBudgetUSD)
+}
+
+func (p *UnifiedProvider) DominanceRivalFraction(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyDominanceRivalFraction, p.base.Narrator.DominanceRivalFraction)
+}
+
+func (p *UnifiedProvider) DominanceRivalCount(ctx context.Context) int {
+	return p.getInt(ctx, KeyDominanceRivalCount, p.base.Narrator.DominanceRivalCount)
+}
+
+func (p *UnifiedProvider) DialogueMode(ctx context.Context) bool {
+	return p.getBool(ctx, KeyDialogueMode, p.base.Narrator.DialogueMode)
+}
+
+func (p *UnifiedProvider) AutoFollowCountryLanguage(ctx context.Context) bool {
+	return p.getBool(ctx, KeyAutoFollowCountryLanguage, p.base.Narrator.AutoFollowCountryLanguage)
+}
+
+func (p *UnifiedProvider) LLMGenerateTimeout(ctx context.Context) time.Duration {
+	return p.getDuration(ctx, KeyLLMGenerateTimeout, time.Duration(p.base.LLM.GenerateTimeout))
+}
+
+func (p *UnifiedProvider) LLMTemplateFallback(ctx context.Context) bool {
+	return p.getBool(ctx, KeyLLMTemplateFallback, p.base.LLM.TemplateFallback)
+}
+
 func (p *UnifiedProvider) MockStartLat(ctx context.Context) float64 {
 	return p.getFloat64(ctx, KeyMockLat, p.base.Sim.Mock.StartLat)
 }
@@ -245,6 +444,15 @@ func (p *UnifiedProvider) SettlementTier(ctx context.Context) int {
 	return p.getInt(ctx, KeySettlementTier, 3)
 }
 
+// IconSet returns the name of the active POI icon set.
+func (p *UnifiedProvider) IconSet(ctx context.Context) string {
+	return p.getString(ctx, KeyIconSet, p.base.Overlay.IconSet)
+}
+
+func (p *UnifiedProvider) MapMinScore(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyMapMinScore, p.base.Overlay.MapMinScore)
+}
+
 func (p *UnifiedProvider) FilterMode(ctx context.Context) string {
 	return p.getString(ctx, KeyFilterMode, "fixed")
 }
@@ -253,6 +461,14 @@ func (p *UnifiedProvider) TargetPOICount(ctx context.Context) int {
 	return p.getInt(ctx, KeyTargetPOICount, 5)
 }
 
+func (p *UnifiedProvider) AdaptiveMargin(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyAdaptiveMargin, p.base.Narrator.AdaptiveMargin)
+}
+
+func (p *UnifiedProvider) AdaptiveWindow(ctx context.Context) int {
+	return p.getInt(ctx, KeyAdaptiveWindow, p.base.Narrator.AdaptiveWindow)
+}
+
 func (p *UnifiedProvider) PauseDuration(ctx context.Context) time.Duration {
 	return p.getDuration(ctx, KeyPauseDuration, time.Duration(p.base.Narrator.PauseDuration))
 }
@@ -269,6 +485,29 @@ func (p *UnifiedProvider) DeferralThreshold(ctx context.Context) float64 {
 	return p.getFloat64(ctx, KeyDeferralThreshold, p.base.Scorer.DeferralThreshold)
 }
 
+func (p *UnifiedProvider) InterestKeywords(ctx context.Context) []string {
+	return p.getStringSlice(ctx, KeyInterestKeywords, p.base.Scorer.InterestKeywords)
+}
+
+func (p *UnifiedProvider) InterestBoost(ctx context.Context) float64 {
+	return p.getFloat64(ctx, KeyInterestBoost, p.base.Scorer.InterestBoost)
+}
+
+// ScorerTuning returns the scorer config with the weights tuned at runtime.
+func (p *UnifiedProvider) ScorerTuning(ctx context.Context) ScorerConfig {
+	sc := p.base.Scorer
+	sc.VarietyPenaltyFirst = p.getFloat64(ctx, KeyVarietyPenaltyFirst, sc.VarietyPenaltyFirst)
+	sc.VarietyPenaltyLast = p.getFloat64(ctx, KeyVarietyPenaltyLast, sc.VarietyPenaltyLast)
+	sc.NoveltyBoost = p.getFloat64(ctx, KeyNoveltyBoost, sc.NoveltyBoost)
+	sc.GroupPenalty = p.getFloat64(ctx, KeyGroupPenalty, sc.GroupPenalty)
+	sc.VisibilityWeight = p.getFloat64(ctx, KeyVisibilityWeight, sc.VisibilityWeight)
+	sc.RegionNoveltyWeight = p.getFloat64(ctx, KeyRegionNoveltyWeight, sc.RegionNoveltyWeight)
+	sc.DeferralThreshold = p.DeferralThreshold(ctx)
+	sc.DeferralMultiplier = p.getFloat64(ctx, KeyDeferralMultiplier, sc.DeferralMultiplier)
+	sc.DeferralProximityBoostPower = p.DeferralProximityBoostPower(ctx)
+	return sc
+}
+
 func (p *UnifiedProvider) EssayEnabled(ctx context.Context) bool {
 	return p.base.Narrator.Essay.Enabled
 }
@@ -281,6 +520,38 @@ func (p *UnifiedProvider) EssayDelayBeforeEssay(ctx context.Context) time.Durati
 	return time.Duration(p.base.Narrator.Essay.DelayBeforeEssay)
 }
 
+func (p *UnifiedProvider) EssayRegionalTopics(ctx context.Context) bool {
+	return p.base.Narrator.Essay.RegionalTopics
+}
+
+func (p *UnifiedProvider) EssayThemedSessions(ctx context.Context) bool {
+	return p.base.Narrator.Essay.ThemedSessions
+}
+
+func (p *UnifiedProvider) EssayThemesPerSession(ctx context.Context) int {
+	return p.base.Narrator.Essay.ThemesPerSession
+}
+
+func (p *UnifiedProvider) EssayExplorer(ctx context.Context) bool {
+	return p.base.Narrator.Essay.Explorer
+}
+
+func (p *UnifiedProvider) EssayExplorerRadius(ctx context.Context) Distance {
+	return p.base.Narrator.Essay.ExplorerRadius
+}
+
+func (p *UnifiedProvider) EssayExplorerDelay(ctx context.Context) time.Duration {
+	return time.Duration(p.base.Narrator.Essay.ExplorerDelay)
+}
+
+func (p *UnifiedProvider) EssayStopWhenExhausted(ctx context.Context) bool {
+	return p.base.Narrator.Essay.OnTopicsExhausted == EssayExhaustedStop
+}
+
+func (p *UnifiedProvider) EssayMinAGL(ctx context.Context) Distance {
+	return p.base.Narrator.Essay.MinAGL
+}
+
 func (p *UnifiedProvider) StyleLibrary(ctx context.Context) []string {
 	return p.getStringSlice(ctx, KeyStyleLibrary, p.base.Narrator.StyleLibrary)
 }
@@ -345,6 +616,10 @@ func (p *UnifiedProvider) BeaconMaxTargets(ctx context.Context) int {
 	return p.getInt(ctx, KeyBeaconMaxTargets, p.base.Beacon.MaxTargets)
 }
 
+func (p *UnifiedProvider) BeaconPoolSize(ctx context.Context) int {
+	return p.getInt(ctx, KeyBeaconPoolSize, p.base.Beacon.PoolSize)
+}
+
 func (p *UnifiedProvider) AircraftIcon(ctx context.Context) string {
 	return p.getString(ctx, KeyAircraftIcon, p.base.Scorer.AircraftIcon)
 }
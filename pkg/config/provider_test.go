@@ -458,3 +458,37 @@ func TestUnifiedProvider(t *testing.T) {
 		}
 	})
 }
+
+func TestUnifiedProvider_UnitDimensions(t *testing.T) {
+	tests := []struct {
+		name         string
+		units        string
+		dims         UnitDimensions
+		wantDistance string
+		wantAltitude string
+	}{
+		{name: "Metric shortcut", units: "metric", wantDistance: UnitsMetric, wantAltitude: UnitsMetric},
+		{name: "Imperial shortcut", units: "Imperial", wantDistance: UnitsImperial, wantAltitude: UnitsImperial},
+		{name: "Hybrid shortcut", units: "hybrid", wantDistance: UnitsMetric, wantAltitude: UnitsImperial},
+		{name: "Empty defaults to imperial", units: "", wantDistance: UnitsImperial, wantAltitude: UnitsImperial},
+		{name: "Override one dimension", units: "imperial", dims: UnitDimensions{Distance: "metric"}, wantDistance: UnitsMetric, wantAltitude: UnitsImperial},
+		{name: "Override both", units: "hybrid", dims: UnitDimensions{Distance: "imperial", Altitude: "metric"}, wantDistance: UnitsImperial, wantAltitude: UnitsMetric},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{}
+			cfg.Narrator.Units = tt.units
+			cfg.Narrator.UnitDimensions = tt.dims
+			p := NewProvider(cfg, nil)
+
+			ctx := context.Background()
+			if got := p.DistanceUnits(ctx); got != tt.wantDistance {
+				t.Errorf("DistanceUnits() = %q, want %q", got, tt.wantDistance)
+			}
+			if got := p.AltitudeUnits(ctx); got != tt.wantAltitude {
+				t.Errorf("AltitudeUnits() = %q, want %q", got, tt.wantAltitude)
+			}
+		})
+	}
+}
@@ -5,10 +5,14 @@ const (
 	KeyMinPOIScore                 = "min_poi_score"
 	KeyFilterMode                  = "filter_mode"
 	KeyTargetPOICount              = "target_poi_count"
+	KeyAdaptiveMargin              = "narrator.adaptive_margin"
+	KeyAdaptiveWindow              = "narrator.adaptive_window"
 	KeyNarrationFrequency          = "narration_frequency"
 	KeyTextLength                  = "text_length"
 	KeyUnits                       = "units"            // Prompt template units (imperial/hybrid/metric)
 	KeyRangeRingUnits              = "range_ring_units" // Map display units (km/nm)
+	KeyDistanceUnits               = "units_distance"   // Prompt distance units (metric/imperial)
+	KeyAltitudeUnits               = "units_altitude"   // Prompt altitude units (metric/imperial)
 	KeyShowCacheLayer              = "show_cache_layer"
 	KeyShowVisibility              = "show_visibility_layer"
 	KeySimSource                   = "sim_source"
@@ -29,12 +33,53 @@ const (
 	KeyActiveTargetLanguage        = "active_target_language"
 	KeyDeferralThreshold           = "scorer.deferral_threshold"
 	KeyDeferralProximityBoostPower = "scorer.deferral_proximity_boost_power"
+	KeyInterestKeywords            = "scorer.interest_keywords"
+	KeyInterestBoost               = "scorer.interest_boost"
 	KeyTwoPassScriptGeneration     = "narrator.two_pass_script_generation"
 	KeyAutoNarrate                 = "narrator.auto_narrate"
 	KeyPauseDuration               = "narrator.pause_between_narrations"
 	KeyRepeatTTL                   = "narrator.repeat_ttl"
 	KeyNarrationLengthShort        = "narrator.narration_length_short_words"
 	KeyNarrationLengthLong         = "narrator.narration_length_long_words"
+	KeyVehicleMode                 = "narrator.vehicle_mode"
+	KeyMinGroundSpeedKts           = "narrator.min_ground_speed_kts"
+	KeyPOIMinAGL                   = "narrator.poi_min_agl"
+	KeyMinArticleLength            = "narrator.min_article_length"
+	KeyNoArticle                   = "narrator.no_article"
+	KeyStreamScripts               = "narrator.stream_scripts"
+	KeyCacheScripts                = "narrator.cache_scripts"
+	KeyScriptCacheTTL              = "narrator.script_cache_ttl"
+	KeyApproachEnabled             = "narrator.approach.enabled"
+	KeyApproachRadius              = "narrator.approach.radius"
+	KeyApproachMinScore            = "narrator.approach.min_score"
+	KeyForwardArcEnabled           = "narrator.forward_arc.enabled"
+	KeyForwardArcMaxBearing        = "narrator.forward_arc.max_relative_bearing"
+	KeyPriorityQIDs                = "narrator.priority.qids"
+	KeyPriorityRadius              = "narrator.priority.radius"
+	KeyMinDistanceBetweenPOIs      = "narrator.min_distance_between_pois_km"
+	KeyMinDistanceMaxSilence       = "narrator.min_distance_max_silence"
+	KeyWPExtractMaxChars           = "narrator.wikipedia_extract.max_chars"
+	KeyPaceLookahead               = "narrator.pace_lookahead"
+	KeySessionBudgetUSD            = "narrator.session_budget_usd"
+	KeyDominanceRivalFraction      = "narrator.dominance_rival_fraction"
+	KeyDominanceRivalCount         = "narrator.dominance_rival_count"
+	KeyDialogueMode                = "narrator.dialogue_mode"
+	KeyAutoFollowCountryLanguage   = "narrator.auto_follow_country_language"
+	KeyPredictionWindow            = "narrator.prediction_window"
+	KeyScaleWithSimRate            = "narrator.scale_with_sim_rate"
+
+	// Scorer tuning, applied from the next scoring pass
+	KeyVarietyPenaltyFirst = "scorer.variety_penalty_first"
+	KeyVarietyPenaltyLast  = "scorer.variety_penalty_last"
+	KeyNoveltyBoost        = "scorer.novelty_boost"
+	KeyGroupPenalty        = "scorer.group_penalty"
+	KeyVisibilityWeight    = "scorer.visibility_weight"
+	KeyRegionNoveltyWeight = "scorer.region_novelty_weight"
+	KeyDeferralMultiplier  = "scorer.deferral_multiplier"
+
+	// LLM settings
+	KeyLLMGenerateTimeout  = "llm.generate_timeout"
+	KeyLLMTemplateFallback = "llm.template_fallback"
 
 	// Beacon settings
 	KeyBeaconEnabled              = "beacon.enabled"
@@ -48,8 +93,11 @@ const (
 	KeyBeaconSinkDistanceClose    = "beacon.target_sink_distance_close"
 	KeyBeaconTargetFloorAGL       = "beacon.target_floor_agl"
 	KeyBeaconMaxTargets           = "beacon.max_targets"
+	KeyBeaconPoolSize             = "beacon.pool_size"
 	KeySettlementLabelLimit       = "settlement_label_limit"
 	KeySettlementTier             = "settlement_tier"
+	KeyIconSet                    = "icon_set"
+	KeyMapMinScore                = "map_min_score"
 
 	// Aircraft settings
 	KeyAircraftIcon        = "aircraft_icon"
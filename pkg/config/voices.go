@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// VoiceMap maps a TTS engine name (as in tts.engine) to voices keyed by
+// locale ("fr-FR") or language code ("fr").
+type VoiceMap map[string]map[string]string
+
+// LoadVoices loads the per-language voice mapping from the given YAML file.
+func LoadVoices(path string) (VoiceMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read voices config: %w", err)
+	}
+
+	var raw VoiceMap
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse voices config: %w", err)
+	}
+
+	// Locales are written inconsistently (fr-FR, fr_fr); match them loosely
+	voices := make(VoiceMap, len(raw))
+	for engine, langs := range raw {
+		voices[engine] = make(map[string]string, len(langs))
+		for lang, voice := range langs {
+			voices[engine][normalizeLocale(lang)] = voice
+		}
+	}
+	return voices, nil
+}
+
+// Resolve returns the engine's voice for the language, preferring an exact
+// locale over its language code, or "" when none is mapped.
+func (v VoiceMap) Resolve(engine, language string) string {
+	langs := v[engine]
+	if len(langs) == 0 || language == "" {
+		return ""
+	}
+	locale := normalizeLocale(language)
+	if voice, ok := langs[locale]; ok {
+		return voice
+	}
+	code, _, _ := strings.Cut(locale, "-")
+	return langs[code]
+}
+
+func normalizeLocale(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(s), "_", "-"))
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadVoices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "voices.yaml")
+	yamlContent := `
+azure-speech:
+  fr: fr-FR-DeniseNeural
+  fr-CA: fr-CA-SylvieNeural
+  de_DE: de-DE-KatjaNeural
+`
+	if err := os.WriteFile(path, []byte(yamlContent), 0o644); err != nil {
+		t.Fatalf("failed to create temp config: %v", err)
+	}
+
+	voices, err := LoadVoices(path)
+	if err != nil {
+		t.Fatalf("LoadVoices failed: %v", err)
+	}
+
+	tests := []struct {
+		engine, language, want string
+	}{
+		{"azure-speech", "fr", "fr-FR-DeniseNeural"},
+		{"azure-speech", "fr-FR", "fr-FR-DeniseNeural"},
+		{"azure-speech", "fr-CA", "fr-CA-SylvieNeural"},
+		{"azure-speech", "de-DE", "de-DE-KatjaNeural"},
+		{"azure-speech", "es-ES", ""},
+		{"edge-tts", "fr", ""},
+		{"azure-speech", "", ""},
+	}
+	for _, tt := range tests {
+		if got := voices.Resolve(tt.engine, tt.language); got != tt.want {
+			t.Errorf("Resolve(%q, %q) = %q, want %q", tt.engine, tt.language, got, tt.want)
+		}
+	}
+}
+
+func TestLoadVoices_ShippedFileMapsNothing(t *testing.T) {
+	voices, err := LoadVoices("../../configs/voices.yaml")
+	if err != nil {
+		t.Fatalf("LoadVoices failed: %v", err)
+	}
+	if len(voices) != 0 {
+		t.Errorf("expected the shipped voices.yaml to map nothing, got %v", voices)
+	}
+}
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+
+	"phileasgo/pkg/sim"
+)
+
+// commsHoldReason identifies holds placed by the push-to-talk watcher, so an
+// API hold for another reason is left alone.
+const commsHoldReason = "ptt"
+
+// CommsHolder holds narration while the pilot is on the radio.
+type CommsHolder interface {
+	Hold(ctx context.Context, reason string, on bool)
+}
+
+// CommsWatcherJob holds narration while the sim reports the pilot
+// transmitting. Sims that don't report it never trigger the job.
+type CommsWatcherJob struct {
+	BaseJob
+	holder           CommsHolder
+	lastTransmitting bool
+}
+
+// NewCommsWatcherJob creates a new push-to-talk watcher job.
+func NewCommsWatcherJob(h CommsHolder) *CommsWatcherJob {
+	// Runs without valid position data: a transmission must still be
+	// released if telemetry drops out mid-call
+	return &CommsWatcherJob{
+		BaseJob: NewBaseJob("CommsWatcherJob", false),
+		holder:  h,
+	}
+}
+
+// ShouldFire returns true when the push-to-talk state has changed.
+func (j *CommsWatcherJob) ShouldFire(t *sim.Telemetry) bool {
+	return t.Transmitting != j.lastTransmitting
+}
+
+// Run starts or ends the hold to match the push-to-talk state.
+func (j *CommsWatcherJob) Run(ctx context.Context, t *sim.Telemetry) {
+	if !j.TryLock() {
+		return
+	}
+	defer j.Unlock()
+
+	if t.Transmitting == j.lastTransmitting {
+		return
+	}
+	slog.Debug("Comms: Push-to-talk changed", "transmitting", t.Transmitting)
+	j.holder.Hold(ctx, commsHoldReason, t.Transmitting)
+	j.lastTransmitting = t.Transmitting
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"phileasgo/pkg/sim"
+)
+
+type recordingHolder struct {
+	calls   []bool
+	reasons []string
+}
+
+func (h *recordingHolder) Hold(ctx context.Context, reason string, on bool) {
+	h.calls = append(h.calls, on)
+	h.reasons = append(h.reasons, reason)
+}
+
+func TestCommsWatcherJob(t *testing.T) {
+	h := &recordingHolder{}
+	job := NewCommsWatcherJob(h)
+
+	steps := []struct {
+		transmitting bool
+		wantFire     bool
+	}{
+		{false, false},
+		{true, true},
+		{true, false},
+		{false, true},
+	}
+
+	for i, s := range steps {
+		tel := &sim.Telemetry{Transmitting: s.transmitting}
+		if got := job.ShouldFire(tel); got != s.wantFire {
+			t.Fatalf("step %d: ShouldFire = %v, want %v", i, got, s.wantFire)
+		}
+		if s.wantFire {
+			job.Run(context.Background(), tel)
+		}
+	}
+
+	if len(h.calls) != 2 || !h.calls[0] || h.calls[1] {
+		t.Errorf("expected a hold then a release, got %v", h.calls)
+	}
+	for _, r := range h.reasons {
+		if r != commsHoldReason {
+			t.Errorf("expected reason %q, got %q", commsHoldReason, r)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"time"
+
+	"phileasgo/pkg/geo"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/sim"
+)
+
+// approachAction is the two-phase narration step for a candidate POI.
+type approachAction int
+
+const (
+	approachNarrate approachAction = iota // Single-phase POI, or landmark abeam: full narration
+	approachAlert                         // Landmark entered the approach radius: short alert
+	approachHold                          // Landmark ahead but not due yet: skip for now
+)
+
+const (
+	// approachAbeamDeg is the relative bearing from which a landmark counts as abeam.
+	// Short of 90° so the narration starts while it is still in view.
+	approachAbeamDeg = 70.0
+	// approachDistSlack absorbs position jitter when detecting an opening range.
+	approachDistSlack = 50.0
+	// approachStateTTL drops state for landmarks that left the candidate list.
+	approachStateTTL = 30 * time.Minute
+)
+
+// approachState tracks a landmark through the two narration phases.
+type approachState struct {
+	alerted bool
+	passed  bool    // Abeam or range opening; sticky so the decision cannot flip back
+	closest float64 // Closest range seen so far, in meters
+	seen    time.Time
+}
+
+// approachAction decides how a candidate is handled under two-phase narration.
+// Qualifying landmarks are held back until they are abeam (or the range starts
+// opening), and get a short alert when they come within the approach radius.
+func (j *NarrationJob) approachAction(ctx context.Context, p *model.POI, t *sim.Telemetry) approachAction {
+	if t == nil || !j.cfgProv.ApproachEnabled(ctx) || p.Score < j.cfgProv.ApproachMinScore(ctx) {
+		return approachNarrate
+	}
+
+	pos := geo.Point{Lat: t.Latitude, Lon: t.Longitude}
+	target := geo.Point{Lat: p.Lat, Lon: p.Lon}
+	dist := geo.Distance(pos, target)
+	relBearing := math.Abs(geo.NormalizeAngle(geo.Bearing(pos, target) - t.Heading))
+
+	if j.approach == nil {
+		j.approach = make(map[string]*approachState)
+	}
+	st, ok := j.approach[p.WikidataID]
+	if !ok {
+		st = &approachState{closest: dist}
+		j.approach[p.WikidataID] = st
+	}
+	st.seen = time.Now()
+	if relBearing >= approachAbeamDeg || dist > st.closest+approachDistSlack {
+		st.passed = true
+	}
+	st.closest = math.Min(st.closest, dist)
+
+	switch {
+	case st.passed:
+		return approachNarrate
+	case !st.alerted && dist <= float64(j.cfgProv.ApproachRadius(ctx)):
+		return approachAlert
+	default:
+		return approachHold
+	}
+}
+
+// triggerApproachAlert speaks the first-phase alert for a landmark.
+func (j *NarrationJob) triggerApproachAlert(ctx context.Context, p *model.POI, t *sim.Telemetry) {
+	if st, ok := j.approach[p.WikidataID]; ok {
+		st.alerted = true
+	}
+	slog.Info("NarrationJob: Approach alert", "name", p.DisplayName())
+	j.narrator.PlayApproachAlert(ctx, p, t)
+}
+
+// pruneApproachState forgets landmarks that were narrated or are long gone.
+func (j *NarrationJob) pruneApproachState(narrated string) {
+	delete(j.approach, narrated)
+	for qid, st := range j.approach {
+		if time.Since(st.seen) > approachStateTTL {
+			delete(j.approach, qid)
+		}
+	}
+}
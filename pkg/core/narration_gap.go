@@ -0,0 +1,102 @@
+package core
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"phileasgo/pkg/config"
+)
+
+// NarrationGap paces auto-narration by the silence since the last one ended.
+// Unlike the pause after each narration, the gap widens while narrations come
+// in quick succession and relaxes once things have been quiet for a while.
+type NarrationGap struct {
+	cfg config.GapConfig
+	now func() time.Time
+
+	mu      sync.Mutex
+	fatigue float64   // Decaying count of recent narrations
+	updated time.Time // When fatigue was last decayed
+	lastEnd time.Time // End of the last narration; zero before the first
+	rate    float64   // Sim rate the gap is compressed by; 0 is real time
+}
+
+// NewNarrationGap creates a gap tracker for the given settings.
+func NewNarrationGap(cfg config.GapConfig) *NarrationGap {
+	return &NarrationGap{cfg: cfg, now: time.Now}
+}
+
+// Started records the start of a narration.
+func (g *NarrationGap) Started() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.decayLocked()
+	g.fatigue++
+}
+
+// Finished records the end of a narration; the gap is measured from here.
+func (g *NarrationGap) Finished() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.lastEnd = g.now()
+}
+
+// SetRate compresses the gap by the sim rate: at 2x the scenery passes in
+// half the time, so half the silence spans the same stretch of the flight.
+func (g *NarrationGap) SetRate(rate float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.rate = rate
+}
+
+// Current returns the effective gap.
+func (g *NarrationGap) Current() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.currentLocked()
+}
+
+// Ready reports whether another narration may be prepared. While one is
+// playing the next is pipelined behind it; the gap then holds back its start
+// (see Wait) rather than its preparation, which would leave it late.
+func (g *NarrationGap) Ready(playing bool) bool {
+	if playing {
+		return true
+	}
+	return g.Wait() <= 0
+}
+
+// Wait returns how much longer the next auto-narration has to stay silent.
+func (g *NarrationGap) Wait() time.Duration {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	gap := g.currentLocked()
+	if gap <= 0 || g.lastEnd.IsZero() {
+		return 0
+	}
+	return max(0, gap-g.now().Sub(g.lastEnd))
+}
+
+func (g *NarrationGap) currentLocked() time.Duration {
+	g.decayLocked()
+	// The first recent narration only earns the minimum gap; fatigue starts
+	// with the second.
+	extra := math.Max(0, g.fatigue-1)
+	gap := time.Duration(g.cfg.Min) + time.Duration(extra*float64(g.cfg.FatigueStep))
+	if limit := time.Duration(g.cfg.Max); limit > 0 && gap > limit {
+		gap = limit
+	}
+	if g.rate > 0 {
+		gap = time.Duration(float64(gap) / g.rate)
+	}
+	return gap
+}
+
+func (g *NarrationGap) decayLocked() {
+	now := g.now()
+	if !g.updated.IsZero() && g.cfg.HalfLife > 0 {
+		g.fatigue *= math.Exp2(-float64(now.Sub(g.updated)) / float64(g.cfg.HalfLife))
+	}
+	g.updated = now
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
+)
+
+func TestNarrationGap(t *testing.T) {
+	cfg := config.GapConfig{
+		Min:         config.Duration(10 * time.Second),
+		FatigueStep: config.Duration(20 * time.Second),
+		Max:         config.Duration(time.Minute),
+		HalfLife:    config.Duration(5 * time.Minute),
+	}
+
+	tests := []struct {
+		name    string
+		bursts  int           // Narrations played back to back
+		quiet   time.Duration // Silence after the last one
+		wantGap time.Duration
+	}{
+		{name: "Single narration keeps the minimum", bursts: 1, wantGap: 10 * time.Second},
+		{name: "Rapid-fire narrations widen the gap", bursts: 3, wantGap: 50 * time.Second},
+		{name: "Gap is capped", bursts: 6, wantGap: time.Minute},
+		{name: "Quiet period relaxes the gap", bursts: 3, quiet: 30 * time.Minute, wantGap: 10 * time.Second},
+		{name: "Long quiet returns to the minimum", bursts: 6, quiet: time.Hour, wantGap: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+			g := NewNarrationGap(cfg)
+			g.now = func() time.Time { return now }
+
+			for range tt.bursts {
+				g.Started()
+				now = now.Add(5 * time.Second)
+				g.Finished()
+			}
+			before := g.Current()
+			now = now.Add(tt.quiet)
+
+			// Decay over the burst itself shaves a little off the ramp
+			got := g.Current()
+			if diff := got - tt.wantGap; diff < -2*time.Second || diff > 0 {
+				t.Errorf("Current() = %v, want about %v", got, tt.wantGap)
+			}
+			if tt.quiet > 0 && got >= before {
+				t.Errorf("expected the gap to relax after %v quiet, stayed at %v", tt.quiet, got)
+			}
+		})
+	}
+}
+
+func TestNarrationGap_Ready(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	g := NewNarrationGap(config.GapConfig{Min: config.Duration(10 * time.Second)})
+	g.now = func() time.Time { return now }
+
+	if !g.Ready(false) {
+		t.Error("expected to be ready before the first narration")
+	}
+
+	g.Started()
+	if !g.Ready(true) {
+		t.Error("expected preparation to be pipelined while playing")
+	}
+	g.Finished()
+	now = now.Add(5 * time.Second)
+	if g.Ready(false) {
+		t.Error("expected to wait for the gap")
+	}
+	if got := g.Wait(); got != 5*time.Second {
+		t.Errorf("Wait() = %v, want 5s", got)
+	}
+	now = now.Add(5 * time.Second)
+	if !g.Ready(false) || g.Wait() != 0 {
+		t.Error("expected to be ready once the gap has passed")
+	}
+
+	zero := NewNarrationGap(config.GapConfig{})
+	zero.Started()
+	if !zero.Ready(true) {
+		t.Error("expected a zero gap to allow pipelining")
+	}
+}
+
+func TestNarrationGap_SimRate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	g := NewNarrationGap(config.GapConfig{Min: config.Duration(10 * time.Second)})
+	g.now = func() time.Time { return now }
+	g.Started()
+	g.Finished()
+
+	g.SetRate(2)
+	if got := g.Current(); got != 5*time.Second {
+		t.Errorf("Current() at 2x = %v, want 5s", got)
+	}
+	now = now.Add(5 * time.Second)
+	if !g.Ready(false) {
+		t.Error("expected to be ready after half the gap at 2x")
+	}
+
+	g.SetRate(1)
+	if g.Ready(false) {
+		t.Error("expected to wait the full gap in real time")
+	}
+}
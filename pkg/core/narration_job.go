@@ -2,15 +2,20 @@ package core
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/geo"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/narrator"
+	"phileasgo/pkg/poi"
 	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/store"
@@ -47,6 +52,53 @@ type NarrationJob struct {
 
 	// Flight tracking
 	lastAGL float64 // Last known AGL for visibility boost check
+
+	// Valley detection (optional, nil when no elevation data is loaded)
+	valley    *terrain.ValleyDetector
+	peakBoost float64
+	inValley  bool
+
+	// Session spend estimate (optional, nil disables the budget check)
+	costs CostTracker
+
+	// Two-phase narration state per landmark QID
+	approach map[string]*approachState
+
+	// Guided tour (optional, nil disables tours)
+	tour TourGuide
+
+	// Silence between auto-narrations (optional, nil disables pacing)
+	gap *NarrationGap
+
+	// Where the last POI was narrated (optional, nil disables spacing)
+	lastNarrated NarratedPOILocator
+
+	// Sim rate of the latest readiness check; 0 until the first
+	simRate float64
+}
+
+// NarratedPOILocator reports the position of the last narrated POI; the
+// session manager implements it.
+type NarratedPOILocator interface {
+	LastNarratedPOI() (lat, lon float64, ok bool)
+}
+
+// NearbyPOIFinder lists tracked POIs around a point, visible or not; the POI
+// manager implements it.
+type NearbyPOIFinder interface {
+	GetNearestTracked(ctx context.Context, lat, lon, radiusM float64, limit int) []poi.NearbyPOI
+}
+
+// AdaptiveThresholder exposes the smoothed adaptive-filter threshold; the POI
+// manager implements it.
+type AdaptiveThresholder interface {
+	AdaptiveThreshold() (float64, bool)
+}
+
+// BlockChecker reports user-blocked POIs from an in-memory set; the POI
+// manager implements it.
+type BlockChecker interface {
+	IsBlocked(ctx context.Context, p *model.POI) bool
 }
 
 func NewNarrationJob(cfgProv config.Provider, n narrator.Service, pm POIProvider, simC sim.Client, st store.Store, los *terrain.LOSChecker) *NarrationJob {
@@ -65,6 +117,62 @@ func NewNarrationJob(cfgProv config.Provider, n narrator.Service, pm POIProvider
 	return j
 }
 
+// SetValleyDetector enables terrain-aware selection: while flying between high
+// terrain, Peak and Volcano POIs have their combined score multiplied by peakBoost.
+func (j *NarrationJob) SetValleyDetector(v *terrain.ValleyDetector, peakBoost float64) {
+	j.valley = v
+	j.peakBoost = peakBoost
+}
+
+// CostTracker reports the estimated API spend of the current session.
+type CostTracker interface {
+	SessionCost() float64
+}
+
+// SetCostTracker enables the session budget: once the spend reported by c
+// reaches Narrator.SessionBudgetUSD, auto-narration stops. Manual requests
+// don't go through the job and remain available.
+func (j *NarrationJob) SetCostTracker(c CostTracker) {
+	j.costs = c
+}
+
+// overBudget reports whether the session has used up its API budget.
+func (j *NarrationJob) overBudget(ctx context.Context) bool {
+	if j.costs == nil {
+		return false
+	}
+	budget := j.cfgProv.SessionBudgetUSD(ctx)
+	return budget > 0 && j.costs.SessionCost() >= budget
+}
+
+// SetGap enables pacing: auto-narration waits for the silence g asks for,
+// which grows after a burst of narrations.
+func (j *NarrationJob) SetGap(g *NarrationGap) {
+	j.gap = g
+}
+
+// SetNarratedPOILocator enables Narrator.MinDistanceBetweenPOIsKm, spacing
+// auto-selected POIs from the last one narrated.
+func (j *NarrationJob) SetNarratedPOILocator(l NarratedPOILocator) {
+	j.lastNarrated = l
+}
+
+// checkGap applies the narration gap on top of the narrator's own pause.
+// During playback it lets the next narration be prepared; the orchestrator
+// holds back its start until the gap has passed.
+func (j *NarrationJob) checkGap() bool {
+	if j.gap == nil || j.gap.Ready(j.narrator.IsPlaying()) {
+		return true
+	}
+	slog.Debug("NarrationJob: Waiting for narration gap", "gap", j.gap.Current())
+	return false
+}
+
+// InValley reports whether the last candidate search happened in a valley.
+func (j *NarrationJob) InValley() bool {
+	return j.inValley
+}
+
 // checkNarratorReady returns true if the narrator is ready to accept a new command.
 // For pipelining, we allow firing if playing, provided timing is right.
 func (j *NarrationJob) checkNarratorReady() bool {
@@ -96,6 +204,9 @@ func (j *NarrationJob) checkNarratorReady() bool {
 	if !j.narrator.IsPlaying() && j.wasBusy {
 		j.wasBusy = false
 		j.lastTime = time.Now()
+		if j.gap != nil {
+			j.gap.Finished()
+		}
 		slog.Debug("NarrationJob: Narration cycle finished (including pause)")
 		return false
 	}
@@ -103,9 +214,32 @@ func (j *NarrationJob) checkNarratorReady() bool {
 	return true
 }
 
+// observeSimRate picks up the sim rate the waits below are scaled by.
+func (j *NarrationJob) observeSimRate(ctx context.Context, t *sim.Telemetry) {
+	rate := 1.0
+	if j.cfgProv.ScaleWithSimRate(ctx) {
+		rate = t.Rate()
+	}
+	j.simRate = rate
+	if j.gap != nil {
+		j.gap.SetRate(rate)
+	}
+}
+
+// wallClock converts a wait meant in flight time to wall-clock time. Under
+// time acceleration the scenery the wait spans passes sooner, and waiting
+// out the full duration would let the POIs behind it slip by unnarrated.
+func (j *NarrationJob) wallClock(d time.Duration) time.Duration {
+	if j.simRate <= 0 {
+		return d
+	}
+	return time.Duration(float64(d) / j.simRate)
+}
+
 // CanPreparePOI checks if the system is ready to prepare a POI narration (Manual or Auto).
 // This includes checking frequency rules (pipelining) and narrator state.
 func (j *NarrationJob) CanPreparePOI(ctx context.Context, t *sim.Telemetry) bool {
+	j.observeSimRate(ctx, t)
 	// 1. Pre-flight checks
 	if !j.checkPreConditions(ctx, t) {
 		return false
@@ -113,6 +247,12 @@ func (j *NarrationJob) CanPreparePOI(ctx context.Context, t *sim.Telemetry) bool
 	if !j.checkFlightStagePOI(t) {
 		return false
 	}
+	if !j.checkMinGroundSpeed(ctx, t) {
+		return false
+	}
+	if !j.checkMinAGL(t, j.cfgProv.POIMinAGL(ctx)) {
+		return false
+	}
 
 	// 2. Narrator Activity Check (Base)
 	// If already have an auto-narration staged or generating, we are busy.
@@ -124,6 +264,9 @@ func (j *NarrationJob) CanPreparePOI(ctx context.Context, t *sim.Telemetry) bool
 	if !j.checkNarratorReady() {
 		return false
 	}
+	if !j.checkGap() {
+		return false
+	}
 
 	// 3. Frequency & Pipeline Logic
 	return j.checkFrequencyRules(ctx)
@@ -131,6 +274,7 @@ func (j *NarrationJob) CanPreparePOI(ctx context.Context, t *sim.Telemetry) bool
 
 // CanPrepareEssay checks if the system is ready for an essay.
 func (j *NarrationJob) CanPrepareEssay(ctx context.Context, t *sim.Telemetry) bool {
+	j.observeSimRate(ctx, t)
 	// 1. Pre-flight
 	if !j.checkPreConditions(ctx, t) {
 		return false
@@ -152,6 +296,10 @@ func (j *NarrationJob) PreparePOI(ctx context.Context, t *sim.Telemetry) bool {
 	defer j.Unlock()
 
 	j.cachedBest = nil
+	if qid, ok := j.tourStop(); ok {
+		return j.prepareTourStop(ctx, qid, t)
+	}
+
 	// Pick best (first visible)
 	best := j.getVisibleCandidate(ctx, t)
 	if best == nil {
@@ -169,21 +317,37 @@ func (j *NarrationJob) PreparePOI(ctx context.Context, t *sim.Telemetry) bool {
 		return false
 	}
 
-	strategy := prompt.DetermineSkewStrategy(best, j.poiMgr.(prompt.POIAnalyzer), t.IsOnGround)
+	if j.approachAction(ctx, best, t) == approachAlert {
+		j.triggerApproachAlert(ctx, best, t)
+		return true
+	}
+
+	strategy := j.skewStrategy(ctx, best, j.poiMgr.(prompt.POIAnalyzer), t.IsOnGround)
 
 	// Logging
 	slog.Info("NarrationJob: Triggering POI", "name", best.DisplayName())
 	j.resetVisibilityBoost(ctx)
+	j.pruneApproachState(best.WikidataID)
+	return j.dispatchPOI(ctx, best.WikidataID, strategy, t)
+}
 
-	// Play or Pipeline
+// dispatchPOI plays the POI, or pipelines it behind the current narration.
+func (j *NarrationJob) dispatchPOI(ctx context.Context, qid, strategy string, t *sim.Telemetry) bool {
 	if j.narrator.IsPlaying() {
-		if err := j.narrator.PrepareNextNarrative(ctx, best.WikidataID, strategy, t); err != nil {
-			slog.Error("NarrationJob: Pipeline preparation failed", "error", err)
+		if err := j.narrator.PrepareNextNarrative(ctx, qid, strategy, t); err != nil {
+			if errors.Is(err, narrator.ErrOutlivesRelevance) {
+				slog.Info("NarrationJob: POI skipped", "qid", qid, "reason", err)
+			} else {
+				slog.Error("NarrationJob: Pipeline preparation failed", "error", err)
+			}
 			return false
 		}
 	} else {
 		// Auto-play (manual=false)
-		j.narrator.PlayPOI(ctx, best.WikidataID, false, false, t, strategy)
+		j.narrator.PlayPOI(ctx, qid, false, false, t, strategy)
+	}
+	if j.gap != nil {
+		j.gap.Started()
 	}
 	return true
 }
@@ -203,14 +367,19 @@ func (j *NarrationJob) PrepareEssay(ctx context.Context, t *sim.Telemetry) {
 }
 
 // checkFlightStagePOI enforces flight stage restrictions for POI auto-narration.
+// Ground vehicles and boats never leave the "ground" stages, so the restriction only applies to aircraft.
 func (j *NarrationJob) checkFlightStagePOI(t *sim.Telemetry) bool {
+	if !j.isAircraftMode() {
+		return true
+	}
+
 	switch t.FlightStage {
 	case sim.StageAirborne, sim.StageClimb, sim.StageCruise, sim.StageDescend:
 		// [NEW] Reinstate post-takeoff delay to allow 'letsgo' announcement to play
 		// and prevent selecting low-value POIs immediately on rotate.
 		takeOffTime := j.sim.GetLastTransition(sim.StageTakeOff)
 		if !takeOffTime.IsZero() {
-			delay := j.cfgProv.TakeoffDelay(context.Background())
+			delay := j.wallClock(j.cfgProv.TakeoffDelay(context.Background()))
 			if time.Since(takeOffTime) < delay {
 				slog.Debug("NarrationJob: Auto-narration suppressed during post-takeoff delay",
 					"elapsed", time.Since(takeOffTime).Round(time.Second),
@@ -228,6 +397,34 @@ func (j *NarrationJob) checkFlightStagePOI(t *sim.Telemetry) bool {
 	}
 }
 
+// checkMinGroundSpeed blocks auto-narration below the configured ground speed.
+// Telemetry at the gate (or from a hovering helicopter) can flicker between stages,
+// so speed is a more robust "actually going somewhere" signal. Manual plays do not
+// pass through the job and are therefore never gated.
+func (j *NarrationJob) checkMinGroundSpeed(ctx context.Context, t *sim.Telemetry) bool {
+	minKts := j.cfgProv.MinGroundSpeedKts(ctx)
+	if minKts <= 0 || t.GroundSpeed >= minKts {
+		return true
+	}
+	slog.Debug("NarrationJob: Auto-narration suppressed below minimum ground speed",
+		"ground_speed", t.GroundSpeed,
+		"min_kts", minKts)
+	return false
+}
+
+// checkMinAGL blocks auto-narration while the aircraft is lower than minAGL.
+// Ground vehicles and boats are always near 0ft AGL, so they are never gated.
+func (j *NarrationJob) checkMinAGL(t *sim.Telemetry, minAGL config.Distance) bool {
+	minFt := float64(minAGL) * 3.28084
+	if minFt <= 0 || !j.isAircraftMode() || t.AltitudeAGL >= minFt {
+		return true
+	}
+	slog.Debug("NarrationJob: Auto-narration suppressed below minimum AGL",
+		"agl_ft", t.AltitudeAGL,
+		"min_ft", minFt)
+	return false
+}
+
 // checkFrequencyRules determines if we can fire based on frequency settings (1-4).
 // Handles pipeline/overlap logic.
 func (j *NarrationJob) checkFrequencyRules(ctx context.Context) bool {
@@ -304,6 +501,11 @@ func (j *NarrationJob) checkPreConditions(ctx context.Context, t *sim.Telemetry)
 		return false
 	}
 
+	if j.overBudget(ctx) {
+		slog.Debug("NarrationJob: Session budget exhausted")
+		return false
+	}
+
 	if j.sim.GetState() != sim.StateActive {
 		return false
 	}
@@ -313,15 +515,87 @@ func (j *NarrationJob) checkPreConditions(ctx context.Context, t *sim.Telemetry)
 	return true
 }
 
+// isAircraftMode returns true unless the user is driving or sailing.
+func (j *NarrationJob) isAircraftMode() bool {
+	mode := j.cfgProv.VehicleMode(context.Background())
+	return mode != config.VehicleModeGround && mode != config.VehicleModeMarine
+}
+
 func (j *NarrationJob) isPlayable(ctx context.Context, p *model.POI) bool {
 	// Check if already in pipeline (Generating, Queued, Playing)
 	// This prevents the "double trigger" issue where a POI is selected again while generating/queued
 	if j.narrator.IsPOIBusy(p.WikidataID) {
 		return false
 	}
+	// Re-checked here because the user may block a POI after it was selected
+	if bc, ok := j.poiMgr.(BlockChecker); ok {
+		if bc.IsBlocked(ctx, p) {
+			return false
+		}
+	} else if poi.IsQIDBlocked(ctx, j.store, p.WikidataID) {
+		return false
+	}
+	if !j.hasEnoughSource(ctx, p) {
+		return false
+	}
 	return !p.IsOnCooldown(j.cfgProv.RepeatTTL(ctx))
 }
 
+// inForwardArc applies Narrator.ForwardArc, keeping POIs the aircraft has
+// already passed out of automatic selection so the guide never has to point
+// behind. Bearings are taken from the predicted position, which is where the
+// narration will be heard. Manual plays don't go through selection.
+func (j *NarrationJob) inForwardArc(ctx context.Context, p *model.POI, t *sim.Telemetry) bool {
+	if t == nil || !j.cfgProv.ForwardArcEnabled(ctx) {
+		return true
+	}
+	from := geo.Point{Lat: t.PredictedLatitude, Lon: t.PredictedLongitude}
+	if from.Lat == 0 && from.Lon == 0 {
+		from = geo.Point{Lat: t.Latitude, Lon: t.Longitude}
+	}
+	relBearing := math.Abs(geo.NormalizeAngle(geo.Bearing(from, geo.Point{Lat: p.Lat, Lon: p.Lon}) - t.Heading))
+	return relBearing <= j.cfgProv.ForwardArcMaxBearing(ctx)
+}
+
+// farFromLastNarrated applies Narrator.MinDistanceBetweenPOIsKm: POIs
+// clustered around the one just narrated would mostly repeat it. Once the
+// silence has lasted MinDistanceMaxSilence, a nearby POI beats saying nothing.
+func (j *NarrationJob) farFromLastNarrated(ctx context.Context, p *model.POI) bool {
+	minKm := j.cfgProv.MinDistanceBetweenPOIsKm(ctx)
+	if minKm <= 0 || j.lastNarrated == nil {
+		return true
+	}
+	lat, lon, ok := j.lastNarrated.LastNarratedPOI()
+	if !ok {
+		return true
+	}
+	if maxSilence := j.wallClock(j.cfgProv.MinDistanceMaxSilence(ctx)); maxSilence > 0 && !j.narrator.IsPlaying() && time.Since(j.lastTime) >= maxSilence {
+		return true
+	}
+	distKm := geo.Distance(geo.Point{Lat: lat, Lon: lon}, geo.Point{Lat: p.Lat, Lon: p.Lon}) / 1000.0
+	if distKm >= minKm {
+		return true
+	}
+	slog.Debug("NarrationJob: Deferring POI close to the last narrated one", "name", p.DisplayName(), "dist_km", distKm)
+	return false
+}
+
+// hasEnoughSource applies Narrator.MinArticleLength, keeping stubs whose
+// article is too thin for a narration out of auto-narration. POIs without
+// any article pass unless Narrator.NoArticle skips them: they were rescued
+// for their physical size and are narrated from Wikidata facts, so an article
+// length says nothing about them.
+func (j *NarrationJob) hasEnoughSource(ctx context.Context, p *model.POI) bool {
+	if !p.HasArticle() {
+		return j.cfgProv.NoArticle(ctx) != config.NoArticleSkip
+	}
+	minLen := j.cfgProv.MinArticleLength(ctx)
+	if minLen <= 0 || p.WPArticleLength <= 0 {
+		return true
+	}
+	return p.WPArticleLength >= minLen
+}
+
 // hasEligiblePOI returns true if there is at least one visible POI candidate.
 // This is used by checkEssayEligible to ensure essays are gap-fillers only.
 func (j *NarrationJob) hasEligiblePOI(ctx context.Context, t *sim.Telemetry) bool {
@@ -348,7 +622,7 @@ func (j *NarrationJob) checkEssayEligible(ctx context.Context, t *sim.Telemetry)
 
 	// Essay-specific cooldown (DelayBetweenEssays)
 	if !j.lastEssayTime.IsZero() {
-		if time.Since(j.lastEssayTime) < j.cfgProv.EssayDelayBetweenEssays(ctx) {
+		if time.Since(j.lastEssayTime) < j.wallClock(j.cfgProv.EssayDelayBetweenEssays(ctx)) {
 			return false
 		}
 	}
@@ -356,7 +630,14 @@ func (j *NarrationJob) checkEssayEligible(ctx context.Context, t *sim.Telemetry)
 	// Global delay before essay (Time since last narration)
 	// Must be quiet for at least DelayBeforeEssay
 	delayBeforeEssay := j.cfgProv.EssayDelayBeforeEssay(ctx)
-	if time.Since(j.lastTime) < delayBeforeEssay {
+	minSilence := j.cfgProv.PauseDuration(ctx) * 2
+	explorer := j.isFeatureless(ctx, t)
+	if explorer {
+		// Nothing to narrate for miles: the silence would only grow
+		delayBeforeEssay = j.cfgProv.EssayExplorerDelay(ctx)
+		minSilence = delayBeforeEssay
+	}
+	if time.Since(j.lastTime) < j.wallClock(delayBeforeEssay) {
 		return false
 	}
 
@@ -367,40 +648,65 @@ func (j *NarrationJob) checkEssayEligible(ctx context.Context, t *sim.Telemetry)
 	}
 
 	// Silence rule: at least 2x PauseDuration (Legacy check, maybe redundant now but safer to keep)
-	minSilence := j.cfgProv.PauseDuration(ctx) * 2
-	if time.Since(j.lastTime) < minSilence {
+	if time.Since(j.lastTime) < j.wallClock(minSilence) {
 		return false
 	}
 
-	// Altitude check
-	if t.AltitudeAGL < 2000 {
+	if !j.checkMinAGL(t, j.cfgProv.EssayMinAGL(ctx)) {
 		return false
 	}
 
-	slog.Debug("NarrationJob: Essay eligible (No POIs, Silence & Cooldown met)")
+	slog.Debug("NarrationJob: Essay eligible (No POIs, Silence & Cooldown met)", "explorer", explorer)
 	return true
 }
 
+// isFeatureless reports whether explorer mode applies: no tracked POI at all,
+// visible or not, within the explorer radius. Without a way to search nearby
+// POIs the terrain is never assumed empty.
+func (j *NarrationJob) isFeatureless(ctx context.Context, t *sim.Telemetry) bool {
+	if !j.cfgProv.EssayExplorer(ctx) {
+		return false
+	}
+	finder, ok := j.poiMgr.(NearbyPOIFinder)
+	if !ok {
+		return false
+	}
+	radius := float64(j.cfgProv.EssayExplorerRadius(ctx))
+	return len(finder.GetNearestTracked(ctx, t.Latitude, t.Longitude, radius, 1)) == 0
+}
+
 // getVisibleCandidate returns the highest-scoring POI that has line-of-sight.
 // If LOS is disabled or no checker is available, falls back to GetBestCandidate.
+// A priority landmark in range beats both.
 func (j *NarrationJob) getVisibleCandidate(ctx context.Context, t *sim.Telemetry) *model.POI {
+	if qid, ok := j.tourStop(); ok {
+		return j.tourCandidate(ctx, qid, t)
+	}
+	if p := j.priorityCandidate(ctx, t); p != nil {
+		return p
+	}
+
 	minScorePtr := j.getPOIQueryThreshold(ctx)
 	minScore := 0.0
 	if minScorePtr != nil {
 		minScore = *minScorePtr
+	} else if th, ok := j.adaptiveThreshold(ctx); ok {
+		// Key the cache on the smoothed threshold so a hysteresis step re-selects
+		minScore = th
 	}
 
 	if j.isCacheValid(t, minScore) {
 		return j.cachedBest
 	}
 	j.updateCacheMetadata(t, minScore)
+	j.updateValleyState(t)
 
 	if !j.cfgProv.LineOfSight(ctx) || j.losChecker == nil {
 		j.cachedBest = j.getBestCandidateFallback(ctx, t)
 		return j.cachedBest
 	}
 
-	candidates := j.poiMgr.GetNarrationCandidates(1000, minScorePtr)
+	candidates := j.narrationCandidates(ctx, 1000, minScorePtr)
 	j.logCandidateScoping(candidates, t)
 
 	if len(candidates) == 0 {
@@ -451,7 +757,10 @@ func (j *NarrationJob) findLOSVisibleCandidate(ctx context.Context, t *sim.Telem
 
 	var visibleCandidates []*model.POI
 	for i, poi := range candidates {
-		if poi.IsDeferred || !j.isPlayable(ctx, poi) || !j.isRarelyEligible(ctx, poi, t) {
+		if poi.IsDeferred || !j.isPlayable(ctx, poi) || !j.isRarelyEligible(ctx, poi, t) || !j.inForwardArc(ctx, poi, t) || !j.farFromLastNarrated(ctx, poi) {
+			continue
+		}
+		if j.approachAction(ctx, poi, t) == approachHold {
 			continue
 		}
 
@@ -501,15 +810,82 @@ func (j *NarrationJob) getBestCandidateFallback(ctx context.Context, t *sim.Tele
 	slog.Debug("NarrationJob: LOS disabled or no checker", "los_enabled", j.cfgProv.LineOfSight(ctx), "checker_nil", j.losChecker == nil)
 	minScore := j.getPOIQueryThreshold(ctx)
 	// Get more candidates to filter out deferred ones
-	cands := j.poiMgr.GetNarrationCandidates(10, minScore)
+	cands := j.narrationCandidates(ctx, 10, minScore)
 	for _, poi := range cands {
-		if !poi.IsDeferred {
+		if !poi.IsDeferred && j.inForwardArc(ctx, poi, t) && j.farFromLastNarrated(ctx, poi) && j.approachAction(ctx, poi, t) != approachHold {
 			return poi
 		}
 	}
 	return nil
 }
 
+// updateValleyState samples the terrain around the aircraft. Only aircraft
+// are checked: on the ground every road through the mountains is a valley.
+func (j *NarrationJob) updateValleyState(t *sim.Telemetry) {
+	if j.valley == nil || t == nil || !j.isAircraftMode() {
+		j.inValley = false
+		return
+	}
+	in := j.valley.InValley(t.Latitude, t.Longitude, t.Heading, t.AltitudeMSL)
+	if in != j.inValley {
+		slog.Info("NarrationJob: Valley state changed", "in_valley", in, "alt_msl_ft", int(t.AltitudeMSL))
+	}
+	j.inValley = in
+}
+
+// applyValleyBoost re-ranks candidates so surrounding peaks come first while
+// flying through a valley. The order is left untouched otherwise.
+func (j *NarrationJob) applyValleyBoost(cands []*model.POI) []*model.POI {
+	if !j.inValley || j.peakBoost <= 1.0 || len(cands) < 2 {
+		return cands
+	}
+	weight := func(p *model.POI) float64 {
+		w := p.Score * p.Visibility
+		if isPeakCategory(p.Category) {
+			w *= j.peakBoost
+		}
+		return w
+	}
+	sort.SliceStable(cands, func(a, b int) bool {
+		return weight(cands[a]) > weight(cands[b])
+	})
+	return cands
+}
+
+func isPeakCategory(category string) bool {
+	return strings.EqualFold(category, "peak") || strings.EqualFold(category, "volcano")
+}
+
+// narrationCandidates fetches the manager's candidates and, in adaptive mode,
+// cuts them at the smoothed threshold the map uses, so the narrator only
+// picks POIs the user can see in the adaptive list.
+func (j *NarrationJob) narrationCandidates(ctx context.Context, limit int, minScore *float64) []*model.POI {
+	cands := j.poiMgr.GetNarrationCandidates(limit, minScore)
+	if th, ok := j.adaptiveThreshold(ctx); ok {
+		kept := cands[:0:0]
+		for _, p := range cands {
+			if p.Score*p.Visibility >= th {
+				kept = append(kept, p)
+			}
+		}
+		cands = kept
+	}
+	return j.applyValleyBoost(cands)
+}
+
+// adaptiveThreshold returns the manager's smoothed threshold when the adaptive
+// filter is active and at least one scoring pass has produced it.
+func (j *NarrationJob) adaptiveThreshold(ctx context.Context) (float64, bool) {
+	if j.cfgProv.FilterMode(ctx) != "adaptive" {
+		return 0, false
+	}
+	at, ok := j.poiMgr.(AdaptiveThresholder)
+	if !ok {
+		return 0, false
+	}
+	return at.AdaptiveThreshold()
+}
+
 func (j *NarrationJob) getPOIQueryThreshold(ctx context.Context) *float64 {
 	if j.cfgProv.FilterMode(ctx) != "adaptive" {
 		val := j.cfgProv.MinScoreThreshold(ctx)
@@ -547,7 +923,11 @@ func (j *NarrationJob) isRarelyEligible(ctx context.Context, poi *model.POI, t *
 	if !ok {
 		return true
 	}
-	return prompt.DetermineSkewStrategy(poi, analyzer, t.IsOnGround) == prompt.StrategyMaxSkew
+	return j.skewStrategy(ctx, poi, analyzer, t.IsOnGround) == prompt.StrategyMaxSkew
+}
+
+func (j *NarrationJob) skewStrategy(ctx context.Context, p *model.POI, analyzer prompt.POIAnalyzer, isOnGround bool) string {
+	return prompt.DetermineSkewStrategy(p, analyzer, isOnGround, j.cfgProv.DominanceRivalFraction(ctx), j.cfgProv.DominanceRivalCount(ctx))
 }
 
 func (j *NarrationJob) checkPOIInLOS(poi *model.POI, aircraftPos geo.Point, aircraftAltFt float64, index int) bool {
@@ -7,6 +7,7 @@ import (
 	"phileasgo/pkg/llm"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/narrator"
+	"phileasgo/pkg/poi"
 	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/sim"
 	"testing"
@@ -108,6 +109,8 @@ func (m *mockPhase2NarratorService) GetPendingManualOverride() (string, string,
 }
 func (m *mockPhase2NarratorService) HasPendingManualOverride() bool { return false }
 func (m *mockPhase2NarratorService) GetPreparedPOI() *model.POI     { return nil }
+func (m *mockPhase2NarratorService) PlayApproachAlert(ctx context.Context, p *model.POI, tel *sim.Telemetry) {
+}
 func (m *mockPhase2NarratorService) PrepareNextNarrative(ctx context.Context, poiID string, strategy string, tel *sim.Telemetry) error {
 	return nil
 }
@@ -249,6 +252,43 @@ func TestPhase2_CanPrepareEssay(t *testing.T) {
 	}
 }
 
+func TestPhase2_CanPrepareEssay_Explorer(t *testing.T) {
+	tests := []struct {
+		name     string
+		explorer bool
+		nearby   []poi.NearbyPOI
+		want     bool
+	}{
+		{name: "Normal rules wait longer", explorer: false, want: false},
+		{name: "Explorer over empty terrain", explorer: true, want: true},
+		{name: "Explorer with a POI in range", explorer: true, nearby: []poi.NearbyPOI{{POI: &model.POI{WikidataID: "Q1"}, DistanceM: 20000}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.Essay.Enabled = true
+			cfg.Narrator.Essay.DelayBeforeEssay = config.Duration(2 * time.Minute)
+			cfg.Narrator.Essay.Explorer = tt.explorer
+			cfg.Narrator.Essay.ExplorerDelay = config.Duration(30 * time.Second)
+			cfg.Narrator.PauseDuration = config.Duration(30 * time.Second)
+
+			job := &NarrationJob{
+				cfgProv:  config.NewProvider(cfg, nil),
+				narrator: &mockNarratorService{},
+				poiMgr:   &mockPOIManager{lat: 25.0, lon: 10.0, nearby: tt.nearby},
+				sim:      &mockJobSimClient{state: sim.StateActive},
+				lastTime: time.Now().Add(-45 * time.Second), // Past the explorer delay only
+			}
+
+			tel := &sim.Telemetry{AltitudeAGL: 5000, Latitude: 25.0, Longitude: 10.0, FlightStage: sim.StageCruise}
+			if got := job.CanPrepareEssay(context.Background(), tel); got != tt.want {
+				t.Errorf("CanPrepareEssay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestPhase2_PreparePOI verifies PreparePOI behavior including boost logic.
 func TestPhase2_PreparePOI(t *testing.T) {
 	cfg := config.DefaultConfig()
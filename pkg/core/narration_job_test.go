@@ -7,9 +7,14 @@ import (
 	"phileasgo/pkg/llm"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/narrator"
+	"phileasgo/pkg/poi"
 	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/session"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/store"
+	"phileasgo/pkg/terrain"
+	"phileasgo/pkg/tour"
+	"phileasgo/pkg/tracker"
 	"testing"
 	"time"
 )
@@ -25,6 +30,7 @@ type mockNarratorService struct {
 	playPOICalled     bool
 	playImageCalled   bool
 	prepareNextCalled bool
+	approachAlerts    []string
 	RemainingFunc     func() time.Duration
 	AvgLatencyFunc    func() time.Duration
 }
@@ -50,6 +56,9 @@ func (m *mockNarratorService) PlayEssay(ctx context.Context, tel *sim.Telemetry)
 func (m *mockNarratorService) PlayPOI(ctx context.Context, poiID string, manual, enqueueIfBusy bool, tel *sim.Telemetry, strategy string) {
 	m.playPOICalled = true
 }
+func (m *mockNarratorService) PlayApproachAlert(ctx context.Context, p *model.POI, tel *sim.Telemetry) {
+	m.approachAlerts = append(m.approachAlerts, p.WikidataID)
+}
 func (m *mockNarratorService) PlayImage(ctx context.Context, imagePath string, tel *sim.Telemetry) {
 	m.playImageCalled = true
 }
@@ -103,9 +112,14 @@ func (m *mockNarratorService) IsUserPaused() bool
 func (m *mockNarratorService) RecordNarration(ctx context.Context, n *model.Narrative) {}
 
 type mockPOIManager struct {
-	best *model.POI
-	lat  float64
-	lon  float64
+	best   *model.POI
+	lat    float64
+	lon    float64
+	nearby []poi.NearbyPOI
+}
+
+func (m *mockPOIManager) GetNearestTracked(ctx context.Context, lat, lon, radiusM float64, limit int) []poi.NearbyPOI {
+	return m.nearby
 }
 
 func (m *mockPOIManager) GetBestCandidate(isOnGround bool) *model.POI {
@@ -456,6 +470,44 @@ func TestNarrationJob_EssayRules(t *testing.T) {
 	}
 }
 
+func TestNarrationJob_SimRateCooldown(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.AutoNarrate = true
+	cfg.Narrator.PauseDuration = config.Duration(30 * time.Second)
+	cfg.Narrator.Essay.Enabled = true
+	cfg.Narrator.Essay.DelayBetweenEssays = config.Duration(10 * time.Minute)
+	cfg.Narrator.Essay.DelayBeforeEssay = config.Duration(time.Second)
+
+	tests := []struct {
+		name         string
+		simRate      float64
+		noScaling    bool
+		lastEssayAgo time.Duration
+		wantEssay    bool
+	}{
+		{name: "Real time waits the full cooldown", simRate: 1, lastEssayAgo: 6 * time.Minute},
+		{name: "Unknown rate assumes real time", simRate: 0, lastEssayAgo: 6 * time.Minute},
+		{name: "2x halves the cooldown", simRate: 2, lastEssayAgo: 6 * time.Minute, wantEssay: true},
+		{name: "2x still waits half the cooldown", simRate: 2, lastEssayAgo: 4 * time.Minute},
+		{name: "Scaling disabled", simRate: 2, noScaling: true, lastEssayAgo: 6 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := *cfg
+			c.Narrator.ScaleWithSimRate = !tt.noScaling
+			job := NewNarrationJob(config.NewProvider(&c, nil), &mockNarratorService{}, &mockPOIManager{lat: 48.0, lon: -123.0}, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+			job.lastTime = time.Now().Add(-5 * time.Minute)
+			job.lastEssayTime = time.Now().Add(-tt.lastEssayAgo)
+
+			tel := &sim.Telemetry{AltitudeAGL: 3000, Latitude: 48.0, Longitude: -123.0, FlightStage: sim.StageCruise, SimRate: tt.simRate}
+			if got := job.CanPrepareEssay(context.Background(), tel); got != tt.wantEssay {
+				t.Errorf("CanPrepareEssay() = %v, want %v", got, tt.wantEssay)
+			}
+		})
+	}
+}
+
 func TestNarrationJob_isPlayable(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Narrator.RepeatTTL = config.Duration(600 * time.Second) // 10m
@@ -463,7 +515,11 @@ func TestNarrationJob_isPlayable(t *testing.T) {
 	now := time.Now()
 	tests := []struct {
 		name       string
+		qid        string
 		lastPlayed time.Time
+		minLength  int
+		articleLen int
+		noArticle  string
 		want       bool
 	}{
 		{
@@ -481,13 +537,60 @@ func TestNarrationJob_isPlayable(t *testing.T) {
 			lastPlayed: now.Add(-20 * time.Minute),
 			want:       true,
 		},
+		{
+			name: "Blocked",
+			qid:  "Q_BLOCKED",
+			want: false,
+		},
+		{
+			name:       "Short article without gate",
+			articleLen: 400,
+			want:       true,
+		},
+		{
+			name:       "Short article below gate",
+			minLength:  2000,
+			articleLen: 400,
+			want:       false,
+		},
+		{
+			name:       "Article at gate",
+			minLength:  2000,
+			articleLen: 2000,
+			want:       true,
+		},
+		{
+			name:      "Rescued POI without article passes gate",
+			minLength: 2000,
+			want:      true,
+		},
+		{
+			name:      "Rescued POI without article skipped",
+			noArticle: config.NoArticleSkip,
+			want:      false,
+		},
+		{
+			name:       "Article kept when article-less POIs are skipped",
+			noArticle:  config.NoArticleSkip,
+			articleLen: 400,
+			want:       true,
+		},
 	}
 
-	prov := config.NewProvider(cfg, nil)
-	job := &NarrationJob{cfgProv: prov, narrator: &mockNarratorService{}}
+	st := NewMockStore()
+	_ = st.SetState(context.Background(), "blocklist_Q_BLOCKED", "true")
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			poi := &model.POI{LastPlayed: tt.lastPlayed}
+			c := *cfg
+			c.Narrator.MinArticleLength = tt.minLength
+			if tt.noArticle != "" {
+				c.Narrator.NoArticle = tt.noArticle
+			}
+			job := &NarrationJob{cfgProv: config.NewProvider(&c, nil), narrator: &mockNarratorService{}, store: st}
+			poi := &model.POI{WikidataID: tt.qid, LastPlayed: tt.lastPlayed, WPArticleLength: tt.articleLen}
+			if tt.articleLen > 0 {
+				poi.WPURL = "https://en.wikipedia.org/wiki/Test"
+			}
 			if got := job.isPlayable(context.Background(), poi); got != tt.want {
 				t.Errorf("isPlayable() = %v, want %v", got, tt.want)
 			}
@@ -532,9 +635,12 @@ func (m *MockStore) GetRecentlyPlayedPOIs(ctx context.Context, since time.Time)
 }
 func (m *MockStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error { return nil }
 func (m *MockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius float64) error { return nil }
-func (m *MockStore) GetCache(ctx context.Context, key string) ([]byte, bool)             { return nil, false }
-func (m *MockStore) HasCache(ctx context.Context, key string) (bool, error)              { return false, nil }
-func (m *MockStore) SetCache(ctx context.Context, key string, val []byte) error          { return nil }
+func (m *MockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	return nil, nil
+}
+func (m *MockStore) GetCache(ctx context.Context, key string) ([]byte, bool)    { return nil, false }
+func (m *MockStore) HasCache(ctx context.Context, key string) (bool, error)     { return false, nil }
+func (m *MockStore) SetCache(ctx context.Context, key string, val []byte) error { return nil }
 func (m *MockStore) ListCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
@@ -547,6 +653,36 @@ func (m *MockStore) SetGeodataCache(ctx context.Context, key string, val []byte,
 func (m *MockStore) GetGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]store.GeodataRecord, error) {
 	return nil, nil
 }
+func (m *MockStore) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	return 0, nil
+}
+func (m *MockStore) ListGeodata(ctx context.Context, q store.GeodataQuery) ([]store.GeodataRecord, int, error) {
+	return nil, 0, nil
+}
+
+func (m *MockStore) AddNarrationLog(ctx context.Context, e store.NarrationLogEntry, keep int) error {
+	return nil
+}
+
+func (m *MockStore) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	return nil, nil
+}
+
+func (m *MockStore) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	return nil
+}
+
+func (m *MockStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return nil, nil
+}
+
+func (m *MockStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	return nil
+}
+
+func (m *MockStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return nil, nil
+}
 func (m *MockStore) ListGeodataCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
@@ -679,6 +815,94 @@ func TestNarrationJob_DynamicMinScore(t *testing.T) {
 	}
 }
 
+// adaptivePOIManager reports a fixed smoothed threshold like poi.Manager does
+// after a scoring pass in adaptive mode.
+type adaptivePOIManager struct {
+	mockPOIManager
+	threshold float64
+}
+
+func (m *adaptivePOIManager) AdaptiveThreshold() (float64, bool) { return m.threshold, true }
+
+func TestNarrationJob_AdaptiveThreshold(t *testing.T) {
+	tests := []struct {
+		name      string
+		threshold float64
+		wantPlay  bool
+	}{
+		{"Combined score above smoothed threshold", 4.0, true},
+		{"Combined score below smoothed threshold", 6.0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.AutoNarrate = true
+			cfg.Narrator.Essay.Enabled = false
+			store := NewMockStore()
+			store.SetState(context.Background(), "filter_mode", "adaptive")
+
+			mockN := &mockNarratorService{}
+			// Combined score 10 × 0.5 = 5
+			pm := &adaptivePOIManager{
+				mockPOIManager: mockPOIManager{best: &model.POI{Score: 10.0, Visibility: 0.5, WikidataID: "Q1"}, lat: 48.0, lon: -123.0},
+				threshold:      tt.threshold,
+			}
+			job := NewNarrationJob(config.NewProvider(cfg, store), mockN, pm, &mockJobSimClient{state: sim.StateActive}, store, nil)
+			job.lastTime = time.Time{}
+
+			tel := &sim.Telemetry{AltitudeAGL: 3000, Latitude: 48.0, Longitude: -123.0, FlightStage: sim.StageCruise}
+			job.PreparePOI(context.Background(), tel)
+			if mockN.playPOICalled != tt.wantPlay {
+				t.Errorf("PlayPOI called = %v, want %v", mockN.playPOICalled, tt.wantPlay)
+			}
+		})
+	}
+}
+
+func TestNarrationJob_SessionBudget(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.AutoNarrate = true
+	cfg.Narrator.SessionBudgetUSD = 0.50
+
+	tr := tracker.New()
+	tr.SetCosts(map[string]float64{"gemini": 2.0}, map[string]float64{"gemini": 2.0}, nil)
+
+	mockN := &mockNarratorService{}
+	pm := &mockPOIManager{best: &model.POI{Score: 10.0, WikidataID: "Q1"}, lat: 48.0, lon: -123.0}
+	simC := &mockJobSimClient{state: sim.StateActive}
+	job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, simC, nil, nil)
+	job.SetCostTracker(tr)
+
+	tel := &sim.Telemetry{
+		AltitudeAGL: 3000,
+		Latitude:    48.0,
+		Longitude:   -123.0,
+		FlightStage: sim.StageCruise,
+	}
+	job.lastTime = time.Time{}
+	ctx := context.Background()
+
+	// 100k tokens at $2/MTok: $0.20, still under budget
+	tr.TrackTokens("gemini", 80000, 20000)
+	if !job.CanPreparePOI(ctx, tel) {
+		t.Fatal("expected auto-narration under budget")
+	}
+
+	// Another 200k tokens: $0.60 in total
+	tr.TrackTokens("gemini", 150000, 50000)
+	if job.CanPreparePOI(ctx, tel) {
+		t.Error("expected CanPreparePOI to be suppressed over budget")
+	}
+	if job.CanPrepareEssay(ctx, tel) {
+		t.Error("expected CanPrepareEssay to be suppressed over budget")
+	}
+
+	tr.ResetSession(ctx)
+	if !job.CanPreparePOI(ctx, tel) {
+		t.Error("expected auto-narration to resume after a session reset")
+	}
+}
+
 func TestNarrationJob_PipelineLogic(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Narrator.AutoNarrate = true
@@ -800,6 +1024,43 @@ func TestNarrationJob_PipelineLogic(t *testing.T) {
 	}
 }
 
+// TestNarrationJob_PipelineWithGap prepares a third narration while the
+// second plays: the default fatigue gap must delay its start, not its
+// preparation.
+func TestNarrationJob_PipelineWithGap(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.AutoNarrate = true
+	cfg.Narrator.MinScoreThreshold = 5.0
+
+	mockN := &mockNarratorService{isPlaying: true, isActive: true}
+	mockN.RemainingFunc = func() time.Duration { return 2 * time.Second }
+	mockN.AvgLatencyFunc = func() time.Duration { return 12 * time.Second }
+	pm := &mockPOIManager{best: &model.POI{Score: 10.0, WikidataID: "Q3"}, lat: 48.0, lon: -123.0}
+	job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+
+	gap := NewNarrationGap(cfg.Narrator.Gap)
+	job.SetGap(gap)
+	gap.Started()
+	gap.Finished()
+	gap.Started() // Second narration, now playing
+	if gap.Current() <= 0 {
+		t.Fatal("expected the default fatigue to require a gap")
+	}
+
+	tel := &sim.Telemetry{AltitudeAGL: 3000, Latitude: 48.0, Longitude: -123.0, FlightStage: sim.StageCruise}
+	ctx := context.Background()
+	if !job.CanPreparePOI(ctx, tel) {
+		t.Fatal("expected the third narration to be prepared during playback")
+	}
+	job.PreparePOI(ctx, tel)
+	if !mockN.prepareNextCalled {
+		t.Error("expected PrepareNextNarrative to pipeline the third narration")
+	}
+	if gap.Wait() <= 0 {
+		t.Error("expected the gap to still hold back the start")
+	}
+}
+
 func TestNarrationJob_FlightStageRestrictions(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Narrator.AutoNarrate = true
@@ -865,6 +1126,86 @@ func TestNarrationJob_FlightStageRestrictions(t *testing.T) {
 	}
 }
 
+func TestNarrationJob_VehicleModeStageBypass(t *testing.T) {
+	tests := []struct {
+		name             string
+		mode             string
+		stage            string
+		expectShouldFire bool
+	}{
+		{name: "Aircraft - Taxi blocked", mode: config.VehicleModeAircraft, stage: sim.StageTaxi, expectShouldFire: false},
+		{name: "Ground - Taxi allowed", mode: config.VehicleModeGround, stage: sim.StageTaxi, expectShouldFire: true},
+		{name: "Marine - Landed allowed", mode: config.VehicleModeMarine, stage: sim.StageLanded, expectShouldFire: true},
+		{name: "Marine - On ground allowed", mode: config.VehicleModeMarine, stage: sim.StageOnGround, expectShouldFire: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.AutoNarrate = true
+			cfg.Narrator.VehicleMode = tt.mode
+
+			mockN := &mockNarratorService{}
+			poi := &model.POI{Score: 10.0, WikidataID: "Q1", Lat: 48.0, Lon: -123.0}
+			pm := &mockPOIManager{best: poi, lat: 48.0, lon: -123.0}
+			simC := &mockJobSimClient{state: sim.StateActive}
+			job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, simC, nil, nil)
+
+			tel := &sim.Telemetry{
+				Latitude:    48.0,
+				Longitude:   -123.0,
+				IsOnGround:  true,
+				FlightStage: tt.stage,
+			}
+
+			if got := job.CanPreparePOI(context.Background(), tel); got != tt.expectShouldFire {
+				t.Errorf("CanPreparePOI() = %v, want %v", got, tt.expectShouldFire)
+			}
+		})
+	}
+}
+
+func TestNarrationJob_MinGroundSpeedGate(t *testing.T) {
+	tests := []struct {
+		name             string
+		minKts           float64
+		groundSpeed      float64
+		expectShouldFire bool
+	}{
+		{name: "Gate disabled - hovering allowed", minKts: 0, groundSpeed: 0, expectShouldFire: true},
+		{name: "Below gate - blocked", minKts: 30, groundSpeed: 12, expectShouldFire: false},
+		{name: "Just below gate - blocked", minKts: 30, groundSpeed: 29.9, expectShouldFire: false},
+		{name: "At gate - released", minKts: 30, groundSpeed: 30, expectShouldFire: true},
+		{name: "Above gate - released", minKts: 30, groundSpeed: 120, expectShouldFire: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.AutoNarrate = true
+			cfg.Narrator.MinGroundSpeedKts = tt.minKts
+
+			mockN := &mockNarratorService{}
+			poi := &model.POI{Score: 50.0, WikidataID: "Q1", Lat: 48.0, Lon: -123.0}
+			pm := &mockPOIManager{best: poi, lat: 48.0, lon: -123.0}
+			simC := &mockJobSimClient{state: sim.StateActive}
+			job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, simC, nil, nil)
+
+			tel := &sim.Telemetry{
+				Latitude:    48.0,
+				Longitude:   -123.0,
+				AltitudeAGL: 500,
+				GroundSpeed: tt.groundSpeed,
+				FlightStage: sim.StageCruise,
+			}
+
+			if got := job.CanPreparePOI(context.Background(), tel); got != tt.expectShouldFire {
+				t.Errorf("CanPreparePOI() = %v, want %v", got, tt.expectShouldFire)
+			}
+		})
+	}
+}
+
 func TestNarrationJob_VisibilityBoostAGLCheck(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Narrator.AutoNarrate = true
@@ -955,3 +1296,339 @@ func TestNarrationJob_StartAirborne_NoDelay(t *testing.T) {
 		t.Error("Started airborne but CanPreparePOI returned false (Grace period incorrectly applied?)")
 	}
 }
+
+// wallElevation reports high terrain everywhere except directly on the track (lon 0).
+type wallElevation struct{}
+
+func (wallElevation) GetElevation(lat, lon float64) (int16, error) {
+	if lon > -0.01 && lon < 0.01 {
+		return 100, nil
+	}
+	return 2000, nil
+}
+func (wallElevation) GetLowestElevation(lat, lon, radiusNM float64) (int16, error) { return 100, nil }
+
+func TestNarrationJob_ValleyPeakBoost(t *testing.T) {
+	prov := config.NewProvider(config.DefaultConfig(), nil)
+	job := &NarrationJob{cfgProv: prov}
+	job.SetValleyDetector(terrain.NewValleyDetector(wallElevation{}, 3, 300), 1.5)
+
+	newCands := func() []*model.POI {
+		return []*model.POI{
+			{WikidataID: "Q_CASTLE", Category: "Castle", Score: 10, Visibility: 1},
+			{WikidataID: "Q_PEAK", Category: "Peak", Score: 8, Visibility: 1},
+		}
+	}
+
+	// Above the walls: order unchanged
+	job.updateValleyState(&sim.Telemetry{Latitude: 45, Longitude: 0, Heading: 0, AltitudeMSL: 10000})
+	if job.InValley() {
+		t.Fatal("expected no valley above the terrain")
+	}
+	if got := job.applyValleyBoost(newCands()); got[0].WikidataID != "Q_CASTLE" {
+		t.Errorf("expected original order outside a valley, got %s first", got[0].WikidataID)
+	}
+
+	// Between the walls: peak wins (8 * 1.5 > 10)
+	job.updateValleyState(&sim.Telemetry{Latitude: 45, Longitude: 0, Heading: 0, AltitudeMSL: 3000})
+	if !job.InValley() {
+		t.Fatal("expected valley detection between the walls")
+	}
+	if got := job.applyValleyBoost(newCands()); got[0].WikidataID != "Q_PEAK" {
+		t.Errorf("expected peak to be boosted, got %s first", got[0].WikidataID)
+	}
+
+	// No detector: never in a valley
+	job.SetValleyDetector(nil, 1.5)
+	job.updateValleyState(&sim.Telemetry{Latitude: 45, Longitude: 0, AltitudeMSL: 3000})
+	if job.InValley() {
+		t.Error("expected nil detector to be a no-op")
+	}
+}
+
+func TestNarrationJob_TwoPhaseApproach(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.AutoNarrate = true
+	cfg.Narrator.Approach.Enabled = true
+	cfg.Narrator.Approach.Radius = config.Distance(9260)
+	cfg.Narrator.Approach.MinScore = 10
+
+	// Landmark 0.1° (~11km) north of the start, aircraft heading north
+	landmark := func(score float64) *model.POI {
+		return &model.POI{WikidataID: "Q_LANDMARK", Score: score, Visibility: 1, Lat: 48.1, Lon: -123.0}
+	}
+	tel := func(lat, lon float64) *sim.Telemetry {
+		return &sim.Telemetry{Latitude: lat, Longitude: lon, Heading: 0, AltitudeAGL: 3000, FlightStage: sim.StageCruise}
+	}
+
+	t.Run("Alert on approach, narrate abeam", func(t *testing.T) {
+		mockN := &mockNarratorService{}
+		pm := &mockPOIManager{best: landmark(20)}
+		job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+		ctx := context.Background()
+
+		steps := []struct {
+			name       string
+			tel        *sim.Telemetry
+			wantAlerts int
+			wantPlay   bool
+		}{
+			{"Outside radius: held", tel(48.0, -123.0), 0, false},
+			{"Inside radius: alert", tel(48.03, -123.0), 1, false},
+			{"Closing in: held, no repeat", tel(48.06, -123.0), 1, false},
+			{"Abeam: full narration", tel(48.1, -123.02), 1, true},
+		}
+		for _, s := range steps {
+			job.PreparePOI(ctx, s.tel)
+			if len(mockN.approachAlerts) != s.wantAlerts || mockN.playPOICalled != s.wantPlay {
+				t.Fatalf("%s: alerts=%d play=%v, want %d and %v", s.name, len(mockN.approachAlerts), mockN.playPOICalled, s.wantAlerts, s.wantPlay)
+			}
+		}
+		if len(job.approach) != 0 {
+			t.Errorf("expected approach state to be cleared after narration, got %d entries", len(job.approach))
+		}
+	})
+
+	t.Run("Low score stays single-phase", func(t *testing.T) {
+		mockN := &mockNarratorService{}
+		pm := &mockPOIManager{best: landmark(5)}
+		job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+
+		job.PreparePOI(context.Background(), tel(48.0, -123.0))
+		if !mockN.playPOICalled || len(mockN.approachAlerts) != 0 {
+			t.Errorf("expected immediate narration without alert, got play=%v alerts=%d", mockN.playPOICalled, len(mockN.approachAlerts))
+		}
+	})
+}
+
+func TestNarrationJob_ForwardArc(t *testing.T) {
+	// Aircraft at 48N heading north; POIs ~11km away
+	tel := &sim.Telemetry{Latitude: 48.0, Longitude: -123.0, Heading: 0, AltitudeAGL: 3000, FlightStage: sim.StageCruise}
+	poiAt := func(lat, lon float64) *model.POI {
+		return &model.POI{WikidataID: "Q_POI", Score: 20, Visibility: 1, Lat: lat, Lon: lon}
+	}
+
+	tests := []struct {
+		name     string
+		enabled  bool
+		poi      *model.POI
+		pred     [2]float64
+		wantPlay bool
+	}{
+		{name: "Behind, filter off", poi: poiAt(47.9, -123.0), wantPlay: true},
+		{name: "Behind, filter on", enabled: true, poi: poiAt(47.9, -123.0), wantPlay: false},
+		{name: "Ahead, filter on", enabled: true, poi: poiAt(48.1, -123.0), wantPlay: true},
+		{name: "Abeam, filter on", enabled: true, poi: poiAt(48.0, -122.85), wantPlay: true},
+		// Abeam now, but behind the predicted position
+		{name: "Passed by the predicted position", enabled: true, poi: poiAt(48.0, -122.85), pred: [2]float64{48.1, -123.0}, wantPlay: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.AutoNarrate = true
+			cfg.Narrator.ForwardArc.Enabled = tt.enabled
+			mockN := &mockNarratorService{}
+			job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, &mockPOIManager{best: tt.poi}, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+
+			state := *tel
+			state.PredictedLatitude, state.PredictedLongitude = tt.pred[0], tt.pred[1]
+			job.PreparePOI(context.Background(), &state)
+			if mockN.playPOICalled != tt.wantPlay {
+				t.Errorf("played = %v, want %v", mockN.playPOICalled, tt.wantPlay)
+			}
+		})
+	}
+}
+
+func TestNarrationJob_MinDistanceBetweenPOIs(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.AutoNarrate = true
+	cfg.Narrator.MinDistanceBetweenPOIsKm = 5
+	tel := &sim.Telemetry{Latitude: 48.0, Longitude: -123.0, AltitudeAGL: 3000, FlightStage: sim.StageCruise}
+
+	first := &model.POI{WikidataID: "Q_FIRST", Score: 50, Visibility: 1, Lat: 48.05, Lon: -123.0}
+	pm := &mockPOIManager{best: first}
+	mockN := &mockNarratorService{}
+	sess := session.NewManager(nil)
+	job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+	job.SetNarratedPOILocator(sess)
+	// The aircraft moves between attempts, so the candidate cache never applies
+	prepare := func() bool {
+		tel.Longitude += 0.001
+		return job.PreparePOI(context.Background(), tel)
+	}
+
+	if !prepare() || !mockN.playPOICalled {
+		t.Fatal("expected the first POI to be narrated")
+	}
+	sess.SetLastNarratedPOI(first.Lat, first.Lon)
+
+	// ~1km from the first
+	pm.best = &model.POI{WikidataID: "Q_SECOND", Score: 50, Visibility: 1, Lat: 48.06, Lon: -123.0}
+	mockN.playPOICalled = false
+	job.lastTime = time.Now()
+	if prepare() || mockN.playPOICalled {
+		t.Error("expected the POI next to the last narrated one to be deferred")
+	}
+
+	// A long silence waives the spacing
+	job.lastTime = time.Now().Add(-time.Duration(cfg.Narrator.MinDistanceMaxSilence) - time.Second)
+	if !prepare() || !mockN.playPOICalled {
+		t.Error("expected the nearby POI to be narrated after a long silence")
+	}
+
+	// ~11km from the first
+	pm.best = &model.POI{WikidataID: "Q_FAR", Score: 50, Visibility: 1, Lat: 48.15, Lon: -123.0}
+	mockN.playPOICalled = false
+	job.lastTime = time.Now()
+	if !prepare() || !mockN.playPOICalled {
+		t.Error("expected a POI beyond the minimum distance to be narrated")
+	}
+}
+
+type tourPOIManager struct {
+	mockPOIManager
+	pois map[string]*model.POI
+}
+
+func (m *tourPOIManager) GetPOI(ctx context.Context, qid string) (*model.POI, error) {
+	if p, ok := m.pois[qid]; ok {
+		return p, nil
+	}
+	return nil, poi.ErrPOINotFound
+}
+
+func TestNarrationJob_Tour(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.AutoNarrate = true
+	cfg.Narrator.Tour.Radius = config.Distance(5000)
+
+	// The best-scoring POI sits right below the aircraft; the tour stop is ~11km north
+	pm := &tourPOIManager{
+		mockPOIManager: mockPOIManager{best: &model.POI{WikidataID: "Q_SCORED", Score: 100, Visibility: 1, Lat: 48.0, Lon: -123.0}},
+		pois: map[string]*model.POI{
+			"Q1": {WikidataID: "Q1", Lat: 48.1, Lon: -123.0},
+		},
+	}
+	tr := tour.New()
+	if err := tr.Start([]string{"Q1", "Q2"}); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	mockN := &mockNarratorService{}
+	job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+	job.SetTour(tr)
+	ctx := context.Background()
+	tel := func(lat float64) *sim.Telemetry {
+		return &sim.Telemetry{Latitude: lat, Longitude: -123.0, AltitudeAGL: 3000, FlightStage: sim.StageCruise}
+	}
+
+	if job.PreparePOI(ctx, tel(48.0)) || mockN.playPOICalled {
+		t.Fatal("expected scored POIs to be ignored while the tour stop is out of range")
+	}
+
+	if !job.PreparePOI(ctx, tel(48.08)) || !mockN.playPOICalled {
+		t.Fatal("expected the tour stop to be narrated within the radius")
+	}
+	if qid, _ := tr.Current(); qid != "Q2" {
+		t.Errorf("expected the tour to advance to Q2, got %q", qid)
+	}
+
+	// Q2 is not loaded yet: the tour waits rather than falling back to scoring
+	mockN.playPOICalled = false
+	if job.PreparePOI(ctx, tel(48.0)) || mockN.playPOICalled {
+		t.Error("expected to wait for an unloaded tour stop")
+	}
+
+	tr.Clear()
+	if !job.PreparePOI(ctx, tel(48.0)) {
+		t.Error("expected normal selection to resume after the tour")
+	}
+}
+
+type priorityPOIManager struct {
+	mockPOIManager
+	priority []*model.POI
+}
+
+func (m *priorityPOIManager) GetPriorityCandidates(lat, lon float64) []*model.POI {
+	return m.priority
+}
+
+func TestNarrationJob_PriorityLandmark(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Narrator.AutoNarrate = true
+	cfg.Narrator.MinScoreThreshold = 10.0
+
+	tests := []struct {
+		name       string
+		stage      string
+		paused     bool
+		lastPlayed time.Time
+		wantPlay   bool
+	}{
+		{name: "Below the score threshold", stage: sim.StageCruise, wantPlay: true},
+		{name: "Paused", stage: sim.StageCruise, paused: true},
+		{name: "Taxiing", stage: sim.StageTaxi},
+		{name: "Already narrated", stage: sim.StageCruise, lastPlayed: time.Now().Add(-time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			landmark := &model.POI{WikidataID: "Q243", Score: 1.0, Lat: 48.01, Lon: -123.0, WPURL: "https://en.wikipedia.org/wiki/Eiffel_Tower", LastPlayed: tt.lastPlayed}
+			pm := &priorityPOIManager{mockPOIManager: mockPOIManager{lat: 48.0, lon: -123.0}, priority: []*model.POI{landmark}}
+			mockN := &mockNarratorService{isPaused: tt.paused}
+			job := NewNarrationJob(config.NewProvider(cfg, nil), mockN, pm, &mockJobSimClient{state: sim.StateActive}, nil, nil)
+			ctx := context.Background()
+			tel := &sim.Telemetry{Latitude: 48.0, Longitude: -123.0, AltitudeAGL: 3000, FlightStage: tt.stage}
+
+			played := job.CanPreparePOI(ctx, tel) && job.PreparePOI(ctx, tel)
+			if played != tt.wantPlay || mockN.playPOICalled != tt.wantPlay {
+				t.Errorf("played = %v (PlayPOI called: %v), want %v", played, mockN.playPOICalled, tt.wantPlay)
+			}
+		})
+	}
+}
+
+func TestNarrationJob_MinAGL(t *testing.T) {
+	tests := []struct {
+		name      string
+		poiMin    config.Distance
+		essayMin  config.Distance
+		agl       float64
+		wantPOI   bool
+		wantEssay bool
+	}{
+		{name: "Defaults: low", poiMin: 0, essayMin: config.Distance(609.6), agl: 1900, wantPOI: true},
+		{name: "Defaults: high", poiMin: 0, essayMin: config.Distance(609.6), agl: 2100, wantPOI: true, wantEssay: true},
+		{name: "Bush flying: below both", poiMin: config.Distance(304.8), essayMin: config.Distance(152.4), agl: 400},
+		{name: "Bush flying: between", poiMin: config.Distance(304.8), essayMin: config.Distance(152.4), agl: 600, wantEssay: true},
+		{name: "Bush flying: above both", poiMin: config.Distance(304.8), essayMin: config.Distance(152.4), agl: 1100, wantPOI: true, wantEssay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.AutoNarrate = true
+			cfg.Narrator.MinScoreThreshold = 0.5
+			cfg.Narrator.Essay.Enabled = true
+			cfg.Narrator.POIMinAGL = tt.poiMin
+			cfg.Narrator.Essay.MinAGL = tt.essayMin
+			prov := config.NewProvider(cfg, nil)
+			ctx := context.Background()
+			tel := &sim.Telemetry{AltitudeAGL: tt.agl, Latitude: 48.0, Longitude: -123.0, FlightStage: sim.StageCruise}
+
+			poiJob := NewNarrationJob(prov, &mockNarratorService{}, &mockPOIManager{best: &model.POI{Score: 1.0, WikidataID: "Q1"}, lat: 48.0, lon: -123.0}, &mockJobSimClient{}, nil, nil)
+			if got := poiJob.CanPreparePOI(ctx, tel); got != tt.wantPOI {
+				t.Errorf("CanPreparePOI at %.0fft = %v, want %v", tt.agl, got, tt.wantPOI)
+			}
+
+			essayJob := NewNarrationJob(prov, &mockNarratorService{}, &mockPOIManager{lat: 48.0, lon: -123.0}, &mockJobSimClient{}, nil, nil)
+			essayJob.lastTime = time.Now().Add(-time.Hour)
+			if got := essayJob.CanPrepareEssay(ctx, tel); got != tt.wantEssay {
+				t.Errorf("CanPrepareEssay at %.0fft = %v, want %v", tt.agl, got, tt.wantEssay)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/sim"
+)
+
+// PriorityProvider lists the priority landmarks in range; the POI manager
+// implements it.
+type PriorityProvider interface {
+	GetPriorityCandidates(lat, lon float64) []*model.POI
+}
+
+// priorityCandidate returns the nearest priority landmark in range that is
+// ready to be narrated. It goes before the scored candidates, so neither the
+// score threshold nor line of sight applies; the readiness checks in
+// CanPreparePOI, such as pause and flight stage, still do.
+func (j *NarrationJob) priorityCandidate(ctx context.Context, t *sim.Telemetry) *model.POI {
+	pp, ok := j.poiMgr.(PriorityProvider)
+	if !ok || t == nil {
+		return nil
+	}
+	for _, p := range pp.GetPriorityCandidates(t.Latitude, t.Longitude) {
+		if j.isPlayable(ctx, p) {
+			slog.Debug("NarrationJob: Priority landmark in range", "name", p.DisplayName(), "score", p.Score)
+			return p
+		}
+	}
+	return nil
+}
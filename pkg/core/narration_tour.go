@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+
+	"phileasgo/pkg/geo"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/sim"
+)
+
+// TourGuide supplies the stops of a guided tour, in order.
+type TourGuide interface {
+	Current() (qid string, ok bool)
+	Complete(qid string)
+}
+
+// POILookup finds a POI by QID, tracked or not.
+type POILookup interface {
+	GetPOI(ctx context.Context, qid string) (*model.POI, error)
+}
+
+// SetTour enables guided tours. While g has a current stop, scoring is
+// ignored and only that stop is narrated, once within Narrator.Tour.Radius.
+func (j *NarrationJob) SetTour(g TourGuide) {
+	j.tour = g
+}
+
+// tourStop returns the current stop of a running tour.
+func (j *NarrationJob) tourStop() (string, bool) {
+	if j.tour == nil {
+		return "", false
+	}
+	return j.tour.Current()
+}
+
+// tourCandidate returns the current stop if it is within the tour radius.
+// A stop whose tile hasn't been loaded yet is not found and simply waits.
+func (j *NarrationJob) tourCandidate(ctx context.Context, qid string, t *sim.Telemetry) *model.POI {
+	lookup, ok := j.poiMgr.(POILookup)
+	if !ok || t == nil {
+		return nil
+	}
+	p, err := lookup.GetPOI(ctx, qid)
+	if err != nil || p == nil {
+		slog.Debug("NarrationJob: Tour stop not available yet", "qid", qid, "error", err)
+		return nil
+	}
+
+	dist := geo.Distance(geo.Point{Lat: t.Latitude, Lon: t.Longitude}, geo.Point{Lat: p.Lat, Lon: p.Lon})
+	if dist > float64(j.cfgProv.AppConfig().Narrator.Tour.Radius) {
+		return nil
+	}
+	return p
+}
+
+// prepareTourStop narrates the current tour stop when in range. Cooldown and
+// the article gate don't apply: the stop was picked by hand.
+func (j *NarrationJob) prepareTourStop(ctx context.Context, qid string, t *sim.Telemetry) bool {
+	p := j.tourCandidate(ctx, qid, t)
+	if p == nil || j.narrator.IsPOIBusy(qid) {
+		return false
+	}
+
+	strategy := j.skewStrategy(ctx, p, j.poiMgr.(prompt.POIAnalyzer), t.IsOnGround)
+	slog.Info("NarrationJob: Triggering tour stop", "name", p.DisplayName())
+	if !j.dispatchPOI(ctx, qid, strategy, t) {
+		return false
+	}
+	j.tour.Complete(qid)
+	return true
+}
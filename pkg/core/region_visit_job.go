@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"phileasgo/pkg/sim"
+	"phileasgo/pkg/store"
+)
+
+// RegionVisitJob records the region below the aircraft at a fixed interval
+// and serves the visit history to the scorer's region novelty term.
+//
+// The history it serves is the one loaded at startup: visits recorded while
+// the app runs only count from its next start, so the region being flown
+// over does not turn familiar during the flight itself.
+type RegionVisitJob struct {
+	BaseJob
+	st       store.RegionVisitStore
+	sim      sim.Client
+	geo      LocationProvider
+	interval time.Duration
+	lastTime time.Time
+
+	mu     sync.RWMutex
+	visits map[string]time.Time // nil until loaded
+}
+
+// NewRegionVisitJob creates a job recording a visit every interval.
+func NewRegionVisitJob(st store.RegionVisitStore, s sim.Client, geo LocationProvider, interval time.Duration) *RegionVisitJob {
+	return &RegionVisitJob{
+		BaseJob:  NewBaseJob("RegionVisits", true),
+		st:       st,
+		sim:      s,
+		geo:      geo,
+		interval: interval,
+	}
+}
+
+// RegionVisits returns the visit history from before this run, or nil until
+// it has been loaded.
+func (j *RegionVisitJob) RegionVisits() map[string]time.Time {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return j.visits
+}
+
+func (j *RegionVisitJob) ShouldFire(t *sim.Telemetry) bool {
+	if atomic.LoadInt32(&j.running) == 1 {
+		return false
+	}
+	return time.Since(j.lastTime) >= j.interval
+}
+
+func (j *RegionVisitJob) Run(ctx context.Context, t *sim.Telemetry) {
+	if !j.TryLock() {
+		return
+	}
+	defer j.Unlock()
+
+	now := time.Now()
+	j.lastTime = now
+
+	if j.RegionVisits() == nil {
+		visits, err := j.st.ListRegionVisits(ctx)
+		if err != nil {
+			slog.Warn("RegionVisits: Failed to load visit history", "error", err)
+			return
+		}
+		if visits == nil {
+			visits = make(map[string]time.Time)
+		}
+		j.mu.Lock()
+		j.visits = visits
+		j.mu.Unlock()
+	}
+
+	// Taxiing around the home airport is not a visit
+	if j.sim.GetState() != sim.StateActive || !t.HasValidData || t.IsOnGround {
+		return
+	}
+
+	region := j.geo.GetLocation(t.Latitude, t.Longitude).RegionKey()
+	if region == "" {
+		return
+	}
+	if err := j.st.SaveRegionVisit(ctx, region, now); err != nil {
+		slog.Warn("RegionVisits: Failed to store visit", "region", region, "error", err)
+	}
+}
@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/sim"
+)
+
+type visitStore struct {
+	saved map[string]time.Time
+}
+
+func (v *visitStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	v.saved[region] = t
+	return nil
+}
+
+func (v *visitStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return map[string]time.Time{"FR/IDF": time.Now().Add(-time.Hour)}, nil
+}
+
+type fixedLocation struct {
+	loc model.LocationInfo
+}
+
+func (f fixedLocation) GetLocation(lat, lon float64) model.LocationInfo { return f.loc }
+func (f fixedLocation) ReorderFeatures(lat, lon float64)                {}
+
+func TestRegionVisitJob(t *testing.T) {
+	airborne := sim.Telemetry{Latitude: 48.1, Longitude: 11.6, HasValidData: true}
+	bavaria := model.LocationInfo{CountryCode: "DE", Admin1Code: "BY"}
+
+	tests := []struct {
+		name       string
+		state      sim.State
+		onGround   bool
+		loc        model.LocationInfo
+		wantRegion string
+	}{
+		{name: "Records the region below", state: sim.StateActive, loc: bavaria, wantRegion: "DE/BY"},
+		{name: "Country without admin1", state: sim.StateActive, loc: model.LocationInfo{CountryCode: "MC"}, wantRegion: "MC"},
+		{name: "Skips international waters", state: sim.StateActive},
+		{name: "Skips on the ground", state: sim.StateActive, onGround: true, loc: bavaria},
+		{name: "Skips when disconnected", state: sim.StateDisconnected, loc: bavaria},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &visitStore{saved: make(map[string]time.Time)}
+			job := NewRegionVisitJob(st, &mockSimClient{state: tt.state}, fixedLocation{loc: tt.loc}, time.Minute)
+			if job.RegionVisits() != nil {
+				t.Fatal("expected no history before the first run")
+			}
+
+			tel := airborne
+			tel.IsOnGround = tt.onGround
+			job.Run(context.Background(), &tel)
+
+			if _, ok := job.RegionVisits()["FR/IDF"]; !ok {
+				t.Errorf("expected the stored history to load, got %v", job.RegionVisits())
+			}
+			if tt.wantRegion == "" {
+				if len(st.saved) != 0 {
+					t.Errorf("expected no visit, got %v", st.saved)
+				}
+				return
+			}
+			if _, ok := st.saved[tt.wantRegion]; !ok || len(st.saved) != 1 {
+				t.Errorf("expected a visit to %s, got %v", tt.wantRegion, st.saved)
+			}
+			// This run's visits only count from the next start
+			if _, ok := job.RegionVisits()[tt.wantRegion]; ok {
+				t.Errorf("expected the served history to exclude this run")
+			}
+			if job.ShouldFire(&tel) {
+				t.Error("expected no visit before the interval elapsed")
+			}
+		})
+	}
+}
@@ -20,11 +20,11 @@ type RiverJob struct {
 	logger    *slog.Logger
 }
 
-// NewRiverJob creates a new RiverJob with a 15s interval.
-func NewRiverJob(manager *poi.Manager) *RiverJob {
+// NewRiverJob creates a new RiverJob running every interval.
+func NewRiverJob(manager *poi.Manager, interval time.Duration) *RiverJob {
 	return &RiverJob{
 		BaseJob:   NewBaseJob("RiverJob", true),
-		threshold: 15 * time.Second,
+		threshold: interval,
 		manager:   manager,
 		logger:    slog.With("component", "river_job"),
 	}
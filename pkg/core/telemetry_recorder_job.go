@@ -0,0 +1,70 @@
+package core
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"phileasgo/pkg/sim"
+	"phileasgo/pkg/store"
+)
+
+// TelemetryRecorderJob is the flight recorder: it appends a compact position
+// sample to the telemetry log at a fixed interval.
+type TelemetryRecorderJob struct {
+	BaseJob
+	st       store.TelemetryLogStore
+	sim      sim.Client
+	interval time.Duration
+	keep     int
+	lastTime time.Time
+}
+
+// NewTelemetryRecorderJob creates a recorder sampling every interval and
+// keeping at most keep samples.
+func NewTelemetryRecorderJob(st store.TelemetryLogStore, s sim.Client, interval time.Duration, keep int) *TelemetryRecorderJob {
+	return &TelemetryRecorderJob{
+		BaseJob:  NewBaseJob("TelemetryRecorder", true),
+		st:       st,
+		sim:      s,
+		interval: interval,
+		keep:     keep,
+	}
+}
+
+func (j *TelemetryRecorderJob) ShouldFire(t *sim.Telemetry) bool {
+	if atomic.LoadInt32(&j.running) == 1 {
+		return false
+	}
+	return time.Since(j.lastTime) >= j.interval
+}
+
+func (j *TelemetryRecorderJob) Run(ctx context.Context, t *sim.Telemetry) {
+	if !j.TryLock() {
+		return
+	}
+	defer j.Unlock()
+
+	// The scheduler only hands out telemetry while active, but the job runs
+	// in its own goroutine and the sim may have dropped in the meantime;
+	// a stale position must not end up in the track.
+	if j.sim.GetState() != sim.StateActive || !t.HasValidData {
+		return
+	}
+
+	now := time.Now()
+	j.lastTime = now
+	sample := store.TelemetrySample{
+		Time:        now,
+		Lat:         t.Latitude,
+		Lon:         t.Longitude,
+		AltitudeMSL: t.AltitudeMSL,
+		Heading:     t.Heading,
+		GroundSpeed: t.GroundSpeed,
+		Stage:       t.FlightStage,
+	}
+	if err := j.st.AddTelemetrySample(ctx, sample, j.keep); err != nil {
+		slog.Warn("Recorder: Failed to store telemetry sample", "error", err)
+	}
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/sim"
+	"phileasgo/pkg/store"
+)
+
+type recordingStore struct {
+	samples []store.TelemetrySample
+	keep    int
+}
+
+func (r *recordingStore) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	r.samples = append(r.samples, s)
+	r.keep = keep
+	return nil
+}
+
+func (r *recordingStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return r.samples, nil
+}
+
+func TestTelemetryRecorderJob(t *testing.T) {
+	tel := sim.Telemetry{Latitude: 47.1, Longitude: 8.2, AltitudeMSL: 4500, Heading: 270, GroundSpeed: 105, FlightStage: "cruise", HasValidData: true}
+
+	tests := []struct {
+		name      string
+		state     sim.State
+		valid     bool
+		wantCount int
+	}{
+		{"Records while active", sim.StateActive, true, 1},
+		{"Skips when disconnected", sim.StateDisconnected, true, 0},
+		{"Skips invalid telemetry", sim.StateActive, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := &recordingStore{}
+			job := NewTelemetryRecorderJob(st, &mockSimClient{state: tt.state}, time.Minute, 100)
+
+			sample := tel
+			sample.HasValidData = tt.valid
+			if !job.ShouldFire(&sample) {
+				t.Fatal("expected the first sample to fire")
+			}
+			job.Run(context.Background(), &sample)
+
+			if len(st.samples) != tt.wantCount {
+				t.Fatalf("expected %d samples, got %d", tt.wantCount, len(st.samples))
+			}
+			if tt.wantCount == 0 {
+				return
+			}
+			got := st.samples[0]
+			if got.Lat != 47.1 || got.Lon != 8.2 || got.AltitudeMSL != 4500 || got.Heading != 270 || got.GroundSpeed != 105 || got.Stage != "cruise" {
+				t.Errorf("unexpected sample %+v", got)
+			}
+			if st.keep != 100 {
+				t.Errorf("expected keep=100, got %d", st.keep)
+			}
+			if job.ShouldFire(&sample) {
+				t.Error("expected no sample before the interval elapsed")
+			}
+		})
+	}
+}
@@ -58,120 +58,3 @@ func (d *DB) PruneCache(olderThan time.Duration) error {
 	_, err := d.Exec("DELETE FROM cache WHERE created_at < ?", deadline)
 	return err
 }
-
-func (d *DB) migrate() error {
-	queries := []string{
-		`CREATE TABLE IF NOT EXISTS poi (
-			wikidata_id TEXT PRIMARY KEY,
-			source TEXT,
-			category TEXT,
-			specific_category TEXT,
-			lat REAL,
-			lon REAL,
-			sitelinks INTEGER,
-			name_en TEXT,
-			name_local TEXT,
-			name_user TEXT,
-			wp_url TEXT,
-			wp_article_length INTEGER,
-			trigger_qid TEXT,
-			last_played DATETIME,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			is_msfs_poi BOOLEAN DEFAULT 0,
-			thumbnail_url TEXT
-		);`,
-		`CREATE TABLE IF NOT EXISTS msfs_poi (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			type TEXT,
-			name TEXT,
-			ident TEXT,
-			lat REAL,
-			lon REAL,
-			elevation REAL
-		);`,
-		`CREATE TABLE IF NOT EXISTS wikidata_hierarchy (
-			qid TEXT PRIMARY KEY,
-			name TEXT,
-			parents TEXT,
-			category TEXT,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME
-		);`,
-		`CREATE TABLE IF NOT EXISTS wikipedia_articles (
-			uuid TEXT PRIMARY KEY,
-			title TEXT,
-			url TEXT,
-			names TEXT,
-			text TEXT,
-			lengths TEXT,
-			thumbnail_url TEXT,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		);`,
-		`CREATE TABLE IF NOT EXISTS persistent_state (
-			key TEXT PRIMARY KEY,
-			value TEXT,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		);`,
-		`CREATE TABLE IF NOT EXISTS cache (
-			key TEXT PRIMARY KEY,
-			value BLOB,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		);`,
-		`CREATE TABLE IF NOT EXISTS cache_geodata (
-			key TEXT PRIMARY KEY,
-			data BLOB,
-			radius_m INTEGER,
-			lat REAL,
-			lon REAL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		);`,
-		`CREATE INDEX IF NOT EXISTS idx_cache_geodata_geo ON cache_geodata(lat, lon);`,
-		`CREATE TABLE IF NOT EXISTS seen_entities (
-			qid TEXT PRIMARY KEY,
-			instances TEXT,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		);`,
-		`CREATE TABLE IF NOT EXISTS regional_categories (
-			lat_grid INTEGER,
-			lon_grid INTEGER,
-			categories TEXT,
-			labels TEXT,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME,
-			PRIMARY KEY (lat_grid, lon_grid)
-		);`,
-	}
-
-	for _, q := range queries {
-		if _, err := d.Exec(q); err != nil {
-			return fmt.Errorf("exec error: %w query: %s", err, q)
-		}
-	}
-
-	// Migration: Add is_msfs_poi if missing
-	var colCount int
-	err := d.QueryRow("SELECT count(*) FROM pragma_table_info('poi') WHERE name='is_msfs_poi'").Scan(&colCount)
-	if err == nil && colCount == 0 {
-		if _, err := d.Exec("ALTER TABLE poi ADD COLUMN is_msfs_poi BOOLEAN DEFAULT 0"); err != nil {
-			return fmt.Errorf("failed to add is_msfs_poi column: %w", err)
-		}
-	}
-
-	// Migration: Add thumbnail_url if missing
-	err = d.QueryRow("SELECT count(*) FROM pragma_table_info('poi') WHERE name='thumbnail_url'").Scan(&colCount)
-	if err == nil && colCount == 0 {
-		if _, err := d.Exec("ALTER TABLE poi ADD COLUMN thumbnail_url TEXT"); err != nil {
-			return fmt.Errorf("failed to add thumbnail_url column: %w", err)
-		}
-	}
-
-	// Migration: Add labels column to regional_categories if missing
-	err = d.QueryRow("SELECT count(*) FROM pragma_table_info('regional_categories') WHERE name='labels'").Scan(&colCount)
-	if err == nil && colCount == 0 {
-		if _, err := d.Exec("ALTER TABLE regional_categories ADD COLUMN labels TEXT"); err != nil {
-			return fmt.Errorf("failed to add labels column to regional_categories: %w", err)
-		}
-	}
-
-	return nil
-}
@@ -18,9 +18,9 @@ import (
 const msfsPOITableStateKey = "msfs_master_csv_mtime"
 
 // Run executes all maintenance tasks: Import and Pruning.
-// It uses the provided logger or falls back to default.
+// Seen entities older than seenPruneAfter are deleted; 0 keeps them.
 // It blocks until completion.
-func Run(ctx context.Context, s store.Store, d *db.DB, csvPath string) error {
+func Run(ctx context.Context, s store.Store, d *db.DB, csvPath string, seenPruneAfter time.Duration) error {
 	slog.Info("Starting database maintenance...")
 
 	if err := importMSFS(ctx, s, csvPath); err != nil {
@@ -36,6 +36,10 @@ func Run(ctx context.Context, s store.Store, d *db.DB, csvPath string) error {
 		slog.Info("Cache pruning completed")
 	}
 
+	if seenPruneAfter > 0 {
+		pruneSeen(ctx, s, seenPruneAfter)
+	}
+
 	return nil
 }
 
@@ -161,3 +165,23 @@ func pruneCache(ctx context.Context, d *db.DB) error {
 	// 30 days
 	return d.PruneCache(30 * 24 * time.Hour)
 }
+
+// seenPruner is implemented by stores that can age out seen entities.
+type seenPruner interface {
+	PruneSeenEntities(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// pruneSeen deletes seen entities long past any sensible TTL; they would be
+// classified again anyway, so keeping them only grows the table.
+func pruneSeen(ctx context.Context, s store.Store, olderThan time.Duration) {
+	p, ok := s.(seenPruner)
+	if !ok {
+		return
+	}
+	n, err := p.PruneSeenEntities(ctx, olderThan)
+	if err != nil {
+		slog.Error("Seen entity pruning failed", "error", err)
+		return
+	}
+	slog.Info("Seen entity pruning completed", "removed", n)
+}
@@ -49,8 +49,17 @@ func TestMaintenance(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	// Seen entities: one past the prune age, one recent
+	oldSeen := time.Now().Add(-400 * 24 * time.Hour).UTC().Format("2006-01-02 15:04:05")
+	if _, err := d.Exec("INSERT INTO seen_entities (qid, instances, created_at) VALUES (?, ?, ?)", "Q1", "[]", oldSeen); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.MarkEntitiesSeen(ctx, map[string][]string{"Q2": nil}); err != nil {
+		t.Fatal(err)
+	}
+
 	// Run Maintenance
-	if err := Run(ctx, s, d, csvPath); err != nil {
+	if err := Run(ctx, s, d, csvPath, 365*24*time.Hour); err != nil {
 		t.Fatalf("Run failed: %v", err)
 	}
 
@@ -90,4 +99,17 @@ func TestMaintenance(t *testing.T) {
 	if count != 1 {
 		t.Error("New cache entry was incorrectly pruned")
 	}
+
+	if err := d.QueryRow("SELECT count(*) FROM seen_entities WHERE qid = ?", "Q1").Scan(&count); err != nil {
+		t.Errorf("Failed to query seen count: %v", err)
+	}
+	if count != 0 {
+		t.Error("Old seen entity was not pruned")
+	}
+	if err := d.QueryRow("SELECT count(*) FROM seen_entities WHERE qid = ?", "Q2").Scan(&count); err != nil {
+		t.Errorf("Failed to query seen count: %v", err)
+	}
+	if count != 1 {
+		t.Error("Recent seen entity was incorrectly pruned")
+	}
 }
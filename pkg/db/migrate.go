@@ -0,0 +1,240 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// migration is one step of the schema history. Steps run in version order,
+// each in its own transaction together with the schema_version record, so a
+// failed step leaves the database at the previous version.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// migrations is the ordered schema history. Append new steps with the next
+// version; never edit or renumber a released one.
+var migrations = []migration{
+	{1, "base schema", createBaseSchema},
+	{2, "poi.is_msfs_poi", addColumn("poi", "is_msfs_poi", "BOOLEAN DEFAULT 0")},
+	{3, "poi.thumbnail_url", addColumn("poi", "thumbnail_url", "TEXT")},
+	{4, "regional_categories.labels", addColumn("regional_categories", "labels", "TEXT")},
+	{5, "poi.description", addColumn("poi", "description", "TEXT")},
+	{6, "region_visits", createTable(`CREATE TABLE IF NOT EXISTS region_visits (
+		region TEXT PRIMARY KEY,
+		last_visit DATETIME
+	);`)},
+	{7, "poi.height", addColumn("poi", "height", "REAL")},
+	{8, "poi.length", addColumn("poi", "length", "REAL")},
+	{9, "poi.area", addColumn("poi", "area", "REAL")},
+}
+
+// Databases created before versioning already hold these tables, so the base
+// schema must stay idempotent.
+var baseSchema = []string{
+	`CREATE TABLE IF NOT EXISTS poi (
+		wikidata_id TEXT PRIMARY KEY,
+		source TEXT,
+		category TEXT,
+		specific_category TEXT,
+		lat REAL,
+		lon REAL,
+		sitelinks INTEGER,
+		name_en TEXT,
+		name_local TEXT,
+		name_user TEXT,
+		wp_url TEXT,
+		wp_article_length INTEGER,
+		trigger_qid TEXT,
+		last_played DATETIME,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		is_msfs_poi BOOLEAN DEFAULT 0,
+		thumbnail_url TEXT
+	);`,
+	`CREATE TABLE IF NOT EXISTS msfs_poi (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		type TEXT,
+		name TEXT,
+		ident TEXT,
+		lat REAL,
+		lon REAL,
+		elevation REAL
+	);`,
+	`CREATE TABLE IF NOT EXISTS wikidata_hierarchy (
+		qid TEXT PRIMARY KEY,
+		name TEXT,
+		parents TEXT,
+		category TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME
+	);`,
+	`CREATE TABLE IF NOT EXISTS wikipedia_articles (
+		uuid TEXT PRIMARY KEY,
+		title TEXT,
+		url TEXT,
+		names TEXT,
+		text TEXT,
+		lengths TEXT,
+		thumbnail_url TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+	`CREATE TABLE IF NOT EXISTS persistent_state (
+		key TEXT PRIMARY KEY,
+		value TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+	`CREATE TABLE IF NOT EXISTS cache (
+		key TEXT PRIMARY KEY,
+		value BLOB,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+	`CREATE TABLE IF NOT EXISTS cache_geodata (
+		key TEXT PRIMARY KEY,
+		data BLOB,
+		radius_m INTEGER,
+		lat REAL,
+		lon REAL,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+	`CREATE INDEX IF NOT EXISTS idx_cache_geodata_geo ON cache_geodata(lat, lon);`,
+	`CREATE TABLE IF NOT EXISTS seen_entities (
+		qid TEXT PRIMARY KEY,
+		instances TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	);`,
+	`CREATE TABLE IF NOT EXISTS regional_categories (
+		lat_grid INTEGER,
+		lon_grid INTEGER,
+		categories TEXT,
+		labels TEXT,
+		created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+		updated_at DATETIME,
+		PRIMARY KEY (lat_grid, lon_grid)
+	);`,
+	`CREATE TABLE IF NOT EXISTS narration_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		title TEXT,
+		type TEXT,
+		created_at DATETIME,
+		duration_ms INTEGER,
+		word_count INTEGER
+	);`,
+	`CREATE TABLE IF NOT EXISTS telemetry_log (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		recorded_at DATETIME,
+		lat REAL,
+		lon REAL,
+		alt_msl REAL,
+		heading REAL,
+		ground_speed REAL,
+		stage TEXT
+	);`,
+}
+
+func createBaseSchema(tx *sql.Tx) error {
+	for _, q := range baseSchema {
+		if _, err := tx.Exec(q); err != nil {
+			return fmt.Errorf("exec error: %w query: %s", err, q)
+		}
+	}
+	return nil
+}
+
+// createTable runs a single CREATE TABLE statement.
+func createTable(q string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		if _, err := tx.Exec(q); err != nil {
+			return fmt.Errorf("exec error: %w query: %s", err, q)
+		}
+		return nil
+	}
+}
+
+// addColumn adds a column unless it exists, which it does in databases whose
+// base schema already included it.
+func addColumn(table, column, def string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		var n int
+		if err := tx.QueryRow("SELECT count(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&n); err != nil {
+			return fmt.Errorf("failed to inspect %s: %w", table, err)
+		}
+		if n > 0 {
+			return nil
+		}
+		if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, def)); err != nil {
+			return fmt.Errorf("failed to add %s.%s: %w", table, column, err)
+		}
+		return nil
+	}
+}
+
+func (d *DB) migrate() error {
+	return runMigrations(d.DB, migrations)
+}
+
+// SchemaVersion returns the highest applied migration version.
+func (d *DB) SchemaVersion() (int, error) {
+	return schemaVersion(d.DB)
+}
+
+func runMigrations(db *sql.DB, steps []migration) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name TEXT,
+		applied_at DATETIME
+	);`); err != nil {
+		return fmt.Errorf("failed to create schema_version: %w", err)
+	}
+
+	current, err := schemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if latest := steps[len(steps)-1].version; current > latest {
+		// A newer build migrated this database; its additions are unknown
+		// to us but were all additive, so carry on.
+		slog.Warn("DB: Schema is newer than this build", "version", current, "known", latest)
+		return nil
+	}
+
+	for _, m := range steps {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(db, m); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+		slog.Info("DB: Applied migration", "version", m.version, "name", m.name)
+	}
+	return nil
+}
+
+func applyMigration(db *sql.DB, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	// A no-op after Commit
+	defer func() { _ = tx.Rollback() }()
+
+	if err := m.up(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version, name, applied_at) VALUES (?, ?, ?)",
+		m.version, m.name, time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to record version: %w", err)
+	}
+	return tx.Commit()
+}
+
+func schemaVersion(db *sql.DB) (int, error) {
+	var v sql.NullInt64
+	if err := db.QueryRow("SELECT MAX(version) FROM schema_version").Scan(&v); err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return int(v.Int64), nil
+}
@@ -0,0 +1,115 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func openRaw(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func hasColumn(t *testing.T, db *sql.DB, table, column string) bool {
+	t.Helper()
+	var n int
+	if err := db.QueryRow("SELECT count(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&n); err != nil {
+		t.Fatalf("pragma: %v", err)
+	}
+	return n > 0
+}
+
+func TestRunMigrations(t *testing.T) {
+	latest := migrations[len(migrations)-1].version
+
+	tests := []struct {
+		name  string
+		setup func(t *testing.T, db *sql.DB)
+	}{
+		{
+			name:  "Empty database",
+			setup: func(t *testing.T, db *sql.DB) {},
+		},
+		{
+			name: "Partially migrated",
+			setup: func(t *testing.T, db *sql.DB) {
+				if err := runMigrations(db, migrations[:2]); err != nil {
+					t.Fatalf("partial migration: %v", err)
+				}
+			},
+		},
+		{
+			name: "Created before versioning",
+			setup: func(t *testing.T, db *sql.DB) {
+				// An old database: tables exist, one later column is missing
+				if _, err := db.Exec("CREATE TABLE regional_categories (lat_grid INTEGER, lon_grid INTEGER, categories TEXT, PRIMARY KEY (lat_grid, lon_grid))"); err != nil {
+					t.Fatalf("setup: %v", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openRaw(t)
+			tt.setup(t, db)
+
+			if err := runMigrations(db, migrations); err != nil {
+				t.Fatalf("runMigrations() failed: %v", err)
+			}
+			// A second run at startup must be a no-op
+			if err := runMigrations(db, migrations); err != nil {
+				t.Fatalf("second runMigrations() failed: %v", err)
+			}
+
+			if v, _ := schemaVersion(db); v != latest {
+				t.Errorf("schema version = %d, want %d", v, latest)
+			}
+			var rows int
+			if err := db.QueryRow("SELECT count(*) FROM schema_version").Scan(&rows); err != nil || rows != len(migrations) {
+				t.Errorf("schema_version rows = %d (%v), want %d", rows, err, len(migrations))
+			}
+			for _, c := range [][2]string{{"poi", "is_msfs_poi"}, {"poi", "thumbnail_url"}, {"poi", "description"}, {"regional_categories", "labels"}} {
+				if !hasColumn(t, db, c[0], c[1]) {
+					t.Errorf("missing column %s.%s", c[0], c[1])
+				}
+			}
+		})
+	}
+}
+
+func TestRunMigrations_RollbackOnFailure(t *testing.T) {
+	db := openRaw(t)
+	steps := append(append([]migration{}, migrations...),
+		migration{
+			version: 100,
+			name:    "broken",
+			up: func(tx *sql.Tx) error {
+				if _, err := tx.Exec("CREATE TABLE half_done (id INTEGER)"); err != nil {
+					return err
+				}
+				return errors.New("boom")
+			},
+		})
+
+	if err := runMigrations(db, steps); err == nil {
+		t.Fatal("expected the broken migration to fail")
+	}
+
+	if v, _ := schemaVersion(db); v != migrations[len(migrations)-1].version {
+		t.Errorf("schema version = %d, want the last good version", v)
+	}
+	var n int
+	_ = db.QueryRow("SELECT count(*) FROM sqlite_master WHERE name = 'half_done'").Scan(&n)
+	if n != 0 {
+		t.Error("expected the failed migration's changes to be rolled back")
+	}
+}
@@ -22,6 +22,9 @@ type Job struct {
 	From string
 	To   string
 
+	// For Essays requested on a specific topic
+	EssayTopic string
+
 	// For Announcements (Phases 2 & 3)
 	Announcement announcement.Item
 
@@ -17,8 +17,11 @@ import (
 	"phileasgo/pkg/logging"
 )
 
+// CountriesGeoJSON is the embedded Natural Earth country boundaries, also used
+// as the land mask for open water detection.
+//
 //go:embed countries.geojson
-var countriesGeoJSON []byte
+var CountriesGeoJSON []byte
 
 // Maritime zone distance thresholds in meters
 const (
@@ -63,7 +66,7 @@ type CountryService struct {
 // NewCountryServiceEmbedded creates a CountryService using embedded GeoJSON data.
 // This is the preferred constructor as it doesn't require external files.
 func NewCountryServiceEmbedded() (*CountryService, error) {
-	return newCountryServiceFromData(countriesGeoJSON)
+	return newCountryServiceFromData(CountriesGeoJSON)
 }
 
 // NewCountryService loads country boundaries from a GeoJSON file.
@@ -3,7 +3,10 @@ package geo
 import (
 	"bufio"
 	"encoding/binary"
+	"errors"
 	"fmt"
+	"io/fs"
+	"log/slog"
 	"math"
 	"math/rand"
 	"os"
@@ -156,9 +159,26 @@ type City struct {
 type Service struct {
 	grid       map[int][]City
 	countrySvc *CountryService // Optional: for accurate country boundary detection
+	degraded   bool            // No city data: countries only, cities are "Unknown"
 }
 
-// NewService loads cities and builds the spatial index.
+// UnknownCity is the city name reported by a degraded service.
+const UnknownCity = "Unknown"
+
+// NewDegradedService creates a service without city data. Countries are
+// still detected once a CountryService is set.
+func NewDegradedService() *Service {
+	return &Service{grid: make(map[int][]City), degraded: true}
+}
+
+// Degraded reports whether the service runs without city data.
+func (s *Service) Degraded() bool {
+	return s.degraded
+}
+
+// NewService loads cities and builds the spatial index. Missing GeoNames
+// files are not an error: the service runs degraded until they are
+// downloaded.
 func NewService(citiesPath, admin1Path string) (*Service, error) {
 	// 1. Load Admin1 Codes (Code -> Name)
 	adminMap := make(map[string]string)
@@ -179,6 +199,10 @@ func NewService(citiesPath, admin1Path string) (*Service, error) {
 	}
 	// 2. Load Cities
 	file, err := os.Open(citiesPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		slog.Warn("Cities data not found, city names are unavailable", "path", citiesPath)
+		return NewDegradedService(), nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to open cities file: %w", err)
 	}
@@ -251,7 +275,11 @@ func (s *Service) GetLocation(lat, lon float64) model.LocationInfo {
 	bestCity, bestLegalCity, minDistSq := s.searchCities(lat, lon, countryResult.CountryCode)
 
 	// 3. Build result
-	return s.assembleLocationInfo(lat, lon, countryResult, bestCity, bestLegalCity, minDistSq)
+	loc := s.assembleLocationInfo(lat, lon, countryResult, bestCity, bestLegalCity, minDistSq)
+	if s.degraded {
+		loc.CityName = UnknownCity
+	}
+	return loc
 }
 
 func (s *Service) searchCities(lat, lon float64, legalCountryCode string) (bestCity, bestLegalCity *City, minDistSq float64) {
@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"math"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/paulmach/orb"
@@ -292,6 +293,35 @@ func TestNewService(t *testing.T) {
 	}
 }
 
+func TestNewService_MissingFiles(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewService(filepath.Join(dir, "cities1000.txt"), filepath.Join(dir, "admin1CodesASCII.txt"))
+	if err != nil {
+		t.Fatalf("expected a degraded service, got error: %v", err)
+	}
+	if !s.Degraded() {
+		t.Error("expected the service to report degraded mode")
+	}
+
+	cs, err := NewCountryServiceEmbedded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.SetCountryService(cs)
+
+	// Paris: the country still comes from the embedded boundaries
+	loc := s.GetLocation(48.8566, 2.3522)
+	if loc.CityName != UnknownCity {
+		t.Errorf("CityName = %q, want %q", loc.CityName, UnknownCity)
+	}
+	if loc.CountryCode != "FR" {
+		t.Errorf("CountryCode = %q, want FR", loc.CountryCode)
+	}
+	if got := s.GetCitiesInBbox(48, 2, 49, 3); len(got) != 0 {
+		t.Errorf("expected no cities, got %d", len(got))
+	}
+}
+
 func TestGeoHelpers(t *testing.T) {
 	// Test NormalizeAngle
 	tests := []struct {
@@ -0,0 +1,40 @@
+package geo
+
+import (
+	"math"
+	"time"
+)
+
+// CivilTwilightDeg is the sun elevation below which it is dark enough for
+// city lights and stars to dominate the view.
+const CivilTwilightDeg = -6.0
+
+// SunElevation returns the sun's elevation above the horizon in degrees at
+// the given position and time, using the NOAA low-precision formulas. The
+// error of a fraction of a degree is irrelevant for telling day from night.
+func SunElevation(lat, lon float64, t time.Time) float64 {
+	t = t.UTC()
+	// Fractional year in radians
+	dayOfYear := float64(t.YearDay() - 1)
+	hours := float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+	gamma := 2 * math.Pi / 365 * (dayOfYear + (hours-12)/24)
+
+	eqTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma)) // Minutes
+	decl := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma) // Radians
+
+	trueSolarMin := hours*60 + eqTime + 4*lon
+	hourAngle := (trueSolarMin/4 - 180) * math.Pi / 180
+
+	latRad := lat * math.Pi / 180
+	cosZenith := math.Sin(latRad)*math.Sin(decl) + math.Cos(latRad)*math.Cos(decl)*math.Cos(hourAngle)
+	cosZenith = math.Max(-1, math.Min(1, cosZenith))
+	return 90 - math.Acos(cosZenith)*180/math.Pi
+}
+
+// IsNight reports whether the sun is below civil twilight.
+func IsNight(lat, lon float64, t time.Time) bool {
+	return SunElevation(lat, lon, t) < CivilTwilightDeg
+}
@@ -0,0 +1,50 @@
+package geo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSunElevation(t *testing.T) {
+	tests := []struct {
+		name      string
+		lat, lon  float64
+		when      time.Time
+		wantNight bool
+		minElev   float64
+		maxElev   float64
+	}{
+		{
+			name: "Paris summer noon", lat: 48.85, lon: 2.35,
+			when:    time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC),
+			minElev: 60, maxElev: 66,
+		},
+		{
+			name: "Paris winter midnight", lat: 48.85, lon: 2.35,
+			when:      time.Date(2024, 12, 21, 23, 0, 0, 0, time.UTC),
+			wantNight: true, minElev: -70, maxElev: -55,
+		},
+		{
+			name: "Tokyo at 12:00 UTC is night", lat: 35.68, lon: 139.69,
+			when:      time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC),
+			wantNight: true, minElev: -90, maxElev: -6,
+		},
+		{
+			name: "Equator at sunset is twilight, not night", lat: 0, lon: 0,
+			when:    time.Date(2024, 3, 20, 18, 10, 0, 0, time.UTC),
+			minElev: -5, maxElev: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elev := SunElevation(tt.lat, tt.lon, tt.when)
+			if elev < tt.minElev || elev > tt.maxElev {
+				t.Errorf("SunElevation() = %.1f, want between %.0f and %.0f", elev, tt.minElev, tt.maxElev)
+			}
+			if got := IsNight(tt.lat, tt.lon, tt.when); got != tt.wantNight {
+				t.Errorf("IsNight() = %v, want %v", got, tt.wantNight)
+			}
+		})
+	}
+}
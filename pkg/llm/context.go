@@ -2,10 +2,46 @@ package llm
 
 import (
 	"context"
+	"sync"
 )
 
 type multiPromptKey struct{}
 
+type servedByKey struct{}
+
+// servedBy holds the name of the provider that answered a request. It is
+// shared by pointer so a composite provider deep in the call can report back.
+type servedBy struct {
+	mu   sync.Mutex
+	name string
+}
+
+// WithServedBy returns a context in which composite providers record which
+// provider answered; read it back with ServedBy once the call returns.
+func WithServedBy(ctx context.Context) context.Context {
+	return context.WithValue(ctx, servedByKey{}, &servedBy{})
+}
+
+// RecordServedBy notes the provider that answered, if the context asks for it.
+func RecordServedBy(ctx context.Context, name string) {
+	if sb, ok := ctx.Value(servedByKey{}).(*servedBy); ok {
+		sb.mu.Lock()
+		sb.name = name
+		sb.mu.Unlock()
+	}
+}
+
+// ServedBy returns the provider recorded for the context, or "" if none was.
+func ServedBy(ctx context.Context) string {
+	sb, ok := ctx.Value(servedByKey{}).(*servedBy)
+	if !ok {
+		return ""
+	}
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	return sb.name
+}
+
 // MultiPrompt is a map of provider names to their specific rendered prompts.
 type MultiPrompt map[string]string
 
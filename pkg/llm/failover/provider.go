@@ -27,6 +27,10 @@ type Provider struct {
 	logPath          string
 	enabled          bool
 	tracker          *tracker.Tracker
+	health           map[string]*healthState // key: providerName
+	cooldown         time.Duration
+	lastServed       string
+	now              func() time.Time
 	mu               sync.RWMutex
 }
 
@@ -36,6 +40,33 @@ type backoffState struct {
 	targetSkips        int
 }
 
+// degradeAfterFailures is how many consecutive retryable failures mark a
+// provider as degraded. A single hiccup is not worth reordering the chain.
+const degradeAfterFailures = 2
+
+type healthState struct {
+	failures      int
+	degradedUntil time.Time
+	served        int64
+}
+
+// ProviderHealth is the state of one provider in the chain.
+type ProviderHealth struct {
+	Name          string     `json:"name"`
+	Disabled      bool       `json:"disabled"` // Fatal error, out for the session
+	Degraded      bool       `json:"degraded"` // Tried after healthy providers
+	DegradedUntil *time.Time `json:"degraded_until,omitempty"`
+	Failures      int        `json:"failures"` // Consecutive retryable failures
+	Served        int64      `json:"served"`   // Requests answered this session
+}
+
+// Health reports the chain in its configured order and the provider that
+// answered the most recent request.
+type Health struct {
+	LastServed string           `json:"last_served,omitempty"`
+	Providers  []ProviderHealth `json:"providers"`
+}
+
 // New creates a new Provider with failover and unified logging.
 // providers: ordered list of all initialized providers (global fallback chain).
 // names: names corresponding to the provider list.
@@ -63,9 +94,42 @@ func New(providers []llm.Provider, names []string, timeouts []time.Duration, pro
 		logPath:          logPath,
 		enabled:          enabled,
 		tracker:          t,
+		health:           make(map[string]*healthState),
+		now:              time.Now,
 	}, nil
 }
 
+// SetDegradedCooldown sets how long a provider that keeps failing is moved
+// behind the healthy ones. Zero, the default, keeps the configured order.
+func (f *Provider) SetDegradedCooldown(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cooldown = d
+}
+
+// Health returns a snapshot of the provider chain.
+func (f *Provider) Health() Health {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	now := f.now()
+	h := Health{LastServed: f.lastServed, Providers: make([]ProviderHealth, 0, len(f.names))}
+	for i, name := range f.names {
+		ph := ProviderHealth{Name: name, Disabled: f.disabled[i]}
+		if hs, ok := f.health[name]; ok {
+			ph.Failures = hs.failures
+			ph.Served = hs.served
+			if now.Before(hs.degradedUntil) {
+				until := hs.degradedUntil
+				ph.Degraded = true
+				ph.DegradedUntil = &until
+			}
+		}
+		h.Providers = append(h.Providers, ph)
+	}
+	return h
+}
+
 // GenerateText implements llm.Provider.
 func (f *Provider) GenerateText(ctx context.Context, profile, prompt string) (string, error) {
 	res, err := f.execute(ctx, profile, prompt, func(pCtx context.Context, p llm.Provider) (any, error) {
@@ -126,6 +190,45 @@ func (f *Provider) GenerateImageJSON(ctx context.Context, profile, prompt, image
 	return err
 }
 
+// GenerateTextStream implements llm.StreamingProvider.
+// Only the preferred candidate is considered: silently streaming from a lower-ranked
+// provider would change which model writes the script. If it cannot stream, or the
+// stream fails to start, the caller is expected to use the blocking path, which
+// carries the full failover logic.
+func (f *Provider) GenerateTextStream(ctx context.Context, profile, prompt string) (*llm.Stream, error) {
+	candidates := f.getCandidates(ctx, profile)
+	if len(candidates) == 0 {
+		return nil, llm.ErrStreamingNotSupported
+	}
+	c := candidates[0]
+
+	// A provider in backoff is left to the blocking path, which owns the skip accounting.
+	backoffKey := c.name + ":" + profile
+	if f.providerBackoffs[c.index] {
+		backoffKey = c.name
+	}
+	f.mu.RLock()
+	bs, inBackoff := f.backoffs[backoffKey]
+	inBackoff = inBackoff && bs.skippedRequests < bs.targetSkips
+	f.mu.RUnlock()
+	if inBackoff {
+		return nil, llm.ErrStreamingNotSupported
+	}
+
+	sp, ok := c.p.(llm.StreamingProvider)
+	if !ok {
+		return nil, llm.ErrStreamingNotSupported
+	}
+
+	ch, err := sp.GenerateTextStream(ctx, profile, prompt)
+	if err != nil {
+		f.logRequest(c.name, profile, prompt, "", err)
+		return nil, err
+	}
+	f.markServed(ctx, c.name)
+	return ch, nil
+}
+
 // HasProfile implements llm.Provider.
 func (f *Provider) HasProfile(profile string) bool {
 	f.mu.RLock()
@@ -203,22 +306,23 @@ func (f *Provider) execute(ctx context.Context, profile, prompt string, fn func(
 			callCtx = context.WithValue(callCtx, request.CtxMaxAttempts, 1)
 		}
 
+		start := time.Now()
 		res, err := fn(callCtx, c.p)
 		cancel()
+		f.trackLatency(c.name, time.Since(start))
 
 		if err == nil {
 			// SUCCESS - Reset Backoff
 			f.mu.Lock()
 			delete(f.backoffs, backoffKey)
 			f.mu.Unlock()
+			f.markServed(ctx, c.name)
 
-			f.trackStats(c.name, true)
 			f.logRequest(c.name, profile, prompt, fmt.Sprintf("%v", res), nil)
 			return res, nil
 		}
 
 		// Handle error
-		f.trackStats(c.name, false)
 		f.logRequest(c.name, profile, prompt, "", err)
 
 		isFatal := isUnrecoverable(err)
@@ -238,6 +342,7 @@ func (f *Provider) execute(ctx context.Context, profile, prompt string, fn func(
 
 		// Retryable error: apply backoff increment
 		f.incrementBackoff(backoffKey)
+		f.markFailed(c.name)
 
 		if !isLast {
 			slog.Info("LLM Provider failed (retryable), falling back",
@@ -257,6 +362,7 @@ func (f *Provider) execute(ctx context.Context, profile, prompt string, fn func(
 			f.mu.Lock()
 			delete(f.backoffs, backoffKey)
 			f.mu.Unlock()
+			f.markServed(ctx, c.name)
 			f.logRequest(c.name, profile, prompt, fmt.Sprintf("%v", res), nil)
 		}
 		return res, err
@@ -309,7 +415,69 @@ func (f *Provider) getCandidates(ctx context.Context, profile string) []candidat
 
 		candidates = append(candidates, candidate{i, p, names[i]})
 	}
-	return candidates
+	return f.healthyFirst(candidates)
+}
+
+// healthyFirst moves degraded providers behind the healthy ones, keeping the
+// configured order within each group. Degraded providers stay in the chain so
+// they still serve when everything else fails.
+func (f *Provider) healthyFirst(candidates []candidate) []candidate {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	now := f.now()
+	ordered := make([]candidate, 0, len(candidates))
+	var degraded []candidate
+	for _, c := range candidates {
+		if hs, ok := f.health[c.name]; ok && now.Before(hs.degradedUntil) {
+			degraded = append(degraded, c)
+			continue
+		}
+		ordered = append(ordered, c)
+	}
+	return append(ordered, degraded...)
+}
+
+// markServed clears the provider's failure streak and records it as the one
+// that answered.
+func (f *Provider) markServed(ctx context.Context, name string) {
+	f.mu.Lock()
+	hs := f.healthLocked(name)
+	hs.failures = 0
+	hs.degradedUntil = time.Time{}
+	hs.served++
+	f.lastServed = name
+	f.mu.Unlock()
+
+	llm.RecordServedBy(ctx, name)
+}
+
+// markFailed counts a retryable failure and degrades the provider once the
+// streak is long enough.
+func (f *Provider) markFailed(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	hs := f.healthLocked(name)
+	hs.failures++
+	if f.cooldown <= 0 || hs.failures < degradeAfterFailures {
+		return
+	}
+	hs.degradedUntil = f.now().Add(f.cooldown)
+	slog.Warn("LLM Provider degraded, trying it last until the cooldown ends",
+		"provider", name,
+		"failures", hs.failures,
+		"cooldown", f.cooldown,
+	)
+}
+
+func (f *Provider) healthLocked(name string) *healthState {
+	hs, ok := f.health[name]
+	if !ok {
+		hs = &healthState{}
+		f.health[name] = hs
+	}
+	return hs
 }
 
 func (f *Provider) shouldBackoff(backoffKey, providerName, profile string) bool {
@@ -351,14 +519,14 @@ func (f *Provider) retryLast(ctx context.Context, p llm.Provider, name string, t
 	delay := 1 * time.Second
 	for attempt := 1; attempt <= 3; attempt++ {
 		callCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
 		res, err := fn(callCtx, p)
 		cancel()
+		f.trackLatency(name, time.Since(start))
 		if err == nil {
-			f.trackStats(name, true)
 			return res, nil
 		}
 
-		f.trackStats(name, false)
 		lastErr = err
 		if isUnrecoverable(err) {
 			return nil, fmt.Errorf("last provider failed with fatal error: %w", err)
@@ -376,13 +544,14 @@ func (f *Provider) retryLast(ctx context.Context, p llm.Provider, name string, t
 	return nil, fmt.Errorf("last provider exhausted after 3 retries: %w", lastErr)
 }
 
-func (f *Provider) trackStats(providerName string, success bool) {
+// trackLatency records how long a provider call took, failures included.
+// Request counts are tracked by the individual providers or the request
+// client; tracking them here as well would double count.
+func (f *Provider) trackLatency(providerName string, d time.Duration) {
 	if f.tracker == nil {
 		return
 	}
-	// Tracking is now handled by individual providers or the request client.
-	// We no longer track global "llm" stats to prevent double counting and clutter.
-
+	f.tracker.TrackLatency(providerName, d)
 }
 
 func (f *Provider) logRequest(providerName, profile, prompt, response string, err error) {
@@ -465,3 +465,54 @@ func TestFailover_OtherMethods(t *testing.T) {
 		t.Fatalf("unexpected error from ValidateModels: %v", err)
 	}
 }
+
+func TestFailover_ServedByAndDegradedCooldown(t *testing.T) {
+	p1 := &mockProvider{
+		responses: []string{"", "", "p1_back"},
+		errors:    []error{fmt.Errorf("503 unavailable"), fmt.Errorf("timeout"), nil},
+	}
+	p2 := &mockProvider{
+		responses: []string{"p2_1", "p2_2", "p2_3", "p2_4", "p2_5"},
+		errors:    []error{nil, nil, nil, nil, nil},
+	}
+
+	f, _ := New([]llm.Provider{p1, p2}, []string{"p1", "p2"}, []time.Duration{time.Second, time.Second}, []bool{false, false}, "", true, nil)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	f.now = func() time.Time { return now }
+	f.SetDegradedCooldown(10 * time.Minute)
+
+	// The primary errors and the secondary serves the response
+	ctx := llm.WithServedBy(context.Background())
+	res, err := f.GenerateText(ctx, "p", "q")
+	if err != nil || res != "p2_1" {
+		t.Fatalf("expected p2_1, got %q (err %v)", res, err)
+	}
+	if got := llm.ServedBy(ctx); got != "p2" {
+		t.Errorf("ServedBy = %q, want p2", got)
+	}
+
+	// Outlast the skip backoff so the second failure comes from a real call
+	for p1.callCount < 2 {
+		if _, err := f.GenerateText(context.Background(), "p", "q"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	h := f.Health()
+	if !h.Providers[0].Degraded || h.Providers[0].Failures != 2 {
+		t.Fatalf("expected p1 degraded after 2 failures, got %+v", h.Providers[0])
+	}
+	if h.LastServed != "p2" || h.Providers[1].Served == 0 {
+		t.Errorf("expected p2 to have served, got %+v", h)
+	}
+
+	// While degraded, p1 is tried after p2 rather than first
+	if cands := f.getCandidates(context.Background(), "p"); cands[0].name != "p2" {
+		t.Errorf("expected p2 first while p1 is degraded, got %s", cands[0].name)
+	}
+
+	// After the cooldown p1 leads again
+	now = now.Add(11 * time.Minute)
+	if cands := f.getCandidates(context.Background(), "p"); cands[0].name != "p1" {
+		t.Errorf("expected p1 first after the cooldown, got %s", cands[0].name)
+	}
+}
@@ -25,6 +25,7 @@ type Client struct {
 	genaiClient *genai.Client
 	apiKey      string
 	profiles    map[string]string // Map intent -> modelName
+	safety      []*genai.SafetySetting
 	rc          *request.Client
 	tracker     *tracker.Tracker
 
@@ -43,6 +44,7 @@ func NewClient(cfg *config.ProviderConfig, rc *request.Client, t *tracker.Tracke
 		tracker:           t,
 		apiKey:            cfg.Key,
 		profiles:          cfg.Profiles,
+		safety:            buildSafetySettings(cfg.SafetySettings),
 		temperatureBase:   1.0, // Defaults
 		temperatureJitter: 0.3,
 		label:             "gemini", // Default label
@@ -172,6 +174,7 @@ func (c *Client) GenerateText(ctx context.Context, profile, prompt string) (stri
 
 	if c.tracker != nil {
 		c.tracker.TrackAPISuccess(c.getLabel())
+		c.trackUsage(resp.UsageMetadata)
 	}
 
 	return text, nil
@@ -228,6 +231,7 @@ func (c *Client) GenerateJSON(ctx context.Context, profile, prompt string, targe
 
 	if c.tracker != nil {
 		c.tracker.TrackAPISuccess(c.getLabel())
+		c.trackUsage(resp.UsageMetadata)
 	}
 
 	return nil
@@ -292,6 +296,7 @@ func (c *Client) GenerateImageText(ctx context.Context, profile, prompt, imagePa
 
 	if c.tracker != nil {
 		c.tracker.TrackAPISuccess(c.getLabel())
+		c.trackUsage(resp.UsageMetadata)
 	}
 
 	return text, nil
@@ -360,6 +365,7 @@ func (c *Client) GenerateImageJSON(ctx context.Context, profile, prompt, imagePa
 
 	if c.tracker != nil {
 		c.tracker.TrackAPISuccess(c.getLabel())
+		c.trackUsage(resp.UsageMetadata)
 	}
 
 	return nil
@@ -390,7 +396,8 @@ func (c *Client) resolveModel(profile string) (string, *genai.GenerateContentCon
 	}
 
 	cfg := &genai.GenerateContentConfig{
-		Temperature: c.getTemperature(),
+		Temperature:    c.getTemperature(),
+		SafetySettings: c.safety,
 	}
 	return model, cfg, nil
 }
@@ -2,10 +2,42 @@ package gemini
 
 import (
 	"log/slog"
+	"sort"
+	"strings"
 
 	"google.golang.org/genai"
 )
 
+// buildSafetySettings converts the configured category -> threshold map into
+// request settings. Unset categories keep the API defaults.
+func buildSafetySettings(cfg map[string]string) []*genai.SafetySetting {
+	if len(cfg) == 0 {
+		return nil
+	}
+	categories := make([]string, 0, len(cfg))
+	for cat := range cfg {
+		categories = append(categories, cat)
+	}
+	sort.Strings(categories) // Stable request payloads
+
+	settings := make([]*genai.SafetySetting, 0, len(cfg))
+	for _, cat := range categories {
+		settings = append(settings, &genai.SafetySetting{
+			Category:  genai.HarmCategory(strings.ToUpper(cat)),
+			Threshold: genai.HarmBlockThreshold(strings.ToUpper(cfg[cat])),
+		})
+	}
+	return settings
+}
+
+// trackUsage records the token counts reported by the API.
+func (c *Client) trackUsage(meta *genai.GenerateContentResponseUsageMetadata) {
+	if c.tracker == nil || meta == nil {
+		return
+	}
+	c.tracker.TrackTokens(c.getLabel(), int64(meta.PromptTokenCount), int64(meta.CandidatesTokenCount+meta.ThoughtsTokenCount))
+}
+
 // logGoogleSearchUsage logs the usage of the Google Search tool.
 // It is extracted for unit testing and nil-safety.
 func logGoogleSearchUsage(name string, meta *genai.GroundingMetadata) {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"google.golang.org/genai"
+
+	"phileasgo/pkg/tracker"
 )
 
 func TestLogGoogleSearchUsage(t *testing.T) {
@@ -63,3 +65,40 @@ func TestLogGoogleSearchUsage(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildSafetySettings(t *testing.T) {
+	if got := buildSafetySettings(nil); got != nil {
+		t.Errorf("expected nil settings for empty config, got %v", got)
+	}
+
+	got := buildSafetySettings(map[string]string{
+		"harm_category_hate_speech":       "block_none",
+		"HARM_CATEGORY_DANGEROUS_CONTENT": "BLOCK_ONLY_HIGH",
+	})
+	want := []*genai.SafetySetting{
+		{Category: genai.HarmCategoryDangerousContent, Threshold: genai.HarmBlockThresholdBlockOnlyHigh},
+		{Category: genai.HarmCategoryHateSpeech, Threshold: genai.HarmBlockThresholdBlockNone},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d settings, got %d", len(want), len(got))
+	}
+	for i := range want {
+		if *got[i] != *want[i] {
+			t.Errorf("setting %d: got %+v, want %+v", i, *got[i], *want[i])
+		}
+	}
+}
+
+func TestTrackUsage(t *testing.T) {
+	tr := tracker.New()
+	c := &Client{tracker: tr, label: "gemini-test"}
+
+	c.trackUsage(nil) // Must not panic
+	c.trackUsage(&genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 120, CandidatesTokenCount: 30, ThoughtsTokenCount: 10})
+	c.trackUsage(&genai.GenerateContentResponseUsageMetadata{PromptTokenCount: 80, CandidatesTokenCount: 20})
+
+	stats := tr.Snapshot()["gemini-test"]
+	if stats.PromptTokens != 200 || stats.CompletionTokens != 60 {
+		t.Errorf("expected 200 prompt / 60 completion tokens, got %d / %d", stats.PromptTokens, stats.CompletionTokens)
+	}
+}
@@ -1,10 +1,12 @@
 package openai
 
 import (
+	"bufio"
 	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
@@ -14,6 +16,7 @@ import (
 	"phileasgo/pkg/llm"
 	"phileasgo/pkg/llm/imageutil"
 	"phileasgo/pkg/request"
+	"phileasgo/pkg/tracker"
 )
 
 // Client implements llm.Provider for any OpenAI-compatible API.
@@ -23,6 +26,7 @@ type Client struct {
 	baseURL  string
 	profiles map[string]string
 	label    string
+	tracker  *tracker.Tracker
 
 	// Temperature settings
 	temperatureBase   float32
@@ -37,6 +41,19 @@ type Request struct {
 	Messages       []Message       `json:"messages"`
 	ResponseFormat *ResponseFormat `json:"response_format,omitempty"`
 	Temperature    float32         `json:"temperature,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+	StreamOptions  *StreamOptions  `json:"stream_options,omitempty"`
+}
+
+// StreamOptions asks for a final usage chunk on streamed responses.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// Usage is the token accounting returned with a completion.
+type Usage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
 }
 
 type Message struct {
@@ -65,6 +82,7 @@ type Response struct {
 			Content string `json:"content"`
 		} `json:"message"`
 	} `json:"choices"`
+	Usage *Usage `json:"usage,omitempty"`
 	Error *struct {
 		Message string `json:"message"`
 		Type    string `json:"type"`
@@ -100,6 +118,24 @@ func (c *Client) SetLabel(label string) {
 	c.label = label
 }
 
+// SetTracker enables token usage (and so cost) reporting.
+func (c *Client) SetTracker(t *tracker.Tracker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tracker = t
+}
+
+// trackUsage records the token counts reported by the API.
+func (c *Client) trackUsage(u *Usage) {
+	c.mu.RLock()
+	t := c.tracker
+	c.mu.RUnlock()
+	if t == nil || u == nil {
+		return
+	}
+	t.TrackTokens(c.getLabel(), u.PromptTokens, u.CompletionTokens)
+}
+
 // ValidateModels checks if the configured models are available.
 func (c *Client) ValidateModels(ctx context.Context) error {
 	if os.Getenv("TEST_MODE") == "true" {
@@ -302,6 +338,7 @@ func (c *Client) Execute(ctx context.Context, oreq Request) (string, error) {
 	if oresp.Error != nil {
 		return "", fmt.Errorf("openai api error: %s (%s)", oresp.Error.Message, oresp.Error.Type)
 	}
+	c.trackUsage(oresp.Usage)
 
 	if len(oresp.Choices) == 0 {
 		return "", fmt.Errorf("api returned no choices")
@@ -310,6 +347,105 @@ func (c *Client) Execute(ctx context.Context, oreq Request) (string, error) {
 	return oresp.Choices[0].Message.Content, nil
 }
 
+// streamChunk is a single server-sent event of a streamed Chat Completions response.
+type streamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+	Usage *Usage `json:"usage,omitempty"` // Final chunk only, with StreamOptions.IncludeUsage
+}
+
+// GenerateTextStream implements llm.StreamingProvider using server-sent events.
+func (c *Client) GenerateTextStream(ctx context.Context, profile, prompt string) (*llm.Stream, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("api key is missing")
+	}
+
+	var err error
+	prompt, err = llm.ResolvePrompt(ctx, c.Name(), profile, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := c.ResolveModel(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	var temp float32 = 0.7
+	if isReasoner(model) {
+		temp = 1.0
+	}
+
+	body, err := json.Marshal(Request{
+		Model:         model,
+		Messages:      []Message{{Role: "user", Content: prompt}},
+		Temperature:   temp,
+		Stream:        true,
+		StreamOptions: &StreamOptions{IncludeUsage: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	headers := map[string]string{
+		"Authorization": "Bearer " + c.apiKey,
+		"Content-Type":  "application/json",
+		"Accept":        "text/event-stream",
+	}
+	ctx = context.WithValue(ctx, request.CtxProviderLabel, c.getLabel())
+
+	rc, err := c.rc.PostStream(ctx, c.baseURL+"/chat/completions", body, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	out := llm.NewStream(64)
+	go func() {
+		defer rc.Close()
+
+		scanner := bufio.NewScanner(rc)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			data = strings.TrimSpace(data)
+			if data == "[DONE]" {
+				out.Close(nil)
+				return
+			}
+
+			var chunk streamChunk
+			if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+				slog.Debug("OpenAI: Skipping malformed stream chunk", "error", err)
+				continue
+			}
+			c.trackUsage(chunk.Usage)
+			if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+				continue
+			}
+
+			if !out.Send(ctx, chunk.Choices[0].Delta.Content) {
+				out.Close(ctx.Err())
+				return
+			}
+		}
+		// Without [DONE] the response was cut off, even when the body ended cleanly
+		err := scanner.Err()
+		if err == nil {
+			err = io.ErrUnexpectedEOF
+		}
+		slog.Warn("OpenAI: Stream interrupted", "provider", c.getLabel(), "error", err)
+		out.Close(fmt.Errorf("stream interrupted: %w", err))
+	}()
+
+	return out, nil
+}
+
 func (c *Client) GenerateImageJSON(ctx context.Context, profile, prompt, imagePath string, target any) error {
 	model, err := c.ResolveModel(profile)
 	if err != nil {
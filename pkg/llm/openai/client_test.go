@@ -353,3 +353,98 @@ func TestOpenAI_ReasonerConstraints(t *testing.T) {
 		t.Errorf("expected temperature 1.0 for vision reasoner, got %f", capturedTemp)
 	}
 }
+
+func TestOpenAI_GenerateTextStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req Request
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		if !req.Stream || req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Error("expected stream flag and usage option in request")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, part := range []string{"Hello", ", ", "world."} {
+			chunk, _ := json.Marshal(map[string]any{
+				"choices": []map[string]any{{"delta": map[string]string{"content": part}}},
+			})
+			_, _ = w.Write([]byte("data: " + string(chunk) + "\n\n"))
+		}
+		_, _ = w.Write([]byte(`data: {"choices":[],"usage":{"prompt_tokens":12,"completion_tokens":3}}` + "\n\n"))
+		_, _ = w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	tr := tracker.New()
+	rc := request.New(nil, tr, request.ClientConfig{})
+	cfg := config.ProviderConfig{Key: "test_key", Profiles: map[string]string{"narration": "test_model"}}
+	c, err := NewClient(&cfg, server.URL, rc)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	c.SetTracker(tr)
+
+	stream, err := c.GenerateTextStream(context.Background(), "narration", "ping")
+	if err != nil {
+		t.Fatalf("failed to start stream: %v", err)
+	}
+	var sb strings.Builder
+	for d := range stream.Deltas() {
+		sb.WriteString(d)
+	}
+	if got := sb.String(); got != "Hello, world." {
+		t.Errorf("expected %q, got %q", "Hello, world.", got)
+	}
+	if err := stream.Err(); err != nil {
+		t.Errorf("expected a complete stream, got %v", err)
+	}
+	if s := tr.Snapshot()["openai"]; s.PromptTokens != 12 || s.CompletionTokens != 3 {
+		t.Errorf("expected 12/3 tokens tracked, got %d/%d", s.PromptTokens, s.CompletionTokens)
+	}
+}
+
+func TestOpenAI_TracksUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"content":"pong"}}],"usage":{"prompt_tokens":100,"completion_tokens":20}}`))
+	}))
+	defer server.Close()
+
+	tr := tracker.New()
+	rc := request.New(nil, tr, request.ClientConfig{})
+	c, _ := NewClient(&config.ProviderConfig{Key: "key", Profiles: map[string]string{"test": "model"}}, server.URL, rc)
+	c.SetLabel("groq")
+	c.SetTracker(tr)
+
+	if _, err := c.GenerateText(context.Background(), "test", "ping"); err != nil {
+		t.Fatalf("GenerateText failed: %v", err)
+	}
+	if s := tr.Snapshot()["groq"]; s.PromptTokens != 100 || s.CompletionTokens != 20 {
+		t.Errorf("expected 100/20 tokens tracked, got %d/%d", s.PromptTokens, s.CompletionTokens)
+	}
+}
+
+func TestOpenAI_GenerateTextStream_CutOff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunk, _ := json.Marshal(map[string]any{
+			"choices": []map[string]any{{"delta": map[string]string{"content": "Hello"}}},
+		})
+		// The body ends without [DONE]
+		_, _ = w.Write([]byte("data: " + string(chunk) + "\n\n"))
+	}))
+	defer server.Close()
+
+	rc := request.New(nil, tracker.New(), request.ClientConfig{})
+	c, err := NewClient(&config.ProviderConfig{Key: "test_key", Profiles: map[string]string{"narration": "test_model"}}, server.URL, rc)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	stream, err := c.GenerateTextStream(context.Background(), "narration", "ping")
+	if err != nil {
+		t.Fatalf("failed to start stream: %v", err)
+	}
+	for range stream.Deltas() {
+	}
+	if stream.Err() == nil {
+		t.Error("expected the cut-off stream to report an error")
+	}
+}
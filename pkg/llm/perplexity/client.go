@@ -11,6 +11,7 @@ import (
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/llm"
 	"phileasgo/pkg/request"
+	"phileasgo/pkg/tracker"
 )
 
 const defaultBaseURL = "https://api.perplexity.ai/chat/completions"
@@ -23,6 +24,7 @@ type Client struct {
 	baseURL  string
 	profiles map[string]string
 	label    string
+	tracker  *tracker.Tracker
 
 	mu sync.RWMutex
 }
@@ -55,7 +57,11 @@ type sonarResponse struct {
 	} `json:"choices"`
 	// Perplexity includes citations in the response
 	Citations []string `json:"citations,omitempty"`
-	Error     *struct {
+	Usage     *struct {
+		PromptTokens     int64 `json:"prompt_tokens"`
+		CompletionTokens int64 `json:"completion_tokens"`
+	} `json:"usage,omitempty"`
+	Error *struct {
 		Message string `json:"message"`
 		Type    string `json:"type"`
 	} `json:"error,omitempty"`
@@ -79,6 +85,24 @@ func NewClient(cfg *config.ProviderConfig, rc *request.Client) (*Client, error)
 	}, nil
 }
 
+// SetTracker enables token usage (and so cost) reporting.
+func (c *Client) SetTracker(t *tracker.Tracker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tracker = t
+}
+
+// trackUsage records the token counts reported by the API.
+func (c *Client) trackUsage(sresp *sonarResponse) {
+	c.mu.RLock()
+	t := c.tracker
+	c.mu.RUnlock()
+	if t == nil || sresp.Usage == nil {
+		return
+	}
+	t.TrackTokens(c.getLabel(), sresp.Usage.PromptTokens, sresp.Usage.CompletionTokens)
+}
+
 func (c *Client) GenerateText(ctx context.Context, profile, prompt string) (string, error) {
 	model, err := c.resolveModel(profile)
 	if err != nil {
@@ -213,6 +237,7 @@ func (c *Client) execute(ctx context.Context, sreq sonarRequest) (string, error)
 	if sresp.Error != nil {
 		return "", fmt.Errorf("perplexity api error: %s (%s)", sresp.Error.Message, sresp.Error.Type)
 	}
+	c.trackUsage(&sresp)
 
 	if len(sresp.Choices) == 0 {
 		return "", fmt.Errorf("perplexity api returned no choices")
@@ -295,6 +320,7 @@ func (c *Client) Search(ctx context.Context, query string) (*SearchResult, error
 	if sresp.Error != nil {
 		return nil, fmt.Errorf("perplexity api error: %s (%s)", sresp.Error.Message, sresp.Error.Type)
 	}
+	c.trackUsage(&sresp)
 
 	if len(sresp.Choices) == 0 {
 		return nil, fmt.Errorf("perplexity api returned no choices")
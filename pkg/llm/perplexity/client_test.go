@@ -90,7 +90,7 @@ func TestGenerateText(t *testing.T) {
 				t.Errorf("expected query 'hello world'")
 			}
 
-			fmt.Fprint(w, `{"choices": [{"message": {"content": "This is the answer."}}]}`)
+			fmt.Fprint(w, `{"choices": [{"message": {"content": "This is the answer."}}], "usage": {"prompt_tokens": 40, "completion_tokens": 8}}`)
 		}))
 		defer ts.Close()
 
@@ -100,6 +100,7 @@ func TestGenerateText(t *testing.T) {
 			Profiles: map[string]string{"narration": "sonar"},
 		}
 		c, _ := NewClient(&cfg, rc)
+		c.SetTracker(tr)
 
 		res, err := c.GenerateText(context.Background(), "narration", "hello world")
 		if err != nil {
@@ -108,6 +109,9 @@ func TestGenerateText(t *testing.T) {
 		if res != "This is the answer." {
 			t.Errorf("expected 'This is the answer.', got %q", res)
 		}
+		if s := tr.Snapshot()["perplexity"]; s.PromptTokens != 40 || s.CompletionTokens != 8 {
+			t.Errorf("expected 40/8 tokens tracked, got %d/%d", s.PromptTokens, s.CompletionTokens)
+		}
 	})
 
 	t.Run("ResolvePrompt Interaction", func(t *testing.T) {
@@ -2,8 +2,13 @@ package llm
 
 import (
 	"context"
+	"errors"
 )
 
+// ErrStreamingNotSupported is returned by GenerateTextStream when no provider able to stream
+// is available; callers should fall back to the blocking methods.
+var ErrStreamingNotSupported = errors.New("llm streaming not supported")
+
 // Provider defines the interface for interacting with LLM services.
 type Provider interface {
 	// GenerateText sends a prompt and returns the text response.
@@ -27,3 +32,48 @@ type Provider interface {
 	// Name returns the provider's identifier (as defined in config).
 	Name() string
 }
+
+// StreamingProvider is implemented by providers that can deliver text incrementally.
+type StreamingProvider interface {
+	// GenerateTextStream sends a prompt and returns a stream of text deltas.
+	// The deltas channel is closed when the response is complete or the
+	// stream fails; Err tells the two apart.
+	GenerateTextStream(ctx context.Context, profile, prompt string) (*Stream, error)
+}
+
+// Stream carries the text deltas of a streamed response. A stream cut off
+// mid-way looks just like a finished one on the channel alone, so the
+// producer records why it ended.
+type Stream struct {
+	ch  chan string
+	err error
+}
+
+// NewStream returns a stream buffering up to buffer deltas.
+func NewStream(buffer int) *Stream {
+	return &Stream{ch: make(chan string, buffer)}
+}
+
+// Deltas returns the channel of text deltas.
+func (s *Stream) Deltas() <-chan string { return s.ch }
+
+// Send delivers a delta, giving up when ctx is done.
+func (s *Stream) Send(ctx context.Context, delta string) bool {
+	select {
+	case s.ch <- delta:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Close ends the stream. A non-nil err marks the response as incomplete.
+// Only the producer calls it, exactly once.
+func (s *Stream) Close(err error) {
+	s.err = err
+	close(s.ch)
+}
+
+// Err returns why the stream ended early, or nil when the response is
+// complete. It is only meaningful once Deltas is closed.
+func (s *Stream) Err() error { return s.err }
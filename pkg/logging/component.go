@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// componentHandler applies per-component level overrides. Components tag
+// their loggers with slog.With("component", ...), which reaches the handler
+// through WithAttrs, so the component is known before a record is built and
+// suppressed records cost no formatting.
+type componentHandler struct {
+	next      slog.Handler
+	level     slog.Level            // Level for untagged and unlisted components
+	overrides map[string]slog.Level // Component name -> level
+	component string
+}
+
+// newComponentHandler wraps next, which must accept records down to the
+// lowest of level and the overrides.
+func newComponentHandler(next slog.Handler, level slog.Level, overrides map[string]slog.Level) slog.Handler {
+	if len(overrides) == 0 {
+		return next
+	}
+	return &componentHandler{next: next, level: level, overrides: overrides}
+}
+
+func (h *componentHandler) threshold() slog.Level {
+	if l, ok := h.overrides[h.component]; ok && h.component != "" {
+		return l
+	}
+	return h.level
+}
+
+func (h *componentHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.threshold() && h.next.Enabled(ctx, level)
+}
+
+// Handle implements slog.Handler
+// nolint:gocritic // r must be passed by value to implement slog.Handler
+func (h *componentHandler) Handle(ctx context.Context, r slog.Record) error {
+	return h.next.Handle(ctx, r)
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.next = h.next.WithAttrs(attrs)
+	for _, a := range attrs {
+		if a.Key == "component" {
+			c.component = strings.ToLower(a.Value.String())
+		}
+	}
+	return &c
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.next = h.next.WithGroup(name)
+	return &c
+}
+
+// parseLevel maps a configured level name to a slog level, defaulting to INFO.
+func parseLevel(s string) slog.Level {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEBUG":
+		return slog.LevelDebug
+	case "WARN":
+		return slog.LevelWarn
+	case "ERROR":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// parseComponentLevels returns the configured overrides keyed by lower-case
+// component name, and the lowest level among them and base.
+func parseComponentLevels(components map[string]string, base slog.Level) (overrides map[string]slog.Level, lowest slog.Level) {
+	lowest = base
+	for name, lvl := range components {
+		if overrides == nil {
+			overrides = make(map[string]slog.Level, len(components))
+		}
+		l := parseLevel(lvl)
+		overrides[strings.ToLower(name)] = l
+		lowest = min(lowest, l)
+	}
+	return overrides, lowest
+}
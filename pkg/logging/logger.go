@@ -46,7 +46,7 @@ func Init(cfg *config.LogConfig, hCfg *config.HistoryConfig) (func(), error) {
 	var closers []io.Closer
 
 	// 1. Setup Server Logger (Stdout + File)
-	serverHandler, file1, err := setupHandler(cfg.Server.Path, cfg.Server.Level, true)
+	serverHandler, file1, err := setupHandler(&cfg.Server, true)
 	if err != nil {
 		return nil, fmt.Errorf("failed to setup server logger: %w", err)
 	}
@@ -56,7 +56,7 @@ func Init(cfg *config.LogConfig, hCfg *config.HistoryConfig) (func(), error) {
 	slog.SetDefault(slog.New(serverHandler))
 
 	// 2. Setup Requests Logger (File Only)
-	requestHandler, file2, err := setupHandler(cfg.Requests.Path, cfg.Requests.Level, false)
+	requestHandler, file2, err := setupHandler(&cfg.Requests, false)
 	if err != nil {
 		// Try to close first file if second fails (best effort)
 		if file1 != nil {
@@ -76,44 +76,38 @@ func Init(cfg *config.LogConfig, hCfg *config.HistoryConfig) (func(), error) {
 	}, nil
 }
 
-func setupHandler(path, levelStr string, stdout bool) (handler slog.Handler, file *os.File, err error) {
-	// Parse Level
-	var level slog.Level
-	switch strings.ToUpper(levelStr) {
-	case "DEBUG":
-		level = slog.LevelDebug
-	case "INFO":
-		level = slog.LevelInfo
-	case "WARN":
-		level = slog.LevelWarn
-	case "ERROR":
-		level = slog.LevelError
-	default:
-		level = slog.LevelInfo
-	}
+func setupHandler(settings *config.LogSettings, stdout bool) (handler slog.Handler, file *os.File, err error) {
+	level := parseLevel(settings.Level)
+	overrides, lowest := parseComponentLevels(settings.Components, level)
 
 	// Create Directory
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+	if err := os.MkdirAll(filepath.Dir(settings.Path), 0o755); err != nil {
 		return nil, nil, err
 	}
 
 	// Open File (Append mode, truncation handled in Init)
-	file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	file, err = os.OpenFile(settings.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	// Options
+	// Options. The file accepts the lowest level any component is set to;
+	// the component handler holds everything else to the configured level.
 	opts := &slog.HandlerOptions{
-		Level:     level,
-		AddSource: level == slog.LevelDebug,
+		Level:     lowest,
+		AddSource: lowest == slog.LevelDebug,
 	}
 
 	// Create File Handler
-	fileHandler := slog.NewTextHandler(file, opts)
+	var fileHandler slog.Handler
+	if strings.EqualFold(settings.Format, config.LogFormatJSON) {
+		fileHandler = slog.NewJSONHandler(file, opts)
+	} else {
+		fileHandler = slog.NewTextHandler(file, opts)
+	}
 
 	if !stdout {
-		return fileHandler, file, nil
+		return newComponentHandler(fileHandler, level, overrides), file, nil
 	}
 
 	// Console Handler - only INFO and up
@@ -128,7 +122,7 @@ func setupHandler(path, levelStr string, stdout bool) (handler slog.Handler, fil
 	})
 
 	handlers := []slog.Handler{fileHandler, consoleHandler, captureHandler}
-	return &multiHandler{handlers: handlers}, file, nil
+	return newComponentHandler(&multiHandler{handlers: handlers}, level, overrides), file, nil
 }
 
 func mathMaxLevel(a, b slog.Level) slog.Level {
@@ -1,8 +1,13 @@
 package logging
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"phileasgo/pkg/config"
@@ -53,3 +58,55 @@ func TestSetupHandler_Levels(t *testing.T) {
 	// Actually logger.go:37 -> `func setupHandler(...)` so it is unexported.
 	// We can add a test in the same package `logging` to access it.
 }
+
+func TestComponentHandler(t *testing.T) {
+	overrides, lowest := parseComponentLevels(map[string]string{"Wikidata": "WARN", "beacon": "debug"}, slog.LevelInfo)
+	var buf bytes.Buffer
+	logger := slog.New(newComponentHandler(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: lowest}), slog.LevelInfo, overrides))
+
+	tests := []struct {
+		name   string
+		logger *slog.Logger
+		level  slog.Level
+		want   bool
+	}{
+		{name: "Component below its level", logger: logger.With("component", "wikidata"), level: slog.LevelInfo},
+		{name: "Component at its level", logger: logger.With("component", "wikidata"), level: slog.LevelWarn, want: true},
+		{name: "Component lowered to debug", logger: logger.With("component", "beacon"), level: slog.LevelDebug, want: true},
+		{name: "Unlisted component", logger: logger.With("component", "mapper"), level: slog.LevelDebug},
+		{name: "Untagged", logger: logger, level: slog.LevelInfo, want: true},
+		{name: "Tag survives groups", logger: logger.With("component", "wikidata").WithGroup("req"), level: slog.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			tt.logger.Log(context.Background(), tt.level, "hello")
+			if got := strings.Contains(buf.String(), "hello"); got != tt.want {
+				t.Errorf("logged = %v, want %v (output %q)", got, tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestSetupHandler_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.log")
+	h, f, err := setupHandler(&config.LogSettings{Path: path, Level: "INFO", Format: config.LogFormatJSON}, false)
+	if err != nil {
+		t.Fatalf("setupHandler failed: %v", err)
+	}
+	slog.New(h).With("component", "wikidata").Info("hello", "qid", "Q1")
+	f.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	var rec map[string]any
+	if err := json.Unmarshal(bytes.TrimSpace(data), &rec); err != nil {
+		t.Fatalf("expected a JSON line, got %q: %v", data, err)
+	}
+	if rec["msg"] != "hello" || rec["component"] != "wikidata" || rec["qid"] != "Q1" {
+		t.Errorf("unexpected record %v", rec)
+	}
+}
@@ -22,6 +22,9 @@ func (s *MockStore) GetRecentlyPlayedPOIs(ctx context.Context, since time.Time)
 }
 func (s *MockStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error { return nil }
 func (s *MockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius float64) error { return nil }
+func (s *MockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	return nil, nil
+}
 func (s *MockStore) MarkEntitiesSeen(ctx context.Context, entities map[string][]string) error {
 	return nil
 }
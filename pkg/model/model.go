@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 )
 
@@ -9,12 +10,16 @@ type SizeResolver interface {
 	GetSize(category string) string
 }
 
+// SourceUser marks POIs imported from the user's own files. Their ID is not a
+// Wikidata QID and their text comes from Description instead of Wikipedia.
+const SourceUser = "user"
+
 // POI represents a Point of Interest from Wikidata/Wikipedia.
 // Note: The Size field is ephemeral and used primarily for UI/lookahead.
 // Dynamic size resolution should be preferred via a SizeResolver.
 type POI struct {
 	WikidataID       string `json:"wikidata_id"`       // Primary Key
-	Source           string `json:"source"`            // "wikidata"
+	Source           string `json:"source"`            // "wikidata" or SourceUser
 	Category         string `json:"category"`          // e.g. "Landmark"
 	SpecificCategory string `json:"specific_category"` // More precise label from Gemini (e.g. "Chalk Formation")
 	Icon             string `json:"icon"`              // e.g. "castle.png"
@@ -32,9 +37,10 @@ type POI struct {
 	NameLocal string `json:"name_local"` // Name in local language
 	NameUser  string `json:"name_user"`  // Name in user's language (default en)
 
-	WPURL           string `json:"wp_url"`            // URL of the *longest* article
-	WPArticleLength int    `json:"wp_article_length"` // Length of the *longest* article
-	ThumbnailURL    string `json:"thumbnail_url"`     // Wikipedia thumbnail URL (fetched on-demand)
+	WPURL           string `json:"wp_url"`                // URL of the *longest* article
+	WPArticleLength int    `json:"wp_article_length"`     // Length of the *longest* article
+	ThumbnailURL    string `json:"thumbnail_url"`         // Wikipedia thumbnail URL (fetched on-demand)
+	Description     string `json:"description,omitempty"` // User-supplied text for POIs without an article
 
 	// Technical
 	TriggerQID string    `json:"trigger_qid"`
@@ -44,6 +50,9 @@ type POI struct {
 	// Scorer Data
 	Size                string    `json:"size"`                 // S, M, L, XL
 	DimensionMultiplier float64   `json:"dimension_multiplier"` // Multiplier from physical dimensions
+	Height              float64   `json:"height,omitempty"`     // Wikidata height in meters; 0 where unknown
+	Length              float64   `json:"length,omitempty"`     // Wikidata length in meters
+	Area                float64   `json:"area,omitempty"`       // Wikidata area in square meters
 	Score               float64   `json:"score"`                // Intrinsic score (content-based, position-agnostic)
 	ScoreDetails        string    `json:"score_details"`        // Explainer for debug
 	IsVisible           bool      `json:"is_visible"`
@@ -119,6 +128,18 @@ type LocationInfo struct {
 	CityAdmin1Name  string `json:"city_admin1_name,omitempty"`
 }
 
+// RegionKey identifies the admin1 region of the location, or its country
+// where no admin1 is known. It is empty over international waters.
+func (l LocationInfo) RegionKey() string {
+	if l.CountryCode == "" {
+		return ""
+	}
+	if l.Admin1Code == "" {
+		return l.CountryCode
+	}
+	return l.CountryCode + "/" + l.Admin1Code
+}
+
 // DisplayName returns the best available name for the POI.
 // Priority: NameUser > NameEn > NameLocal > WikidataID
 func (p *POI) DisplayName() string {
@@ -134,6 +155,16 @@ func (p *POI) DisplayName() string {
 	return p.WikidataID
 }
 
+// HasArticle reports whether there is text to narrate the POI from: a WP
+// article, or the description of a user POI. POIs rescued for their size
+// often have neither.
+func (p *POI) HasArticle() bool {
+	if p.Source == SourceUser {
+		return p.Description != ""
+	}
+	return p.WPURL != "" && !strings.Contains(p.WPURL, "wikidata.org")
+}
+
 // IsOnCooldown returns true if the POI was played recently and is still within the cooldown period.
 func (p *POI) IsOnCooldown(ttl time.Duration) bool {
 	if p.LastPlayed.IsZero() {
@@ -15,6 +15,8 @@ const (
 	NarrativeTypeBorder     NarrativeType = "border"
 	NarrativeTypeLetsgo     NarrativeType = "letsgo"
 	NarrativeTypeBriefing   NarrativeType = "briefing"
+	NarrativeTypeApproach   NarrativeType = "approach"
+	NarrativeTypeOpenWater  NarrativeType = "open_water"
 )
 
 // GenerationResponse is the structured format expected from the LLM.
@@ -52,6 +54,7 @@ type Narrative struct {
 	Lon float64 `json:"lon,omitempty"`
 
 	// Metadata
-	Manual    bool      `json:"manual"` // True if manually requested by user
+	Manual    bool      `json:"manual"`             // True if manually requested by user
+	Fallback  bool      `json:"fallback,omitempty"` // Template blurb spoken because script generation failed
 	CreatedAt time.Time `json:"created_at"`
 }
@@ -2,18 +2,27 @@ package narrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
 	"os"
+	"slices"
+	"strings"
 	"sync"
+	"unicode"
 
 	"gopkg.in/yaml.v3"
 
 	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/model"
 	"phileasgo/pkg/prompt"
 )
 
+// ErrEssayTopicsExhausted is returned by SelectTopic once every topic has
+// been used this session and the handler is set to stop rather than repeat.
+var ErrEssayTopicsExhausted = errors.New("essay topics exhausted")
+
 // EssayTopic represents a single essay topic definition.
 type EssayTopic struct {
 	ID          string `yaml:"id"`
@@ -21,17 +30,66 @@ type EssayTopic struct {
 	Description string `yaml:"description"`
 	MaxWords    int    `yaml:"max_words"`
 	Icon        string `yaml:"icon"`
+	// Regions restricts the topic to ISO country codes ("DE") or first-level
+	// region names ("Bavaria"). Untagged topics fit everywhere.
+	Regions []string `yaml:"regions"`
+}
+
+// EssayTheme groups related topics for a themed session, e.g. a day of
+// industrial history.
+type EssayTheme struct {
+	ID     string   `yaml:"id"`
+	Name   string   `yaml:"name"`
+	Topics []string `yaml:"topics"` // Topic IDs
+}
+
+const (
+	// regionMatchWeight is how much likelier a topic tagged for the region
+	// below is drawn than an untagged one.
+	regionMatchWeight = 4
+	// themeMatchWeight is how much likelier a topic of the session's themes
+	// is drawn than any other, which stays a rare fallback.
+	themeMatchWeight = 10
+)
+
+// matchesRegion reports whether the topic is tagged for the location.
+func (t *EssayTopic) matchesRegion(loc *model.LocationInfo) bool {
+	for _, r := range t.Regions {
+		if strings.EqualFold(r, loc.CountryCode) || (loc.Admin1Name != "" && strings.EqualFold(r, loc.Admin1Name)) {
+			return true
+		}
+	}
+	return false
 }
 
-// EssayConfig holds the list of defined essay topics.
+// selectionWeight returns the draw weight of the topic at loc. Topics tagged
+// for other regions are not eligible; a nil loc disables regional weighting.
+func (t *EssayTopic) selectionWeight(loc *model.LocationInfo) int {
+	switch {
+	case loc == nil || len(t.Regions) == 0:
+		return 1
+	case t.matchesRegion(loc):
+		return regionMatchWeight
+	default:
+		return 0
+	}
+}
+
+// EssayConfig holds the list of defined essay topics and themes.
 type EssayConfig struct {
 	Topics []EssayTopic `yaml:"topics"`
+	Themes []EssayTheme `yaml:"themes"`
 }
 
 // EssayHandler manages the selection and prompting of regional essays.
 type EssayHandler struct {
 	topics        []EssayTopic
+	themes        []EssayTheme
 	availablePool []string // IDs of topics available in the current rotation cycle
+	used          []string // IDs of topics picked in the current rotation cycle
+	stopOnEmpty   bool     // Stop instead of starting a new cycle once the pool is used up
+	activeThemes  []string
+	themed        map[string]bool // Topic IDs of the active themes
 	mu            sync.Mutex
 	prompts       *prompts.Manager
 }
@@ -50,14 +108,106 @@ func NewEssayHandler(configPath string, prompts *prompts.Manager) (*EssayHandler
 
 	return &EssayHandler{
 		topics:        cfg.Topics,
+		themes:        cfg.Themes,
 		availablePool: make([]string, 0),
 		prompts:       prompts,
 	}, nil
 }
 
+// PickThemes draws up to n distinct themes for a new session and returns
+// their IDs. It does not activate them; see SetThemes.
+func (h *EssayHandler) PickThemes(n int) []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ids := make([]string, 0, len(h.themes))
+	for _, t := range h.themes {
+		ids = append(ids, t.ID)
+	}
+	rand.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+	return ids[:min(n, len(ids))]
+}
+
+// SetThemes makes SelectTopic prefer the topics of the given themes. Unknown
+// IDs are ignored; no IDs turns themed selection off.
+func (h *EssayHandler) SetThemes(ids []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if slices.Equal(ids, h.activeThemes) {
+		return
+	}
+	h.activeThemes = slices.Clone(ids)
+	h.themed = nil
+	for _, t := range h.themes {
+		if !slices.Contains(ids, t.ID) {
+			continue
+		}
+		if h.themed == nil {
+			h.themed = make(map[string]bool)
+		}
+		for _, id := range t.Topics {
+			h.themed[id] = true
+		}
+	}
+	if len(ids) > 0 {
+		slog.Info("EssayHandler: Session themes set", "themes", ids, "topics", len(h.themed))
+	}
+}
+
+// SetStopWhenExhausted makes SelectTopic fail with ErrEssayTopicsExhausted
+// once the rotation is used up, instead of starting a new cycle.
+func (h *EssayHandler) SetStopWhenExhausted(stop bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopOnEmpty = stop
+}
+
+// UsedTopics returns the IDs of the topics picked in the current rotation
+// cycle, for the session state.
+func (h *EssayHandler) UsedTopics() []string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return slices.Clone(h.used)
+}
+
+// SetUsedTopics restores the rotation cycle from the topics already picked
+// this session, so a restart doesn't replay them. Topics with the same
+// title as a picked one are left out too. No IDs starts a fresh cycle.
+func (h *EssayHandler) SetUsedTopics(ids []string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if slices.Equal(ids, h.used) {
+		return
+	}
+	h.used = slices.Clone(ids)
+	h.availablePool = h.availablePool[:0]
+	if len(ids) == 0 {
+		return // SelectTopic refills on the next pick
+	}
+	usedKeys := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if t := h.topicByID(id); t != nil {
+			usedKeys[topicKey(t)] = true
+		}
+	}
+	for _, t := range h.topics {
+		if !usedKeys[topicKey(&t)] {
+			h.availablePool = append(h.availablePool, t.ID)
+		}
+	}
+}
+
 // SelectTopic selects a random topic from the rotation pool.
-// It guarantees that all topics are played once before any repeat.
-func (h *EssayHandler) SelectTopic() (*EssayTopic, error) {
+// It guarantees that all eligible topics are played once before any repeat,
+// and that of topics with near-identical titles only one is played per cycle.
+// With a location, topics tagged for that region are preferred and topics
+// tagged for other regions are skipped. With active themes, their topics are
+// preferred and the cycle restarts once they are used up, so the remaining
+// topics only come up as rare fallbacks. When set to stop on exhaustion, the
+// cycle never restarts and ErrEssayTopicsExhausted ends the essays instead.
+func (h *EssayHandler) SelectTopic(loc *model.LocationInfo) (*EssayTopic, error) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -65,33 +215,111 @@ func (h *EssayHandler) SelectTopic() (*EssayTopic, error) {
 		return nil, fmt.Errorf("no essay topics available")
 	}
 
-	// Refill if empty
-	if len(h.availablePool) == 0 {
-		h.availablePool = make([]string, len(h.topics))
-		for i, t := range h.topics {
-			h.availablePool[i] = t.ID
+	// Refill if empty, if only topics for other regions are left, or if the
+	// themed topics of this cycle are used up
+	weights, total, themed := h.poolWeights(loc)
+	if total == 0 || (h.themed != nil && themed == 0) {
+		switch {
+		case !h.stopOnEmpty || len(h.used) == 0:
+			h.availablePool = make([]string, len(h.topics))
+			for i, t := range h.topics {
+				h.availablePool[i] = t.ID
+			}
+			h.used = nil
+			slog.Info("EssayHandler: Topic pool exhausted. Starting new rotation cycle.", "topics", len(h.topics))
+			weights, total, _ = h.poolWeights(loc)
+		case len(h.availablePool) == 0:
+			return nil, ErrEssayTopicsExhausted
 		}
-		slog.Info("EssayHandler: Topic pool exhausted. Starting new rotation cycle.", "topics", len(h.topics))
+	}
+	if total == 0 {
+		// Every topic is tagged for elsewhere; better any essay than none
+		weights, total, _ = h.poolWeights(nil)
 	}
 
-	// Pick random index
-	idx := rand.Intn(len(h.availablePool))
+	// Pick weighted random index
+	idx := 0
+	for r := rand.Intn(total); r >= weights[idx]; idx++ {
+		r -= weights[idx]
+	}
 	selectedID := h.availablePool[idx]
 
-	// Swap with last and shrink to remove (O(1))
-	h.availablePool[idx] = h.availablePool[len(h.availablePool)-1]
-	h.availablePool = h.availablePool[:len(h.availablePool)-1]
+	selected := h.topicByID(selectedID)
+	if selected == nil {
+		return nil, fmt.Errorf("topic %s not found in rotation", selectedID)
+	}
+
+	// Remove the pick and any topic with the same title from the pool
+	key := topicKey(selected)
+	h.availablePool = slices.DeleteFunc(h.availablePool, func(id string) bool {
+		t := h.topicByID(id)
+		return id == selectedID || (t != nil && topicKey(t) == key)
+	})
+	h.used = append(h.used, selectedID)
+
+	if loc != nil {
+		slog.Info("EssayHandler: Topic selected", "topic", selected.ID, "country", loc.CountryCode, "region", loc.Admin1Name,
+			"region_tagged", len(selected.Regions) > 0, "region_match", selected.matchesRegion(loc), "themed", h.themed[selected.ID])
+	}
+	return selected, nil
+}
 
-	// Find the topic object
+// topicKey reduces a topic title to its significant words, in any order and
+// without plurals, so "Castles of Bavaria" and "Bavaria's castles" match.
+// Topics without a title only match themselves.
+func topicKey(t *EssayTopic) string {
+	var words []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(t.Name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		// Skips articles and prepositions, and the "s" of a possessive, but
+		// keeps numbers apart: "Battles of 1813" isn't "Battles of 1945"
+		if len([]rune(w)) <= 3 && !unicode.IsDigit([]rune(w)[0]) {
+			continue
+		}
+		words = append(words, strings.TrimSuffix(w, "s"))
+	}
+	if len(words) == 0 {
+		return "id:" + t.ID
+	}
+	slices.Sort(words)
+	return strings.Join(slices.Compact(words), " ")
+}
+
+// poolWeights returns the draw weight of each pool entry, their sum and the
+// part of the sum that falls on themed topics.
+func (h *EssayHandler) poolWeights(loc *model.LocationInfo) (weights []int, total, themed int) {
+	weights = make([]int, len(h.availablePool))
+	for i, id := range h.availablePool {
+		if t := h.topicByID(id); t != nil {
+			weights[i] = t.selectionWeight(loc)
+			if h.themed[id] {
+				weights[i] *= themeMatchWeight
+				themed += weights[i]
+			}
+			total += weights[i]
+		}
+	}
+	return weights, total, themed
+}
+
+// topicByID returns a copy of the topic with the given ID.
+func (h *EssayHandler) topicByID(id string) *EssayTopic {
 	for _, t := range h.topics {
-		if t.ID == selectedID {
-			// Return a copy
+		if t.ID == id {
 			selected := t
-			return &selected, nil
+			return &selected
 		}
 	}
+	return nil
+}
 
-	return nil, fmt.Errorf("topic %s not found in rotation", selectedID)
+// Topic returns the topic with the given ID, outside the rotation.
+func (h *EssayHandler) Topic(id string) (*EssayTopic, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t := h.topicByID(id)
+	return t, t != nil
 }
 
 func (h *EssayHandler) BuildPrompt(ctx context.Context, topic *EssayTopic, pd *prompt.Data) (string, error) {
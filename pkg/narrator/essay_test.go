@@ -2,12 +2,14 @@ package narrator
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/model"
 	"phileasgo/pkg/prompt"
 )
 
@@ -48,7 +50,7 @@ topics:
 	// Initial state: availablePool is empty.
 
 	// Pick 1
-	t1, err := eh.SelectTopic()
+	t1, err := eh.SelectTopic(nil)
 	if err != nil {
 		t.Fatalf("SelectTopic 1 failed: %v", err)
 	}
@@ -58,7 +60,7 @@ topics:
 	}
 
 	// Pick 2
-	t2, err := eh.SelectTopic()
+	t2, err := eh.SelectTopic(nil)
 	if err != nil {
 		t.Fatalf("SelectTopic 2 failed: %v", err)
 	}
@@ -70,7 +72,7 @@ topics:
 	}
 
 	// Pick 3
-	t3, err := eh.SelectTopic()
+	t3, err := eh.SelectTopic(nil)
 	if err != nil {
 		t.Fatalf("SelectTopic 3 failed: %v", err)
 	}
@@ -82,7 +84,7 @@ topics:
 	}
 
 	// Pick 4 -> Pool size was 0. Should refill to 3 and pick 1 -> 2 remaining.
-	t4, err := eh.SelectTopic()
+	t4, err := eh.SelectTopic(nil)
 	if err != nil {
 		t.Fatalf("SelectTopic 4 failed: %v", err)
 	}
@@ -220,8 +222,198 @@ func TestSelectTopic_EmptyConfig(t *testing.T) {
 	pm, _ := prompts.NewManager(tmpDir)
 
 	eh, _ := NewEssayHandler(configPath, pm)
-	_, err := eh.SelectTopic()
+	_, err := eh.SelectTopic(nil)
 	if err == nil {
 		t.Error("Expected error for empty topics, got nil")
 	}
 }
+
+func TestEssayHandler_SelectTopic_Regional(t *testing.T) {
+	newHandler := func() *EssayHandler {
+		return &EssayHandler{topics: []EssayTopic{
+			{ID: "beer", Regions: []string{"DE", "CZ"}},
+			{ID: "alps", Regions: []string{"Bavaria"}},
+			{ID: "history"},
+			{ID: "sushi", Regions: []string{"JP"}},
+		}}
+	}
+	pick := func(t *testing.T, h *EssayHandler, loc *model.LocationInfo) string {
+		t.Helper()
+		topic, err := h.SelectTopic(loc)
+		if err != nil {
+			t.Fatalf("SelectTopic failed: %v", err)
+		}
+		return topic.ID
+	}
+
+	t.Run("Skips topics tagged for other regions", func(t *testing.T) {
+		h := newHandler()
+		loc := &model.LocationInfo{CountryCode: "de", Admin1Name: "Bavaria"}
+		seen := map[string]bool{}
+		for i := 0; i < 6; i++ {
+			seen[pick(t, h, loc)] = true
+		}
+		if seen["sushi"] || !seen["beer"] || !seen["alps"] || !seen["history"] {
+			t.Errorf("unexpected topics over Bavaria: %v", seen)
+		}
+	})
+
+	t.Run("Prefers matching topics", func(t *testing.T) {
+		counts := map[string]int{}
+		loc := &model.LocationInfo{CountryCode: "JP"}
+		for i := 0; i < 400; i++ {
+			counts[pick(t, newHandler(), loc)]++
+		}
+		if counts["beer"]+counts["alps"] > 0 {
+			t.Errorf("foreign topics picked: %v", counts)
+		}
+		// Expected 4:1 odds for the first draw of a cycle
+		if counts["sushi"] < 2*counts["history"] {
+			t.Errorf("regional topic not preferred: %v", counts)
+		}
+	})
+
+	t.Run("Falls back when nothing fits", func(t *testing.T) {
+		h := &EssayHandler{topics: []EssayTopic{{ID: "beer", Regions: []string{"DE"}}}}
+		if id := pick(t, h, &model.LocationInfo{CountryCode: "US"}); id != "beer" {
+			t.Errorf("expected fallback to beer, got %s", id)
+		}
+	})
+
+	t.Run("Nil location ignores tags", func(t *testing.T) {
+		h := newHandler()
+		seen := map[string]bool{}
+		for i := 0; i < 4; i++ {
+			seen[pick(t, h, nil)] = true
+		}
+		if len(seen) != 4 {
+			t.Errorf("expected full rotation, got %v", seen)
+		}
+	})
+}
+
+func TestEssayHandler_Themes(t *testing.T) {
+	newHandler := func() *EssayHandler {
+		return &EssayHandler{
+			topics: []EssayTopic{{ID: "history"}, {ID: "economy"}, {ID: "fauna"}, {ID: "flora"}, {ID: "myths"}},
+			themes: []EssayTheme{
+				{ID: "industry", Topics: []string{"history", "economy"}},
+				{ID: "nature", Topics: []string{"fauna", "flora"}},
+			},
+		}
+	}
+
+	t.Run("PickThemes caps at the defined themes", func(t *testing.T) {
+		h := newHandler()
+		if got := h.PickThemes(1); len(got) != 1 {
+			t.Errorf("expected 1 theme, got %v", got)
+		}
+		if got := h.PickThemes(5); len(got) != 2 {
+			t.Errorf("expected both themes, got %v", got)
+		}
+	})
+
+	t.Run("Prefers themed topics", func(t *testing.T) {
+		themed := 0
+		for i := 0; i < 50; i++ {
+			h := newHandler()
+			h.SetThemes([]string{"industry"})
+			topic, err := h.SelectTopic(nil)
+			if err != nil {
+				t.Fatalf("SelectTopic failed: %v", err)
+			}
+			if topic.ID == "history" || topic.ID == "economy" {
+				themed++
+			}
+		}
+		// 20 of 23 weight units are themed
+		if themed < 30 {
+			t.Errorf("expected mostly themed topics, got %d of 50", themed)
+		}
+	})
+
+	t.Run("Restarts the cycle when the themed topics are used up", func(t *testing.T) {
+		h := newHandler()
+		h.SetThemes([]string{"industry"})
+		h.availablePool = []string{"fauna", "flora", "myths"}
+		topic, err := h.SelectTopic(nil)
+		if err != nil {
+			t.Fatalf("SelectTopic failed: %v", err)
+		}
+		if len(h.availablePool) != len(h.topics)-1 {
+			t.Errorf("expected a refilled pool, got %v (picked %s)", h.availablePool, topic.ID)
+		}
+	})
+
+	t.Run("No themes turns themed selection off", func(t *testing.T) {
+		h := newHandler()
+		h.SetThemes([]string{"industry"})
+		h.SetThemes(nil)
+		if h.themed != nil {
+			t.Errorf("expected no themed topics, got %v", h.themed)
+		}
+	})
+}
+
+func TestEssayHandler_NoRepeatsWithinSession(t *testing.T) {
+	topics := []EssayTopic{
+		{ID: "castles", Name: "Castles of Bavaria"},
+		{ID: "castles_alt", Name: "Bavaria's Castles"},
+		{ID: "rivers", Name: "Rivers"},
+		{ID: "war_1813", Name: "Battles of 1813"},
+		{ID: "war_1945", Name: "Battles of 1945"},
+		{ID: "untitled_a"},
+		{ID: "untitled_b"},
+	}
+	// The two castle topics count as one
+	const distinct = 6
+
+	tests := []struct {
+		name    string
+		stop    bool
+		restart bool // Move the used topics through the session state halfway
+	}{
+		{name: "Reset on exhaustion"},
+		{name: "Stop on exhaustion", stop: true},
+		{name: "Survives a restart", restart: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &EssayHandler{topics: topics}
+			h.SetStopWhenExhausted(tt.stop)
+
+			seen := make(map[string]bool)
+			for i := 0; i < distinct; i++ {
+				if tt.restart && i == distinct/2 {
+					used := h.UsedTopics()
+					h = &EssayHandler{topics: topics}
+					h.SetUsedTopics(used)
+				}
+				topic, err := h.SelectTopic(nil)
+				if err != nil {
+					t.Fatalf("pick %d: SelectTopic failed: %v", i, err)
+				}
+				key := topicKey(topic)
+				if seen[key] {
+					t.Fatalf("pick %d: %s repeated before the pool was exhausted", i, topic.ID)
+				}
+				seen[key] = true
+			}
+
+			topic, err := h.SelectTopic(nil)
+			if tt.stop {
+				if !errors.Is(err, ErrEssayTopicsExhausted) {
+					t.Errorf("expected ErrEssayTopicsExhausted, got %v (%v)", err, topic)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected a new cycle, got %v", err)
+			}
+			if used := h.UsedTopics(); len(used) != 1 || used[0] != topic.ID {
+				t.Errorf("expected the new cycle to hold only %s, got %v", topic.ID, used)
+			}
+		})
+	}
+}
@@ -67,7 +67,12 @@ func NewLLMProvider(cfg config.LLMConfig, hCfg config.HistorySettings, rc *reque
 	}
 
 	// Wrap in Failover Provider with unified logging and names
-	return failover.New(providers, names, timeouts, providerBackoffs, hCfg.Path, hCfg.Enabled, t)
+	fp, err := failover.New(providers, names, timeouts, providerBackoffs, hCfg.Path, hCfg.Enabled, t)
+	if err != nil {
+		return nil, err
+	}
+	fp.SetDegradedCooldown(time.Duration(cfg.DegradedCooldown))
+	return fp, nil
 }
 
 // buildProvider constructs a single LLM provider from its configuration.
@@ -76,9 +81,17 @@ func buildProvider(pCfg *config.ProviderConfig, _ string, rc *request.Client, t
 	case "gemini":
 		return gemini.NewClient(pCfg, rc, t)
 	case "openai", "groq", "nvidia", "deepseek":
-		return openai.NewClient(pCfg, "", rc)
+		c, err := openai.NewClient(pCfg, "", rc)
+		if err == nil && t != nil {
+			c.SetTracker(t)
+		}
+		return c, err
 	case "perplexity":
-		return perplexity.NewClient(pCfg, rc)
+		c, err := perplexity.NewClient(pCfg, rc)
+		if err == nil && t != nil {
+			c.SetTracker(t)
+		}
+		return c, err
 	case "tavily":
 		return tavily.NewClient(pCfg, rc)
 	default:
@@ -114,5 +127,5 @@ func NewTTSProvider(cfg *config.TTSConfig, langProv tts.LanguageProvider, t *tra
 		t.SetFreeTier(cfg.Engine, free)
 	}
 
-	return prov, err
+	return tts.Limit(prov, cfg.MaxConcurrent), err
 }
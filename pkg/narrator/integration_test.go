@@ -3,27 +3,31 @@ package narrator_test
 import (
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"phileasgo/pkg/llm/prompts"
 	"phileasgo/pkg/prompt"
 )
 
-// TestNarrator_Integration verifies that the actual production templates
-// can be rendered with the actual struct used in AIService.
-func TestNarrator_Integration(t *testing.T) {
+// productionPrompts loads the REAL templates from configs/prompts.
+func productionPrompts(t *testing.T) *prompts.Manager {
+	t.Helper()
 	// Locate project root relative to this test file
 	_, filename, _, _ := runtime.Caller(0)
 	projectRoot := filepath.Join(filepath.Dir(filename), "..", "..")
 	promptsDir := filepath.Join(projectRoot, "configs", "prompts")
 
-	// Initialize Manager with REAL templates
 	pm, err := prompts.NewManager(promptsDir)
 	if err != nil {
 		t.Fatalf("Failed to load production templates from %s: %v", promptsDir, err)
 	}
+	return pm
+}
 
-	data := prompt.Data{
+// scriptData returns a complete data set for narrator/script.tmpl.
+func scriptData() prompt.Data {
+	return prompt.Data{
 		"TourGuideName":        "Ava",
 		"Persona":              "Intelligent",
 		"Accent":               "Neutral",
@@ -43,6 +47,7 @@ func TestNarrator_Integration(t *testing.T) {
 		"NameUser":             "Paris",
 		"Name":                 "Paris",
 		"Category":             "City",
+		"CategoryLabel":        "City",
 		"WikipediaText":        "Text",
 		"ArticleURL":           "http://example.com",
 		"TargetLanguage":       "en",
@@ -53,6 +58,7 @@ func TestNarrator_Integration(t *testing.T) {
 		"ActiveStyle":          "Informative",
 		"ActiveSecretWord":     "Phileas",
 		"Avoid":                []string{"Politics"},
+		"AvoidRepeating":       []string{"Eiffel Tower"},
 		"IsOnGround":           false,
 		"City":                 "Paris",
 		"Heading":              180.0,
@@ -72,6 +78,9 @@ func TestNarrator_Integration(t *testing.T) {
 		"RelativeDir":      "ahead",
 		"UnitSystem":       "metric",
 		"IsStub":           false,
+		"DimensionFacts":   "",
+		"WikidataFacts":    "",
+		"IsNight":          false,
 		"PregroundContext": "Notes",
 		"TTSInstructions":  "Speak clearly.",
 		"LastSentence":     "Hello.",
@@ -88,9 +97,21 @@ func TestNarrator_Integration(t *testing.T) {
 		"From":             "France",
 		"To":               "Germany",
 		"NarrativeType":    "script",
+		"VehicleMode":      "aircraft",
+		"NavInstruction":   "12 o'clock (ahead), 10 km",
+
+		"DistanceUnits":     "metric",
+		"AltitudeUnits":     "metric",
+		"AltitudeAGLMeters": 1524.0,
 	}
+}
 
-	content, err := pm.Render("narrator/script.tmpl", data)
+// TestNarrator_Integration verifies that the actual production templates
+// can be rendered with the actual struct used in AIService.
+func TestNarrator_Integration(t *testing.T) {
+	pm := productionPrompts(t)
+
+	content, err := pm.Render("narrator/script.tmpl", scriptData())
 	if err != nil {
 		t.Fatalf("Failed to render production template 'narrator/script.tmpl': %v", err)
 	}
@@ -101,3 +122,54 @@ func TestNarrator_Integration(t *testing.T) {
 
 	t.Logf("Successfully rendered template. Preview:\n%.100s...", content)
 }
+
+func TestNarrator_WikidataFacts(t *testing.T) {
+	pm := productionPrompts(t)
+
+	data := scriptData()
+	data["WikidataFacts"] = "- Inception: 1889\n- Height: 330 metre"
+	content, err := pm.Render("narrator/script.tmpl", data)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(content, "FACTS (from Wikidata)") || !strings.Contains(content, "- Height: 330 metre") {
+		t.Errorf("facts missing from the prompt:\n%s", content)
+	}
+
+	data["WikidataFacts"] = ""
+	if content, _ = pm.Render("narrator/script.tmpl", data); strings.Contains(content, "FACTS (from Wikidata)") {
+		t.Error("facts heading rendered without facts")
+	}
+}
+
+func TestNarrator_UnitDimensions(t *testing.T) {
+	pm := productionPrompts(t)
+
+	tests := []struct {
+		name               string
+		distance, altitude string
+		wantDist, wantAlt  string
+	}{
+		{name: "Metric distance, feet altitude", distance: "metric", altitude: "imperial", wantDist: "**Distance**: 10 km", wantAlt: "about 5000 ft AGL"},
+		{name: "Imperial distance, metric altitude", distance: "imperial", altitude: "metric", wantDist: "**Distance**: 5.4 miles", wantAlt: "about 1524 m AGL"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := scriptData()
+			data["DistanceUnits"] = tt.distance
+			data["AltitudeUnits"] = tt.altitude
+
+			content, err := pm.Render("narrator/script.tmpl", data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			if !strings.Contains(content, tt.wantDist) {
+				t.Errorf("expected %q in the prompt", tt.wantDist)
+			}
+			if !strings.Contains(content, tt.wantAlt) {
+				t.Errorf("expected %q in the prompt", tt.wantAlt)
+			}
+		})
+	}
+}
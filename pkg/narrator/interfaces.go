@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"phileasgo/pkg/announcement"
+	"phileasgo/pkg/beacon"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/llm"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/sim"
@@ -51,6 +53,11 @@ type BeaconProvider interface {
 	Clear()
 }
 
+// MultiBeaconProvider is implemented by beacon services that can mark several targets at once.
+type MultiBeaconProvider interface {
+	SetTargets(ctx context.Context, points []geo.Point, objects []beacon.TargetObject) error
+}
+
 // Generator defines the interface for narration generation.
 type Generator interface {
 	GenerateNarrative(ctx context.Context, req *GenerationRequest) (*model.Narrative, error)
@@ -1,6 +1,8 @@
 package narrator
 
 import (
+	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -68,3 +70,58 @@ func TestLatencyTracking(t *testing.T) {
 		t.Errorf("Expected length 10, got %d", len(s.latencies))
 	}
 }
+
+func TestLatencyPersistence(t *testing.T) {
+	newService := func(st *MockStore) *AIService {
+		return &AIService{
+			cfg:       config.NewProvider(config.DefaultConfig(), nil),
+			latencies: make([]time.Duration, 0, maxLatencySamples),
+			stats:     make(map[string]any),
+			sim:       &MockSim{},
+			st:        st,
+		}
+	}
+	stored := func(age time.Duration, samples string) *MockStore {
+		savedAt := time.Now().Add(-age).Format(time.RFC3339)
+		return &MockStore{State: map[string]string{
+			latencyStateKey: fmt.Sprintf(`{"samples_ms": %s, "saved_at": %q}`, samples, savedAt),
+		}}
+	}
+
+	t.Run("Round trip seeds the average", func(t *testing.T) {
+		st := &MockStore{}
+		s := newService(st)
+		s.updateLatency(10 * time.Second)
+		s.updateLatency(20 * time.Second)
+
+		restored := newService(st)
+		restored.restoreLatencies(context.Background())
+		if avg := restored.AverageLatency(); avg != 15*time.Second {
+			t.Errorf("Expected restored avg 15s, got %v", avg)
+		}
+	})
+
+	tests := []struct {
+		name    string
+		st      *MockStore
+		wantAvg time.Duration
+		wantLen int
+	}{
+		{name: "Stale window is ignored", st: stored(25*time.Hour, "[5000]"), wantAvg: 60 * time.Second, wantLen: 0},
+		{name: "Window is capped to newest samples", st: stored(time.Hour, "[100000,100000,4000,4000,4000,4000,4000,4000,4000,4000,4000,4000]"), wantAvg: 4 * time.Second, wantLen: 10},
+		{name: "Garbage is ignored", st: &MockStore{State: map[string]string{latencyStateKey: "not json"}}, wantAvg: 60 * time.Second, wantLen: 0},
+		{name: "Nothing stored", st: &MockStore{}, wantAvg: 60 * time.Second, wantLen: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newService(tt.st)
+			s.restoreLatencies(context.Background())
+			if avg := s.AverageLatency(); avg != tt.wantAvg {
+				t.Errorf("Expected avg %v, got %v", tt.wantAvg, avg)
+			}
+			if len(s.latencies) != tt.wantLen {
+				t.Errorf("Expected %d samples, got %d", tt.wantLen, len(s.latencies))
+			}
+		})
+	}
+}
@@ -10,7 +10,9 @@ import (
 
 	"phileasgo/pkg/announcement"
 	"phileasgo/pkg/audio"
+	"phileasgo/pkg/beacon"
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/llm"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/prompt"
@@ -190,6 +192,7 @@ type MockAudio struct {
 	PlayErr         error
 	IsPlayingVal    bool
 	IsUserPausedVal bool
+	Holds           map[string]bool
 	CanReplay       bool
 	Replayed        bool
 	PlaySync        bool
@@ -233,6 +236,26 @@ func (m *MockAudio) SetUserPaused(paused bool) {
 func (m *MockAudio) IsUserPaused() bool        { m.mu.RLock(); defer m.mu.RUnlock(); return m.IsUserPausedVal }
 func (m *MockAudio) ResetUserPause()           { m.mu.Lock(); defer m.mu.Unlock(); m.IsUserPausedVal = false }
 func (m *MockAudio) LastNarrationFile() string { m.mu.RLock(); defer m.mu.RUnlock(); return m.LastFile }
+func (m *MockAudio) SetHold(reason string, on bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Holds == nil {
+		m.Holds = make(map[string]bool)
+	}
+	if on {
+		m.Holds[reason] = true
+	} else {
+		delete(m.Holds, reason)
+	}
+}
+func (m *MockAudio) HoldReason() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for r := range m.Holds {
+		return r
+	}
+	return ""
+}
 func (m *MockAudio) ReplayLastNarration(onComplete func()) bool {
 	if m.CanReplay {
 		m.Replayed = true
@@ -347,6 +370,7 @@ type MockBeacon struct {
 	TargetSet  bool
 	LastTgtLat float64
 	LastTgtLon float64
+	Targets    []geo.Point
 }
 
 func (m *MockBeacon) SetTarget(ctx context.Context, lat, lon float64, title, livery string) error {
@@ -356,6 +380,11 @@ func (m *MockBeacon) SetTarget(ctx context.Context, lat, lon float64, title, liv
 	return nil
 }
 
+func (m *MockBeacon) SetTargets(ctx context.Context, points []geo.Point, objects []beacon.TargetObject) error {
+	m.Targets = points
+	return nil
+}
+
 func (m *MockBeacon) Clear() {
 	m.Cleared = true
 	m.TargetSet = false
@@ -416,6 +445,7 @@ type MockStore struct {
 	Articles   map[string]*model.Article
 	RecentPOIs []*model.POI
 	State      map[string]string
+	Cache      map[string][]byte
 }
 
 func (m *MockStore) SavePOI(ctx context.Context, p *model.POI) error {
@@ -440,6 +470,9 @@ func (m *MockStore) GetRecentlyPlayedPOIs(ctx context.Context, since time.Time)
 }
 func (m *MockStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error { return nil }
 func (m *MockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius float64) error { return nil }
+func (m *MockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	return nil, nil
+}
 func (m *MockStore) SaveArticle(ctx context.Context, a *model.Article) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -461,9 +494,25 @@ func (m *MockStore) GetArticle(ctx context.Context, uuid string) (*model.Article
 }
 func (m *MockStore) GetConfig(ctx context.Context) (map[string]string, error)    { return nil, nil }
 func (m *MockStore) SaveConfig(ctx context.Context, cfg map[string]string) error { return nil }
-func (m *MockStore) GetCache(ctx context.Context, key string) ([]byte, bool)     { return nil, false }
-func (m *MockStore) HasCache(ctx context.Context, key string) (bool, error)      { return false, nil }
-func (m *MockStore) SetCache(ctx context.Context, key string, val []byte) error  { return nil }
+func (m *MockStore) GetCache(ctx context.Context, key string) ([]byte, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	val, ok := m.Cache[key]
+	return val, ok
+}
+func (m *MockStore) HasCache(ctx context.Context, key string) (bool, error) {
+	_, ok := m.GetCache(ctx, key)
+	return ok, nil
+}
+func (m *MockStore) SetCache(ctx context.Context, key string, val []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.Cache == nil {
+		m.Cache = make(map[string][]byte)
+	}
+	m.Cache[key] = val
+	return nil
+}
 func (m *MockStore) ListCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
@@ -476,6 +525,36 @@ func (m *MockStore) SetGeodataCache(ctx context.Context, key string, val []byte,
 func (m *MockStore) GetGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]store.GeodataRecord, error) {
 	return nil, nil
 }
+func (m *MockStore) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	return 0, nil
+}
+func (m *MockStore) ListGeodata(ctx context.Context, q store.GeodataQuery) ([]store.GeodataRecord, int, error) {
+	return nil, 0, nil
+}
+
+func (m *MockStore) AddNarrationLog(ctx context.Context, e store.NarrationLogEntry, keep int) error {
+	return nil
+}
+
+func (m *MockStore) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	return nil, nil
+}
+
+func (m *MockStore) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	return nil
+}
+
+func (m *MockStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return nil, nil
+}
+
+func (m *MockStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	return nil
+}
+
+func (m *MockStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return nil, nil
+}
 func (m *MockStore) ListGeodataCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
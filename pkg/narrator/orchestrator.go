@@ -2,6 +2,7 @@ package narrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -10,7 +11,9 @@ import (
 
 	"phileasgo/pkg/announcement"
 	"phileasgo/pkg/audio"
+	"phileasgo/pkg/beacon"
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/llm"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/playback"
@@ -50,11 +53,40 @@ type Orchestrator struct {
 
 	pacingDuration time.Duration
 	skipCooldown   bool
+	shutdownGrace  time.Duration // How long Stop waits for the current narration; 0 cuts it off
+	draining       bool          // Set by Stop: the current narration may finish, nothing new starts
 
 	// Beacon Registry & Rotation
 	beaconRegistry config.BeaconRegistry
 	colorKeys      []string
 	colorIndex     int
+	markQueued     bool // Also mark queued POIs when the beacon service supports multiple targets
+
+	chimeFor func(category string) string // Category chime file; "" for the default
+
+	pacer Pacer // Silence enforced before auto-narrations (optional)
+
+	maxHold    time.Duration          // Holds are released after this long; 0 waits for the release
+	holdTimers map[string]*time.Timer // Pending forced releases by hold reason
+
+	onStateChange func(e PlaybackEvent)
+	playSeq       uint64 // Bumped per started narration to detect crossfade handovers
+	skippedSeq    uint64 // playSeq of the last narration the user skipped
+}
+
+// Playback states reported through PlaybackEvent.
+const (
+	PlaybackStarted = "start"
+	PlaybackStopped = "stop"
+	PlaybackSkipped = "skip"
+)
+
+// PlaybackEvent describes a narration playback state change.
+type PlaybackEvent struct {
+	State string
+	Title string
+	Type  model.NarrativeType
+	POI   *model.POI
 }
 
 // NewOrchestrator creates a new narrator orchestrator.
@@ -81,14 +113,142 @@ func NewOrchestrator(
 	}
 }
 
+// SetOnStateChange sets the callback for playback state changes.
+func (o *Orchestrator) SetOnStateChange(cb func(e PlaybackEvent)) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.onStateChange = cb
+}
+
+// notifyState reports a playback state change for the current narration.
+// Must be called without holding o.mu.
+func (o *Orchestrator) notifyState(state string) {
+	o.mu.RLock()
+	cb := o.onStateChange
+	e := PlaybackEvent{State: state, Title: o.currentTitle, Type: o.currentType, POI: o.currentPOI}
+	o.mu.RUnlock()
+	if cb != nil {
+		cb(e)
+	}
+}
+
 func (o *Orchestrator) Start() {
 	o.gen.ProcessGenerationQueue(context.Background())
 }
 
+// SetShutdownGrace sets how long Stop waits for the current narration to
+// finish playing before the audio is shut down.
+func (o *Orchestrator) SetShutdownGrace(d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.shutdownGrace = d
+}
+
+// SetMaxHold sets how long a hold lasts at most. A radio tool that crashes
+// between pause and resume would otherwise silence the tour for good.
+func (o *Orchestrator) SetMaxHold(d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.maxHold = d
+}
+
+// Pacer enforces silence between auto-narrations. The orchestrator reports
+// when each narration ends and holds queued auto-narrations back for Wait.
+type Pacer interface {
+	Finished()
+	Wait() time.Duration
+}
+
+// SetPacer holds the start of queued auto-narrations back until p allows
+// them. Manual narrations are never held.
+func (o *Orchestrator) SetPacer(p Pacer) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.pacer = p
+}
+
+// chimeSelector is implemented by audio services that can swap the
+// pre-narration chime per clip.
+type chimeSelector interface {
+	SetNextChime(path string)
+}
+
+// SetChimeResolver sets how a POI's category maps to its pre-narration
+// chime, so listeners can tell a castle from a lake before the guide speaks.
+func (o *Orchestrator) SetChimeResolver(fn func(category string) string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.chimeFor = fn
+}
+
+// selectChime hands the audio service the chime for n's category. Other
+// narratives keep the default chime.
+func (o *Orchestrator) selectChime(n *model.Narrative) {
+	cs, ok := o.audio.(chimeSelector)
+	if !ok || n.POI == nil {
+		return
+	}
+	o.mu.RLock()
+	chimeFor := o.chimeFor
+	o.mu.RUnlock()
+	if chimeFor == nil {
+		return
+	}
+	if path := chimeFor(n.POI.Category); path != "" {
+		cs.SetNextChime(path)
+	}
+}
+
+// Stop lets the narration that is playing finish, bounded by the shutdown
+// grace period, and starts nothing new in the meantime.
 func (o *Orchestrator) Stop() {
+	o.mu.Lock()
+	o.draining = true
+	grace := o.shutdownGrace
+	active := o.active
+	o.mu.Unlock()
+
+	if grace > 0 && active {
+		slog.Info("Orchestrator: Waiting for the current narration to finish", "grace", grace)
+		if !o.waitIdle(grace) {
+			slog.Warn("Orchestrator: Shutdown grace period expired, cutting the narration", "grace", grace)
+		}
+	}
 	o.audio.Shutdown()
 }
 
+// waitIdle reports whether playback went idle within timeout.
+func (o *Orchestrator) waitIdle(timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	tick := time.NewTicker(20 * time.Millisecond)
+	defer tick.Stop()
+	for {
+		if !o.isPlaying() {
+			return true
+		}
+		select {
+		case <-deadline.C:
+			return !o.isPlaying()
+		case <-tick.C:
+		}
+	}
+}
+
+// isPlaying reports whether a narration is playing, ignoring the queue and
+// any generation in flight that IsActive counts.
+func (o *Orchestrator) isPlaying() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.active
+}
+
+func (o *Orchestrator) isDraining() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.draining
+}
+
 func (o *Orchestrator) IsActive() bool {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -121,11 +281,7 @@ func (o *Orchestrator) PlayPOI(ctx context.Context, poiID string, manual, enqueu
 	if o.beaconSvc != nil {
 		if pm := o.POIManager(); pm != nil {
 			if p, err := pm.GetPOI(ctx, poiID); err == nil && p != nil {
-				o.assignBeaconColor(p)
-				entry := o.getRegistryEntryByColor(p.BeaconColor)
-				if entry != nil {
-					_ = o.beaconSvc.SetTarget(ctx, p.Lat, p.Lon, entry.Title, entry.Livery)
-				}
+				_ = o.pointBeaconAt(ctx, p)
 			}
 		}
 	}
@@ -195,6 +351,14 @@ func (o *Orchestrator) PlayCity(ctx context.Context, name string) {
 	slog.Warn("Orchestrator: No local settlement match found for city narration", "name", name)
 }
 
+func (o *Orchestrator) PlayApproachAlert(ctx context.Context, p *model.POI, tel *sim.Telemetry) {
+	if ai, ok := o.gen.(interface {
+		PlayApproachAlert(ctx context.Context, p *model.POI, tel *sim.Telemetry)
+	}); ok {
+		ai.PlayApproachAlert(ctx, p, tel)
+	}
+}
+
 func (o *Orchestrator) PrepareNextNarrative(ctx context.Context, poiID, strategy string, tel *sim.Telemetry) error {
 	if ai, ok := o.gen.(interface {
 		PrepareNextNarrative(ctx context.Context, poiID, strategy string, tel *sim.Telemetry) error
@@ -243,7 +407,7 @@ func (o *Orchestrator) GenerateNarrative(ctx context.Context, req *GenerationReq
 }
 
 func (o *Orchestrator) ProcessPlaybackQueue(ctx context.Context) {
-	if o.IsPaused() {
+	if o.IsPaused() || o.isDraining() {
 		return
 	}
 
@@ -256,8 +420,18 @@ func (o *Orchestrator) ProcessPlaybackQueue(ctx context.Context) {
 		o.mu.Unlock()
 		return
 	}
+	pacer := o.pacer
 	o.mu.Unlock()
 
+	// A pipelined narration is ready early; its start still waits for the gap
+	if head := o.q.Peek(); pacer != nil && head != nil && !head.Manual {
+		if wait := pacer.Wait(); wait > 0 {
+			slog.Debug("Orchestrator: Holding next narration for the gap", "wait", wait)
+			time.AfterFunc(wait, func() { o.ProcessPlaybackQueue(ctx) })
+			return
+		}
+	}
+
 	next := o.q.Pop()
 	if next == nil {
 		return
@@ -277,14 +451,48 @@ func (o *Orchestrator) PlayNarrative(ctx context.Context, n *model.Narrative) er
 	}
 	o.mu.Unlock()
 
-	audioFile := o.setPlaybackState(n)
+	return o.startPlayback(ctx, n)
+}
 
-	if err := o.audio.Play(audioFile, false, o.finalizePlayback); err != nil {
+// startPlayback plays n regardless of the active flag, so a crossfade can
+// hand over from the narration that is still fading out.
+func (o *Orchestrator) startPlayback(ctx context.Context, n *model.Narrative) error {
+	if o.isDraining() {
+		return fmt.Errorf("shutting down")
+	}
+	audioFile := o.setPlaybackState(n)
+	o.mu.RLock()
+	seq := o.playSeq
+	o.mu.RUnlock()
+	// Announced before Play: the completion callback may fire before Play returns
+	o.notifyState(PlaybackStarted)
+
+	onComplete := func() {
+		// A narration replaced by a crossfade must not finalize its successor;
+		// watchCrossfade already recorded it at the handover.
+		o.mu.RLock()
+		superseded := o.playSeq != seq
+		skipped := o.skippedSeq == seq
+		o.mu.RUnlock()
+		if superseded {
+			return
+		}
+		if !skipped {
+			go o.recordPlayed(n)
+		}
+		o.finalizePlayback()
+	}
+	o.selectChime(n)
+	if err := o.audio.Play(audioFile, false, onComplete); err != nil {
+		o.notifyState(PlaybackStopped)
 		o.mu.Lock()
 		o.active = false
 		o.mu.Unlock()
 		return err
 	}
+	if fade := o.crossfadeDuration(); fade > 0 {
+		go o.watchCrossfade(seq, fade, n)
+	}
 
 	// Post-play logic (session, state, logging)
 	if n.POI != nil {
@@ -296,14 +504,22 @@ func (o *Orchestrator) PlayNarrative(ctx context.Context, n *model.Narrative) er
 		// Spawn colored beacon in MSFS
 		o.assignBeaconColor(n.POI)
 		if o.beaconSvc != nil {
-			entry := o.getRegistryEntryByColor(n.POI.BeaconColor)
-			if entry != nil {
-				go func() {
-					if err := o.beaconSvc.SetTarget(context.Background(), n.POI.Lat, n.POI.Lon, entry.Title, entry.Livery); err != nil {
-						slog.Error("Orchestrator: Failed to spawn beacon", "error", err)
-					}
-				}()
-			}
+			go func() {
+				if err := o.pointBeaconAt(context.Background(), n.POI); err != nil {
+					slog.Error("Orchestrator: Failed to spawn beacon", "error", err)
+				}
+			}()
+		}
+	}
+	// Template fallbacks and approach alerts are not narrations in their own
+	// right: keep them out of the trip history and the narrated count.
+	if n.Fallback || n.Type == model.NarrativeTypeApproach {
+		return nil
+	}
+	if o.sessionMgr != nil {
+		o.sessionMgr.IncrementCount()
+		if n.POI != nil {
+			o.sessionMgr.SetLastNarratedPOI(n.POI.Lat, n.POI.Lon)
 		}
 	}
 	// Record the event
@@ -312,6 +528,21 @@ func (o *Orchestrator) PlayNarrative(ctx context.Context, n *model.Narrative) er
 	return nil
 }
 
+// recordPlayed adds a narration to the narration history once its playback
+// ended, so the history lists only what was actually heard: a narrative
+// generated but dropped from the queue never shows up. Like the narrated
+// count, it leaves out template fallbacks and approach alerts.
+func (o *Orchestrator) recordPlayed(n *model.Narrative) {
+	if n.Fallback || n.Type == model.NarrativeTypeApproach {
+		return
+	}
+	if r, ok := o.gen.(interface {
+		RecordNarrationStats(ctx context.Context, n *model.Narrative)
+	}); ok {
+		r.RecordNarrationStats(context.Background(), n)
+	}
+}
+
 func (o *Orchestrator) setPlaybackState(n *model.Narrative) string {
 	ext := "." + n.Format
 	audioFile := n.AudioPath
@@ -323,6 +554,7 @@ func (o *Orchestrator) setPlaybackState(n *model.Narrative) string {
 	defer o.mu.Unlock()
 
 	o.active = true
+	o.playSeq++
 	o.currentTitle = n.Title
 	o.currentType = n.Type
 	o.currentDuration = n.Duration
@@ -346,13 +578,21 @@ func (o *Orchestrator) setPlaybackState(n *model.Narrative) string {
 }
 
 func (o *Orchestrator) finalizePlayback() {
+	o.mu.RLock()
+	pacer := o.pacer
+	o.mu.RUnlock()
+	if pacer != nil {
+		pacer.Finished()
+	}
+
 	// If Skip was called, audio.Stop() should have triggered finalizePlayback
 	// via the onComplete callback. We just need to make sure we don't sleep
-	// if we're skipping.
-	if !o.ShouldSkipCooldown() {
+	// if we're skipping. Nothing follows once shutdown began, so no pause either.
+	if !o.ShouldSkipCooldown() && !o.isDraining() {
 		time.Sleep(o.pacingDuration)
 	}
 
+	o.notifyState(PlaybackStopped)
 	o.mu.Lock()
 	o.active = false
 	o.currentPOI = nil
@@ -370,22 +610,14 @@ func (o *Orchestrator) finalizePlayback() {
 		// If next in queue is a POI, point the beacon there
 		if next != nil && next.POI != nil {
 			slog.Info("Orchestrator: Switching marker to next queued POI", "qid", next.POI.WikidataID)
-			o.assignBeaconColor(next.POI)
-			entry := o.getRegistryEntryByColor(next.POI.BeaconColor)
-			if entry != nil {
-				_ = o.beaconSvc.SetTarget(context.Background(), next.POI.Lat, next.POI.Lon, entry.Title, entry.Livery)
-			}
+			_ = o.pointBeaconAt(context.Background(), next.POI)
 		} else if ai, ok := o.gen.(interface {
 			GetPreparedPOI() *model.POI
 		}); ok {
 			generating := ai.GetPreparedPOI()
 			if generating != nil {
 				slog.Info("Orchestrator: Switching marker to currently generating POI", "qid", generating.WikidataID)
-				o.assignBeaconColor(generating)
-				entry := o.getRegistryEntryByColor(generating.BeaconColor)
-				if entry != nil {
-					_ = o.beaconSvc.SetTarget(context.Background(), generating.Lat, generating.Lon, entry.Title, entry.Livery)
-				}
+				_ = o.pointBeaconAt(context.Background(), generating)
 			}
 		}
 	}
@@ -396,7 +628,55 @@ func (o *Orchestrator) finalizePlayback() {
 func (o *Orchestrator) SkipCooldown()            { o.skipCooldown = true }
 func (o *Orchestrator) ShouldSkipCooldown() bool { return o.skipCooldown }
 func (o *Orchestrator) ResetSkipCooldown()       { o.skipCooldown = false }
-func (o *Orchestrator) IsPaused() bool           { return o.audio.IsUserPaused() }
+func (o *Orchestrator) IsPaused() bool {
+	return o.audio.IsUserPaused() || o.audio.HoldReason() != ""
+}
+
+// Hold starts or ends a narration hold, e.g. while the pilot is on the
+// radio. Nothing new starts while a hold is active; the queue picks up again
+// once the last hold ends, or once the hold has lasted the max hold time.
+func (o *Orchestrator) Hold(ctx context.Context, reason string, on bool) {
+	o.armHoldTimer(reason, on)
+	o.audio.SetHold(reason, on)
+	if !on {
+		go o.ProcessPlaybackQueue(ctx)
+	}
+}
+
+// armHoldTimer (re)starts the forced release of the hold for reason, or
+// cancels it when the hold ends. Renewing a hold restarts its clock.
+func (o *Orchestrator) armHoldTimer(reason string, on bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if t, ok := o.holdTimers[reason]; ok {
+		t.Stop()
+		delete(o.holdTimers, reason)
+	}
+	if !on || o.maxHold <= 0 {
+		return
+	}
+	if o.holdTimers == nil {
+		o.holdTimers = make(map[string]*time.Timer)
+	}
+	maxHold := o.maxHold
+	var timer *time.Timer
+	timer = time.AfterFunc(maxHold, func() {
+		o.mu.Lock()
+		current := o.holdTimers[reason] == timer
+		o.mu.Unlock()
+		if !current {
+			return // Released or renewed in the meantime
+		}
+		slog.Warn("Orchestrator: Hold not released in time, resuming narration", "reason", reason, "max_hold", maxHold)
+		o.Hold(context.Background(), reason, false)
+	})
+	o.holdTimers[reason] = timer
+}
+
+// HoldReason returns the active hold reasons, or "" when not held.
+func (o *Orchestrator) HoldReason() string { return o.audio.HoldReason() }
+
 func (o *Orchestrator) CurrentPOI() *model.POI {
 	o.mu.RLock()
 	defer o.mu.RUnlock()
@@ -471,13 +751,45 @@ func (o *Orchestrator) Skip() {
 		o.mu.Unlock()
 		return
 	}
+	// Stop fires the completion callback, which must not count a skipped
+	// narration as heard.
+	o.skippedSeq = o.playSeq
 	o.mu.Unlock()
 
 	slog.Info("Orchestrator: Skipping current narration", "title", o.currentTitle)
+	o.notifyState(PlaybackSkipped)
 	o.SkipCooldown()
 	o.audio.Stop()
 	// audio.Stop() will trigger finalizePlayback via the onComplete callback
 }
+
+// SkipAndSuppress keeps the POI from being narrated again this session and
+// drops any queued narration about it. An empty poiID means the POI playing
+// now. The current narration is skipped only if it is about that POI.
+func (o *Orchestrator) SkipAndSuppress(poiID string) {
+	o.mu.RLock()
+	current := o.currentPOI
+	o.mu.RUnlock()
+
+	if poiID == "" && current != nil {
+		poiID = current.WikidataID
+	}
+	if poiID == "" {
+		o.Skip()
+		return
+	}
+
+	if o.sessionMgr != nil {
+		o.sessionMgr.SuppressPOI(poiID)
+	}
+	removed := o.q.RemovePOI(poiID)
+	slog.Info("Orchestrator: POI suppressed for this session", "qid", poiID, "dropped_from_queue", removed)
+
+	if current != nil && current.WikidataID == poiID {
+		o.Skip()
+	}
+}
+
 func (o *Orchestrator) TriggerIdentAction() {
 	// Implement based on what we see in AIService
 }
@@ -518,6 +830,21 @@ func (o *Orchestrator) AverageLatency() time.Duration {
 	return 60 * time.Second
 }
 
+// ApplyPredictionWindow re-applies the sim lookahead after a config change.
+func (o *Orchestrator) ApplyPredictionWindow() {
+	if ai, ok := o.gen.(interface{ ApplyPredictionWindow() }); ok {
+		ai.ApplyPredictionWindow()
+	}
+}
+
+// TTSHealth reports whether the configured TTS engine is still in use.
+func (o *Orchestrator) TTSHealth() error {
+	if ai, ok := o.gen.(interface{ TTSHealth() error }); ok {
+		return ai.TTSHealth()
+	}
+	return nil
+}
+
 func (o *Orchestrator) Reset(ctx context.Context) {
 	o.mu.Lock()
 	o.q.Clear()
@@ -567,6 +894,29 @@ func (o *Orchestrator) PlayEssay(ctx context.Context, tel *sim.Telemetry) bool {
 	return o.gen.PlayEssay(ctx, tel)
 }
 
+// TopicEssayPlayer narrates essays on a chosen topic.
+type TopicEssayPlayer interface {
+	HasEssayTopic(topicID string) bool
+	PlayEssayTopic(ctx context.Context, topicID string, tel *sim.Telemetry, enqueue bool) error
+}
+
+// PlayEssayTopic narrates an essay on the given topic, if the generator
+// supports it. The generator only knows whether it is busy generating, so a
+// narration still playing is checked here.
+func (o *Orchestrator) PlayEssayTopic(ctx context.Context, topicID string, tel *sim.Telemetry, enqueue bool) error {
+	p, ok := o.gen.(TopicEssayPlayer)
+	if !ok {
+		return errors.New("essay topics not supported by the generator")
+	}
+	if !p.HasEssayTopic(topicID) {
+		return fmt.Errorf("%w: %s", ErrUnknownEssayTopic, topicID)
+	}
+	if !enqueue && o.IsPlaying() {
+		return ErrNarratorBusy
+	}
+	return p.PlayEssayTopic(ctx, topicID, tel, enqueue)
+}
+
 // DataProvider Implementation (Delegated to Generator)
 func (o *Orchestrator) GetLocation(lat, lon float64) model.LocationInfo {
 	if ai, ok := o.gen.(announcement.DataProvider); ok {
@@ -642,6 +992,49 @@ func (o *Orchestrator) assignBeaconColor(p *model.POI) {
 	slog.Info("Orchestrator: Assigned beacon color to POI", "poi", p.WikidataID, "color", key, "hex", p.BeaconColor)
 }
 
+// SetMarkQueuedBeacons enables marking queued POIs alongside the current one.
+func (o *Orchestrator) SetMarkQueuedBeacons(enabled bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.markQueued = enabled
+}
+
+// pointBeaconAt marks p with its colored beacon. With queued marking enabled,
+// every queued POI gets a beacon too and p is passed first so it is highlighted
+// as the primary target (formation, altitude tracking).
+func (o *Orchestrator) pointBeaconAt(ctx context.Context, p *model.POI) error {
+	o.assignBeaconColor(p)
+	entry := o.getRegistryEntryByColor(p.BeaconColor)
+	if entry == nil {
+		return nil
+	}
+
+	o.mu.RLock()
+	markQueued := o.markQueued
+	o.mu.RUnlock()
+
+	multi, ok := o.beaconSvc.(MultiBeaconProvider)
+	if !markQueued || !ok {
+		return o.beaconSvc.SetTarget(ctx, p.Lat, p.Lon, entry.Title, entry.Livery)
+	}
+
+	points := []geo.Point{{Lat: p.Lat, Lon: p.Lon}}
+	objects := []beacon.TargetObject{{Title: entry.Title, Livery: entry.Livery}}
+	for _, q := range o.q.QueuedPOIs() {
+		if q.WikidataID == p.WikidataID {
+			continue
+		}
+		o.assignBeaconColor(q)
+		qEntry := o.getRegistryEntryByColor(q.BeaconColor)
+		if qEntry == nil {
+			continue
+		}
+		points = append(points, geo.Point{Lat: q.Lat, Lon: q.Lon})
+		objects = append(objects, beacon.TargetObject{Title: qEntry.Title, Livery: qEntry.Livery})
+	}
+	return multi.SetTargets(ctx, points, objects)
+}
+
 func (o *Orchestrator) getRegistryEntryByColor(hexColor string) *config.BeaconRegistryEntry {
 	for _, entry := range o.beaconRegistry {
 		if entry.MapColor == hexColor {
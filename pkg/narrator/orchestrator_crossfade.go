@@ -0,0 +1,65 @@
+package narrator
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"phileasgo/pkg/model"
+)
+
+// crossfadePoll is how often the tail of a narration is checked for the
+// crossfade window. Coarse enough to be cheap, fine enough for short fades.
+const crossfadePoll = 50 * time.Millisecond
+
+// crossfadeDuration returns the audio backend's crossfade, if it has one.
+func (o *Orchestrator) crossfadeDuration() time.Duration {
+	if cf, ok := o.audio.(interface{ CrossfadeDuration() time.Duration }); ok {
+		return cf.CrossfadeDuration()
+	}
+	return 0
+}
+
+// watchCrossfade starts the next queued narration once the current one is
+// within the crossfade window, so the audio layer can blend the two instead
+// of playing them back-to-back. Nothing happens if the queue is empty then;
+// the narration finishes and the pacing pause applies as usual. The audio
+// layer drops the completion callback of a faded-out clip, so the outgoing
+// narration is recorded as played here.
+func (o *Orchestrator) watchCrossfade(seq uint64, fade time.Duration, outgoing *model.Narrative) {
+	ticker := time.NewTicker(crossfadePoll)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		o.mu.RLock()
+		current := o.active && o.playSeq == seq
+		o.mu.RUnlock()
+		if !current {
+			return
+		}
+		if o.IsPaused() || o.audio.IsPaused() {
+			continue
+		}
+		remaining := o.audio.Remaining()
+		if remaining <= 0 {
+			return
+		}
+		if remaining > fade {
+			continue
+		}
+
+		next := o.q.Pop()
+		if next == nil {
+			return
+		}
+		slog.Debug("Orchestrator: Crossfading into next narration", "from", o.CurrentTitle(), "to", next.Title)
+		o.notifyState(PlaybackStopped)
+		if err := o.startPlayback(context.Background(), next); err != nil {
+			slog.Error("Orchestrator: Crossfade playback failed", "error", err)
+			go o.ProcessPlaybackQueue(context.Background())
+			return
+		}
+		go o.recordPlayed(outgoing)
+		return
+	}
+}
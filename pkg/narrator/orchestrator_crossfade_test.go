@@ -0,0 +1,111 @@
+package narrator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/playback"
+)
+
+// crossfadeAudio keeps every narration "playing" inside the crossfade window
+// and holds on to completion callbacks so the test decides when clips end.
+type crossfadeAudio struct {
+	MockAudio
+	cbMu      sync.Mutex
+	callbacks []func()
+}
+
+func (m *crossfadeAudio) Play(filepath string, startPaused bool, onComplete func()) error {
+	m.mu.Lock()
+	m.PlayCalls++
+	m.LastFile = filepath
+	m.mu.Unlock()
+	m.cbMu.Lock()
+	m.callbacks = append(m.callbacks, onComplete)
+	m.cbMu.Unlock()
+	return nil
+}
+
+func (m *crossfadeAudio) Remaining() time.Duration         { return 200 * time.Millisecond }
+func (m *crossfadeAudio) CrossfadeDuration() time.Duration { return 500 * time.Millisecond }
+
+func (m *crossfadeAudio) playCalls() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.PlayCalls
+}
+
+func TestOrchestrator_CrossfadeHandover(t *testing.T) {
+	aud := &crossfadeAudio{}
+	q := playback.NewManager()
+	gen := &statsRecordingGen{recorded: make(chan *model.Narrative, 2)}
+	o := NewOrchestrator(gen, aud, q, nil, nil, nil, nil, nil)
+	o.pacingDuration = 0
+
+	var evMu sync.Mutex
+	var events []string
+	o.SetOnStateChange(func(e PlaybackEvent) {
+		evMu.Lock()
+		events = append(events, e.State+":"+e.Title)
+		evMu.Unlock()
+	})
+
+	q.Enqueue(&model.Narrative{Type: model.NarrativeTypePOI, Title: "Second", AudioPath: "b", Format: "mp3"}, false)
+	first := &model.Narrative{Type: model.NarrativeTypePOI, Title: "First", AudioPath: "a", Format: "mp3"}
+	if err := o.PlayNarrative(context.Background(), first); err != nil {
+		t.Fatalf("PlayNarrative failed: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for aud.playCalls() < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if aud.playCalls() != 2 {
+		t.Fatalf("queued narration was not started inside the crossfade window")
+	}
+	if got := o.CurrentTitle(); got != "Second" {
+		t.Errorf("current title = %q, want Second", got)
+	}
+
+	// The first clip completing late must not finalize its successor
+	aud.cbMu.Lock()
+	firstDone := aud.callbacks[0]
+	aud.cbMu.Unlock()
+	firstDone()
+	o.mu.RLock()
+	active := o.active
+	o.mu.RUnlock()
+	if !active || o.CurrentTitle() != "Second" {
+		t.Errorf("superseded completion cleared the playing narration")
+	}
+
+	// The faded-out narration is recorded once, at the handover
+	select {
+	case got := <-gen.recorded:
+		if got != first {
+			t.Errorf("recorded %q, want First", got.Title)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected the faded-out narration to be recorded")
+	}
+	select {
+	case got := <-gen.recorded:
+		t.Errorf("unexpected second record %q", got.Title)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	evMu.Lock()
+	defer evMu.Unlock()
+	want := []string{"start:First", "stop:First", "start:Second"}
+	if len(events) != len(want) {
+		t.Fatalf("got events %v, want %v", events, want)
+	}
+	for i := range want {
+		if events[i] != want[i] {
+			t.Errorf("event %d = %q, want %q", i, events[i], want[i])
+		}
+	}
+}
@@ -2,10 +2,16 @@ package narrator
 
 import (
 	"context"
+	"errors"
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/playback"
 	"phileasgo/pkg/session"
+	"phileasgo/pkg/sim"
+	"sync"
 	"testing"
+	"time"
 )
 
 // TestBeaconNotClearedOnPlayback verifies that playing any narrative
@@ -75,3 +81,368 @@ func TestOrchestrator_Pause(t *testing.T) {
 		t.Error("Expected no playback while paused")
 	}
 }
+
+func TestOrchestrator_PointBeaconAt_MarkQueued(t *testing.T) {
+	registry := config.BeaconRegistry{
+		"red":  {Title: "Balloon", Livery: "RED", MapColor: "#ff0000"},
+		"blue": {Title: "Balloon", Livery: "BLUE", MapColor: "#0000ff"},
+	}
+	current := &model.POI{WikidataID: "Q1", Lat: 1, Lon: 1}
+	queued := &model.POI{WikidataID: "Q2", Lat: 2, Lon: 2}
+
+	tests := []struct {
+		name        string
+		markQueued  bool
+		wantTargets []geo.Point
+		wantSingle  bool
+	}{
+		{name: "Disabled uses single target", markQueued: false, wantSingle: true},
+		{name: "Enabled marks current first, then queue", markQueued: true, wantTargets: []geo.Point{{Lat: 1, Lon: 1}, {Lat: 2, Lon: 2}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current.BeaconColor, queued.BeaconColor = "", ""
+			mockBeacon := &MockBeacon{}
+			pbQ := playback.NewManager()
+			pbQ.Enqueue(&model.Narrative{Type: model.NarrativeTypePOI, POI: queued}, false)
+			pbQ.Enqueue(&model.Narrative{Type: model.NarrativeTypePOI, POI: current}, false)
+
+			o := NewOrchestrator(&MockAIService{}, &MockAudio{}, pbQ, nil, mockBeacon, nil, registry, []string{"red", "blue"})
+			o.SetMarkQueuedBeacons(tt.markQueued)
+
+			if err := o.pointBeaconAt(context.Background(), current); err != nil {
+				t.Fatalf("pointBeaconAt failed: %v", err)
+			}
+
+			if mockBeacon.TargetSet != tt.wantSingle {
+				t.Errorf("SetTarget called = %v, want %v", mockBeacon.TargetSet, tt.wantSingle)
+			}
+			if len(mockBeacon.Targets) != len(tt.wantTargets) {
+				t.Fatalf("got %d targets, want %d", len(mockBeacon.Targets), len(tt.wantTargets))
+			}
+			for i, p := range tt.wantTargets {
+				if mockBeacon.Targets[i] != p {
+					t.Errorf("target %d = %v, want %v", i, mockBeacon.Targets[i], p)
+				}
+			}
+		})
+	}
+}
+
+func TestOrchestrator_StateChangeEvents(t *testing.T) {
+	o := NewOrchestrator(&MockAIService{}, &MockAudio{PlaySync: true}, playback.NewManager(), nil, nil, nil, nil, nil)
+	o.pacingDuration = 0
+
+	var events []PlaybackEvent
+	o.SetOnStateChange(func(e PlaybackEvent) { events = append(events, e) })
+
+	poi := &model.POI{WikidataID: "Q243"}
+	n := &model.Narrative{Type: model.NarrativeTypePOI, Title: "Eiffel Tower", POI: poi, AudioPath: "test_audio", Format: "mp3"}
+	if err := o.PlayNarrative(context.Background(), n); err != nil {
+		t.Fatalf("PlayNarrative failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2: %+v", len(events), events)
+	}
+	if events[0].State != PlaybackStarted || events[0].POI != poi || events[0].Title != "Eiffel Tower" {
+		t.Errorf("unexpected start event: %+v", events[0])
+	}
+	if events[1].State != PlaybackStopped || events[1].POI != poi {
+		t.Errorf("unexpected stop event: %+v", events[1])
+	}
+}
+
+func TestOrchestrator_SkipAndSuppress(t *testing.T) {
+	playing := &model.POI{WikidataID: "Q1"}
+	tests := []struct {
+		name       string
+		poiID      string
+		wantQID    string
+		wantSkip   bool
+		wantQueued int
+	}{
+		{"Current POI", "", "Q1", true, 1},
+		{"Same POI by ID", "Q1", "Q1", true, 1},
+		{"Other POI keeps playing", "Q2", "Q2", false, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess := session.NewManager(nil)
+			o := NewOrchestrator(&MockAIService{}, &MockAudio{}, playback.NewManager(), sess, nil, nil, nil, nil)
+			o.active = true
+			o.currentPOI = playing
+			o.q.Enqueue(&model.Narrative{POI: &model.POI{WikidataID: "Q1"}}, false)
+			o.q.Enqueue(&model.Narrative{POI: &model.POI{WikidataID: "Q2"}}, false)
+
+			o.SkipAndSuppress(tt.poiID)
+
+			if !sess.IsSuppressed(tt.wantQID) {
+				t.Errorf("expected %s to be suppressed", tt.wantQID)
+			}
+			if o.q.HasPOI(tt.wantQID) || o.q.Count() != tt.wantQueued {
+				t.Errorf("expected %s dropped from the queue, %d left", tt.wantQID, o.q.Count())
+			}
+			if o.ShouldSkipCooldown() != tt.wantSkip {
+				t.Errorf("expected skip=%v, got %v", tt.wantSkip, o.ShouldSkipCooldown())
+			}
+		})
+	}
+}
+
+// chimeAudio records the chime selected for each clip.
+type chimeAudio struct {
+	MockAudio
+	chimes []string
+}
+
+func (m *chimeAudio) SetNextChime(path string) {
+	m.chimes = append(m.chimes, path)
+}
+
+func TestOrchestrator_CategoryChime(t *testing.T) {
+	catCfg := &config.CategoriesConfig{Categories: map[string]config.Category{
+		"castle": {Chime: "data/chimes/castle.mp3"},
+		"lake":   {},
+	}}
+
+	tests := []struct {
+		name      string
+		narrative *model.Narrative
+		want      []string // Chimes handed to audio; none means the default plays
+	}{
+		{name: "Mapped category", narrative: &model.Narrative{Type: model.NarrativeTypePOI, POI: &model.POI{Category: "Castle"}}, want: []string{"data/chimes/castle.mp3"}},
+		{name: "Category without a chime", narrative: &model.Narrative{Type: model.NarrativeTypePOI, POI: &model.POI{Category: "Lake"}}},
+		{name: "Unknown category", narrative: &model.Narrative{Type: model.NarrativeTypePOI, POI: &model.POI{Category: "Volcano"}}},
+		{name: "No POI", narrative: &model.Narrative{Type: model.NarrativeTypeDebriefing}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aud := &chimeAudio{}
+			o := NewOrchestrator(&MockAIService{}, aud, playback.NewManager(), nil, nil, nil, nil, nil)
+			o.pacingDuration = 0
+			o.SetChimeResolver(catCfg.ChimePath)
+
+			tt.narrative.AudioPath = "clip"
+			tt.narrative.Format = "mp3"
+			if err := o.PlayNarrative(context.Background(), tt.narrative); err != nil {
+				t.Fatalf("PlayNarrative failed: %v", err)
+			}
+			if len(aud.chimes) != len(tt.want) || (len(tt.want) > 0 && aud.chimes[0] != tt.want[0]) {
+				t.Errorf("chimes = %v, want %v", aud.chimes, tt.want)
+			}
+		})
+	}
+}
+
+// fixedPacer holds auto-narrations back for a fixed wait.
+type fixedPacer struct {
+	mu       sync.Mutex
+	wait     time.Duration
+	finished int
+}
+
+func (p *fixedPacer) Finished() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.finished++
+}
+
+func (p *fixedPacer) Wait() time.Duration {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.wait
+}
+
+func TestOrchestrator_PacerHoldsAutoNarration(t *testing.T) {
+	tests := []struct {
+		name     string
+		manual   bool
+		wait     time.Duration
+		wantPlay bool
+	}{
+		{name: "Gap passed", wantPlay: true},
+		{name: "Auto narration waits for the gap", wait: time.Hour},
+		{name: "Manual narration is not held", manual: true, wait: time.Hour, wantPlay: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aud := &MockAudio{}
+			q := playback.NewManager()
+			o := NewOrchestrator(&MockAIService{}, aud, q, nil, nil, nil, nil, nil)
+			o.SetPacer(&fixedPacer{wait: tt.wait})
+
+			q.Enqueue(&model.Narrative{Type: model.NarrativeTypePOI, Title: "Next", AudioPath: "a", Format: "mp3", Manual: tt.manual}, false)
+			o.ProcessPlaybackQueue(context.Background())
+
+			aud.mu.RLock()
+			played := aud.PlayCalls > 0
+			aud.mu.RUnlock()
+			if played != tt.wantPlay {
+				t.Errorf("played = %v, want %v", played, tt.wantPlay)
+			}
+			if !tt.wantPlay && q.Count() != 1 {
+				t.Errorf("queue count = %d, want the narration kept", q.Count())
+			}
+		})
+	}
+}
+
+// statsRecordingGen records the narratives handed to RecordNarrationStats.
+type statsRecordingGen struct {
+	MockAIService
+	recorded chan *model.Narrative
+}
+
+func (g *statsRecordingGen) RecordNarrationStats(ctx context.Context, n *model.Narrative) {
+	g.recorded <- n
+}
+
+func TestOrchestrator_RecordsStatsAfterPlayback(t *testing.T) {
+	tests := []struct {
+		name       string
+		narrative  *model.Narrative
+		skip       bool
+		wantRecord bool
+	}{
+		{
+			name:       "Played narration is recorded",
+			narrative:  &model.Narrative{Type: model.NarrativeTypePOI, Title: "Eiffel Tower"},
+			wantRecord: true,
+		},
+		{
+			name:      "Skipped narration is not recorded",
+			narrative: &model.Narrative{Type: model.NarrativeTypePOI, Title: "Eiffel Tower"},
+			skip:      true,
+		},
+		{
+			name:      "Template fallback is not recorded",
+			narrative: &model.Narrative{Type: model.NarrativeTypePOI, Title: "Eiffel Tower", Fallback: true},
+		},
+		{
+			name:      "Approach alert is not recorded",
+			narrative: &model.Narrative{Type: model.NarrativeTypeApproach, Title: "Approach"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &statsRecordingGen{recorded: make(chan *model.Narrative, 1)}
+			aud := &crossfadeAudio{} // Holds the completion callback until the test fires it
+			o := NewOrchestrator(gen, aud, playback.NewManager(), nil, nil, nil, nil, nil)
+			o.pacingDuration = 0
+
+			n := tt.narrative
+			n.AudioPath, n.Format = "test_audio", "mp3"
+			if err := o.PlayNarrative(context.Background(), n); err != nil {
+				t.Fatalf("PlayNarrative failed: %v", err)
+			}
+			if tt.skip {
+				o.Skip()
+			}
+			aud.cbMu.Lock()
+			done := aud.callbacks[0]
+			aud.cbMu.Unlock()
+			done()
+
+			select {
+			case got := <-gen.recorded:
+				if !tt.wantRecord {
+					t.Errorf("recorded %q, want nothing", got.Title)
+				} else if got != n {
+					t.Errorf("recorded %q, want the played narrative", got.Title)
+				}
+			case <-time.After(200 * time.Millisecond):
+				if tt.wantRecord {
+					t.Error("expected the stats to be recorded after playback")
+				}
+			}
+		})
+	}
+}
+
+func TestOrchestrator_HoldReleasedAfterMaxHold(t *testing.T) {
+	tests := []struct {
+		name     string
+		maxHold  time.Duration
+		release  bool // The caller ends the hold itself
+		wantHeld bool
+	}{
+		{name: "Forgotten hold is released", maxHold: 30 * time.Millisecond, wantHeld: false},
+		{name: "No max hold waits for the release", maxHold: 0, wantHeld: true},
+		{name: "Released hold stays released", maxHold: 30 * time.Millisecond, release: true, wantHeld: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aud := &MockAudio{}
+			o := NewOrchestrator(&MockAIService{}, aud, playback.NewManager(), nil, nil, nil, nil, nil)
+			o.SetMaxHold(tt.maxHold)
+
+			o.Hold(context.Background(), "comms", true)
+			if !o.IsPaused() {
+				t.Fatal("expected narration held")
+			}
+			if tt.release {
+				o.Hold(context.Background(), "comms", false)
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			if got := o.HoldReason() != ""; got != tt.wantHeld {
+				t.Errorf("held = %v, want %v", got, tt.wantHeld)
+			}
+		})
+	}
+}
+
+// essayTopicGen knows a single essay topic and records what was requested.
+type essayTopicGen struct {
+	MockAIService
+	requested string
+}
+
+func (g *essayTopicGen) HasEssayTopic(topicID string) bool { return topicID == "cuisine" }
+
+func (g *essayTopicGen) PlayEssayTopic(ctx context.Context, topicID string, tel *sim.Telemetry, enqueue bool) error {
+	g.requested = topicID
+	return nil
+}
+
+func TestOrchestrator_PlayEssayTopic(t *testing.T) {
+	tests := []struct {
+		name      string
+		topic     string
+		playing   bool
+		enqueue   bool
+		wantErr   error
+		wantTopic string
+	}{
+		{name: "Idle", topic: "cuisine", wantTopic: "cuisine"},
+		{name: "Busy playing", topic: "cuisine", playing: true, wantErr: ErrNarratorBusy},
+		{name: "Playing with enqueue", topic: "cuisine", playing: true, enqueue: true, wantTopic: "cuisine"},
+		{name: "Unknown topic while playing", topic: "volcanoes", playing: true, wantErr: ErrUnknownEssayTopic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := &essayTopicGen{}
+			o := NewOrchestrator(gen, &MockAudio{IsPlayingVal: tt.playing}, playback.NewManager(), nil, nil, nil, nil, nil)
+
+			err := o.PlayEssayTopic(context.Background(), tt.topic, nil, tt.enqueue)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+			} else if err != nil {
+				t.Fatalf("PlayEssayTopic failed: %v", err)
+			}
+			if gen.requested != tt.wantTopic {
+				t.Errorf("requested topic = %q, want %q", gen.requested, tt.wantTopic)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package narrator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/playback"
+)
+
+// heldAudio keeps each clip playing until the test completes it and records
+// when the audio was shut down.
+type heldAudio struct {
+	MockAudio
+	cbMu       sync.Mutex
+	onComplete func()
+	shutdown   bool
+}
+
+func (m *heldAudio) Play(filepath string, startPaused bool, onComplete func()) error {
+	m.mu.Lock()
+	m.PlayCalls++
+	m.mu.Unlock()
+	m.cbMu.Lock()
+	m.onComplete = onComplete
+	m.cbMu.Unlock()
+	return nil
+}
+
+func (m *heldAudio) Shutdown() {
+	m.cbMu.Lock()
+	defer m.cbMu.Unlock()
+	m.shutdown = true
+}
+
+func (m *heldAudio) complete() {
+	m.cbMu.Lock()
+	cb := m.onComplete
+	m.cbMu.Unlock()
+	cb()
+}
+
+func (m *heldAudio) isShutdown() bool {
+	m.cbMu.Lock()
+	defer m.cbMu.Unlock()
+	return m.shutdown
+}
+
+func TestOrchestrator_StopDrainsPlayback(t *testing.T) {
+	tests := []struct {
+		name       string
+		grace      time.Duration
+		finishClip bool
+		maxStop    time.Duration // Upper bound on how long Stop may take
+	}{
+		{name: "Clip finishes within grace", grace: 2 * time.Second, finishClip: true, maxStop: time.Second},
+		{name: "Grace expires", grace: 100 * time.Millisecond, maxStop: time.Second},
+		{name: "No grace cuts immediately", grace: 0, maxStop: 50 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aud := &heldAudio{}
+			q := playback.NewManager()
+			o := NewOrchestrator(&MockAIService{}, aud, q, nil, nil, nil, nil, nil)
+			o.pacingDuration = 0
+			o.SetShutdownGrace(tt.grace)
+
+			if err := o.PlayNarrative(context.Background(), &model.Narrative{Type: model.NarrativeTypePOI, Title: "Current", AudioPath: "a", Format: "mp3"}); err != nil {
+				t.Fatalf("PlayNarrative failed: %v", err)
+			}
+			q.Enqueue(&model.Narrative{Type: model.NarrativeTypePOI, Title: "Next", AudioPath: "b", Format: "mp3"}, false)
+
+			start := time.Now()
+			stopped := make(chan struct{})
+			go func() {
+				o.Stop()
+				close(stopped)
+			}()
+
+			if tt.finishClip {
+				time.Sleep(100 * time.Millisecond)
+				select {
+				case <-stopped:
+					t.Fatal("Stop returned while the clip was still playing")
+				default:
+				}
+				if aud.isShutdown() {
+					t.Fatal("audio shut down while the clip was still playing")
+				}
+				aud.complete()
+			}
+
+			select {
+			case <-stopped:
+			case <-time.After(tt.maxStop + tt.grace):
+				t.Fatal("Stop did not return")
+			}
+			if elapsed := time.Since(start); elapsed > tt.maxStop {
+				t.Errorf("Stop took %v, want at most %v", elapsed, tt.maxStop)
+			}
+			if !aud.isShutdown() {
+				t.Error("audio was not shut down")
+			}
+
+			// Nothing new may start once shutdown began
+			o.ProcessPlaybackQueue(context.Background())
+			time.Sleep(20 * time.Millisecond)
+			if got := aud.PlayCalls; got != 1 {
+				t.Errorf("play calls = %d, want 1", got)
+			}
+			if err := o.PlayNarrative(context.Background(), &model.Narrative{Type: model.NarrativeTypePOI, Title: "Late", AudioPath: "c", Format: "mp3"}); err == nil {
+				t.Error("PlayNarrative succeeded after Stop")
+			}
+		})
+	}
+}
@@ -104,20 +104,22 @@ func (m *MockAudioService) Play(path string, skipChecks bool, onComplete func())
 	}
 	return nil
 }
-func (m *MockAudioService) Stop()                     {}
-func (m *MockAudioService) Shutdown()                 {}
-func (m *MockAudioService) Pause()                    {}
-func (m *MockAudioService) Resume()                   {}
-func (m *MockAudioService) Skip()                     {}
-func (m *MockAudioService) IsPlaying() bool           { return m.IsBusyVal }
-func (m *MockAudioService) IsBusy() bool              { return m.IsBusyVal }
-func (m *MockAudioService) IsPaused() bool            { return false }
-func (m *MockAudioService) SetVolume(vol float64)     {}
-func (m *MockAudioService) Volume() float64           { return 1.0 }
-func (m *MockAudioService) SetUserPaused(paused bool) {}
-func (m *MockAudioService) IsUserPaused() bool        { return false }
-func (m *MockAudioService) ResetUserPause()           {}
-func (m *MockAudioService) LastNarrationFile() string { return "" }
+func (m *MockAudioService) Stop()                          {}
+func (m *MockAudioService) Shutdown()                      {}
+func (m *MockAudioService) Pause()                         {}
+func (m *MockAudioService) Resume()                        {}
+func (m *MockAudioService) Skip()                          {}
+func (m *MockAudioService) IsPlaying() bool                { return m.IsBusyVal }
+func (m *MockAudioService) IsBusy() bool                   { return m.IsBusyVal }
+func (m *MockAudioService) IsPaused() bool                 { return false }
+func (m *MockAudioService) SetVolume(vol float64)          {}
+func (m *MockAudioService) Volume() float64                { return 1.0 }
+func (m *MockAudioService) SetUserPaused(paused bool)      {}
+func (m *MockAudioService) IsUserPaused() bool             { return false }
+func (m *MockAudioService) ResetUserPause()                {}
+func (m *MockAudioService) SetHold(reason string, on bool) {}
+func (m *MockAudioService) HoldReason() string             { return "" }
+func (m *MockAudioService) LastNarrationFile() string      { return "" }
 func (m *MockAudioService) ReplayLastNarration(onComplete func()) bool {
 	if m.ShouldReplay && onComplete != nil {
 		go onComplete()
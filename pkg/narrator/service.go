@@ -35,6 +35,8 @@ type Service interface {
 	IsPlaying() bool
 	// PlayPOI triggers narration for a specific POI.
 	PlayPOI(ctx context.Context, poiID string, manual, enqueueIfBusy bool, tel *sim.Telemetry, strategy string)
+	// PlayApproachAlert speaks a short "coming up" alert for a landmark ahead.
+	PlayApproachAlert(ctx context.Context, p *model.POI, tel *sim.Telemetry)
 	// PrepareNextNarrative prepares a narrative for a POI and stages it for later playback.
 	PrepareNextNarrative(ctx context.Context, poiID, strategy string, tel *sim.Telemetry) error
 	// GetPreparedPOI returns the POI currently staged or generating, if any.
@@ -347,6 +349,11 @@ func (s *StubService) CurrentImagePath() string {
 	return ""
 }
 
+// PlayApproachAlert announces an approaching landmark (stub: no-op).
+func (s *StubService) PlayApproachAlert(ctx context.Context, p *model.POI, tel *sim.Telemetry) {
+	slog.Info("Narrator stub: Approach alert requested")
+}
+
 // IsPOIBusy returns true if the POI is currently generating, queued, or playing (stub: false).
 func (s *StubService) IsPOIBusy(poiID string) bool {
 	return false
@@ -367,6 +374,11 @@ func (s *StubService) Skip() {
 	slog.Info("Narrator stub: Skip requested")
 }
 
+// SkipAndSuppress logs the request in the stub service.
+func (s *StubService) SkipAndSuppress(poiID string) {
+	slog.Info("Narrator stub: Skip and suppress requested", "poi_id", poiID)
+}
+
 // TriggerIdentAction triggers the ident action in the stub service.
 func (s *StubService) TriggerIdentAction() {
 	slog.Info("Narrator stub: Ident action triggered")
@@ -111,6 +111,8 @@ type AIService struct {
 	fallbackTracker *tracker.Tracker
 
 	enricher POIEnricher
+
+	voices config.VoiceMap // Per-language voices; nil uses the engine voices
 }
 
 // NewAIService creates a new AI-powered narrator generator.
@@ -147,7 +149,7 @@ func NewAIService(
 		langRes:         langRes,
 		categoriesCfg:   categoriesCfg,
 		stats:           make(map[string]any),
-		latencies:       make([]time.Duration, 0, 10),
+		latencies:       make([]time.Duration, 0, maxLatencySamples),
 		essayH:          essayH,
 		interests:       interests,
 		avoid:           avoid,
@@ -157,8 +159,9 @@ func NewAIService(
 		genQ:            generation.NewManager(),
 		enricher:        enricher,
 	}
-	// Initial default window
-	s.sim.SetPredictionWindow(60 * time.Second)
+	// Initial default window, replaced by the previous session's if recent
+	s.sim.SetPredictionWindow(s.cfg.PredictionWindow(context.Background()))
+	s.restoreLatencies(context.Background())
 
 	s.promptAssembler = prompt.NewAssembler(
 		cfg,
@@ -179,6 +182,12 @@ func NewAIService(
 	return s
 }
 
+// SetFactSource supplies the Wikidata facts quoted in POI prompts.
+func (s *AIService) SetFactSource(facts prompt.FactSource) {
+	s.initAssembler()
+	s.promptAssembler.SetFactSource(facts)
+}
+
 // SetOnPlayback sets the callback for when a narrative is ready for playback.
 func (s *AIService) SetOnPlayback(cb func(n *model.Narrative, priority bool)) {
 	s.mu.Lock()
@@ -227,6 +236,7 @@ func (s *AIService) AudioService() audio.Service {
 func (s *AIService) initAssembler() {
 	if s.promptAssembler == nil {
 		s.promptAssembler = prompt.NewAssembler(s.cfg, s.st, s.prompts, s.geoSvc, s.wikipedia, s.poiMgr, s.llm, s.categoriesCfg, s.cfg.AppConfig().LLM.Fallback, s.langRes, s.density, s.interests, s.avoid)
+		s.promptAssembler.SetLatencySource(s.AverageLatency)
 	}
 }
 
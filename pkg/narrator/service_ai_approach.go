@@ -0,0 +1,131 @@
+package narrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	"phileasgo/pkg/audio"
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/sim"
+)
+
+// approachAlert is the first phase of a two-phase landmark narration. In
+// English it is rendered from this template instead of generated: it has to
+// be spoken while the landmark is still ahead, and LLM latency would eat
+// into that window. Other narration languages get the same sentence from
+// narrator/approach.tmpl.
+var approachAlert = template.Must(template.New("approach").Parse(
+	"Coming up {{.Direction}}: {{.Name}}, about {{.Distance}}."))
+
+// approachAlertTimeout bounds the LLM call for a non-English alert. A late
+// alert is worse than none: the full narration follows once abeam anyway.
+const approachAlertTimeout = 10 * time.Second
+
+// PlayApproachAlert announces a landmark the vehicle is approaching. The full
+// narration is triggered separately once the landmark is abeam.
+func (s *AIService) PlayApproachAlert(ctx context.Context, p *model.POI, tel *sim.Telemetry) {
+	if p == nil || tel == nil {
+		return
+	}
+
+	go func() {
+		script, language, err := s.approachAlertScript(context.Background(), p, tel)
+		if err != nil {
+			slog.Error("Narrator: Failed to render approach alert", "poi", p.WikidataID, "error", err)
+			return
+		}
+
+		safeID := "approach_" + strings.ReplaceAll(p.WikidataID, "/", "_")
+		audioPath, format, err := s.synthesizeAudio(context.Background(), script, safeID, language)
+		if err != nil {
+			s.handleTTSError(err)
+			return
+		}
+		duration, _ := audio.GetDuration(audioPath)
+
+		slog.Info("Narrator: Approach alert ready", "poi", p.DisplayName())
+		// No POI attached: playback would put the landmark on cooldown and
+		// suppress the full narration that follows.
+		s.enqueuePlayback(&model.Narrative{
+			Type:      model.NarrativeTypeApproach,
+			Title:     p.DisplayName(),
+			Script:    script,
+			AudioPath: audioPath,
+			Format:    format,
+			Duration:  duration,
+			Lat:       p.Lat,
+			Lon:       p.Lon,
+			CreatedAt: time.Now(),
+		}, true)
+	}()
+}
+
+// approachAlertScript returns the alert in the narration language, along
+// with that language for TTS voice selection.
+func (s *AIService) approachAlertScript(ctx context.Context, p *model.POI, tel *sim.Telemetry) (script, language string, err error) {
+	pos := geo.Point{Lat: tel.Latitude, Lon: tel.Longitude}
+	target := geo.Point{Lat: p.Lat, Lon: p.Lon}
+	relBearing := geo.NormalizeAngle(geo.Bearing(pos, target) - tel.Heading)
+
+	direction := "ahead"
+	switch {
+	case relBearing <= -20:
+		direction = "on your left"
+	case relBearing >= 20:
+		direction = "on your right"
+	}
+
+	data := s.AssembleGeneric(ctx, tel)
+	data["Direction"] = direction
+	data["Name"] = p.DisplayName()
+	data["Distance"] = spokenDistance(geo.Distance(pos, target), s.cfg.VehicleMode(ctx), s.cfg.DistanceUnits(ctx))
+	language, _ = data["Language"].(string)
+
+	if code, _ := data["Language_code"].(string); code == "" || code == "en" {
+		var sb strings.Builder
+		err = approachAlert.Execute(&sb, data)
+		return sb.String(), language, err
+	}
+
+	promptBody, err := s.prompts.Render("narrator/approach.tmpl", data)
+	if err != nil {
+		return "", language, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, approachAlertTimeout)
+	defer cancel()
+	script, err = s.llm.GenerateText(ctx, "announcements", promptBody)
+	if err != nil {
+		return "", language, err
+	}
+	script = strings.TrimSpace(strings.Trim(strings.TrimSpace(script), `"`))
+	if script == "" {
+		return "", language, errors.New("empty approach alert")
+	}
+	return script, language, nil
+}
+
+// spokenDistance phrases a distance for TTS using the same unit choice as the
+// prompt navigation instructions: nautical miles at sea and for imperial
+// pilots, statute miles for imperial drivers, kilometers otherwise.
+func spokenDistance(meters float64, mode, distUnits string) string {
+	value, unit := meters/1000.0, "kilometer"
+	switch {
+	case mode == config.VehicleModeMarine, distUnits == config.UnitsImperial && mode != config.VehicleModeGround:
+		value, unit = meters/1852.0, "nautical mile"
+	case distUnits == config.UnitsImperial:
+		value, unit = meters/1609.344, "mile"
+	}
+	n := math.Max(1, math.Round(value))
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", int(n), unit)
+}
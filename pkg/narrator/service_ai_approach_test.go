@@ -0,0 +1,106 @@
+package narrator
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/session"
+	"phileasgo/pkg/sim"
+)
+
+func TestSpokenDistance(t *testing.T) {
+	tests := []struct {
+		name    string
+		meters  float64
+		mode    string
+		unitSys string
+		want    string
+	}{
+		{"Metric aircraft", 8200, config.VehicleModeAircraft, "metric", "8 kilometers"},
+		{"Imperial aircraft", 9260, config.VehicleModeAircraft, "imperial", "5 nautical miles"},
+		{"Imperial ground", 8047, config.VehicleModeGround, "imperial", "5 miles"},
+		{"Marine ignores units", 1852, config.VehicleModeMarine, "metric", "1 nautical mile"},
+		{"Never below one", 200, config.VehicleModeAircraft, "hybrid", "1 kilometer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spokenDistance(tt.meters, tt.mode, tt.unitSys); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAIService_ApproachAlertScript(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	pm, err := prompts.NewManager(filepath.Join(filepath.Dir(filename), "..", "..", "configs", "prompts"))
+	if err != nil {
+		t.Fatalf("Failed to load production templates: %v", err)
+	}
+	p := &model.POI{WikidataID: "Q1", NameEn: "Mont Blanc", Lat: 45.9, Lon: 6.9}
+
+	tests := []struct {
+		name     string
+		language string
+		heading  float64
+		llmReply string
+		llmErr   error
+		want     string
+		wantLLM  bool
+		wantErr  bool
+	}{
+		{"Dead ahead", "en-US", 90, "", nil, "Coming up ahead: Mont Blanc, about 8 kilometers.", false, false},
+		{"Off the left", "en-GB", 150, "", nil, "Coming up on your left: Mont Blanc, about 8 kilometers.", false, false},
+		{"Off the right", "en-US", 30, "", nil, "Coming up on your right: Mont Blanc, about 8 kilometers.", false, false},
+		{"German via prompt", "de-DE", 150, "\"Gleich links: Mont Blanc, etwa 8 Kilometer.\"\n", nil, "Gleich links: Mont Blanc, etwa 8 Kilometer.", true, false},
+		{"LLM failure drops the alert", "de-DE", 150, "", errors.New("timeout"), "", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.Units = "metric"
+			cfg.Narrator.ActiveTargetLanguage = tt.language
+
+			var gotPrompt, gotProfile string
+			mockLLM := &MockLLM{
+				GenerateTextFunc: func(ctx context.Context, name, prompt string) (string, error) {
+					gotProfile, gotPrompt = name, prompt
+					return tt.llmReply, tt.llmErr
+				},
+			}
+			svc := NewAIService(config.NewProvider(cfg, nil), mockLLM, &MockTTS{}, pm, &MockPOIProvider{}, &MockGeo{}, &MockSim{}, &MockStore{}, nil, nil, nil, nil, nil, nil, nil, session.NewManager(nil), nil, nil)
+
+			tel := &sim.Telemetry{Latitude: 45.9, Longitude: 6.8, Heading: tt.heading}
+			got, language, err := svc.approachAlertScript(context.Background(), p, tel)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("approachAlertScript error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if language != tt.language {
+				t.Errorf("got language %q, want %q", language, tt.language)
+			}
+			if (gotPrompt != "") != tt.wantLLM {
+				t.Fatalf("LLM called = %v, want %v", gotPrompt != "", tt.wantLLM)
+			}
+			if tt.wantLLM {
+				if gotProfile != "announcements" {
+					t.Errorf("expected the announcements profile, got %q", gotProfile)
+				}
+				for _, want := range []string{"Mont Blanc", "on your left", "8 kilometers", "(de)"} {
+					if !strings.Contains(gotPrompt, want) {
+						t.Errorf("expected the prompt to contain %q", want)
+					}
+				}
+			}
+		})
+	}
+}
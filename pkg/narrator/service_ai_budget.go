@@ -0,0 +1,108 @@
+package narrator
+
+import (
+	"log/slog"
+	"strings"
+	"unicode/utf8"
+
+	"phileasgo/pkg/prompt"
+)
+
+// defaultTrimOrder applies when the budget is set but no order is configured.
+var defaultTrimOrder = []string{"RecentContext", "WikipediaText"}
+
+// estimateTokens approximates a model tokenizer in one pass over the text:
+// about four characters per token for Latin script. Other scripts are counted
+// a token per character, which overestimates most of them but keeps CJK text,
+// where that is close to the truth, within the budget.
+func estimateTokens(text string) int {
+	ascii, other := 0, 0
+	for _, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+	}
+	return (ascii+3)/4 + other
+}
+
+// trimToTokens shortens text to about maxTokens, preferring to end on a
+// sentence so the model isn't handed half a fact.
+func trimToTokens(text string, maxTokens int) string {
+	if maxTokens <= 0 {
+		return ""
+	}
+	ascii, other, cut := 0, 0, len(text)
+	for i, r := range text {
+		if r < utf8.RuneSelf {
+			ascii++
+		} else {
+			other++
+		}
+		if (ascii+3)/4+other > maxTokens {
+			cut = i
+			break
+		}
+	}
+	if cut == len(text) {
+		return text
+	}
+
+	head := text[:cut]
+	if i := strings.LastIndex(head, ". "); i > len(head)/2 {
+		head = head[:i+1]
+	} else if i := strings.LastIndexAny(head, " \n"); i > 0 {
+		head = head[:i]
+	}
+	return head + " …"
+}
+
+// renderWithinBudget renders a prompt and, when its estimated size exceeds the
+// configured budget, trims the low-priority sections of pd in the configured
+// order and renders again. pd keeps the trimmed sections, so a second pass
+// built from it stays within the budget too.
+func (s *AIService) renderWithinBudget(tmpl string, pd prompt.Data) (string, error) {
+	out, err := s.prompts.Render(tmpl, pd)
+	if err != nil {
+		return "", err
+	}
+
+	llmCfg := s.cfg.AppConfig().LLM
+	budget := llmCfg.PromptTokenBudget
+	before := estimateTokens(out)
+	if budget <= 0 || before <= budget {
+		return out, nil
+	}
+
+	order := llmCfg.PromptTrimOrder
+	if len(order) == 0 {
+		order = defaultTrimOrder
+	}
+
+	var trimmed []string
+	for _, key := range order {
+		excess := estimateTokens(out) - budget
+		if excess <= 0 {
+			break
+		}
+		text, ok := pd[key].(string)
+		if !ok || text == "" {
+			continue
+		}
+		// Leave room for the ellipsis marking the cut
+		pd[key] = trimToTokens(text, estimateTokens(text)-excess-2)
+		trimmed = append(trimmed, key)
+		if out, err = s.prompts.Render(tmpl, pd); err != nil {
+			return "", err
+		}
+	}
+
+	after := estimateTokens(out)
+	if after > budget {
+		slog.Warn("Narrator: Prompt exceeds the token budget after trimming", "tmpl", tmpl, "sections", trimmed, "tokens_before", before, "tokens", after, "budget", budget)
+	} else {
+		slog.Info("Narrator: Trimmed prompt to fit the token budget", "tmpl", tmpl, "sections", trimmed, "tokens_before", before, "tokens", after, "budget", budget)
+	}
+	return out, nil
+}
@@ -0,0 +1,72 @@
+package narrator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/prompt"
+)
+
+func TestRenderWithinBudget(t *testing.T) {
+	tmpDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(tmpDir, "narrator"), 0o755)
+	_ = os.MkdirAll(filepath.Join(tmpDir, "common"), 0o755)
+	tmpl := `Narrate {{.POINameUser}}.
+INSTRUCTION: Keep it under {{.MaxWords}} words.
+Recent: {{.RecentContext}}
+WP: {{.WikipediaText}}`
+	_ = os.WriteFile(filepath.Join(tmpDir, "narrator", "script.tmpl"), []byte(tmpl), 0o644)
+	pm, err := prompts.NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to init prompt manager: %v", err)
+	}
+
+	sentence := "The castle was rebuilt after the fire of 1742. "
+	tests := []struct {
+		name       string
+		budget     int
+		recent     string
+		wp         string
+		wantRecent bool // RecentContext survives
+		wantWP     bool // WikipediaText survives, at least in part
+		wantTrim   bool
+	}{
+		{name: "Within budget", budget: 500, recent: "Old Mill", wp: sentence, wantRecent: true, wantWP: true},
+		{name: "Recent context goes first", budget: 500, recent: strings.Repeat("Old Mill, ", 200), wp: sentence, wantWP: true, wantTrim: true},
+		{name: "Then WP text is shortened", budget: 500, recent: strings.Repeat("Old Mill, ", 200), wp: strings.Repeat(sentence, 200), wantWP: true, wantTrim: true},
+		{name: "Disabled", budget: 0, recent: strings.Repeat("Old Mill, ", 200), wp: strings.Repeat(sentence, 200), wantRecent: true, wantWP: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.LLM.PromptTokenBudget = tt.budget
+			s := &AIService{cfg: config.NewProvider(cfg, nil), prompts: pm}
+			pd := prompt.Data{"POINameUser": "Burg Eltz", "MaxWords": 100, "RecentContext": tt.recent, "WikipediaText": tt.wp}
+
+			out, err := s.renderWithinBudget("narrator/script.tmpl", pd)
+			if err != nil {
+				t.Fatalf("renderWithinBudget failed: %v", err)
+			}
+			if tt.budget > 0 && estimateTokens(out) > tt.budget {
+				t.Errorf("prompt estimated at %d tokens, budget %d", estimateTokens(out), tt.budget)
+			}
+			if !strings.Contains(out, "Narrate Burg Eltz.") || !strings.Contains(out, "INSTRUCTION: Keep it under 100 words.") {
+				t.Errorf("POI name or instruction lost:\n%s", out)
+			}
+			if got := pd["RecentContext"] == tt.recent; got != tt.wantRecent {
+				t.Errorf("RecentContext kept = %v, want %v", got, tt.wantRecent)
+			}
+			if got := strings.Contains(out, "The castle was rebuilt"); got != tt.wantWP {
+				t.Errorf("WP text kept = %v, want %v", got, tt.wantWP)
+			}
+			if got := strings.Contains(out, "…") || pd["RecentContext"] == ""; got != tt.wantTrim {
+				t.Errorf("trimmed = %v, want %v", got, tt.wantTrim)
+			}
+		})
+	}
+}
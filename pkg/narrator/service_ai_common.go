@@ -12,7 +12,7 @@ import (
 	"phileasgo/pkg/tts"
 )
 
-func (s *AIService) synthesizeAudio(ctx context.Context, script, safeID string) (audioPath, format string, err error) {
+func (s *AIService) synthesizeAudio(ctx context.Context, script, safeID, language string) (audioPath, format string, err error) {
 	// Use system temp directory instead of persistent cache
 	cacheDir := os.TempDir()
 
@@ -20,8 +20,12 @@ func (s *AIService) synthesizeAudio(ctx context.Context, script, safeID string)
 	outputPath := filepath.Join(cacheDir, fmt.Sprintf("phileas_narration_%s_%d", safeID, time.Now().UnixNano()))
 
 	ttsProvider := s.getTTSProvider()
-	voiceID := s.getVoiceID()
-	format, err = ttsProvider.Synthesize(ctx, script, voiceID, outputPath)
+	voiceID := s.getVoiceID(language)
+	if s.cfg.DialogueMode(ctx) {
+		format, err = s.synthesizeDialogue(ctx, ttsProvider, script, voiceID, outputPath)
+	} else {
+		format, err = ttsProvider.Synthesize(ctx, script, voiceID, outputPath)
+	}
 	if err != nil {
 		return "", "", err
 	}
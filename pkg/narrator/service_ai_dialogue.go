@@ -0,0 +1,62 @@
+package narrator
+
+import (
+	"context"
+	"strings"
+
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/tts"
+)
+
+// synthesizeDialogue speaks a guide/passenger script. Engines that can append
+// audio and have a passenger voice configured get one request per turn in
+// alternating voices; any other engine reads the script in the guide's voice.
+func (s *AIService) synthesizeDialogue(ctx context.Context, prov tts.Provider, script, voiceID, outputPath string) (string, error) {
+	turns := tts.SplitSpeakerTurns(script)
+	ap, ok := prov.(tts.AppendProvider)
+	passengerVoice := s.getPassengerVoiceID()
+	if !ok || passengerVoice == "" || !hasPassengerTurn(turns) {
+		return prov.Synthesize(ctx, tts.StripSpeakerLabels(script), voiceID, outputPath)
+	}
+
+	var format string
+	for _, turn := range turns {
+		voice := voiceID
+		if strings.EqualFold(turn.Speaker, prompt.PassengerName) {
+			voice = passengerVoice
+		}
+		f, err := ap.SynthesizeAppend(ctx, turn.Text, voice, outputPath)
+		if err != nil {
+			return "", err
+		}
+		format = f
+	}
+	return format, nil
+}
+
+func hasPassengerTurn(turns []tts.Turn) bool {
+	for _, t := range turns {
+		if strings.EqualFold(t.Speaker, prompt.PassengerName) {
+			return true
+		}
+	}
+	return false
+}
+
+// getPassengerVoiceID returns the passenger voice for the active TTS engine,
+// or "" if the engine has none.
+func (s *AIService) getPassengerVoiceID() string {
+	appCfg := s.cfg.AppConfig()
+	if s.isUsingFallbackTTS() {
+		return appCfg.TTS.EdgeTTS.PassengerVoiceID
+	}
+
+	switch appCfg.TTS.Engine {
+	case "azure-speech":
+		return appCfg.TTS.AzureSpeech.PassengerVoiceID
+	case "edge-tts":
+		return appCfg.TTS.EdgeTTS.PassengerVoiceID
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,92 @@
+package narrator
+
+import (
+	"context"
+	"testing"
+
+	"phileasgo/pkg/config"
+)
+
+// voiceTTS records the voice and text of every append.
+type voiceTTS struct {
+	appendTTS
+	VoiceIDs []string
+}
+
+func (m *voiceTTS) SynthesizeAppend(ctx context.Context, text, voiceID, outputPath string) (string, error) {
+	m.VoiceIDs = append(m.VoiceIDs, voiceID)
+	return m.appendTTS.SynthesizeAppend(ctx, text, voiceID, outputPath)
+}
+
+func TestAIService_SynthesizeDialogue(t *testing.T) {
+	const script = "Ava: Look to the left.\nAndrew: Is that the lake?\nAva: It is."
+
+	tests := []struct {
+		name           string
+		dialogue       bool
+		passengerVoice string
+		wantVoices     []string
+		wantSingleText string
+	}{
+		{
+			name:           "Alternating voices",
+			dialogue:       true,
+			passengerVoice: "voice-m",
+			wantVoices:     []string{"voice-f", "voice-m", "voice-f"},
+		},
+		{
+			name:           "No passenger voice strips labels",
+			dialogue:       true,
+			wantSingleText: "Look to the left.\nIs that the lake?\nIt is.",
+		},
+		{
+			name:           "Dialogue mode off",
+			passengerVoice: "voice-m",
+			wantSingleText: script,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.DialogueMode = tt.dialogue
+			cfg.TTS.Engine = "edge-tts"
+			cfg.TTS.EdgeTTS.VoiceID = "voice-f"
+			cfg.TTS.EdgeTTS.PassengerVoiceID = tt.passengerVoice
+
+			prov := &voiceTTS{}
+			var singleText string
+			prov.SynthesizeFunc = func(ctx context.Context, text, voiceID, outputPath string) (string, error) {
+				singleText = text
+				prov.SynthesizeFunc = nil
+				return prov.MockTTS.Synthesize(ctx, text, voiceID, outputPath)
+			}
+			svc := &AIService{cfg: config.NewProvider(cfg, nil), tts: prov}
+
+			if _, _, err := svc.synthesizeAudio(context.Background(), script, "test", ""); err != nil {
+				t.Fatalf("synthesizeAudio failed: %v", err)
+			}
+
+			if tt.wantVoices != nil {
+				if len(prov.VoiceIDs) != len(tt.wantVoices) {
+					t.Fatalf("expected %d turns, got voices %v", len(tt.wantVoices), prov.VoiceIDs)
+				}
+				for i, v := range tt.wantVoices {
+					if prov.VoiceIDs[i] != v {
+						t.Errorf("turn %d: expected voice %s, got %s", i, v, prov.VoiceIDs[i])
+					}
+				}
+				if prov.Chunks[1] != "Is that the lake?" {
+					t.Errorf("expected the label stripped from the turn, got %q", prov.Chunks[1])
+				}
+				return
+			}
+			if len(prov.VoiceIDs) != 0 {
+				t.Errorf("expected a single synthesis, got appends %v", prov.VoiceIDs)
+			}
+			if singleText != tt.wantSingleText {
+				t.Errorf("expected text %q, got %q", tt.wantSingleText, singleText)
+			}
+		})
+	}
+}
@@ -2,12 +2,23 @@ package narrator
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
+	"phileasgo/pkg/generation"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/sim"
 )
 
+var (
+	// ErrUnknownEssayTopic is returned for a topic ID not in essays.yaml.
+	ErrUnknownEssayTopic = errors.New("unknown essay topic")
+	// ErrNarratorBusy is returned when a request can't start right away.
+	ErrNarratorBusy = errors.New("narrator busy")
+)
+
 // PlayEssay triggers a regional essay narration.
 func (s *AIService) PlayEssay(ctx context.Context, tel *sim.Telemetry) bool {
 	if s.essayH == nil {
@@ -28,19 +39,110 @@ func (s *AIService) PlayEssay(ctx context.Context, tel *sim.Telemetry) bool {
 
 	slog.Info("Narrator: Triggering Essay")
 
-	topic, err := s.essayH.SelectTopic()
+	var loc *model.LocationInfo
+	if s.cfg.EssayRegionalTopics(ctx) {
+		loc = s.essayLocation(ctx, tel)
+	}
+	s.applyEssayThemes(ctx)
+	s.essayH.SetStopWhenExhausted(s.cfg.EssayStopWhenExhausted(ctx))
+	s.essayH.SetUsedTopics(s.session().EssayTopics())
+	topic, err := s.essayH.SelectTopic(loc)
+	if errors.Is(err, ErrEssayTopicsExhausted) {
+		slog.Info("Narrator: Every essay topic played this session, no more essays")
+		return false
+	}
 	if err != nil {
 		slog.Error("Narrator: Failed to select essay topic", "error", err)
 		return false
 	}
+	s.session().SetEssayTopics(s.essayH.UsedTopics())
 
 	go s.narrateEssay(context.Background(), topic, tel)
 	return true
 }
 
-func (s *AIService) narrateEssay(ctx context.Context, topic *EssayTopic, tel *sim.Telemetry) {
-	s.initAssembler()
+// applyEssayThemes activates the session's essay themes, choosing them on
+// the first essay of a session. They live in the session state so a restart
+// mid-flight keeps the same themes.
+func (s *AIService) applyEssayThemes(ctx context.Context) {
+	if !s.cfg.EssayThemedSessions(ctx) {
+		s.essayH.SetThemes(nil)
+		return
+	}
+	themes := s.session().EssayThemes()
+	if len(themes) == 0 {
+		themes = s.essayH.PickThemes(s.cfg.EssayThemesPerSession(ctx))
+		s.session().SetEssayThemes(themes)
+	}
+	s.essayH.SetThemes(themes)
+}
+
+// essayLocation resolves the region below the aircraft for topic selection.
+func (s *AIService) essayLocation(ctx context.Context, tel *sim.Telemetry) *model.LocationInfo {
+	if s.geoSvc == nil {
+		return nil
+	}
+	if tel == nil {
+		t, err := s.sim.GetTelemetry(ctx)
+		if err != nil {
+			return nil
+		}
+		tel = &t
+	}
+	loc := s.geoSvc.GetLocation(tel.Latitude, tel.Longitude)
+	return &loc
+}
+
+// PlayEssayTopic narrates an essay on the topic with the given ID, bypassing
+// the rotation. While another narration is being generated it fails with
+// ErrNarratorBusy, unless enqueue is set, in which case the essay waits its
+// turn in the generation queue.
+func (s *AIService) PlayEssayTopic(ctx context.Context, topicID string, tel *sim.Telemetry, enqueue bool) error {
+	if !s.HasEssayTopic(topicID) {
+		return fmt.Errorf("%w: %s", ErrUnknownEssayTopic, topicID)
+	}
+	if s.IsGenerating() && !enqueue {
+		return ErrNarratorBusy
+	}
+
+	slog.Info("Narrator: Essay requested", "topic", topicID, "enqueue", enqueue)
+	s.enqueueGeneration(&generation.Job{
+		Type:       model.NarrativeTypeEssay,
+		EssayTopic: topicID,
+		Manual:     true,
+		Telemetry:  tel,
+		CreatedAt:  time.Now(),
+	})
+	go s.ProcessGenerationQueue(context.Background())
+	return nil
+}
+
+// HasEssayTopic reports whether essays.yaml defines the topic.
+func (s *AIService) HasEssayTopic(topicID string) bool {
+	if s.essayH == nil {
+		return false
+	}
+	_, ok := s.essayH.Topic(topicID)
+	return ok
+}
+
+// handleEssayJob builds the request for an essay queued by PlayEssayTopic.
+func (s *AIService) handleEssayJob(ctx context.Context, job *generation.Job) *GenerationRequest {
+	topic, ok := s.essayH.Topic(job.EssayTopic)
+	if !ok {
+		slog.Error("Narrator: Essay job failed - topic not found", "topic", job.EssayTopic)
+		return nil
+	}
+	req, err := s.essayRequest(ctx, topic, job.Telemetry)
+	if err != nil {
+		slog.Error("Narrator: Failed to render essay prompt", "error", err)
+		return nil
+	}
+	req.Manual = job.Manual
+	return req
+}
 
+func (s *AIService) narrateEssay(ctx context.Context, topic *EssayTopic, tel *sim.Telemetry) {
 	slog.Info("Narrator: Narrating Essay", "topic", topic.Name)
 
 	if !s.claimGeneration(nil) {
@@ -48,6 +150,26 @@ func (s *AIService) narrateEssay(ctx context.Context, topic *EssayTopic, tel *si
 	}
 	defer s.releaseGeneration()
 
+	req, err := s.essayRequest(ctx, topic, tel)
+	if err != nil {
+		slog.Error("Narrator: Failed to render essay prompt", "error", err)
+		return
+	}
+
+	narrative, err := s.GenerateNarrative(ctx, req)
+	if err != nil {
+		slog.Error("Narrator: Essay generation failed", "error", err)
+		return
+	}
+
+	s.enqueuePlayback(narrative, false)
+}
+
+// essayRequest gathers the context around the aircraft and renders the
+// essay prompt for the topic.
+func (s *AIService) essayRequest(ctx context.Context, topic *EssayTopic, tel *sim.Telemetry) (*GenerationRequest, error) {
+	s.initAssembler()
+
 	// Gather Context
 	if tel == nil {
 		t, _ := s.sim.GetTelemetry(ctx)
@@ -66,11 +188,10 @@ func (s *AIService) narrateEssay(ctx context.Context, topic *EssayTopic, tel *si
 
 	prompt, err := s.essayH.BuildPrompt(ctx, topic, &pd)
 	if err != nil {
-		slog.Error("Narrator: Failed to render essay prompt", "error", err)
-		return
+		return nil, err
 	}
 
-	req := GenerationRequest{
+	return &GenerationRequest{
 		Type:          model.NarrativeTypeEssay,
 		Prompt:        prompt,
 		Title:         topic.Name,
@@ -81,13 +202,5 @@ func (s *AIService) narrateEssay(ctx context.Context, topic *EssayTopic, tel *si
 		SkipBusyCheck: true,
 		TwoPass:       s.cfg.TwoPassScriptGeneration(ctx),
 		PromptData:    pd,
-	}
-
-	narrative, err := s.GenerateNarrative(ctx, &req)
-	if err != nil {
-		slog.Error("Narrator: Essay generation failed", "error", err)
-		return
-	}
-
-	s.enqueuePlayback(narrative, false)
+	}, nil
 }
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/model"
 	"phileasgo/pkg/session"
 	"phileasgo/pkg/sim"
 )
@@ -110,3 +112,92 @@ func TestAIService_PlayEssay_NoHandler(t *testing.T) {
 		t.Error("Expected PlayEssay to return false when handler is nil")
 	}
 }
+
+func TestAIService_PlayEssayTopic(t *testing.T) {
+	tmpDir := t.TempDir()
+	essayCfgPath := filepath.Join(tmpDir, "essays.yaml")
+	_ = os.WriteFile(essayCfgPath, []byte(`
+topics:
+  - id: "flight"
+    name: "History of Flight"
+    max_words: 50
+  - id: "cuisine"
+    name: "Local Cuisine"
+    max_words: 50
+`), 0o644)
+	_ = os.MkdirAll(filepath.Join(tmpDir, "narrator"), 0o755)
+	_ = os.WriteFile(filepath.Join(tmpDir, "narrator", "essay.tmpl"), []byte("Write about {{.TopicName}}"), 0o644)
+	_ = os.MkdirAll(filepath.Join(tmpDir, "common"), 0o755)
+
+	pm, err := prompts.NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to create prompt manager: %v", err)
+	}
+	eh, err := NewEssayHandler(essayCfgPath, pm)
+	if err != nil {
+		t.Fatalf("Failed to create essay handler: %v", err)
+	}
+
+	newService := func(llm *MockLLM) *AIService {
+		cfg := config.NewProvider(&config.Config{Narrator: config.NarratorConfig{TargetLanguage: "en"}}, nil)
+		return NewAIService(cfg, llm, &MockTTS{Format: "mp3"}, pm, &MockPOIProvider{}, &MockGeo{Country: "France"},
+			&MockSim{Telemetry: sim.Telemetry{Latitude: 48.0, Longitude: 2.0}}, &MockStore{}, &MockWikipedia{},
+			nil, nil, eh, nil, nil, nil, session.NewManager(nil), nil, nil)
+	}
+
+	t.Run("Known topic is generated", func(t *testing.T) {
+		prompts := make(chan string, 1)
+		svc := newService(&MockLLM{GenerateJSONFunc: func(ctx context.Context, name, prompt string, target any) error {
+			select {
+			case prompts <- prompt:
+			default:
+			}
+			if res, ok := target.(*model.GenerationResponse); ok {
+				res.Script = "Essay Script"
+			}
+			return nil
+		}})
+		played := make(chan *model.Narrative, 1)
+		svc.SetOnPlayback(func(n *model.Narrative, priority bool) { played <- n })
+
+		if err := svc.PlayEssayTopic(context.Background(), "cuisine", nil, false); err != nil {
+			t.Fatalf("PlayEssayTopic failed: %v", err)
+		}
+
+		select {
+		case n := <-played:
+			if n.Type != model.NarrativeTypeEssay || n.EssayTopic != "Local Cuisine" {
+				t.Errorf("expected an essay on Local Cuisine, got %s on %q", n.Type, n.EssayTopic)
+			}
+			if !n.Manual {
+				t.Error("expected a requested essay to be manual")
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("essay was never generated")
+		}
+		if p := <-prompts; !strings.Contains(p, "Write about Local Cuisine") {
+			t.Errorf("expected the prompt to use the chosen topic, got %q", p)
+		}
+	})
+
+	t.Run("Unknown topic", func(t *testing.T) {
+		svc := newService(&MockLLM{Response: "Essay Script"})
+		if err := svc.PlayEssayTopic(context.Background(), "volcanoes", nil, false); !errors.Is(err, ErrUnknownEssayTopic) {
+			t.Errorf("expected ErrUnknownEssayTopic, got %v", err)
+		}
+	})
+
+	t.Run("Busy narrator", func(t *testing.T) {
+		svc := newService(&MockLLM{Response: "Essay Script"})
+		svc.generating = true
+		if err := svc.PlayEssayTopic(context.Background(), "flight", nil, false); !errors.Is(err, ErrNarratorBusy) {
+			t.Errorf("expected ErrNarratorBusy, got %v", err)
+		}
+		if err := svc.PlayEssayTopic(context.Background(), "flight", nil, true); err != nil {
+			t.Errorf("expected the essay to be queued, got %v", err)
+		}
+		if !svc.HasPendingGeneration() {
+			t.Error("expected the essay in the generation queue")
+		}
+	})
+}
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"phileasgo/pkg/audio"
+	"phileasgo/pkg/llm"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/request"
+	"phileasgo/pkg/store"
 )
 
 // GenerateNarrative creates a narrative from a standardized request.
@@ -21,10 +23,15 @@ func (s *AIService) GenerateNarrative(ctx context.Context, req *GenerationReques
 	}
 	startTime := time.Now()
 	predicted := s.AverageLatency()
+	var firstAudio time.Duration
 
 	// Defer Cleanup
 	defer func() {
 		actual := time.Since(startTime)
+		// Streamed narrations are ready once the first chunk is spoken
+		if firstAudio > 0 {
+			actual = firstAudio
+		}
 		s.updateLatency(actual)
 		s.mu.Lock()
 		s.generating = false
@@ -42,34 +49,74 @@ func (s *AIService) GenerateNarrative(ctx context.Context, req *GenerationReques
 	// PHASE 2: Improved logging for Wikipedia comparison
 	s.logWikipediaContext(req)
 
-	// 3. Generate Script (LLM)
-	resp, err := s.generateInitialScript(ctx, req)
-	if err != nil {
-		return nil, err
-	}
-
-	script := resp.Script
-	extractedTitle := resp.Title
-
-	// 4. Second Pass Refinement (if enabled)
-	if req.TwoPass {
-		script = s.performSecondPass(ctx, req, script)
-	} else {
-		// 5. Rescue Script (if too long) - Mutually exclusive with 2-pass
-		script = s.performRescueIfNeeded(ctx, req, script)
-	}
-
-	// 5. TTS Synthesis (with retries)
 	safeID := req.SafeID
 	if safeID == "" {
 		safeID = "gen_" + time.Now().Format("150405")
 	}
 
+	// 3. Generate Script (LLM), unless an identical prompt was answered before
+	cacheKey := s.scriptCacheKey(ctx, req)
+	var script, extractedTitle string
 	var audioPath, format string
+	fallback := false
+	if cached, ok := s.lookupCachedScript(ctx, cacheKey); ok {
+		slog.Info("Narrator: Using cached script", "poi", req.Title)
+		script = cached.Script
+		extractedTitle = cached.Title
+	} else if streamed, ok := s.generateStreamed(ctx, req, safeID, startTime); ok && streamed.Err == nil && !streamed.Unusable {
+		script = streamed.Script
+		extractedTitle = streamed.Title
+		audioPath, format = streamed.AudioPath, streamed.Format
+		firstAudio = streamed.FirstAudio
+		s.storeCachedScript(ctx, cacheKey, extractedTitle, script)
+	} else {
+		var resp model.GenerationResponse
+		var err error
+		switch {
+		case ok && streamed.Err != nil:
+			err = streamed.Err
+		case ok:
+			resp = model.GenerationResponse{Title: streamed.Title, Script: streamed.Script}
+		default:
+			resp, err = s.generateScriptWithDeadline(ctx, req)
+		}
+		if err == nil {
+			if resp, fallback, err = s.ensureUsableScript(ctx, req, resp); err != nil {
+				return nil, err
+			}
+		}
+		switch {
+		case err != nil:
+			blurb, ok := s.templateFallbackScript(ctx, req)
+			if !ok {
+				return nil, err
+			}
+			slog.Warn("Narrator: Script generation failed, using template fallback", "poi", req.Title, "error", err)
+			script = blurb
+			fallback = true
+		case fallback:
+			script = resp.Script
+		default:
+			script = resp.Script
+			extractedTitle = resp.Title
+
+			// 4. Second Pass Refinement (if enabled)
+			if req.TwoPass {
+				script = s.performSecondPass(ctx, req, script)
+			} else {
+				// 5. Rescue Script (if too long) - Mutually exclusive with 2-pass
+				script = s.performRescueIfNeeded(ctx, req, script)
+			}
+
+			s.storeCachedScript(ctx, cacheKey, extractedTitle, script)
+		}
+	}
+
+	// 5. TTS Synthesis (with retries), unless streaming already produced the audio
 	var synthErr error
 
-	for attempt := 1; attempt <= 3; attempt++ {
-		audioPath, format, synthErr = s.synthesizeAudio(ctx, script, safeID)
+	for attempt := 1; audioPath == "" && attempt <= 3; attempt++ {
+		audioPath, format, synthErr = s.synthesizeAudio(ctx, script, safeID, s.narrationLanguage(ctx, req))
 		if synthErr == nil {
 			break
 		}
@@ -94,7 +141,38 @@ func (s *AIService) GenerateNarrative(ctx context.Context, req *GenerationReques
 	// 6. Get Audio Duration
 	duration, _ := audio.GetDuration(audioPath)
 
-	return s.constructNarrative(req, script, extractedTitle, audioPath, format, startTime, predicted, duration), nil
+	n := s.constructNarrative(req, script, extractedTitle, audioPath, format, startTime, predicted, duration)
+	n.Fallback = fallback
+	return n, nil
+}
+
+// narrationLogKeep bounds the persisted narration history.
+const narrationLogKeep = 200
+
+// RecordNarrationStats logs a played narrative and appends it to the
+// persisted history, which the GUI terminal reloads after being reopened.
+func (s *AIService) RecordNarrationStats(ctx context.Context, n *model.Narrative) {
+	words := len(strings.Fields(n.Script))
+	slog.Info("Narrator: Narration stats",
+		"title", n.Title,
+		"type", n.Type,
+		"words", words,
+		"duration", n.Duration.Round(100*time.Millisecond),
+		"latency", n.GenerationLatency.Round(100*time.Millisecond),
+	)
+	if s.st == nil {
+		return
+	}
+	entry := store.NarrationLogEntry{
+		Title:     n.Title,
+		Type:      string(n.Type),
+		CreatedAt: n.CreatedAt,
+		Duration:  n.Duration,
+		WordCount: words,
+	}
+	if err := s.st.AddNarrationLog(ctx, entry, narrationLogKeep); err != nil {
+		slog.Warn("Narrator: Failed to persist narration log", "error", err)
+	}
 }
 
 func (s *AIService) logWikipediaContext(req *GenerationRequest) {
@@ -132,11 +210,19 @@ func (s *AIService) generateInitialScript(ctx context.Context, req *GenerationRe
 		return res, nil
 	}
 
+	ctx = llm.WithServedBy(ctx)
+	start := time.Now()
 	var resp model.GenerationResponse
 	err := s.llm.GenerateJSON(ctx, profile, req.Prompt, &resp)
 	if err != nil {
 		return model.GenerationResponse{}, fmt.Errorf("LLM generation failed: %w", err)
 	}
+	slog.Info("Narrator: Generating script",
+		"poi", req.Title,
+		"profile", profile,
+		"provider", llm.ServedBy(ctx),
+		"took", time.Since(start).Round(100*time.Millisecond),
+	)
 	return resp, nil
 }
 
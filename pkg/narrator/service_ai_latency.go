@@ -0,0 +1,84 @@
+package narrator
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+const (
+	// latencyStateKey is the state store key of the persisted latency window.
+	latencyStateKey = "narrator_latencies"
+	// maxLatencySamples is the size of the rolling generation latency window.
+	maxLatencySamples = 10
+	// latencyMaxAge discards a persisted window nobody has updated for a day;
+	// provider mix and network conditions have likely changed since.
+	latencyMaxAge = 24 * time.Hour
+)
+
+// persistedLatencies is the stored form of the rolling latency window.
+type persistedLatencies struct {
+	SamplesMS []int64   `json:"samples_ms"`
+	SavedAt   time.Time `json:"saved_at"`
+}
+
+// saveLatencies persists the latency window so the next session starts with
+// realistic timing instead of the 60s default.
+func (s *AIService) saveLatencies(ctx context.Context, window []time.Duration) {
+	if s.st == nil {
+		return
+	}
+	p := persistedLatencies{SamplesMS: make([]int64, len(window)), SavedAt: time.Now()}
+	for i, d := range window {
+		p.SamplesMS[i] = d.Milliseconds()
+	}
+	data, err := json.Marshal(p)
+	if err != nil {
+		return
+	}
+	if err := s.st.SetState(ctx, latencyStateKey, string(data)); err != nil {
+		slog.Warn("Narrator: Failed to persist latency window", "error", err)
+	}
+}
+
+// restoreLatencies seeds the latency window from the previous session.
+func (s *AIService) restoreLatencies(ctx context.Context) {
+	if s.st == nil {
+		return
+	}
+	val, ok := s.st.GetState(ctx, latencyStateKey)
+	if !ok || val == "" {
+		return
+	}
+	var p persistedLatencies
+	if err := json.Unmarshal([]byte(val), &p); err != nil {
+		slog.Warn("Narrator: Discarding unreadable latency window", "error", err)
+		return
+	}
+	if time.Since(p.SavedAt) > latencyMaxAge {
+		slog.Debug("Narrator: Ignoring stale latency window", "saved_at", p.SavedAt)
+		return
+	}
+
+	samples := p.SamplesMS
+	if len(samples) > maxLatencySamples {
+		samples = samples[len(samples)-maxLatencySamples:]
+	}
+	window := make([]time.Duration, 0, maxLatencySamples)
+	for _, ms := range samples {
+		if ms > 0 {
+			window = append(window, time.Duration(ms)*time.Millisecond)
+		}
+	}
+	if len(window) == 0 {
+		return
+	}
+
+	s.mu.Lock()
+	s.latencies = window
+	s.mu.Unlock()
+
+	s.ApplyPredictionWindow()
+	slog.Info("Narrator: Restored latency window", "samples", len(window), "avg", s.AverageLatency())
+}
@@ -2,6 +2,7 @@ package narrator
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -9,6 +10,7 @@ import (
 
 	"phileasgo/pkg/generation"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/sim"
 )
 
@@ -69,7 +71,11 @@ func (s *AIService) playPOIAutomated(_ context.Context, p *model.POI, tel *sim.T
 		}()
 
 		promptData := s.promptAssembler.ForPOI(genCtx, p, tel, strategy, s.getSessionState())
-		prompt, err := s.prompts.Render("narrator/script.tmpl", promptData)
+		if err := s.checkRelevance(p, promptData); err != nil {
+			slog.Info("Narrator: Skipping POI", "poi_id", p.WikidataID, "reason", err)
+			return
+		}
+		prompt, err := s.renderWithinBudget("narrator/script.tmpl", promptData)
 		if err != nil {
 			slog.Error("Narrator: Failed to render prompt", "error", err)
 			return
@@ -111,7 +117,10 @@ func (s *AIService) PrepareNextNarrative(ctx context.Context, poiID, strategy st
 	}
 
 	pd := s.promptAssembler.ForPOI(ctx, p, tel, strategy, s.getSessionState())
-	prompt, err := s.prompts.Render("narrator/script.tmpl", pd)
+	if err := s.checkRelevance(p, pd); err != nil {
+		return err
+	}
+	prompt, err := s.renderWithinBudget("narrator/script.tmpl", pd)
 	if err != nil {
 		return err
 	}
@@ -142,3 +151,25 @@ func (s *AIService) PrepareNextNarrative(ctx context.Context, poiID, strategy st
 	s.enqueuePlayback(narrative, false)
 	return nil
 }
+
+// ErrOutlivesRelevance is returned when a POI is skipped because no
+// narration of a useful length fits the time left near it.
+var ErrOutlivesRelevance = errors.New("narration would outlive the POI's relevance")
+
+// checkRelevance returns ErrOutlivesRelevance for a POI that would be passed
+// before its narration ends, and holds it back so the next scoring pass
+// doesn't pick it straight back up.
+func (s *AIService) checkRelevance(p *model.POI, pd prompt.Data) error {
+	if !outlivesRelevance(pd) {
+		return nil
+	}
+	s.suppressForAWhile(p)
+	return ErrOutlivesRelevance
+}
+
+// outlivesRelevance reports whether the assembler judged that no narration
+// of a useful length fits the time left near the POI.
+func outlivesRelevance(pd prompt.Data) bool {
+	outlives, _ := pd["OutlivesRelevance"].(bool)
+	return outlives
+}
@@ -2,11 +2,13 @@ package narrator
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/llm/prompts"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/session"
 	"phileasgo/pkg/sim"
 	"strings"
@@ -119,3 +121,30 @@ func TestAIService_PrepareNextNarrative(t *testing.T) {
 		t.Error("Expected error for missing POI, got nil")
 	}
 }
+
+func TestAIService_CheckRelevance(t *testing.T) {
+	tests := []struct {
+		name           string
+		outlives       bool
+		wantErr        error
+		wantSuppressed bool
+	}{
+		{"Fits the window", false, nil, false},
+		{"Outlives the POI", true, ErrOutlivesRelevance, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sess := session.NewManager(nil)
+			svc := &AIService{sessionMgr: sess}
+			p := &model.POI{WikidataID: "Q1"}
+
+			err := svc.checkRelevance(p, prompt.Data{"OutlivesRelevance": tt.outlives})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("got error %v, want %v", err, tt.wantErr)
+			}
+			if got := sess.IsSuppressed("Q1"); got != tt.wantSuppressed {
+				t.Errorf("suppressed = %v, want %v", got, tt.wantSuppressed)
+			}
+		})
+	}
+}
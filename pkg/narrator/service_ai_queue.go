@@ -96,6 +96,8 @@ func (s *AIService) ProcessGenerationQueue(ctx context.Context) {
 		switch job.Type {
 		case model.NarrativeTypePOI:
 			req = s.handlePOIJob(genCtx, job)
+		case model.NarrativeTypeEssay:
+			req = s.handleEssayJob(genCtx, job)
 		default:
 			req = s.handleAnnouncementJob(genCtx, job)
 		}
@@ -205,7 +207,13 @@ func (s *AIService) handlePOIJob(ctx context.Context, job *generation.Job) *Gene
 	}
 
 	promptData := s.promptAssembler.ForPOI(ctx, p, job.Telemetry, job.Strategy, s.getSessionState())
-	promptStr, _ := s.prompts.Render("narrator/script.tmpl", promptData)
+	if !job.Manual {
+		if err := s.checkRelevance(p, promptData); err != nil {
+			slog.Info("Narrator: Skipping POI", "poi_id", p.WikidataID, "reason", err)
+			return nil
+		}
+	}
+	promptStr, _ := s.renderWithinBudget("narrator/script.tmpl", promptData)
 
 	req := &GenerationRequest{
 		Type:          model.NarrativeTypePOI,
@@ -219,6 +227,7 @@ func (s *AIService) handlePOIJob(ctx context.Context, job *generation.Job) *Gene
 		ThumbnailURL:  p.ThumbnailURL,
 		ShowInfoPanel: true,
 		TwoPass:       s.cfg.TwoPassScriptGeneration(ctx),
+		PromptData:    promptData,
 	}
 
 	s.mu.Lock()
@@ -241,7 +250,16 @@ func (s *AIService) handleAnnouncementJob(ctx context.Context, job *generation.J
 	}
 
 	tmpl := fmt.Sprintf("announcement/%s.tmpl", strings.ToLower(string(job.Type)))
-	promptBody, err := s.prompts.Render(tmpl, data)
+	if pt, ok := job.Announcement.(announcement.PromptTemplater); ok {
+		tmpl = pt.PromptTemplate()
+	}
+	var promptBody string
+	if pd, ok := data.(prompt.Data); ok {
+		// Briefings carry a whole WP article
+		promptBody, err = s.renderWithinBudget(tmpl, pd)
+	} else {
+		promptBody, err = s.prompts.Render(tmpl, data)
+	}
 	if err != nil {
 		slog.Error("Narrator: Failed to render announcement prompt", "error", err, "tmpl", tmpl)
 		return nil
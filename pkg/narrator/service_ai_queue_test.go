@@ -13,6 +13,8 @@ import (
 	"phileasgo/pkg/prompt"
 	"phileasgo/pkg/session"
 	"phileasgo/pkg/sim"
+	"runtime"
+	"strings"
 	"testing"
 )
 
@@ -185,3 +187,54 @@ func (m *mockAnnouncement) SetHeldNarrative(n *model.Narrative)  { m.held = n }
 func (m *mockAnnouncement) IsRepeatable() bool                   { return true }
 func (m *mockAnnouncement) TwoPass() bool                        { return m.twoPass }
 func (m *mockAnnouncement) SetTwoPass(v bool)                    { m.twoPass = v }
+
+func TestAIService_DebriefingPromptIncludesTripSummary(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	pm, err := prompts.NewManager(filepath.Join(filepath.Dir(filename), "..", "..", "configs", "prompts"))
+	if err != nil {
+		t.Fatalf("Failed to load production templates: %v", err)
+	}
+
+	var captured string
+	mockLLM := &MockLLM{
+		GenerateJSONFunc: func(ctx context.Context, name, prompt string, target any) error {
+			captured = prompt
+			res := target.(*model.GenerationResponse)
+			res.Title = "Journey's End"
+			res.Script = "What a flight it has been."
+			return nil
+		},
+	}
+
+	cfg := config.NewProvider(config.DefaultConfig(), nil)
+	sess := session.NewManager(nil)
+	sess.RecordSystemEvent("Take-off", "flight_stage", 47.45, 8.56, nil)
+	sess.AddEvent(&model.TripEvent{Type: "narration", Category: "Castle", Title: "Kyburg", Summary: "Medieval seat of the Kyburg counts", Lat: 47.46, Lon: 8.74})
+	sess.AddTrackPoint(47.45, 8.56)
+	sess.AddTrackPoint(47.46, 8.74)
+	sess.IncrementCount()
+
+	svc := NewAIService(cfg, mockLLM, &MockTTS{Format: "mp3"}, pm, &MockPOIProvider{}, &MockGeo{}, &MockSim{}, &MockStore{}, nil, nil, nil, nil, nil, nil, nil, sess, nil, nil)
+	svc.running = true
+	o := NewOrchestrator(svc, &MockAudio{}, playback.NewManager(), sess, nil, nil, nil, nil)
+	debrief := announcement.NewDebriefing(config.DefaultConfig(), o, sess)
+
+	tel := &sim.Telemetry{Latitude: 47.45, Longitude: 8.56, FlightStage: sim.StageParked, IsOnGround: true}
+	req := svc.handleAnnouncementJob(context.Background(), &generation.Job{
+		Type:         model.NarrativeTypeDebriefing,
+		Announcement: debrief,
+		Telemetry:    tel,
+	})
+	if req == nil {
+		t.Fatal("expected a generation request for the debriefing")
+	}
+	if _, err := svc.GenerateNarrative(context.Background(), req); err != nil {
+		t.Fatalf("GenerateNarrative failed: %v", err)
+	}
+
+	for _, want := range []string{"Kyburg - Medieval seat of the Kyburg counts", "Places narrated: 1", "Distance flown: about 14 km"} {
+		if !strings.Contains(captured, want) {
+			t.Errorf("expected the LLM prompt to contain %q", want)
+		}
+	}
+}
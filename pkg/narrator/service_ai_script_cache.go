@@ -0,0 +1,101 @@
+package narrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"phileasgo/pkg/model"
+)
+
+// scriptCacheProvider is the tracker label under which script cache hits and misses are reported.
+const scriptCacheProvider = "script_cache"
+
+// cachedScript is the persisted form of a generated POI script.
+type cachedScript struct {
+	Title     string    `json:"title"`
+	Script    string    `json:"script"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// scriptCacheKey returns the cache key for a POI generation request, or "" if the
+// request is not cacheable. Language and persona are hashed alongside the prompt so
+// that switching either never replays a script written for the other.
+func (s *AIService) scriptCacheKey(ctx context.Context, req *GenerationRequest) string {
+	if req.Type != model.NarrativeTypePOI || req.POI == nil || req.ImagePath != "" || req.Prompt == "" {
+		return ""
+	}
+	if !s.cfg.CacheScripts(ctx) {
+		return ""
+	}
+
+	persona := ""
+	if req.PromptData != nil {
+		persona, _ = req.PromptData["Persona"].(string)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%s", s.cfg.ActiveTargetLanguage(ctx), persona, req.Prompt)
+	return fmt.Sprintf("script_%s_%s", req.POI.WikidataID, hex.EncodeToString(h.Sum(nil)))
+}
+
+// lookupCachedScript returns a previously generated script for the key if it is
+// younger than the configured TTL.
+func (s *AIService) lookupCachedScript(ctx context.Context, key string) (cachedScript, bool) {
+	var entry cachedScript
+	if key == "" || s.st == nil {
+		return entry, false
+	}
+
+	data, ok := s.st.GetCache(ctx, key)
+	if ok {
+		if err := json.Unmarshal(data, &entry); err != nil {
+			slog.Warn("Narrator: Discarding unreadable cached script", "key", key, "error", err)
+			ok = false
+		}
+	}
+	if ok && entry.Script == "" {
+		ok = false
+	}
+	if ok {
+		if ttl := s.cfg.ScriptCacheTTL(ctx); ttl > 0 && time.Since(entry.CreatedAt) > ttl {
+			ok = false
+		}
+	}
+
+	if ok {
+		s.trackScriptCache(true)
+		return entry, true
+	}
+	s.trackScriptCache(false)
+	return cachedScript{}, false
+}
+
+// storeCachedScript persists a successfully generated script under the key.
+func (s *AIService) storeCachedScript(ctx context.Context, key, title, script string) {
+	if key == "" || s.st == nil || script == "" {
+		return
+	}
+	data, err := json.Marshal(cachedScript{Title: title, Script: script, CreatedAt: time.Now()})
+	if err != nil {
+		return
+	}
+	if err := s.st.SetCache(ctx, key, data); err != nil {
+		slog.Warn("Narrator: Failed to cache script", "key", key, "error", err)
+	}
+}
+
+func (s *AIService) trackScriptCache(hit bool) {
+	if s.fallbackTracker == nil {
+		return
+	}
+	if hit {
+		s.fallbackTracker.TrackCacheHit(scriptCacheProvider)
+	} else {
+		s.fallbackTracker.TrackCacheMiss(scriptCacheProvider)
+	}
+}
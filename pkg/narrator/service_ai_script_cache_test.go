@@ -0,0 +1,122 @@
+package narrator
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/session"
+	"phileasgo/pkg/tracker"
+)
+
+func TestAIService_ScriptCache(t *testing.T) {
+	newService := func(cacheOn bool, lang string, st *MockStore, tr *tracker.Tracker) (*AIService, *MockLLM) {
+		cfg := config.DefaultConfig()
+		cfg.Narrator.CacheScripts = cacheOn
+		cfg.Narrator.ScriptCacheTTL = config.Duration(time.Hour)
+		cfg.Narrator.ActiveTargetLanguage = lang
+		mockLLM := &MockLLM{Response: `{"title": "Mock Title", "script": "Fresh narration."}`}
+		svc := &AIService{
+			cfg:             config.NewProvider(cfg, nil),
+			llm:             mockLLM,
+			tts:             &MockTTS{Format: "mp3"},
+			st:              st,
+			sim:             &MockSim{},
+			prompts:         &prompts.Manager{},
+			sessionMgr:      session.NewManager(nil),
+			fallbackTracker: tr,
+			running:         true,
+		}
+		svc.promptAssembler = prompt.NewAssembler(svc.cfg, svc.st, svc.prompts, nil, nil, nil, svc.llm, nil, nil, nil, nil, nil, nil)
+		return svc, mockLLM
+	}
+	newReq := func(persona string) *GenerationRequest {
+		return &GenerationRequest{
+			Type:       model.NarrativeTypePOI,
+			Prompt:     "Tell me about this place.",
+			POI:        &model.POI{WikidataID: "Q90"},
+			PromptData: prompt.Data{"Persona": persona},
+		}
+	}
+
+	t.Run("Second identical request is served from cache", func(t *testing.T) {
+		st := &MockStore{}
+		tr := tracker.New()
+		svc, mockLLM := newService(true, "en-US", st, tr)
+
+		for i := 0; i < 2; i++ {
+			n, err := svc.GenerateNarrative(context.Background(), newReq("Guide"))
+			if err != nil {
+				t.Fatalf("GenerateNarrative failed: %v", err)
+			}
+			if n.Script != "Fresh narration." {
+				t.Errorf("run %d: got script %q", i, n.Script)
+			}
+		}
+
+		if mockLLM.GenerateTextCalls != 1 {
+			t.Errorf("expected 1 LLM call, got %d", mockLLM.GenerateTextCalls)
+		}
+		stats := tr.Snapshot()[scriptCacheProvider]
+		if stats.CacheHits != 1 || stats.CacheMisses != 1 {
+			t.Errorf("expected 1 hit / 1 miss, got %d / %d", stats.CacheHits, stats.CacheMisses)
+		}
+	})
+
+	t.Run("Disabled cache always calls LLM", func(t *testing.T) {
+		st := &MockStore{}
+		svc, mockLLM := newService(false, "en-US", st, nil)
+
+		for i := 0; i < 2; i++ {
+			if _, err := svc.GenerateNarrative(context.Background(), newReq("Guide")); err != nil {
+				t.Fatalf("GenerateNarrative failed: %v", err)
+			}
+		}
+		if mockLLM.GenerateTextCalls != 2 {
+			t.Errorf("expected 2 LLM calls, got %d", mockLLM.GenerateTextCalls)
+		}
+		if len(st.Cache) != 0 {
+			t.Errorf("expected nothing cached, got %d entries", len(st.Cache))
+		}
+	})
+
+	t.Run("Language and persona change the key", func(t *testing.T) {
+		svc, _ := newService(true, "en-US", &MockStore{}, nil)
+		base := svc.scriptCacheKey(context.Background(), newReq("Guide"))
+		if base == "" {
+			t.Fatal("expected cacheable request")
+		}
+		if k := svc.scriptCacheKey(context.Background(), newReq("Pirate")); k == base {
+			t.Error("persona change did not change cache key")
+		}
+		other, _ := newService(true, "de-DE", &MockStore{}, nil)
+		if k := other.scriptCacheKey(context.Background(), newReq("Guide")); k == base {
+			t.Error("language change did not change cache key")
+		}
+	})
+
+	t.Run("Expired entry is regenerated", func(t *testing.T) {
+		st := &MockStore{}
+		svc, mockLLM := newService(true, "en-US", st, nil)
+		req := newReq("Guide")
+		key := svc.scriptCacheKey(context.Background(), req)
+		stale, _ := json.Marshal(cachedScript{Title: "Old", Script: "Stale narration.", CreatedAt: time.Now().Add(-2 * time.Hour)})
+		_ = st.SetCache(context.Background(), key, stale)
+
+		n, err := svc.GenerateNarrative(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		if n.Script != "Fresh narration." {
+			t.Errorf("expected regenerated script, got %q", n.Script)
+		}
+		if mockLLM.GenerateTextCalls != 1 {
+			t.Errorf("expected 1 LLM call, got %d", mockLLM.GenerateTextCalls)
+		}
+	})
+}
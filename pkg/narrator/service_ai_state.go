@@ -119,19 +119,22 @@ func (s *AIService) AverageLatency() time.Duration {
 func (s *AIService) updateLatency(d time.Duration) {
 	s.mu.Lock()
 	s.latencies = append(s.latencies, d)
-	if len(s.latencies) > 10 {
+	if len(s.latencies) > maxLatencySamples {
 		s.latencies = s.latencies[1:]
 	}
+	window := append([]time.Duration(nil), s.latencies...)
 
-	var sum time.Duration
-	for _, lat := range s.latencies {
-		sum += lat
-	}
-	avg := sum / time.Duration(len(s.latencies))
 	s.mu.Unlock()
 
-	predWindow := max(avg*2, 60*time.Second)
-	s.sim.SetPredictionWindow(predWindow)
+	s.ApplyPredictionWindow()
+	s.saveLatencies(context.Background(), window)
+}
+
+// ApplyPredictionWindow pushes the lookahead to the sim: twice the average
+// LLM latency, but never less than the configured prediction window. Called
+// on every latency sample and whenever the configured window changes.
+func (s *AIService) ApplyPredictionWindow() {
+	s.sim.SetPredictionWindow(max(s.AverageLatency()*2, s.cfg.PredictionWindow(context.Background())))
 }
 
 func (s *AIService) POIManager() POIProvider {
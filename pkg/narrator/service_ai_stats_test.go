@@ -1,6 +1,7 @@
 package narrator
 
 import (
+	"phileasgo/pkg/config"
 	"phileasgo/pkg/session"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 func TestAIService_StatsAndLatency(t *testing.T) {
 	mockSim := &MockSim{}
 	svc := &AIService{
+		cfg:   config.NewProvider(config.DefaultConfig(), nil),
 		sim:   mockSim,
 		stats: make(map[string]any),
 	}
@@ -0,0 +1,367 @@
+package narrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"phileasgo/pkg/llm"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/tts"
+)
+
+// minStreamChunkChars keeps follow-up TTS requests large enough that the
+// per-request overhead of the TTS engine does not dominate.
+const minStreamChunkChars = 200
+
+var scriptFieldStart = regexp.MustCompile(`"script"\s*:\s*"`)
+
+// streamResult is the outcome of a streamed generation. AudioPath is empty
+// when the script arrived but incremental synthesis failed; the caller then
+// synthesizes the full script the regular way.
+type streamResult struct {
+	Title      string
+	Script     string
+	AudioPath  string
+	Format     string
+	FirstAudio time.Duration
+	Unusable   bool  // Script is empty, too short or a refusal; no audio kept
+	Err        error // Generation deadline expired; the blocking path would only time out again
+}
+
+// canStreamScript reports whether a request may take the streaming path.
+// Only single-pass POI narrations qualify: image prompts, second passes and
+// rescues all need the complete script before anything can be spoken, and
+// dialogue scripts must be split into turns first.
+func (s *AIService) canStreamScript(ctx context.Context, req *GenerationRequest) (llm.StreamingProvider, tts.AppendProvider, bool) {
+	if req.Type != model.NarrativeTypePOI || req.ImagePath != "" || req.TwoPass {
+		return nil, nil, false
+	}
+	if !s.cfg.StreamScripts(ctx) || s.cfg.DialogueMode(ctx) {
+		return nil, nil, false
+	}
+	sp, ok := s.llm.(llm.StreamingProvider)
+	if !ok {
+		return nil, nil, false
+	}
+	ap, ok := s.getTTSProvider().(tts.AppendProvider)
+	if !ok {
+		return nil, nil, false
+	}
+	return sp, ap, true
+}
+
+// generateStreamed streams the POI script from the LLM and feeds completed
+// sentences to the TTS engine while the rest is still being generated.
+// ok is false when streaming was not possible and the blocking path must run.
+func (s *AIService) generateStreamed(ctx context.Context, req *GenerationRequest, safeID string, startTime time.Time) (res streamResult, ok bool) {
+	sp, ap, ok := s.canStreamScript(ctx, req)
+	if !ok {
+		return res, false
+	}
+
+	// The failover provider records who streamed into the context, as on the
+	// blocking path
+	ctx = llm.WithServedBy(ctx)
+	// The generation deadline covers the LLM stream only: TTS keeps the
+	// caller's context so the chunks already spoken are not cut short
+	genCtx, cancel := s.withGenerateDeadline(ctx, req)
+	defer cancel()
+	llmStart := time.Now()
+	stream, err := sp.GenerateTextStream(genCtx, "narration", req.Prompt)
+	if err != nil {
+		if genCtx.Err() != nil && ctx.Err() == nil {
+			return streamResult{Err: fmt.Errorf("script stream: %w", genCtx.Err())}, true
+		}
+		if !errors.Is(err, llm.ErrStreamingNotSupported) {
+			slog.Warn("Narrator: Script streaming failed to start, using blocking generation", "error", err)
+		}
+		return res, false
+	}
+
+	outputPath := filepath.Join(os.TempDir(), fmt.Sprintf("phileas_narration_%s_%d", safeID, time.Now().UnixNano()))
+	voiceID := s.getVoiceID(s.narrationLanguage(ctx, req))
+
+	chunks := make(chan string, 16)
+	var wg sync.WaitGroup
+	var ttsErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for chunk := range chunks {
+			if ttsErr != nil {
+				continue // Drain so the producer never blocks
+			}
+			format, err := ap.SynthesizeAppend(ctx, chunk, voiceID, outputPath)
+			if err != nil {
+				ttsErr = err
+				continue
+			}
+			if res.Format == "" {
+				res.Format = format
+				res.FirstAudio = time.Since(startTime)
+				slog.Debug("Narrator: First streamed audio chunk ready", "poi", req.Title, "after", res.FirstAudio)
+			}
+		}
+	}()
+
+	var raw strings.Builder
+	dec := &scriptStreamDecoder{}
+	splitter := &sentenceSplitter{}
+	for delta := range stream.Deltas() {
+		raw.WriteString(delta)
+		for _, c := range splitter.Push(dec.Push(delta)) {
+			chunks <- c
+		}
+	}
+	if err := stream.Err(); err != nil {
+		// A cut-off script must be neither played nor cached; the blocking
+		// path starts over with the full failover logic
+		close(chunks)
+		wg.Wait()
+		s.removeStreamOutput(outputPath, res.Format)
+		if genCtx.Err() != nil && ctx.Err() == nil {
+			return streamResult{Err: fmt.Errorf("script stream: %w", genCtx.Err())}, true
+		}
+		slog.Warn("Narrator: Script stream broke off, using blocking generation", "poi", req.Title, "error", err)
+		return streamResult{}, false
+	}
+	if rest := splitter.Flush(); rest != "" {
+		chunks <- rest
+	}
+	close(chunks)
+	wg.Wait()
+
+	if raw.Len() == 0 {
+		slog.Warn("Narrator: Script stream returned no content, using blocking generation")
+		s.removeStreamOutput(outputPath, res.Format)
+		return streamResult{}, false
+	}
+	slog.Info("Narrator: Generating script",
+		"poi", req.Title,
+		"profile", "narration",
+		"provider", llm.ServedBy(ctx),
+		"streamed", true,
+		"took", time.Since(llmStart).Round(100*time.Millisecond),
+	)
+
+	// The streamed fragments were only good enough for speech; title and the
+	// stored script come from the complete response.
+	var resp model.GenerationResponse
+	if err := llm.UnmarshalFlexible([]byte(llm.CleanJSONBlock(raw.String())), &resp); err != nil || resp.Script == "" {
+		resp = model.GenerationResponse{Script: strings.TrimSpace(dec.Text())}
+	}
+	res.Title = resp.Title
+	res.Script = resp.Script
+	if res.Script == "" {
+		s.removeStreamOutput(outputPath, res.Format)
+		return streamResult{}, false
+	}
+	if unusableReason(res.Script, s.cfg.AppConfig().LLM.MinScriptWords) != "" {
+		// The refusal was already synthesized; drop it so it is never played
+		// and let the caller apply LLM.OnEmptyScript to the text
+		s.removeStreamOutput(outputPath, res.Format)
+		return streamResult{Title: res.Title, Script: res.Script, Unusable: true}, true
+	}
+
+	if ttsErr != nil || res.Format == "" {
+		slog.Warn("Narrator: Streamed TTS failed, synthesizing full script", "error", ttsErr, "poi", req.Title)
+		s.removeStreamOutput(outputPath, res.Format)
+		res.Format = ""
+		res.FirstAudio = 0
+		return res, true
+	}
+
+	fullPath := outputPath
+	if !strings.HasSuffix(strings.ToLower(fullPath), "."+res.Format) {
+		fullPath += "." + res.Format
+	}
+	if err := tts.VerifyAudioFile(fullPath); err != nil {
+		slog.Warn("Narrator: Streamed audio failed verification, synthesizing full script", "error", err)
+		_ = os.Remove(fullPath)
+		res.Format = ""
+		res.FirstAudio = 0
+		return res, true
+	}
+	res.AudioPath = fullPath
+	return res, true
+}
+
+func (s *AIService) removeStreamOutput(outputPath, format string) {
+	if format == "" {
+		return
+	}
+	_ = os.Remove(outputPath + "." + format)
+}
+
+// scriptStreamDecoder incrementally extracts the value of the "script" field
+// from a streamed JSON response. Responses that do not start like JSON are
+// passed through unchanged.
+type scriptStreamDecoder struct {
+	buf     strings.Builder // Undecided input (before the field, or a split escape)
+	out     strings.Builder
+	mode    int
+	pending string
+}
+
+const (
+	decodeDetect = iota
+	decodeSeek
+	decodeField
+	decodePlain
+	decodeDone
+)
+
+// Push consumes a stream delta and returns newly decoded script text.
+func (d *scriptStreamDecoder) Push(delta string) string {
+	start := d.out.Len()
+	d.pending += delta
+
+	for d.pending != "" {
+		switch d.mode {
+		case decodeDetect:
+			trimmed := strings.TrimLeftFunc(d.pending, unicode.IsSpace)
+			if trimmed == "" {
+				d.pending = ""
+				continue
+			}
+			if trimmed[0] == '{' || trimmed[0] == '`' {
+				d.mode = decodeSeek
+			} else {
+				d.mode = decodePlain
+			}
+			d.pending = trimmed
+		case decodePlain:
+			d.out.WriteString(d.pending)
+			d.pending = ""
+		case decodeSeek:
+			d.buf.WriteString(d.pending)
+			d.pending = ""
+			seen := d.buf.String()
+			loc := scriptFieldStart.FindStringIndex(seen)
+			if loc == nil {
+				continue
+			}
+			d.buf.Reset()
+			d.mode = decodeField
+			d.pending = seen[loc[1]:]
+		case decodeField:
+			if !d.decodeField() {
+				return d.out.String()[start:]
+			}
+		case decodeDone:
+			d.pending = ""
+		}
+	}
+	return d.out.String()[start:]
+}
+
+// decodeField decodes pending string content up to the closing quote. It
+// returns false when it needs more input to complete an escape sequence.
+func (d *scriptStreamDecoder) decodeField() bool {
+	p := d.pending
+	for i := 0; i < len(p); i++ {
+		switch p[i] {
+		case '"':
+			d.mode = decodeDone
+			d.pending = ""
+			return true
+		case '\\':
+			if i+1 >= len(p) {
+				d.pending = p[i:]
+				return false
+			}
+			switch p[i+1] {
+			case 'n', 'r', 't':
+				d.out.WriteByte(' ')
+			case 'u':
+				if i+6 > len(p) {
+					d.pending = p[i:]
+					return false
+				}
+				if r, err := strconv.ParseUint(p[i+2:i+6], 16, 32); err == nil {
+					d.out.WriteRune(rune(r))
+				}
+				i += 4
+			default:
+				d.out.WriteByte(p[i+1])
+			}
+			i++
+		default:
+			d.out.WriteByte(p[i])
+		}
+	}
+	d.pending = ""
+	return true
+}
+
+// Text returns all script text decoded so far.
+func (d *scriptStreamDecoder) Text() string {
+	return d.out.String()
+}
+
+// sentenceSplitter groups streamed text into speakable chunks. The first
+// sentence is released as soon as it is complete so audio can start early;
+// later chunks are batched to at least minStreamChunkChars.
+type sentenceSplitter struct {
+	buf     strings.Builder
+	emitted int
+}
+
+// Push adds text and returns any chunks that are ready for synthesis.
+func (sp *sentenceSplitter) Push(text string) []string {
+	if text == "" {
+		return nil
+	}
+	sp.buf.WriteString(text)
+
+	var ready []string
+	for {
+		s := sp.buf.String()
+		cut := sp.nextCut(s)
+		if cut < 0 {
+			return ready
+		}
+		chunk := strings.TrimSpace(s[:cut])
+		sp.buf.Reset()
+		sp.buf.WriteString(s[cut:])
+		if chunk != "" {
+			ready = append(ready, chunk)
+			sp.emitted++
+		}
+	}
+}
+
+// nextCut returns the index just past the last sentence end that completes
+// a chunk, or -1 when no chunk is ready yet.
+func (sp *sentenceSplitter) nextCut(s string) int {
+	cut := -1
+	for i := 0; i+1 < len(s); i++ {
+		if !strings.ContainsRune(".!?", rune(s[i])) || !unicode.IsSpace(rune(s[i+1])) {
+			continue
+		}
+		if sp.emitted == 0 {
+			return i + 1
+		}
+		if i+1 >= minStreamChunkChars {
+			cut = i + 1
+		}
+	}
+	return cut
+}
+
+// Flush returns whatever text is left once the stream has ended.
+func (sp *sentenceSplitter) Flush() string {
+	rest := strings.TrimSpace(sp.buf.String())
+	sp.buf.Reset()
+	return rest
+}
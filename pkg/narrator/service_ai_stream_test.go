@@ -0,0 +1,304 @@
+package narrator
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/llm"
+	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/session"
+	"phileasgo/pkg/tts"
+)
+
+// streamingLLM adds streaming on top of MockLLM so the plain mock keeps
+// exercising the blocking path everywhere else.
+type streamingLLM struct {
+	MockLLM
+	Deltas    []string
+	StreamErr error
+	BreakErr  error // Ends the stream with this error after the deltas
+	Stall     bool  // Keeps the stream open after the deltas until ctx is done
+}
+
+func (m *streamingLLM) GenerateTextStream(ctx context.Context, profile, prompt string) (*llm.Stream, error) {
+	if m.StreamErr != nil {
+		return nil, m.StreamErr
+	}
+	st := llm.NewStream(len(m.Deltas))
+	for _, d := range m.Deltas {
+		st.Send(ctx, d)
+	}
+	if m.Stall {
+		go func() {
+			<-ctx.Done()
+			st.Close(ctx.Err())
+		}()
+		return st, nil
+	}
+	st.Close(m.BreakErr)
+	return st, nil
+}
+
+type appendTTS struct {
+	MockTTS
+	Chunks    []string
+	AppendErr error
+}
+
+func (m *appendTTS) SynthesizeAppend(ctx context.Context, text, voiceID, outputPath string) (string, error) {
+	if m.AppendErr != nil {
+		return "", m.AppendErr
+	}
+	m.Chunks = append(m.Chunks, text)
+	f, err := os.OpenFile(outputPath+".mp3", os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	_, err = f.Write(make([]byte, tts.MinAudioSize/2+1))
+	return "mp3", err
+}
+
+func TestScriptStreamDecoder(t *testing.T) {
+	tests := []struct {
+		name   string
+		deltas []string
+		want   string
+	}{
+		{
+			name:   "JSON split across deltas",
+			deltas: []string{`{"title": "Tow`, `er", "scr`, `ipt": "It is `, `tall.\nVery \"tall\"`, `."}`},
+			want:   `It is tall. Very "tall".`,
+		},
+		{
+			name:   "Fenced JSON with unicode escape",
+			deltas: []string{"```json\n{\"script\": \"Caf", `\u00`, `e9 open."}`, "\n```"},
+			want:   "Café open.",
+		},
+		{
+			name:   "Escape split at delta boundary",
+			deltas: []string{`{"script": "a\`, `"b"}`},
+			want:   `a"b`,
+		},
+		{
+			name:   "Plain text passes through",
+			deltas: []string{"  Just ", "text."},
+			want:   "Just text.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &scriptStreamDecoder{}
+			var got strings.Builder
+			for _, delta := range tt.deltas {
+				got.WriteString(d.Push(delta))
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %q, want %q", got.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestSentenceSplitter(t *testing.T) {
+	long := strings.Repeat("word ", 45) + "end."
+	sp := &sentenceSplitter{}
+
+	var got []string
+	got = append(got, sp.Push("First sentence. Second")...)
+	got = append(got, sp.Push(" one. ")...)
+	got = append(got, sp.Push(long+" Tail")...)
+	if rest := sp.Flush(); rest != "" {
+		got = append(got, rest)
+	}
+
+	want := []string{"First sentence.", "Second one. " + long, "Tail"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestAIService_GenerateNarrative_Streaming(t *testing.T) {
+	newService := func(streamOn bool, l llm.Provider, ttsProv tts.Provider) *AIService {
+		cfg := config.DefaultConfig()
+		cfg.Narrator.StreamScripts = streamOn
+		svc := &AIService{
+			cfg:        config.NewProvider(cfg, nil),
+			llm:        l,
+			tts:        ttsProv,
+			st:         &MockStore{},
+			sim:        &MockSim{},
+			prompts:    &prompts.Manager{},
+			sessionMgr: session.NewManager(nil),
+			running:    true,
+		}
+		svc.promptAssembler = prompt.NewAssembler(svc.cfg, svc.st, svc.prompts, nil, nil, nil, svc.llm, nil, nil, nil, nil, nil, nil)
+		return svc
+	}
+	newReq := func() *GenerationRequest {
+		return &GenerationRequest{
+			Type:   model.NarrativeTypePOI,
+			Prompt: "Tell me about this place.",
+			POI:    &model.POI{WikidataID: "Q90"},
+		}
+	}
+	deltas := []string{`{"title": "Paris", "script": "Hello there. `, `This is Paris."}`}
+
+	t.Run("Streams and appends audio per chunk", func(t *testing.T) {
+		l := &streamingLLM{Deltas: deltas}
+		tp := &appendTTS{}
+		svc := newService(true, l, tp)
+
+		n, err := svc.GenerateNarrative(context.Background(), newReq())
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		defer os.Remove(n.AudioPath)
+
+		if n.Script != "Hello there. This is Paris." || n.Title != "Paris" {
+			t.Errorf("unexpected narrative: title=%q script=%q", n.Title, n.Script)
+		}
+		if want := []string{"Hello there.", "This is Paris."}; !reflect.DeepEqual(tp.Chunks, want) {
+			t.Errorf("got chunks %q, want %q", tp.Chunks, want)
+		}
+		if l.GenerateTextCalls != 0 || tp.SynthesizeCalls != 0 {
+			t.Errorf("blocking path used: llm=%d tts=%d", l.GenerateTextCalls, tp.SynthesizeCalls)
+		}
+	})
+
+	t.Run("Falls back when streaming is unsupported", func(t *testing.T) {
+		l := &streamingLLM{StreamErr: llm.ErrStreamingNotSupported}
+		l.Response = `{"title": "Paris", "script": "Blocking script."}`
+		tp := &appendTTS{}
+		svc := newService(true, l, tp)
+
+		n, err := svc.GenerateNarrative(context.Background(), newReq())
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		defer os.Remove(n.AudioPath)
+
+		if n.Script != "Blocking script." || tp.SynthesizeCalls != 1 {
+			t.Errorf("expected blocking generation, got script=%q tts=%d", n.Script, tp.SynthesizeCalls)
+		}
+	})
+
+	t.Run("Broken stream regenerates on the blocking path", func(t *testing.T) {
+		// Long enough to pass the usability check on its own
+		cut := `{"title": "Paris", "script": "Hello there. This is Paris, the capital of France, and we are `
+		l := &streamingLLM{Deltas: []string{cut}, BreakErr: io.ErrUnexpectedEOF}
+		l.Response = `{"title": "Paris", "script": "Blocking script."}`
+		tp := &appendTTS{}
+		svc := newService(true, l, tp)
+		svc.cfg.AppConfig().Narrator.CacheScripts = true
+		req := newReq()
+
+		n, err := svc.GenerateNarrative(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		defer os.Remove(n.AudioPath)
+
+		if n.Script != "Blocking script." || tp.SynthesizeCalls != 1 {
+			t.Errorf("expected the blocking script synthesized, got script=%q tts=%d", n.Script, tp.SynthesizeCalls)
+		}
+		if cached, ok := svc.lookupCachedScript(context.Background(), svc.scriptCacheKey(context.Background(), req)); ok && cached.Script != "Blocking script." {
+			t.Errorf("expected the truncated script kept out of the cache, got %q", cached.Script)
+		}
+	})
+
+	t.Run("Stalled stream hits the deadline and plays the template blurb", func(t *testing.T) {
+		l := &streamingLLM{Deltas: []string{`{"title": "Paris", "script": "Hello `}, Stall: true}
+		l.Response = `{"title": "Paris", "script": "Blocking script."}`
+		tp := &appendTTS{}
+		svc := newService(true, l, tp)
+		svc.cfg.AppConfig().LLM.GenerateTimeout = config.Duration(50 * time.Millisecond)
+		svc.cfg.AppConfig().LLM.TemplateFallback = true
+		req := newReq()
+		req.POI = &model.POI{WikidataID: "Q90", NameUser: "Paris", Category: "City"}
+
+		start := time.Now()
+		n, err := svc.GenerateNarrative(context.Background(), req)
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		defer os.Remove(n.AudioPath)
+
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("generation deadline not applied to the stream, took %v", elapsed)
+		}
+		if !n.Fallback || n.Script != "We're passing Paris, a city." {
+			t.Errorf("expected the template blurb, got fallback=%v script=%q", n.Fallback, n.Script)
+		}
+		if l.GenerateTextCalls != 0 {
+			t.Errorf("expected no blocking regeneration after the deadline, got %d calls", l.GenerateTextCalls)
+		}
+	})
+
+	t.Run("Chunk TTS failure synthesizes full script", func(t *testing.T) {
+		l := &streamingLLM{Deltas: deltas}
+		tp := &appendTTS{AppendErr: os.ErrPermission}
+		svc := newService(true, l, tp)
+
+		n, err := svc.GenerateNarrative(context.Background(), newReq())
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		defer os.Remove(n.AudioPath)
+
+		if tp.SynthesizeCalls != 1 || l.GenerateTextCalls != 0 {
+			t.Errorf("expected one full synthesis and no regeneration, got tts=%d llm=%d", tp.SynthesizeCalls, l.GenerateTextCalls)
+		}
+	})
+
+	t.Run("Streamed refusal is not played", func(t *testing.T) {
+		l := &streamingLLM{Deltas: []string{`{"title": "", "script": "I'm sorry, but `, `I can't help with that."}`}}
+		tp := &appendTTS{}
+		svc := newService(true, l, tp)
+		svc.cfg.AppConfig().LLM.OnEmptyScript = config.EmptyScriptSkip
+		req := newReq()
+
+		n, err := svc.GenerateNarrative(context.Background(), req)
+		if !errors.Is(err, ErrUnusableScript) {
+			if n != nil {
+				os.Remove(n.AudioPath)
+			}
+			t.Fatalf("err = %v, want ErrUnusableScript", err)
+		}
+		if len(tp.Chunks) == 0 {
+			t.Fatal("expected the refusal to have been streamed to TTS")
+		}
+		if l.GenerateTextCalls != 0 || tp.SynthesizeCalls != 0 {
+			t.Errorf("expected no regeneration, got llm=%d tts=%d", l.GenerateTextCalls, tp.SynthesizeCalls)
+		}
+		if !svc.sessionMgr.IsSuppressed(req.POI.WikidataID) {
+			t.Error("expected the POI to be suppressed after the skip")
+		}
+	})
+
+	t.Run("Disabled by config", func(t *testing.T) {
+		l := &streamingLLM{Deltas: deltas}
+		l.Response = `{"title": "Paris", "script": "Blocking script."}`
+		tp := &appendTTS{}
+		svc := newService(false, l, tp)
+
+		n, err := svc.GenerateNarrative(context.Background(), newReq())
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		defer os.Remove(n.AudioPath)
+
+		if len(tp.Chunks) != 0 || n.Script != "Blocking script." {
+			t.Errorf("expected no streaming, got %d chunks", len(tp.Chunks))
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package narrator
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"text/template"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
+)
+
+// ErrUnusableScript is returned when the LLM's script is empty, too short or
+// a refusal, and LLM.OnEmptyScript gives up on the narration.
+var ErrUnusableScript = errors.New("LLM returned no usable script")
+
+// refusalPrefixes open the typical safety refusal, in the narration
+// languages models most often answer in. Matched on the lowercased start of
+// the script, so a narration quoting one mid-text is not caught. A bare
+// "I can't" is left out: "I can't think of a finer view..." is a fine opener.
+var refusalPrefixes = []string{
+	// English
+	"i'm sorry", "i am sorry", "sorry, i", "i apologize", "as an ai",
+	"i can't help", "i cannot help", "i can't assist", "i cannot assist",
+	"i can't provide", "i cannot provide", "i can't write", "i cannot write",
+	"i'm unable to", "i am unable to", "i'm not able to", "i won't be able to",
+	// German
+	"es tut mir leid", "entschuldigung, ich", "als ki", "ich kann dabei nicht", "ich kann dir nicht", "ich kann ihnen nicht",
+	// French
+	"je suis désolé", "désolé, je", "en tant qu'ia", "je ne peux pas vous aider", "je ne peux pas t'aider",
+	// Spanish, Portuguese, Italian
+	"lo siento", "como ia", "no puedo ayudar", "desculpe", "sinto muito", "não posso ajudar",
+	"mi dispiace", "come ia", "non posso aiutar",
+	// Dutch
+	"het spijt me", "als ai", "ik kan je daar niet", "ik kan u daar niet",
+}
+
+// refusalMaxWords bounds what counts as a refusal. Refusals are a sentence
+// or two; a full narration that happens to open with an apology is not one.
+const refusalMaxWords = 60
+
+// fallbackBlurbs are spoken when no script could be generated, so the user
+// still hears which POI the aircraft is passing. They are templates rather
+// than prompts because the LLM is what just failed. Keyed by language code;
+// other languages get the English one. Only English lowercases the category:
+// the gendered languages name it after a dash instead of with an article.
+var fallbackBlurbs = map[string]*template.Template{
+	"en": template.Must(template.New("fallback_en").Parse("We're passing {{.NameUser}}{{if .Category}}, a {{.Category}}{{end}}.")),
+	"de": template.Must(template.New("fallback_de").Parse("Unter uns: {{.NameUser}}{{if .Category}} – {{.Category}}{{end}}.")),
+	"fr": template.Must(template.New("fallback_fr").Parse("Sous nous : {{.NameUser}}{{if .Category}} – {{.Category}}{{end}}.")),
+	"es": template.Must(template.New("fallback_es").Parse("Debajo de nosotros: {{.NameUser}}{{if .Category}} – {{.Category}}{{end}}.")),
+	"it": template.Must(template.New("fallback_it").Parse("Sotto di noi: {{.NameUser}}{{if .Category}} – {{.Category}}{{end}}.")),
+	"pt": template.Must(template.New("fallback_pt").Parse("Abaixo de nós: {{.NameUser}}{{if .Category}} – {{.Category}}{{end}}.")),
+	"nl": template.Must(template.New("fallback_nl").Parse("Onder ons: {{.NameUser}}{{if .Category}} – {{.Category}}{{end}}.")),
+}
+
+// generateScriptWithDeadline runs the initial script generation under the
+// configured deadline. Without one a hanging provider stalls the whole
+// narration pipeline.
+func (s *AIService) generateScriptWithDeadline(ctx context.Context, req *GenerationRequest) (model.GenerationResponse, error) {
+	ctx, cancel := s.withGenerateDeadline(ctx, req)
+	defer cancel()
+	return s.generateInitialScript(ctx, req)
+}
+
+// withGenerateDeadline applies LLM.GenerateTimeout to POI script generation,
+// streamed or not.
+func (s *AIService) withGenerateDeadline(ctx context.Context, req *GenerationRequest) (context.Context, context.CancelFunc) {
+	if req.Type == model.NarrativeTypePOI {
+		if timeout := s.cfg.LLMGenerateTimeout(ctx); timeout > 0 {
+			return context.WithTimeout(ctx, timeout)
+		}
+	}
+	return ctx, func() {}
+}
+
+// templateFallbackScript renders the fallback blurb for a POI narration.
+// ok is false when the fallback is disabled or not applicable.
+func (s *AIService) templateFallbackScript(ctx context.Context, req *GenerationRequest) (script string, ok bool) {
+	if !s.cfg.LLMTemplateFallback(ctx) {
+		return "", false
+	}
+	return renderFallbackBlurb(req)
+}
+
+// renderFallbackBlurb renders the fallback blurb in the narration language;
+// ok is false for narrations without a POI to name.
+func renderFallbackBlurb(req *GenerationRequest) (script string, ok bool) {
+	if req.Type != model.NarrativeTypePOI || req.POI == nil {
+		return "", false
+	}
+
+	code, _ := req.PromptData["Language_code"].(string)
+	blurb, localized := fallbackBlurbs[code]
+	category := strings.ToLower(req.POI.Category)
+	if !localized {
+		blurb = fallbackBlurbs["en"]
+	} else if label, _ := req.PromptData["CategoryLabel"].(string); label != "" && code != "en" {
+		category = label
+	}
+
+	var sb strings.Builder
+	err := blurb.Execute(&sb, map[string]string{
+		"NameUser": req.POI.DisplayName(),
+		"Category": category,
+	})
+	if err != nil {
+		slog.Error("Narrator: Failed to render fallback blurb", "error", err)
+		return "", false
+	}
+	return sb.String(), true
+}
+
+// unusableReason reports why a script can't be narrated, or "" if it can.
+func unusableReason(script string, minWords int) string {
+	script = strings.TrimSpace(script)
+	if script == "" {
+		return "empty"
+	}
+	head := strings.ToLower(strings.ReplaceAll(script, "’", "'"))
+	words := len(strings.Fields(script))
+	for _, p := range refusalPrefixes {
+		if words <= refusalMaxWords && strings.HasPrefix(head, p) {
+			return "refusal"
+		}
+	}
+	if words < minWords {
+		return "too short"
+	}
+	return ""
+}
+
+// ensureUsableScript applies LLM.OnEmptyScript when the model returned an
+// empty, too short or refused script. fallback is true when resp is the
+// template blurb; ErrUnusableScript means the narration is dropped.
+func (s *AIService) ensureUsableScript(ctx context.Context, req *GenerationRequest, resp model.GenerationResponse) (_ model.GenerationResponse, fallback bool, err error) {
+	llmCfg := s.cfg.AppConfig().LLM
+	reason := unusableReason(resp.Script, llmCfg.MinScriptWords)
+	if reason == "" {
+		return resp, false, nil
+	}
+	// The response is logged in full: refusals are what prompt tuning needs to see
+	slog.Warn("Narrator: LLM returned an unusable script",
+		"reason", reason,
+		"action", llmCfg.OnEmptyScript,
+		"type", req.Type,
+		"title", req.Title,
+		"response", resp.Script,
+	)
+
+	switch llmCfg.OnEmptyScript {
+	case config.EmptyScriptRetry:
+		retry := *req
+		retry.Prompt = s.simplifiedPrompt(req)
+		again, err := s.generateScriptWithDeadline(ctx, &retry)
+		if err == nil {
+			if reason = unusableReason(again.Script, llmCfg.MinScriptWords); reason == "" {
+				slog.Info("Narrator: Retry with a simplified prompt succeeded", "title", req.Title)
+				return again, false, nil
+			}
+		}
+		slog.Warn("Narrator: Retry with a simplified prompt failed", "title", req.Title, "reason", reason, "error", err, "response", again.Script)
+	case config.EmptyScriptTemplate:
+		if blurb, ok := renderFallbackBlurb(req); ok {
+			return model.GenerationResponse{Script: blurb}, true, nil
+		}
+	}
+
+	// Hold the POI back so the next scoring pass doesn't pick it straight
+	// back up and hit the same wall. Suppression rather than LastPlayed: the
+	// POI was never narrated and must not show up as played.
+	s.suppressForAWhile(req.POI)
+	return resp, false, ErrUnusableScript
+}
+
+// skipSuppression is how long a POI whose narration was given up on stays
+// out of automatic selection.
+const skipSuppression = 30 * time.Minute
+
+// suppressForAWhile keeps a POI out of automatic selection for skipSuppression.
+func (s *AIService) suppressForAWhile(p *model.POI) {
+	if p == nil || p.WikidataID == "" || s.sessionMgr == nil {
+		return
+	}
+	s.sessionMgr.SuppressPOIFor(p.WikidataID, skipSuppression)
+}
+
+// simplifiedPromptTokens caps the article text in a simplified prompt.
+const simplifiedPromptTokens = 1000
+
+// simplifiedPrompt renders a POI prompt again without the optional context
+// and with a shortened article, the usual suspects behind an empty or
+// refused answer. Other narrations are retried as they were.
+func (s *AIService) simplifiedPrompt(req *GenerationRequest) string {
+	if req.Type != model.NarrativeTypePOI || req.PromptData == nil || s.prompts == nil {
+		return req.Prompt
+	}
+	pd := make(prompt.Data, len(req.PromptData))
+	for k, v := range req.PromptData {
+		pd[k] = v
+	}
+	pd["RecentContext"] = ""
+	pd["PregroundContext"] = ""
+	pd["AvoidRepeating"] = []string{}
+	if text, ok := pd["WikipediaText"].(string); ok {
+		pd["WikipediaText"] = trimToTokens(text, simplifiedPromptTokens)
+	}
+
+	out, err := s.prompts.Render("narrator/script.tmpl", pd)
+	if err != nil {
+		slog.Warn("Narrator: Failed to render simplified prompt, retrying as is", "error", err)
+		return req.Prompt
+	}
+	return out
+}
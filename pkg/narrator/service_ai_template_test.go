@@ -0,0 +1,288 @@
+package narrator
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/llm/prompts"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/playback"
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/session"
+)
+
+func TestAIService_GenerateNarrative_TemplateFallback(t *testing.T) {
+	// slowLLM blocks until the generation deadline cancels the call
+	slowLLM := func() *MockLLM {
+		return &MockLLM{
+			GenerateJSONFunc: func(ctx context.Context, name, prompt string, target any) error {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(5 * time.Second):
+					return nil
+				}
+			},
+		}
+	}
+	newService := func(fallbackOn bool, l *MockLLM, tp *MockTTS) *AIService {
+		cfg := config.DefaultConfig()
+		cfg.LLM.GenerateTimeout = config.Duration(50 * time.Millisecond)
+		cfg.LLM.TemplateFallback = fallbackOn
+		svc := &AIService{
+			cfg:        config.NewProvider(cfg, nil),
+			llm:        l,
+			tts:        tp,
+			st:         &MockStore{},
+			sim:        &MockSim{},
+			prompts:    &prompts.Manager{},
+			sessionMgr: session.NewManager(nil),
+			running:    true,
+		}
+		svc.promptAssembler = prompt.NewAssembler(svc.cfg, svc.st, svc.prompts, nil, nil, nil, svc.llm, nil, nil, nil, nil, nil, nil)
+		return svc
+	}
+	newReq := func() *GenerationRequest {
+		return &GenerationRequest{
+			Type:   model.NarrativeTypePOI,
+			Prompt: "Tell me about this place.",
+			Title:  "Eiffel Tower",
+			POI:    &model.POI{WikidataID: "Q243", NameUser: "Eiffel Tower", Category: "Monument"},
+		}
+	}
+
+	t.Run("Slow LLM plays the template blurb", func(t *testing.T) {
+		tp := &MockTTS{}
+		svc := newService(true, slowLLM(), tp)
+
+		start := time.Now()
+		n, err := svc.GenerateNarrative(context.Background(), newReq())
+		if err != nil {
+			t.Fatalf("GenerateNarrative failed: %v", err)
+		}
+		defer os.Remove(n.AudioPath)
+
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("generation deadline not applied, took %v", elapsed)
+		}
+		if !n.Fallback {
+			t.Error("expected narrative to be marked as fallback")
+		}
+		if want := "We're passing Eiffel Tower, a monument."; n.Script != want {
+			t.Errorf("got script %q, want %q", n.Script, want)
+		}
+		if tp.SynthesizeCalls != 1 {
+			t.Errorf("expected blurb to be synthesized once, got %d", tp.SynthesizeCalls)
+		}
+	})
+
+	t.Run("Disabled fallback returns the error", func(t *testing.T) {
+		tp := &MockTTS{}
+		svc := newService(false, slowLLM(), tp)
+
+		if _, err := svc.GenerateNarrative(context.Background(), newReq()); err == nil {
+			t.Fatal("expected generation error")
+		}
+		if tp.SynthesizeCalls != 0 {
+			t.Errorf("expected no synthesis, got %d", tp.SynthesizeCalls)
+		}
+	})
+}
+
+func TestAIService_GenerateNarrative_UnusableScript(t *testing.T) {
+	tmpDir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(tmpDir, "narrator"), 0o755)
+	_ = os.MkdirAll(filepath.Join(tmpDir, "common"), 0o755)
+	_ = os.WriteFile(filepath.Join(tmpDir, "narrator", "script.tmpl"), []byte("Narrate {{.POINameUser}}. Recent: {{.RecentContext}}"), 0o644)
+	pm, err := prompts.NewManager(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to init prompt manager: %v", err)
+	}
+
+	const good = "The Eiffel Tower rises 330 metres above the Champ de Mars in Paris."
+	tests := []struct {
+		name         string
+		action       string
+		minWords     int
+		responses    []string // Per call; the last one repeats
+		wantScript   string
+		wantFallback bool
+		wantErr      error
+		wantCalls    int
+		wantCooldown bool
+	}{
+		{name: "Empty, retry succeeds", action: config.EmptyScriptRetry, responses: []string{"", good}, wantScript: good, wantCalls: 2},
+		{name: "Refusal, retry refused again", action: config.EmptyScriptRetry, responses: []string{"I'm sorry, but I can't help with that."}, wantErr: ErrUnusableScript, wantCalls: 2, wantCooldown: true},
+		{name: "Refusal, template blurb", action: config.EmptyScriptTemplate, responses: []string{"I cannot write about this."}, wantScript: "We're passing Eiffel Tower, a monument.", wantFallback: true, wantCalls: 1},
+		{name: "Too short, skipped", action: config.EmptyScriptSkip, minWords: 5, responses: []string{"Eiffel Tower."}, wantErr: ErrUnusableScript, wantCalls: 1, wantCooldown: true},
+		{name: "Usable script untouched", action: config.EmptyScriptRetry, minWords: 5, responses: []string{good}, wantScript: good, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent []string
+			l := &MockLLM{
+				GenerateJSONFunc: func(ctx context.Context, name, prompt string, target any) error {
+					sent = append(sent, prompt)
+					i := len(sent) - 1
+					if i >= len(tt.responses) {
+						i = len(tt.responses) - 1
+					}
+					target.(*model.GenerationResponse).Script = tt.responses[i]
+					return nil
+				},
+			}
+			cfg := config.DefaultConfig()
+			cfg.LLM.OnEmptyScript = tt.action
+			cfg.LLM.MinScriptWords = tt.minWords
+			tp := &MockTTS{}
+			sess := session.NewManager(nil)
+			svc := &AIService{
+				cfg:        config.NewProvider(cfg, nil),
+				llm:        l,
+				tts:        tp,
+				st:         &MockStore{},
+				sim:        &MockSim{},
+				prompts:    pm,
+				poiMgr:     &MockPOIProvider{},
+				sessionMgr: sess,
+				running:    true,
+			}
+			svc.promptAssembler = prompt.NewAssembler(svc.cfg, svc.st, svc.prompts, nil, nil, nil, svc.llm, nil, nil, nil, nil, nil, nil)
+
+			poi := &model.POI{WikidataID: "Q243", NameUser: "Eiffel Tower", Category: "Monument"}
+			req := &GenerationRequest{
+				Type:       model.NarrativeTypePOI,
+				Prompt:     "Narrate Eiffel Tower. Recent: Louvre, Notre-Dame",
+				Title:      "Eiffel Tower",
+				POI:        poi,
+				PromptData: prompt.Data{"POINameUser": "Eiffel Tower", "RecentContext": "Louvre, Notre-Dame"},
+			}
+
+			n, err := svc.GenerateNarrative(context.Background(), req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if len(sent) != tt.wantCalls {
+				t.Errorf("LLM calls = %d, want %d", len(sent), tt.wantCalls)
+			}
+			if len(sent) > 1 && strings.Contains(sent[1], "Louvre") {
+				t.Errorf("retry prompt was not simplified: %q", sent[1])
+			}
+			if got := sess.IsSuppressed(poi.WikidataID); got != tt.wantCooldown {
+				t.Errorf("POI suppressed = %v, want %v", got, tt.wantCooldown)
+			}
+			if !poi.LastPlayed.IsZero() {
+				t.Error("skipped POI must not be marked as played")
+			}
+			if tt.wantErr != nil {
+				if tp.SynthesizeCalls != 0 {
+					t.Errorf("expected no synthesis, got %d", tp.SynthesizeCalls)
+				}
+				return
+			}
+			defer os.Remove(n.AudioPath)
+			if n.Script != tt.wantScript {
+				t.Errorf("script = %q, want %q", n.Script, tt.wantScript)
+			}
+			if n.Fallback != tt.wantFallback {
+				t.Errorf("fallback = %v, want %v", n.Fallback, tt.wantFallback)
+			}
+		})
+	}
+}
+
+func TestOrchestrator_FallbackNotCounted(t *testing.T) {
+	sess := session.NewManager(nil)
+	o := NewOrchestrator(&MockAIService{}, &MockAudio{PlaySync: true}, playback.NewManager(), sess, nil, nil, nil, nil)
+
+	n := &model.Narrative{Type: model.NarrativeTypePOI, Title: "Blurb", AudioPath: "test_audio", Format: "mp3", Fallback: true}
+	if err := o.PlayNarrative(context.Background(), n); err != nil {
+		t.Fatalf("PlayNarrative failed: %v", err)
+	}
+	if got := o.NarratedCount(); got != 0 {
+		t.Errorf("fallback counted as narration: count = %d", got)
+	}
+
+	n = &model.Narrative{Type: model.NarrativeTypePOI, Title: "Full", AudioPath: "test_audio", Format: "mp3"}
+	if err := o.PlayNarrative(context.Background(), n); err != nil {
+		t.Fatalf("PlayNarrative failed: %v", err)
+	}
+	if got := o.NarratedCount(); got != 1 {
+		t.Errorf("expected narration to be counted, got %d", got)
+	}
+}
+
+func TestUnusableReason(t *testing.T) {
+	long := "I can't think of a finer view than the one ahead. " + strings.Repeat("The valley opens up below us. ", 15)
+	tests := []struct {
+		name   string
+		script string
+		want   string
+	}{
+		{"Empty", "  ", "empty"},
+		{"English refusal", "I'm sorry, but I can't help with that.", "refusal"},
+		{"German refusal", "Es tut mir leid, dabei kann ich nicht helfen.", "refusal"},
+		{"French refusal", "Je suis désolé, mais je ne peux pas écrire ce texte.", "refusal"},
+		{"Legit I can't opener", "I can't think of a finer view than this castle on the hill.", ""},
+		{"Long narration opening with an apology", "I'm sorry to report " + long, ""},
+		{"Usable", long, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := unusableReason(tt.script, 0); got != tt.want {
+				t.Errorf("unusableReason = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderFallbackBlurb(t *testing.T) {
+	poi := &model.POI{NameUser: "Schloss Neuschwanstein", Category: "Castle"}
+	tests := []struct {
+		name       string
+		promptData prompt.Data
+		want       string
+	}{
+		{name: "No language", want: "We're passing Schloss Neuschwanstein, a castle."},
+		{
+			name:       "English",
+			promptData: prompt.Data{"Language_code": "en", "CategoryLabel": "Castle"},
+			want:       "We're passing Schloss Neuschwanstein, a castle.",
+		},
+		{
+			name:       "German uses the category label",
+			promptData: prompt.Data{"Language_code": "de", "CategoryLabel": "Burg"},
+			want:       "Unter uns: Schloss Neuschwanstein – Burg.",
+		},
+		{
+			name:       "French without a label",
+			promptData: prompt.Data{"Language_code": "fr"},
+			want:       "Sous nous : Schloss Neuschwanstein – castle.",
+		},
+		{
+			name:       "Unknown language falls back to English",
+			promptData: prompt.Data{"Language_code": "ja", "CategoryLabel": "城"},
+			want:       "We're passing Schloss Neuschwanstein, a castle.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &GenerationRequest{Type: model.NarrativeTypePOI, POI: poi, PromptData: tt.promptData}
+			got, ok := renderFallbackBlurb(req)
+			if !ok {
+				t.Fatal("expected a blurb")
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -710,6 +710,9 @@ func TestAllProductionTemplatesExecuteSuccessfully(t *testing.T) {
 	data["NameUser"] = "Paris"
 	data["Name"] = "Paris"
 	data["Category"] = "City"
+	data["Direction"] = "on your left"
+	data["Distance"] = "8 kilometers"
+	data["CategoryLabel"] = "City"
 	data["WikipediaText"] = "Text"
 	data["ArticleURL"] = "http://example.com"
 	data["Country"] = "France"
@@ -726,10 +729,15 @@ func TestAllProductionTemplatesExecuteSuccessfully(t *testing.T) {
 	data["Avoid"] = []string{"Politics"}
 	data["UnitSystem"] = "metric"
 	data["IsStub"] = false
+	data["DimensionFacts"] = ""
+	data["WikidataFacts"] = ""
 	data["PregroundContext"] = "Notes"
 	data["TTSInstructions"] = "Speak."
 	data["LastSentence"] = "Hello."
 	data["TripSummary"] = "Summary."
+	data["NarratedCount"] = 3
+	data["DistanceFlownKm"] = 120
+	data["DistanceFlownNm"] = 65
 	data["Language_name"] = "English"
 	data["TargetLanguage"] = "en"
 	data["ClockPos"] = 12
@@ -757,7 +765,15 @@ func TestAllProductionTemplatesExecuteSuccessfully(t *testing.T) {
 	data["DomStrat"] = "Uniform"
 	data["From"] = "France"
 	data["To"] = "Germany"
+	data["DistanceKM"] = 10
+	data["WaterName"] = "North Atlantic Ocean"
+	data["Lake"] = false
+	data["MinDistanceKM"] = 50
+	data["ScreenshotMode"] = "describe"
 	data["NarrativeType"] = "script"
+	data["DialogueMode"] = true
+	data["Text"] = "Texte"
+	data["SourceLanguage"] = "fr"
 
 	err = filepath.Walk(promptsDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".tmpl") {
@@ -780,3 +796,123 @@ func TestAllProductionTemplatesExecuteSuccessfully(t *testing.T) {
 		t.Fatalf("Walk failed: %v", err)
 	}
 }
+
+func TestNightToning_RendersInScript(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	promptsDir := filepath.Join(filepath.Dir(filename), "..", "..", "configs", "prompts")
+	if _, err := os.Stat(promptsDir); os.IsNotExist(err) {
+		t.Skip("configs/prompts not found, skipping production template test")
+	}
+	pm, err := prompts.NewManager(promptsDir)
+	if err != nil {
+		t.Fatalf("Failed to load production templates: %v", err)
+	}
+
+	appCfg := config.DefaultConfig()
+	appCfg.Narrator.NightToning = true
+	svc := NewAIService(config.NewProvider(appCfg, nil), &MockLLM{}, &MockTTS{}, pm, &MockPOIProvider{}, &MockGeo{}, &MockSim{}, &MockStore{}, nil, nil, nil, nil, nil, nil, nil, session.NewManager(nil), nil, nil)
+	p := &model.POI{WikidataID: "Q1", NameEn: "Notre-Dame", Category: "Church", Lat: 48.853, Lon: 2.35}
+
+	for _, night := range []bool{false, true} {
+		// Midwinter noon or midnight over Paris
+		simTime := time.Date(2024, 12, 21, 12, 0, 0, 0, time.UTC)
+		if night {
+			simTime = time.Date(2024, 12, 21, 23, 0, 0, 0, time.UTC)
+		}
+		tel := &sim.Telemetry{Latitude: 48.85, Longitude: 2.3, SimTime: simTime}
+		data := svc.promptAssembler.ForPOI(context.Background(), p, tel, "", svc.getSessionState())
+		out, err := pm.Render("narrator/script.tmpl", data)
+		if err != nil {
+			t.Fatalf("Failed to render: %v", err)
+		}
+		if got := strings.Contains(out, "### NIGHT"); got != night {
+			t.Errorf("night=%v: night section present = %v", night, got)
+		}
+	}
+}
+
+func TestAvoidRepetition_RendersInScript(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	promptsDir := filepath.Join(filepath.Dir(filename), "..", "..", "configs", "prompts")
+	if _, err := os.Stat(promptsDir); os.IsNotExist(err) {
+		t.Skip("configs/prompts not found, skipping production template test")
+	}
+	pm, err := prompts.NewManager(promptsDir)
+	if err != nil {
+		t.Fatalf("Failed to load production templates: %v", err)
+	}
+
+	current := &model.POI{WikidataID: "Q1", NameEn: "Notre-Dame", Category: "Church", Lat: 48.853, Lon: 2.35}
+	st := &MockStore{RecentPOIs: []*model.POI{
+		{WikidataID: "Q243", NameEn: "Eiffel Tower", Category: "Monument", Lat: 48.858, Lon: 2.294},
+		{WikidataID: "Q1", NameEn: "Notre-Dame", Category: "Church", Lat: 48.853, Lon: 2.35},
+		{WikidataID: "Q90", NameEn: "Far Away", Category: "City", Lat: 52.5, Lon: 13.4},
+	}}
+
+	for _, enabled := range []bool{false, true} {
+		appCfg := config.DefaultConfig()
+		appCfg.Narrator.AvoidRepetition = enabled
+		svc := NewAIService(config.NewProvider(appCfg, nil), &MockLLM{}, &MockTTS{}, pm, &MockPOIProvider{}, &MockGeo{}, &MockSim{}, st, nil, nil, nil, nil, nil, nil, nil, session.NewManager(nil), nil, nil)
+
+		data := svc.promptAssembler.ForPOI(context.Background(), current, &sim.Telemetry{Latitude: 48.85, Longitude: 2.3}, "", svc.getSessionState())
+		out, err := pm.Render("narrator/script.tmpl", data)
+		if err != nil {
+			t.Fatalf("Failed to render: %v", err)
+		}
+
+		if got := strings.Contains(out, "Do not repeat facts already told about **Eiffel Tower**."); got != enabled {
+			t.Errorf("AvoidRepetition=%v: avoid-list present = %v\n%s", enabled, got, out)
+		}
+		// The reference context stays either way
+		if !strings.Contains(out, "Eiffel Tower (Monument)") {
+			t.Errorf("AvoidRepetition=%v: expected the recent context to remain", enabled)
+		}
+	}
+}
+
+func TestScriptPrompt_NoArticleDimensions(t *testing.T) {
+	_, filename, _, _ := runtime.Caller(0)
+	promptsDir := filepath.Join(filepath.Dir(filename), "..", "..", "configs", "prompts")
+	if _, err := os.Stat(promptsDir); os.IsNotExist(err) {
+		t.Skip("configs/prompts not found, skipping production template test")
+	}
+	pm, err := prompts.NewManager(promptsDir)
+	if err != nil {
+		t.Fatalf("Failed to load production templates: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mode    string
+		wpURL   string
+		wantDim bool
+	}{
+		{name: "Dimension template for rescued POI", mode: config.NoArticleDimensions, wantDim: true},
+		{name: "Regular script when narrating", mode: config.NoArticleNarrate},
+		{name: "Regular script with an article", mode: config.NoArticleDimensions, wpURL: "https://en.wikipedia.org/wiki/Tower"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appCfg := config.DefaultConfig()
+			appCfg.Narrator.NoArticle = tt.mode
+			svc := NewAIService(config.NewProvider(appCfg, nil), &MockLLM{}, &MockTTS{}, pm, &MockPOIProvider{}, &MockGeo{}, &MockSim{}, &MockStore{}, &MockWikipedia{Content: "<p>A tall tower.</p>"}, nil, nil, nil, nil, nil, nil, session.NewManager(nil), nil, nil)
+			svc.initAssembler()
+
+			p := &model.POI{WikidataID: "Q1", NameEn: "Transmitter Mast", Category: "height", Height: 300, DimensionMultiplier: 3.2, WPURL: tt.wpURL}
+			pd := svc.promptAssembler.ForPOI(context.Background(), p, &sim.Telemetry{}, "", svc.getSessionState())
+			out, err := svc.renderWithinBudget("narrator/script.tmpl", pd)
+			if err != nil {
+				t.Fatalf("Failed to render script: %v", err)
+			}
+
+			gotDim := strings.Contains(out, "NARRATION LENGTH (DIMENSIONS)")
+			if gotDim != tt.wantDim {
+				t.Errorf("dimension template used = %v, want %v", gotDim, tt.wantDim)
+			}
+			if tt.wantDim && !strings.Contains(out, "Height: 300 m") {
+				t.Errorf("expected the height in the prompt:\n%s", out)
+			}
+		})
+	}
+}
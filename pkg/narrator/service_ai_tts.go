@@ -1,8 +1,11 @@
 package narrator
 
 import (
+	"context"
+	"fmt"
 	"log/slog"
 
+	"phileasgo/pkg/config"
 	"phileasgo/pkg/tts"
 	"phileasgo/pkg/tts/edgetts"
 )
@@ -18,7 +21,7 @@ func (s *AIService) activateFallback() {
 	}
 
 	slog.Warn("Narrator: Activating edge-tts fallback for this session")
-	s.fallbackTTS = edgetts.NewProvider(s.fallbackTracker) // With tracker for stats
+	s.fallbackTTS = tts.Limit(edgetts.NewProvider(s.fallbackTracker), s.cfg.AppConfig().TTS.MaxConcurrent) // With tracker for stats
 	s.useFallbackTTS = true
 }
 
@@ -33,16 +36,24 @@ func (s *AIService) getTTSProvider() tts.Provider {
 	return s.tts
 }
 
-// getVoiceID returns the configured voice ID for the active TTS engine.
-func (s *AIService) getVoiceID() string {
+// getVoiceID returns the voice for the active TTS engine: the one mapped to
+// the narration language in voices.yaml, else the engine's configured voice.
+// The mapping only applies while following the country's language; otherwise
+// the narration language is the user's own and so is their chosen voice.
+func (s *AIService) getVoiceID(language string) string {
 	appCfg := s.cfg.AppConfig()
+	engine := appCfg.TTS.Engine
 	// If using fallback (EdgeTTS), use its config
 	if s.useFallbackTTS {
-		return appCfg.TTS.EdgeTTS.VoiceID
+		engine = "edge-tts"
+	}
+	if s.cfg.AutoFollowCountryLanguage(context.Background()) {
+		if voice := s.voices.Resolve(engine, language); voice != "" {
+			return voice
+		}
 	}
 
-	// Otherwise check the primary engine
-	switch appCfg.TTS.Engine {
+	switch engine {
 	case "azure-speech":
 		return appCfg.TTS.AzureSpeech.VoiceID
 	case "fish-audio":
@@ -54,9 +65,32 @@ func (s *AIService) getVoiceID() string {
 	}
 }
 
+// SetVoices sets the per-language voice mapping.
+func (s *AIService) SetVoices(voices config.VoiceMap) {
+	s.voices = voices
+}
+
+// narrationLanguage returns the locale a request is narrated in, which
+// differs from the active target language when following the country below.
+func (s *AIService) narrationLanguage(ctx context.Context, req *GenerationRequest) string {
+	if lang, ok := req.PromptData["Language"].(string); ok && lang != "" {
+		return lang
+	}
+	return s.cfg.ActiveTargetLanguage(ctx)
+}
+
 // isUsingFallbackTTS returns true if fallback TTS is active.
 func (s *AIService) isUsingFallbackTTS() bool {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	return s.useFallbackTTS
 }
+
+// TTSHealth reports an error once the configured engine has failed fatally
+// and narration has moved to the edge-tts fallback for the session.
+func (s *AIService) TTSHealth() error {
+	if s.isUsingFallbackTTS() {
+		return fmt.Errorf("%s failed, using edge-tts fallback", s.cfg.AppConfig().TTS.Engine)
+	}
+	return nil
+}
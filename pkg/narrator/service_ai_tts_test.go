@@ -1,8 +1,14 @@
 package narrator
 
 import (
-	"phileasgo/pkg/config"
+	"context"
+	"os"
 	"testing"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/prompt"
+	"phileasgo/pkg/tts"
 )
 
 func TestAIService_TTSFallback(t *testing.T) {
@@ -39,7 +45,52 @@ func TestAIService_TTSFallback(t *testing.T) {
 	}
 
 	// 3. Voice ID reflects the engine (Edge in fallback)
-	if v := svc.getVoiceID(); v != "en-US-JennyNeural-Fallback" {
+	if v := svc.getVoiceID(""); v != "en-US-JennyNeural-Fallback" {
 		t.Errorf("expected Edge fallback voice ID, got %s", v)
 	}
 }
+
+func TestAIService_VoicePerLanguage(t *testing.T) {
+	tests := []struct {
+		name       string
+		language   string
+		autoFollow bool
+		want       string
+	}{
+		{"French POI gets the mapped voice", "fr-FR", true, "fr-FR-DeniseNeural"},
+		{"Unmapped language uses the engine voice", "es-ES", true, "en-US-JennyNeural"},
+		{"Mapping ignored without auto-follow", "fr-FR", false, "en-US-JennyNeural"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.TTS.Engine = "azure-speech"
+			cfg.TTS.AzureSpeech.VoiceID = "en-US-JennyNeural"
+			cfg.Narrator.AutoFollowCountryLanguage = tt.autoFollow
+
+			mockTTS := &MockTTS{}
+			var gotVoice string
+			mockTTS.SynthesizeFunc = func(ctx context.Context, text, voiceID, outputPath string) (string, error) {
+				gotVoice = voiceID
+				_ = os.WriteFile(outputPath+".mp3", make([]byte, tts.MinAudioSize+1), 0o644)
+				return "mp3", nil
+			}
+			mockLLM := &MockLLM{Response: "TITLE: OK\nBonjour"}
+			s := &AIService{tts: mockTTS, llm: mockLLM, sim: &MockSim{}, cfg: config.NewProvider(cfg, nil)}
+			s.promptAssembler = prompt.NewAssembler(s.cfg, nil, nil, nil, nil, nil, mockLLM, nil, nil, nil, nil, nil, nil)
+			s.SetVoices(config.VoiceMap{"azure-speech": {"fr": "fr-FR-DeniseNeural"}})
+
+			req := &GenerationRequest{
+				Type:       model.NarrativeTypePOI,
+				PromptData: prompt.Data{"Language": tt.language},
+			}
+			if _, err := s.GenerateNarrative(context.Background(), req); err != nil {
+				t.Fatalf("GenerateNarrative failed: %v", err)
+			}
+			if gotVoice != tt.want {
+				t.Errorf("expected voice %s, got %s", tt.want, gotVoice)
+			}
+		})
+	}
+}
@@ -142,6 +142,35 @@ func (m *Manager) HasPOI(poiID string) bool {
 	return false
 }
 
+// RemovePOI drops all queued narratives about the given POI and returns how
+// many were removed.
+func (m *Manager) RemovePOI(poiID string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	kept := m.queue[:0]
+	for _, n := range m.queue {
+		if n.POI == nil || n.POI.WikidataID != poiID {
+			kept = append(kept, n)
+		}
+	}
+	removed := len(m.queue) - len(kept)
+	m.queue = kept
+	return removed
+}
+
+// QueuedPOIs returns the POIs of all queued narratives in playback order.
+func (m *Manager) QueuedPOIs() []*model.POI {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var res []*model.POI
+	for _, n := range m.queue {
+		if n.POI != nil {
+			res = append(res, n.POI)
+		}
+	}
+	return res
+}
+
 // HasAuto checks if there are any automatic narratives in the queue.
 func (m *Manager) HasAuto() bool {
 	m.mu.RLock()
@@ -125,6 +125,20 @@ func TestManager_HasPOI(t *testing.T) {
 	}
 }
 
+func TestManager_RemovePOI(t *testing.T) {
+	m := NewManager()
+	m.Enqueue(&model.Narrative{ID: "1", POI: &model.POI{WikidataID: "Q1"}}, false)
+	m.Enqueue(&model.Narrative{ID: "2", Type: model.NarrativeTypeEssay}, false)
+	m.Enqueue(&model.Narrative{ID: "3", POI: &model.POI{WikidataID: "Q1"}}, true)
+
+	if n := m.RemovePOI("Q1"); n != 2 {
+		t.Errorf("expected 2 narratives removed, got %d", n)
+	}
+	if m.Count() != 1 || m.Peek().ID != "2" {
+		t.Errorf("expected only the essay to remain, got %d items", m.Count())
+	}
+}
+
 func TestManager_Promote(t *testing.T) {
 	m := NewManager()
 	m.Enqueue(&model.Narrative{ID: "1", POI: &model.POI{WikidataID: "Q1"}}, false)
@@ -154,3 +168,15 @@ func TestManager_EnqueueNil(t *testing.T) {
 		t.Errorf("expected count 0 after enqueuing nil, got %d", m.Count())
 	}
 }
+
+func TestManager_QueuedPOIs(t *testing.T) {
+	m := NewManager()
+	m.Enqueue(&model.Narrative{ID: "1", POI: &model.POI{WikidataID: "Q1"}}, false)
+	m.Enqueue(&model.Narrative{ID: "2", Type: model.NarrativeTypeEssay}, false)
+	m.Enqueue(&model.Narrative{ID: "3", POI: &model.POI{WikidataID: "Q3"}}, false)
+
+	pois := m.QueuedPOIs()
+	if len(pois) != 2 || pois[0].WikidataID != "Q1" || pois[1].WikidataID != "Q3" {
+		t.Errorf("expected [Q1 Q3], got %v", pois)
+	}
+}
@@ -0,0 +1,87 @@
+package poi
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// adaptiveState smooths the adaptive filter threshold across scoring passes.
+// Without it the threshold tracks the N-th best score exactly, so a single
+// POI entering or leaving range flips the visible set every tick.
+type adaptiveState struct {
+	threshold  float64
+	set        bool
+	underTicks int // Consecutive passes with fewer candidates than the target
+}
+
+// next advances the state with the combined scores (sorted descending) of the
+// current playable candidates and returns the threshold to apply.
+// The threshold is raised only when the count exceeds the target by margin
+// (a fraction of target), and lowered only after window consecutive passes
+// below target.
+func (s *adaptiveState) next(scores []float64, target int, margin float64, window int) float64 {
+	if target < 1 {
+		target = 1
+	}
+	raw := -math.MaxFloat64 // Fewer candidates than target: all qualify
+	if len(scores) > target {
+		raw = scores[target-1]
+	}
+	if !s.set {
+		s.threshold, s.set, s.underTicks = raw, true, 0
+		return s.threshold
+	}
+
+	count := sort.Search(len(scores), func(i int) bool { return scores[i] < s.threshold })
+	upper := target + int(math.Ceil(float64(target)*math.Max(0, margin)))
+
+	switch {
+	case count > upper:
+		s.threshold = raw
+		s.underTicks = 0
+	case count < target:
+		s.underTicks++
+		if s.underTicks >= window {
+			s.threshold = raw
+			s.underTicks = 0
+		}
+	default:
+		s.underTicks = 0
+	}
+	return s.threshold
+}
+
+// updateAdaptiveThreshold runs one hysteresis step after a scoring pass.
+func (m *Manager) updateAdaptiveThreshold(ctx context.Context) {
+	if m.config.FilterMode(ctx) != "adaptive" {
+		m.adaptiveMu.Lock()
+		m.adaptive = adaptiveState{}
+		m.adaptiveMu.Unlock()
+		return
+	}
+
+	m.mu.RLock()
+	ttl := m.config.RepeatTTL(ctx)
+	scores := make([]float64, 0, len(m.trackedPOIs))
+	for _, p := range m.trackedPOIs {
+		if p.IsHiddenFeature || !p.IsVisible || !m.isPlayable(p, ttl) || m.IsBlocked(ctx, p) {
+			continue
+		}
+		scores = append(scores, p.Score*p.Visibility)
+	}
+	m.mu.RUnlock()
+	sort.Sort(sort.Reverse(sort.Float64Slice(scores)))
+
+	m.adaptiveMu.Lock()
+	defer m.adaptiveMu.Unlock()
+	m.adaptive.next(scores, m.config.TargetPOICount(ctx), m.config.AdaptiveMargin(ctx), m.config.AdaptiveWindow(ctx))
+}
+
+// AdaptiveThreshold returns the smoothed adaptive threshold, if one has been
+// computed since adaptive mode was enabled.
+func (m *Manager) AdaptiveThreshold() (float64, bool) {
+	m.adaptiveMu.Lock()
+	defer m.adaptiveMu.Unlock()
+	return m.adaptive.threshold, m.adaptive.set
+}
@@ -0,0 +1,106 @@
+package poi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/sim"
+)
+
+func TestAdaptiveState_Hysteresis(t *testing.T) {
+	// tick describes one scoring pass: n candidates scoring top, top-1, ...
+	type tick struct{ n, top int }
+	ranked := func(tk tick) []float64 {
+		out := make([]float64, tk.n)
+		for i := range out {
+			out[i] = float64(tk.top - i)
+		}
+		return out
+	}
+	const allQualify = -math.MaxFloat64
+
+	tests := []struct {
+		name  string
+		ticks []tick
+		want  []float64
+	}{
+		{
+			name:  "Small overshoot keeps threshold",
+			ticks: []tick{{6, 100}, {7, 110}, {6, 100}, {7, 110}},
+			want:  []float64{96, 96, 96, 96},
+		},
+		{
+			name:  "Large overshoot raises immediately",
+			ticks: []tick{{6, 100}, {10, 110}},
+			want:  []float64{96, 106},
+		},
+		{
+			name:  "Short dip does not lower threshold",
+			ticks: []tick{{10, 100}, {3, 100}, {10, 100}, {3, 100}, {10, 100}},
+			want:  []float64{96, 96, 96, 96, 96},
+		},
+		{
+			name:  "Sustained under-count lowers after window",
+			ticks: []tick{{10, 100}, {3, 100}, {3, 100}, {3, 100}},
+			want:  []float64{96, 96, 96, allQualify},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var s adaptiveState
+			for i, tk := range tt.ticks {
+				if got := s.next(ranked(tk), 5, 0.25, 3); got != tt.want[i] {
+					t.Errorf("tick %d (%d candidates): got %v, want %v", i, tk.n, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestManager_AdaptiveThreshold_NoThrashing(t *testing.T) {
+	ctx := context.Background()
+	st := NewMockStore()
+	st.state[config.KeyFilterMode] = "adaptive"
+	st.state[config.KeyTargetPOICount] = "5"
+	mgr := NewManager(config.NewProvider(config.DefaultConfig(), st), st, nil)
+
+	for i := 0; i < 12; i++ {
+		_ = mgr.TrackPOI(ctx, &model.POI{
+			WikidataID: fmt.Sprintf("Q%d", i),
+			NameEn:     fmt.Sprintf("POI %d", i),
+			Score:      float64(100 - i),
+			Visibility: 1,
+		})
+	}
+
+	// Candidates drift in and out of view around the target every tick.
+	visibleCounts := []int{6, 5, 4, 6, 5, 4, 6, 5, 4, 6}
+	changes := 0
+	var last float64
+	for tick, n := range visibleCounts {
+		for _, p := range mgr.GetTrackedPOIs() {
+			var idx int
+			_, _ = fmt.Sscanf(p.WikidataID, "Q%d", &idx)
+			p.IsVisible = idx < n
+		}
+		mgr.NotifyScoringComplete(ctx, &sim.Telemetry{}, 0)
+
+		th, ok := mgr.AdaptiveThreshold()
+		if !ok {
+			t.Fatalf("tick %d: expected adaptive threshold to be set", tick)
+		}
+		if tick > 0 && th != last {
+			changes++
+		}
+		last = th
+	}
+
+	if changes > 0 {
+		t.Errorf("adaptive threshold changed %d times for a count fluctuating around target", changes)
+	}
+}
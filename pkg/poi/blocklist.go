@@ -0,0 +1,110 @@
+package poi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"phileasgo/pkg/model"
+	"phileasgo/pkg/store"
+)
+
+// blocklistPrefix namespaces blocked QIDs in the persistent state store.
+const blocklistPrefix = "blocklist_"
+
+// BlocklistKey returns the state key marking a QID as blocked.
+func BlocklistKey(qid string) string {
+	return blocklistPrefix + qid
+}
+
+// IsQIDBlocked reports whether the user has blocked the given QID by reading
+// the state store directly. Prefer Manager.IsBlocked in hot loops; it keeps
+// the blocklist in memory.
+func IsQIDBlocked(ctx context.Context, st store.StateStore, qid string) bool {
+	if st == nil || qid == "" {
+		return false
+	}
+	_, found := st.GetState(ctx, BlocklistKey(qid))
+	return found
+}
+
+// stateKeyLister is implemented by stores that can enumerate state keys,
+// which lets the manager load the whole blocklist with a single query.
+type stateKeyLister interface {
+	ListStateKeys(ctx context.Context, prefix string) ([]string, error)
+}
+
+// BlockPOI permanently excludes a POI from narration and the UI lists.
+func (m *Manager) BlockPOI(ctx context.Context, qid string) error {
+	if err := m.store.SetState(ctx, BlocklistKey(qid), "true"); err != nil {
+		return fmt.Errorf("failed to block POI %s: %w", qid, err)
+	}
+	m.blockedMu.Lock()
+	if m.blocked != nil {
+		m.blocked[qid] = true
+	}
+	m.blockedMu.Unlock()
+	m.logger.Info("POI blocked", "qid", qid)
+	return nil
+}
+
+// UnblockPOI removes a POI from the blocklist.
+func (m *Manager) UnblockPOI(ctx context.Context, qid string) error {
+	if err := m.store.DeleteState(ctx, BlocklistKey(qid)); err != nil {
+		return fmt.Errorf("failed to unblock POI %s: %w", qid, err)
+	}
+	m.blockedMu.Lock()
+	delete(m.blocked, qid)
+	m.blockedMu.Unlock()
+	m.logger.Info("POI unblocked", "qid", qid)
+	return nil
+}
+
+// IsBlocked reports whether a POI is blocked by QID or by its category.
+// The category check catches POIs persisted before the category was blocked.
+func (m *Manager) IsBlocked(ctx context.Context, p *model.POI) bool {
+	if m.catConfig != nil && m.catConfig.IsBlockedCategory(p.Category) {
+		return true
+	}
+	if p.WikidataID == "" {
+		return false
+	}
+	blocked, ok := m.inBlockedSet(ctx, p.WikidataID)
+	if !ok {
+		return IsQIDBlocked(ctx, m.store, p.WikidataID)
+	}
+	return blocked
+}
+
+// inBlockedSet looks a QID up in the in-memory blocklist, loading it on first
+// use. ok is false when the store cannot enumerate keys (or the load failed);
+// callers then fall back to per-QID lookups.
+func (m *Manager) inBlockedSet(ctx context.Context, qid string) (blocked, ok bool) {
+	m.blockedMu.RLock()
+	if m.blocked != nil {
+		blocked = m.blocked[qid]
+		m.blockedMu.RUnlock()
+		return blocked, true
+	}
+	m.blockedMu.RUnlock()
+
+	lister, ok := m.store.(stateKeyLister)
+	if !ok {
+		return false, false
+	}
+
+	m.blockedMu.Lock()
+	defer m.blockedMu.Unlock()
+	if m.blocked == nil {
+		keys, err := lister.ListStateKeys(ctx, blocklistPrefix)
+		if err != nil {
+			m.logger.Warn("Failed to load blocklist", "error", err)
+			return false, false
+		}
+		m.blocked = make(map[string]bool, len(keys))
+		for _, k := range keys {
+			m.blocked[strings.TrimPrefix(k, blocklistPrefix)] = true
+		}
+	}
+	return m.blocked[qid], true
+}
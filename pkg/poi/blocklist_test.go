@@ -0,0 +1,105 @@
+package poi
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
+)
+
+func TestManager_Blocklist(t *testing.T) {
+	ctx := context.Background()
+	st := NewMockStore()
+	catCfg := &config.CategoriesConfig{BlockedCategories: []string{"Aerodrome"}}
+	mgr := NewManager(config.NewProvider(config.DefaultConfig(), nil), st, catCfg)
+
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q1", NameEn: "POI 1", Category: "castle", Score: 10, Visibility: 1, IsVisible: true})
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q2", NameEn: "POI 2", Category: "castle", Score: 8, Visibility: 1, IsVisible: true})
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q3", NameEn: "POI 3", Category: "aerodrome", Score: 9, Visibility: 1, IsVisible: true})
+
+	ids := func(pois []*model.POI) map[string]bool {
+		out := make(map[string]bool)
+		for _, p := range pois {
+			out[p.WikidataID] = true
+		}
+		return out
+	}
+
+	// Blocked category is excluded from the start
+	got := ids(mgr.GetNarrationCandidates(10, nil))
+	if got["Q3"] || !got["Q1"] || !got["Q2"] {
+		t.Fatalf("unexpected candidates before blocking: %v", got)
+	}
+
+	if err := mgr.BlockPOI(ctx, "Q1"); err != nil {
+		t.Fatalf("BlockPOI failed: %v", err)
+	}
+	if got := ids(mgr.GetNarrationCandidates(10, nil)); got["Q1"] || !got["Q2"] {
+		t.Errorf("blocked POI still a candidate: %v", got)
+	}
+	ui, _ := mgr.GetPOIsForUI("fixed", 10, 0)
+	if got := ids(ui); got["Q1"] || got["Q3"] {
+		t.Errorf("blocked POI still shown in UI: %v", got)
+	}
+	if !IsQIDBlocked(ctx, st, "Q1") {
+		t.Error("expected Q1 to be blocked in store")
+	}
+
+	if err := mgr.UnblockPOI(ctx, "Q1"); err != nil {
+		t.Fatalf("UnblockPOI failed: %v", err)
+	}
+	if got := ids(mgr.GetNarrationCandidates(10, nil)); !got["Q1"] {
+		t.Errorf("unblocked POI not a candidate again: %v", got)
+	}
+}
+
+// listingStore counts blocklist reads so tests can assert the set is loaded once.
+type listingStore struct {
+	*MockStore
+	lists, gets int
+}
+
+func (s *listingStore) ListStateKeys(ctx context.Context, prefix string) ([]string, error) {
+	s.lists++
+	var keys []string
+	for k := range s.state {
+		if strings.HasPrefix(k, prefix) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (s *listingStore) GetState(ctx context.Context, key string) (string, bool) {
+	s.gets++
+	return s.MockStore.GetState(ctx, key)
+}
+
+func TestManager_BlocklistInMemory(t *testing.T) {
+	ctx := context.Background()
+	st := &listingStore{MockStore: NewMockStore()}
+	_ = st.SetState(ctx, BlocklistKey("Q1"), "true")
+	mgr := NewManager(config.NewProvider(config.DefaultConfig(), nil), st, &config.CategoriesConfig{})
+
+	p1 := &model.POI{WikidataID: "Q1"}
+	p2 := &model.POI{WikidataID: "Q2"}
+	for i := 0; i < 3; i++ {
+		if !mgr.IsBlocked(ctx, p1) || mgr.IsBlocked(ctx, p2) {
+			t.Fatalf("unexpected blocked state on pass %d", i)
+		}
+	}
+	if err := mgr.BlockPOI(ctx, "Q2"); err != nil {
+		t.Fatalf("BlockPOI failed: %v", err)
+	}
+	if err := mgr.UnblockPOI(ctx, "Q1"); err != nil {
+		t.Fatalf("UnblockPOI failed: %v", err)
+	}
+	if mgr.IsBlocked(ctx, p1) || !mgr.IsBlocked(ctx, p2) {
+		t.Error("Block/Unblock not reflected in the in-memory set")
+	}
+	if st.lists != 1 || st.gets != 0 {
+		t.Errorf("expected one list and no per-QID reads, got lists=%d gets=%d", st.lists, st.gets)
+	}
+}
@@ -0,0 +1,109 @@
+package poi
+
+import (
+	"math"
+	"sort"
+
+	"phileasgo/pkg/geo"
+	"phileasgo/pkg/model"
+)
+
+// capBandM groups POIs by distance, so that within a band score and age
+// decide rather than a few meters either way.
+const capBandM = 10000.0
+
+// capSlack is the fraction of the cap freed once it is exceeded, so a burst
+// of new POIs doesn't sort the whole set on every insert.
+const capSlack = 0.1
+
+// enforceTrackedCap drops the least relevant POIs once more than
+// POI.MaxTracked are tracked. Least relevant means far behind the aircraft
+// first, then low score, then old. POIs held by the eviction guard and user
+// POIs stay.
+func (m *Manager) enforceTrackedCap() {
+	if m.config == nil {
+		return
+	}
+	limit := m.config.AppConfig().POI.MaxTracked
+	if limit <= 0 {
+		return
+	}
+
+	m.mu.RLock()
+	excess := len(m.trackedPOIs) - limit
+	if excess <= 0 {
+		m.mu.RUnlock()
+		return
+	}
+	ranked := m.rankForEvictionLocked()
+	m.mu.RUnlock()
+
+	// The guard is asked without holding the lock: it calls into the
+	// narrator, which may itself be waiting on the manager.
+	target := excess + int(math.Ceil(float64(limit)*capSlack))
+	victims := make([]string, 0, target)
+	for _, id := range ranked {
+		if len(victims) == target {
+			break
+		}
+		if m.isEvictionGuarded != nil && m.isEvictionGuarded(id) {
+			continue
+		}
+		victims = append(victims, id)
+	}
+
+	m.mu.Lock()
+	for _, id := range victims {
+		delete(m.trackedPOIs, id)
+	}
+	remaining := len(m.trackedPOIs)
+	m.mu.Unlock()
+
+	m.logger.Debug("Pruned tracked POIs (Cap)", "removed", len(victims), "remaining", remaining, "cap", limit)
+}
+
+// rankForEvictionLocked returns the tracked POI IDs, least relevant first.
+func (m *Manager) rankForEvictionLocked() []string {
+	type entry struct {
+		poi    *model.POI
+		behind bool
+		band   int
+	}
+
+	hasPos := m.lastScoredLat != 0 || m.lastScoredLon != 0
+	pos := geo.Point{Lat: m.lastScoredLat, Lon: m.lastScoredLon}
+	entries := make([]entry, 0, len(m.trackedPOIs))
+	for _, p := range m.trackedPOIs {
+		// User POIs are never reloaded with a tile, so they stay
+		if p.Source == model.SourceUser {
+			continue
+		}
+		e := entry{poi: p}
+		if hasPos {
+			target := geo.Point{Lat: p.Lat, Lon: p.Lon}
+			e.band = int(geo.Distance(pos, target) / capBandM)
+			e.behind = math.Abs(geo.NormalizeAngle(geo.Bearing(pos, target)-m.lastScoredHeading)) > 90
+		}
+		entries = append(entries, e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.behind != b.behind {
+			return a.behind
+		}
+		if a.band != b.band {
+			return a.band > b.band
+		}
+		if a.poi.Score != b.poi.Score {
+			return a.poi.Score < b.poi.Score
+		}
+		return a.poi.CreatedAt.Before(b.poi.CreatedAt)
+	})
+
+	ids := make([]string, len(entries))
+	for i, e := range entries {
+		ids[i] = e.poi.WikidataID
+	}
+	return ids
+}
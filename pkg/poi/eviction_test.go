@@ -2,8 +2,10 @@ package poi
 
 import (
 	"context"
+	"fmt"
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/sim"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -86,3 +88,36 @@ func TestManager_PruneByDistance(t *testing.T) {
 		})
 	}
 }
+
+func TestManager_TrackedCap(t *testing.T) {
+	cfg := &config.Config{POI: config.POIConfig{MaxTracked: 20}}
+	mgr := NewManager(config.NewProvider(cfg, nil), NewMockStore(), nil)
+	mgr.UpdateScoringState(0.01, 0)
+	mgr.NotifyScoringComplete(context.Background(), &sim.Telemetry{Heading: 0}, 0)
+
+	// The narrated POI is the farthest behind, the first to go without the guard
+	mgr.SetEvictionGuard(func(qid string) bool { return qid == "Q_narrating" })
+	_ = mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q_narrating", NameEn: "Narrating", Lat: -1.0})
+	_ = mgr.TrackPOI(context.Background(), &model.POI{WikidataID: "Q_ahead_best", NameEn: "Best", Lat: 0.05, Score: 100})
+
+	for i := range 100 {
+		lat := -0.5 + float64(i)*0.01 // From far behind to ahead
+		_ = mgr.TrackPOI(context.Background(), &model.POI{WikidataID: fmt.Sprintf("Q%d", i), NameEn: "POI", Lat: lat, Score: float64(i % 10)})
+		assert.LessOrEqual(t, mgr.ActiveCount(), 20, "tracked count must stay bounded")
+	}
+
+	_, kept := mgr.trackedPOIs["Q_narrating"]
+	assert.True(t, kept, "the narrated POI must survive eviction")
+	_, kept = mgr.trackedPOIs["Q_ahead_best"]
+	assert.True(t, kept, "a close, high scoring POI ahead should survive")
+	_, kept = mgr.trackedPOIs["Q0"]
+	assert.False(t, kept, "a far POI behind should be evicted")
+}
+
+func TestManager_TrackedCap_Disabled(t *testing.T) {
+	mgr := NewManager(config.NewProvider(&config.Config{}, nil), NewMockStore(), nil)
+	for i := range 50 {
+		_ = mgr.TrackPOI(context.Background(), &model.POI{WikidataID: fmt.Sprintf("Q%d", i), NameEn: "POI"})
+	}
+	assert.Equal(t, 50, mgr.ActiveCount())
+}
@@ -55,12 +55,24 @@ type Manager struct {
 	catConfig *config.CategoriesConfig
 
 	// Consistency State
-	lastScoredLat float64
-	lastScoredLon float64
+	lastScoredLat     float64
+	lastScoredLon     float64
+	lastScoredHeading float64
+
+	// Adaptive filter hysteresis (separate lock: updated after scoring, read by the UI)
+	adaptiveMu sync.Mutex
+	adaptive   adaptiveState
+
+	// Blocked QIDs (separate lock: IsBlocked runs inside loops that hold mu).
+	// nil until loaded from the store on first use.
+	blockedMu sync.RWMutex
+	blocked   map[string]bool
 
 	// Callbacks
 	onScoringComplete func(ctx context.Context, t *sim.Telemetry)
 	onValleyAltitude  func(altMeters float64)
+	isSuppressed      func(qid string) bool
+	isEvictionGuarded func(qid string) bool
 
 	// River Integration (set via setter to break circular dependency)
 	poiLoader     Loader
@@ -103,6 +115,18 @@ func (m *Manager) SetValleyAltitudeCallback(fn func(altMeters float64)) {
 	m.onValleyAltitude = fn
 }
 
+// SetSuppressionCheck sets the function reporting POIs the user suppressed
+// for the current session. They are left out of the narration candidates.
+func (m *Manager) SetSuppressionCheck(fn func(qid string) bool) {
+	m.isSuppressed = fn
+}
+
+// SetEvictionGuard sets the function reporting POIs that must stay tracked
+// when the tracked-POI cap is enforced, such as the one being narrated.
+func (m *Manager) SetEvictionGuard(fn func(qid string) bool) {
+	m.isEvictionGuarded = fn
+}
+
 // UpsertPOI saves a POI to the database and adds it to the active tracking list.
 // It performs in-place updates on existing pointers in the active cache to ensure
 // pointer consistency across the application (e.g. for in-progress narrations).
@@ -129,7 +153,7 @@ func (m *Manager) upsertInternal(ctx context.Context, p *model.POI, shouldSave b
 	}
 
 	// 1. Ensure Icon Availability (Heal on Load)
-	m.ensureIcon(p)
+	m.ensureIcon(ctx, p)
 
 	m.mu.Lock()
 
@@ -152,6 +176,10 @@ func (m *Manager) upsertInternal(ctx context.Context, p *model.POI, shouldSave b
 	m.trackedPOIs[p.WikidataID] = p
 	m.mu.Unlock()
 
+	if isNew {
+		m.enforceTrackedCap()
+	}
+
 	// 3. Save to DB (optional)
 	if shouldSave {
 		if err := m.store.SavePOI(ctx, p); err != nil {
@@ -166,22 +194,18 @@ func (m *Manager) upsertInternal(ctx context.Context, p *model.POI, shouldSave b
 	return nil
 }
 
-// ensureIcon populates the POI icon if missing, using config or internal defaults.
+// ensureIcon populates the POI icon from the active icon set, falling back to
+// internal defaults and finally the generic marker. The configured icon wins
+// over a stored one so that switching sets also re-themes POIs loaded from the DB.
 // Always applies IconArtistic from config, even if Icon is already set.
-func (m *Manager) ensureIcon(p *model.POI) {
-	if p.Category == "" {
-		return
-	}
-
+func (m *Manager) ensureIcon(ctx context.Context, p *model.POI) {
 	// 1. Check Config (Case-Insensitive)
-	if m.catConfig != nil {
-		if cfg, ok := m.catConfig.Categories[strings.ToLower(p.Category)]; ok {
-			if p.Icon == "" && cfg.Icon != "" {
-				p.Icon = cfg.Icon
-			}
-			if cfg.IconArtistic != "" {
-				p.IconArtistic = cfg.IconArtistic
-			}
+	if m.catConfig != nil && p.Category != "" {
+		if icon := m.catConfig.IconFor(p.Category, m.iconSet(ctx)); icon != "" {
+			p.Icon = icon
+		}
+		if cfg, ok := m.catConfig.Categories[strings.ToLower(p.Category)]; ok && cfg.IconArtistic != "" {
+			p.IconArtistic = cfg.IconArtistic
 		}
 	}
 
@@ -196,10 +220,19 @@ func (m *Manager) ensureIcon(p *model.POI) {
 			p.Icon = "arrow"
 		case "landmark":
 			p.Icon = "monument"
+		default:
+			p.Icon = config.GenericIcon
 		}
 	}
 }
 
+func (m *Manager) iconSet(ctx context.Context) string {
+	if m.config == nil {
+		return config.DefaultIconSet
+	}
+	return m.config.IconSet(ctx)
+}
+
 // EnrichWithMSFS checks for MSFS overlap and updates the POI if found.
 func (m *Manager) EnrichWithMSFS(ctx context.Context, p *model.POI) error {
 	if p.IsMSFSPOI {
@@ -245,6 +278,9 @@ func (m *Manager) updateExistingPOI(existing, p *model.POI) {
 	if p.ThumbnailURL != "" {
 		existing.ThumbnailURL = p.ThumbnailURL
 	}
+	if p.Description != "" {
+		existing.Description = p.Description
+	}
 
 	// 2. Metadata Preservation
 	if !p.LastPlayed.IsZero() && p.LastPlayed.After(existing.LastPlayed) {
@@ -288,6 +324,45 @@ func (m *Manager) GetTrackedPOIs() []*model.POI {
 	return list
 }
 
+// NearbyPOI pairs a tracked POI with its distance from a query point.
+type NearbyPOI struct {
+	POI       *model.POI
+	DistanceM float64
+}
+
+// GetNearestTracked returns tracked POIs within radiusM of the given point,
+// nearest first. Only the in-memory tracking set is searched; a limit <= 0
+// returns all matches.
+func (m *Manager) GetNearestTracked(ctx context.Context, lat, lon, radiusM float64, limit int) []NearbyPOI {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	origin := geo.Point{Lat: lat, Lon: lon}
+	result := make([]NearbyPOI, 0)
+	for _, p := range m.trackedPOIs {
+		if p.IsHiddenFeature || m.IsBlocked(ctx, p) {
+			continue
+		}
+		dist := geo.Distance(origin, geo.Point{Lat: p.Lat, Lon: p.Lon})
+		if dist > radiusM {
+			continue
+		}
+		result = append(result, NearbyPOI{POI: p, DistanceM: dist})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].DistanceM != result[j].DistanceM {
+			return result[i].DistanceM < result[j].DistanceM
+		}
+		return result[i].POI.WikidataID < result[j].POI.WikidataID
+	})
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
 // PruneTracked removes POIs that are too far or too old.
 // For now, simple active list management. We can implement distance-based pruning later if needed.
 func (m *Manager) PruneTracked(olderThan time.Duration) int {
@@ -322,6 +397,11 @@ func (m *Manager) PruneByDistance(lat, lon, heading, thresholdKm float64) int {
 	thresholdM := thresholdKm * 1000.0
 
 	for id, p := range m.trackedPOIs {
+		// User POIs are never reloaded with a tile, so they stay
+		if p.Source == model.SourceUser {
+			continue
+		}
+
 		// 1. Distance Check
 		distM := geo.Distance(geo.Point{Lat: lat, Lon: lon}, geo.Point{Lat: p.Lat, Lon: p.Lon})
 		if distM <= thresholdM {
@@ -362,15 +442,16 @@ func (m *Manager) GetPOIsForUI(filterMode string, targetCount int, minScore floa
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	ttl := m.config.RepeatTTL(context.Background())
+	ctx := context.Background()
+	ttl := m.config.RepeatTTL(ctx)
 
 	// 1. Separate "Played" (Blue markers) from "Playable Candidates"
 	var played []*model.POI
 	var playableVisible []*model.POI
 
 	for _, p := range m.trackedPOIs {
-		// Geographical "Hidden" features are never shown in the POI lists
-		if p.IsHiddenFeature {
+		// Geographical "Hidden" features and blocked POIs are never shown in the POI lists
+		if p.IsHiddenFeature || m.IsBlocked(ctx, p) {
 			continue
 		}
 
@@ -388,7 +469,9 @@ func (m *Manager) GetPOIsForUI(filterMode string, targetCount int, minScore floa
 	combinedScore := func(p *model.POI) float64 { return p.Score * p.Visibility }
 
 	effectiveThreshold := minScore
-	if filterMode == "adaptive" && len(playableVisible) > 0 {
+	if smoothed, ok := m.AdaptiveThreshold(); filterMode == "adaptive" && ok {
+		effectiveThreshold = smoothed
+	} else if filterMode == "adaptive" && len(playableVisible) > 0 {
 		// Sort by combined score descending to find the cutoff
 		sort.Slice(playableVisible, func(i, j int) bool {
 			return combinedScore(playableVisible[i]) > combinedScore(playableVisible[j])
@@ -443,12 +526,13 @@ func (m *Manager) GetNarrationCandidates(limit int, minScore *float64) []*model.
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	ttl := m.config.RepeatTTL(context.Background())
+	ctx := context.Background()
+	ttl := m.config.RepeatTTL(ctx)
 	candidates := make([]*model.POI, 0, len(m.trackedPOIs))
 
 	for _, p := range m.trackedPOIs {
-		// 1. Geographical "Hidden" features are never auto-narrated
-		if p.IsHiddenFeature {
+		// 1. Geographical "Hidden" features, blocked and suppressed POIs are never auto-narrated
+		if p.IsHiddenFeature || m.IsBlocked(ctx, p) || (m.isSuppressed != nil && m.isSuppressed(p.WikidataID)) {
 			continue
 		}
 
@@ -471,8 +555,15 @@ func (m *Manager) GetNarrationCandidates(limit int, minScore *float64) []*model.
 	}
 
 	// Sort Descending by combined score (Intrinsic × Visibility)
+	tieBreak := m.config.AppConfig().Scorer.TieBreak
+	origin := geo.Point{Lat: m.lastScoredLat, Lon: m.lastScoredLon}
 	sort.Slice(candidates, func(i, j int) bool {
-		return candidates[i].Score*candidates[i].Visibility > candidates[j].Score*candidates[j].Visibility
+		ci := candidates[i].Score * candidates[i].Visibility
+		cj := candidates[j].Score * candidates[j].Visibility
+		if ci != cj {
+			return ci > cj
+		}
+		return tieBreakLess(candidates[i], candidates[j], tieBreak, origin)
 	})
 
 	if len(candidates) > limit {
@@ -481,6 +572,82 @@ func (m *Manager) GetNarrationCandidates(limit int, minScore *float64) []*model.
 	return candidates
 }
 
+// GetPriorityCandidates returns the tracked priority landmarks within the
+// priority radius of the given position, nearest first. Score and
+// visibility don't matter; blocked, suppressed and recently played POIs are
+// still left out.
+func (m *Manager) GetPriorityCandidates(lat, lon float64) []*model.POI {
+	ctx := context.Background()
+	qids := m.config.PriorityQIDs(ctx)
+	if len(qids) == 0 {
+		return nil
+	}
+	radius := float64(m.config.PriorityRadius(ctx))
+	ttl := m.config.RepeatTTL(ctx)
+	origin := geo.Point{Lat: lat, Lon: lon}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var candidates []*model.POI
+	for _, qid := range qids {
+		p, ok := m.trackedPOIs[qid]
+		if !ok || m.IsBlocked(ctx, p) || (m.isSuppressed != nil && m.isSuppressed(qid)) || !m.isPlayable(p, ttl) {
+			continue
+		}
+		if geo.Distance(origin, geo.Point{Lat: p.Lat, Lon: p.Lon}) <= radius {
+			candidates = append(candidates, p)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		di := geo.Distance(origin, geo.Point{Lat: candidates[i].Lat, Lon: candidates[i].Lon})
+		dj := geo.Distance(origin, geo.Point{Lat: candidates[j].Lat, Lon: candidates[j].Lon})
+		return di < dj
+	})
+	return candidates
+}
+
+// Tie-break keys for candidates with equal scores.
+const (
+	TieBreakSitelinks     = "sitelinks"
+	TieBreakArticleLength = "article_length"
+	TieBreakDistance      = "distance"
+)
+
+var defaultTieBreak = []string{TieBreakSitelinks, TieBreakArticleLength, TieBreakDistance}
+
+// tieBreakLess orders two equally scored POIs by the given keys, favouring
+// the more notable one, and finally by QID so the best pick doesn't flip
+// between ticks with map iteration order. Distance is skipped until a
+// scoring pass has set origin.
+func tieBreakLess(a, b *model.POI, keys []string, origin geo.Point) bool {
+	if len(keys) == 0 {
+		keys = defaultTieBreak
+	}
+	for _, k := range keys {
+		switch k {
+		case TieBreakSitelinks:
+			if a.Sitelinks != b.Sitelinks {
+				return a.Sitelinks > b.Sitelinks
+			}
+		case TieBreakArticleLength:
+			if a.WPArticleLength != b.WPArticleLength {
+				return a.WPArticleLength > b.WPArticleLength
+			}
+		case TieBreakDistance:
+			if origin == (geo.Point{}) {
+				continue
+			}
+			da := geo.Distance(origin, geo.Point{Lat: a.Lat, Lon: a.Lon})
+			db := geo.Distance(origin, geo.Point{Lat: b.Lat, Lon: b.Lon})
+			if da != db {
+				return da < db
+			}
+		}
+	}
+	return a.WikidataID < b.WikidataID
+}
+
 // isPlayable helper checks if a POI is on cooldown.
 func (m *Manager) isPlayable(p *model.POI, ttl time.Duration) bool {
 	return !p.IsOnCooldown(ttl)
@@ -535,6 +702,12 @@ func (m *Manager) NotifyScoringComplete(ctx context.Context, t *sim.Telemetry, l
 	// If dynamic setting is needed, we should lock/atomic load.
 	// Assuming setup happens before runtime or we lock.
 	// Current SetScoringCallback is not locked, but usually called at init.
+	if t != nil {
+		m.mu.Lock()
+		m.lastScoredHeading = t.Heading
+		m.mu.Unlock()
+	}
+	m.updateAdaptiveThreshold(ctx)
 	if m.onScoringComplete != nil {
 		m.onScoringComplete(ctx, t)
 	}
@@ -595,6 +768,31 @@ func (m *Manager) ResetLastPlayed(ctx context.Context, lat, lon, radius float64)
 	return m.store.ResetLastPlayed(ctx, lat, lon, radius)
 }
 
+// PurgeArea deletes the POIs within radius meters from the store and stops
+// tracking them, including tracked POIs that were never saved. Returns the
+// number of POIs deleted from the store.
+func (m *Manager) PurgeArea(ctx context.Context, lat, lon, radius float64) (int, error) {
+	qids, err := m.store.DeletePOIsInRadius(ctx, lat, lon, radius)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete POIs: %w", err)
+	}
+
+	m.mu.Lock()
+	for _, qid := range qids {
+		delete(m.trackedPOIs, qid)
+	}
+	center := geo.Point{Lat: lat, Lon: lon}
+	for id, p := range m.trackedPOIs {
+		if geo.Distance(center, geo.Point{Lat: p.Lat, Lon: p.Lon}) <= radius {
+			delete(m.trackedPOIs, id)
+		}
+	}
+	m.mu.Unlock()
+
+	m.logger.Info("Purged POIs", "lat", lat, "lon", lon, "radius_m", radius, "pois", len(qids))
+	return len(qids), nil
+}
+
 // ResetSession clears the in-memory cache of tracked POIs.
 // This is called on teleportation to remove POIs from the previous location.
 // It does NOT clear the database history (preserved for "seen" filtering).
@@ -602,14 +800,15 @@ func (m *Manager) ResetSession(ctx context.Context) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Clear all tracked POIs
-	// Go optimized map clearing:
-	for k := range m.trackedPOIs {
-		delete(m.trackedPOIs, k)
+	// Clear all tracked POIs except the user's, which no tile load would
+	// bring back. Reallocate to avoid GC overhead if map size varies wildly.
+	kept := make(map[string]*model.POI)
+	for k, p := range m.trackedPOIs {
+		if p.Source == model.SourceUser {
+			kept[k] = p
+		}
 	}
-	// Or simply reallocate: m.trackedPOIs = make(map[string]*model.POI)
-	// Reallocation is safer to avoid GC overhead of large maps if map size varies wildly.
-	m.trackedPOIs = make(map[string]*model.POI)
+	m.trackedPOIs = kept
 
 	// Reset consistency state
 	m.lastScoredLat = 0
@@ -53,6 +53,17 @@ func (s *MockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius float6
 	return nil
 }
 
+func (s *MockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	var qids []string
+	for id, p := range s.savedPOIs {
+		if math.Abs(p.Lat-lat) <= radius/111000.0 && math.Abs(p.Lon-lon) <= radius/111000.0 {
+			qids = append(qids, id)
+			delete(s.savedPOIs, id)
+		}
+	}
+	return qids, nil
+}
+
 // Stubs for other interface methods...
 func (s *MockStore) GetPOI(ctx context.Context, id string) (*model.POI, error) {
 	return s.savedPOIs[id], nil
@@ -203,6 +214,73 @@ func TestManager_ActiveTracking(t *testing.T) {
 
 }
 
+func TestManager_EnsureIcon(t *testing.T) {
+	catCfg := &config.CategoriesConfig{
+		Categories: map[string]config.Category{
+			"castle": {Icon: "castle", IconArtistic: "fort"},
+		},
+		IconSets: map[string]map[string]string{"minimal": {"castle": "square"}},
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		set      string
+		category string
+		stored   string
+		want     string
+	}{
+		{name: "Default set", set: config.DefaultIconSet, category: "Castle", want: "castle"},
+		{name: "Active set replaces stored icon", set: "minimal", category: "castle", stored: "castle", want: "square"},
+		{name: "Internal fallback", set: "minimal", category: "Area", want: "circle-stroked"},
+		{name: "Unknown category keeps stored icon", set: "minimal", category: "shop", stored: "shop", want: "shop"},
+		{name: "Generic marker", set: "minimal", category: "shop", want: config.GenericIcon},
+		{name: "Generic marker without category", set: "minimal", want: config.GenericIcon},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			appCfg := config.DefaultConfig()
+			appCfg.Overlay.IconSet = tt.set
+			mgr := NewManager(config.NewProvider(appCfg, nil), NewMockStore(), catCfg)
+
+			p := &model.POI{WikidataID: "Q1", NameEn: "P1", Category: tt.category, Icon: tt.stored}
+			if err := mgr.TrackPOI(ctx, p); err != nil {
+				t.Fatalf("TrackPOI failed: %v", err)
+			}
+			if p.Icon != tt.want {
+				t.Errorf("Icon = %q, want %q", p.Icon, tt.want)
+			}
+		})
+	}
+}
+
+func TestManager_PurgeArea(t *testing.T) {
+	mockStore := NewMockStore()
+	mgr := NewManager(config.NewProvider(&config.Config{}, nil), mockStore, nil)
+	ctx := context.Background()
+
+	_ = mgr.UpsertPOI(ctx, &model.POI{WikidataID: "Q1", NameEn: "Near", Lat: 50.0, Lon: 10.0})
+	_ = mgr.UpsertPOI(ctx, &model.POI{WikidataID: "Q2", NameEn: "Far", Lat: 51.0, Lon: 10.0})
+	// Tracked only, never saved
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q3", NameEn: "Unsaved", Lat: 50.001, Lon: 10.0})
+
+	n, err := mgr.PurgeArea(ctx, 50.0, 10.0, 1000)
+	if err != nil {
+		t.Fatalf("PurgeArea failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 POI deleted from the store, got %d", n)
+	}
+	if _, ok := mockStore.savedPOIs["Q1"]; ok {
+		t.Error("Expected Q1 deleted from the store")
+	}
+
+	tracked := mgr.GetTrackedPOIs()
+	if len(tracked) != 1 || tracked[0].WikidataID != "Q2" {
+		t.Errorf("Expected only Q2 to stay tracked, got %d POIs", len(tracked))
+	}
+}
+
 func TestManager_Prune(t *testing.T) {
 	mockStore := NewMockStore()
 	mgr := NewManager(config.NewProvider(&config.Config{}, nil), mockStore, nil)
@@ -317,6 +395,71 @@ func TestManager_CandidateLogic(t *testing.T) {
 	}
 }
 
+func TestManager_GetNarrationCandidates_TieBreak(t *testing.T) {
+	tests := []struct {
+		name     string
+		tieBreak []string
+		a, b     model.POI
+		want     string
+	}{
+		{
+			name: "More sitelinks wins",
+			a:    model.POI{WikidataID: "Q1", Sitelinks: 5},
+			b:    model.POI{WikidataID: "Q2", Sitelinks: 40},
+			want: "Q2",
+		},
+		{
+			name: "Longer article breaks a sitelink tie",
+			a:    model.POI{WikidataID: "Q1", Sitelinks: 10, WPArticleLength: 9000},
+			b:    model.POI{WikidataID: "Q2", Sitelinks: 10, WPArticleLength: 2000},
+			want: "Q1",
+		},
+		{
+			name: "Closer wins when prominence is equal",
+			a:    model.POI{WikidataID: "Q1", Lat: 0.5},
+			b:    model.POI{WikidataID: "Q2", Lat: 0.1},
+			want: "Q2",
+		},
+		{
+			name:     "Reordered keys",
+			tieBreak: []string{"article_length", "sitelinks"},
+			a:        model.POI{WikidataID: "Q1", Sitelinks: 50, WPArticleLength: 1000},
+			b:        model.POI{WikidataID: "Q2", Sitelinks: 5, WPArticleLength: 8000},
+			want:     "Q2",
+		},
+		{
+			name: "QID as last resort",
+			a:    model.POI{WikidataID: "Q2"},
+			b:    model.POI{WikidataID: "Q1"},
+			want: "Q1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Scorer.TieBreak = tt.tieBreak
+			mgr := NewManager(config.NewProvider(cfg, nil), NewMockStore(), nil)
+			mgr.UpdateScoringState(0.01, 0)
+
+			for _, p := range []model.POI{tt.a, tt.b} {
+				p.NameEn = p.WikidataID
+				p.Score = 7.5
+				p.Visibility = 1.0
+				p.IsVisible = true
+				_ = mgr.TrackPOI(context.Background(), &p)
+			}
+
+			// Repeat to catch map-order dependent picks
+			for range 10 {
+				if got := mgr.GetNarrationCandidates(1, nil); len(got) != 1 || got[0].WikidataID != tt.want {
+					t.Fatalf("expected %s to win the tie, got %v", tt.want, got)
+				}
+			}
+		})
+	}
+}
+
 func TestManager_ResetLastPlayed(t *testing.T) {
 	mgr := NewManager(config.NewProvider(&config.Config{}, nil), NewMockStore(), nil)
 	ctx := context.Background()
@@ -530,6 +673,19 @@ func TestManager_GetNarrationCandidates(t *testing.T) {
 	}
 }
 
+func TestManager_GetNarrationCandidates_Suppressed(t *testing.T) {
+	mgr := NewManager(config.NewProvider(&config.Config{}, nil), NewMockStore(), nil)
+	ctx := context.Background()
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "P1", NameEn: "P1", Score: 10, Visibility: 1, IsVisible: true})
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "P2", NameEn: "P2", Score: 8, Visibility: 1, IsVisible: true})
+
+	mgr.SetSuppressionCheck(func(qid string) bool { return qid == "P1" })
+	got := mgr.GetNarrationCandidates(10, nil)
+	if len(got) != 1 || got[0].WikidataID != "P2" {
+		t.Errorf("expected only P2, got %v", got)
+	}
+}
+
 func TestManager_CountScoredAbove_Competition(t *testing.T) {
 	mgr := NewManager(config.NewProvider(&config.Config{}, nil), NewMockStore(), nil)
 	ctx := context.Background()
@@ -673,3 +829,27 @@ func TestManager_UpdateRivers(t *testing.T) {
 		t.Errorf("Expected nil POI for unknown river, got %v", p)
 	}
 }
+
+func TestManager_GetPriorityCandidates(t *testing.T) {
+	ctx := context.Background()
+	cfg := config.DefaultConfig()
+	cfg.Narrator.Priority.QIDs = []string{"Q_NEAR", "Q_FAR", "Q_PLAYED", "Q_CLOSEST"}
+	cfg.Narrator.Priority.Radius = config.Distance(10000)
+	mgr := NewManager(config.NewProvider(cfg, nil), NewMockStore(), nil)
+
+	// ~5.5km, ~22km, ~1km and ~1km north of the aircraft at 48,0
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q_NEAR", NameEn: "Near", Lat: 48.05, Score: 0.1})
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q_FAR", NameEn: "Far", Lat: 48.2})
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q_PLAYED", NameEn: "Played", Lat: 48.01, LastPlayed: time.Now()})
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q_CLOSEST", NameEn: "Closest", Lat: 48.009})
+	_ = mgr.TrackPOI(ctx, &model.POI{WikidataID: "Q_OTHER", NameEn: "Other", Lat: 48.0, Score: 100, IsVisible: true})
+
+	got := mgr.GetPriorityCandidates(48.0, 0)
+	if len(got) != 2 || got[0].WikidataID != "Q_CLOSEST" || got[1].WikidataID != "Q_NEAR" {
+		ids := make([]string, len(got))
+		for i, p := range got {
+			ids[i] = p.WikidataID
+		}
+		t.Errorf("expected [Q_CLOSEST Q_NEAR], got %v", ids)
+	}
+}
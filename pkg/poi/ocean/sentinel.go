@@ -0,0 +1,229 @@
+package ocean
+
+import (
+	"log/slog"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+	"github.com/paulmach/orb/planar"
+
+	"phileasgo/pkg/geo"
+	geodata "phileasgo/pkg/geo/data"
+)
+
+// Water describes the open water below the aircraft.
+type Water struct {
+	Name       string // Sea or ocean name; empty where no marine area is known
+	WikidataID string
+	Lake       bool // Not part of any marine area, e.g. the Caspian
+}
+
+type area struct {
+	name       string
+	wikidataID string
+	geom       orb.Geometry
+	bbox       orb.Bound
+}
+
+// Sentinel detects when the aircraft is over open water, far from any land.
+type Sentinel struct {
+	logger *slog.Logger
+	land   []area
+	seas   []area
+	coast  map[cell][]segment // Land boundary segments by the cells they touch
+	mu     sync.RWMutex
+}
+
+// cell is a coastCellDeg square of the coastline index, by its south-west
+// corner.
+type cell struct{ lat, lon int }
+
+type segment struct{ a, b geo.Point }
+
+// coastCellDeg is the cell size of the coastline index. A check only looks at
+// the segments in the cells around the position instead of every coastline
+// in the world.
+const coastCellDeg = 1.0
+
+func cellOf(lat, lon float64) cell {
+	return cell{int(math.Floor(lat / coastCellDeg)), int(math.Floor(lon / coastCellDeg))}
+}
+
+// NewSentinel loads the land mask and the marine areas from GeoJSON files.
+// marinePath may be empty, in which case open water is never named or told
+// apart from lakes.
+func NewSentinel(logger *slog.Logger, landPath, marinePath string) *Sentinel {
+	s := &Sentinel{logger: logger}
+	if err := s.loadData(landPath, &s.land); err != nil {
+		logger.Error("Failed to load land data", "path", landPath, "err", err)
+	}
+	s.indexCoast()
+	if marinePath != "" {
+		if err := s.loadData(marinePath, &s.seas); err != nil {
+			logger.Error("Failed to load marine data", "path", marinePath, "err", err)
+		}
+	}
+	s.logger.Info("Loaded open water data", "land", len(s.land), "seas", len(s.seas))
+	return s
+}
+
+// NewSentinelEmbedded uses the embedded country boundaries as the land mask
+// and the embedded marine areas for naming.
+func NewSentinelEmbedded(logger *slog.Logger) *Sentinel {
+	s := &Sentinel{logger: logger}
+	if err := s.loadDataFromBytes(geo.CountriesGeoJSON, &s.land); err != nil {
+		logger.Error("Failed to load embedded land data", "err", err)
+	}
+	s.indexCoast()
+	if err := s.loadDataFromBytes(geodata.MarineGeoJSON, &s.seas); err != nil {
+		logger.Error("Failed to load embedded marine data", "err", err)
+	}
+	s.logger.Info("Loaded open water data (embedded)", "land", len(s.land), "seas", len(s.seas))
+	return s
+}
+
+func (s *Sentinel) loadData(path string, dst *[]area) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return s.loadDataFromBytes(data, dst)
+}
+
+func (s *Sentinel) loadDataFromBytes(data []byte, dst *[]area) error {
+	fc, err := geojson.UnmarshalFeatureCollection(data)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range fc.Features {
+		switch f.Geometry.(type) {
+		case orb.Polygon, orb.MultiPolygon:
+		default:
+			continue
+		}
+		name, _ := f.Properties["name"].(string)
+		if name == "" {
+			name, _ = f.Properties["NAME"].(string)
+		}
+		qid, _ := f.Properties["qid"].(string)
+		*dst = append(*dst, area{
+			name:       name,
+			wikidataID: qid,
+			geom:       f.Geometry,
+			bbox:       f.Geometry.Bound(),
+		})
+	}
+	return nil
+}
+
+// Check returns the water below the position, or nil when the position is on
+// land or within minDistM of it.
+func (s *Sentinel) Check(lat, lon, minDistM float64) *Water {
+	p := orb.Point{lon, lat}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.land) == 0 {
+		// Without a land mask everything would look like open water
+		return nil
+	}
+	if s.nearLand(p, minDistM) {
+		return nil
+	}
+
+	w := &Water{Lake: len(s.seas) > 0}
+	bestSize := math.MaxFloat64
+	for _, a := range s.seas {
+		if !a.bbox.Contains(p) || !contains(a.geom, p) {
+			continue
+		}
+		w.Lake = false
+		// Marine areas nest (a gulf inside an ocean); the smallest is the
+		// most specific name.
+		if size := (a.bbox.Max[0] - a.bbox.Min[0]) * (a.bbox.Max[1] - a.bbox.Min[1]); size < bestSize {
+			bestSize = size
+			w.Name = a.name
+			w.WikidataID = a.wikidataID
+		}
+	}
+	return w
+}
+
+// indexCoast buckets every land boundary segment into the cells its bounding
+// box touches.
+func (s *Sentinel) indexCoast() {
+	s.coast = make(map[cell][]segment)
+	for _, a := range s.land {
+		var polys []orb.Polygon
+		switch geom := a.geom.(type) {
+		case orb.Polygon:
+			polys = []orb.Polygon{geom}
+		case orb.MultiPolygon:
+			polys = geom
+		}
+		for _, poly := range polys {
+			for _, ring := range poly {
+				for i := 0; i < len(ring)-1; i++ {
+					seg := segment{
+						a: geo.Point{Lat: ring[i][1], Lon: ring[i][0]},
+						b: geo.Point{Lat: ring[i+1][1], Lon: ring[i+1][0]},
+					}
+					lo := cellOf(math.Min(seg.a.Lat, seg.b.Lat), math.Min(seg.a.Lon, seg.b.Lon))
+					hi := cellOf(math.Max(seg.a.Lat, seg.b.Lat), math.Max(seg.a.Lon, seg.b.Lon))
+					for la := lo.lat; la <= hi.lat; la++ {
+						for lo2 := lo.lon; lo2 <= hi.lon; lo2++ {
+							c := cell{la, lo2}
+							s.coast[c] = append(s.coast[c], seg)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// nearLand reports whether p is on land or within distM of its coastline.
+func (s *Sentinel) nearLand(p orb.Point, distM float64) bool {
+	for _, a := range s.land {
+		if a.bbox.Contains(p) && contains(a.geom, p) {
+			return true
+		}
+	}
+	if distM <= 0 {
+		return false
+	}
+
+	// Pad the search by the distance; longitude degrees shrink towards the
+	// poles, so cap the scale to keep the pad finite.
+	padLat := distM / 111320.0
+	padLon := math.Min(padLat/math.Max(math.Cos(p[1]*math.Pi/180.0), 0.01), 180)
+	pos := geo.Point{Lat: p[1], Lon: p[0]}
+
+	lo := cellOf(p[1]-padLat, p[0]-padLon)
+	hi := cellOf(p[1]+padLat, p[0]+padLon)
+	for la := lo.lat; la <= hi.lat; la++ {
+		for lon := lo.lon; lon <= hi.lon; lon++ {
+			for _, seg := range s.coast[cell{la, lon}] {
+				if d, _ := geo.DistancePointSegment(pos, seg.a, seg.b); d <= distM {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+func contains(g orb.Geometry, p orb.Point) bool {
+	switch geom := g.(type) {
+	case orb.Polygon:
+		return planar.PolygonContains(geom, p)
+	case orb.MultiPolygon:
+		return planar.MultiPolygonContains(geom, p)
+	}
+	return false
+}
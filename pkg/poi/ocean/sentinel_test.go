@@ -0,0 +1,106 @@
+package ocean
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// quietLogger returns a logger that discards all output.
+func quietLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// An island spanning 0..1 degrees in both axes.
+const landGeoJSON = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"properties": {"name": "Island"},
+			"geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]]}
+		}
+	]
+}`
+
+// An ocean with a gulf inside it, both west of 5 degrees east.
+const marineGeoJSON = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"type": "Feature",
+			"properties": {"name": "Test Ocean", "qid": "Q1"},
+			"geometry": {"type": "Polygon", "coordinates": [[[-10, -10], [5, -10], [5, 10], [-10, 10], [-10, -10]]]}
+		},
+		{
+			"type": "Feature",
+			"properties": {"name": "Test Gulf", "qid": "Q2"},
+			"geometry": {"type": "Polygon", "coordinates": [[[-5, -5], [-3, -5], [-3, -3], [-5, -3], [-5, -5]]]}
+		}
+	]
+}`
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestSentinel_Check(t *testing.T) {
+	s := NewSentinel(quietLogger(), writeFile(t, "land.geojson", landGeoJSON), writeFile(t, "marine.geojson", marineGeoJSON))
+
+	tests := []struct {
+		name     string
+		lat, lon float64
+		minDistM float64
+		wantNil  bool
+		wantName string
+		wantLake bool
+	}{
+		{name: "On land", lat: 0.5, lon: 0.5, minDistM: 50000, wantNil: true},
+		{name: "Near the coast", lat: 0.5, lon: 1.2, minDistM: 50000, wantNil: true},
+		{name: "Near the coast from a neighbouring cell", lat: -0.2, lon: 0.5, minDistM: 50000, wantNil: true},
+		{name: "Coastal waters with no threshold", lat: 0.5, lon: 1.2, minDistM: 0, wantName: "Test Ocean"},
+		{name: "Open ocean", lat: 5, lon: 4, minDistM: 50000, wantName: "Test Ocean"},
+		{name: "Nested gulf wins", lat: -4, lon: -4, minDistM: 50000, wantName: "Test Gulf"},
+		{name: "Outside marine areas is a lake", lat: 0, lon: 20, minDistM: 50000, wantLake: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := s.Check(tt.lat, tt.lon, tt.minDistM)
+			if tt.wantNil {
+				if got != nil {
+					t.Fatalf("expected nil, got %+v", got)
+				}
+				return
+			}
+			if got == nil {
+				t.Fatal("expected open water, got nil")
+			}
+			if got.Name != tt.wantName || got.Lake != tt.wantLake {
+				t.Errorf("got %+v, want name %q lake %v", got, tt.wantName, tt.wantLake)
+			}
+		})
+	}
+}
+
+func TestSentinel_MissingData(t *testing.T) {
+	// Without a land mask nothing counts as open water
+	s := NewSentinel(quietLogger(), "/nonexistent/land.geojson", "")
+	if got := s.Check(5, 4, 0); got != nil {
+		t.Errorf("expected nil without land data, got %+v", got)
+	}
+
+	// Without marine areas water is unnamed and never a lake
+	s = NewSentinel(quietLogger(), writeFile(t, "land.geojson", landGeoJSON), "")
+	got := s.Check(5, 4, 50000)
+	if got == nil || got.Name != "" || got.Lake {
+		t.Errorf("expected unnamed open water, got %+v", got)
+	}
+}
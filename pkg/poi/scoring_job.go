@@ -36,17 +36,21 @@ type ScoringManager interface {
 // re-scoring all POIs when the aircraft is parked or taxiing slowly.
 const minScoringDisplacementM = 128.0
 
+// defaultScoringInterval applies to jobs with no configured interval.
+const defaultScoringInterval = 5 * time.Second
+
 // ScoringJob manages the periodic scoring of POIs.
 type ScoringJob struct {
 	name    string
 	running int32 // Atomic lock
 
-	manager ScoringManager
-	sim     sim.Client
-	scorer  *scorer.Scorer
-	cfg     config.Provider
-	busyFn  func(qid string) bool
-	lastRun time.Time
+	manager  ScoringManager
+	sim      sim.Client
+	scorer   *scorer.Scorer
+	cfg      config.Provider
+	busyFn   func(qid string) bool
+	lastRun  time.Time
+	interval time.Duration
 
 	// State from the last full scoring pass, used to skip redundant passes.
 	lastScoredPos   geo.Point
@@ -64,14 +68,19 @@ func NewScoringJob(
 	busyFn func(qid string) bool,
 	logger *slog.Logger, // Optional
 ) *ScoringJob {
+	interval := cfg.AppConfig().Scheduler.Interval(jobName)
+	if interval <= 0 {
+		interval = defaultScoringInterval
+	}
 	return &ScoringJob{
-		name:    jobName,
-		manager: manager,
-		sim:     simClient,
-		scorer:  sc,
-		cfg:     cfg,
-		busyFn:  busyFn,
-		lastRun: time.Now(),
+		name:     jobName,
+		manager:  manager,
+		sim:      simClient,
+		scorer:   sc,
+		cfg:      cfg,
+		busyFn:   busyFn,
+		lastRun:  time.Now(),
+		interval: interval,
 	}
 }
 
@@ -85,13 +94,12 @@ func (j *ScoringJob) NeedsTelemetry() bool {
 	return true
 }
 
-// ShouldFire returns true if 5 seconds have passed since the last run.
+// ShouldFire returns true once the scoring interval has passed since the last run.
 func (j *ScoringJob) ShouldFire(t *sim.Telemetry) bool {
 	if atomic.LoadInt32(&j.running) == 1 {
 		return false
 	}
-	// Strict 5s interval check
-	return time.Since(j.lastRun) >= 5*time.Second
+	return time.Since(j.lastRun) >= j.interval
 }
 
 func (j *ScoringJob) TryLock() bool {
@@ -167,11 +175,13 @@ func (j *ScoringJob) performScoringPass(ctx context.Context) {
 	boostFactor := j.manager.GetBoostFactor(ctx)
 
 	input := scorer.ScoringInput{
-		Telemetry:       telemetry,
-		CategoryHistory: history,
-		RepeatTTL:       j.cfg.RepeatTTL(ctx),
-		BoostFactor:     boostFactor,
-		IsPOIBusy:       j.busyFn,
+		Telemetry:        telemetry,
+		CategoryHistory:  history,
+		RepeatTTL:        j.cfg.RepeatTTL(ctx),
+		BoostFactor:      boostFactor,
+		IsPOIBusy:        j.busyFn,
+		InterestKeywords: j.cfg.InterestKeywords(ctx),
+		InterestBoost:    j.cfg.InterestBoost(ctx),
 	}
 
 	// Create Scoring Session (Pre-calculates terrain/context once)
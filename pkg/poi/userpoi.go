@@ -0,0 +1,201 @@
+package poi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+
+	"phileasgo/pkg/model"
+)
+
+// defaultUserCategory applies to imported POIs without a category.
+const defaultUserCategory = "Attraction"
+
+// ErrInvalidUserPOI is returned for import files with unusable entries.
+var ErrInvalidUserPOI = errors.New("invalid user POI")
+
+// userPOISource lists POIs by source. The SQLite store implements it; user
+// POIs are only restored at startup where it does.
+type userPOISource interface {
+	GetPOIsBySource(ctx context.Context, source string) ([]*model.POI, error)
+}
+
+// ParseUserPOIs reads POIs from a GeoJSON FeatureCollection of points or a
+// CSV file with a header row. Both take a name, coordinates, and optionally a
+// description and category. The format is detected from the content.
+func ParseUserPOIs(r io.Reader) ([]*model.POI, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import: %w", err)
+	}
+	// Spreadsheet exports often start with a byte order mark
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\ufeff")), " \t\r\n")
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		return parseUserGeoJSON(trimmed)
+	}
+	return parseUserCSV(bytes.NewReader(trimmed))
+}
+
+func parseUserGeoJSON(data []byte) ([]*model.POI, error) {
+	fc, err := geojson.UnmarshalFeatureCollection(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: not a GeoJSON FeatureCollection: %v", ErrInvalidUserPOI, err)
+	}
+
+	pois := make([]*model.POI, 0, len(fc.Features))
+	for i, f := range fc.Features {
+		pt, ok := f.Geometry.(orb.Point)
+		if !ok {
+			return nil, fmt.Errorf("%w: feature %d is not a point", ErrInvalidUserPOI, i+1)
+		}
+		p, err := newUserPOI(f.Properties.MustString("name", ""), pt.Lat(), pt.Lon(),
+			f.Properties.MustString("description", ""), f.Properties.MustString("category", ""))
+		if err != nil {
+			return nil, fmt.Errorf("feature %d: %w", i+1, err)
+		}
+		pois = append(pois, p)
+	}
+	return pois, nil
+}
+
+func parseUserCSV(r io.Reader) ([]*model.POI, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: missing CSV header: %v", ErrInvalidUserPOI, err)
+	}
+
+	cols := make(map[string]int)
+	for i, h := range header {
+		switch strings.ToLower(strings.TrimSpace(h)) {
+		case "name":
+			cols["name"] = i
+		case "lat", "latitude":
+			cols["lat"] = i
+		case "lon", "lng", "longitude":
+			cols["lon"] = i
+		case "description":
+			cols["description"] = i
+		case "category":
+			cols["category"] = i
+		}
+	}
+	for _, c := range []string{"name", "lat", "lon"} {
+		if _, ok := cols[c]; !ok {
+			return nil, fmt.Errorf("%w: CSV header lacks a %q column", ErrInvalidUserPOI, c)
+		}
+	}
+	field := func(rec []string, col string) string {
+		i, ok := cols[col]
+		if !ok || i >= len(rec) {
+			return ""
+		}
+		return strings.TrimSpace(rec[i])
+	}
+
+	var pois []*model.POI
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidUserPOI, line, err)
+		}
+		lat, errLat := strconv.ParseFloat(field(rec, "lat"), 64)
+		lon, errLon := strconv.ParseFloat(field(rec, "lon"), 64)
+		if errLat != nil || errLon != nil {
+			return nil, fmt.Errorf("%w: line %d: coordinates are not numbers", ErrInvalidUserPOI, line)
+		}
+		p, err := newUserPOI(field(rec, "name"), lat, lon, field(rec, "description"), field(rec, "category"))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		pois = append(pois, p)
+	}
+	return pois, nil
+}
+
+func newUserPOI(name string, lat, lon float64, description, category string) (*model.POI, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: missing name", ErrInvalidUserPOI)
+	}
+	if math.IsNaN(lat) || math.IsNaN(lon) || lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return nil, fmt.Errorf("%w: %q has coordinates out of range (%g, %g)", ErrInvalidUserPOI, name, lat, lon)
+	}
+	// Null Island is what an empty cell or a failed geocode looks like
+	if lat == 0 && lon == 0 {
+		return nil, fmt.Errorf("%w: %q has no coordinates", ErrInvalidUserPOI, name)
+	}
+	if category == "" {
+		category = defaultUserCategory
+	}
+
+	return &model.POI{
+		WikidataID:  userPOIID(name, lat, lon),
+		Source:      model.SourceUser,
+		Category:    category,
+		Lat:         lat,
+		Lon:         lon,
+		NameEn:      name,
+		NameUser:    name,
+		Description: strings.TrimSpace(description),
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+// userPOIID derives a stable ID, so importing a file again updates its POIs
+// instead of duplicating them.
+func userPOIID(name string, lat, lon float64) string {
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%.5f|%.5f", strings.ToLower(name), lat, lon)))
+	return "user_" + hex.EncodeToString(sum[:6])
+}
+
+// ImportUserPOIs saves and tracks the given user POIs. They stay tracked
+// wherever the aircraft goes; scoring decides when they are in range.
+func (m *Manager) ImportUserPOIs(ctx context.Context, pois []*model.POI) (int, error) {
+	count := 0
+	for _, p := range pois {
+		if err := m.UpsertPOI(ctx, p); err != nil {
+			return count, err
+		}
+		count++
+	}
+	m.logger.Info("Imported user POIs", "count", count)
+	return count, nil
+}
+
+// LoadUserPOIs tracks the user POIs saved by earlier imports.
+func (m *Manager) LoadUserPOIs(ctx context.Context) (int, error) {
+	src, ok := m.store.(userPOISource)
+	if !ok {
+		return 0, nil
+	}
+	pois, err := src.GetPOIsBySource(ctx, model.SourceUser)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load user POIs: %w", err)
+	}
+	for _, p := range pois {
+		if err := m.TrackPOI(ctx, p); err != nil {
+			return 0, err
+		}
+	}
+	if len(pois) > 0 {
+		m.logger.Info("Loaded user POIs", "count", len(pois))
+	}
+	return len(pois), nil
+}
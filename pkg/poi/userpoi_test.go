@@ -0,0 +1,144 @@
+package poi
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/model"
+)
+
+func (s *MockStore) GetPOIsBySource(ctx context.Context, source string) ([]*model.POI, error) {
+	var result []*model.POI
+	for _, p := range s.savedPOIs {
+		if p.Source == source {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func TestParseUserPOIs(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		wantNames []string
+		wantErr   string
+	}{
+		{
+			name: "GeoJSON points",
+			input: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {"name": "Family Cabin", "description": "Built by grandpa in 1962."},
+				 "geometry": {"type": "Point", "coordinates": [10.75, 59.91]}}
+			]}`,
+			wantNames: []string{"Family Cabin"},
+		},
+		{
+			name:      "CSV with a byte order mark and aliases",
+			input:     "\ufeffName,Latitude,Lng,Description,Category\nOld Bridge,47.5,8.7,Where we met,Bridge\nLake Spot,47.6,8.8,,\n",
+			wantNames: []string{"Old Bridge", "Lake Spot"},
+		},
+		{
+			name:    "CSV without coordinates column",
+			input:   "name,description\nCabin,Nice\n",
+			wantErr: `lacks a "lat" column`,
+		},
+		{
+			name:    "Latitude out of range",
+			input:   "name,lat,lon\nCabin,91,10\n",
+			wantErr: "line 2",
+		},
+		{
+			name:    "Empty coordinates",
+			input:   "name,lat,lon\nCabin,0,0\n",
+			wantErr: "no coordinates",
+		},
+		{
+			name: "GeoJSON line",
+			input: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {"name": "Road"}, "geometry": {"type": "LineString", "coordinates": [[0, 1], [1, 1]]}}
+			]}`,
+			wantErr: "not a point",
+		},
+		{
+			name: "Missing name",
+			input: `{"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {}, "geometry": {"type": "Point", "coordinates": [10, 50]}}
+			]}`,
+			wantErr: "missing name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pois, err := ParseUserPOIs(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !errors.Is(err, ErrInvalidUserPOI) || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pois) != len(tt.wantNames) {
+				t.Fatalf("expected %d POIs, got %d", len(tt.wantNames), len(pois))
+			}
+			for i, p := range pois {
+				if p.NameEn != tt.wantNames[i] || p.Source != model.SourceUser || !strings.HasPrefix(p.WikidataID, "user_") {
+					t.Errorf("unexpected POI %+v", p)
+				}
+				if p.Category == "" {
+					t.Errorf("expected a default category for %s", p.NameEn)
+				}
+			}
+		})
+	}
+}
+
+func TestUserPOIID_Stable(t *testing.T) {
+	a := userPOIID("Family Cabin", 59.91, 10.75)
+	if b := userPOIID("family cabin", 59.910001, 10.75); a != b {
+		t.Errorf("expected re-imports to keep the ID, got %s and %s", a, b)
+	}
+	if c := userPOIID("Family Cabin", 59.92, 10.75); a == c {
+		t.Error("expected a different spot to get a different ID")
+	}
+}
+
+func TestManager_UserPOIsStayTracked(t *testing.T) {
+	ctx := context.Background()
+	st := NewMockStore()
+	m := NewManager(config.NewProvider(config.DefaultConfig(), nil), st, nil)
+
+	pois, err := ParseUserPOIs(strings.NewReader("name,lat,lon\nFar Cabin,10,10\n"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if n, err := m.ImportUserPOIs(ctx, pois); err != nil || n != 1 {
+		t.Fatalf("ImportUserPOIs() = %d, %v", n, err)
+	}
+	id := pois[0].WikidataID
+
+	// Far behind the aircraft: a Wikidata POI would be pruned here
+	m.PruneByDistance(50, 50, 0, 10)
+	if p, _ := m.GetPOI(ctx, id); p == nil || len(m.GetTrackedPOIs()) != 1 {
+		t.Fatal("expected the user POI to survive pruning")
+	}
+
+	m.ResetSession(ctx)
+	if len(m.GetTrackedPOIs()) != 1 {
+		t.Fatal("expected the user POI to survive a session reset")
+	}
+
+	// A restart restores it from the store
+	m2 := NewManager(config.NewProvider(config.DefaultConfig(), nil), st, nil)
+	if n, err := m2.LoadUserPOIs(ctx); err != nil || n != 1 {
+		t.Fatalf("LoadUserPOIs() = %d, %v", n, err)
+	}
+	if len(m2.GetTrackedPOIs()) != 1 {
+		t.Error("expected the restored user POI to be tracked")
+	}
+}
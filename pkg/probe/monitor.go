@@ -0,0 +1,73 @@
+package probe
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Component statuses reported by Summarize.
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded" // A non-critical probe failed; the app still narrates
+	StatusDown     = "down"     // A critical probe failed
+)
+
+// Monitor runs probes on demand and reuses the results for a short while, so
+// a polling dashboard doesn't turn every refresh into a round of LLM and
+// database calls.
+type Monitor struct {
+	probes []Probe
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu      sync.Mutex
+	results []Result
+	checked time.Time
+}
+
+// NewMonitor creates a monitor for probes whose results stay fresh for ttl.
+func NewMonitor(probes []Probe, ttl time.Duration) *Monitor {
+	return &Monitor{probes: probes, ttl: ttl, now: time.Now}
+}
+
+// Results returns the latest probe results and when they were taken, running
+// the probes again once the cached results are older than the TTL. Concurrent
+// callers wait for a single run rather than each starting their own.
+func (m *Monitor) Results(ctx context.Context) ([]Result, time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.results == nil || m.now().Sub(m.checked) >= m.ttl {
+		m.results = Run(ctx, m.probes)
+		m.checked = m.now()
+	}
+	return m.results, m.checked
+}
+
+// Summarize returns the overall status of a set of results: down if a critical
+// probe failed, degraded if only non-critical ones did, ok otherwise.
+func Summarize(results []Result) string {
+	status := StatusOK
+	for _, r := range results {
+		switch ComponentStatus(r) {
+		case StatusDown:
+			return StatusDown
+		case StatusDegraded:
+			status = StatusDegraded
+		}
+	}
+	return status
+}
+
+// ComponentStatus returns the status of a single result.
+func ComponentStatus(r Result) string {
+	switch {
+	case r.Error == nil:
+		return StatusOK
+	case r.Probe.Critical:
+		return StatusDown
+	default:
+		return StatusDegraded
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestRun(t *testing.T) {
@@ -85,3 +86,50 @@ func TestAnalyzeResults(t *testing.T) {
 		})
 	}
 }
+
+func TestMonitor_CachesResults(t *testing.T) {
+	calls := 0
+	m := NewMonitor([]Probe{{
+		Name:  "Counter",
+		Check: func(ctx context.Context) error { calls++; return nil },
+	}}, time.Minute)
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	m.now = func() time.Time { return now }
+
+	m.Results(context.Background())
+	m.Results(context.Background())
+	if calls != 1 {
+		t.Errorf("expected cached results within the TTL, got %d runs", calls)
+	}
+
+	now = now.Add(time.Minute)
+	_, checked := m.Results(context.Background())
+	if calls != 2 {
+		t.Errorf("expected a fresh run after the TTL, got %d runs", calls)
+	}
+	if !checked.Equal(now) {
+		t.Errorf("expected checked time %v, got %v", now, checked)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	fail := errors.New("unreachable")
+	tests := []struct {
+		name    string
+		results []Result
+		want    string
+	}{
+		{name: "All pass", results: []Result{{Probe: Probe{Critical: true}}, {}}, want: StatusOK},
+		{name: "Non-critical failure", results: []Result{{Probe: Probe{Critical: true}}, {Error: fail}}, want: StatusDegraded},
+		{name: "Critical failure", results: []Result{{Error: fail}, {Probe: Probe{Critical: true}, Error: fail}}, want: StatusDown},
+		{name: "No probes", want: StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Summarize(tt.results); got != tt.want {
+				t.Errorf("Summarize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,11 +2,15 @@ package prompt
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
 	"strings"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"phileasgo/pkg/articleproc"
 	"phileasgo/pkg/config"
@@ -15,6 +19,7 @@ import (
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/sim"
 	"phileasgo/pkg/wikidata"
+	"phileasgo/pkg/wikipedia"
 )
 
 type Assembler struct {
@@ -31,6 +36,8 @@ type Assembler struct {
 	density              *wikidata.DensityManager
 	interests            []string
 	avoid                []string
+	latency              func() time.Duration
+	facts                FactSource
 }
 
 func NewAssembler(
@@ -65,6 +72,18 @@ func NewAssembler(
 	}
 }
 
+// SetLatencySource supplies the average time from request to playback, which
+// the relevance fit subtracts from the time left near a POI.
+func (a *Assembler) SetLatencySource(latency func() time.Duration) {
+	a.latency = latency
+}
+
+// SetFactSource supplies the Wikidata facts quoted in POI prompts when
+// Narrator.WikidataFacts is on.
+func (a *Assembler) SetFactSource(facts FactSource) {
+	a.facts = facts
+}
+
 func (a *Assembler) NewPromptData(session SessionState) Data {
 	pd := make(Data)
 	a.injectPersona(pd, session)
@@ -74,7 +93,7 @@ func (a *Assembler) NewPromptData(session SessionState) Data {
 
 func (a *Assembler) ensureCommonKeys(pd Data) {
 	keys := []string{
-		"POINameUser", "POINameNative", "Category",
+		"POINameUser", "POINameNative", "Category", "CategoryLabel",
 		"WikipediaText", "PregroundContext", "RecentContext",
 		"Movement", "ClockPos", "RelativeDir", "CardinalDir",
 		"DistMeters", "DistKm", "DistNm",
@@ -85,6 +104,8 @@ func (a *Assembler) ensureCommonKeys(pd Data) {
 		"TTSInstructions", "UnitsInstruction", "UnitSystem",
 		"Persona", "Accent", "Language", "TourGuideName",
 		"FlightStage", "TargetLanguage", "Language_code", "Language_name", "Language_region_code",
+		"NavInstruction", "VehicleMode", "DistanceUnits", "AltitudeUnits",
+		"DimensionFacts", "WikidataFacts",
 	}
 
 	for _, k := range keys {
@@ -94,7 +115,7 @@ func (a *Assembler) ensureCommonKeys(pd Data) {
 	}
 
 	// Ensure numeric keys are present
-	numKeys := []string{"Lat", "Lon", "AltitudeMSL", "AltitudeAGL", "Heading", "GroundSpeed", "PredictedLat", "PredictedLon", "MaxWords"}
+	numKeys := []string{"Lat", "Lon", "AltitudeMSL", "AltitudeAGL", "AltitudeAGLMeters", "Heading", "GroundSpeed", "PredictedLat", "PredictedLon", "MaxWords"}
 	for _, k := range numKeys {
 		if _, ok := pd[k]; !ok {
 			pd[k] = 0
@@ -108,6 +129,9 @@ func (a *Assembler) ensureCommonKeys(pd Data) {
 	if _, ok := pd["IsOnGround"]; !ok {
 		pd["IsOnGround"] = false
 	}
+	if _, ok := pd["IsNight"]; !ok {
+		pd["IsNight"] = false
+	}
 
 	// Ensure slice keys
 	if _, ok := pd["Interests"]; !ok {
@@ -116,6 +140,9 @@ func (a *Assembler) ensureCommonKeys(pd Data) {
 	if _, ok := pd["Avoid"]; !ok {
 		pd["Avoid"] = []string{}
 	}
+	if _, ok := pd["AvoidRepeating"]; !ok {
+		pd["AvoidRepeating"] = []string{}
+	}
 }
 
 func (a *Assembler) ForPOI(ctx context.Context, p *model.POI, tel *sim.Telemetry, strategy string, session SessionState) Data {
@@ -123,6 +150,16 @@ func (a *Assembler) ForPOI(ctx context.Context, p *model.POI, tel *sim.Telemetry
 	a.injectTelemetry(pd, tel)
 	a.injectPOI(ctx, pd, p)
 	a.injectUnits(pd)
+	if p != nil {
+		a.followCountryLanguage(ctx, pd, p.Lat, p.Lon)
+	} else if tel != nil {
+		a.followCountryLanguage(ctx, pd, tel.Latitude, tel.Longitude)
+	}
+	// After the language is settled, which may follow the country
+	a.translateWikipediaText(ctx, pd, p)
+	a.localizeCategory(pd, p)
+	a.injectDimensions(ctx, pd, p)
+	a.injectFacts(ctx, pd, p)
 
 	// Custom/Specific logic for this request
 	wikiInfo := a.fetchWikipediaText(ctx, p)
@@ -132,7 +169,8 @@ func (a *Assembler) ForPOI(ctx context.Context, p *model.POI, tel *sim.Telemetry
 	}
 	pregroundWords := len(strings.Fields(pregroundText))
 
-	maxWords, domStrat := a.sampleNarrationLength(p, strategy, wikiInfo.WordCount+pregroundWords)
+	maxWords, domStrat := a.sampleNarrationLength(p, tel, strategy, wikiInfo.WordCount+pregroundWords)
+	maxWords, outlives := a.fitRelevanceWindow(p, tel, maxWords)
 
 	if p == nil {
 		pd["MaxWords"] = maxWords
@@ -157,6 +195,7 @@ func (a *Assembler) ForPOI(ctx context.Context, p *model.POI, tel *sim.Telemetry
 	pd["DomStrat"] = domStrat
 	pd["IsStub"] = isStub
 	pd["ArticleURL"] = p.WPURL
+	pd["OutlivesRelevance"] = outlives
 
 	// Inject raw navigation data for template-side logic
 	a.injectNavigationData(pd, p, tel)
@@ -171,6 +210,9 @@ func (a *Assembler) ForGeneric(ctx context.Context, tel *sim.Telemetry, session
 	pd := a.NewPromptData(session)
 	a.injectTelemetry(pd, tel)
 	a.injectUnits(pd)
+	if tel != nil {
+		a.followCountryLanguage(ctx, pd, tel.Latitude, tel.Longitude)
+	}
 	pd["TTSInstructions"] = a.fetchTTSInstructions(pd)
 	return pd
 }
@@ -183,12 +225,14 @@ func (a *Assembler) injectTelemetry(pd Data, t *sim.Telemetry) {
 	pd["Lon"] = t.Longitude
 	pd["AltitudeMSL"] = t.AltitudeMSL
 	pd["AltitudeAGL"] = t.AltitudeAGL
+	pd["AltitudeAGLMeters"] = t.AltitudeAGL * 0.3048
 	pd["Heading"] = t.Heading
 	pd["GroundSpeed"] = t.GroundSpeed
 	pd["PredictedLat"] = t.PredictedLatitude
 	pd["PredictedLon"] = t.PredictedLongitude
 	pd["FlightStage"] = sim.FormatStage(t.FlightStage)
 	pd["IsOnGround"] = t.IsOnGround
+	pd["IsNight"] = a.isNight(t)
 
 	// Geographical context for aircraft position
 	loc := a.geoSvc.GetLocation(t.Latitude, t.Longitude)
@@ -196,15 +240,29 @@ func (a *Assembler) injectTelemetry(pd Data, t *sim.Telemetry) {
 	pd["TargetCountry"] = loc.CountryName
 }
 
+// isNight reports whether it is dark at the aircraft. The sim's clock is
+// preferred since users often fly at a time of their choosing; clients that
+// don't report it fall back to the system clock.
+func (a *Assembler) isNight(t *sim.Telemetry) bool {
+	if !a.cfg.AppConfig().Narrator.NightToning {
+		return false
+	}
+	when := t.SimTime
+	if when.IsZero() {
+		when = time.Now()
+	}
+	return geo.IsNight(t.Latitude, t.Longitude, when)
+}
+
 func (a *Assembler) injectPersona(pd Data, session SessionState) {
 	appCfg := a.cfg.AppConfig()
-	pd["TourGuideName"] = "Ava"
+	pd["TourGuideName"] = GuideName
 	pd["Persona"] = "Intelligent, fascinating"
 	pd["Accent"] = "Neutral"
 	pd["Language"] = a.cfg.ActiveTargetLanguage(context.Background())
 	pd["FemalePersona"] = "Intelligent, fascinating"
 	pd["FemaleAccent"] = "Neutral"
-	pd["PassengerMale"] = "Andrew"
+	pd["PassengerMale"] = PassengerName
 	pd["MaleAccent"] = "Neutral"
 	pd["TripSummary"] = a.formatTripLog(session.Events)
 	pd["LastSentence"] = session.LastSentence
@@ -220,6 +278,7 @@ func (a *Assembler) injectPersona(pd Data, session SessionState) {
 	pd["MinPOIScore"] = a.cfg.MinScoreThreshold(context.Background())
 	pd["TextLengthScale"] = a.cfg.TextLengthScale(context.Background())
 	pd["UnitSetting"] = a.cfg.Units(context.Background())
+	pd["VehicleMode"] = a.cfg.VehicleMode(context.Background())
 	pd["Interests"] = a.interests
 	pd["Avoid"] = a.avoid
 
@@ -241,6 +300,35 @@ func (a *Assembler) injectPersona(pd Data, session SessionState) {
 	pd["Language_region_code"] = targetLang
 }
 
+// followCountryLanguage switches the language fields to the primary language
+// of the country at lat/lon. It is resolved per request, so a border crossing
+// takes effect with the next narration; positions outside a country or in a
+// country without a mapped language keep the configured language.
+func (a *Assembler) followCountryLanguage(ctx context.Context, pd Data, lat, lon float64) {
+	if !a.cfg.AutoFollowCountryLanguage(ctx) {
+		return
+	}
+	res, ok := a.langRes.(CountryLanguageResolver)
+	if !ok {
+		return
+	}
+	country := a.geoSvc.GetLocation(lat, lon).CountryCode
+	if country == "" {
+		return
+	}
+	info, ok := res.PrimaryLanguage(country)
+	if !ok || info.Code == "" {
+		return
+	}
+
+	locale := info.Code + "-" + strings.ToUpper(country)
+	pd["Language"] = locale
+	pd["TargetLanguage"] = locale
+	pd["Language_code"] = info.Code
+	pd["Language_name"] = info.Name
+	pd["Language_region_code"] = locale
+}
+
 func (a *Assembler) formatTripLog(events []model.TripEvent) string {
 	if len(events) == 0 {
 		return ""
@@ -283,24 +371,127 @@ func (a *Assembler) injectPOI(ctx context.Context, pd Data, p *model.POI) {
 	// Content
 	pd["WikipediaText"] = a.fetchWikipediaText(ctx, p).Prose
 	pd["PregroundContext"] = a.fetchPregroundContext(ctx, p)
-	pd["RecentContext"] = a.fetchRecentContext(ctx, p.Lat, p.Lon)
+	recent := a.fetchRecentNearby(ctx, p.Lat, p.Lon)
+	pd["RecentContext"] = formatRecentContext(recent)
+	if a.cfg.AppConfig().Narrator.AvoidRepetition {
+		pd["AvoidRepeating"] = recentTitles(recent, p.WikidataID)
+	}
+}
+
+// injectDimensions lists the Wikidata dimensions of a POI without an article
+// when Narrator.NoArticle asks for them, so the script can be built on what
+// made the POI worth rescuing instead of on nothing.
+func (a *Assembler) injectDimensions(ctx context.Context, pd Data, p *model.POI) {
+	if p == nil || p.HasArticle() || a.cfg.NoArticle(ctx) != config.NoArticleDimensions {
+		return
+	}
+	var facts []string
+	if p.Height > 0 {
+		facts = append(facts, fmt.Sprintf("Height: %.0f m", p.Height))
+	}
+	if p.Length > 0 {
+		facts = append(facts, fmt.Sprintf("Length: %.0f m", p.Length))
+	}
+	switch {
+	case p.Area >= 1e6:
+		facts = append(facts, fmt.Sprintf("Area: %.1f km²", p.Area/1e6))
+	case p.Area > 0:
+		facts = append(facts, fmt.Sprintf("Area: %.0f m²", p.Area))
+	}
+	if len(facts) == 0 {
+		return
+	}
+	// The rescue multiplier compares the POI with the largest features of
+	// the surrounding tiles
+	if p.DimensionMultiplier > 1 {
+		facts = append(facts, fmt.Sprintf("Local prominence: about %.1f times the typical largest comparable feature nearby", p.DimensionMultiplier))
+	}
+	pd["DimensionFacts"] = strings.Join(facts, "\n")
+}
+
+// injectFacts lists the Wikidata facts configured for the POI's category,
+// which anchor the script where the article is long, noisy or missing. User
+// POIs have no Wikidata entity to ask.
+func (a *Assembler) injectFacts(ctx context.Context, pd Data, p *model.POI) {
+	if p == nil || p.WikidataID == "" || p.Source == model.SourceUser || a.facts == nil || a.categoriesCfg == nil || !a.cfg.AppConfig().Narrator.WikidataFacts {
+		return
+	}
+	props := a.categoriesCfg.FactProperties(p.Category)
+	if len(props) == 0 {
+		return
+	}
+
+	lines := make([]string, 0, len(props))
+	for _, f := range a.fetchFacts(ctx, p.WikidataID, props) {
+		label := f.Label
+		if r, size := utf8.DecodeRuneInString(label); r != utf8.RuneError {
+			label = string(unicode.ToUpper(r)) + label[size:]
+		}
+		lines = append(lines, fmt.Sprintf("- %s: %s", label, strings.Join(f.Values, ", ")))
+	}
+	pd["WikidataFacts"] = strings.Join(lines, "\n")
+}
+
+// fetchFacts returns the facts for an entity, cached per entity and property
+// set since claims rarely change. A failed lookup is not cached, so the next
+// narration of the POI tries again.
+func (a *Assembler) fetchFacts(ctx context.Context, qid string, props []string) []wikidata.Fact {
+	key := fmt.Sprintf("wd_facts_%s_%s", qid, strings.Join(props, "_"))
+	var facts []wikidata.Fact
+	if cached, ok := a.st.GetCache(ctx, key); ok && json.Unmarshal(cached, &facts) == nil {
+		return facts
+	}
+
+	facts, err := a.facts.GetEntityFacts(ctx, qid, props)
+	if err != nil {
+		slog.Warn("Failed to fetch Wikidata facts", "qid", qid, "error", err)
+		return nil
+	}
+	if data, err := json.Marshal(facts); err == nil {
+		if err := a.st.SetCache(ctx, key, data); err != nil {
+			slog.Warn("Failed to cache Wikidata facts", "qid", qid, "error", err)
+		}
+	}
+	return facts
+}
+
+// localizeCategory names the POI's category in the narration language.
+// Category keeps the key, which templates use to pick category guidance.
+func (a *Assembler) localizeCategory(pd Data, p *model.POI) {
+	if p == nil {
+		return
+	}
+	pd["CategoryLabel"] = p.Category
+	if a.categoriesCfg == nil {
+		return
+	}
+	lang, _ := pd["Language_region_code"].(string)
+	pd["CategoryLabel"] = a.categoriesCfg.Label(p.Category, lang)
 }
 
 func (a *Assembler) injectUnits(pd Data) {
 	pd["UnitsInstruction"] = a.fetchUnitsInstruction()
 	pd["UnitSystem"] = strings.ToLower(a.cfg.Units(context.Background()))
+	pd["DistanceUnits"] = a.cfg.DistanceUnits(context.Background())
+	pd["AltitudeUnits"] = a.cfg.AltitudeUnits(context.Background())
 }
 
 func (a *Assembler) fetchUnitsInstruction() string {
-	unitSys := strings.ToLower(a.cfg.Units(context.Background()))
-	tmplName := fmt.Sprintf("units/%s.tmpl", unitSys)
-
-	// Default to imperial if invalid/empty
-	if unitSys == "" {
-		tmplName = "units/imperial.tmpl"
+	ctx := context.Background()
+
+	// The instruction follows the resolved per-dimension units, so it always
+	// agrees with the distances and altitudes in the prompt: the named system
+	// that matches them, or a combined instruction where none does.
+	dims := map[string]string{"DistanceUnits": a.cfg.DistanceUnits(ctx), "AltitudeUnits": a.cfg.AltitudeUnits(ctx)}
+	var data any
+	tmplName := "units/mixed.tmpl"
+	if named := namedUnitSystem(dims["DistanceUnits"], dims["AltitudeUnits"]); named != "" {
+		tmplName = fmt.Sprintf("units/%s.tmpl", named)
+	} else {
+		data = dims
 	}
 
-	content, err := a.prompts.Render(tmplName, nil)
+	content, err := a.prompts.Render(tmplName, data)
 	if err != nil {
 		slog.Error("Failed to render units template", "template", tmplName, "error", err)
 		return ""
@@ -308,10 +499,29 @@ func (a *Assembler) fetchUnitsInstruction() string {
 	return content
 }
 
+// namedUnitSystem returns the unit system whose template covers the
+// per-dimension units, or "" when none does.
+func namedUnitSystem(distance, altitude string) string {
+	switch {
+	case distance == config.UnitsMetric && altitude == config.UnitsMetric:
+		return config.UnitsMetric
+	case distance == config.UnitsImperial && altitude == config.UnitsImperial:
+		return config.UnitsImperial
+	case distance == config.UnitsMetric && altitude == config.UnitsImperial:
+		return config.UnitsHybrid
+	}
+	return ""
+}
+
 func (a *Assembler) fetchWikipediaText(ctx context.Context, p *model.POI) *articleproc.Info {
 	if p == nil || p.WikidataID == "" {
 		return &articleproc.Info{}
 	}
+	if p.Source == model.SourceUser {
+		return &articleproc.Info{Prose: p.Description, WordCount: len(strings.Fields(p.Description))}
+	}
+	lang := articleLang(p.WPURL)
+
 	// 1. Try Store using QID as UUID
 	art, _ := a.st.GetArticle(ctx, p.WikidataID)
 	if art != nil && art.Text != "" {
@@ -320,7 +530,7 @@ func (a *Assembler) fetchWikipediaText(ctx context.Context, p *model.POI) *artic
 			wordCount = a.density.EstimateWordCount(len(art.Text), p.WPURL)
 		}
 		return &articleproc.Info{
-			Prose:     art.Text,
+			Prose:     truncateProse(art.Text, a.cfg.WPExtractMaxChars(ctx, lang)),
 			WordCount: wordCount,
 		}
 	}
@@ -335,13 +545,12 @@ func (a *Assembler) fetchWikipediaText(ctx context.Context, p *model.POI) *artic
 		return &articleproc.Info{}
 	}
 	title := parts[len(parts)-1]
-	lang := "en"
-	if strings.Contains(parts[2], ".") {
-		lang = strings.Split(parts[2], ".")[0]
-	}
 
 	htmlContent, err := a.wikipedia.GetArticleHTML(ctx, title, lang)
 	if err != nil {
+		if !errors.Is(err, wikipedia.ErrArticleNotFound) {
+			slog.Warn("Assembler: Failed to fetch Wikipedia article", "qid", p.WikidataID, "title", title, "error", err)
+		}
 		return &articleproc.Info{}
 	}
 
@@ -350,7 +559,8 @@ func (a *Assembler) fetchWikipediaText(ctx context.Context, p *model.POI) *artic
 		return &articleproc.Info{}
 	}
 
-	// 3. Cache it
+	// 3. Cache it. The full text is stored so a changed limit applies to
+	// cached articles too; WordCount keeps describing the whole article.
 	_ = a.st.SaveArticle(ctx, &model.Article{
 		UUID:  p.WikidataID,
 		Title: title,
@@ -358,29 +568,147 @@ func (a *Assembler) fetchWikipediaText(ctx context.Context, p *model.POI) *artic
 		Text:  info.Prose,
 	})
 
+	info.Prose = truncateProse(info.Prose, a.cfg.WPExtractMaxChars(ctx, lang))
 	return info
 }
 
-func (a *Assembler) fetchRecentContext(ctx context.Context, lat, lon float64) string {
+// translateWikipediaText replaces the Wikipedia extract with a translation
+// into the narration language when the article is in another language.
+// Translations are cached per article and target language; any failure keeps
+// the original text. User POIs are narrated from their own description, not
+// from an article.
+func (a *Assembler) translateWikipediaText(ctx context.Context, pd Data, p *model.POI) {
+	cfg := a.cfg.AppConfig().Narrator.Translation
+	if !cfg.Enabled || p == nil || p.WikidataID == "" || p.Source == model.SourceUser {
+		return
+	}
+	text, _ := pd["WikipediaText"].(string)
+	target, _ := pd["Language_code"].(string)
+	source := articleLang(p.WPURL)
+	if text == "" || target == "" || strings.EqualFold(source, target) {
+		return
+	}
+	if !a.llm.HasProfile(cfg.Profile) {
+		return
+	}
+
+	key := fmt.Sprintf("wp_translation_%s_%s", p.WikidataID, target)
+	if cached, ok := a.st.GetCache(ctx, key); ok {
+		pd["WikipediaText"] = string(cached)
+		return
+	}
+
+	prompt, err := a.prompts.Render("context/translation.tmpl", map[string]any{
+		"Text":           text,
+		"SourceLanguage": source,
+		"Language_name":  pd["Language_name"],
+		"Language_code":  target,
+	})
+	if err != nil {
+		slog.Warn("Failed to render translation template", "error", err)
+		return
+	}
+	translated, err := a.llm.GenerateText(ctx, cfg.Profile, prompt)
+	translated = strings.TrimSpace(translated)
+	if err != nil || translated == "" {
+		slog.Warn("Wikipedia translation failed, using original text", "qid", p.WikidataID, "from", source, "to", target, "error", err)
+		return
+	}
+
+	if err := a.st.SetCache(ctx, key, []byte(translated)); err != nil {
+		slog.Warn("Failed to cache Wikipedia translation", "qid", p.WikidataID, "error", err)
+	}
+	pd["WikipediaText"] = translated
+}
+
+// articleLang returns the language subdomain of a Wikipedia URL.
+func articleLang(wpURL string) string {
+	parts := strings.Split(wpURL, "/")
+	if len(parts) > 2 && strings.Contains(parts[2], ".") {
+		return strings.Split(parts[2], ".")[0]
+	}
+	return "en"
+}
+
+// truncateProse limits text to maxChars characters. The cut falls on the last
+// paragraph boundary that fits, or on a word boundary when the first
+// paragraph alone is too long, and is marked with an ellipsis.
+func truncateProse(text string, maxChars int) string {
+	if maxChars <= 0 || utf8.RuneCountInString(text) <= maxChars {
+		return text
+	}
+
+	limit := len(text)
+	n := 0
+	for i := range text {
+		if n == maxChars {
+			limit = i
+			break
+		}
+		n++
+	}
+	head := text[:limit]
+
+	if i := strings.LastIndex(head, "\n\n"); i > 0 {
+		head = head[:i]
+	} else if i := strings.LastIndexAny(head, " \n"); i > 0 {
+		head = head[:i]
+	}
+	return strings.TrimRightFunc(head, unicode.IsSpace) + " …"
+}
+
+// fetchRecentNearby returns the POIs played within the last hour and 50 km
+// of the position, most recent first. It returns nil, rather than an empty
+// list, when the store fails.
+func (a *Assembler) fetchRecentNearby(ctx context.Context, lat, lon float64) []*model.POI {
 	since := time.Now().Add(-1 * time.Hour)
 	pois, err := a.st.GetRecentlyPlayedPOIs(ctx, since)
 	if err != nil {
-		return "None"
+		return nil
 	}
 
-	var contextParts []string
+	nearby := []*model.POI{}
 	p1 := geo.Point{Lat: lat, Lon: lon}
 	for _, p := range pois {
 		p2 := geo.Point{Lat: p.Lat, Lon: p.Lon}
-		dist := geo.Distance(p1, p2)
-		if dist < 50000 {
-			contextParts = append(contextParts, fmt.Sprintf("%s (%s)", p.NameEn, p.Category))
+		if geo.Distance(p1, p2) < 50000 {
+			nearby = append(nearby, p)
 		}
 	}
+	return nearby
+}
 
+func formatRecentContext(pois []*model.POI) string {
+	if pois == nil {
+		return "None"
+	}
+	contextParts := make([]string, 0, len(pois))
+	for _, p := range pois {
+		contextParts = append(contextParts, fmt.Sprintf("%s (%s)", p.NameEn, p.Category))
+	}
 	return strings.Join(contextParts, ", ")
 }
 
+// maxAvoidRepeating keeps the "don't repeat" list short; older narrations
+// are unlikely to be echoed anyway.
+const maxAvoidRepeating = 5
+
+// recentTitles returns the titles of the recent POIs other than the one
+// being narrated, for the prompt's "don't repeat" list.
+func recentTitles(pois []*model.POI, currentID string) []string {
+	titles := []string{}
+	for _, p := range pois {
+		if p.WikidataID == currentID {
+			continue
+		}
+		titles = append(titles, p.DisplayName())
+		if len(titles) == maxAvoidRepeating {
+			break
+		}
+	}
+	return titles
+}
+
 func (a *Assembler) fetchPregroundContext(ctx context.Context, p *model.POI) string {
 	if a.categoriesCfg == nil || !a.categoriesCfg.ShouldPreground(p.Category) {
 		return ""
@@ -473,15 +801,29 @@ func (a *Assembler) fetchTTSInstructions(data Data) string {
 		tmplName = "tts/edge-tts.tmpl"
 	}
 
+	dialogue := a.cfg.DialogueMode(context.Background())
+	data["DialogueMode"] = dialogue
+
 	content, err := a.prompts.Render(tmplName, data)
 	if err != nil {
 		slog.Error("Failed to render TTS template", "template", tmplName, "error", err)
 		return "Do not use speaker labels."
 	}
-	return content
+	if !dialogue {
+		return content
+	}
+
+	// The speaker labels are requested regardless of engine; engines without
+	// a second voice get them stripped before synthesis.
+	turns, err := a.prompts.Render("tts/dialogue.tmpl", data)
+	if err != nil {
+		slog.Error("Failed to render dialogue template", "error", err)
+		return content
+	}
+	return content + "\n" + turns
 }
 
-func (a *Assembler) sampleNarrationLength(p *model.POI, strategy string, sourceWords int) (words int, strategyUsed string) {
+func (a *Assembler) sampleNarrationLength(p *model.POI, tel *sim.Telemetry, strategy string, sourceWords int) (words int, strategyUsed string) {
 	shortTarget := a.cfg.NarrationLengthShort(context.Background())
 	longTarget := a.cfg.NarrationLengthLong(context.Background())
 	if shortTarget <= 0 {
@@ -499,7 +841,7 @@ func (a *Assembler) sampleNarrationLength(p *model.POI, strategy string, sourceW
 		baseTarget = shortTarget
 	}
 
-	targetLimit := a.ApplyWordLengthMultiplier(baseTarget)
+	targetLimit := int(float64(a.ApplyWordLengthMultiplier(baseTarget)) * a.paceFactor(p, tel))
 	sourceLimit := sourceWords / 2
 
 	finalWords := targetLimit
@@ -510,6 +852,105 @@ func (a *Assembler) sampleNarrationLength(p *model.POI, strategy string, sourceW
 	return finalWords, strategy
 }
 
+// Bounds for paceFactor: an imminent POI at most halves the narration, an
+// empty leg stretches it by half.
+const (
+	paceMinFactor = 0.5
+	paceMaxFactor = 1.5
+)
+
+// candidateLister is implemented by POI managers that can name the upcoming
+// narration candidates.
+type candidateLister interface {
+	GetNarrationCandidates(limit int, minScore *float64) []*model.POI
+}
+
+// paceFactor scales the narration length by the flight time to the next best
+// candidate relative to the configured look-ahead, so a narration ends
+// roughly when the next POI comes up. It returns 1 when there is no other
+// candidate or no usable telemetry.
+func (a *Assembler) paceFactor(p *model.POI, tel *sim.Telemetry) float64 {
+	lookahead := a.cfg.PaceLookahead(context.Background())
+	lister, ok := a.poiMgr.(candidateLister)
+	if !ok || p == nil || tel == nil || lookahead <= 0 || tel.GroundSpeed < 1 {
+		return 1.0
+	}
+
+	var next *model.POI
+	for _, c := range lister.GetNarrationCandidates(3, nil) {
+		if c.WikidataID != p.WikidataID {
+			next = c
+			break
+		}
+	}
+	if next == nil {
+		return 1.0
+	}
+
+	distM := geo.Distance(geo.Point{Lat: tel.Latitude, Lon: tel.Longitude}, geo.Point{Lat: next.Lat, Lon: next.Lon})
+	eta := time.Duration(distM / (tel.GroundSpeed * 0.514444) * float64(time.Second))
+	factor := math.Max(paceMinFactor, math.Min(paceMaxFactor, eta.Seconds()/lookahead.Seconds()))
+	slog.Debug("Assembler: Narration pace", "next", next.WikidataID, "eta", eta.Round(time.Second), "factor", factor)
+	return factor
+}
+
+// spokenWordsPerMinute is the typical TTS speaking rate, used to turn a
+// time budget into a word count.
+const spokenWordsPerMinute = 150.0
+
+// fitRelevanceWindow caps words to what can be spoken before the aircraft
+// leaves the POI's relevance radius, allowing for the generation latency.
+// It reports outlives when even RelevanceFit.MinWords would not fit, in
+// which case automated narrations skip the POI. POIs the track never brings
+// within the radius are left alone: they were picked for other reasons.
+func (a *Assembler) fitRelevanceWindow(p *model.POI, tel *sim.Telemetry, words int) (fitted int, outlives bool) {
+	rf := a.cfg.AppConfig().Narrator.RelevanceFit
+	if !rf.Enabled || p == nil || tel == nil || tel.GroundSpeed < 1 || words <= 0 {
+		return words, false
+	}
+
+	remaining, ok := timeWithinRadius(tel, p, float64(rf.Radius))
+	if !ok {
+		return words, false
+	}
+	if a.latency != nil {
+		remaining -= a.latency()
+	}
+
+	budget := int(remaining.Minutes() * spokenWordsPerMinute)
+	if budget >= words {
+		return words, false
+	}
+	if budget < rf.MinWords {
+		slog.Info("Assembler: Narration would outlive the POI", "poi_id", p.WikidataID, "remaining", remaining.Round(time.Second), "words", words)
+		return words, true
+	}
+	slog.Debug("Assembler: Narration shortened to the relevance window", "poi_id", p.WikidataID, "remaining", remaining.Round(time.Second), "words", budget)
+	return budget, false
+}
+
+// timeWithinRadius returns how long the aircraft, holding its track and
+// groundspeed, stays within radiusM of the POI. ok is false when the track
+// never comes that close.
+func timeWithinRadius(tel *sim.Telemetry, p *model.POI, radiusM float64) (remaining time.Duration, ok bool) {
+	pos := geo.Point{Lat: tel.Latitude, Lon: tel.Longitude}
+	target := geo.Point{Lat: p.Lat, Lon: p.Lon}
+	dist := geo.Distance(pos, target)
+	rel := (geo.Bearing(pos, target) - tel.Heading) * math.Pi / 180.0
+
+	along := dist * math.Cos(rel)
+	cross := dist * math.Sin(rel)
+	if math.Abs(cross) > radiusM {
+		return 0, false
+	}
+	// Distance along the track to where it leaves the circle
+	exit := along + math.Sqrt(radiusM*radiusM-cross*cross)
+	if exit <= 0 {
+		return 0, true
+	}
+	return time.Duration(exit / (tel.GroundSpeed * 0.514444) * float64(time.Second)), true
+}
+
 func (a *Assembler) ApplyWordLengthMultiplier(baseWords int) int {
 	textLength := a.cfg.TextLengthScale(context.Background())
 
@@ -525,10 +966,14 @@ func (a *Assembler) ApplyWordLengthMultiplier(baseWords int) int {
 }
 
 func (a *Assembler) DetermineSkewStrategy(p *model.POI, isOnGround bool) string {
-	return DetermineSkewStrategy(p, a.poiMgr, isOnGround)
+	ctx := context.Background()
+	return DetermineSkewStrategy(p, a.poiMgr, isOnGround, a.cfg.DominanceRivalFraction(ctx), a.cfg.DominanceRivalCount(ctx))
 }
 
-func DetermineSkewStrategy(p *model.POI, poiMgr POIProvider, isOnGround bool) string {
+// DetermineSkewStrategy keeps a POI short when it competes with others: more
+// than rivalCount POIs (p itself included) scoring above rivalFraction of
+// its score. Counting stops at rivalCount+1, which is all the rule needs.
+func DetermineSkewStrategy(p *model.POI, poiMgr POIProvider, isOnGround bool, rivalFraction float64, rivalCount int) string {
 	if p == nil {
 		return StrategyUniform
 	}
@@ -537,10 +982,10 @@ func DetermineSkewStrategy(p *model.POI, poiMgr POIProvider, isOnGround bool) st
 		return StrategyMaxSkew
 	}
 
-	threshold := math.Max(p.Score*0.2, 0.5)
-	rivals := poiMgr.CountScoredAbove(threshold, 2)
+	threshold := math.Max(p.Score*rivalFraction, 0.5)
+	rivals := poiMgr.CountScoredAbove(threshold, rivalCount+1)
 
-	if rivals > 1 {
+	if rivals > rivalCount {
 		return StrategyMinSkew
 	}
 	return StrategyMaxSkew
@@ -573,6 +1018,31 @@ func (a *Assembler) injectNavigationData(pd Data, p *model.POI, tel *sim.Telemet
 	pd["CardinalDir"] = a.calculateCardinalDir(normBearing)
 	pd["RelativeDir"] = a.calculateRelativeDir(relBearing)
 	pd["Movement"] = a.calculateMovement(relBearing)
+
+	mode := a.cfg.VehicleMode(context.Background())
+	pd["NavInstruction"] = a.calculateNavInstruction(mode, a.cfg.DistanceUnits(context.Background()), relBearing, normBearing, distMeters)
+}
+
+// calculateNavInstruction phrases direction and distance for the active vehicle mode.
+// Clock positions only make sense from a cockpit; drivers and sailors get compass bearings,
+// and sailors always get nautical miles regardless of the unit setting.
+func (a *Assembler) calculateNavInstruction(mode, distUnits string, relBearing, normBearing, distMeters float64) string {
+	switch mode {
+	case config.VehicleModeMarine:
+		return fmt.Sprintf("bearing %03.0f° (%s), %v nm", normBearing, a.calculateCardinalDir(normBearing), a.humanRound(distMeters*0.000539957))
+	case config.VehicleModeGround:
+		dist := fmt.Sprintf("%v km", a.humanRound(distMeters/1000.0))
+		if distUnits == config.UnitsImperial {
+			dist = fmt.Sprintf("%v miles", a.humanRound(distMeters/1609.344))
+		}
+		return fmt.Sprintf("to the %s (%s), %s away", a.calculateCardinalDir(normBearing), a.calculateRelativeDir(relBearing), dist)
+	default:
+		dist := fmt.Sprintf("%v km", a.humanRound(distMeters/1000.0))
+		if distUnits == config.UnitsImperial {
+			dist = fmt.Sprintf("%v nm", a.humanRound(distMeters*0.000539957))
+		}
+		return fmt.Sprintf("%d o'clock (%s), %s", a.calculateClockPos(relBearing), a.calculateRelativeDir(relBearing), dist)
+	}
 }
 
 func (a *Assembler) calculateClockPos(relBearing float64) int {
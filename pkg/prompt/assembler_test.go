@@ -2,8 +2,11 @@ package prompt
 
 import (
 	"context"
+	"errors"
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/sim"
+	"phileasgo/pkg/wikidata"
 	"strings"
 	"testing"
 	"time"
@@ -41,6 +44,10 @@ func (m *MockStore) GetState(ctx context.Context, key string) (string, bool) {
 	val, ok := m.State[key]
 	return val, ok
 }
+func (m *MockStore) GetCache(ctx context.Context, key string) ([]byte, bool) { return nil, false }
+func (m *MockStore) SetCache(ctx context.Context, key string, val []byte) error {
+	return nil
+}
 
 type MockLLM struct{}
 
@@ -94,6 +101,7 @@ func TestAssembler_NewPromptData(t *testing.T) {
 
 func TestAssembler_DetermineSkewStrategy(t *testing.T) {
 	a := &Assembler{
+		cfg:    config.NewProvider(config.DefaultConfig(), nil),
 		poiMgr: &MockPOIProvider{Rivals: 5},
 	}
 	poi := &model.POI{Score: 10}
@@ -111,6 +119,49 @@ func TestAssembler_DetermineSkewStrategy(t *testing.T) {
 	}
 }
 
+// scoredPOIProvider counts against real scores, so the threshold matters.
+type scoredPOIProvider struct {
+	Scores []float64
+}
+
+func (m *scoredPOIProvider) CountScoredAbove(threshold float64, limit int) int {
+	n := 0
+	for _, s := range m.Scores {
+		if s > threshold && n < limit {
+			n++
+		}
+	}
+	return n
+}
+
+func TestAssembler_DetermineSkewStrategy_RivalFraction(t *testing.T) {
+	poi := &model.POI{Score: 10}
+	// The POI itself plus two rivals at 60% and 30% of its score
+	scores := []float64{10, 6, 3}
+
+	tests := []struct {
+		name     string
+		fraction float64
+		want     string
+	}{
+		{"Default fraction sees rivals", 0.2, StrategyMinSkew},
+		{"Raised fraction ignores weaker POIs", 0.8, StrategyMaxSkew},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.DominanceRivalFraction = tt.fraction
+			a := &Assembler{
+				cfg:    config.NewProvider(cfg, nil),
+				poiMgr: &scoredPOIProvider{Scores: scores},
+			}
+			if got := a.DetermineSkewStrategy(poi, false); got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestAssembler_ForPOI_NilTelemetry(t *testing.T) {
 	a := &Assembler{
 		cfg: config.NewProvider(&config.Config{
@@ -150,10 +201,59 @@ func TestAssembler_ForPOI_NilTelemetry(t *testing.T) {
 	}
 }
 
+func TestAssembler_ForPOI_CategoryLabel(t *testing.T) {
+	cats := &config.CategoriesConfig{
+		Categories: map[string]config.Category{
+			"aerodrome": {Labels: map[string]string{"de": "Flugplatz"}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		lang      string
+		category  string
+		wantLabel string
+	}{
+		{name: "German label", lang: "de-DE", category: "Aerodrome", wantLabel: "Flugplatz"},
+		{name: "No label for language", lang: "en-US", category: "Aerodrome", wantLabel: "Aerodrome"},
+		{name: "Category without labels", lang: "de-DE", category: "Castle", wantLabel: "Castle"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Assembler{
+				cfg: config.NewProvider(&config.Config{
+					Narrator: config.NarratorConfig{
+						ActiveTargetLanguage:  tt.lang,
+						TargetLanguageLibrary: []string{tt.lang},
+					},
+				}, nil),
+				geoSvc:        &MockGeo{Country: "Test", City: "TestCity"},
+				st:            &MockStore{State: map[string]string{}},
+				prompts:       &MockRenderer{},
+				wikipedia:     &MockWikipedia{},
+				poiMgr:        &MockPOIProvider{},
+				llm:           &MockLLM{},
+				categoriesCfg: cats,
+			}
+			p := &model.POI{WikidataID: "Q1", NameEn: "Test Field", Category: tt.category, Lat: 10, Lon: 10}
+
+			pd := a.ForPOI(context.Background(), p, nil, "", SessionState{})
+			if pd["Category"] != tt.category {
+				t.Errorf("Category = %v, want the key %q", pd["Category"], tt.category)
+			}
+			if pd["CategoryLabel"] != tt.wantLabel {
+				t.Errorf("CategoryLabel = %v, want %q", pd["CategoryLabel"], tt.wantLabel)
+			}
+		})
+	}
+}
+
 func TestAssembler_FetchUnitsInstruction(t *testing.T) {
 	tests := []struct {
 		name     string
 		units    string
+		dims     config.UnitDimensions
 		expected string
 	}{
 		{
@@ -176,6 +276,29 @@ func TestAssembler_FetchUnitsInstruction(t *testing.T) {
 			units:    "",
 			expected: "units/imperial.tmpl",
 		},
+		{
+			name:     "Unknown system defaults to Imperial",
+			units:    "nautical",
+			expected: "units/imperial.tmpl",
+		},
+		{
+			name:     "Override on the default system",
+			units:    "",
+			dims:     config.UnitDimensions{Distance: "metric"},
+			expected: "units/hybrid.tmpl",
+		},
+		{
+			name:     "Override matching a named system",
+			units:    "imperial",
+			dims:     config.UnitDimensions{Distance: "metric"},
+			expected: "units/hybrid.tmpl",
+		},
+		{
+			name:     "Override matching no named system",
+			units:    "imperial",
+			dims:     config.UnitDimensions{Altitude: "metric"},
+			expected: "units/mixed.tmpl",
+		},
 	}
 
 	for _, tt := range tests {
@@ -190,7 +313,8 @@ func TestAssembler_FetchUnitsInstruction(t *testing.T) {
 			a := &Assembler{
 				cfg: config.NewProvider(&config.Config{
 					Narrator: config.NarratorConfig{
-						Units: tt.units,
+						Units:          tt.units,
+						UnitDimensions: tt.dims,
 					},
 				}, nil),
 				prompts:   mockRenderer,
@@ -253,3 +377,452 @@ func TestAssembler_InterestsAndAvoid(t *testing.T) {
 		t.Errorf("Expected IsOnGround to be present and false, got %v", pd["IsOnGround"])
 	}
 }
+
+func TestAssembler_CalculateNavInstruction(t *testing.T) {
+	a := &Assembler{}
+
+	tests := []struct {
+		name       string
+		mode       string
+		distUnits  string
+		relBearing float64
+		bearing    float64
+		distMeters float64
+		want       string
+	}{
+		{
+			name: "Aircraft uses clock position", mode: config.VehicleModeAircraft, distUnits: "metric",
+			relBearing: 90, bearing: 180, distMeters: 5000,
+			want: "3 o'clock (right), 5 km",
+		},
+		{
+			name: "Ground uses compass direction", mode: config.VehicleModeGround, distUnits: "metric",
+			relBearing: 270, bearing: 45, distMeters: 2000,
+			want: "to the North-East (left), 2 km away",
+		},
+		{
+			name: "Ground imperial uses statute miles", mode: config.VehicleModeGround, distUnits: "imperial",
+			relBearing: 0, bearing: 0, distMeters: 3218.688,
+			want: "to the North (ahead), 2 miles away",
+		},
+		{
+			name: "Marine always uses nautical miles", mode: config.VehicleModeMarine, distUnits: "metric",
+			relBearing: 0, bearing: 90, distMeters: 5556,
+			want: "bearing 090° (East), 3 nm",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := a.calculateNavInstruction(tt.mode, tt.distUnits, tt.relBearing, tt.bearing, tt.distMeters)
+			if got != tt.want {
+				t.Errorf("calculateNavInstruction() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTruncateProse(t *testing.T) {
+	para := strings.Repeat("word ", 19) + "end." // 99 chars
+	long := strings.Join([]string{para, para, para, para}, "\n\n")
+
+	tests := []struct {
+		name     string
+		text     string
+		maxChars int
+		want     string
+	}{
+		{"Short article untouched", para, 500, para},
+		{"Zero disables", long, 0, long},
+		{"Cut on paragraph boundary", long, 250, para + "\n\n" + para + " …"},
+		{"Long first paragraph cut on word", long, 12, "word word …"},
+		{"Counts characters not bytes", "Zürich Zürich", 13, "Zürich Zürich"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateProse(tt.text, tt.maxChars); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type articleStore struct {
+	MockStore
+	text string
+}
+
+func (m *articleStore) GetArticle(ctx context.Context, uuid string) (*model.Article, error) {
+	return &model.Article{UUID: uuid, Text: m.text}, nil
+}
+
+func TestAssembler_FetchWikipediaText_LanguageLimit(t *testing.T) {
+	para := strings.Repeat("x", 90)
+	article := strings.Repeat(para+"\n\n", 9) + para // ~1000 chars
+
+	cfg := config.DefaultConfig()
+	cfg.Narrator.WikipediaExtract = config.WPExtractConfig{
+		MaxChars:  2000,
+		Languages: map[string]int{"zh": 200},
+	}
+	a := &Assembler{cfg: config.NewProvider(cfg, nil), st: &articleStore{text: article}}
+
+	en := a.fetchWikipediaText(context.Background(), &model.POI{WikidataID: "Q1", WPURL: "https://en.wikipedia.org/wiki/X"})
+	if en.Prose != article {
+		t.Errorf("expected full article under global limit, got %d chars", len(en.Prose))
+	}
+
+	zh := a.fetchWikipediaText(context.Background(), &model.POI{WikidataID: "Q1", WPURL: "https://zh.wikipedia.org/wiki/X"})
+	if want := para + "\n\n" + para + " …"; zh.Prose != want {
+		t.Errorf("expected per-language cut after two paragraphs, got %q", zh.Prose)
+	}
+	if zh.WordCount != en.WordCount {
+		t.Errorf("word count should describe the whole article: %d vs %d", zh.WordCount, en.WordCount)
+	}
+}
+
+type cacheStore struct {
+	MockStore
+	cache map[string][]byte
+}
+
+func (m *cacheStore) GetCache(ctx context.Context, key string) ([]byte, bool) {
+	v, ok := m.cache[key]
+	return v, ok
+}
+
+func (m *cacheStore) SetCache(ctx context.Context, key string, val []byte) error {
+	m.cache[key] = val
+	return nil
+}
+
+type translatingLLM struct {
+	MockLLM
+	out   string
+	err   error
+	calls int
+}
+
+func (m *translatingLLM) HasProfile(name string) bool { return name == "translation" }
+func (m *translatingLLM) GenerateText(ctx context.Context, profile, prompt string) (string, error) {
+	m.calls++
+	return m.out, m.err
+}
+
+func TestAssembler_TranslateWikipediaText(t *testing.T) {
+	frPOI := &model.POI{WikidataID: "Q90", WPURL: "https://fr.wikipedia.org/wiki/Paris"}
+	dePOI := &model.POI{WikidataID: "Q90", WPURL: "https://de.wikipedia.org/wiki/Paris"}
+	userPOI := &model.POI{WikidataID: "user_0123456789ab", Source: model.SourceUser}
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		poi       *model.POI
+		cached    string
+		llmOut    string
+		llmErr    error
+		want      string
+		wantCalls int
+		wantCache bool
+	}{
+		{name: "Disabled", enabled: false, poi: frPOI, llmOut: "Übersetzt", want: "Original"},
+		{name: "Same language", enabled: true, poi: dePOI, llmOut: "Übersetzt", want: "Original"},
+		{name: "User POI", enabled: true, poi: userPOI, llmOut: "Übersetzt", want: "Original"},
+		{name: "Translated and cached", enabled: true, poi: frPOI, llmOut: " Übersetzt\n", want: "Übersetzt", wantCalls: 1, wantCache: true},
+		{name: "Cache hit", enabled: true, poi: frPOI, cached: "Aus dem Cache", want: "Aus dem Cache"},
+		{name: "Failure keeps original", enabled: true, poi: frPOI, llmErr: errors.New("quota"), want: "Original", wantCalls: 1},
+		{name: "Empty result keeps original", enabled: true, poi: frPOI, llmOut: "  ", want: "Original", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.Translation.Enabled = tt.enabled
+			st := &cacheStore{cache: map[string][]byte{}}
+			if tt.cached != "" {
+				st.cache["wp_translation_Q90_de"] = []byte(tt.cached)
+			}
+			llm := &translatingLLM{out: tt.llmOut, err: tt.llmErr}
+			a := &Assembler{cfg: config.NewProvider(cfg, nil), st: st, llm: llm, prompts: &MockRenderer{}}
+
+			pd := Data{"WikipediaText": "Original", "Language_code": "de", "Language_name": "German"}
+			a.translateWikipediaText(context.Background(), pd, tt.poi)
+
+			if got := pd["WikipediaText"]; got != tt.want {
+				t.Errorf("WikipediaText = %q, want %q", got, tt.want)
+			}
+			if llm.calls != tt.wantCalls {
+				t.Errorf("expected %d LLM calls, got %d", tt.wantCalls, llm.calls)
+			}
+			if _, ok := st.cache["wp_translation_Q90_de"]; ok != (tt.wantCache || tt.cached != "") {
+				t.Errorf("unexpected cache state: %v", st.cache)
+			}
+		})
+	}
+}
+
+type factSource struct {
+	facts []wikidata.Fact
+	err   error
+	calls int
+}
+
+func (m *factSource) GetEntityFacts(ctx context.Context, id string, props []string) ([]wikidata.Fact, error) {
+	m.calls++
+	return m.facts, m.err
+}
+
+func TestAssembler_InjectFacts(t *testing.T) {
+	cats := &config.CategoriesConfig{
+		Categories: map[string]config.Category{
+			"tower": {Facts: []string{"P571", "P2048"}},
+		},
+	}
+	facts := []wikidata.Fact{
+		{Property: "P571", Label: "inception", Values: []string{"1889"}},
+		{Property: "P2048", Label: "height", Values: []string{"330 metre"}},
+	}
+	const want = "- Inception: 1889\n- Height: 330 metre"
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		category  string
+		source    string
+		cached    bool
+		err       error
+		want      string
+		wantCalls int
+	}{
+		{name: "Disabled", enabled: false, category: "Tower", want: ""},
+		{name: "Fetched and cached", enabled: true, category: "Tower", want: want, wantCalls: 1},
+		{name: "Cache hit", enabled: true, category: "Tower", cached: true, want: want},
+		{name: "Category without facts", enabled: true, category: "Castle", want: ""},
+		{name: "User POI", enabled: true, category: "Tower", source: model.SourceUser, want: ""},
+		{name: "Lookup failure leaves them out", enabled: true, category: "Tower", err: errors.New("timeout"), want: "", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.WikidataFacts = tt.enabled
+			st := &cacheStore{cache: map[string][]byte{}}
+			const key = "wd_facts_Q243_P571_P2048"
+			if tt.cached {
+				st.cache[key] = []byte(`[{"property":"P571","label":"inception","values":["1889"]},{"property":"P2048","label":"height","values":["330 metre"]}]`)
+			}
+			src := &factSource{facts: facts, err: tt.err}
+			a := &Assembler{cfg: config.NewProvider(cfg, nil), st: st, categoriesCfg: cats}
+			a.SetFactSource(src)
+
+			pd := Data{}
+			a.injectFacts(context.Background(), pd, &model.POI{WikidataID: "Q243", Category: tt.category, Source: tt.source})
+
+			if got, _ := pd["WikidataFacts"].(string); got != tt.want {
+				t.Errorf("WikidataFacts = %q, want %q", got, tt.want)
+			}
+			if src.calls != tt.wantCalls {
+				t.Errorf("expected %d lookups, got %d", tt.wantCalls, src.calls)
+			}
+			if _, ok := st.cache[key]; ok != (tt.want != "") {
+				t.Errorf("unexpected cache state: %v", st.cache)
+			}
+		})
+	}
+}
+
+type candidatePOIProvider struct {
+	MockPOIProvider
+	Candidates []*model.POI
+}
+
+func (m *candidatePOIProvider) GetNarrationCandidates(limit int, minScore *float64) []*model.POI {
+	return m.Candidates
+}
+
+func TestAssembler_SampleNarrationLength_Pace(t *testing.T) {
+	current := &model.POI{WikidataID: "Q1", Lat: 0, Lon: 0}
+	// 120 kts covers ~3.7 km per minute
+	tel := &sim.Telemetry{Latitude: 0, Longitude: 0, GroundSpeed: 120}
+
+	newAssembler := func(candidates ...*model.POI) *Assembler {
+		cfg := config.DefaultConfig()
+		cfg.Narrator.PaceLookahead = config.Duration(3 * time.Minute)
+		return &Assembler{
+			cfg:    config.NewProvider(cfg, nil),
+			poiMgr: &candidatePOIProvider{Candidates: candidates},
+		}
+	}
+	sample := func(a *Assembler) int {
+		words, _ := a.sampleNarrationLength(current, tel, StrategyMaxSkew, 10000)
+		return words
+	}
+
+	base := sample(newAssembler(current))
+
+	tests := []struct {
+		name    string
+		nextLat float64
+		cmp     func(got int) bool
+		want    string
+	}{
+		{"Imminent POI shortens", 0.03, func(got int) bool { return got < base }, "shorter"}, // ~3.3 km, under a minute
+		{"Distant POI lengthens", 0.5, func(got int) bool { return got > base }, "longer"},   // ~55 km, 15 minutes
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &model.POI{WikidataID: "Q2", Lat: tt.nextLat}
+			got := sample(newAssembler(current, next))
+			if !tt.cmp(got) {
+				t.Errorf("got %d words, want %s than base %d", got, tt.want, base)
+			}
+		})
+	}
+}
+
+// countryLanguages maps country codes to their primary language.
+type countryLanguages map[string]model.LanguageInfo
+
+func (m countryLanguages) GetLanguageInfo(code string) model.LanguageInfo {
+	if info, ok := m[code]; ok {
+		return info
+	}
+	return model.LanguageInfo{Code: "en", Name: "English"}
+}
+
+func (m countryLanguages) PrimaryLanguage(code string) (model.LanguageInfo, bool) {
+	info, ok := m[code]
+	return info, ok
+}
+
+func TestAssembler_ForPOI_AutoFollowCountryLanguage(t *testing.T) {
+	tests := []struct {
+		name       string
+		follow     bool
+		country    string
+		wantCode   string
+		wantName   string
+		wantLocale string
+	}{
+		{"POI in France", true, "FR", "fr", "French", "fr-FR"},
+		{"Country without a mapped language", true, "AQ", "en", "English", "en-US"},
+		{"Over international waters", true, "", "en", "English", "en-US"},
+		{"Toggle off", false, "FR", "en", "English", "en-US"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Assembler{
+				cfg: config.NewProvider(&config.Config{
+					Narrator: config.NarratorConfig{
+						ActiveTargetLanguage:      "en-US",
+						TargetLanguageLibrary:     []string{"en-US"},
+						AutoFollowCountryLanguage: tt.follow,
+					},
+				}, nil),
+				geoSvc:    &MockGeo{Country: tt.country, City: "Lyon"},
+				st:        &MockStore{State: map[string]string{}},
+				prompts:   &MockRenderer{},
+				wikipedia: &MockWikipedia{},
+				poiMgr:    &MockPOIProvider{},
+				llm:       &MockLLM{},
+				langRes: countryLanguages{
+					"FR": {Code: "fr", Name: "French"},
+					"US": {Code: "en", Name: "English"},
+				},
+			}
+			p := &model.POI{Lat: 45.76, Lon: 4.83, WikidataID: "Q456", NameEn: "Lyon Cathedral", Score: 1.0}
+
+			pd := a.ForPOI(context.Background(), p, nil, "", SessionState{})
+
+			if pd["Language_code"] != tt.wantCode {
+				t.Errorf("Language_code: expected %s, got %v", tt.wantCode, pd["Language_code"])
+			}
+			if pd["Language_name"] != tt.wantName {
+				t.Errorf("Language_name: expected %s, got %v", tt.wantName, pd["Language_name"])
+			}
+			for _, k := range []string{"Language", "TargetLanguage", "Language_region_code"} {
+				if pd[k] != tt.wantLocale {
+					t.Errorf("%s: expected %s, got %v", k, tt.wantLocale, pd[k])
+				}
+			}
+		})
+	}
+}
+
+func TestAssembler_ForGeneric_IsNight(t *testing.T) {
+	tests := []struct {
+		name    string
+		toning  bool
+		simTime time.Time
+		want    bool
+	}{
+		{"Paris at midnight", true, time.Date(2024, 12, 21, 23, 0, 0, 0, time.UTC), true},
+		{"Paris at noon", true, time.Date(2024, 6, 21, 12, 0, 0, 0, time.UTC), false},
+		{"Toning disabled", false, time.Date(2024, 12, 21, 23, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Assembler{
+				cfg: config.NewProvider(&config.Config{
+					Narrator: config.NarratorConfig{
+						ActiveTargetLanguage:  "en-US",
+						TargetLanguageLibrary: []string{"en-US"},
+						NightToning:           tt.toning,
+					},
+				}, nil),
+				geoSvc:  &MockGeo{Country: "FR", City: "Paris"},
+				st:      &MockStore{State: map[string]string{}},
+				prompts: &MockRenderer{},
+			}
+			tel := &sim.Telemetry{Latitude: 48.85, Longitude: 2.35, SimTime: tt.simTime}
+
+			pd := a.ForGeneric(context.Background(), tel, SessionState{})
+
+			if pd["IsNight"] != tt.want {
+				t.Errorf("IsNight: expected %v, got %v", tt.want, pd["IsNight"])
+			}
+		})
+	}
+}
+
+func TestAssembler_FitRelevanceWindow(t *testing.T) {
+	tests := []struct {
+		name         string
+		enabled      bool
+		speed        float64
+		poiLat       float64
+		poiLon       float64
+		wantShorter  bool
+		wantOutlives bool
+	}{
+		// Exit point ~20.5 km ahead: 400 s at 100 kts, 83 s at 480 kts
+		{name: "Slow aircraft keeps the length", enabled: true, speed: 100, poiLat: 0.05},
+		{name: "Fast aircraft shortens", enabled: true, speed: 480, poiLat: 0.05, wantShorter: true},
+		// Passed 10 km ago, only 5 km of the radius left
+		{name: "Fast aircraft past the POI skips", enabled: true, speed: 480, poiLat: -0.09, wantOutlives: true},
+		{name: "POI off the track is left alone", enabled: true, speed: 480, poiLon: 0.3},
+		{name: "Disabled", enabled: false, speed: 480, poiLat: -0.09},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Narrator.RelevanceFit = config.RelevanceFitConfig{Enabled: tt.enabled, Radius: 15000, MinWords: 40}
+			a := &Assembler{cfg: config.NewProvider(cfg, nil)}
+			a.SetLatencySource(func() time.Duration { return 20 * time.Second })
+
+			p := &model.POI{WikidataID: "Q1", Lat: tt.poiLat, Lon: tt.poiLon}
+			tel := &sim.Telemetry{Heading: 0, GroundSpeed: tt.speed}
+
+			got, outlives := a.fitRelevanceWindow(p, tel, 200)
+			if outlives != tt.wantOutlives {
+				t.Errorf("outlives = %v, want %v", outlives, tt.wantOutlives)
+			}
+			if shorter := got < 200; shorter != tt.wantShorter {
+				t.Errorf("words = %d, want shorter: %v", got, tt.wantShorter)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package prompt
 import (
 	"context"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/wikidata"
 	"time"
 )
 
@@ -37,6 +38,8 @@ type Store interface {
 	SaveArticle(ctx context.Context, art *model.Article) error
 	GetRecentlyPlayedPOIs(ctx context.Context, since time.Time) ([]*model.POI, error)
 	GetState(ctx context.Context, key string) (string, bool)
+	GetCache(ctx context.Context, key string) ([]byte, bool)
+	SetCache(ctx context.Context, key string, val []byte) error
 }
 
 type LLMProvider interface {
@@ -56,6 +59,17 @@ type LanguageResolver interface {
 	GetLanguageInfo(code string) model.LanguageInfo
 }
 
+// CountryLanguageResolver is implemented by language resolvers that can tell
+// a country without a mapped language apart from an English-speaking one.
+type CountryLanguageResolver interface {
+	PrimaryLanguage(countryCode string) (model.LanguageInfo, bool)
+}
+
+// FactSource supplies readable Wikidata facts about an entity.
+type FactSource interface {
+	GetEntityFacts(ctx context.Context, id string, props []string) ([]wikidata.Fact, error)
+}
+
 type Renderer interface {
 	Render(name string, data any) (string, error)
 }
@@ -66,3 +80,10 @@ const (
 	StrategyUniform = "uniform"
 	StrategyFixed   = "fixed"
 )
+
+// Persona names. Dialogue scripts label turns with them, and the narrator
+// picks the voice for each turn by name.
+const (
+	GuideName     = "Ava"
+	PassengerName = "Andrew"
+)
@@ -0,0 +1,114 @@
+package request
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// Circuit breaker states, as reported by HostBreaker.Snapshot.
+const (
+	BreakerClosed   = "closed"
+	BreakerOpen     = "open"
+	BreakerHalfOpen = "half_open"
+)
+
+// ErrCircuitOpen is returned without a network call while a host's breaker
+// is open.
+var ErrCircuitOpen = errors.New("circuit open")
+
+// HostBreaker is a circuit breaker per host. After threshold consecutive
+// failures a host is cut off for the cooldown, so callers fail fast instead
+// of queueing behind retries against a host that is down. Once the cooldown
+// has passed the breaker is half-open: requests go out again under the
+// provider backoff, the first success closes it and a failure re-opens it.
+type HostBreaker struct {
+	mu        sync.Mutex
+	hosts     map[string]*breakerState
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+}
+
+type breakerState struct {
+	failures int
+	openedAt time.Time // Zero while closed
+}
+
+// BreakerStatus is the state of one host's breaker.
+type BreakerStatus struct {
+	State     string     `json:"state"`
+	Failures  int        `json:"consecutive_failures"`
+	OpenUntil *time.Time `json:"open_until,omitempty"`
+}
+
+// NewHostBreaker creates a breaker that opens after threshold consecutive
+// failures and stays open for cooldown.
+func NewHostBreaker(threshold int, cooldown time.Duration) *HostBreaker {
+	return &HostBreaker{
+		hosts:     make(map[string]*breakerState),
+		threshold: threshold,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// Allow reports whether a request to host may go out.
+func (b *HostBreaker) Allow(host string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stateLocked(b.hosts[host]) != BreakerOpen
+}
+
+// RecordFailure counts a failed request and opens the breaker at the
+// threshold, or re-opens it when the half-open probe failed.
+func (b *HostBreaker) RecordFailure(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	s, ok := b.hosts[host]
+	if !ok {
+		s = &breakerState{}
+		b.hosts[host] = s
+	}
+	s.failures++
+	if s.failures >= b.threshold || !s.openedAt.IsZero() {
+		s.openedAt = b.now()
+	}
+}
+
+// RecordSuccess closes the breaker.
+func (b *HostBreaker) RecordSuccess(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.hosts, host)
+}
+
+// Snapshot returns the state of every host that has failed since its last
+// success.
+func (b *HostBreaker) Snapshot() map[string]BreakerStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	out := make(map[string]BreakerStatus, len(b.hosts))
+	for host, s := range b.hosts {
+		st := BreakerStatus{State: b.stateLocked(s), Failures: s.failures}
+		if st.State == BreakerOpen {
+			until := s.openedAt.Add(b.cooldown)
+			st.OpenUntil = &until
+		}
+		out[host] = st
+	}
+	return out
+}
+
+func (b *HostBreaker) stateLocked(s *breakerState) string {
+	switch {
+	case s == nil || s.openedAt.IsZero():
+		return BreakerClosed
+	case b.now().Before(s.openedAt.Add(b.cooldown)):
+		return BreakerOpen
+	default:
+		return BreakerHalfOpen
+	}
+}
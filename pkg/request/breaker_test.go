@@ -0,0 +1,112 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/tracker"
+)
+
+func TestHostBreaker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	b := NewHostBreaker(3, 30*time.Second)
+	b.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		b.RecordFailure("a.example")
+	}
+	if !b.Allow("a.example") {
+		t.Fatal("expected the breaker to stay closed below the threshold")
+	}
+
+	b.RecordFailure("a.example")
+	if b.Allow("a.example") {
+		t.Fatal("expected the breaker to open at the threshold")
+	}
+	if !b.Allow("b.example") {
+		t.Error("expected other hosts to be unaffected")
+	}
+	if st := b.Snapshot()["a.example"]; st.State != BreakerOpen || st.OpenUntil == nil {
+		t.Errorf("expected an open state with an end time, got %+v", st)
+	}
+
+	now = now.Add(31 * time.Second)
+	if !b.Allow("a.example") || b.Snapshot()["a.example"].State != BreakerHalfOpen {
+		t.Fatal("expected the breaker to be half-open after the cooldown")
+	}
+
+	// A failed probe re-opens it straight away
+	b.RecordFailure("a.example")
+	if b.Allow("a.example") {
+		t.Fatal("expected a failed half-open probe to re-open the breaker")
+	}
+
+	now = now.Add(31 * time.Second)
+	b.RecordSuccess("a.example")
+	if !b.Allow("a.example") {
+		t.Fatal("expected a success to close the breaker")
+	}
+	if _, ok := b.Snapshot()["a.example"]; ok {
+		t.Error("expected a closed breaker to drop out of the snapshot")
+	}
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var healthy atomic.Bool
+	var hits atomic.Int32
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer svr.Close()
+	u, _ := url.Parse(svr.URL)
+
+	client := New(nil, tracker.New(), ClientConfig{
+		Retries:          1,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         time.Millisecond,
+		BreakerThreshold: 3,
+		BreakerCooldown:  200 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	// 1. Failures open the breaker
+	for i := 0; i < 3; i++ {
+		if _, err := client.Get(ctx, svr.URL, ""); err == nil || errors.Is(err, ErrCircuitOpen) {
+			t.Fatalf("request %d: expected an upstream error, got %v", i, err)
+		}
+	}
+	if st := client.BreakerStates()[u.Host]; st.State != BreakerOpen {
+		t.Fatalf("expected the breaker to be open, got %+v", st)
+	}
+
+	// 2. Open: fail fast without touching the host
+	before := hits.Load()
+	if _, err := client.Get(ctx, svr.URL, ""); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen, got %v", err)
+	}
+	if hits.Load() != before {
+		t.Error("expected no request to reach the host while open")
+	}
+
+	// 3. Half-open after the cooldown: a success closes it
+	healthy.Store(true)
+	time.Sleep(250 * time.Millisecond)
+	body, err := client.Get(ctx, svr.URL, "")
+	if err != nil || string(body) != "ok" {
+		t.Fatalf("expected recovery after the cooldown, got %q, %v", body, err)
+	}
+	if _, ok := client.BreakerStates()[u.Host]; ok {
+		t.Error("expected the breaker to be closed after recovery")
+	}
+}
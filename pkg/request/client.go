@@ -2,6 +2,7 @@ package request
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -22,6 +23,27 @@ var (
 	defaultUserAgent = fmt.Sprintf("Phileas Tour Guide for MSFS (Phileas/%s; aurel42@gmail.com)", version.Version)
 )
 
+// StatusError is an HTTP error response. Callers check the code with
+// errors.As, e.g. to remember a 404 instead of asking again.
+type StatusError struct {
+	Code int
+	// Body is the start of the response body, for APIs that only say why
+	// they failed in there (e.g. a WDQS query timeout is a plain 500).
+	Body string
+}
+
+// maxErrorBody bounds how much of an error response is kept.
+const maxErrorBody = 4096
+
+func newStatusError(resp *http.Response) *StatusError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+	return &StatusError{Code: resp.StatusCode, Body: string(body)}
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("api error: status %d", e.Code)
+}
+
 // CtxKey is a type for context keys to avoid collisions.
 type CtxKey string
 
@@ -37,12 +59,18 @@ const CtxProviderLabel CtxKey = "provider_label"
 // Value should be a []string of provider names.
 const CtxExcludedProviders CtxKey = "excluded_providers"
 
+// CtxSendTimeout is the context key for a deadline that starts when a queued
+// request is actually sent, so time spent waiting behind other jobs in the
+// provider queue does not count against it. Value should be a time.Duration.
+const CtxSendTimeout CtxKey = "send_timeout"
+
 // Client handles HTTP requests with queuing, caching, and tracking.
 type Client struct {
 	httpClient *http.Client
 	cache      cache.Cacher
 	tracker    *tracker.Tracker
 	backoff    *ProviderBackoff
+	breaker    *HostBreaker
 
 	// Config
 	retries int
@@ -71,6 +99,10 @@ type ClientConfig struct {
 	Timeout   time.Duration
 	BaseDelay time.Duration
 	MaxDelay  time.Duration
+	// A host fails fast for BreakerCooldown after BreakerThreshold
+	// consecutive failures.
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
 // New creates a new Client.
@@ -88,12 +120,19 @@ func New(c cache.Cacher, t *tracker.Tracker, cfg ClientConfig) *Client {
 	if cfg.MaxDelay == 0 {
 		cfg.MaxDelay = 60 * time.Second
 	}
+	if cfg.BreakerThreshold == 0 {
+		cfg.BreakerThreshold = 5
+	}
+	if cfg.BreakerCooldown == 0 {
+		cfg.BreakerCooldown = 30 * time.Second
+	}
 
 	return &Client{
 		httpClient: &http.Client{Timeout: cfg.Timeout},
 		cache:      c,
 		tracker:    t,
 		backoff:    NewProviderBackoff(cfg.BaseDelay, cfg.MaxDelay),
+		breaker:    NewHostBreaker(cfg.BreakerThreshold, cfg.BreakerCooldown),
 		retries:    cfg.Retries,
 		queues:     make(map[string]chan job),
 	}
@@ -104,6 +143,12 @@ func (c *Client) SetTransport(t http.RoundTripper) {
 	c.httpClient.Transport = t
 }
 
+// BreakerStates returns the circuit breaker state of every host that has
+// failed since its last success.
+func (c *Client) BreakerStates() map[string]BreakerStatus {
+	return c.breaker.Snapshot()
+}
+
 // Get performs a GET request with queuing and caching if key is provided.
 func (c *Client) Get(ctx context.Context, u, cacheKey string) ([]byte, error) {
 	return c.GetWithHeaders(ctx, u, nil, cacheKey)
@@ -181,6 +226,61 @@ func (c *Client) PostWithHeaders(ctx context.Context, u string, body []byte, hea
 	}
 }
 
+// PostStream performs a POST request and returns the open response body for incremental reading.
+// Streams bypass the per-provider queue (a long-lived stream would block the worker) and are
+// attempted once; provider backoff and success/failure tracking still apply.
+// The caller must close the returned body.
+func (c *Client) PostStream(ctx context.Context, u string, body []byte, headers map[string]string) (io.ReadCloser, error) {
+	parsedURL, err := url.Parse(u)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	provider := c.resolveProvider(ctx, parsedURL.Host)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", u, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", defaultUserAgent)
+	}
+
+	if !c.breaker.Allow(req.URL.Host) {
+		return nil, fmt.Errorf("%w for %s", ErrCircuitOpen, req.URL.Host)
+	}
+	c.backoff.Wait(provider)
+	logging.TraceDefault("Network Request (stream)", "host", req.URL.Host, "path", req.URL.Path)
+
+	// The client-wide timeout covers reading the whole body, which is what bounds a stream.
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.breaker.RecordFailure(req.URL.Host)
+		}
+		c.backoff.RecordFailure(provider)
+		c.tracker.TrackAPIFailure(provider)
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		resp.Body.Close()
+		if resp.StatusCode == 429 || resp.StatusCode >= 500 {
+			c.backoff.RecordFailure(provider)
+			c.breaker.RecordFailure(req.URL.Host)
+		}
+		c.tracker.TrackAPIFailure(provider)
+		return nil, fmt.Errorf("api error: status %d", resp.StatusCode)
+	}
+
+	c.backoff.RecordSuccess(provider)
+	c.breaker.RecordSuccess(req.URL.Host)
+	c.tracker.TrackAPISuccess(provider)
+	return resp.Body, nil
+}
+
 func (c *Client) resolveProvider(ctx context.Context, host string) string {
 	if label, ok := ctx.Value(CtxProviderLabel).(string); ok && label != "" {
 		return label
@@ -227,6 +327,17 @@ func (c *Client) dispatch(provider string, j job) {
 	}
 }
 
+// executeJob runs a dequeued request, starting its send timeout (if any) now
+// rather than when it was enqueued.
+func (c *Client) executeJob(req *http.Request) ([]byte, error) {
+	if d, ok := req.Context().Value(CtxSendTimeout).(time.Duration); ok && d > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), d)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+	return c.executeWithBackoff(req)
+}
+
 // worker processes requests for a specific provider sequentially.
 func (c *Client) worker(provider string, q <-chan job) {
 	for j := range q {
@@ -249,7 +360,12 @@ func (c *Client) worker(provider string, q <-chan job) {
 			j.req.Header.Set("User-Agent", defaultUserAgent)
 		}
 
-		body, err := c.executeWithBackoff(j.req)
+		body, err := c.executeJob(j.req)
+		if errors.Is(err, ErrCircuitOpen) {
+			// Nothing went out, so neither tracking nor the safety gap apply
+			j.respChan <- jobResult{err: err}
+			continue
+		}
 
 		if err == nil {
 			c.tracker.TrackAPISuccess(provider)
@@ -371,7 +487,11 @@ func (c *Client) executeWithBackoff(req *http.Request) ([]byte, error) {
 		}
 	}
 
+	var lastErr error
 	for attempt := 0; attempt < maxAttempts; attempt++ {
+		if !c.breaker.Allow(req.URL.Host) {
+			return nil, fmt.Errorf("%w for %s", ErrCircuitOpen, req.URL.Host)
+		}
 		// Wait for any provider-level backoff (unless we are in single-attempt mode)
 		if maxAttempts > 1 {
 			c.backoff.Wait(provider)
@@ -384,10 +504,12 @@ func (c *Client) executeWithBackoff(req *http.Request) ([]byte, error) {
 		if !retryable {
 			return nil, err
 		}
+		lastErr = err
 		// Continue to next attempt
 	}
 
-	return nil, fmt.Errorf("max attempts (%d) exceeded for %s", maxAttempts, provider)
+	// Keep the last cause so callers can tell e.g. timeouts from throttling
+	return nil, fmt.Errorf("max attempts (%d) exceeded for %s: %w", maxAttempts, provider, lastErr)
 }
 
 func (c *Client) executeAttempt(req *http.Request, provider string, attempt int) (body []byte, retryable bool, err error) {
@@ -418,6 +540,7 @@ func (c *Client) executeAttempt(req *http.Request, provider string, attempt int)
 		slog.Debug("Request failed", "provider", provider, "error", err)
 		slog.Warn("Request failed, retrying", "provider", provider, "attempt", attempt+1, "error", err)
 		c.backoff.RecordFailure(provider)
+		c.breaker.RecordFailure(req.URL.Host)
 		return nil, true, err
 	}
 	defer resp.Body.Close()
@@ -427,12 +550,16 @@ func (c *Client) executeAttempt(req *http.Request, provider string, attempt int)
 		slog.Debug("Request failed (retryable)", "status", resp.StatusCode, "provider", provider)
 		slog.Warn("API Backoff", "status", resp.StatusCode, "provider", provider, "attempt", attempt+1)
 		c.backoff.RecordFailure(provider)
-		return nil, true, fmt.Errorf("api error: status %d", resp.StatusCode)
+		c.breaker.RecordFailure(req.URL.Host)
+		return nil, true, newStatusError(resp)
 	}
 
+	// The host answered, so even a terminal client error closes the breaker
+	c.breaker.RecordSuccess(req.URL.Host)
+
 	if resp.StatusCode >= 400 {
 		slog.Debug("Request failed (terminal)", "status", resp.StatusCode, "provider", provider, "url", req.URL.String())
-		return nil, false, fmt.Errorf("api error: status %d", resp.StatusCode)
+		return nil, false, newStatusError(resp)
 	}
 
 	// Success - record it for gradual recovery
@@ -428,3 +428,35 @@ func TestClient_BypassWait(t *testing.T) {
 		})
 	}
 }
+
+func TestCtxSendTimeout_StartsAtSend(t *testing.T) {
+	const delay = 100 * time.Millisecond
+	svr := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		w.WriteHeader(200)
+	}))
+	defer svr.Close()
+
+	d, err := db.Init(filepath.Join(t.TempDir(), "send_timeout.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer d.Close()
+	client := New(cache.NewSQLiteCache(d), tracker.New(), ClientConfig{})
+
+	// Three jobs queue behind each other, so the last one waits ~2x delay
+	// before it is sent. A deadline counted from enqueue would expire it.
+	ctx := context.WithValue(context.Background(), CtxSendTimeout, 2*delay)
+	errs := make(chan error, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			_, err := client.Get(ctx, svr.URL, "")
+			errs <- err
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		if err := <-errs; err != nil {
+			t.Errorf("queued request failed: %v", err)
+		}
+	}
+}
@@ -198,3 +198,30 @@ func TestDefaultSession_DetermineDeferral(t *testing.T) {
 		}
 	})
 }
+
+func TestDefaultSession_CalculateDeferral_Multiplier(t *testing.T) {
+	s := setupDeferralScorer()
+	cfg := *s.config.Load()
+	cfg.DeferralMultiplier = 0.25
+	s.config.Store(&cfg)
+
+	// Flying north at 60kts; the POI 40nm ahead gets twice as visible later
+	futurePositions := make([]geo.Point, 0, 9)
+	for _, mins := range []float64{1, 2, 3, 5, 7, 9, 11, 13, 15} {
+		futurePositions = append(futurePositions, geo.Point{Lat: mins / 60.0, Lon: 0})
+	}
+	sess := &DefaultSession{
+		scorer:          s,
+		input:           &ScoringInput{Telemetry: sim.Telemetry{AltitudeAGL: 1000, AltitudeMSL: 1000, GroundSpeed: 60}, BoostFactor: 1.0},
+		futurePositions: futurePositions,
+	}
+	poi := &model.POI{Lat: 40.0 / 60.0, Lon: 0, Category: "mountain", Score: 8, IsVisible: true, Visibility: 0.2}
+
+	sess.CalculateDeferral(poi)
+	if !poi.IsDeferred {
+		t.Fatal("expected POI to be deferred")
+	}
+	if poi.Score != 2 {
+		t.Errorf("expected deferred score 2 (8 x 0.25), got %.2f", poi.Score)
+	}
+}
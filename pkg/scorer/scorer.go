@@ -1,9 +1,12 @@
 package scorer
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"phileasgo/pkg/config"
@@ -21,6 +24,11 @@ type ScoringInput struct {
 	RepeatTTL       time.Duration `json:"repeat_ttl"`
 	BoostFactor     float64       `json:"boost_factor"` // Multiplier for visibility range (1.0 - 1.5)
 
+	// Interest boost, read from the live config each pass so keyword changes
+	// apply on the next scoring cycle without reprocessing tiles.
+	InterestKeywords []string `json:"interest_keywords"`
+	InterestBoost    float64  `json:"interest_boost"`
+
 	// [GAP FIX] IsPOIBusy allows the Scorer to skip POIs that are currently
 	// generating or playing, preventing their scores from being zeroed out.
 	IsPOIBusy func(qid string) bool
@@ -36,33 +44,98 @@ type Session interface {
 
 // Scorer calculates dynamic scores for POIs.
 type Scorer struct {
-	config              *config.ScorerConfig
+	config              atomic.Pointer[config.ScorerConfig]
+	tuning              TuningSource
 	catConfig           *config.CategoriesConfig
 	visCalc             *visibility.Calculator
 	elevation           terrain.ElevationGetter
+	los                 LineOfSight
 	density             DensityResolver
 	pregroundingEnabled bool
+
+	regions     RegionResolver
+	history     RegionHistory
+	regionMu    sync.Mutex
+	regionOfPOI map[string]string // POIs don't move, so each is resolved once
+}
+
+// maxRegionCacheEntries bounds regionOfPOI over a long flight. Dropping the
+// whole cache when it fills is cheap: only POIs still in range get resolved
+// again.
+const maxRegionCacheEntries = 20000
+
+// RegionResolver resolves the region of a position, as geo.Service does.
+type RegionResolver interface {
+	GetLocation(lat, lon float64) model.LocationInfo
+}
+
+// RegionHistory reports when regions were last flown over, keyed by
+// model.LocationInfo.RegionKey. A nil map means the history is not loaded yet.
+type RegionHistory interface {
+	RegionVisits() map[string]time.Time
+}
+
+// LineOfSight checks terrain occlusion, as implemented by terrain.LOSChecker.
+type LineOfSight interface {
+	IsVisible(p1, p2 geo.Point, alt1Ft, alt2Ft, stepSizeKM float64) bool
+	GetElevation(lat, lon float64) (float64, error)
 }
 
+// losStepKM is coarser than the narration check: this runs for every POI in
+// range on each scoring pass.
+const losStepKM = 1.0
+
 // DensityResolver defines the density management interface.
 type DensityResolver interface {
 	GetAdjustedLength(rawLen int, url string) int
 }
 
+// TuningSource supplies the scorer weights as tuned at runtime;
+// config.Provider implements it.
+type TuningSource interface {
+	ScorerTuning(ctx context.Context) config.ScorerConfig
+}
+
 // NewScorer creates a new Scorer.
 func NewScorer(cfg *config.ScorerConfig, catCfg *config.CategoriesConfig, visCalc *visibility.Calculator, elev terrain.ElevationGetter, density DensityResolver, pregroundingEnabled bool) *Scorer {
-	return &Scorer{
-		config:              cfg,
+	s := &Scorer{
 		catConfig:           catCfg,
 		visCalc:             visCalc,
 		elevation:           elev,
 		density:             density,
 		pregroundingEnabled: pregroundingEnabled,
 	}
+	s.config.Store(cfg)
+	return s
+}
+
+// SetTuning makes every scoring pass start from the weights in src, so
+// tuning them takes effect without rebuilding the scorer.
+func (s *Scorer) SetTuning(src TuningSource) {
+	s.tuning = src
+}
+
+// SetLineOfSight enables the terrain visibility term. Only set it when
+// elevation data is loaded; without a checker the term contributes nothing.
+func (s *Scorer) SetLineOfSight(los LineOfSight) {
+	s.los = los
+}
+
+// SetRegionNovelty enables the region novelty term, which favours POIs in
+// regions not flown over recently.
+func (s *Scorer) SetRegionNovelty(r RegionResolver, h RegionHistory) {
+	s.regions = r
+	s.history = h
+	s.regionOfPOI = make(map[string]string)
 }
 
 // NewSession initiates a new scoring cycle, pre-calculating expensive terrain data.
 func (s *Scorer) NewSession(input *ScoringInput) Session {
+	if s.tuning != nil {
+		tuned := s.tuning.ScorerTuning(context.Background())
+		s.config.Store(&tuned)
+	}
+
 	// Pre-calculate lowest elevation in dynamic radius based on XL visibility at MSL
 	// This ensures we scan far enough to see big mountains if we are high up.
 	boost := input.BoostFactor
@@ -75,18 +148,22 @@ func (s *Scorer) NewSession(input *ScoringInput) Session {
 	}
 
 	// This is the O(1) op performed once per cycle.
-	lowestElev, err := s.elevation.GetLowestElevation(input.Telemetry.Latitude, input.Telemetry.Longitude, radiusNM)
-	if err != nil {
-		// Log error? For now, fallback to 0 (MSL) or current elevation?
-		// Since we cap at 0 in implementation, 0 is safe default.
-		lowestElev = 0
+	// Without elevation data, fall back to 0 (MSL).
+	var lowestElev int16
+	if s.elevation != nil {
+		var err error
+		lowestElev, err = s.elevation.GetLowestElevation(input.Telemetry.Latitude, input.Telemetry.Longitude, radiusNM)
+		if err != nil {
+			// Since we cap at 0 in implementation, 0 is safe default.
+			lowestElev = 0
+		}
 	}
 
 	// Pre-calculate future positions for deferral logic:
 	// Near bucket: 1, 2, 3 minutes
 	// Far bucket: 5, 7, 9, 11, 13, 15 minutes (every other minute)
 	var futurePositions []geo.Point
-	if s.config.DeferralEnabled && input.Telemetry.GroundSpeed > 10 {
+	if s.config.Load().DeferralEnabled && input.Telemetry.GroundSpeed > 10 {
 		horizons := []float64{1, 2, 3, 5, 7, 9, 11, 13, 15} // minutes
 		futurePositions = make([]geo.Point, len(horizons))
 		tel := input.Telemetry
@@ -102,12 +179,19 @@ func (s *Scorer) NewSession(input *ScoringInput) Session {
 		}
 	}
 
+	var visits map[string]time.Time
+	if s.history != nil && s.config.Load().RegionNoveltyWeight > 0 {
+		visits = s.history.RegionVisits()
+	}
+
 	return &DefaultSession{
 		scorer:          s,
 		input:           input,
 		lowestElev:      float64(lowestElev),
 		maxRadiusNM:     radiusNM,
 		futurePositions: futurePositions,
+		regionVisits:    visits,
+		now:             time.Now(),
 	}
 }
 
@@ -117,6 +201,8 @@ type DefaultSession struct {
 	lowestElev      float64
 	maxRadiusNM     float64
 	futurePositions []geo.Point // Pre-calculated positions at +1, +3, +5, +10, +15 min
+	regionVisits    map[string]time.Time
+	now             time.Time
 }
 
 // Calculate updates the Score, Visibility, IsVisible, and IsDeferred fields of the POI.
@@ -212,9 +298,86 @@ func (sess *DefaultSession) calculateIntrinsicScore(poi *model.POI) (score float
 		poi.Badges = append(poi.Badges, "fresh")
 	}
 
+	// Interest Boost
+	if input.InterestBoost > 0 && input.InterestBoost != 1.0 {
+		if kw := matchInterest(poi, input.InterestKeywords); kw != "" {
+			score *= input.InterestBoost
+			logs = append(logs, fmt.Sprintf("Interest Boost (%s): x%.2f", kw, input.InterestBoost))
+		}
+	}
+
+	// Region Novelty
+	if mult, region := sess.regionNovelty(poi); mult != 1.0 {
+		score *= mult
+		logs = append(logs, fmt.Sprintf("Region Novelty (%s): x%.2f", region, mult))
+	}
+
 	return score, logs
 }
 
+// regionNovelty returns the novelty multiplier for the POI's region and the
+// region, or 1 when the term is disabled or the POI lies in no region.
+func (sess *DefaultSession) regionNovelty(poi *model.POI) (mult float64, region string) {
+	s := sess.scorer
+	if sess.regionVisits == nil || s.regions == nil {
+		return 1.0, ""
+	}
+	region = s.regionOf(poi)
+	if region == "" {
+		return 1.0, ""
+	}
+	return regionNoveltyMultiplier(s.config.Load().RegionNoveltyWeight, time.Duration(s.config.Load().RegionNoveltyHalfLife), sess.regionVisits[region], sess.now), region
+}
+
+func (s *Scorer) regionOf(poi *model.POI) string {
+	s.regionMu.Lock()
+	defer s.regionMu.Unlock()
+	region, ok := s.regionOfPOI[poi.WikidataID]
+	if !ok {
+		region = s.regions.GetLocation(poi.Lat, poi.Lon).RegionKey()
+		if len(s.regionOfPOI) >= maxRegionCacheEntries {
+			s.regionOfPOI = make(map[string]string)
+		}
+		s.regionOfPOI[poi.WikidataID] = region
+	}
+	return region
+}
+
+// regionNoveltyMultiplier ranges from 1-weight for a region visited just now
+// to 1+weight for one never visited. Freshness recovers exponentially, so a
+// region visited one half-life ago is neutral.
+func regionNoveltyMultiplier(weight float64, halfLife time.Duration, lastVisit, now time.Time) float64 {
+	fresh := 1.0
+	if !lastVisit.IsZero() {
+		fresh = 0
+		if age := now.Sub(lastVisit); halfLife > 0 && age > 0 {
+			fresh = 1 - math.Exp2(-float64(age)/float64(halfLife))
+		}
+	}
+	return 1 + weight*(2*fresh-1)
+}
+
+// matchInterest returns the first keyword found in the POI's categories or
+// names, or "" if none matches.
+func matchInterest(poi *model.POI, keywords []string) string {
+	if len(keywords) == 0 {
+		return ""
+	}
+	fields := []string{poi.Category, poi.SpecificCategory, poi.NameEn, poi.NameUser, poi.NameLocal}
+	for _, kw := range keywords {
+		needle := strings.ToLower(strings.TrimSpace(kw))
+		if needle == "" {
+			continue
+		}
+		for _, f := range fields {
+			if f != "" && strings.Contains(strings.ToLower(f), needle) {
+				return kw
+			}
+		}
+	}
+	return ""
+}
+
 // CalculateDeferral computes the expensive deferral decision for a single POI.
 // This is meant to be called only for the top N visible candidates after
 // the main Calculate() pass, to avoid running 9-position visibility checks
@@ -227,6 +390,12 @@ func (sess *DefaultSession) CalculateDeferral(poi *model.POI) {
 	if poi.IsDeferred {
 		poi.Badges = append(poi.Badges, "deferred")
 		poi.ScoreDetails += "\nDeferred: Better view coming"
+		// Sink the POI in the rankings too, so the map and the adaptive
+		// threshold reflect that it is not up next.
+		if mult := sess.scorer.config.Load().DeferralMultiplier; mult >= 0 && mult < 1 {
+			poi.Score *= mult
+			poi.ScoreDetails += fmt.Sprintf(" (x%.2f)", mult)
+		}
 	}
 }
 
@@ -287,12 +456,12 @@ func (sess *DefaultSession) determineDeferral(poi *model.POI, heading, currentVi
 		}
 	}
 
-	threshold := sess.scorer.config.DeferralThreshold
+	threshold := sess.scorer.config.Load().DeferralThreshold
 	if threshold <= 0 {
 		threshold = 1.1 // Default: defer if future is 10% better
 	}
 
-	power := sess.scorer.config.DeferralProximityBoostPower
+	power := sess.scorer.config.Load().DeferralProximityBoostPower
 	if power <= 0 {
 		power = 1.0
 	}
@@ -392,12 +561,39 @@ func (s *Scorer) calculateVisibilityScore(poi *model.POI, state *sim.Telemetry,
 		logs = append(logs, fmt.Sprintf("Dimensions: x%.1f", poi.DimensionMultiplier))
 	}
 
-	// Store final visibility score (includes size penalty and dimension multiplier)
+	// 6. Apply Terrain Line of Sight
+	if factor, log := s.lineOfSightFactor(poi, state); factor != 1.0 {
+		score *= factor
+		logs = append(logs, log)
+	}
+
+	// Store final visibility score (includes size penalty, dimension multiplier and line of sight)
 	poi.Visibility = score
 
 	return score, logs, false
 }
 
+// lineOfSightFactor returns 1+w when terrain leaves the POI in view and 1-w
+// when it hides it. It is neutral when the weight or the checker is unset, or
+// the POI's ground elevation is unknown.
+func (s *Scorer) lineOfSightFactor(poi *model.POI, state *sim.Telemetry) (factor float64, log string) {
+	w := s.config.Load().VisibilityWeight
+	if w <= 0 || s.los == nil {
+		return 1.0, ""
+	}
+	poiElevM, err := s.los.GetElevation(poi.Lat, poi.Lon)
+	if err != nil {
+		return 1.0, ""
+	}
+
+	aircraft := geo.Point{Lat: state.Latitude, Lon: state.Longitude}
+	target := geo.Point{Lat: poi.Lat, Lon: poi.Lon}
+	if s.los.IsVisible(aircraft, target, state.AltitudeMSL, poiElevM*3.28084, losStepKM) {
+		return 1.0 + w, fmt.Sprintf("Line of Sight: x%.2f", 1.0+w)
+	}
+	return math.Max(0, 1.0-w), fmt.Sprintf("Terrain Occluded: x%.2f", math.Max(0, 1.0-w))
+}
+
 func (s *Scorer) calculateContentScore(poi *model.POI) (score float64, logs []string) {
 	score = 1.0
 
@@ -412,7 +608,7 @@ func (s *Scorer) calculateContentScore(poi *model.POI) (score float64, logs []st
 	// Pregrounding bonus: categories with preground=true get virtual article boost
 	pregroundApplied := false
 	if s.pregroundingEnabled && s.catConfig.ShouldPreground(poi.Category) {
-		boost := s.config.PregroundBoost
+		boost := s.config.Load().PregroundBoost
 		if boost <= 0 {
 			boost = 4000 // Default
 		}
@@ -426,7 +622,7 @@ func (s *Scorer) calculateContentScore(poi *model.POI) (score float64, logs []st
 	if lengthMult > 1.0 {
 		score *= lengthMult
 		if pregroundApplied {
-			logs = append(logs, fmt.Sprintf("Length (%d+%d chars): x%.2f", poi.WPArticleLength, int(s.config.PregroundBoost), lengthMult))
+			logs = append(logs, fmt.Sprintf("Length (%d+%d chars): x%.2f", poi.WPArticleLength, int(s.config.Load().PregroundBoost), lengthMult))
 		} else {
 			logs = append(logs, fmt.Sprintf("Length (%d chars): x%.2f", poi.WPArticleLength, lengthMult))
 		}
@@ -462,12 +658,19 @@ func (s *Scorer) calculateContentScore(poi *model.POI) (score float64, logs []st
 		logs = append(logs, "MSFS POI: x4.0")
 	}
 
+	// User POI: picked by the user, but without sitelinks or an article to
+	// lift its score
+	if poi.Source == model.SourceUser {
+		score *= 4.0
+		logs = append(logs, "User POI: x4.0")
+	}
+
 	return score, logs
 }
 
 func (s *Scorer) calculateVarietyScore(poi *model.POI, history []string) (multiplier float64, logs []string) {
 	if len(history) == 0 {
-		return s.config.NoveltyBoost, []string{fmt.Sprintf("Novelty Boost (No History): x%.2f", s.config.NoveltyBoost)}
+		return s.config.Load().NoveltyBoost, []string{fmt.Sprintf("Novelty Boost (No History): x%.2f", s.config.Load().NoveltyBoost)}
 	}
 
 	foundIdx := -1
@@ -478,17 +681,17 @@ func (s *Scorer) calculateVarietyScore(poi *model.POI, history []string) (multip
 		}
 	}
 
-	if foundIdx != -1 && foundIdx < s.config.VarietyPenaltyNum {
-		appliedPenalty := s.config.VarietyPenaltyFirst
-		if s.config.VarietyPenaltyNum > 1 {
-			fraction := float64(foundIdx) / float64(s.config.VarietyPenaltyNum-1)
-			appliedPenalty = s.config.VarietyPenaltyFirst + (s.config.VarietyPenaltyLast-s.config.VarietyPenaltyFirst)*fraction
+	if foundIdx != -1 && foundIdx < s.config.Load().VarietyPenaltyNum {
+		appliedPenalty := s.config.Load().VarietyPenaltyFirst
+		if s.config.Load().VarietyPenaltyNum > 1 {
+			fraction := float64(foundIdx) / float64(s.config.Load().VarietyPenaltyNum-1)
+			appliedPenalty = s.config.Load().VarietyPenaltyFirst + (s.config.Load().VarietyPenaltyLast-s.config.Load().VarietyPenaltyFirst)*fraction
 		}
 		return appliedPenalty, []string{fmt.Sprintf("Variety Penalty (Pos %d): x%.2f", foundIdx+1, appliedPenalty)}
 	}
 
 	// If not found in history, OR found but outside penalty window
-	boost := s.config.NoveltyBoost
+	boost := s.config.Load().NoveltyBoost
 	logs = append(logs, fmt.Sprintf("Novelty Boost: x%.2f", boost))
 	multiplier = boost
 
@@ -499,7 +702,7 @@ func (s *Scorer) calculateVarietyScore(poi *model.POI, history []string) (multip
 		lastGroup := s.catConfig.GetGroup(lastCat)
 
 		if candGroup != "" && lastGroup != "" && candGroup == lastGroup {
-			groupPenalty := s.config.GroupPenalty
+			groupPenalty := s.config.Load().GroupPenalty
 			multiplier *= groupPenalty
 			logs = append(logs, fmt.Sprintf("Group Penalty (%s): x%.2f", candGroup, groupPenalty))
 		}
@@ -533,7 +736,7 @@ func (sess *DefaultSession) getEffectiveWordLength(poi *model.POI) int {
 
 func (sess *DefaultSession) assignLengthBadges(poi *model.POI, effLen int) {
 	s := sess.scorer
-	limit := s.config.Badges.DeepDive.ArticleLenMin
+	limit := s.config.Load().Badges.DeepDive.ArticleLenMin
 	if limit <= 0 {
 		limit = 20000
 	}
@@ -551,14 +754,14 @@ func (sess *DefaultSession) assignStubBadge(poi *model.POI, effLen int) {
 		return
 	}
 
-	stubLimit := s.config.Badges.Stub.ArticleLenMax
+	stubLimit := s.config.Load().Badges.Stub.ArticleLenMax
 	if stubLimit <= 0 {
 		stubLimit = 2500
 	}
 
 	adjEffectiveLen := float64(effLen)
 	if s.pregroundingEnabled && s.catConfig != nil && s.catConfig.ShouldPreground(poi.Category) {
-		boost := s.config.PregroundBoost
+		boost := s.config.Load().PregroundBoost
 		if boost <= 0 {
 			boost = 4000
 		}
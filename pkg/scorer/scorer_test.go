@@ -1,11 +1,14 @@
 package scorer
 
 import (
+	"errors"
+	"math"
 	"strings"
 	"testing"
 	"time"
 
 	"phileasgo/pkg/config"
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/sim"
 
@@ -630,3 +633,210 @@ func TestScorer_StubRescue(t *testing.T) {
 		})
 	}
 }
+
+func TestScorer_InterestBoost(t *testing.T) {
+	s := setupScorer()
+	tel := sim.Telemetry{
+		Latitude: -0.04, Longitude: 0.0,
+		AltitudeMSL: 1000, AltitudeAGL: 1000, Heading: 0,
+	}
+	newPOI := func() *model.POI {
+		return &model.POI{
+			WikidataID: "Q123", NameEn: "Hütte Steel Mill",
+			Lat: 0.0, Lon: 0.0, Category: "Church",
+		}
+	}
+
+	base := newPOI()
+	s.NewSession(&ScoringInput{Telemetry: tel}).Calculate(base)
+	if base.Score <= 0 {
+		t.Fatalf("expected positive base score, got %.2f", base.Score)
+	}
+
+	tests := []struct {
+		name     string
+		keywords []string
+		boost    float64
+		want     float64
+	}{
+		{name: "Name match", keywords: []string{"steel mill"}, boost: 1.5, want: base.Score * 1.5},
+		{name: "Category match", keywords: []string{"CHURCH"}, boost: 2.0, want: base.Score * 2.0},
+		{name: "No match", keywords: []string{"airport"}, boost: 1.5, want: base.Score},
+		{name: "No keywords", keywords: nil, boost: 1.5, want: base.Score},
+		{name: "Unset boost", keywords: []string{"steel mill"}, boost: 0, want: base.Score},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poi := newPOI()
+			input := &ScoringInput{Telemetry: tel, InterestKeywords: tt.keywords, InterestBoost: tt.boost}
+			s.NewSession(input).Calculate(poi)
+			if diff := poi.Score - tt.want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("Score = %.4f, want %.4f", poi.Score, tt.want)
+			}
+			if boosted := strings.Contains(poi.ScoreDetails, "Interest Boost"); boosted != (tt.want != base.Score) {
+				t.Errorf("unexpected score details: %s", poi.ScoreDetails)
+			}
+		})
+	}
+}
+
+// mockLOS reports every POI in blocked as hidden by terrain.
+type mockLOS struct {
+	blocked map[geo.Point]bool
+	elevErr error
+}
+
+func (m *mockLOS) IsVisible(p1, p2 geo.Point, alt1Ft, alt2Ft, stepSizeKM float64) bool {
+	return !m.blocked[p2]
+}
+
+func (m *mockLOS) GetElevation(lat, lon float64) (float64, error) { return 0, m.elevErr }
+
+func TestScorer_LineOfSight(t *testing.T) {
+	tel := sim.Telemetry{
+		Latitude: -0.04, Longitude: 0.0,
+		AltitudeMSL: 1000, AltitudeAGL: 1000, Heading: 0,
+	}
+	visiblePOI := func() *model.POI {
+		return &model.POI{WikidataID: "Q1", NameEn: "Open", Lat: 0.0, Lon: 0.01, Category: "Church"}
+	}
+	hiddenPOI := func() *model.POI {
+		return &model.POI{WikidataID: "Q2", NameEn: "Behind Ridge", Lat: 0.0, Lon: -0.01, Category: "Church"}
+	}
+	los := &mockLOS{blocked: map[geo.Point]bool{{Lat: 0.0, Lon: -0.01}: true}}
+
+	baseSess := setupScorer().NewSession(&ScoringInput{Telemetry: tel})
+	baseVisible, baseHidden := visiblePOI(), hiddenPOI()
+	baseSess.Calculate(baseVisible)
+	baseSess.Calculate(baseHidden)
+	if baseVisible.Visibility <= 0 || baseHidden.Visibility <= 0 {
+		t.Fatalf("expected positive base visibility, got %.2f / %.2f", baseVisible.Visibility, baseHidden.Visibility)
+	}
+
+	tests := []struct {
+		name          string
+		weight        float64
+		los           LineOfSight
+		visibleFactor float64
+		hiddenFactor  float64
+	}{
+		{name: "Weighted", weight: 0.25, los: los, visibleFactor: 1.25, hiddenFactor: 0.75},
+		{name: "Zero weight", weight: 0, los: los, visibleFactor: 1, hiddenFactor: 1},
+		{name: "No checker", weight: 0.25, los: nil, visibleFactor: 1, hiddenFactor: 1},
+		{name: "Unknown elevation", weight: 0.25, los: &mockLOS{blocked: los.blocked, elevErr: errors.New("no data")}, visibleFactor: 1, hiddenFactor: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := setupScorer()
+			s.config.Load().VisibilityWeight = tt.weight
+			if tt.los != nil {
+				s.SetLineOfSight(tt.los)
+			}
+			sess := s.NewSession(&ScoringInput{Telemetry: tel})
+			visible, hidden := visiblePOI(), hiddenPOI()
+			sess.Calculate(visible)
+			sess.Calculate(hidden)
+
+			if want := baseVisible.Visibility * tt.visibleFactor; math.Abs(visible.Visibility-want) > 1e-9 {
+				t.Errorf("visible POI: Visibility = %.4f, want %.4f", visible.Visibility, want)
+			}
+			if want := baseHidden.Visibility * tt.hiddenFactor; math.Abs(hidden.Visibility-want) > 1e-9 {
+				t.Errorf("occluded POI: Visibility = %.4f, want %.4f", hidden.Visibility, want)
+			}
+			if hidden.Score != visible.Score {
+				t.Errorf("expected line of sight to leave the intrinsic score alone, got %.4f vs %.4f", hidden.Score, visible.Score)
+			}
+		})
+	}
+}
+
+func TestScorer_NoElevationData(t *testing.T) {
+	s := setupScorer()
+	s.elevation = nil
+	s.config.Load().VisibilityWeight = 0.25
+
+	poi := &model.POI{WikidataID: "Q1", NameEn: "Open", Lat: 0.0, Lon: 0.0, Category: "Church"}
+	sess := s.NewSession(&ScoringInput{Telemetry: sim.Telemetry{Latitude: -0.04, AltitudeMSL: 1000, AltitudeAGL: 1000}})
+	sess.Calculate(poi)
+	if sess.LowestElevation() != 0 || poi.Visibility <= 0 {
+		t.Errorf("expected sea-level scoring without elevation data, got floor=%.0f visibility=%.2f", sess.LowestElevation(), poi.Visibility)
+	}
+}
+
+// mockRegions puts everything east of the prime meridian in DE/BY and the
+// rest in FR/IDF.
+type mockRegions struct{}
+
+func (mockRegions) GetLocation(lat, lon float64) model.LocationInfo {
+	if lon > 0 {
+		return model.LocationInfo{CountryCode: "DE", Admin1Code: "BY"}
+	}
+	return model.LocationInfo{CountryCode: "FR", Admin1Code: "IDF"}
+}
+
+type mockRegionHistory map[string]time.Time
+
+func (m mockRegionHistory) RegionVisits() map[string]time.Time { return m }
+
+func TestScorer_RegionNovelty(t *testing.T) {
+	const weight = 0.2
+	tel := sim.Telemetry{
+		Latitude: -0.04, Longitude: 0.0,
+		AltitudeMSL: 1000, AltitudeAGL: 1000, Heading: 0,
+	}
+	unvisitedPOI := func() *model.POI {
+		return &model.POI{WikidataID: "Q1", NameEn: "Church", Lat: 0.0, Lon: 0.01, Category: "Church"}
+	}
+	visitedPOI := func() *model.POI {
+		return &model.POI{WikidataID: "Q2", NameEn: "Church", Lat: 0.0, Lon: -0.01, Category: "Church"}
+	}
+
+	base := unvisitedPOI()
+	setupScorer().NewSession(&ScoringInput{Telemetry: tel}).Calculate(base)
+	if base.Score <= 0 {
+		t.Fatalf("expected positive base score, got %.2f", base.Score)
+	}
+
+	s := setupScorer()
+	s.config.Load().RegionNoveltyWeight = weight
+	s.config.Load().RegionNoveltyHalfLife = config.Duration(30 * 24 * time.Hour)
+	s.SetRegionNovelty(mockRegions{}, mockRegionHistory{"FR/IDF": time.Now()})
+
+	sess := s.NewSession(&ScoringInput{Telemetry: tel})
+	unvisited, visited := unvisitedPOI(), visitedPOI()
+	sess.Calculate(unvisited)
+	sess.Calculate(visited)
+
+	if math.Abs(unvisited.Score-base.Score*(1+weight)) > 1e-6 {
+		t.Errorf("unvisited region score = %.4f, want %.4f", unvisited.Score, base.Score*(1+weight))
+	}
+	if math.Abs(visited.Score-base.Score*(1-weight)) > 1e-6 {
+		t.Errorf("just-visited region score = %.4f, want %.4f", visited.Score, base.Score*(1-weight))
+	}
+	if !strings.Contains(visited.ScoreDetails, "Region Novelty (FR/IDF)") {
+		t.Errorf("unexpected score details: %s", visited.ScoreDetails)
+	}
+}
+
+func TestRegionNoveltyMultiplier(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	halfLife := 30 * 24 * time.Hour
+
+	tests := []struct {
+		name      string
+		lastVisit time.Time
+		want      float64
+	}{
+		{name: "Never visited", want: 1.2},
+		{name: "Just visited", lastVisit: now, want: 0.8},
+		{name: "One half-life ago is neutral", lastVisit: now.Add(-halfLife), want: 1.0},
+		{name: "Two half-lives ago", lastVisit: now.Add(-2 * halfLife), want: 1.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := regionNoveltyMultiplier(0.2, halfLife, tt.lastVisit, now); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("multiplier = %.4f, want %.4f", got, tt.want)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"phileasgo/pkg/geo"
 	"phileasgo/pkg/logging"
 	"phileasgo/pkg/model"
 	"phileasgo/pkg/prompt"
@@ -15,12 +16,19 @@ import (
 
 // Manager handles transient flight session context.
 type Manager struct {
-	mu            sync.RWMutex
-	events        []model.TripEvent
-	lastSentence  string
-	narratedCount int
-	stageData     sim.StageState
-	sim           sim.Client
+	mu              sync.RWMutex
+	events          []model.TripEvent
+	lastSentence    string
+	narratedCount   int
+	stageData       sim.StageState
+	essayThemes     []string
+	essayTopics     []string
+	suppressed      map[string]bool      // POI QIDs not to be narrated again this session
+	suppressedUntil map[string]time.Time // POI QIDs held back until the given time
+	lastPOI         *geo.Point           // Position of the last narrated POI
+	lastFix         *geo.Point           // Last track point fed by AddTrackPoint
+	distanceM       float64              // Distance flown along the track points
+	sim             sim.Client
 }
 
 // NewManager creates a new session manager.
@@ -192,6 +200,178 @@ func (m *Manager) NarratedCount() int {
 	return m.narratedCount
 }
 
+// maxTrackLeg is the longest gap between two track points still counted as
+// flown. Anything longer is a teleport or slew, not part of the route.
+const maxTrackLeg = 50000.0 // Meters
+
+// AddTrackPoint adds the leg from the previous track point to the session's
+// distance flown. The telemetry loop feeds it every few hundred meters.
+func (m *Manager) AddTrackPoint(lat, lon float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := geo.Point{Lat: lat, Lon: lon}
+	if m.lastFix != nil {
+		if d := geo.Distance(*m.lastFix, p); d <= maxTrackLeg {
+			m.distanceM += d
+		}
+	}
+	m.lastFix = &p
+}
+
+// DistanceFlown returns the distance in meters accumulated from the track
+// points of the session.
+func (m *Manager) DistanceFlown() float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.distanceM
+}
+
+// Snapshot is the live view of the session served by /api/session/current.
+type Snapshot struct {
+	Active        bool           `json:"active"`
+	Message       string         `json:"message,omitempty"`
+	StartedAt     *time.Time     `json:"started_at,omitempty"`
+	DistanceM     float64        `json:"distance_m"`
+	NarratedCount int            `json:"narrated_count"`
+	Stage         string         `json:"stage"`
+	Airborne      bool           `json:"airborne"`
+	Summary       []SummaryEntry `json:"summary"`
+}
+
+// SummaryEntry is one narrated stop of the trip.
+type SummaryEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Title     string    `json:"title"`
+	Summary   string    `json:"summary"`
+}
+
+// Current returns the live session state. A session is active once it has
+// recorded anything or the aircraft is airborne, so a restored session
+// shows up straight away while a parked aircraft before the flight reports
+// no session.
+func (m *Manager) Current(ctx context.Context) Snapshot {
+	// Query the sim before taking the lock, as in AddEvent
+	var snap Snapshot
+	if m.sim != nil {
+		if tel, err := m.sim.GetTelemetry(ctx); err == nil {
+			snap.Airborne = !tel.IsOnGround
+		}
+		snap.Stage = m.sim.GetStageState().Current
+	}
+
+	snap.DistanceM = m.DistanceFlown()
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if snap.Stage == "" {
+		snap.Stage = m.stageData.Current
+	}
+	snap.NarratedCount = m.narratedCount
+	snap.Summary = []SummaryEntry{}
+	for _, e := range m.events {
+		if e.Type == "narration" && e.Summary != "" {
+			snap.Summary = append(snap.Summary, SummaryEntry{Timestamp: e.Timestamp, Title: e.Title, Summary: e.Summary})
+		}
+	}
+	if len(m.events) > 0 {
+		// Events survive a restore, so the first one dates the session
+		started := m.events[0].Timestamp
+		snap.StartedAt = &started
+	}
+
+	snap.Active = snap.Airborne || len(m.events) > 0 || m.narratedCount > 0
+	if !snap.Active {
+		snap.Message = "no active session"
+	}
+	return snap
+}
+
+// SetEssayThemes records the essay themes chosen for this session.
+func (m *Manager) SetEssayThemes(ids []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.essayThemes = append([]string(nil), ids...)
+}
+
+// EssayThemes returns the essay themes chosen for this session, if any.
+func (m *Manager) EssayThemes() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string(nil), m.essayThemes...)
+}
+
+// SetEssayTopics records the essay topics played in the current rotation.
+func (m *Manager) SetEssayTopics(ids []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.essayTopics = append([]string(nil), ids...)
+}
+
+// EssayTopics returns the essay topics played in the current rotation.
+func (m *Manager) EssayTopics() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]string(nil), m.essayTopics...)
+}
+
+// SuppressPOI keeps the POI from being narrated again this session.
+func (m *Manager) SuppressPOI(qid string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.suppressed == nil {
+		m.suppressed = make(map[string]bool)
+	}
+	m.suppressed[qid] = true
+}
+
+// SuppressPOIFor keeps the POI from being narrated for d. Unlike SuppressPOI
+// this is not persisted: it covers transient failures, not user choices.
+func (m *Manager) SuppressPOIFor(qid string, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := time.Now()
+	if m.suppressedUntil == nil {
+		m.suppressedUntil = make(map[string]time.Time)
+	}
+	for k, until := range m.suppressedUntil {
+		if !until.After(now) {
+			delete(m.suppressedUntil, k)
+		}
+	}
+	m.suppressedUntil[qid] = now.Add(d)
+}
+
+// IsSuppressed reports whether the POI was suppressed for this session or
+// is still inside a SuppressPOIFor window.
+func (m *Manager) IsSuppressed(qid string) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.suppressed[qid] {
+		return true
+	}
+	until, ok := m.suppressedUntil[qid]
+	return ok && time.Now().Before(until)
+}
+
+// SetLastNarratedPOI records the position of the POI just narrated.
+func (m *Manager) SetLastNarratedPOI(lat, lon float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastPOI = &geo.Point{Lat: lat, Lon: lon}
+}
+
+// LastNarratedPOI returns the position of the last narrated POI, if any.
+func (m *Manager) LastNarratedPOI() (lat, lon float64, ok bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if m.lastPOI == nil {
+		return 0, 0, false
+	}
+	return m.lastPOI.Lat, m.lastPOI.Lon, true
+}
+
 // GetEvents returns a copy of the trip events.
 func (m *Manager) GetEvents() []model.TripEvent {
 	m.mu.RLock()
@@ -208,6 +388,13 @@ func (m *Manager) Reset() {
 	m.lastSentence = ""
 	m.narratedCount = 0
 	m.stageData = sim.StageState{}
+	m.essayThemes = nil
+	m.essayTopics = nil
+	m.suppressed = nil
+	m.suppressedUntil = nil
+	m.lastPOI = nil
+	m.lastFix = nil
+	m.distanceM = 0
 }
 
 // ResetSession implements the SessionResettable interface for deep resets.
@@ -224,6 +411,10 @@ type PersistentState struct {
 	Lat           float64           `json:"lat"`
 	Lon           float64           `json:"lon"`
 	StageData     sim.StageState    `json:"stage_data"`
+	EssayThemes   []string          `json:"essay_themes,omitempty"`
+	EssayTopics   []string          `json:"essay_topics,omitempty"`
+	Suppressed    []string          `json:"suppressed_pois,omitempty"`
+	DistanceM     float64           `json:"distance_m,omitempty"`
 }
 
 // GetPersistentState returns a JSON-encoded representation of the current session state.
@@ -238,6 +429,12 @@ func (m *Manager) GetPersistentState(lat, lon float64) ([]byte, error) {
 		Lat:           lat,
 		Lon:           lon,
 		StageData:     m.stageData,
+		EssayThemes:   m.essayThemes,
+		EssayTopics:   m.essayTopics,
+		DistanceM:     m.distanceM,
+	}
+	for qid := range m.suppressed {
+		ps.Suppressed = append(ps.Suppressed, qid)
 	}
 
 	return json.Marshal(ps)
@@ -257,6 +454,17 @@ func (m *Manager) Restore(data []byte) error {
 	m.lastSentence = ps.LastSentence
 	m.narratedCount = ps.NarratedCount
 	m.stageData = ps.StageData
+	m.essayThemes = ps.EssayThemes
+	m.essayTopics = ps.EssayTopics
+	m.distanceM = ps.DistanceM
+	m.lastFix = nil
+	m.suppressed = nil
+	for _, qid := range ps.Suppressed {
+		if m.suppressed == nil {
+			m.suppressed = make(map[string]bool)
+		}
+		m.suppressed[qid] = true
+	}
 	// Lat/Lon are stored for distance check, not needed in active state for now
 
 	return nil
@@ -97,3 +97,169 @@ func TestManager(t *testing.T) {
 		t.Errorf("expected 0 count after ResetSession")
 	}
 }
+
+func TestManager_DistanceFlown(t *testing.T) {
+	m := NewManager(nil)
+	if d := m.DistanceFlown(); d != 0 {
+		t.Errorf("expected 0 without track points, got %.0f", d)
+	}
+
+	// Ten legs of 0.1° longitude at 47°N, ~7.6 km each
+	for i := 0; i <= 10; i++ {
+		m.AddTrackPoint(47.0, 8.0+float64(i)*0.1)
+	}
+	m.AddTrackPoint(50.0, 9.0) // Teleport, not flown
+	m.AddTrackPoint(50.0, 9.1) // ~7.2 km
+
+	if d := m.DistanceFlown(); d < 82000 || d > 84000 {
+		t.Errorf("expected about 83 km, got %.0f m", d)
+	}
+
+	data, err := m.GetPersistentState(50.0, 9.1)
+	if err != nil {
+		t.Fatalf("GetPersistentState failed: %v", err)
+	}
+	restored := NewManager(nil)
+	if err := restored.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.DistanceFlown() != m.DistanceFlown() {
+		t.Errorf("expected the distance to survive a restart, got %.0f m", restored.DistanceFlown())
+	}
+
+	m.Reset()
+	if d := m.DistanceFlown(); d != 0 {
+		t.Errorf("expected 0 after Reset, got %.0f", d)
+	}
+}
+
+func TestManager_EssayThemesPersist(t *testing.T) {
+	m := NewManager(&mockSimClient{})
+	m.SetEssayThemes([]string{"nature", "industrial_history"})
+	m.SetEssayTopics([]string{"castles"})
+
+	data, err := m.GetPersistentState(0, 0)
+	if err != nil {
+		t.Fatalf("GetPersistentState failed: %v", err)
+	}
+	restored := NewManager(&mockSimClient{})
+	if err := restored.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if got := restored.EssayThemes(); len(got) != 2 || got[0] != "nature" || got[1] != "industrial_history" {
+		t.Errorf("expected the themes to survive a restart, got %v", got)
+	}
+	if got := restored.EssayTopics(); len(got) != 1 || got[0] != "castles" {
+		t.Errorf("expected the played topics to survive a restart, got %v", got)
+	}
+
+	restored.Reset()
+	if got := restored.EssayThemes(); len(got) != 0 {
+		t.Errorf("expected no themes after reset, got %v", got)
+	}
+	if got := restored.EssayTopics(); len(got) != 0 {
+		t.Errorf("expected no played topics after reset, got %v", got)
+	}
+}
+
+func TestManager_SuppressPOI(t *testing.T) {
+	m := NewManager(&mockSimClient{})
+	m.SuppressPOI("Q1")
+	if !m.IsSuppressed("Q1") || m.IsSuppressed("Q2") {
+		t.Fatal("expected only Q1 to be suppressed")
+	}
+
+	data, err := m.GetPersistentState(0, 0)
+	if err != nil {
+		t.Fatalf("GetPersistentState failed: %v", err)
+	}
+	restored := NewManager(&mockSimClient{})
+	if err := restored.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if !restored.IsSuppressed("Q1") {
+		t.Error("expected the suppression to survive a restart")
+	}
+
+	restored.ResetSession(context.Background())
+	if restored.IsSuppressed("Q1") {
+		t.Error("expected the suppression to clear on session reset")
+	}
+}
+
+func TestManager_SuppressPOIFor(t *testing.T) {
+	m := NewManager(&mockSimClient{})
+	m.SuppressPOIFor("Q1", time.Hour)
+	m.SuppressPOIFor("Q2", -time.Second)
+	if !m.IsSuppressed("Q1") || m.IsSuppressed("Q2") {
+		t.Fatal("expected only the unexpired suppression to apply")
+	}
+
+	data, err := m.GetPersistentState(0, 0)
+	if err != nil {
+		t.Fatalf("GetPersistentState failed: %v", err)
+	}
+	restored := NewManager(&mockSimClient{})
+	if err := restored.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if restored.IsSuppressed("Q1") {
+		t.Error("expected a timed suppression not to be persisted")
+	}
+}
+
+type stageSimClient struct {
+	mockSimClient
+	onGround bool
+	stage    string
+}
+
+func (m *stageSimClient) GetTelemetry(ctx context.Context) (sim.Telemetry, error) {
+	return sim.Telemetry{Latitude: m.lat, Longitude: m.lon, IsOnGround: m.onGround}, nil
+}
+
+func (m *stageSimClient) GetStageState() sim.StageState {
+	return sim.StageState{Current: m.stage}
+}
+
+func TestManager_Current(t *testing.T) {
+	// Parked before the flight: no session yet
+	parked := &stageSimClient{onGround: true, stage: sim.StageParked}
+	snap := NewManager(parked).Current(context.Background())
+	if snap.Active || snap.Message == "" || snap.StartedAt != nil || snap.Stage != sim.StageParked {
+		t.Errorf("expected an inactive session, got %+v", snap)
+	}
+
+	// A session persisted in flight and restored after a restart
+	start := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	m := NewManager(&mockSimClient{})
+	m.events = []model.TripEvent{
+		{Timestamp: start, Type: "transition", Title: "Takeoff", Lat: 48.0, Lon: 2.0},
+		{Timestamp: start.Add(10 * time.Minute), Type: "narration", Title: "Castle", Summary: "An old castle.", Lat: 48.1, Lon: 2.0},
+	}
+	m.narratedCount = 1
+	m.AddTrackPoint(48.0, 2.0)
+	m.AddTrackPoint(48.1, 2.0)
+	data, err := m.GetPersistentState(48.1, 2.0)
+	if err != nil {
+		t.Fatalf("GetPersistentState failed: %v", err)
+	}
+	restored := NewManager(&stageSimClient{stage: sim.StageCruise})
+	if err := restored.Restore(data); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	snap = restored.Current(context.Background())
+	if !snap.Active || !snap.Airborne || snap.Stage != sim.StageCruise || snap.NarratedCount != 1 {
+		t.Errorf("unexpected restored session: %+v", snap)
+	}
+	if snap.StartedAt == nil || !snap.StartedAt.Equal(start) {
+		t.Errorf("expected the session to start at %v, got %v", start, snap.StartedAt)
+	}
+	if snap.DistanceM < 11000 || snap.DistanceM > 11300 {
+		t.Errorf("expected ~11.1 km flown, got %.0f m", snap.DistanceM)
+	}
+	if len(snap.Summary) != 1 || snap.Summary[0].Title != "Castle" {
+		t.Errorf("expected the narration in the summary, got %+v", snap.Summary)
+	}
+}
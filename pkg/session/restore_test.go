@@ -49,6 +49,9 @@ func (m *MockStore) GetRecentlyPlayedPOIs(ctx context.Context, since time.Time)
 }
 func (m *MockStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error { return nil }
 func (m *MockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius float64) error { return nil }
+func (m *MockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	return nil, nil
+}
 
 // CacheStore
 func (m *MockStore) GetCache(ctx context.Context, key string) ([]byte, bool)    { return nil, false }
@@ -68,6 +71,36 @@ func (m *MockStore) SetGeodataCache(ctx context.Context, key string, val []byte,
 func (m *MockStore) GetGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]store.GeodataRecord, error) {
 	return nil, nil
 }
+func (m *MockStore) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	return 0, nil
+}
+func (m *MockStore) ListGeodata(ctx context.Context, q store.GeodataQuery) ([]store.GeodataRecord, int, error) {
+	return nil, 0, nil
+}
+
+func (m *MockStore) AddNarrationLog(ctx context.Context, e store.NarrationLogEntry, keep int) error {
+	return nil
+}
+
+func (m *MockStore) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	return nil, nil
+}
+
+func (m *MockStore) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	return nil
+}
+
+func (m *MockStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return nil, nil
+}
+
+func (m *MockStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	return nil
+}
+
+func (m *MockStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return nil, nil
+}
 func (m *MockStore) ListGeodataCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
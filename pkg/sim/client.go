@@ -34,6 +34,22 @@ type Client interface {
 	ExecuteCommand(ctx context.Context, cmd string, args map[string]any) error
 }
 
+// ConnectionStatus describes the lifecycle of a simulator connection.
+type ConnectionStatus struct {
+	Connected        bool
+	Reconnects       int       // Successful connections after the first one
+	LastConnected    time.Time // Zero until the first connection
+	LastDisconnected time.Time
+	NextAttempt      time.Time // Scheduled retry while disconnected
+	LastError        string    // Why the most recent attempt failed
+}
+
+// ConnectionReporter is implemented by clients that manage a reconnecting
+// connection to the simulator.
+type ConnectionReporter interface {
+	ConnectionStatus() ConnectionStatus
+}
+
 // EventRecorder defines an interface for logging system events (like flight stages).
 type EventRecorder interface {
 	RecordSystemEvent(title, eventType string, lat, lon float64, metadata map[string]string)
@@ -69,14 +85,40 @@ type Telemetry struct {
 	FlightStage string // Detailed stage (parked, taxi, climb, etc)
 	APStatus    string // G1000-style autopilot status (e.g. "HDG 270  AP  ALT 5000ft")
 
+	// Controls, reported only by clients that set HasControls. Stage
+	// detection falls back to speed heuristics without them.
+	ParkingBrake bool
+	Throttle     float64 // Throttle lever, 0..1
+	OnRunway     bool
+	HasControls  bool
+
 	// Transponder
 	Squawk int  // TRANSPONDER CODE
 	Ident  bool // TRANSPONDER IDENT
 
+	// Transmitting is true while the pilot holds push-to-talk. Only
+	// reported by clients that can detect it.
+	Transmitting bool
+
+	// SimTime is the sim's UTC clock, or zero when the client doesn't know it.
+	SimTime time.Time
+
+	// SimRate is the sim's time acceleration (4 at 4x), or zero when the
+	// client doesn't know it. Use Rate to read it.
+	SimRate float64
+
 	// Metadata
 	Provider string // "mock", "simconnect", etc.
 }
 
+// Rate returns the sim rate, assuming real time when it is unknown.
+func (t *Telemetry) Rate() float64 {
+	if t == nil || t.SimRate <= 0 {
+		return 1
+	}
+	return t.SimRate
+}
+
 // DetermineFlightStage calculates a basic flight phase.
 // Deprecated: Use StageMachine for stateful flight stage tracking.
 func DetermineFlightStage(t *Telemetry) string {
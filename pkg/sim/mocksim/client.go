@@ -34,6 +34,7 @@ type Config struct {
 	StartLon       float64
 	StartAlt       float64
 	StartHeading   *float64
+	SimRate        float64 // Time acceleration; 0 runs in real time
 }
 
 type ScenarioStep struct {
@@ -64,6 +65,7 @@ type MockClient struct {
 	isLanding        bool
 	landingStartTime time.Time
 	turnCount        int
+	simRate          float64 // Time acceleration, like the sim rate in MSFS
 
 	// Ground Track Calculation
 	trackBuf *geo.TrackBuffer
@@ -73,11 +75,23 @@ type MockClient struct {
 	stageMachine *sim.StageMachine
 
 	useCustomScenario bool
+
+	// Scripted route (optional). routeLeg indexes the waypoint the current
+	// leg starts from; the last waypoint starts the loop leg.
+	route    []Waypoint
+	routeLeg int
 }
 
 // NewClient creates a new mock simulator client.
 func NewClient(cfg Config) *MockClient {
-	m := &MockClient{
+	m := newClient(cfg)
+	m.start()
+	return m
+}
+
+// newClient builds a parked client without starting the physics loop.
+func newClient(cfg Config) *MockClient {
+	return &MockClient{
 		config:           cfg,
 		stopCh:           make(chan struct{}),
 		predictionWindow: 60 * time.Second,
@@ -102,11 +116,21 @@ func NewClient(cfg Config) *MockClient {
 		vsBuf:        sim.NewVerticalSpeedBuffer(5 * time.Second),
 		stageMachine: sim.NewStageMachine(),
 		lastUpdate:   time.Now(),
+		simRate:      normalizeRate(cfg.SimRate),
 	}
+}
+
+// normalizeRate treats unset or invalid rates as real time.
+func normalizeRate(rate float64) float64 {
+	if rate <= 0 {
+		return 1
+	}
+	return rate
+}
 
+func (m *MockClient) start() {
 	m.wg.Add(1)
 	go m.physicsLoop()
-	return m
 }
 
 // SetElevationProvider injects an elevation provider for accurate AGL calculations.
@@ -153,6 +177,14 @@ func (m *MockClient) SetPredictionWindow(d time.Duration) {
 	m.predictionWindow = d
 }
 
+// SetSimRate sets the time acceleration: the aircraft moves rate times as
+// fast and telemetry reports the rate, as MSFS does at 2x or 4x.
+func (m *MockClient) SetSimRate(rate float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.simRate = normalizeRate(rate)
+}
+
 // GetLastTransition returns the timestamp of the last transition to the given stage.
 func (m *MockClient) GetLastTransition(stage string) time.Time {
 	m.mu.Lock()
@@ -211,6 +243,13 @@ func (m *MockClient) ExecuteCommand(ctx context.Context, cmd string, args map[st
 			m.mu.Unlock()
 		}()
 		return nil
+	case "sim_rate":
+		rate, ok := args["rate"].(float64)
+		if !ok || rate <= 0 {
+			return fmt.Errorf("sim_rate needs a positive rate")
+		}
+		m.simRate = rate
+		return nil
 	default:
 		return fmt.Errorf("unknown command: %s", cmd)
 	}
@@ -259,7 +298,8 @@ func (m *MockClient) update() {
 	defer m.mu.Unlock()
 
 	now := time.Now()
-	dt := now.Sub(m.lastUpdate).Seconds()
+	// Physics runs on sim time, which time acceleration speeds up
+	dt := now.Sub(m.lastUpdate).Seconds() * m.simRate
 	m.lastUpdate = now
 
 	stateDuration := now.Sub(m.stateStart)
@@ -349,8 +389,9 @@ func (m *MockClient) moveGeodesic(dt float64) {
 }
 
 func (m *MockClient) updateDerivedState(now time.Time) {
-	// Update Prediction
-	distMetersPred := m.tel.GroundSpeed * 0.514444 * m.predictionWindow.Seconds()
+	// Update Prediction; the window is wall-clock time
+	m.tel.SimRate = m.simRate
+	distMetersPred := m.tel.GroundSpeed * 0.514444 * m.predictionWindow.Seconds() * m.simRate
 	if distMetersPred > 0 {
 		pred := geo.DestinationPoint(
 			geo.Point{Lat: m.tel.Latitude, Lon: m.tel.Longitude},
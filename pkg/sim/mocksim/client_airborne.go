@@ -9,6 +9,10 @@ import (
 
 func (m *MockClient) updateAirborne(dt float64, now time.Time) {
 	m.tel.IsOnGround = false
+	if len(m.route) > 0 && !m.isLanding {
+		m.updateRoute(dt, now)
+		return
+	}
 	// Wander logic - Every fourth turn is a drastic 45 degree turn
 	if now.Sub(m.lastTurnTime) > 60*time.Second {
 		m.turnCount++
@@ -144,6 +144,47 @@ func TestMock_ExecuteCommand(t *testing.T) {
 	if !m.isLanding {
 		t.Error("isLanding flag not set")
 	}
+
+	// 4. sim_rate
+	if err := m.ExecuteCommand(ctx, "sim_rate", map[string]any{"rate": 0.0}); err == nil {
+		t.Error("Expected error for a zero sim rate")
+	}
+	if err := m.ExecuteCommand(ctx, "sim_rate", map[string]any{"rate": 4.0}); err != nil {
+		t.Errorf("Unexpected error for sim_rate: %v", err)
+	}
+	if m.simRate != 4 {
+		t.Errorf("simRate = %v, want 4", m.simRate)
+	}
+}
+
+func TestMockPhysics_SimRate(t *testing.T) {
+	m := NewClient(Config{StartLat: 51.5, StartLon: -0.12})
+	defer m.Close()
+	m.SetSimRate(2)
+
+	m.mu.Lock()
+	m.state = StageAirborne
+	m.tel.Heading = 90.0
+	m.tel.GroundSpeed = 120.0
+	m.lastUpdate = time.Now().Add(-60 * time.Second)
+	m.mu.Unlock()
+
+	start := geo.Point{Lat: 51.5, Lon: -0.12}
+	m.update()
+	tel, _ := m.GetTelemetry(context.Background())
+
+	// At 2x, a wall-clock minute at 120 kts covers 4 NM, and so does the
+	// one-minute prediction window
+	if distNM := geo.Distance(start, geo.Point{Lat: tel.Latitude, Lon: tel.Longitude}) / 1852.0; distNM < 3.98 || distNM > 4.02 {
+		t.Errorf("expected ~4.0 NM travelled at 2x, got %.4f NM", distNM)
+	}
+	pos := geo.Point{Lat: tel.Latitude, Lon: tel.Longitude}
+	if predNM := geo.Distance(pos, geo.Point{Lat: tel.PredictedLatitude, Lon: tel.PredictedLongitude}) / 1852.0; predNM < 3.98 || predNM > 4.02 {
+		t.Errorf("expected the prediction ~4.0 NM ahead at 2x, got %.4f NM", predNM)
+	}
+	if tel.SimRate != 2 {
+		t.Errorf("SimRate = %v, want 2", tel.SimRate)
+	}
 }
 
 func TestMock_AirborneTurningAndLanding(t *testing.T) {
@@ -0,0 +1,159 @@
+package mocksim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"phileasgo/pkg/geo"
+)
+
+// minRouteSpeed keeps the aircraft moving on legs that slow down to a
+// stop, so the final waypoint is actually reached.
+const minRouteSpeed = 40.0
+
+// Waypoint is a point of a scripted route.
+type Waypoint struct {
+	Lat   float64 `yaml:"lat"`
+	Lon   float64 `yaml:"lon"`
+	Alt   float64 `yaml:"alt"`   // Feet MSL
+	Speed float64 `yaml:"speed"` // Knots ground speed
+}
+
+// NewClientFromRoute creates a mock client that flies the waypoints in order,
+// following the great circle between them. Altitude and speed change linearly
+// along each leg. If the last waypoint has zero speed the aircraft lands there
+// and, after the usual parked pause, flies the route again; otherwise it
+// flies a loop leg from the last waypoint straight back to the second one
+// (the first one for a two-point route) and carries on from there.
+//
+// With a startup config the flight begins parked at the first waypoint and
+// goes through the parked, taxi and hold phases of cfg; the Start* fields are
+// taken from the route. Without one it starts airborne at the first waypoint.
+func NewClientFromRoute(waypoints []Waypoint, startup *Config) (*MockClient, error) {
+	if len(waypoints) < 2 {
+		return nil, errors.New("route needs at least two waypoints")
+	}
+
+	first := waypoints[0]
+	heading := geo.Bearing(geo.Point{Lat: first.Lat, Lon: first.Lon}, geo.Point{Lat: waypoints[1].Lat, Lon: waypoints[1].Lon})
+	cfg := Config{}
+	if startup != nil {
+		cfg = *startup
+	}
+	cfg.StartLat = first.Lat
+	cfg.StartLon = first.Lon
+	cfg.StartAlt = first.Alt
+	cfg.StartHeading = &heading
+
+	m := newClient(cfg)
+	m.route = append([]Waypoint(nil), waypoints...)
+	if startup == nil {
+		// No known field elevation: assume sea level until an elevation
+		// provider says otherwise.
+		m.groundAlt = 0
+		m.state = StageAirborne
+		m.safeAltReached = true
+		m.tel.IsOnGround = false
+		m.tel.GroundSpeed = first.Speed
+	}
+	m.start()
+	return m, nil
+}
+
+// LoadRoute reads a YAML list of waypoints.
+func LoadRoute(path string) ([]Waypoint, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read route: %w", err)
+	}
+	var waypoints []Waypoint
+	if err := yaml.Unmarshal(data, &waypoints); err != nil {
+		return nil, fmt.Errorf("failed to parse route %s: %w", path, err)
+	}
+	return waypoints, nil
+}
+
+// updateRoute advances along the current leg. Heading is re-aimed at the
+// leg's end every tick, which traces the great circle.
+func (m *MockClient) updateRoute(dt float64, now time.Time) {
+	from, to := m.legEnds()
+	pos := geo.Point{Lat: m.tel.Latitude, Lon: m.tel.Longitude}
+	target := geo.Point{Lat: to.Lat, Lon: to.Lon}
+	legLen := geo.Distance(geo.Point{Lat: from.Lat, Lon: from.Lon}, target)
+
+	m.tel.GroundSpeed = lerp(from.Speed, to.Speed, legProgress(legLen, geo.Distance(pos, target)))
+	step := math.Max(m.tel.GroundSpeed, minRouteSpeed) * 0.514444 * dt
+	if step >= geo.Distance(pos, target) {
+		m.tel.Latitude = to.Lat
+		m.tel.Longitude = to.Lon
+		m.tel.AltitudeMSL = to.Alt
+		m.advanceLeg(now)
+		return
+	}
+
+	m.tel.Heading = geo.Bearing(pos, target)
+	next := geo.DestinationPoint(pos, step, m.tel.Heading)
+	m.tel.Latitude = next.Lat
+	m.tel.Longitude = next.Lon
+	m.tel.AltitudeMSL = lerp(from.Alt, to.Alt, legProgress(legLen, geo.Distance(next, target)))
+}
+
+// legProgress returns the completed fraction of a leg, clamped to [0, 1].
+func legProgress(legLen, remaining float64) float64 {
+	if legLen <= 0 {
+		return 1
+	}
+	return math.Max(0, math.Min(1, 1-remaining/legLen))
+}
+
+func lerp(a, b, f float64) float64 {
+	return a + (b-a)*f
+}
+
+// legEnds returns the waypoints of the current leg.
+func (m *MockClient) legEnds() (from, to Waypoint) {
+	last := len(m.route) - 1
+	if m.routeLeg < last {
+		return m.route[m.routeLeg], m.route[m.routeLeg+1]
+	}
+	return m.route[last], m.route[m.loopTarget()]
+}
+
+// loopTarget is the waypoint the loop leg returns to. The first waypoint is
+// usually the departure airfield, so the loop skips it when it can.
+func (m *MockClient) loopTarget() int {
+	if len(m.route) > 2 {
+		return 1
+	}
+	return 0
+}
+
+// advanceLeg moves on to the next leg, landing or looping at the end.
+func (m *MockClient) advanceLeg(now time.Time) {
+	last := len(m.route) - 1
+	if m.routeLeg == last {
+		// Loop leg flown: carry on from the waypoint it returned to
+		m.routeLeg = m.loopTarget()
+		return
+	}
+	m.routeLeg++
+	if m.routeLeg < last || m.route[last].Speed > 0 {
+		return
+	}
+
+	m.routeLeg = 0
+	if m.elevation == nil {
+		m.groundAlt = m.route[last].Alt
+	}
+	m.tel.GroundSpeed = 0
+	m.tel.AltitudeMSL = m.groundAlt
+	m.tel.IsOnGround = true
+	m.state = StageParked
+	m.stateStart = now
+	m.landingStartTime = now
+}
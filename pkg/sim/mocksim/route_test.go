@@ -0,0 +1,133 @@
+package mocksim
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/geo"
+)
+
+func TestNewClientFromRoute(t *testing.T) {
+	route := []Waypoint{
+		{Lat: 47.0, Lon: 8.0, Alt: 3000, Speed: 120},
+		{Lat: 47.0, Lon: 8.2, Alt: 5000, Speed: 120},
+		{Lat: 47.1, Lon: 8.1, Alt: 1400, Speed: 0},
+	}
+
+	// step runs one physics update covering d of simulated time.
+	step := func(m *MockClient, d time.Duration) {
+		m.mu.Lock()
+		m.lastUpdate = time.Now().Add(-d)
+		m.mu.Unlock()
+		m.update()
+	}
+
+	t.Run("Rejects a single waypoint", func(t *testing.T) {
+		if _, err := NewClientFromRoute(route[:1], nil); err == nil {
+			t.Error("expected an error for a one-point route")
+		}
+	})
+
+	t.Run("Flies the legs and lands at a zero-speed end", func(t *testing.T) {
+		m, err := NewClientFromRoute(route, nil)
+		if err != nil {
+			t.Fatalf("NewClientFromRoute failed: %v", err)
+		}
+		m.Close() // Drive the physics by hand
+
+		step(m, 30*time.Second)
+		tel, _ := m.GetTelemetry(context.Background())
+		if tel.IsOnGround {
+			t.Fatal("expected to start airborne")
+		}
+		if math.Abs(tel.Heading-90) > 1 {
+			t.Errorf("expected an easterly heading on the first leg, got %.1f", tel.Heading)
+		}
+		if tel.AltitudeMSL <= 3000 || tel.AltitudeMSL >= 5000 {
+			t.Errorf("expected altitude between the waypoints, got %.0f", tel.AltitudeMSL)
+		}
+		if tel.AltitudeAGL != tel.AltitudeMSL {
+			t.Errorf("expected AGL from a sea-level ground without elevation data, got %.0f", tel.AltitudeAGL)
+		}
+
+		for i := 0; i < 30 && m.state == StageAirborne; i++ {
+			step(m, time.Minute)
+		}
+		tel, _ = m.GetTelemetry(context.Background())
+		if m.state != StageParked || !tel.IsOnGround {
+			t.Fatalf("expected to be parked after the route, state=%s onGround=%v", m.state, tel.IsOnGround)
+		}
+		end := geo.Point{Lat: route[2].Lat, Lon: route[2].Lon}
+		if d := geo.Distance(geo.Point{Lat: tel.Latitude, Lon: tel.Longitude}, end); d > 1 {
+			t.Errorf("expected to land on the last waypoint, %.0fm away", d)
+		}
+	})
+
+	t.Run("Startup prefix begins parked", func(t *testing.T) {
+		m, err := NewClientFromRoute(route, &Config{DurationParked: time.Hour})
+		if err != nil {
+			t.Fatalf("NewClientFromRoute failed: %v", err)
+		}
+		m.Close()
+
+		step(m, time.Second)
+		tel, _ := m.GetTelemetry(context.Background())
+		if m.state != StageParked || !tel.IsOnGround {
+			t.Errorf("expected to be parked, state=%s onGround=%v", m.state, tel.IsOnGround)
+		}
+		if tel.Latitude != route[0].Lat || tel.Longitude != route[0].Lon {
+			t.Errorf("expected to start at the first waypoint, got %.4f,%.4f", tel.Latitude, tel.Longitude)
+		}
+	})
+	t.Run("Loops from the last waypoint back to the second", func(t *testing.T) {
+		loop := []Waypoint{
+			{Lat: 47.0, Lon: 8.0, Alt: 3000, Speed: 120},
+			{Lat: 47.0, Lon: 8.2, Alt: 5000, Speed: 120},
+			{Lat: 47.1, Lon: 8.1, Alt: 4000, Speed: 120},
+		}
+		m, err := NewClientFromRoute(loop, nil)
+		if err != nil {
+			t.Fatalf("NewClientFromRoute failed: %v", err)
+		}
+		m.Close()
+
+		// 10s at 120 kts is about 620m per step
+		const maxStep = 700.0
+		pos := func() geo.Point {
+			tel, _ := m.GetTelemetry(context.Background())
+			return geo.Point{Lat: tel.Latitude, Lon: tel.Longitude}
+		}
+		for i := 0; i < 500 && m.routeLeg != len(loop)-1; i++ {
+			step(m, 10*time.Second)
+		}
+		if m.routeLeg != len(loop)-1 {
+			t.Fatal("never reached the last waypoint")
+		}
+
+		prev := pos()
+		for i := 0; i < 500 && m.routeLeg != 1; i++ {
+			step(m, 10*time.Second)
+			tel, _ := m.GetTelemetry(context.Background())
+			if tel.IsOnGround {
+				t.Fatal("expected a looping route to stay airborne")
+			}
+			if tel.AltitudeMSL < 4000 || tel.AltitudeMSL > 5000 {
+				t.Errorf("altitude %.0f left the loop leg's range", tel.AltitudeMSL)
+			}
+			cur := pos()
+			if d := geo.Distance(prev, cur); d > maxStep {
+				t.Fatalf("position jumped %.0fm in one step", d)
+			}
+			prev = cur
+		}
+		if m.routeLeg != 1 {
+			t.Fatalf("expected to carry on from the second waypoint, on leg %d", m.routeLeg)
+		}
+		second := geo.Point{Lat: loop[1].Lat, Lon: loop[1].Lon}
+		if d := geo.Distance(pos(), second); d > 1 {
+			t.Errorf("expected the loop leg to end on the second waypoint, %.0fm away", d)
+		}
+	})
+}
@@ -46,14 +46,26 @@ var InFlightStates = map[int]bool{
 const (
 	DefIDTelemetry = 0
 	DefIDObjectPos = 1 // New definition for setting object data
+	DefIDComms     = 2
 	ReqIDTelemetry = 0
+	ReqIDComms     = 1
 	EvtIDSimStop   = 0 // Client-side ID for SimStop
+	// FlightLoaded moves the aircraft without a take-off or landing
+	EvtIDFlightLoaded = 1
 )
 
 // Client implements sim.Client for Microsoft Flight Simulator via SimConnect.
 type Client struct {
-	handle           uintptr
-	connected        bool
+	// Connection lifecycle, guarded by connMu. connGen increments on every
+	// successful Open so a dispatch loop left over from an earlier session
+	// cannot tear down its successor.
+	connMu     sync.Mutex
+	handle     uintptr
+	connected  bool
+	connGen    uint64
+	connStatus sim.ConnectionStatus
+	lost       chan struct{} // Signalled when a live connection drops
+
 	stopChan         chan struct{}
 	telemetry        sim.Telemetry
 	cameraState      int32
@@ -82,6 +94,9 @@ type Client struct {
 	// Telemetry Validity
 	hasValidData bool
 
+	// Push-to-talk state, from its own on-change request
+	transmitting bool
+
 	// New configuration fields
 	simProcess string
 }
@@ -105,6 +120,7 @@ func NewClient(appName, dllPath, simProcess string, reconnectInterval time.Durat
 		connected:        false,
 		simState:         sim.StateDisconnected,
 		stopChan:         make(chan struct{}),
+		lost:             make(chan struct{}, 1),
 		logger:           slog.Default().With("component", "simconnect"),
 		appName:          appName,
 		dllPath:          dllPath,
@@ -167,10 +183,35 @@ func (c *Client) GetLastTransition(stage string) time.Time {
 // Close disconnects and cleans up.
 func (c *Client) Close() error {
 	close(c.stopChan)
-	if c.handle != 0 {
-		return Close(c.handle)
+
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	if c.handle == 0 {
+		return nil
 	}
-	return nil
+	err := Close(c.handle)
+	c.handle = 0
+	c.connected = false
+	return err
+}
+
+// ConnectionStatus reports the SimConnect connection lifecycle.
+func (c *Client) ConnectionStatus() sim.ConnectionStatus {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.connStatus
+}
+
+// activeHandle returns the handle of the live connection, if any.
+func (c *Client) activeHandle() (uintptr, uint64, bool) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	return c.handle, c.connGen, c.connected && c.handle != 0
+}
+
+func (c *Client) isConnected() bool {
+	_, _, ok := c.activeHandle()
+	return ok
 }
 
 // SpawnAirTraffic spawns a non-ATC aircraft (AI object) and returns its ObjectID.
@@ -187,7 +228,8 @@ func (c *Client) SpawnAirTraffic(reqID uint32, title, livery, tailNum string, la
 	}
 
 	// Ensure connected
-	if !c.connected {
+	handle, _, ok := c.activeHandle()
+	if !ok {
 		return 0, sim.ErrNotConnected
 	}
 
@@ -206,7 +248,7 @@ func (c *Client) SpawnAirTraffic(reqID uint32, title, livery, tailNum string, la
 	}()
 
 	// MSFS 2024: Use EX1 variant to support liveries
-	if err := AICreateNonATCAircraftEX1(c.handle, title, livery, tailNum, &initPos, reqID); err != nil {
+	if err := AICreateNonATCAircraftEX1(handle, title, livery, tailNum, &initPos, reqID); err != nil {
 		return 0, err
 	}
 
@@ -221,15 +263,17 @@ func (c *Client) SpawnAirTraffic(reqID uint32, title, livery, tailNum string, la
 
 // RemoveObject removes a sim object by its ID.
 func (c *Client) RemoveObject(objectID, reqID uint32) error {
-	if !c.connected {
+	handle, _, ok := c.activeHandle()
+	if !ok {
 		return sim.ErrNotConnected
 	}
-	return AIRemoveObject(c.handle, objectID, reqID)
+	return AIRemoveObject(handle, objectID, reqID)
 }
 
 // SetObjectPosition updates the position of a sim object.
 func (c *Client) SetObjectPosition(objectID uint32, lat, lon, alt, pitch, bank, hdg float64) error {
-	if !c.connected {
+	handle, _, ok := c.activeHandle()
+	if !ok {
 		return sim.ErrNotConnected
 	}
 
@@ -242,86 +286,171 @@ func (c *Client) SetObjectPosition(objectID uint32, lat, lon, alt, pitch, bank,
 	}
 
 	// We assume DefIDObjectPos exists (added in setupDataDefinitions)
-	return SetDataOnSimObject(c.handle, DefIDObjectPos, objectID, 0, 0,
+	return SetDataOnSimObject(handle, DefIDObjectPos, objectID, 0, 0,
 		uint32(unsafe.Sizeof(data)), unsafe.Pointer(&data))
 }
 
-func (c *Client) connectionLoop() {
-	ticker := time.NewTicker(c.reconnectInt)
-	defer ticker.Stop()
+// minReconnectBackoff is the first retry delay after a lost connection or a
+// failed attempt. MSFS usually needs far longer to come back, so the delay
+// doubles up to the configured reconnect interval.
+const minReconnectBackoff = 2 * time.Second
 
-	// Initial attempt
-	if IsSimulatorRunning(c.simProcess) {
-		c.connect()
+// nextBackoff returns the delay before the next connection attempt.
+func nextBackoff(cur, limit time.Duration) time.Duration {
+	if cur <= 0 {
+		return min(minReconnectBackoff, limit)
 	}
+	return min(cur*2, limit)
+}
 
+// connectionLoop owns the connection lifecycle: it connects when the
+// simulator is running, waits for the connection to drop, then retries with
+// backoff until the simulator is back.
+func (c *Client) connectionLoop() {
+	var backoff time.Duration
 	for {
+		if c.isConnected() {
+			select {
+			case <-c.stopChan:
+				return
+			case <-c.lost:
+				if c.isConnected() {
+					continue // Stale signal from a failed setup
+				}
+			}
+			backoff = 0
+		} else if c.tryConnect() {
+			backoff = 0
+			continue
+		}
+
+		backoff = nextBackoff(backoff, c.reconnectInt)
+		c.connMu.Lock()
+		c.connStatus.NextAttempt = time.Now().Add(backoff)
+		c.connMu.Unlock()
+
+		timer := time.NewTimer(backoff)
 		select {
 		case <-c.stopChan:
+			timer.Stop()
 			return
-		case <-ticker.C:
-			if !c.connected && IsSimulatorRunning(c.simProcess) {
-				c.connect()
-			}
+		case <-timer.C:
 		}
 	}
 }
 
-func (c *Client) connect() {
+// tryConnect makes one connection attempt and records its outcome.
+func (c *Client) tryConnect() bool {
+	if !IsSimulatorRunning(c.simProcess) {
+		c.recordAttemptError(fmt.Errorf("simulator process %q not running", c.simProcess))
+		return false
+	}
+	if err := c.connect(); err != nil {
+		c.logger.Debug("Connection failed", "error", err)
+		c.recordAttemptError(err)
+		return false
+	}
+	return true
+}
+
+func (c *Client) recordAttemptError(err error) {
+	c.connMu.Lock()
+	defer c.connMu.Unlock()
+	c.connStatus.LastError = err.Error()
+}
+
+func (c *Client) connect() error {
 	c.logger.Debug("Attempting SimConnect connection...")
 
 	handle, err := Open(c.appName)
 	if err != nil {
-		c.logger.Debug("Connection failed", "error", err)
-		return
+		return err
 	}
 
+	c.connMu.Lock()
 	c.handle = handle
 	c.connected = true
+	c.connGen++
+	gen := c.connGen
+	c.connMu.Unlock()
 
+	// Derived values from the previous session would otherwise leak into the
+	// first samples after a sim restart.
 	c.telemetryMu.Lock()
 	c.simState = sim.StateInactive
 	c.hasValidData = false
+	c.trackBuf.Reset()
+	c.vsBuf.Reset()
 	c.telemetryMu.Unlock()
 
 	c.lastMessageTime = time.Time{} // Initialize watchdog (waits for first message)
-	c.logger.Info("SimConnect Connected")
 
-	// Setup data definitions
+	// Data definitions belong to the connection, so they are re-registered
+	// on every reconnect.
 	if err := c.setupDataDefinitions(); err != nil {
-		c.logger.Error("Failed to setup data definitions", "error", err)
-		c.disconnect()
-		return
+		c.disconnect(gen)
+		return fmt.Errorf("failed to setup data definitions: %w", err)
+	}
+
+	c.connMu.Lock()
+	now := time.Now()
+	if !c.connStatus.LastConnected.IsZero() {
+		c.connStatus.Reconnects++
 	}
+	c.connStatus.Connected = true
+	c.connStatus.LastConnected = now
+	c.connStatus.NextAttempt = time.Time{}
+	c.connStatus.LastError = ""
+	c.connMu.Unlock()
+	c.logger.Info("SimConnect Connected", "session", gen)
 
 	// Start dispatch loop
-	go c.dispatchLoop()
+	go c.dispatchLoop(handle, gen)
 
 	// Subscribe to SimStop to detect quit reliably
-	if err := SubscribeToSystemEvent(c.handle, EvtIDSimStop, "SimStop"); err != nil {
+	if err := SubscribeToSystemEvent(handle, EvtIDSimStop, "SimStop"); err != nil {
 		c.logger.Error("Failed to subscribe to SimStop", "error", err)
 	}
+	if err := SubscribeToSystemEvent(handle, EvtIDFlightLoaded, "FlightLoaded"); err != nil {
+		c.logger.Error("Failed to subscribe to FlightLoaded", "error", err)
+	}
+	return nil
 }
 
-func (c *Client) disconnect() {
-	if !c.connected {
+// disconnect closes the connection identified by gen and wakes the
+// connection loop so it can start retrying.
+func (c *Client) disconnect(gen uint64) {
+	c.connMu.Lock()
+	if !c.connected || c.connGen != gen {
+		c.connMu.Unlock()
 		return
 	}
+	if c.handle != 0 {
+		_ = Close(c.handle)
+		c.handle = 0
+	}
+	c.connected = false
+	c.connStatus.Connected = false
+	c.connStatus.LastDisconnected = time.Now()
+	c.connMu.Unlock()
 
 	c.telemetryMu.Lock()
 	c.simState = sim.StateDisconnected
 	c.hasValidData = false
 	c.telemetryMu.Unlock()
 
-	if c.handle != 0 {
-		_ = Close(c.handle)
-		c.handle = 0
+	select {
+	case c.lost <- struct{}{}:
+	default:
 	}
-	c.connected = false
 	c.logger.Info("SimConnect Disconnected")
 }
 
 func (c *Client) setupDataDefinitions() error {
+	c.connMu.Lock()
+	handle := c.handle
+	c.connMu.Unlock()
+
 	// 1. Telemetry Data
 	defs := []struct {
 		name     string
@@ -367,10 +496,21 @@ func (c *Client) setupDataDefinitions() error {
 		// For display: HDG bug and DTK
 		{"AUTOPILOT HEADING LOCK DIR", "Degrees", DATATYPE_FLOAT64},
 		{"GPS WP DESIRED TRACK", "Degrees", DATATYPE_FLOAT64},
+		// Sim clock for day/night
+		{"ZULU TIME", "Seconds", DATATYPE_FLOAT64},
+		{"ZULU DAY OF YEAR", "Number", DATATYPE_FLOAT64},
+		{"ZULU YEAR", "Number", DATATYPE_FLOAT64},
+		// Controls for flight stage detection
+		{"BRAKE PARKING POSITION", "Bool", DATATYPE_FLOAT64},
+		{"GENERAL ENG THROTTLE LEVER POSITION:1", "Percent", DATATYPE_FLOAT64},
+		// Time acceleration, which speeds up the world but not the narration
+		{"SIMULATION RATE", "Number", DATATYPE_FLOAT64},
+		// Tells holding on the runway apart from parking
+		{"ON ANY RUNWAY", "Bool", DATATYPE_FLOAT64},
 	}
 
 	for _, d := range defs {
-		if err := AddToDataDefinition(c.handle, DefIDTelemetry, d.name, d.unit, d.dataType); err != nil {
+		if err := AddToDataDefinition(handle, DefIDTelemetry, d.name, d.unit, d.dataType); err != nil {
 			return err
 		}
 	}
@@ -393,28 +533,45 @@ func (c *Client) setupDataDefinitions() error {
 	}
 	for _, d := range objDefs {
 		// Note: Using same float64 for all
-		if err := AddToDataDefinition(c.handle, DefIDObjectPos, d.name, d.unit, d.dataType); err != nil {
+		if err := AddToDataDefinition(handle, DefIDObjectPos, d.name, d.unit, d.dataType); err != nil {
 			return err
 		}
 	}
 
 	// Request data at 1Hz (PERIOD_SECOND)
-	return RequestDataOnSimObject(c.handle, ReqIDTelemetry, DefIDTelemetry, OBJECT_ID_USER, PERIOD_SECOND, 0, 0, 0, 0)
+	if err := RequestDataOnSimObject(handle, ReqIDTelemetry, DefIDTelemetry, OBJECT_ID_USER, PERIOD_SECOND, 0, 0, 0, 0); err != nil {
+		return err
+	}
+
+	c.setupCommsDefinition(handle)
+	return nil
+}
+
+// setupCommsDefinition requests the push-to-talk state. Not every sim or
+// aircraft exposes it, so failure only disables comms detection.
+func (c *Client) setupCommsDefinition(handle uintptr) {
+	if err := AddToDataDefinition(handle, DefIDComms, "PILOT TRANSMITTING", "Bool", DATATYPE_INT32); err != nil {
+		c.logger.Warn("Comms detection unavailable", "error", err)
+		return
+	}
+	if err := RequestDataOnSimObject(handle, ReqIDComms, DefIDComms, OBJECT_ID_USER, PERIOD_VISUAL_FRAME, DATA_REQUEST_FLAG_CHANGED, 0, 0, 0); err != nil {
+		c.logger.Warn("Comms detection unavailable", "error", err)
+	}
 }
 
-func (c *Client) dispatchLoop() {
+func (c *Client) dispatchLoop(handle uintptr, gen uint64) {
 	for {
 		select {
 		case <-c.stopChan:
 			return
 		default:
-			if !c.connected || c.handle == 0 {
+			if _, cur, ok := c.activeHandle(); !ok || cur != gen {
 				return
 			}
-			ppData, _, err := GetNextDispatch(c.handle)
+			ppData, _, err := GetNextDispatch(handle)
 			if err != nil {
 				c.logger.Error("GetNextDispatch error", "error", err)
-				c.disconnect()
+				c.disconnect(gen)
 				return
 			}
 
@@ -423,7 +580,7 @@ func (c *Client) dispatchLoop() {
 				// Only enforce timeout if we have received at least one message (lastMessageTime is set)
 				if !c.lastMessageTime.IsZero() && time.Since(c.lastMessageTime) > 5*time.Second {
 					c.logger.Warn("Watchdog timeout (no data for 5s), resetting connection")
-					c.disconnect()
+					c.disconnect(gen)
 					return
 				}
 				// No message, sleep briefly to prevent busy loop
@@ -455,6 +612,12 @@ func (c *Client) handleMessage(ppData unsafe.Pointer) {
 			c.handleQuit("Event")
 		}
 
+	case RECV_ID_EVENT_FILENAME:
+		evt := (*RecvEventFilename)(ppData)
+		if evt.UEventID == EvtIDFlightLoaded {
+			c.handleFlightLoaded(cStringToGo(evt.FileName[:]))
+		}
+
 	case RECV_ID_EXCEPTION:
 		recvEx := (*RecvException)(ppData)
 		c.logger.Warn("SimConnect Exception", "exception", recvEx.Exception, "sendID", recvEx.SendID)
@@ -476,7 +639,21 @@ func (c *Client) handleOpen(ppData unsafe.Pointer) {
 
 func (c *Client) handleQuit(source string) {
 	c.logger.Info("Simulator Quit detected", "source", source)
-	c.disconnect()
+	// Messages are only handled by the dispatch loop of the live session.
+	_, gen, _ := c.activeHandle()
+	c.disconnect(gen)
+}
+
+// handleFlightLoaded restarts stage detection: a loaded flight puts the
+// aircraft somewhere new, which the stage machine would otherwise take for a
+// take-off or landing.
+func (c *Client) handleFlightLoaded(file string) {
+	c.logger.Info("Flight loaded", "file", file)
+	c.telemetryMu.Lock()
+	defer c.telemetryMu.Unlock()
+	if c.stageMachine != nil {
+		c.stageMachine.Reset()
+	}
 }
 
 func (c *Client) handleAssignedObject(ppData unsafe.Pointer) {
@@ -494,6 +671,10 @@ func (c *Client) handleAssignedObject(ppData unsafe.Pointer) {
 
 func (c *Client) handleSimObjectData(ppData unsafe.Pointer) {
 	recvData := (*RecvSimobjectData)(ppData)
+	if recvData.RequestID == ReqIDComms {
+		c.handleCommsData(ppData)
+		return
+	}
 	if recvData.RequestID == ReqIDTelemetry {
 		// Data follows immediately after the header
 		dataPtr := unsafe.Pointer(uintptr(ppData) + unsafe.Sizeof(RecvSimobjectData{}))
@@ -527,7 +708,13 @@ func (c *Client) handleSimObjectData(ppData unsafe.Pointer) {
 			// Speed in Knots -> Meters/Second
 			// 1 Knot = 0.514444 m/s
 			// Distance = Speed * WindowDuration
-			distMeters := data.GroundSpeed * 0.514444 * c.predictionWindow.Seconds()
+			// The window is wall-clock time; under time acceleration the
+			// aircraft covers SimRate times the distance in it.
+			rate := data.SimRate
+			if rate <= 0 {
+				rate = 1
+			}
+			distMeters := data.GroundSpeed * 0.514444 * c.predictionWindow.Seconds() * rate
 
 			var predLat, predLon float64
 			if distMeters > 0 {
@@ -568,9 +755,16 @@ func (c *Client) handleSimObjectData(ppData unsafe.Pointer) {
 				PredictedLongitude: predLon,
 				IsOnGround:         isOnGround,
 				EngineOn:           data.Engine > 0 || data.Engine2 > 0,
+				ParkingBrake:       data.ParkingBrake != 0,
+				Throttle:           data.Throttle / 100.0,
+				OnRunway:           data.OnRunway != 0,
+				HasControls:        true,
 				APStatus:           formatAPStatus(data),
 				Squawk:             int(data.Squawk),
 				Ident:              data.Ident != 0,
+				Transmitting:       c.transmitting,
+				SimTime:            zuluTime(data),
+				SimRate:            data.SimRate,
 				Provider:           "simconnect",
 				HasValidData:       true, // Only set telemetry when valid
 			}
@@ -583,6 +777,31 @@ func (c *Client) handleSimObjectData(ppData unsafe.Pointer) {
 	}
 }
 
+// zuluTime converts the sim's UTC clock to a time, or the zero time when the
+// sim has not reported one.
+func zuluTime(data *TelemetryData) time.Time {
+	if data.ZuluYear < 1 || data.ZuluDay < 1 {
+		return time.Time{}
+	}
+	return time.Date(int(data.ZuluYear), time.January, 1, 0, 0, 0, 0, time.UTC).
+		AddDate(0, 0, int(data.ZuluDay)-1).
+		Add(time.Duration(data.ZuluTime * float64(time.Second)))
+}
+
+func (c *Client) handleCommsData(ppData unsafe.Pointer) {
+	data := (*CommsData)(unsafe.Pointer(uintptr(ppData) + unsafe.Sizeof(RecvSimobjectData{})))
+
+	c.telemetryMu.Lock()
+	defer c.telemetryMu.Unlock()
+
+	transmitting := data.Transmitting != 0
+	if transmitting != c.transmitting {
+		c.logger.Debug("Pilot transmitting changed", "transmitting", transmitting)
+	}
+	c.transmitting = transmitting
+	c.telemetry.Transmitting = transmitting
+}
+
 // validateTelemetry checks for spurious data patterns common in SimConnect.
 // Returns true if telemetry is valid, false if it should be discarded.
 func (c *Client) validateTelemetry(data *TelemetryData) bool {
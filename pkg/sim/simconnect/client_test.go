@@ -164,9 +164,60 @@ func TestGetTelemetry_Validity(t *testing.T) {
 	}
 
 	// 3. Simulate Disconnect (Reset)
-	c.disconnect()
+	c.disconnect(0)
 	_, err = c.GetTelemetry(context.Background())
 	if err != sim.ErrWaitingForTelemetry {
 		t.Errorf("GetTelemetry disconnected: want ErrWaitingForTelemetry, got %v", err)
 	}
 }
+
+func TestNextBackoff(t *testing.T) {
+	tests := []struct {
+		name  string
+		cur   time.Duration
+		limit time.Duration
+		want  time.Duration
+	}{
+		{"First retry", 0, 30 * time.Second, minReconnectBackoff},
+		{"Doubles", 4 * time.Second, 30 * time.Second, 8 * time.Second},
+		{"Capped at limit", 16 * time.Second, 30 * time.Second, 30 * time.Second},
+		{"Limit below minimum", 0, time.Second, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextBackoff(tt.cur, tt.limit); got != tt.want {
+				t.Errorf("nextBackoff(%v, %v) = %v, want %v", tt.cur, tt.limit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisconnect_IgnoresStaleSession(t *testing.T) {
+	c := &Client{
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+		connected: true,
+		connGen:   2,
+		lost:      make(chan struct{}, 1),
+		simState:  sim.StateActive,
+	}
+
+	// A dispatch loop from the previous session must not drop the new one.
+	c.disconnect(1)
+	if !c.connected || c.GetState() != sim.StateActive {
+		t.Fatal("stale session tore down the live connection")
+	}
+
+	c.disconnect(2)
+	if c.connected || c.GetState() != sim.StateDisconnected {
+		t.Fatal("expected disconnected state")
+	}
+	status := c.ConnectionStatus()
+	if status.Connected || status.LastDisconnected.IsZero() {
+		t.Errorf("unexpected status: %+v", status)
+	}
+	select {
+	case <-c.lost:
+	default:
+		t.Error("connection loop was not woken")
+	}
+}
@@ -54,6 +54,11 @@ const (
 	PERIOD_SECOND       uint32 = 4
 )
 
+// Data request flags
+const (
+	DATA_REQUEST_FLAG_CHANGED uint32 = 1 // Only send data when it changes
+)
+
 // Object types
 const (
 	SIMOBJECT_TYPE_USER            uint32 = 0
@@ -72,6 +77,8 @@ const (
 	RECV_ID_OPEN                              uint32 = 2
 	RECV_ID_QUIT                              uint32 = 3
 	RECV_ID_EVENT                             uint32 = 4
+	RECV_ID_EVENT_OBJECT_ADDREMOVE            uint32 = 5
+	RECV_ID_EVENT_FILENAME                    uint32 = 6
 	RECV_ID_SIMOBJECT_DATA                    uint32 = 8
 	RECV_ID_SIMOBJECT_DATA_BYTYPE             uint32 = 9
 	RECV_ID_ASSIGNED_OBJECT_ID                uint32 = 12
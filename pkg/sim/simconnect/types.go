@@ -31,13 +31,23 @@ type RecvException struct {
 	Index     uint32
 }
 
-// RecvEvent is received when a subscribed system event occurs.
+// RecvEvent is received when a subscribed system event occurs. It mirrors
+// SIMCONNECT_RECV_EVENT, where the group ID precedes the event ID.
 type RecvEvent struct {
 	Recv
+	UGroupID uint32
 	UEventID uint32
 	Data     uint32
 }
 
+// RecvEventFilename is received for system events that name a file, such as
+// FlightLoaded.
+type RecvEventFilename struct {
+	RecvEvent
+	FileName [260]byte
+	Flags    uint32
+}
+
 // RecvSimobjectData is received with requested sim object data.
 type RecvSimobjectData struct {
 	Recv
@@ -116,6 +126,16 @@ type TelemetryData struct {
 	ALTVar        float64 // AUTOPILOT ALTITUDE LOCK VAR (ft)
 	HDGBug        float64 // AUTOPILOT HEADING LOCK DIR (degrees)
 	DTK           float64 // GPS WP DESIRED TRACK (degrees)
+
+	// Sim clock, which the user may have set far from the real time
+	ZuluTime float64 // ZULU TIME (seconds since midnight)
+	ZuluDay  float64 // ZULU DAY OF YEAR
+	ZuluYear float64 // ZULU YEAR
+
+	ParkingBrake float64 // BRAKE PARKING POSITION
+	Throttle     float64 // GENERAL ENG THROTTLE LEVER POSITION:1 (percent)
+	SimRate      float64 // SIMULATION RATE
+	OnRunway     float64 // ON ANY RUNWAY
 }
 
 // MarkerUpdateData is the struct for updating marker positions.
@@ -131,6 +151,12 @@ type MarkerUpdateData struct {
 	Airspeed    int32
 }
 
+// CommsData matches the DefIDComms definition. It is requested separately
+// from the telemetry, on change, so a push-to-talk is seen within a frame.
+type CommsData struct {
+	Transmitting int32
+}
+
 // RecvEnumerateSimObjectsAndLiveries is received after calling EnumerateSimObjectsAndLiveries.
 type RecvEnumerateSimObjectsAndLiveries struct {
 	Recv
@@ -17,6 +17,9 @@ const (
 	StageCruise   = "cruise"
 	StageDescend  = "descend"
 	StageLanded   = "landed"
+
+	// StageTakeOffRoll is the ground run before lift-off.
+	StageTakeOffRoll = "takeoff_roll"
 )
 
 // StageMachine tracks the flight phase state across telemetry ticks.
@@ -43,6 +46,17 @@ func (m *StageMachine) SetRecorder(r EventRecorder) {
 	m.recorder = r
 }
 
+// Reset forgets the current stage so the next tick starts afresh, as after
+// the sim loads a flight. Transition history is kept for the session.
+func (m *StageMachine) Reset() {
+	m.current = ""
+	m.lastGroundSpeed = 0
+	m.isAccelerating = false
+	m.isDecelerating = false
+	m.transitionStart = time.Time{}
+	m.lockedUntil = time.Time{}
+}
+
 // StageState represents the persistent state of the machine.
 type StageState struct {
 	Current        string               `json:"current"`
@@ -249,6 +263,20 @@ func (m *StageMachine) updateGroundState(t *Telemetry, current string) string {
 	if !t.EngineOn && t.GroundSpeed < 1 {
 		return StageParked
 	}
+	// A set parking brake while stopped off the runway tells the gate apart
+	// from waiting with engines running. Lined up on the runway, pilots set
+	// it too: that is a hold, not a stand.
+	if t.HasControls && t.ParkingBrake && t.GroundSpeed < 1 && !t.OnRunway {
+		return StageParked
+	}
+
+	if m.isTakeOffRoll(t, current) {
+		return StageTakeOffRoll
+	}
+	// Rollout: still fast after touchdown is not taxiing yet
+	if current == StageLanded && t.GroundSpeed >= takeOffRollSpeed {
+		return StageLanded
+	}
 
 	// Engine On
 	if t.EngineOn {
@@ -266,11 +294,37 @@ func (m *StageMachine) updateGroundState(t *Telemetry, current string) string {
 	switch current {
 	case StageParked, StageTaxi, StageHold, StageLanded, StageOnGround:
 		return current
+	case StageTakeOffRoll:
+		// Rejected take-off
+		return StageTaxi
 	}
 
 	return StageOnGround
 }
 
+const (
+	takeOffRollSpeed    = 40.0 // Knots; faster than any taxi
+	takeOffRollThrottle = 0.7  // Throttle lever fraction that means take-off power
+	takeOffRollMinSpeed = 15.0 // Knots; below this, power may be a breakaway thrust
+)
+
+// isTakeOffRoll combines the throttle, when the client reports it, with the
+// ground speed trend: take-off power while rolling is a take-off run, and so
+// is accelerating through taxi speeds when the throttle is unknown.
+func (m *StageMachine) isTakeOffRoll(t *Telemetry, current string) bool {
+	if !t.EngineOn {
+		return false
+	}
+	if t.HasControls {
+		return t.Throttle >= takeOffRollThrottle && t.GroundSpeed >= takeOffRollMinSpeed
+	}
+	if t.GroundSpeed < takeOffRollSpeed {
+		return false
+	}
+	// Keep the roll through ticks where the speed briefly stops rising
+	return m.isAccelerating || (current == StageTakeOffRoll && !m.isDecelerating)
+}
+
 func (m *StageMachine) updateAirborneState(t *Telemetry, current string) string {
 	// Simple performance-based states
 	if t.VerticalSpeed > 300 {
@@ -162,6 +162,141 @@ func TestStageMachine_Robustness(t *testing.T) {
 	}
 }
 
+func TestStageMachine_GroundClassifier(t *testing.T) {
+	slog.SetLogLoggerLevel(slog.LevelError)
+
+	type step struct {
+		tel  Telemetry
+		want string
+	}
+	ctrl := func(gs, throttle float64, brake bool) Telemetry {
+		return Telemetry{IsOnGround: true, EngineOn: true, GroundSpeed: gs, Throttle: throttle, ParkingBrake: brake, HasControls: true}
+	}
+	speed := func(gs float64) Telemetry {
+		return Telemetry{IsOnGround: true, EngineOn: true, GroundSpeed: gs}
+	}
+	onRunway := func(t Telemetry) Telemetry {
+		t.OnRunway = true
+		return t
+	}
+
+	tests := []struct {
+		name  string
+		start string
+		steps []step
+	}{
+		{
+			name: "Throttle up on the runway is a take-off roll",
+			steps: []step{
+				{ctrl(12, 0.2, false), StageTaxi},
+				{ctrl(0, 0.1, false), StageHold},
+				{ctrl(18, 0.9, false), StageTakeOffRoll},
+				{ctrl(60, 0.9, false), StageTakeOffRoll},
+			},
+		},
+		{
+			name: "Fast taxi at low throttle stays taxi",
+			steps: []step{
+				{ctrl(25, 0.3, false), StageTaxi},
+				{ctrl(28, 0.3, false), StageTaxi},
+			},
+		},
+		{
+			name: "Breakaway thrust is not a take-off",
+			steps: []step{
+				{ctrl(0, 0.8, false), StageHold},
+			},
+		},
+		{
+			name: "Parking brake at the gate with engines running",
+			steps: []step{
+				{ctrl(0, 0, true), StageParked},
+				{ctrl(0, 0, false), StageHold},
+			},
+		},
+		{
+			name: "Parking brake while lined up on the runway is a hold",
+			steps: []step{
+				{onRunway(ctrl(0, 0, true)), StageHold},
+				{onRunway(ctrl(18, 0.9, false)), StageTakeOffRoll},
+			},
+		},
+		{
+			name: "Parking brake set while still rolling is not parked",
+			steps: []step{
+				{ctrl(6, 0, true), StageTaxi},
+			},
+		},
+		{
+			name: "Rejected take-off returns to taxi",
+			steps: []step{
+				{ctrl(50, 0.9, false), StageTakeOffRoll},
+				{ctrl(30, 0, false), StageTaxi},
+			},
+		},
+		{
+			name: "Without controls, accelerating past taxi speed",
+			steps: []step{
+				{speed(20), StageTaxi},
+				{speed(45), StageTakeOffRoll},
+				// Speed flat for a tick
+				{speed(45), StageTakeOffRoll},
+				{speed(30), StageTaxi},
+			},
+		},
+		{
+			name:  "Rollout after landing is not taxiing",
+			start: StageLanded,
+			steps: []step{
+				{ctrl(70, 0, false), StageLanded},
+				{speed(50), StageLanded},
+				{ctrl(20, 0.2, false), StageTaxi},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock := &MockClock{current: time.Now()}
+			sm := NewStageMachine(clock.Now)
+			sm.current = StageOnGround
+			if tt.start != "" {
+				sm.current = tt.start
+			}
+
+			for i, s := range tt.steps {
+				clock.Advance(time.Second)
+				tel := s.tel
+				if got := sm.Update(&tel); got != s.want {
+					t.Errorf("Step %d: wanted %s, got %s", i, s.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestStageMachine_Reset(t *testing.T) {
+	slog.SetLogLoggerLevel(slog.LevelError)
+	clock := &MockClock{current: time.Now()}
+	sm := NewStageMachine(clock.Now)
+
+	sm.Update(&Telemetry{IsOnGround: false, AltitudeAGL: 5000})
+	clock.Advance(time.Second)
+	if got := sm.Update(&Telemetry{IsOnGround: false, AltitudeAGL: 5000}); got != StageCruise {
+		t.Fatalf("expected cruise, got %s", got)
+	}
+
+	// A flight loaded at a gate must not be validated as a landing
+	sm.Reset()
+	clock.Advance(time.Second)
+	if got := sm.Update(&Telemetry{IsOnGround: true}); got != StageOnGround {
+		t.Errorf("expected a fresh ground start, got %s", got)
+	}
+	if sm.GetLastTransition(StageTakeOff).IsZero() {
+		t.Error("expected the transition history to survive the reset")
+	}
+}
+
 func TestFormatStage(t *testing.T) {
 	tests := []struct {
 		in   string
@@ -170,6 +305,7 @@ func TestFormatStage(t *testing.T) {
 		{"parked", "Parked"},
 		{"on_the_ground", "On The Ground"},
 		{"take-off", "Take-Off"},
+		{"takeoff_roll", "Takeoff Roll"},
 		{"climb", "Climb"},
 		{"", "Unknown"},
 	}
@@ -15,6 +15,8 @@ type POIStore interface {
 	GetRecentlyPlayedPOIs(ctx context.Context, since time.Time) ([]*model.POI, error)
 	SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error
 	ResetLastPlayed(ctx context.Context, lat, lon, radius float64) error
+	// DeletePOIsInRadius deletes POIs within radius meters and returns their QIDs.
+	DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error)
 }
 
 // CacheStore handles generic key-value caching.
@@ -27,10 +29,34 @@ type CacheStore interface {
 
 // GeodataRecord represents metadata for a cached tile.
 type GeodataRecord struct {
-	Key    string
-	Lat    float64
-	Lon    float64
-	Radius int
+	Key       string
+	Lat       float64
+	Lon       float64
+	Radius    int
+	CreatedAt time.Time
+}
+
+// Geodata listing orders.
+const (
+	GeodataSortRecent   = "recent"   // Newest first
+	GeodataSortDistance = "distance" // Nearest to NearLat/NearLon first
+	GeodataSortSize     = "size"     // Largest stored payload first
+)
+
+// GeodataQuery selects one page of cached tiles. A nil Bounds lists everything.
+type GeodataQuery struct {
+	Bounds    *Bounds
+	KeyPrefix string // Only keys starting with this; "" lists all
+	Sort      string
+	NearLat   float64
+	NearLon   float64
+	Limit     int
+	Offset    int
+}
+
+// Bounds is a lat/lon bounding box.
+type Bounds struct {
+	MinLat, MaxLat, MinLon, MaxLon float64
 }
 
 // GeodataStore handles geodata-specific caching with radius metadata.
@@ -38,7 +64,10 @@ type GeodataStore interface {
 	GetGeodataCache(ctx context.Context, key string) ([]byte, int, bool)
 	SetGeodataCache(ctx context.Context, key string, val []byte, radius int, lat, lon float64) error
 	GetGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]GeodataRecord, error)
+	DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error)
 	ListGeodataCacheKeys(ctx context.Context, prefix string) ([]string, error)
+	// ListGeodata returns one page of tile records and the total matching count.
+	ListGeodata(ctx context.Context, q GeodataQuery) ([]GeodataRecord, int, error)
 }
 
 // HierarchyStore handles Wikidata classification hierarchy.
@@ -82,3 +111,49 @@ type StateStore interface {
 	SetState(ctx context.Context, key, val string) error
 	DeleteState(ctx context.Context, key string) error
 }
+
+// NarrationLogEntry is one narration in the persisted history.
+type NarrationLogEntry struct {
+	Title     string
+	Type      string
+	CreatedAt time.Time
+	Duration  time.Duration
+	WordCount int
+}
+
+// NarrationLogStore keeps the recent narration history for the GUI.
+type NarrationLogStore interface {
+	// AddNarrationLog appends an entry and prunes the log to the newest keep entries.
+	AddNarrationLog(ctx context.Context, e NarrationLogEntry, keep int) error
+	// ListNarrationLog returns up to limit entries, newest first.
+	ListNarrationLog(ctx context.Context, limit int) ([]NarrationLogEntry, error)
+}
+
+// TelemetrySample is one position recorded by the flight recorder.
+type TelemetrySample struct {
+	Time        time.Time
+	Lat         float64
+	Lon         float64
+	AltitudeMSL float64 // Feet
+	Heading     float64 // Degrees true
+	GroundSpeed float64 // Knots
+	Stage       string
+}
+
+// TelemetryLogStore keeps the flight recorder's position samples.
+type TelemetryLogStore interface {
+	// AddTelemetrySample appends a sample and prunes the log to the newest keep
+	// samples. A keep of 0 or less records nothing.
+	AddTelemetrySample(ctx context.Context, s TelemetrySample, keep int) error
+	// ListTelemetrySamples returns up to limit samples recorded at or after
+	// since, oldest first.
+	ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]TelemetrySample, error)
+}
+
+// RegionVisitStore remembers when each region (see model.LocationInfo.RegionKey)
+// was last flown over.
+type RegionVisitStore interface {
+	SaveRegionVisit(ctx context.Context, region string, t time.Time) error
+	// ListRegionVisits returns the last visit of every region.
+	ListRegionVisits(ctx context.Context) (map[string]time.Time, error)
+}
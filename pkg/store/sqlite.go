@@ -31,6 +31,9 @@ type Store interface {
 	MSFSPOIStore
 	RegionalCategoriesStore
 	StateStore
+	NarrationLogStore
+	TelemetryLogStore
+	RegionVisitStore
 
 	// Close closes the store connection.
 	Close() error
@@ -40,6 +43,7 @@ type Store interface {
 type SQLiteStore struct {
 	db         *db.DB // Changed from *data.DB
 	stateCache sync.Map
+	seenTTL    time.Duration
 }
 
 // cachedState holds a single persistent_state row in the in-memory cache.
@@ -53,6 +57,12 @@ func NewSQLiteStore(db *db.DB) *SQLiteStore { // Changed from *data.DB
 	return &SQLiteStore{db: db}
 }
 
+// SetSeenTTL makes seen entities older than ttl count as unseen, so they are
+// classified again. 0 keeps them seen forever.
+func (s *SQLiteStore) SetSeenTTL(ttl time.Duration) {
+	s.seenTTL = ttl
+}
+
 func (s *SQLiteStore) Close() error {
 	return s.db.Close()
 }
@@ -63,7 +73,7 @@ func (s *SQLiteStore) Close() error {
 
 func (s *SQLiteStore) GetPOI(ctx context.Context, wikidataID string) (*model.POI, error) {
 	row := s.db.QueryRowContext(ctx,
-		`SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url
+		`SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url, description, height, length, area
 		 FROM poi WHERE wikidata_id = ?`, wikidataID)
 
 	var p model.POI
@@ -119,7 +129,7 @@ func (s *SQLiteStore) GetPOIsBatch(ctx context.Context, wikidataIDs []string) (m
 		return make(map[string]*model.POI), nil
 	}
 
-	query := `SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url
+	query := `SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url, description, height, length, area
 			  FROM poi WHERE wikidata_id IN (`
 	args := make([]any, len(wikidataIDs))
 	for i, id := range wikidataIDs {
@@ -151,16 +161,18 @@ func (s *SQLiteStore) GetPOIsBatch(ctx context.Context, wikidataIDs []string) (m
 func scanPOI(scanner interface{ Scan(dest ...any) error }, p *model.POI) error {
 	var lastPlayed sql.NullTime
 	var specificCategory sql.NullString
-	var nameEn, nameLocal, nameUser, wpURL, triggerQID, thumbURL sql.NullString
+	var nameEn, nameLocal, nameUser, wpURL, triggerQID, thumbURL, description sql.NullString
 	var sitelinks, wpLength sql.NullInt64
 	var isMSFS sql.NullBool
+	var height, length, area sql.NullFloat64
 
 	err := scanner.Scan(
 		&p.WikidataID, &p.Source, &p.Category, &specificCategory,
 		&p.Lat, &p.Lon, &sitelinks,
 		&nameEn, &nameLocal, &nameUser,
 		&wpURL, &wpLength,
-		&triggerQID, &lastPlayed, &p.CreatedAt, &isMSFS, &thumbURL,
+		&triggerQID, &lastPlayed, &p.CreatedAt, &isMSFS, &thumbURL, &description,
+		&height, &length, &area,
 	)
 	if err != nil {
 		return err
@@ -190,6 +202,10 @@ func scanPOI(scanner interface{ Scan(dest ...any) error }, p *model.POI) error {
 	if thumbURL.Valid {
 		p.ThumbnailURL = thumbURL.String
 	}
+	if description.Valid {
+		p.Description = description.String
+	}
+	p.Height, p.Length, p.Area = height.Float64, length.Float64, area.Float64
 	if sitelinks.Valid {
 		p.Sitelinks = int(sitelinks.Int64)
 	}
@@ -206,8 +222,8 @@ func (s *SQLiteStore) SavePOI(ctx context.Context, p *model.POI) error {
 	query := `INSERT OR REPLACE INTO poi (
 		wikidata_id, source, category, specific_category, lat, lon, sitelinks, 
 		name_en, name_local, name_user, wp_url, wp_article_length,
-		trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url
-	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url, description, height, length, area
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
 
 	createdAt := p.CreatedAt
 	if createdAt.IsZero() {
@@ -217,13 +233,14 @@ func (s *SQLiteStore) SavePOI(ctx context.Context, p *model.POI) error {
 	_, err := s.db.ExecContext(ctx, query,
 		p.WikidataID, p.Source, p.Category, p.SpecificCategory, p.Lat, p.Lon, p.Sitelinks,
 		p.NameEn, p.NameLocal, p.NameUser, p.WPURL, p.WPArticleLength,
-		p.TriggerQID, p.LastPlayed, createdAt, p.IsMSFSPOI, p.ThumbnailURL,
+		p.TriggerQID, p.LastPlayed, createdAt, p.IsMSFSPOI, p.ThumbnailURL, p.Description,
+		p.Height, p.Length, p.Area,
 	)
 	return err
 }
 
 func (s *SQLiteStore) GetRecentlyPlayedPOIs(ctx context.Context, since time.Time) ([]*model.POI, error) {
-	query := `SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url
+	query := `SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url, description, height, length, area
 			  FROM poi WHERE last_played > ? ORDER BY last_played DESC LIMIT 10`
 
 	rows, err := s.db.QueryContext(ctx, query, since)
@@ -243,6 +260,84 @@ func (s *SQLiteStore) GetRecentlyPlayedPOIs(ctx context.Context, since time.Time
 	return results, nil
 }
 
+// GetPOIsBySource returns all POIs from the given source, such as the
+// user-imported ones, which no tile load brings back.
+func (s *SQLiteStore) GetPOIsBySource(ctx context.Context, source string) ([]*model.POI, error) {
+	query := `SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url, description, height, length, area
+			  FROM poi WHERE source = ?`
+
+	rows, err := s.db.QueryContext(ctx, query, source)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*model.POI
+	for rows.Next() {
+		var p model.POI
+		if err := scanPOI(rows, &p); err != nil {
+			return nil, err
+		}
+		results = append(results, &p)
+	}
+	return results, rows.Err()
+}
+
+// boundsPageSize is how many POIs ForEachPOIInBounds reads per query.
+var boundsPageSize = 500
+
+// ForEachPOIInBounds calls fn for each stored POI inside b, in pages, so an
+// export of a large area is never held in memory at once. Each page is read
+// and its rows closed before fn runs: the pool has a single connection, and
+// a cursor held while fn writes to a slow client would stall every other
+// store call. A box with MinLon > MaxLon crosses the antimeridian. An error
+// from fn stops the iteration and is returned.
+func (s *SQLiteStore) ForEachPOIInBounds(ctx context.Context, b Bounds, fn func(*model.POI) error) error {
+	lonCond := "lon BETWEEN ? AND ?"
+	if b.MinLon > b.MaxLon {
+		lonCond = "(lon >= ? OR lon <= ?)"
+	}
+	query := `SELECT wikidata_id, source, category, specific_category, lat, lon, sitelinks, name_en, name_local, name_user, wp_url, wp_article_length, trigger_qid, last_played, created_at, is_msfs_poi, thumbnail_url, description, height, length, area
+			  FROM poi WHERE lat BETWEEN ? AND ? AND ` + lonCond + ` AND wikidata_id > ?
+			  ORDER BY wikidata_id LIMIT ?`
+
+	after := ""
+	for {
+		page, err := s.poiPage(ctx, query, b.MinLat, b.MaxLat, b.MinLon, b.MaxLon, after, boundsPageSize)
+		if err != nil {
+			return err
+		}
+		for _, p := range page {
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+		if len(page) < boundsPageSize {
+			return nil
+		}
+		after = page[len(page)-1].WikidataID
+	}
+}
+
+// poiPage reads one page of POIs and releases the connection before returning.
+func (s *SQLiteStore) poiPage(ctx context.Context, query string, args ...any) ([]*model.POI, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var page []*model.POI
+	for rows.Next() {
+		var p model.POI
+		if err := scanPOI(rows, &p); err != nil {
+			return nil, err
+		}
+		page = append(page, &p)
+	}
+	return page, rows.Err()
+}
+
 func (s *SQLiteStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error {
 	_, err := s.db.ExecContext(ctx, `UPDATE poi SET last_played = ? WHERE wikidata_id = ?`, t, poiID)
 	return err
@@ -267,6 +362,52 @@ func (s *SQLiteStore) ResetLastPlayed(ctx context.Context, lat, lon, radius floa
 	return err
 }
 
+// DeletePOIsInRadius deletes POIs within radius meters of lat/lon, including
+// their last_played history, and returns the deleted QIDs.
+func (s *SQLiteStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	degLat := (radius / 1000.0) / 111.0
+	degLon := degLat / math.Max(math.Cos(lat*math.Pi/180.0), 0.01)
+
+	// User-imported POIs are not cached data and cannot be fetched again
+	rows, err := s.db.QueryContext(ctx, `SELECT wikidata_id, lat, lon FROM poi
+			  WHERE lat BETWEEN ? AND ? AND lon BETWEEN ? AND ? AND source IS NOT 'user'`,
+		lat-degLat, lat+degLat, lon-degLon, lon+degLon)
+	if err != nil {
+		return nil, err
+	}
+	var qids []string
+	for rows.Next() {
+		var qid string
+		var pLat, pLon float64
+		if err := rows.Scan(&qid, &pLat, &pLon); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		// The box is only a pre-filter; its corners lie outside the radius
+		if haversine(lat, lon, pLat, pLon) <= radius {
+			qids = append(qids, qid)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	const chunkSize = 500
+	for i := 0; i < len(qids); i += chunkSize {
+		chunk := qids[i:min(i+chunkSize, len(qids))]
+		query := "DELETE FROM poi WHERE wikidata_id IN (?" + strings.Repeat(",?", len(chunk)-1) + ")"
+		args := make([]interface{}, len(chunk))
+		for j, qid := range chunk {
+			args[j] = qid
+		}
+		if _, err := s.db.ExecContext(ctx, query, args...); err != nil {
+			return nil, err
+		}
+	}
+	return qids, nil
+}
+
 // --- MSFS ---
 
 func (s *SQLiteStore) GetMSFSPOI(ctx context.Context, id int64) (*model.MSFSPOI, error) {
@@ -313,33 +454,32 @@ func (s *SQLiteStore) CheckMSFSPOI(ctx context.Context, lat, lon, radius float64
 	}
 	defer rows.Close()
 
-	const R = 6371000.0 // Earth radius in meters
-
 	for rows.Next() {
 		var pLat, pLon float64
 		if err := rows.Scan(&pLat, &pLon); err != nil {
 			return false, err
 		}
 
-		// Haversine Distance
-		dLat := (pLat - lat) * (math.Pi / 180.0)
-		dLon := (pLon - lon) * (math.Pi / 180.0)
-		lat1 := lat * (math.Pi / 180.0)
-		lat2 := pLat * (math.Pi / 180.0)
-
-		a := math.Sin(dLat/2)*math.Sin(dLat/2) +
-			math.Cos(lat1)*math.Cos(lat2)*
-				math.Sin(dLon/2)*math.Sin(dLon/2)
-		c := 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
-		dist := R * c
-
-		if dist <= radius {
+		if haversine(lat, lon, pLat, pLon) <= radius {
 			return true, nil
 		}
 	}
 	return false, nil
 }
 
+// haversine returns the great-circle distance in meters. pkg/geo cannot be
+// used here, it depends on the store through the logging config.
+func haversine(lat1, lon1, lat2, lon2 float64) float64 {
+	const R = 6371000.0 // Earth radius in meters
+
+	dLat := (lat2 - lat1) * (math.Pi / 180.0)
+	dLon := (lon2 - lon1) * (math.Pi / 180.0)
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180.0)*math.Cos(lat2*math.Pi/180.0)*
+			math.Sin(dLon/2)*math.Sin(dLon/2)
+	return R * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
 func (s *SQLiteStore) ClearMSFSPOIs(ctx context.Context) error {
 	_, err := s.db.ExecContext(ctx, "DELETE FROM msfs_poi")
 	return err
@@ -495,6 +635,10 @@ func (s *SQLiteStore) GetSeenEntitiesBatch(ctx context.Context, qids []string) (
 		args[i] = id
 	}
 	query += ")"
+	if s.seenTTL > 0 {
+		query += " AND created_at >= ?"
+		args = append(args, seenDeadline(s.seenTTL))
+	}
 
 	rows, err := s.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -530,13 +674,14 @@ func (s *SQLiteStore) MarkEntitiesSeen(ctx context.Context, entities map[string]
 	}
 	defer func() { _ = tx.Rollback() }()
 
-	stmt, err := tx.PrepareContext(ctx, "INSERT OR IGNORE INTO seen_entities (qid, instances, created_at) VALUES (?, ?, ?)")
+	// Re-marking an expired entity restarts its TTL
+	stmt, err := tx.PrepareContext(ctx, "INSERT OR REPLACE INTO seen_entities (qid, instances, created_at) VALUES (?, ?, ?)")
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	now := time.Now().UTC()
+	now := time.Now().UTC().Format(seenTimeFormat)
 	for qid, instances := range entities {
 		instancesJSON, err := json.Marshal(instances)
 		if err != nil {
@@ -550,6 +695,24 @@ func (s *SQLiteStore) MarkEntitiesSeen(ctx context.Context, entities map[string]
 	return tx.Commit()
 }
 
+// seenTimeFormat matches SQLite's CURRENT_TIMESTAMP, so created_at compares
+// as text against deadlines.
+const seenTimeFormat = "2006-01-02 15:04:05"
+
+func seenDeadline(age time.Duration) string {
+	return time.Now().Add(-age).UTC().Format(seenTimeFormat)
+}
+
+// PruneSeenEntities deletes seen entities recorded more than olderThan ago
+// and returns how many were removed.
+func (s *SQLiteStore) PruneSeenEntities(ctx context.Context, olderThan time.Duration) (int64, error) {
+	res, err := s.db.ExecContext(ctx, "DELETE FROM seen_entities WHERE created_at < ?", seenDeadline(olderThan))
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
 // DeleteSeenEntities removes specific QIDs from the seen cache, allowing them to be re-evaluated.
 func (s *SQLiteStore) DeleteSeenEntities(ctx context.Context, qids []string) error {
 	if len(qids) == 0 {
@@ -746,6 +909,71 @@ func (s *SQLiteStore) GetGeodataInBounds(ctx context.Context, minLat, maxLat, mi
 	return results, nil
 }
 
+// ListGeodata returns one page of cached tile records plus the total number
+// matching the filter, so callers never hold the whole table in memory.
+// Distance order uses an equirectangular approximation, which is exact enough
+// for ranking and needs no SQL math functions.
+func (s *SQLiteStore) ListGeodata(ctx context.Context, q GeodataQuery) ([]GeodataRecord, int, error) {
+	var conds []string
+	var args []any
+	if b := q.Bounds; b != nil {
+		conds = append(conds, "lat BETWEEN ? AND ? AND lon BETWEEN ? AND ?")
+		args = append(args, b.MinLat, b.MaxLat, b.MinLon, b.MaxLon)
+	}
+	if q.KeyPrefix != "" {
+		conds = append(conds, "substr(key, 1, ?) = ?")
+		args = append(args, len(q.KeyPrefix), q.KeyPrefix)
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = " WHERE " + strings.Join(conds, " AND ")
+	}
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM cache_geodata"+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	order := " ORDER BY created_at DESC, key"
+	switch q.Sort {
+	case GeodataSortDistance:
+		cosLat := math.Cos(q.NearLat * math.Pi / 180.0)
+		order = " ORDER BY (lat - ?) * (lat - ?) + (lon - ?) * (lon - ?) * ?, key"
+		args = append(args, q.NearLat, q.NearLat, q.NearLon, q.NearLon, cosLat*cosLat)
+	case GeodataSortSize:
+		order = " ORDER BY length(data) DESC, key"
+	}
+	args = append(args, q.Limit, q.Offset)
+
+	rows, err := s.db.QueryContext(ctx, "SELECT key, lat, lon, radius_m, created_at FROM cache_geodata"+where+order+" LIMIT ? OFFSET ?", args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var results []GeodataRecord
+	for rows.Next() {
+		var r GeodataRecord
+		if err := rows.Scan(&r.Key, &r.Lat, &r.Lon, &r.Radius, &r.CreatedAt); err != nil {
+			return nil, 0, err
+		}
+		results = append(results, r)
+	}
+	return results, total, rows.Err()
+}
+
+// DeleteGeodataInBounds deletes cached tiles whose center lies within the
+// bounds and returns how many were removed.
+func (s *SQLiteStore) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM cache_geodata
+	          WHERE lat BETWEEN ? AND ? AND lon BETWEEN ? AND ?`, minLat, maxLat, minLon, maxLon)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
 func (s *SQLiteStore) ListCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	rows, err := s.db.QueryContext(ctx, "SELECT key FROM cache WHERE key LIKE ?", prefix+"%")
 	if err != nil {
@@ -882,3 +1110,120 @@ func (s *SQLiteStore) DeleteState(ctx context.Context, key string) error {
 	}
 	return err
 }
+
+// ListStateKeys lists all persistent state keys with the given prefix.
+func (s *SQLiteStore) ListStateKeys(ctx context.Context, prefix string) ([]string, error) {
+	// substr instead of LIKE: state prefixes contain '_', which LIKE treats as a wildcard
+	rows, err := s.db.QueryContext(ctx, "SELECT key FROM persistent_state WHERE substr(key, 1, ?) = ?", len(prefix), prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var k string
+		if err := rows.Scan(&k); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// --- Narration Log ---
+
+func (s *SQLiteStore) AddNarrationLog(ctx context.Context, e NarrationLogEntry, keep int) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO narration_log (title, type, created_at, duration_ms, word_count) VALUES (?, ?, ?, ?, ?)",
+		e.Title, e.Type, e.CreatedAt, e.Duration.Milliseconds(), e.WordCount)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, "DELETE FROM narration_log WHERE id NOT IN (SELECT id FROM narration_log ORDER BY id DESC LIMIT ?)", keep)
+	return err
+}
+
+func (s *SQLiteStore) ListNarrationLog(ctx context.Context, limit int) ([]NarrationLogEntry, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT title, type, created_at, duration_ms, word_count FROM narration_log ORDER BY id DESC LIMIT ?", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []NarrationLogEntry
+	for rows.Next() {
+		var e NarrationLogEntry
+		var durationMs int64
+		if err := rows.Scan(&e.Title, &e.Type, &e.CreatedAt, &durationMs, &e.WordCount); err != nil {
+			return nil, err
+		}
+		e.Duration = time.Duration(durationMs) * time.Millisecond
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// --- Telemetry Log ---
+
+func (s *SQLiteStore) AddTelemetrySample(ctx context.Context, t TelemetrySample, keep int) error {
+	// Pruning to the newest keep samples would wipe the log
+	if keep <= 0 {
+		return nil
+	}
+	res, err := s.db.ExecContext(ctx, "INSERT INTO telemetry_log (recorded_at, lat, lon, alt_msl, heading, ground_speed, stage) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		t.Time, t.Lat, t.Lon, t.AltitudeMSL, t.Heading, t.GroundSpeed, t.Stage)
+	if err != nil {
+		return err
+	}
+	// Ids only grow, so pruning by id range stays cheap on a large log
+	id, err := res.LastInsertId()
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx, "DELETE FROM telemetry_log WHERE id <= ?", id-int64(keep))
+	return err
+}
+
+func (s *SQLiteStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]TelemetrySample, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT recorded_at, lat, lon, alt_msl, heading, ground_speed, stage FROM telemetry_log WHERE recorded_at >= ? ORDER BY id LIMIT ?", since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []TelemetrySample
+	for rows.Next() {
+		var t TelemetrySample
+		if err := rows.Scan(&t.Time, &t.Lat, &t.Lon, &t.AltitudeMSL, &t.Heading, &t.GroundSpeed, &t.Stage); err != nil {
+			return nil, err
+		}
+		samples = append(samples, t)
+	}
+	return samples, rows.Err()
+}
+
+// --- Region Visits ---
+
+func (s *SQLiteStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO region_visits (region, last_visit) VALUES (?, ?) ON CONFLICT(region) DO UPDATE SET last_visit = excluded.last_visit", region, t)
+	return err
+}
+
+func (s *SQLiteStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT region, last_visit FROM region_visits")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	visits := make(map[string]time.Time)
+	for rows.Next() {
+		var region string
+		var t time.Time
+		if err := rows.Scan(&region, &t); err != nil {
+			return nil, err
+		}
+		visits[region] = t
+	}
+	return visits, rows.Err()
+}
@@ -34,6 +34,50 @@ func TestSQLiteStore(t *testing.T) {
 	testResetLastPlayed(t, ctx, store)
 	testClassificationPriority(t, ctx, store)
 	testThumbnail(t, ctx, store)
+	testUserPOIs(t, ctx, store)
+	testDimensions(t, ctx, store)
+}
+
+func testDimensions(t *testing.T, ctx context.Context, store *SQLiteStore) {
+	t.Run("Dimensions", func(t *testing.T) {
+		p := &model.POI{WikidataID: "QDIM", Source: "wikidata", Lat: 41, Lon: 41, Height: 120, Length: 850, Area: 2.5e6}
+		if err := store.SavePOI(ctx, p); err != nil {
+			t.Fatalf("SavePOI failed: %v", err)
+		}
+		got, err := store.GetPOI(ctx, "QDIM")
+		if err != nil || got == nil {
+			t.Fatalf("GetPOI failed: %v", err)
+		}
+		if got.Height != 120 || got.Length != 850 || got.Area != 2.5e6 {
+			t.Errorf("dimensions = %v/%v/%v, want 120/850/2.5e6", got.Height, got.Length, got.Area)
+		}
+	})
+}
+
+func testUserPOIs(t *testing.T, ctx context.Context, store *SQLiteStore) {
+	t.Run("UserPOIs", func(t *testing.T) {
+		user := &model.POI{WikidataID: "user_abc", Source: model.SourceUser, Lat: 40, Lon: 40, NameEn: "Cabin", Description: "Grandpa's cabin"}
+		wd := &model.POI{WikidataID: "QU1", Source: "wikidata", Lat: 40, Lon: 40}
+		_ = store.SavePOI(ctx, user)
+		_ = store.SavePOI(ctx, wd)
+
+		pois, err := store.GetPOIsBySource(ctx, model.SourceUser)
+		if err != nil {
+			t.Fatalf("GetPOIsBySource failed: %v", err)
+		}
+		if len(pois) != 1 || pois[0].Description != "Grandpa's cabin" {
+			t.Fatalf("expected the user POI with its description, got %+v", pois)
+		}
+
+		// Purging an area drops cached POIs but keeps the user's own
+		deleted, err := store.DeletePOIsInRadius(ctx, 40, 40, 1000)
+		if err != nil {
+			t.Fatalf("DeletePOIsInRadius failed: %v", err)
+		}
+		if len(deleted) != 1 || deleted[0] != "QU1" {
+			t.Errorf("expected only QU1 deleted, got %v", deleted)
+		}
+	})
 }
 
 func testClassificationPriority(t *testing.T, ctx context.Context, store *SQLiteStore) {
@@ -242,6 +286,16 @@ func testState(t *testing.T, ctx context.Context, store *SQLiteStore) {
 		if sVal != "my_val" {
 			t.Errorf("Expected 'my_val', got '%s'", sVal)
 		}
+
+		_ = store.SetState(ctx, "blocklist_Q1", "true")
+		_ = store.SetState(ctx, "blocklistXQ2", "true")
+		keys, err := store.ListStateKeys(ctx, "blocklist_")
+		if err != nil {
+			t.Fatalf("ListStateKeys failed: %v", err)
+		}
+		if len(keys) != 1 || keys[0] != "blocklist_Q1" {
+			t.Errorf("Expected [blocklist_Q1], got %v", keys)
+		}
 	})
 }
 
@@ -2,7 +2,12 @@ package store
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,7 +16,7 @@ import (
 )
 
 // setupTestStore creates a test database and store for each test.
-func setupTestStore(t *testing.T) (*SQLiteStore, func()) {
+func setupTestStore(t testing.TB) (*SQLiteStore, func()) {
 	t.Helper()
 	tempDir := t.TempDir()
 	dbPath := filepath.Join(tempDir, "test.db")
@@ -96,6 +101,110 @@ func TestPOIStore_GetRecentlyPlayedPOIs(t *testing.T) {
 	}
 }
 
+func TestPOIStore_DeletePOIsInRadius(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q1", Lat: 52.0, Lon: 13.0})
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q2", Lat: 52.005, Lon: 13.005}) // ~650m
+	// Inside the bounding box but outside the circle
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q3", Lat: 52.008, Lon: 13.013})
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q4", Lat: 53.0, Lon: 14.0})
+
+	qids, err := store.DeletePOIsInRadius(ctx, 52.0, 13.0, 1000)
+	if err != nil {
+		t.Fatalf("DeletePOIsInRadius failed: %v", err)
+	}
+	if len(qids) != 2 {
+		t.Errorf("Expected 2 deleted POIs, got %v", qids)
+	}
+
+	for id, wantGone := range map[string]bool{"Q1": true, "Q2": true, "Q3": false, "Q4": false} {
+		p, err := store.GetPOI(ctx, id)
+		if err != nil {
+			t.Fatalf("GetPOI(%s) failed: %v", id, err)
+		}
+		if gone := p == nil; gone != wantGone {
+			t.Errorf("%s: expected deleted=%v, got %v", id, wantGone, gone)
+		}
+	}
+}
+
+func TestPOIStore_ForEachPOIInBounds(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q1", Lat: 52.0, Lon: 13.0})
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q2", Lat: 52.5, Lon: 13.5})
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q3", Lat: 53.5, Lon: 13.0})
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q4", Lat: -17.0, Lon: 179.5})
+	_ = store.SavePOI(ctx, &model.POI{WikidataID: "Q5", Lat: -17.0, Lon: -179.5})
+
+	tests := []struct {
+		name string
+		b    Bounds
+		want []string
+	}{
+		{name: "Box", b: Bounds{MinLat: 51.5, MaxLat: 53, MinLon: 12.5, MaxLon: 14}, want: []string{"Q1", "Q2"}},
+		{name: "Across the antimeridian", b: Bounds{MinLat: -18, MaxLat: -16, MinLon: 179, MaxLon: -179}, want: []string{"Q4", "Q5"}},
+		{name: "Empty", b: Bounds{MinLat: 0, MaxLat: 1, MinLon: 0, MaxLon: 1}, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			err := store.ForEachPOIInBounds(ctx, tt.b, func(p *model.POI) error {
+				got = append(got, p.WikidataID)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ForEachPOIInBounds failed: %v", err)
+			}
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Pages leave the connection free", func(t *testing.T) {
+		defer func(n int) { boundsPageSize = n }(boundsPageSize)
+		boundsPageSize = 2
+
+		tctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		var got []string
+		err := store.ForEachPOIInBounds(tctx, Bounds{MinLat: -90, MaxLat: 90, MinLon: -180, MaxLon: 180}, func(p *model.POI) error {
+			// Would block on the single connection if a cursor were open
+			if _, err := store.GetPOI(tctx, p.WikidataID); err != nil {
+				return err
+			}
+			got = append(got, p.WikidataID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("ForEachPOIInBounds failed: %v", err)
+		}
+		if strings.Join(got, ",") != "Q1,Q2,Q3,Q4,Q5" {
+			t.Errorf("got %v, want all five POIs in order", got)
+		}
+	})
+
+	t.Run("Callback error stops the iteration", func(t *testing.T) {
+		stop := errors.New("stop")
+		calls := 0
+		err := store.ForEachPOIInBounds(ctx, Bounds{MinLat: -90, MaxLat: 90, MinLon: -180, MaxLon: 180}, func(p *model.POI) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) || calls != 1 {
+			t.Errorf("err = %v after %d calls, want the callback error after 1", err, calls)
+		}
+	})
+}
+
 // =============================================================================
 // MSFSPOIStore Tests
 // =============================================================================
@@ -376,6 +485,55 @@ func TestSeenEntityStore_MarkAndGet(t *testing.T) {
 	}
 }
 
+func TestSeenEntityStore_TTL(t *testing.T) {
+	ctx := context.Background()
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		ttl      time.Duration
+		age      time.Duration
+		wantSeen bool
+	}{
+		{name: "no TTL keeps old entries", ttl: 0, age: 400 * day, wantSeen: true},
+		{name: "within TTL", ttl: 90 * day, age: 30 * day, wantSeen: true},
+		{name: "past TTL is re-evaluated", ttl: 90 * day, age: 100 * day, wantSeen: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, cleanup := setupTestStore(t)
+			defer cleanup()
+			store.SetSeenTTL(tt.ttl)
+
+			created := time.Now().Add(-tt.age).UTC().Format("2006-01-02 15:04:05")
+			if _, err := store.db.Exec("INSERT INTO seen_entities (qid, instances, created_at) VALUES (?, ?, ?)", "Q1", `["Q5"]`, created); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := store.GetSeenEntitiesBatch(ctx, []string{"Q1"})
+			if err != nil {
+				t.Fatalf("GetSeenEntitiesBatch() error = %v", err)
+			}
+			if _, seen := got["Q1"]; seen != tt.wantSeen {
+				t.Errorf("seen = %v, want %v", seen, tt.wantSeen)
+			}
+
+			// Marking it again after re-evaluation restarts the TTL
+			if err := store.MarkEntitiesSeen(ctx, map[string][]string{"Q1": {"Q6"}}); err != nil {
+				t.Fatalf("MarkEntitiesSeen() error = %v", err)
+			}
+			got, err = store.GetSeenEntitiesBatch(ctx, []string{"Q1"})
+			if err != nil {
+				t.Fatalf("GetSeenEntitiesBatch() error = %v", err)
+			}
+			if inst := got["Q1"]; len(inst) != 1 || inst[0] != "Q6" {
+				t.Errorf("instances after re-marking = %v, want [Q6]", inst)
+			}
+		})
+	}
+}
+
 func TestSeenEntityStore_GetEmptyBatch(t *testing.T) {
 	store, cleanup := setupTestStore(t)
 	defer cleanup()
@@ -593,6 +751,186 @@ func TestGeodataStore_GetGeodataInBounds(t *testing.T) {
 	}
 }
 
+func TestGeodataStore_DeleteGeodataInBounds(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_ = store.SetGeodataCache(ctx, "k1", []byte("data1"), 1000, 52.0, 13.0)
+	_ = store.SetGeodataCache(ctx, "k2", []byte("data2"), 2000, 53.0, 14.0)
+	_ = store.SetGeodataCache(ctx, "k3", []byte("data3"), 3000, 52.1, 13.1)
+
+	n, err := store.DeleteGeodataInBounds(ctx, 51.9, 52.2, 12.9, 13.2)
+	if err != nil {
+		t.Fatalf("DeleteGeodataInBounds failed: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("Expected 2 deleted tiles, got %d", n)
+	}
+	if _, _, ok := store.GetGeodataCache(ctx, "k1"); ok {
+		t.Error("Expected k1 to be deleted")
+	}
+	if _, _, ok := store.GetGeodataCache(ctx, "k2"); !ok {
+		t.Error("Expected k2 outside the bounds to remain")
+	}
+}
+
+func TestGeodataStore_ListGeodata(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	_ = store.SetGeodataCache(ctx, "k1", []byte("data1"), 1000, 52.0, 13.0)
+	_ = store.SetGeodataCache(ctx, "k2", []byte("data2"), 2000, 53.0, 14.0)
+	_ = store.SetGeodataCache(ctx, "k3", []byte("data3"), 3000, 52.1, 13.1)
+	var big strings.Builder
+	for i := 0; i < 2000; i++ {
+		fmt.Fprintf(&big, "%d,", i*7919)
+	}
+	_ = store.SetGeodataCache(ctx, "wd_h3_small", []byte("tile"), 3000, 40.0, 10.0)
+	_ = store.SetGeodataCache(ctx, "wd_h3_big", []byte(big.String()), 3000, 40.1, 10.1)
+
+	keys := func(recs []GeodataRecord) string {
+		out := make([]string, len(recs))
+		for i, r := range recs {
+			out[i] = r.Key
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name      string
+		query     GeodataQuery
+		wantKeys  string
+		wantTotal int
+	}{
+		{"First page", GeodataQuery{Limit: 2}, "", 5},
+		{"Bounds", GeodataQuery{Bounds: &Bounds{MinLat: 51.9, MaxLat: 52.2, MinLon: 12.9, MaxLon: 13.2}, Limit: 10}, "", 2},
+		{"Nearest first", GeodataQuery{Sort: GeodataSortDistance, NearLat: 53.0, NearLon: 14.0, Limit: 3}, "k2,k3,k1", 5},
+		{"Offset", GeodataQuery{Sort: GeodataSortDistance, NearLat: 53.0, NearLon: 14.0, Limit: 1, Offset: 1}, "k3", 5},
+		{"Prefix, largest first", GeodataQuery{KeyPrefix: "wd_h3_", Sort: GeodataSortSize, Limit: 10}, "wd_h3_big,wd_h3_small", 2},
+		{"Prefix capped", GeodataQuery{KeyPrefix: "wd_h3_", Sort: GeodataSortSize, Limit: 1}, "wd_h3_big", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs, total, err := store.ListGeodata(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("ListGeodata failed: %v", err)
+			}
+			if total != tt.wantTotal {
+				t.Errorf("expected total %d, got %d", tt.wantTotal, total)
+			}
+			if tt.wantKeys != "" && keys(recs) != tt.wantKeys {
+				t.Errorf("expected keys %s, got %s", tt.wantKeys, keys(recs))
+			}
+			if tt.query.Limit < tt.wantTotal && len(recs) != tt.query.Limit {
+				t.Errorf("expected a page of %d, got %d", tt.query.Limit, len(recs))
+			}
+		})
+	}
+}
+
+func TestNarrationLogStore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	base := time.Now().Truncate(time.Second)
+	for i, title := range []string{"First", "Second", "Third"} {
+		e := NarrationLogEntry{Title: title, Type: "poi", CreatedAt: base.Add(time.Duration(i) * time.Minute), Duration: 42500 * time.Millisecond, WordCount: 120}
+		if err := store.AddNarrationLog(ctx, e, 2); err != nil {
+			t.Fatalf("AddNarrationLog failed: %v", err)
+		}
+	}
+
+	entries, err := store.ListNarrationLog(ctx, 10)
+	if err != nil {
+		t.Fatalf("ListNarrationLog failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected the log pruned to 2 entries, got %d", len(entries))
+	}
+	if entries[0].Title != "Third" || entries[1].Title != "Second" {
+		t.Errorf("expected newest first, got %s, %s", entries[0].Title, entries[1].Title)
+	}
+	if e := entries[0]; e.Duration != 42500*time.Millisecond || e.WordCount != 120 || !e.CreatedAt.Equal(base.Add(2*time.Minute)) {
+		t.Errorf("unexpected entry %+v", e)
+	}
+
+	if entries, _ := store.ListNarrationLog(ctx, 1); len(entries) != 1 {
+		t.Errorf("expected the limit to apply, got %d entries", len(entries))
+	}
+}
+
+func TestTelemetryLogStore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	base := time.Now().Truncate(time.Second)
+	for i := range 4 {
+		s := TelemetrySample{Time: base.Add(time.Duration(i) * time.Second), Lat: 47 + float64(i)*0.01, Lon: 8, AltitudeMSL: 3500, Heading: 90, GroundSpeed: 110, Stage: "cruise"}
+		if err := store.AddTelemetrySample(ctx, s, 3); err != nil {
+			t.Fatalf("AddTelemetrySample failed: %v", err)
+		}
+	}
+
+	samples, err := store.ListTelemetrySamples(ctx, time.Time{}, 10)
+	if err != nil {
+		t.Fatalf("ListTelemetrySamples failed: %v", err)
+	}
+	if len(samples) != 3 {
+		t.Fatalf("expected the log pruned to 3 samples, got %d", len(samples))
+	}
+	if !samples[0].Time.Equal(base.Add(time.Second)) || !samples[2].Time.Equal(base.Add(3*time.Second)) {
+		t.Errorf("expected oldest first, got %v .. %v", samples[0].Time, samples[2].Time)
+	}
+	if s := samples[2]; s.Lat != 47.03 || s.AltitudeMSL != 3500 || s.Stage != "cruise" {
+		t.Errorf("unexpected sample %+v", s)
+	}
+
+	samples, _ = store.ListTelemetrySamples(ctx, base.Add(2*time.Second), 1)
+	if len(samples) != 1 || !samples[0].Time.Equal(base.Add(2*time.Second)) {
+		t.Errorf("expected since and limit to apply, got %+v", samples)
+	}
+
+	// keep <= 0 records nothing and leaves the log alone
+	if err := store.AddTelemetrySample(ctx, TelemetrySample{Time: base.Add(4 * time.Second)}, 0); err != nil {
+		t.Fatalf("AddTelemetrySample failed: %v", err)
+	}
+	if samples, _ = store.ListTelemetrySamples(ctx, time.Time{}, 10); len(samples) != 3 {
+		t.Errorf("expected the log untouched with keep 0, got %d samples", len(samples))
+	}
+}
+
+func TestRegionVisitStore(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	first := time.Now().Add(-48 * time.Hour).Truncate(time.Second)
+	later := first.Add(24 * time.Hour)
+	for _, v := range []struct {
+		region string
+		t      time.Time
+	}{{"DE/BY", first}, {"FR", first}, {"DE/BY", later}} {
+		if err := store.SaveRegionVisit(ctx, v.region, v.t); err != nil {
+			t.Fatalf("SaveRegionVisit failed: %v", err)
+		}
+	}
+
+	visits, err := store.ListRegionVisits(ctx)
+	if err != nil {
+		t.Fatalf("ListRegionVisits failed: %v", err)
+	}
+	if len(visits) != 2 {
+		t.Fatalf("expected 2 regions, got %v", visits)
+	}
+	if !visits["DE/BY"].Equal(later) || !visits["FR"].Equal(first) {
+		t.Errorf("expected the latest visit per region, got %v", visits)
+	}
+}
+
 func TestGeodataStore_GetMissing(t *testing.T) {
 	store, cleanup := setupTestStore(t)
 	defer cleanup()
@@ -633,3 +971,42 @@ func TestCacheInterface_GetSet(t *testing.T) {
 		t.Error("Get() expected miss for nonexistent key")
 	}
 }
+
+// BenchmarkGetGeodataInBounds queries a fixed 0.3° box while the cache grows
+// tenfold per step. The (lat, lon) index scans only the latitude band of the
+// box, so the time per query grows with the width of the cached area (the
+// square root of the tiles here), not with their number.
+func BenchmarkGetGeodataInBounds(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 100_000} {
+		b.Run(fmt.Sprintf("tiles=%d", n), func(b *testing.B) {
+			store, cleanup := setupTestStore(b)
+			defer cleanup()
+			ctx := context.Background()
+
+			// Spread the tiles over a square grid, about 10km apart like real ones
+			tx, err := store.db.BeginTx(ctx, nil)
+			if err != nil {
+				b.Fatalf("BeginTx failed: %v", err)
+			}
+			side := int(math.Sqrt(float64(n)))
+			for i := 0; i < n; i++ {
+				lat, lon := 30.0+float64(i/side)*0.1, float64(i%side)*0.1
+				if _, err := tx.ExecContext(ctx, `INSERT INTO cache_geodata (key, data, radius_m, lat, lon, created_at) VALUES (?, ?, ?, ?, ?, ?)`,
+					fmt.Sprintf("wd_h3_%d", i), []byte("{}"), 9800, lat, lon, time.Now()); err != nil {
+					b.Fatalf("insert failed: %v", err)
+				}
+			}
+			if err := tx.Commit(); err != nil {
+				b.Fatalf("Commit failed: %v", err)
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				records, err := store.GetGeodataInBounds(ctx, 31.0, 31.3, 1.0, 1.3)
+				if err != nil || len(records) == 0 {
+					b.Fatalf("GetGeodataInBounds = %d records, %v", len(records), err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package terrain
+
+import (
+	"phileasgo/pkg/geo"
+)
+
+// ValleyDetector recognises flight through a valley or canyon: terrain on
+// both sides of the track rising well above the aircraft.
+type ValleyDetector struct {
+	elevation ElevationGetter
+	radiusKM  float64
+	minWallM  float64
+}
+
+// NewValleyDetector creates a detector sampling radiusKM to either side of the
+// track. minWallM is how far both walls must rise above the aircraft.
+// A nil elevation source makes the detector a no-op.
+func NewValleyDetector(e ElevationGetter, radiusKM, minWallM float64) *ValleyDetector {
+	return &ValleyDetector{
+		elevation: e,
+		radiusKM:  radiusKM,
+		minWallM:  minWallM,
+	}
+}
+
+// InValley reports whether the aircraft at altMSLFt is flanked by high
+// terrain. Samples are taken abeam and ahead along the heading, so a wall
+// only needs to exist somewhere along the next radiusKM of track.
+func (v *ValleyDetector) InValley(lat, lon, headingDeg, altMSLFt float64) bool {
+	if v == nil || v.elevation == nil || v.radiusKM <= 0 {
+		return false
+	}
+
+	const feetToMeters = 0.3048
+	wallMin := altMSLFt*feetToMeters + v.minWallM
+	radiusM := v.radiusKM * 1000.0
+	pos := geo.Point{Lat: lat, Lon: lon}
+
+	left, right := false, false
+	for _, ahead := range []float64{0, 0.5, 1.0} {
+		center := geo.DestinationPoint(pos, radiusM*ahead, headingDeg)
+		if !left {
+			left = v.sampleAbove(geo.DestinationPoint(center, radiusM, headingDeg-90), wallMin)
+		}
+		if !right {
+			right = v.sampleAbove(geo.DestinationPoint(center, radiusM, headingDeg+90), wallMin)
+		}
+		if left && right {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *ValleyDetector) sampleAbove(p geo.Point, minM float64) bool {
+	elev, err := v.elevation.GetElevation(p.Lat, p.Lon)
+	if err != nil {
+		return false
+	}
+	return float64(elev) >= minM
+}
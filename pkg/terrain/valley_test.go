@@ -0,0 +1,58 @@
+package terrain
+
+import (
+	"errors"
+	"testing"
+)
+
+// funcElevation adapts a function to ElevationGetter.
+type funcElevation func(lat, lon float64) (int16, error)
+
+func (f funcElevation) GetElevation(lat, lon float64) (int16, error) { return f(lat, lon) }
+func (f funcElevation) GetLowestElevation(lat, lon, radiusNM float64) (int16, error) {
+	return f(lat, lon)
+}
+
+func TestValleyDetector_InValley(t *testing.T) {
+	// Flying north along lon 0: terrain west and east of the track as given.
+	walls := func(west, east int16) ElevationGetter {
+		return funcElevation(func(lat, lon float64) (int16, error) {
+			switch {
+			case lon < -0.01:
+				return west, nil
+			case lon > 0.01:
+				return east, nil
+			}
+			return 100, nil
+		})
+	}
+
+	tests := []struct {
+		name   string
+		elev   ElevationGetter
+		altFt  float64
+		radius float64
+		want   bool
+	}{
+		{name: "Canyon walls on both sides", elev: walls(1500, 1400), altFt: 2000, radius: 3, want: true},
+		{name: "Wall on one side only", elev: walls(1500, 200), altFt: 2000, radius: 3, want: false},
+		{name: "Flying above the walls", elev: walls(1500, 1400), altFt: 4000, radius: 3, want: false},
+		{name: "Elevation errors", elev: funcElevation(func(lat, lon float64) (int16, error) { return 0, errors.New("no data") }), altFt: 2000, radius: 3, want: false},
+		{name: "Nil elevation is a no-op", elev: nil, altFt: 2000, radius: 3, want: false},
+		{name: "Zero radius disables sampling", elev: walls(1500, 1400), altFt: 2000, radius: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValleyDetector(tt.elev, tt.radius, 300)
+			if got := v.InValley(45.0, 0.0, 0, tt.altFt); got != tt.want {
+				t.Errorf("InValley() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilDetector *ValleyDetector
+	if nilDetector.InValley(45.0, 0.0, 0, 1000) {
+		t.Error("nil detector must report no valley")
+	}
+}
@@ -0,0 +1,140 @@
+// Package tour holds a curated, ordered list of POIs to narrate in sequence.
+// While a tour is active it replaces score-based selection: the narration job
+// only narrates the current stop, once the aircraft is within reach of it.
+package tour
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+var qidPattern = regexp.MustCompile(`^Q[1-9][0-9]*$`)
+
+// Status is a snapshot of the tour progress.
+type Status struct {
+	Active    bool     `json:"active"`
+	Index     int      `json:"index"` // Zero-based index of the current stop
+	Total     int      `json:"total"`
+	Remaining int      `json:"remaining"` // Stops not yet narrated or skipped, current included
+	Current   string   `json:"current,omitempty"`
+	Stops     []string `json:"stops,omitempty"`
+}
+
+// Tour tracks the stops of a guided tour and the current position in it.
+// The zero value is an inactive tour; all methods are safe for concurrent use.
+type Tour struct {
+	mu    sync.RWMutex
+	stops []string
+	index int
+}
+
+// New creates an inactive tour.
+func New() *Tour {
+	return &Tour{}
+}
+
+// Start replaces any running tour with stops, beginning at the first.
+func (t *Tour) Start(stops []string) error {
+	if len(stops) == 0 {
+		return errors.New("tour needs at least one stop")
+	}
+	for _, qid := range stops {
+		if !qidPattern.MatchString(qid) {
+			return fmt.Errorf("invalid tour stop %q, want a Wikidata QID", qid)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.stops = append([]string(nil), stops...)
+	t.index = 0
+	slog.Info("Tour: Started", "stops", len(stops), "first", stops[0])
+	return nil
+}
+
+// Clear ends the tour; normal selection resumes.
+func (t *Tour) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.stops != nil {
+		slog.Info("Tour: Cleared", "index", t.index, "total", len(t.stops))
+	}
+	t.stops = nil
+	t.index = 0
+}
+
+// Current returns the stop to narrate next. ok is false when no tour is
+// running or the last stop is done.
+func (t *Tour) Current() (qid string, ok bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.index >= len(t.stops) {
+		return "", false
+	}
+	return t.stops[t.index], true
+}
+
+// Complete advances past qid if it is the current stop. Narrations of other
+// POIs, e.g. manual ones, leave the tour where it is.
+func (t *Tour) Complete(qid string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.index < len(t.stops) && t.stops[t.index] == qid {
+		t.advanceLocked()
+	}
+}
+
+// Skip advances to the next stop without narrating the current one.
+func (t *Tour) Skip() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.index < len(t.stops) {
+		slog.Info("Tour: Skipped stop", "qid", t.stops[t.index])
+		t.advanceLocked()
+	}
+}
+
+func (t *Tour) advanceLocked() {
+	t.index++
+	if t.index >= len(t.stops) {
+		slog.Info("Tour: Finished", "stops", len(t.stops))
+		t.stops = nil
+		t.index = 0
+	}
+}
+
+// Status returns the current progress.
+func (t *Tour) Status() Status {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	if t.index >= len(t.stops) {
+		return Status{}
+	}
+	return Status{
+		Active:    true,
+		Index:     t.index,
+		Total:     len(t.stops),
+		Remaining: len(t.stops) - t.index,
+		Current:   t.stops[t.index],
+		Stops:     append([]string(nil), t.stops...),
+	}
+}
+
+// LoadFile reads a YAML list of QIDs, in tour order.
+func LoadFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tour: %w", err)
+	}
+	var stops []string
+	if err := yaml.Unmarshal(data, &stops); err != nil {
+		return nil, fmt.Errorf("failed to parse tour %s: %w", path, err)
+	}
+	return stops, nil
+}
@@ -0,0 +1,61 @@
+package tour
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTour(t *testing.T) {
+	tr := New()
+	if _, ok := tr.Current(); ok {
+		t.Fatal("expected a new tour to be inactive")
+	}
+
+	if err := tr.Start(nil); err == nil {
+		t.Error("expected an error for an empty tour")
+	}
+	if err := tr.Start([]string{"Q1", "Eiffel Tower"}); err == nil {
+		t.Error("expected an error for a stop that isn't a QID")
+	}
+
+	if err := tr.Start([]string{"Q1", "Q2", "Q3"}); err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	tr.Complete("Q3") // Not the current stop
+	if qid, _ := tr.Current(); qid != "Q1" {
+		t.Errorf("expected Q1 to remain current, got %s", qid)
+	}
+
+	tr.Complete("Q1")
+	st := tr.Status()
+	if st.Current != "Q2" || st.Index != 1 || st.Remaining != 2 || st.Total != 3 {
+		t.Errorf("unexpected status after completing Q1: %+v", st)
+	}
+
+	tr.Skip()
+	if qid, _ := tr.Current(); qid != "Q3" {
+		t.Errorf("expected Q3 after a skip, got %s", qid)
+	}
+
+	tr.Complete("Q3")
+	if st := tr.Status(); st.Active {
+		t.Errorf("expected the tour to end after the last stop, got %+v", st)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tour.yaml")
+	if err := os.WriteFile(path, []byte("- Q243 # Eiffel Tower\n- Q90\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	stops, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if len(stops) != 2 || stops[0] != "Q243" || stops[1] != "Q90" {
+		t.Errorf("unexpected stops: %v", stops)
+	}
+}
@@ -1,14 +1,28 @@
 package tracker
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
+	"time"
 )
 
 // Tracker tracks usage statistics per provider.
 type Tracker struct {
 	mu    sync.RWMutex
 	stats map[string]*ProviderStats
+
+	// Latency histograms and named application counters change far less
+	// often than the provider counters, so a plain mutex is enough.
+	extraMu sync.Mutex
+	latency map[string]*Histogram
+	events  map[string]int64
+
+	// Estimated spend since the last session reset, from the unit costs.
+	costPerMTokIn  map[string]float64
+	costPerMTokOut map[string]float64
+	costPerMChar   map[string]float64
+	sessionCost    float64
 }
 
 // ProviderStats holds metrics for a specific provider.
@@ -19,13 +33,38 @@ type ProviderStats struct {
 	APISuccess    int64
 	APIFailures   int64
 	APIZeroResult int64
-	FreeTier      bool
+	// Token usage, for providers that report it
+	PromptTokens     int64
+	CompletionTokens int64
+	// Characters sent for synthesis, for TTS providers
+	Characters int64
+	FreeTier   bool
+}
+
+// Named application events recorded with TrackEvent.
+const (
+	EventTilesCached    = "tiles_cached"    // Tile fetched via SPARQL and written to the cache
+	EventTileCacheHits  = "tile_cache_hits" // Tile served from the cache
+	EventSPARQLFailures = "sparql_failures" // Tile fetch that failed
+)
+
+// LatencyBuckets are the upper bounds, in seconds, of the latency histograms.
+var LatencyBuckets = []float64{0.5, 1, 2, 5, 10, 20, 40, 80}
+
+// Histogram is a latency distribution. Counts holds one non-cumulative count
+// per LatencyBuckets entry plus a final overflow count.
+type Histogram struct {
+	Counts []int64
+	Count  int64
+	Sum    float64 // Seconds
 }
 
 // New creates a new Tracker.
 func New() *Tracker {
 	return &Tracker{
-		stats: make(map[string]*ProviderStats),
+		stats:   make(map[string]*ProviderStats),
+		latency: make(map[string]*Histogram),
+		events:  make(map[string]int64),
 	}
 }
 
@@ -70,6 +109,113 @@ func (t *Tracker) TrackAPIZero(provider string) {
 	atomic.AddInt64(&t.getStats(provider).APIZeroResult, 1)
 }
 
+// TrackTokens adds reported prompt and completion token counts.
+func (t *Tracker) TrackTokens(provider string, prompt, completion int64) {
+	s := t.getStats(provider)
+	atomic.AddInt64(&s.PromptTokens, prompt)
+	atomic.AddInt64(&s.CompletionTokens, completion)
+	t.addCost(&t.costPerMTokIn, provider, prompt)
+	t.addCost(&t.costPerMTokOut, provider, completion)
+}
+
+// TrackCharacters adds the number of characters sent to a TTS provider.
+func (t *Tracker) TrackCharacters(provider string, n int) {
+	atomic.AddInt64(&t.getStats(provider).Characters, int64(n))
+	t.addCost(&t.costPerMChar, provider, int64(n))
+}
+
+// SetCosts sets the unit prices, in USD per million input and output tokens
+// (LLM) and per million characters (TTS), keyed by provider. Unpriced
+// providers are free.
+func (t *Tracker) SetCosts(inPerMTok, outPerMTok, perMChar map[string]float64) {
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	t.costPerMTokIn = inPerMTok
+	t.costPerMTokOut = outPerMTok
+	t.costPerMChar = perMChar
+}
+
+// addCost prices units against a rate table. The table is passed by
+// pointer so it is read under extraMu, like SetCosts writes it.
+func (t *Tracker) addCost(rates *map[string]float64, provider string, units int64) {
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	if rate := (*rates)[provider]; rate > 0 {
+		t.sessionCost += float64(units) * rate / 1e6
+	}
+}
+
+// SessionCost returns the estimated spend in USD since the last session reset.
+func (t *Tracker) SessionCost() float64 {
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	return t.sessionCost
+}
+
+// ResetSession starts a new cost budget. The usage counters are kept; they
+// cover the whole run.
+func (t *Tracker) ResetSession(ctx context.Context) {
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	t.sessionCost = 0
+}
+
+// TrackLatency records the duration of a provider call.
+func (t *Tracker) TrackLatency(provider string, d time.Duration) {
+	sec := d.Seconds()
+	idx := len(LatencyBuckets)
+	for i, le := range LatencyBuckets {
+		if sec <= le {
+			idx = i
+			break
+		}
+	}
+
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	h, ok := t.latency[provider]
+	if !ok {
+		h = &Histogram{Counts: make([]int64, len(LatencyBuckets)+1)}
+		t.latency[provider] = h
+	}
+	h.Counts[idx]++
+	h.Count++
+	h.Sum += sec
+}
+
+// TrackEvent increments a named application counter, e.g. EventTilesCached.
+func (t *Tracker) TrackEvent(name string) {
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	t.events[name]++
+}
+
+// LatencySnapshot returns a copy of the latency histograms.
+func (t *Tracker) LatencySnapshot() map[string]Histogram {
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	result := make(map[string]Histogram, len(t.latency))
+	for k, h := range t.latency {
+		result[k] = Histogram{
+			Counts: append([]int64(nil), h.Counts...),
+			Count:  h.Count,
+			Sum:    h.Sum,
+		}
+	}
+	return result
+}
+
+// Events returns a copy of the named application counters.
+func (t *Tracker) Events() map[string]int64 {
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	result := make(map[string]int64, len(t.events))
+	for k, v := range t.events {
+		result[k] = v
+	}
+	return result
+}
+
 // SetFreeTier sets the free tier status for a provider.
 func (t *Tracker) SetFreeTier(provider string, free bool) {
 	t.getStats(provider).FreeTier = free
@@ -88,7 +234,11 @@ func (t *Tracker) Snapshot() map[string]ProviderStats {
 			APISuccess:    atomic.LoadInt64(&v.APISuccess),
 			APIFailures:   atomic.LoadInt64(&v.APIFailures),
 			APIZeroResult: atomic.LoadInt64(&v.APIZeroResult),
-			FreeTier:      v.FreeTier,
+
+			PromptTokens:     atomic.LoadInt64(&v.PromptTokens),
+			CompletionTokens: atomic.LoadInt64(&v.CompletionTokens),
+			Characters:       atomic.LoadInt64(&v.Characters),
+			FreeTier:         v.FreeTier,
 		}
 	}
 	return result
@@ -105,5 +255,14 @@ func (t *Tracker) Reset() {
 		atomic.StoreInt64(&s.APISuccess, 0)
 		atomic.StoreInt64(&s.APIFailures, 0)
 		atomic.StoreInt64(&s.APIZeroResult, 0)
+		atomic.StoreInt64(&s.PromptTokens, 0)
+		atomic.StoreInt64(&s.CompletionTokens, 0)
+		atomic.StoreInt64(&s.Characters, 0)
 	}
+
+	t.extraMu.Lock()
+	defer t.extraMu.Unlock()
+	t.latency = make(map[string]*Histogram)
+	t.events = make(map[string]int64)
+	t.sessionCost = 0
 }
@@ -1,7 +1,10 @@
 package tracker
 
 import (
+	"context"
+	"math"
 	"testing"
+	"time"
 )
 
 func TestTracker(t *testing.T) {
@@ -79,3 +82,66 @@ func TestResetPreservesFreeTier(t *testing.T) {
 		t.Errorf("Post-Reset: APISuccess should be 0, got %d", s.APISuccess)
 	}
 }
+
+func TestTrackTokens(t *testing.T) {
+	tr := New()
+	tr.TrackTokens("llm", 100, 40)
+	tr.TrackTokens("llm", 50, 10)
+
+	s := tr.Snapshot()["llm"]
+	if s.PromptTokens != 150 || s.CompletionTokens != 50 {
+		t.Errorf("Expected 150/50 tokens, got %d/%d", s.PromptTokens, s.CompletionTokens)
+	}
+
+	tr.Reset()
+	s = tr.Snapshot()["llm"]
+	if s.PromptTokens != 0 || s.CompletionTokens != 0 {
+		t.Errorf("Post-Reset: Expected zero tokens, got %d/%d", s.PromptTokens, s.CompletionTokens)
+	}
+}
+
+func TestTracker_LatencyAndEvents(t *testing.T) {
+	tr := New()
+	tr.TrackLatency("gemini", 300*time.Millisecond)
+	tr.TrackLatency("gemini", 3*time.Second)
+	tr.TrackLatency("gemini", 2*time.Minute)
+	tr.TrackCharacters("edge-tts", 42)
+	tr.TrackEvent("tiles_cached")
+	tr.TrackEvent("tiles_cached")
+
+	h := tr.LatencySnapshot()["gemini"]
+	if h.Count != 3 || h.Counts[0] != 1 || h.Counts[3] != 1 || h.Counts[len(LatencyBuckets)] != 1 {
+		t.Errorf("unexpected histogram: %+v", h)
+	}
+	if got := tr.Snapshot()["edge-tts"].Characters; got != 42 {
+		t.Errorf("expected 42 characters, got %d", got)
+	}
+	if got := tr.Events()["tiles_cached"]; got != 2 {
+		t.Errorf("expected 2 tiles cached, got %d", got)
+	}
+
+	tr.Reset()
+	if len(tr.LatencySnapshot()) != 0 || len(tr.Events()) != 0 {
+		t.Error("Reset should clear latency and events")
+	}
+}
+
+func TestTracker_SessionCost(t *testing.T) {
+	tr := New()
+	tr.SetCosts(map[string]float64{"gemini": 0.5}, map[string]float64{"gemini": 3}, map[string]float64{"azure-speech": 16})
+
+	tr.TrackTokens("gemini", 600000, 400000)  // $0.30 input + $1.20 output
+	tr.TrackCharacters("azure-speech", 50000) // $0.80
+	tr.TrackTokens("groq", 1000000, 0)        // Unpriced
+	if got := tr.SessionCost(); math.Abs(got-2.30) > 1e-9 {
+		t.Errorf("SessionCost() = %v, want 2.30", got)
+	}
+
+	tr.ResetSession(context.Background())
+	if got := tr.SessionCost(); got != 0 {
+		t.Errorf("SessionCost() after reset = %v, want 0", got)
+	}
+	if s := tr.Snapshot()["gemini"]; s.PromptTokens != 600000 {
+		t.Errorf("ResetSession cleared usage counters: %d prompt tokens", s.PromptTokens)
+	}
+}
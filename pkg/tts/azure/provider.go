@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"unicode/utf8"
 
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/tracker"
@@ -43,6 +44,16 @@ func NewProvider(cfg config.AzureSpeechConfig, langProv tts.LanguageProvider, t
 
 // Synthesize generates speech from text using Azure Speech.
 func (p *Provider) Synthesize(ctx context.Context, text, voiceID, outputPath string) (string, error) {
+	return p.synthesize(ctx, text, voiceID, outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+}
+
+// SynthesizeAppend implements tts.AppendProvider. The requested output format is
+// headerless MP3, so consecutive responses can simply be concatenated.
+func (p *Provider) SynthesizeAppend(ctx context.Context, text, voiceID, outputPath string) (string, error) {
+	return p.synthesize(ctx, text, voiceID, outputPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND)
+}
+
+func (p *Provider) synthesize(ctx context.Context, text, voiceID, outputPath string, flag int) (string, error) {
 	// 1. Determine Voice ID
 	vid := p.voiceID
 	if voiceID != "" {
@@ -106,7 +117,7 @@ func (p *Provider) Synthesize(ctx context.Context, text, voiceID, outputPath str
 		filename = filename + "." + ext
 	}
 
-	f, err := os.Create(filename)
+	f, err := os.OpenFile(filename, flag, 0o666)
 	if err != nil {
 		return "", fmt.Errorf("failed to create output file: %w", err)
 	}
@@ -121,6 +132,7 @@ func (p *Provider) Synthesize(ctx context.Context, text, voiceID, outputPath str
 
 	if p.tracker != nil {
 		p.tracker.TrackAPISuccess("azure-speech")
+		p.tracker.TrackCharacters("azure-speech", utf8.RuneCountInString(text))
 	}
 
 	return ext, nil
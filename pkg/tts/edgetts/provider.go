@@ -10,6 +10,7 @@ import (
 	"os"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
@@ -30,6 +31,16 @@ func NewProvider(t *tracker.Tracker) *Provider {
 
 // Synthesize generates an .mp3 file using Edge TTS.
 func (p *Provider) Synthesize(ctx context.Context, text, voice, outputPath string) (string, error) {
+	return p.synthesize(ctx, text, voice, outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC)
+}
+
+// SynthesizeAppend implements tts.AppendProvider. Edge output is plain MP3 frames,
+// which play back correctly when concatenated.
+func (p *Provider) SynthesizeAppend(ctx context.Context, text, voice, outputPath string) (string, error) {
+	return p.synthesize(ctx, text, voice, outputPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND)
+}
+
+func (p *Provider) synthesize(ctx context.Context, text, voice, outputPath string, flag int) (string, error) {
 	if voice == "" {
 		return "", fmt.Errorf("voice ID is required")
 	}
@@ -40,7 +51,7 @@ func (p *Provider) Synthesize(ctx context.Context, text, voice, outputPath strin
 	if !strings.HasSuffix(strings.ToLower(fullPath), ".mp3") {
 		fullPath += ".mp3"
 	}
-	file, err := os.Create(fullPath)
+	file, err := os.OpenFile(fullPath, flag, 0o666)
 	if err != nil {
 		return "", fmt.Errorf("failed to create output file: %w", err)
 	}
@@ -70,6 +81,7 @@ func (p *Provider) Synthesize(ctx context.Context, text, voice, outputPath strin
 
 	if p.tracker != nil {
 		p.tracker.TrackAPISuccess("edge-tts")
+		p.tracker.TrackCharacters("edge-tts", utf8.RuneCountInString(text))
 	}
 	return "mp3", nil
 }
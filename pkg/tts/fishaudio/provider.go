@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/tracker"
@@ -102,6 +103,7 @@ func (p *Provider) executeWithRetry(ctx context.Context, jsonData []byte, text,
 			// Success!
 			if p.tracker != nil {
 				p.tracker.TrackAPISuccess("fish-audio")
+				p.tracker.TrackCharacters("fish-audio", utf8.RuneCountInString(text))
 			}
 			return ext, nil
 		}
@@ -0,0 +1,58 @@
+package tts
+
+import "context"
+
+// Limit wraps p so that at most n syntheses run at once; further calls queue
+// until a slot frees up or their context ends. Some engines throttle or
+// degrade under concurrent requests, which streaming and two-phase narration
+// can otherwise produce. The wrapper keeps p's AppendProvider capability.
+// n <= 0 returns p unchanged.
+func Limit(p Provider, n int) Provider {
+	if n <= 0 {
+		return p
+	}
+	l := &limited{Provider: p, sem: make(chan struct{}, n)}
+	if ap, ok := p.(AppendProvider); ok {
+		return &limitedAppend{limited: l, ap: ap}
+	}
+	return l
+}
+
+type limited struct {
+	Provider
+	sem chan struct{}
+}
+
+func (l *limited) acquire(ctx context.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (l *limited) release() {
+	<-l.sem
+}
+
+func (l *limited) Synthesize(ctx context.Context, text, voice, outputPath string) (string, error) {
+	if err := l.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer l.release()
+	return l.Provider.Synthesize(ctx, text, voice, outputPath)
+}
+
+type limitedAppend struct {
+	*limited
+	ap AppendProvider
+}
+
+func (l *limitedAppend) SynthesizeAppend(ctx context.Context, text, voice, outputPath string) (string, error) {
+	if err := l.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer l.release()
+	return l.ap.SynthesizeAppend(ctx, text, voice, outputPath)
+}
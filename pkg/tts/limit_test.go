@@ -0,0 +1,102 @@
+package tts
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// slowProvider records the peak number of concurrent syntheses.
+type slowProvider struct {
+	running, peak atomic.Int32
+}
+
+func (p *slowProvider) Synthesize(ctx context.Context, text, voice, outputPath string) (string, error) {
+	n := p.running.Add(1)
+	defer p.running.Add(-1)
+	for {
+		peak := p.peak.Load()
+		if n <= peak || p.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return "mp3", nil
+}
+
+func (p *slowProvider) Voices(ctx context.Context) ([]Voice, error) { return nil, nil }
+
+type slowAppendProvider struct {
+	slowProvider
+}
+
+func (p *slowAppendProvider) SynthesizeAppend(ctx context.Context, text, voice, outputPath string) (string, error) {
+	return p.Synthesize(ctx, text, voice, outputPath)
+}
+
+func TestLimit_MaxConcurrent(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		wantPeak int32
+	}{
+		{name: "Serial", limit: 1, wantPeak: 1},
+		{name: "Two at a time", limit: 2, wantPeak: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &slowProvider{}
+			p := Limit(inner, tt.limit)
+
+			var wg sync.WaitGroup
+			for range 6 {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					if _, err := p.Synthesize(context.Background(), "text", "voice", "out.mp3"); err != nil {
+						t.Errorf("Synthesize failed: %v", err)
+					}
+				}()
+			}
+			wg.Wait()
+
+			if got := inner.peak.Load(); got != tt.wantPeak {
+				t.Errorf("peak concurrency = %d, want %d", got, tt.wantPeak)
+			}
+		})
+	}
+}
+
+func TestLimit_Wrapping(t *testing.T) {
+	plain := &slowProvider{}
+	if Limit(plain, 0) != Provider(plain) {
+		t.Error("expected no wrapper without a limit")
+	}
+	if _, ok := Limit(plain, 1).(AppendProvider); ok {
+		t.Error("expected the wrapper not to claim append support the engine lacks")
+	}
+
+	appender := &slowAppendProvider{}
+	ap, ok := Limit(appender, 1).(AppendProvider)
+	if !ok {
+		t.Fatal("expected the wrapper to keep append support")
+	}
+	if _, err := ap.SynthesizeAppend(context.Background(), "text", "voice", "out.mp3"); err != nil {
+		t.Errorf("SynthesizeAppend failed: %v", err)
+	}
+}
+
+func TestLimit_QueuedCallHonoursContext(t *testing.T) {
+	p := Limit(&slowProvider{}, 1)
+	l := p.(*limited)
+	l.sem <- struct{}{} // Occupy the only slot
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := p.Synthesize(ctx, "text", "voice", "out.mp3"); err == nil {
+		t.Error("expected a queued call to give up with its context")
+	}
+}
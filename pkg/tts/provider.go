@@ -28,6 +28,14 @@ type Provider interface {
 	Voices(ctx context.Context) ([]Voice, error)
 }
 
+// AppendProvider is implemented by providers whose output can be concatenated
+// (e.g. raw MP3 frames), so a script can be synthesized chunk by chunk into one file.
+type AppendProvider interface {
+	// SynthesizeAppend generates audio from text and appends it to outputPath,
+	// creating the file if needed. Returns the audio format like Synthesize.
+	SynthesizeAppend(ctx context.Context, text, voice, outputPath string) (string, error)
+}
+
 // Voice represents an available TTS voice.
 type Voice struct {
 	ID       string
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/go-ole/go-ole"
 	"github.com/go-ole/go-ole/oleutil"
@@ -94,6 +95,7 @@ func (p *Provider) Synthesize(ctx context.Context, text, voiceID, outputPath str
 	tts.Log("SAPI", cleanText, 200, nil)
 	if p.tracker != nil {
 		p.tracker.TrackAPISuccess("sapi")
+		p.tracker.TrackCharacters("sapi", utf8.RuneCountInString(cleanText))
 	}
 
 	return "wav", nil
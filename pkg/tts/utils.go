@@ -4,9 +4,19 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 )
 
-var speakerLabelRegex = regexp.MustCompile(`(?m)^[A-Za-z]+(\s*\([^)]+\))?:\s*`)
+var (
+	speakerLabelRegex = regexp.MustCompile(`(?m)^[A-Za-z]+(\s*\([^)]+\))?:\s*`)
+	speakerTurnRegex  = regexp.MustCompile(`^([A-Za-z]+)(?:\s*\([^)]+\))?:\s*`)
+)
+
+// Turn is one speaker's part of a dialogue script.
+type Turn struct {
+	Speaker string // Empty for text before the first label
+	Text    string
+}
 
 // VerifyAudioFile checks if the audio file at path exists and is larger than MinAudioSize.
 func VerifyAudioFile(path string) error {
@@ -29,3 +39,25 @@ func VerifyAudioFile(path string) error {
 func StripSpeakerLabels(script string) string {
 	return speakerLabelRegex.ReplaceAllString(script, "")
 }
+
+// SplitSpeakerTurns splits a script with speaker labels ("Ava: ...") into
+// turns. Unlabeled lines continue the current turn.
+func SplitSpeakerTurns(script string) []Turn {
+	var turns []Turn
+	for _, line := range strings.Split(script, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if m := speakerTurnRegex.FindStringSubmatchIndex(line); m != nil {
+			turns = append(turns, Turn{Speaker: line[m[2]:m[3]], Text: line[m[1]:]})
+			continue
+		}
+		if len(turns) == 0 {
+			turns = append(turns, Turn{})
+		}
+		last := &turns[len(turns)-1]
+		last.Text = strings.TrimSpace(last.Text + " " + line)
+	}
+	return turns
+}
@@ -38,3 +38,48 @@ func TestVerifyAudioFile(t *testing.T) {
 		}
 	})
 }
+
+func TestSplitSpeakerTurns(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []Turn
+	}{
+		{
+			name:   "Alternating speakers",
+			script: "Ava: Look to the left.\nAndrew: Is that the lake?\nAva: It is.",
+			want: []Turn{
+				{Speaker: "Ava", Text: "Look to the left."},
+				{Speaker: "Andrew", Text: "Is that the lake?"},
+				{Speaker: "Ava", Text: "It is."},
+			},
+		},
+		{
+			name:   "Label with annotation and continuation lines",
+			script: "Ava (guide): First line.\n\nSecond line.\nAndrew: Wow.",
+			want: []Turn{
+				{Speaker: "Ava", Text: "First line. Second line."},
+				{Speaker: "Andrew", Text: "Wow."},
+			},
+		},
+		{
+			name:   "Unlabeled script",
+			script: "Just a plain narration.",
+			want:   []Turn{{Text: "Just a plain narration."}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitSpeakerTurns(tt.script)
+			if len(got) != len(tt.want) {
+				t.Fatalf("expected %d turns, got %d: %+v", len(tt.want), len(got), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("turn %d: expected %+v, got %+v", i, tt.want[i], got[i])
+				}
+			}
+		})
+	}
+}
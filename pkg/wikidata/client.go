@@ -5,9 +5,11 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
 	"net/url"
 	"sort"
 	"strconv"
@@ -76,14 +78,44 @@ func (c *Client) QuerySPARQL(ctx context.Context, query, cacheKey string, radius
 	logging.Trace(c.Logger, "SPARQL Query Completed", "duration", duration, "cached", err == nil && len(body) > 0)
 
 	if err != nil {
+		if isQueryTimeout(err) {
+			return nil, "", fmt.Errorf("%w: %v", ErrTimeout, err)
+		}
 		return nil, "", fmt.Errorf("%w: %v", ErrNetwork, err)
 	}
 
 	// Parse Response (Zero-Alloc Streaming)
 	articles, _, err := ParseSPARQLStreaming(strings.NewReader(string(body)))
+	if err != nil && strings.Contains(string(body), "TimeoutException") {
+		// WDQS may send a 200, stream part of the results and then append
+		// the exception once its time is up
+		return nil, "", fmt.Errorf("%w: truncated result: %v", ErrTimeout, err)
+	}
 	return articles, string(body), err
 }
 
+// isQueryTimeout reports whether a failed request looks like the query
+// service running out of time. WDQS reports query timeouts as a 500 carrying
+// the Java exception, or the gateway answers 504 first. Any other 500 is a
+// server fault, and splitting the tile would not help.
+func isQueryTimeout(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var se *request.StatusError
+	if !errors.As(err, &se) {
+		return false
+	}
+	switch se.Code {
+	case 504:
+		return true
+	case 500:
+		return strings.Contains(se.Body, "TimeoutException")
+	}
+	return false
+}
+
 // QueryEntities fetches specific entities by their QIDs using the same unified schema as tile queries.
 func (c *Client) QueryEntities(ctx context.Context, ids []string) ([]Article, string, error) {
 	if len(ids) == 0 {
@@ -96,6 +128,7 @@ func (c *Client) QueryEntities(ctx context.Context, ids []string) ([]Article, st
 	}
 	valuesClause := strings.Join(builders, " ")
 
+	// Dimensions are normalized to m and m², as in buildCheapQuery
 	query := fmt.Sprintf(`SELECT DISTINCT ?item ?lat ?lon ?sitelinks 
             (GROUP_CONCAT(DISTINCT ?instance_of_uri; separator=",") AS ?instances) 
             ?area ?height ?length ?width
@@ -105,10 +138,10 @@ func (c *Client) QueryEntities(ctx context.Context, ids []string) ([]Article, st
             
             OPTIONAL { ?item wdt:P31 ?instance_of_uri . } 
             OPTIONAL { ?item wikibase:sitelinks ?sitelinks . } 
-            OPTIONAL { ?item wdt:P2046 ?area . }
-            OPTIONAL { ?item wdt:P2048 ?height . }
-            OPTIONAL { ?item wdt:P2043 ?length . }
-            OPTIONAL { ?item wdt:P2049 ?width . }
+            OPTIONAL { ?item p:P2046 ?areaSt . ?areaSt a wikibase:BestRank ; psn:P2046/wikibase:quantityAmount ?area . }
+            OPTIONAL { ?item p:P2048 ?heightSt . ?heightSt a wikibase:BestRank ; psn:P2048/wikibase:quantityAmount ?height . }
+            OPTIONAL { ?item p:P2043 ?lengthSt . ?lengthSt a wikibase:BestRank ; psn:P2043/wikibase:quantityAmount ?length . }
+            OPTIONAL { ?item p:P2049 ?widthSt . ?widthSt a wikibase:BestRank ; psn:P2049/wikibase:quantityAmount ?width . }
             
             FILTER(?sitelinks > 0)
         } 
@@ -127,37 +160,16 @@ func (c *Client) QueryEntities(ctx context.Context, ids []string) ([]Article, st
 func ParseSPARQLStreaming(r io.Reader) ([]Article, string, error) {
 	dec := json.NewDecoder(r)
 
-	// We only care about matching "bindings" -> [ ... ]
-	// Structure: { ..., "results": { "bindings": [ ... ] } }
-
-	// Fast-forward to "bindings"
-	foundBindings := false
-	for {
-		t, err := dec.Token()
-		if err == io.EOF {
-			break
-		}
-		if err != nil {
-			return nil, "", fmt.Errorf("json stream error: %w", err)
-		}
-
-		if s, ok := t.(string); ok && s == "bindings" {
-			foundBindings = true
-			break
-		}
+	foundBindings, err := seekBindings(dec)
+	if err != nil {
+		return nil, "", err
 	}
-
 	if !foundBindings {
 		// Valid SPARQL response might have empty results, but "bindings" key usually exists.
 		// If not found, return empty.
 		return []Article{}, "", nil
 	}
 
-	// Consume open bracket '['
-	if _, err := dec.Token(); err != nil {
-		return nil, "", fmt.Errorf("expected array open: %w", err)
-	}
-
 	// Stream bindings
 	var articles []Article
 	seen := make(map[string]bool)
@@ -209,6 +221,51 @@ func ParseSPARQLStreaming(r io.Reader) ([]Article, string, error) {
 	return articles, "", nil
 }
 
+// CountSPARQLBindings counts the result rows of a SPARQL response without
+// decoding them. Tile queries group by item, so this is the article count.
+func CountSPARQLBindings(r io.Reader) (int, error) {
+	dec := json.NewDecoder(r)
+
+	found, err := seekBindings(dec)
+	if err != nil || !found {
+		return 0, err
+	}
+
+	count := 0
+	for dec.More() {
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return count, fmt.Errorf("failed to skip binding: %w", err)
+		}
+		count++
+	}
+	return count, nil
+}
+
+// seekBindings fast-forwards the decoder into the "bindings" array.
+// Structure: { ..., "results": { "bindings": [ ... ] } }
+func seekBindings(dec *json.Decoder) (bool, error) {
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("json stream error: %w", err)
+		}
+
+		if s, ok := t.(string); ok && s == "bindings" {
+			break
+		}
+	}
+
+	// Consume open bracket '['
+	if _, err := dec.Token(); err != nil {
+		return false, fmt.Errorf("expected array open: %w", err)
+	}
+	return true, nil
+}
+
 // GetEntityClaims fetches specific property claims (e.g. P31, P279) for an entity.
 // It returns a list of target QIDs and the English label of the entity.
 func (c *Client) GetEntityClaims(ctx context.Context, id, property string) (targets []string, label string, err error) {
@@ -609,3 +666,11 @@ func parseFloatPtr(s string) *float64 {
 	}
 	return &f
 }
+
+// deref reads an optional dimension, 0 where Wikidata has none.
+func deref(f *float64) float64 {
+	if f == nil {
+		return 0
+	}
+	return *f
+}
@@ -2,6 +2,7 @@ package wikidata
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -210,14 +211,82 @@ func TestGetEntityClaims(t *testing.T) {
 	}
 }
 
+func TestGetEntityFacts(t *testing.T) {
+	claimsResp := `{"entities": {"Q243": {"claims": {
+		"P571": [{"rank": "normal", "mainsnak": {"datavalue": {"type": "time", "value": {"time": "+1887-01-28T00:00:00Z", "precision": 11}}}}],
+		"P2048": [{"rank": "normal", "mainsnak": {"datavalue": {"type": "quantity", "value": {"amount": "+330", "unit": "http://www.wikidata.org/entity/Q11573"}}}}],
+		"P84": [
+			{"rank": "preferred", "mainsnak": {"datavalue": {"type": "wikibase-entityid", "value": {"id": "Q778243"}}}},
+			{"rank": "normal", "mainsnak": {"datavalue": {"type": "wikibase-entityid", "value": {"id": "Q1"}}}}
+		],
+		"P625": [{"rank": "normal", "mainsnak": {"datavalue": {"type": "globecoordinate", "value": {"latitude": 48.8}}}}]
+	}}}}`
+	labelsResp := `{"entities": {
+		"P571": {"labels": {"en": {"value": "inception"}}},
+		"P2048": {"labels": {"en": {"value": "height"}}},
+		"P84": {"labels": {"en": {"value": "architect"}}},
+		"Q11573": {"labels": {"en": {"value": "metre"}}},
+		"Q778243": {"labels": {"en": {"value": "Stephen Sauvestre"}}}
+	}}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("props") == "claims" {
+			fmt.Fprint(w, claimsResp)
+			return
+		}
+		fmt.Fprint(w, labelsResp)
+	}))
+	defer server.Close()
+
+	reqClient := request.New(&mockCache{}, tracker.New(), request.ClientConfig{Retries: 0})
+	client := NewClient(reqClient, slog.Default())
+	client.APIEndpoint = server.URL + "/w/api.php"
+
+	facts, err := client.GetEntityFacts(context.Background(), "Q243", []string{"P571", "P2048", "P84", "P625", "P1082"})
+	if err != nil {
+		t.Fatalf("GetEntityFacts failed: %v", err)
+	}
+
+	want := []Fact{
+		{Property: "P571", Label: "inception", Values: []string{"28 January 1887"}},
+		{Property: "P2048", Label: "height", Values: []string{"330 metre"}},
+		{Property: "P84", Label: "architect", Values: []string{"Stephen Sauvestre"}},
+	}
+	if fmt.Sprint(facts) != fmt.Sprint(want) {
+		t.Errorf("facts = %v, want %v", facts, want)
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	tests := []struct {
+		time      string
+		precision int
+		want      string
+	}{
+		{"+1887-01-28T00:00:00Z", 11, "28 January 1887"},
+		{"+1887-03-00T00:00:00Z", 10, "March 1887"},
+		{"+1887-00-00T00:00:00Z", 9, "1887"},
+		{"+1887-00-00T00:00:00Z", 8, "1880s"},
+		{"+1200-00-00T00:00:00Z", 7, "around 1200"},
+		{"-0500-00-00T00:00:00Z", 9, "500 BC"},
+		{"garbage", 9, ""},
+	}
+	for _, tt := range tests {
+		if got := formatTime(tt.time, tt.precision); got != tt.want {
+			t.Errorf("formatTime(%q, %d) = %q, want %q", tt.time, tt.precision, got, tt.want)
+		}
+	}
+}
+
 func TestQuerySPARQL(t *testing.T) {
 	tests := []struct {
-		name       string
-		mockResp   string
-		mockStatus int
-		mockErr    bool
-		wantErr    bool
-		wantCount  int
+		name        string
+		mockResp    string
+		mockStatus  int
+		mockErr     bool
+		wantErr     bool
+		wantTimeout bool
+		wantCount   int
 	}{
 		{
 			name: "Success",
@@ -247,6 +316,30 @@ func TestQuerySPARQL(t *testing.T) {
 			wantErr:    true,
 			wantCount:  0,
 		},
+		{
+			name:        "Query timeout (500)",
+			mockResp:    "SPARQL-QUERY: queryStr=SELECT\njava.util.concurrent.TimeoutException\n\tat java.util.concurrent.FutureTask.get",
+			mockStatus:  http.StatusInternalServerError,
+			wantErr:     true,
+			wantTimeout: true,
+			wantCount:   0,
+		},
+		{
+			name:        "Gateway timeout (504)",
+			mockResp:    "",
+			mockStatus:  http.StatusGatewayTimeout,
+			wantErr:     true,
+			wantTimeout: true,
+			wantCount:   0,
+		},
+		{
+			name:        "Truncated by timeout",
+			mockResp:    `{"results":{"bindings":[{"item":{"value":"http://www.wikidata.org/entity/Q1"}` + "\njava.util.concurrent.TimeoutException",
+			mockStatus:  http.StatusOK,
+			wantErr:     true,
+			wantTimeout: true,
+			wantCount:   0,
+		},
 		{
 			name:       "Malformed JSON",
 			mockResp:   "{invalid json}",
@@ -282,6 +375,9 @@ func TestQuerySPARQL(t *testing.T) {
 			if (err != nil) != tt.wantErr {
 				t.Fatalf("QuerySPARQL() error = %v, wantErr %v", err, tt.wantErr)
 			}
+			if errors.Is(err, ErrTimeout) != tt.wantTimeout {
+				t.Errorf("QuerySPARQL() error = %v, wantTimeout %v", err, tt.wantTimeout)
+			}
 			if !tt.wantErr {
 				if len(articles) != tt.wantCount {
 					t.Fatalf("Expected %d articles, got %d", tt.wantCount, len(articles))
@@ -9,6 +9,9 @@ var (
 	ErrParse = errors.New("wikidata parse error")
 	// ErrInvalidQuery indicates the generated SPARQL query was invalid.
 	ErrInvalidQuery = errors.New("wikidata invalid query")
+	// ErrTimeout indicates the query service gave up on a query, either with
+	// an error status or by cutting the result stream short.
+	ErrTimeout = errors.New("wikidata query timeout")
 	// ErrNotFound indicates the requested entity was not found.
 	ErrNotFound = errors.New("wikidata entity not found")
 )
@@ -0,0 +1,220 @@
+package wikidata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Fact is one property of an entity with its values as readable text.
+type Fact struct {
+	Property string   `json:"property"`
+	Label    string   `json:"label"`
+	Values   []string `json:"values"`
+}
+
+// maxFactValues keeps properties with a long history, like population, from
+// flooding the prompt.
+const maxFactValues = 3
+
+// factsResponse is wrapperEntityResponse with claim ranks, which decide
+// which values count.
+type factsResponse struct {
+	Entities map[string]struct {
+		Claims map[string][]struct {
+			Mainsnak map[string]interface{} `json:"mainsnak"`
+			Rank     string                 `json:"rank"`
+		} `json:"claims"`
+	} `json:"entities"`
+}
+
+// factValue is a claim value before item and unit labels are known.
+type factValue struct {
+	text string // Formatted literal, or the amount of a quantity
+	ref  string // Item value or quantity unit, resolved to its label
+}
+
+// GetEntityFacts fetches the given properties of an entity as readable facts,
+// in the order requested. Unlike GetEntityClaims it reads dates, quantities
+// and strings too, and names item values and units by their English labels.
+// Properties without a usable value are left out.
+func (c *Client) GetEntityFacts(ctx context.Context, id string, props []string) ([]Fact, error) {
+	u, _ := url.Parse(c.APIEndpoint)
+	q := u.Query()
+	q.Add("action", "wbgetentities")
+	q.Add("format", "json")
+	q.Add("ids", id)
+	q.Add("props", "claims")
+	u.RawQuery = q.Encode()
+
+	body, err := c.request.Get(ctx, u.String(), "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNetwork, err)
+	}
+
+	var result factsResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("%w: failed to decode json: %v", ErrParse, err)
+	}
+	ent, ok := result.Entities[id]
+	if !ok {
+		return nil, fmt.Errorf("entity %s not found in response", id)
+	}
+
+	values := make(map[string][]factValue)
+	var refs []string
+	for _, prop := range props {
+		claims := ent.Claims[prop]
+		// A preferred value, like the current population, supersedes the rest
+		preferred := false
+		for _, cl := range claims {
+			if cl.Rank == "preferred" {
+				preferred = true
+				break
+			}
+		}
+		for _, cl := range claims {
+			if cl.Rank == "deprecated" || (preferred && cl.Rank != "preferred") {
+				continue
+			}
+			v, ok := parseFactValue(cl.Mainsnak)
+			if !ok {
+				continue
+			}
+			values[prop] = append(values[prop], v)
+			if v.ref != "" {
+				refs = append(refs, v.ref)
+			}
+			if len(values[prop]) == maxFactValues {
+				break
+			}
+		}
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	// Properties are entities too, so one lookup names them and the values
+	for prop := range values {
+		refs = append(refs, prop)
+	}
+	meta, err := c.GetEntitiesBatch(ctx, refs)
+	if err != nil {
+		return nil, err
+	}
+
+	var facts []Fact
+	for _, prop := range props {
+		f := Fact{Property: prop, Label: meta[prop].Labels["en"]}
+		if f.Label == "" {
+			f.Label = prop
+		}
+		for _, v := range values[prop] {
+			if s := v.format(meta); s != "" {
+				f.Values = append(f.Values, s)
+			}
+		}
+		if len(f.Values) > 0 {
+			facts = append(facts, f)
+		}
+	}
+	return facts, nil
+}
+
+// format renders the value, dropping item values without an English label
+// rather than quoting a bare QID.
+func (v factValue) format(meta map[string]EntityMetadata) string {
+	label := meta[v.ref].Labels["en"]
+	switch {
+	case v.ref == "":
+		return v.text
+	case v.text == "":
+		return label
+	case label == "":
+		return v.text
+	default:
+		return v.text + " " + label
+	}
+}
+
+// parseFactValue reads the main value of a claim. Coordinates and other
+// types without a natural reading are skipped.
+func parseFactValue(mainsnak map[string]interface{}) (factValue, bool) {
+	dv, ok := mainsnak["datavalue"].(map[string]interface{})
+	if !ok {
+		return factValue{}, false
+	}
+	typ, _ := dv["type"].(string)
+	if s, ok := dv["value"].(string); ok && typ == "string" {
+		return factValue{text: s}, s != ""
+	}
+	val, ok := dv["value"].(map[string]interface{})
+	if !ok {
+		return factValue{}, false
+	}
+
+	switch typ {
+	case "wikibase-entityid":
+		id, _ := val["id"].(string)
+		return factValue{ref: id}, id != ""
+	case "time":
+		t, _ := val["time"].(string)
+		precision, _ := val["precision"].(float64)
+		s := formatTime(t, int(precision))
+		return factValue{text: s}, s != ""
+	case "quantity":
+		amount, _ := val["amount"].(string)
+		amount = strings.TrimPrefix(amount, "+")
+		if amount == "" {
+			return factValue{}, false
+		}
+		v := factValue{text: amount}
+		// Unitless quantities carry the unit "1"
+		if unit, _ := val["unit"].(string); strings.Contains(unit, "/entity/") {
+			v.ref = unit[strings.LastIndex(unit, "/")+1:]
+		}
+		return v, true
+	case "monolingualtext":
+		text, _ := val["text"].(string)
+		return factValue{text: text}, text != ""
+	}
+	return factValue{}, false
+}
+
+// formatTime renders a Wikidata timestamp ("+1742-03-02T00:00:00Z") to the
+// precision it was recorded with: 11 day, 10 month, 9 year, 8 decade.
+func formatTime(t string, precision int) string {
+	if len(t) < 2 {
+		return ""
+	}
+	bc := t[0] == '-'
+	parts := strings.SplitN(strings.SplitN(t[1:], "T", 2)[0], "-", 3)
+	year, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts) < 3 {
+		return ""
+	}
+	month, _ := strconv.Atoi(parts[1])
+	day, _ := strconv.Atoi(parts[2])
+
+	var s string
+	switch {
+	case precision >= 11 && day > 0 && month > 0:
+		s = fmt.Sprintf("%d %s %d", day, time.Month(month), year)
+	case precision == 10 && month > 0:
+		s = fmt.Sprintf("%s %d", time.Month(month), year)
+	case precision == 8:
+		s = fmt.Sprintf("%ds", year/10*10)
+	case precision < 8:
+		s = fmt.Sprintf("around %d", year)
+	default:
+		s = strconv.Itoa(year)
+	}
+	if bc {
+		s += " BC"
+	}
+	return s
+}
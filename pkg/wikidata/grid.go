@@ -10,20 +10,66 @@ import (
 const (
 	// H3 Resolution 6
 	h3Resolution = 6
+
+	// Resolutions a configured tile radius may select: res 4 cells outgrow
+	// the 10 km SPARQL radius cap, res 9 cells would take a query each for
+	// a handful of articles.
+	minTileResolution = 5
+	maxTileResolution = 8
 )
 
 // Grid handles H3 grid calculations.
-type Grid struct{}
+type Grid struct {
+	res int
+}
 
-// NewGrid creates a new Grid instance.
+// NewGrid creates a new Grid instance at the default resolution.
 func NewGrid() *Grid {
-	return &Grid{}
+	return &Grid{res: h3Resolution}
+}
+
+// NewGridForRadius creates a grid whose tiles come closest to radiusKm from
+// center to corner. H3 only offers fixed sizes, so the radius picks a
+// resolution; 0 keeps the default. Cache keys carry the H3 index, which
+// encodes the resolution, so tiles cached at another size never match and
+// are fetched afresh rather than misread.
+func NewGridForRadius(radiusKm float64) *Grid {
+	if radiusKm <= 0 {
+		return NewGrid()
+	}
+	best, bestDiff := h3Resolution, math.MaxFloat64
+	for res := minTileResolution; res <= maxTileResolution; res++ {
+		// A hexagon's circumradius equals its edge length
+		edge, err := h3.HexagonEdgeLengthAvgKm(res)
+		if err != nil {
+			continue
+		}
+		if diff := math.Abs(edge - radiusKm); diff < bestDiff {
+			best, bestDiff = res, diff
+		}
+	}
+	return &Grid{res: best}
+}
+
+// Resolution returns the H3 resolution of the grid's tiles.
+func (g *Grid) Resolution() int {
+	return g.res
+}
+
+// SpacingKm returns the approximate distance between neighbouring tile
+// centers.
+func (g *Grid) SpacingKm() float64 {
+	edge, err := h3.HexagonEdgeLengthAvgKm(g.res)
+	if err != nil {
+		return 0
+	}
+	return edge * math.Sqrt(3)
 }
 
 // TileAt returns the H3 cell for the given coordinate.
 func (g *Grid) TileAt(lat, lon float64) HexTile {
 	ll := h3.NewLatLng(lat, lon)
-	cell, err := h3.LatLngToCell(ll, h3Resolution)
+	cell, err := h3.LatLngToCell(ll, g.res)
 	if err != nil {
 		return HexTile{} // Handle error gracefully (empty index)
 	}
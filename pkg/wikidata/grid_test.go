@@ -1,7 +1,9 @@
 package wikidata
 
 import (
+	"fmt"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -121,6 +123,65 @@ func TestGrid_TileRadius(t *testing.T) {
 	}
 }
 
+func TestGrid_ConfiguredTileRadius(t *testing.T) {
+	tests := []struct {
+		name     string
+		radiusKm float64
+		wantRes  int
+	}{
+		{"Default", 0, 6},
+		{"Sparse countryside", 8.5, 5},
+		{"Dense city", 1.2, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sched := NewScheduler(100)
+			sched.SetTileRadius(tt.radiusKm)
+			g := sched.grid
+			if g.Resolution() != tt.wantRes {
+				t.Fatalf("Resolution() = %d, want %d", g.Resolution(), tt.wantRes)
+			}
+
+			want := tt.radiusKm
+			if want == 0 {
+				want = 3.2
+			}
+			tile := g.TileAt(48.1, 11.6)
+			if tile.Index == "" {
+				t.Fatal("expected a tile")
+			}
+
+			// The query covers the tile at the configured size
+			s := &Service{scheduler: sched}
+			radiusM := s.queryRadiusMeters(tile)
+			if got := float64(radiusM) / 1000; got < want*0.8 || got > want*1.4 {
+				t.Errorf("query radius = %.2f km, want ~%.1f km", got, want)
+			}
+			query := buildCheapQuery(48.1, 11.6, fmt.Sprintf("%.3f", float64(radiusM)/1000), 0)
+			if !strings.Contains(query, fmt.Sprintf(`wikibase:radius "%.3f"`, float64(radiusM)/1000)) {
+				t.Errorf("query does not use the tile radius: %s", query)
+			}
+
+			// Neighbouring tiles sit about sqrt(3) radii apart
+			spacing := g.SpacingKm()
+			if spacing < want*1.4 || spacing > want*2.1 {
+				t.Errorf("SpacingKm() = %.2f, want ~%.1f", spacing, want*math.Sqrt(3))
+			}
+			n := g.Neighbors(tile)[0]
+			cLat, cLon := g.TileCenter(tile)
+			nLat, nLon := g.TileCenter(n)
+			if d := DistKm(cLat, cLon, nLat, nLon); math.Abs(d-spacing)/spacing > 0.25 {
+				t.Errorf("neighbour distance %.2f km, SpacingKm() %.2f km", d, spacing)
+			}
+			// Tiles of another size never share a cache key with the default ones
+			if def := NewGrid().TileAt(48.1, 11.6); (def.Key() == tile.Key()) != (tt.wantRes == h3Resolution) {
+				t.Errorf("key %q vs default %q", tile.Key(), def.Key())
+			}
+		})
+	}
+}
+
 func TestGrid_TileCorners(t *testing.T) {
 	g := NewGrid()
 
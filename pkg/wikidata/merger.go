@@ -10,33 +10,39 @@ import (
 	"phileasgo/pkg/model"
 )
 
-// MergePOIs groups spatially close POIs and selects the best candidate based on Article Length.
+// MergePOIs groups spatially close POIs and selects the best candidate based on Category Weight and Article Length.
 // It returns the accepted POIs and a list of QIDs that were rejected (merged away).
 func MergePOIs(candidates []*model.POI, cfg *config.CategoriesConfig, logger *slog.Logger) (accepted []*model.POI, rejected []string) {
 	if len(candidates) == 0 {
 		return nil, nil
 	}
 
-	// 1. Sort Candidates by "Quality" (Length Descending)
+	// 1. Sort Candidates by "Quality" (Weight, then Length Descending)
 	// This ensures we always process the "best" POIs first and let them "gobble" smaller ones.
+	// Category weight comes first so that, when different categories overlap,
+	// the higher-weight category wins the merge regardless of article length.
 	sort.Slice(candidates, func(i, j int) bool {
-		// Priority 1: Article Length
+		// Priority 1: Category Weight
+		wi, wj := cfg.GetWeight(candidates[i].Category), cfg.GetWeight(candidates[j].Category)
+		if wi != wj {
+			return wi > wj
+		}
+		// Priority 2: Article Length
 		if candidates[i].WPArticleLength != candidates[j].WPArticleLength {
 			return candidates[i].WPArticleLength > candidates[j].WPArticleLength
 		}
-		// Priority 2: Sitelinks
+		// Priority 3: Sitelinks
 		if candidates[i].Sitelinks != candidates[j].Sitelinks {
 			return candidates[i].Sitelinks > candidates[j].Sitelinks
 		}
-		// Priority 3: Stability (QID)
+		// Priority 4: Stability (QID)
 		return candidates[i].WikidataID < candidates[j].WikidataID
 	})
 
 	// 2. Greedy Selection
 	for _, cand := range candidates {
 		// Determine Merge Distance for this Candidate
-		candSize := cfg.GetSize(cand.Category)
-		candRadius := cfg.GetMergeDistance(candSize)
+		candRadius := cfg.GetMergeRadius(cand.Category)
 		candGroup := cfg.GetGroup(cand.Category)
 
 		isDuplicate := false
@@ -50,8 +56,12 @@ func MergePOIs(candidates []*model.POI, cfg *config.CategoriesConfig, logger *sl
 			}
 
 			// Determine Merge Distance for Accepted POI
-			accSize := cfg.GetSize(acc.Category)
-			accRadius := cfg.GetMergeDistance(accSize)
+			accRadius := cfg.GetMergeRadius(acc.Category)
+
+			// A radius of 0 means "never merge": such POIs neither gobble nor get gobbled.
+			if candRadius <= 0 || accRadius <= 0 {
+				continue
+			}
 
 			// Effective Merge Distance is the MAX of the two radii
 			// Rationale: A Large item (Airport) should gobble a Small item (Terminal) even if the Small item has a small radius.
@@ -100,8 +110,7 @@ func MergeArticles(candidates []Article, cfg *config.CategoriesConfig, logger *s
 	for i := range candidates {
 		cand := &candidates[i]
 		// Determine Merge Distance
-		candSize := cfg.GetSize(cand.Category)
-		candRadius := cfg.GetMergeDistance(candSize)
+		candRadius := cfg.GetMergeRadius(cand.Category)
 		candGroup := cfg.GetGroup(cand.Category)
 
 		isDuplicate := false
@@ -109,8 +118,7 @@ func MergeArticles(candidates []Article, cfg *config.CategoriesConfig, logger *s
 		for j := range accepted {
 			acc := &accepted[j]
 			// Determine Merge Distance for Accepted Item
-			accSize := cfg.GetSize(acc.Category)
-			accRadius := cfg.GetMergeDistance(accSize)
+			accRadius := cfg.GetMergeRadius(acc.Category)
 
 			// 2a. Group Isolation Check: Never merge across different category groups
 			accGroup := cfg.GetGroup(acc.Category)
@@ -118,7 +126,10 @@ func MergeArticles(candidates []Article, cfg *config.CategoriesConfig, logger *s
 				continue // distinct items, skip merge check against this 'acc'
 			}
 
-			// 2b. Spatial Check
+			// 2b. Spatial Check (radius 0 = never merge)
+			if candRadius <= 0 || accRadius <= 0 {
+				continue
+			}
 			mergeDist := math.Max(candRadius, accRadius)
 			distMeters := geo.Distance(
 				geo.Point{Lat: cand.Lat, Lon: cand.Lon},
@@ -117,6 +117,87 @@ func TestMergePOIs(t *testing.T) {
 	}
 }
 
+func TestMergePOIs_CategoryRadius(t *testing.T) {
+	radius := func(km float64) *float64 { return &km }
+
+	cfg := &config.CategoriesConfig{
+		Categories: map[string]config.Category{
+			"peak":     {Size: "XL", MergeRadiusKm: radius(0.2)},
+			"district": {Size: "S", MergeRadiusKm: radius(5)},
+			"castle":   {Size: "M", Weight: 1.2},
+			"church":   {Size: "M", Weight: 0.9},
+			"volcano":  {Size: "XL", MergeRadiusKm: radius(0)},
+		},
+		MergeDistance: map[string]float64{
+			"S":  100,
+			"M":  1500,
+			"XL": 5000,
+		},
+		GroupLookup: map[string]string{},
+	}
+
+	logger := slog.Default()
+
+	tests := []struct {
+		name       string
+		candidates []*model.POI
+		expected   []string
+	}{
+		{
+			name: "Same Category - Tiny Radius Keeps Peaks Distinct",
+			// XL size would merge at 5km, but the per-category 200m radius wins.
+			candidates: []*model.POI{
+				{WikidataID: "Peak1", Category: "peak", Lat: 0, Lon: 0, WPArticleLength: 1000},
+				{WikidataID: "Peak2", Category: "peak", Lat: 0.01, Lon: 0, WPArticleLength: 500}, // ~1.1km
+			},
+			expected: []string{"Peak1", "Peak2"},
+		},
+		{
+			name: "Same Category - Large Radius Merges Districts",
+			// S size would only merge at 100m, but the per-category 5km radius wins.
+			candidates: []*model.POI{
+				{WikidataID: "D1", Category: "district", Lat: 0, Lon: 0, WPArticleLength: 1000},
+				{WikidataID: "D2", Category: "district", Lat: 0.02, Lon: 0, WPArticleLength: 500}, // ~2.2km
+			},
+			expected: []string{"D1"},
+		},
+		{
+			name: "Cross Category - Higher Weight Wins Despite Shorter Article",
+			candidates: []*model.POI{
+				{WikidataID: "Church", Category: "church", Lat: 0, Lon: 0, WPArticleLength: 5000},
+				{WikidataID: "Castle", Category: "castle", Lat: 0.005, Lon: 0, WPArticleLength: 1000}, // ~550m
+			},
+			expected: []string{"Castle"},
+		},
+		{
+			name: "Radius Zero - Never Merge",
+			// The volcano never merges, even with an overlapping large-radius category.
+			candidates: []*model.POI{
+				{WikidataID: "Volcano", Category: "volcano", Lat: 0, Lon: 0, WPArticleLength: 1000},
+				{WikidataID: "Volcano2", Category: "volcano", Lat: 0, Lon: 0, WPArticleLength: 500},
+				{WikidataID: "District", Category: "district", Lat: 0.001, Lon: 0, WPArticleLength: 100},
+			},
+			expected: []string{"District", "Volcano", "Volcano2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, _ := MergePOIs(tt.candidates, cfg, logger)
+			var gotQIDs []string
+			for _, p := range result {
+				gotQIDs = append(gotQIDs, p.WikidataID)
+			}
+			sort.Strings(tt.expected)
+			sort.Strings(gotQIDs)
+
+			if !reflect.DeepEqual(tt.expected, gotQIDs) {
+				t.Errorf("Expected %v, got %v", tt.expected, gotQIDs)
+			}
+		})
+	}
+}
+
 func TestMergeArticles(t *testing.T) {
 	// Setup Config with Groups and Sizes
 	cfg := &config.CategoriesConfig{
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"phileasgo/pkg/config"
 	"phileasgo/pkg/model"
+	"phileasgo/pkg/request"
 )
 
 func (p *Pipeline) enrichAndSave(ctx context.Context, articles []Article, localLangs []string, userLang string) error {
@@ -16,8 +18,10 @@ func (p *Pipeline) enrichAndSave(ctx context.Context, articles []Article, localL
 	var candidates []*model.POI
 	var rejectedQIDs []string
 
+	iconSet := p.cfgProv.IconSet(ctx)
+	iconGetter := func(category string) string { return p.getIcon(category, iconSet) }
 	for i := range articles {
-		if poi := p.constructPOI(&articles[i], lengths, localLangs, userLang, p.getIcon); poi != nil {
+		if poi := p.constructPOI(&articles[i], lengths, localLangs, userLang, iconGetter); poi != nil {
 			candidates = append(candidates, poi)
 		} else {
 			rejectedQIDs = append(rejectedQIDs, articles[i].QID)
@@ -67,18 +71,38 @@ func (p *Pipeline) fetchArticleLengths(ctx context.Context, articles []Article,
 		}
 	}
 
-	lengths := make(map[string]map[string]int)
+	// One batch per language. They all go through the Wikipedia queue one
+	// request at a time, so the timeout is a send timeout: it starts when the
+	// request leaves the queue, not while it waits behind the other languages.
+	// A failed or timed-out language only loses its own lengths and the POIs
+	// are built from whatever else returned.
+	if timeout := time.Duration(p.cfgProv.AppConfig().Wikidata.ArticleLength.Timeout); timeout > 0 {
+		ctx = context.WithValue(ctx, request.CtxSendTimeout, timeout)
+	}
+
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		lengths = make(map[string]map[string]int)
+	)
 	for lang, titles := range titlesByLang {
 		if len(titles) == 0 {
 			continue
 		}
-		res, err := p.wiki.GetArticleLengths(ctx, titles, lang)
-		if err != nil {
-			p.logger.Warn("Failed to fetch article lengths", "lang", lang, "error", err)
-			continue
-		}
-		lengths[lang] = res
+		wg.Add(1)
+		go func(lang string, titles []string) {
+			defer wg.Done()
+			res, err := p.wiki.GetArticleLengths(ctx, titles, lang)
+			if err != nil {
+				p.logger.Warn("Failed to fetch article lengths", "lang", lang, "error", err)
+				return
+			}
+			mu.Lock()
+			lengths[lang] = res
+			mu.Unlock()
+		}(lang, titles)
 	}
+	wg.Wait()
 	return lengths
 }
 
@@ -106,6 +130,9 @@ func (p *Pipeline) constructPOI(a *Article, lengths map[string]map[string]int, l
 		TriggerQID:          "",
 		CreatedAt:           time.Now(),
 		DimensionMultiplier: a.DimensionMultiplier,
+		Height:              deref(a.Height),
+		Length:              deref(a.Length),
+		Area:                deref(a.Area),
 	}
 
 	poi.Icon = iconGetter(a.Category)
@@ -211,13 +238,15 @@ func (p *Pipeline) replaceSpace(s string) string {
 	return strings.ReplaceAll(s, " ", "_")
 }
 
-func (p *Pipeline) getIcon(category string) string {
+// getIcon resolves the category icon in the given icon set. Unresolved icons
+// are left empty for the POI manager's fallbacks.
+func (p *Pipeline) getIcon(category, set string) string {
 	type configProvider interface {
 		GetConfig() *config.CategoriesConfig
 	}
 	if cp, ok := p.classifier.(configProvider); ok {
-		if cfg, ok := cp.GetConfig().Categories[strings.ToLower(category)]; ok {
-			return cfg.Icon
+		if cfg := cp.GetConfig(); cfg != nil {
+			return cfg.IconFor(category, set)
 		}
 	}
 	return ""
@@ -277,9 +277,17 @@ func (p *Pipeline) logRescueStats(lat, lon float64, localMax rescue.TileStats, m
 	)
 }
 
+// getSitelinksMin resolves the sitelink gate for a category. A category
+// without its own minimum gets the global floor; the global ceiling keeps a
+// single misconfigured category from demanding an absurd count.
 func (p *Pipeline) getSitelinksMin(category string) int {
-	if cfg, ok := p.classifier.GetConfig().Categories[category]; ok {
-		return cfg.SitelinksMin
+	wd := p.cfgProv.AppConfig().Wikidata
+	minLinks := wd.DefaultSitelinksMin
+	if cfg, ok := p.classifier.GetConfig().Categories[category]; ok && cfg.SitelinksMin > 0 {
+		minLinks = cfg.SitelinksMin
 	}
-	return 0
+	if wd.MaxSitelinksMin > 0 && minLinks > wd.MaxSitelinksMin {
+		minLinks = wd.MaxSitelinksMin
+	}
+	return minLinks
 }
@@ -3,22 +3,60 @@ package wikidata
 import (
 	"math"
 	"sort"
-)
 
-const spacingKm = 5.6 // Approx center-to-center distance for H3 Res 6
+	"phileasgo/pkg/config"
+)
 
 // Scheduler determines the next tile to fetch.
 type Scheduler struct {
 	grid      *Grid
 	maxDistKm float64
+	cone      config.HeadingConeConfig
 }
 
-// NewScheduler creates a new scheduler.
+// NewScheduler creates a new scheduler with a 60 degree half-angle cone
+// reaching out to maxDistKm.
 func NewScheduler(maxDistKm float64) *Scheduler {
 	return &Scheduler{
 		grid:      NewGrid(),
 		maxDistKm: maxDistKm,
+		cone:      config.HeadingConeConfig{HalfAngle: 60},
+	}
+}
+
+// SetTileRadius sizes the grid's tiles to the nearest H3 resolution for the
+// radius; 0 keeps the default. Call it before the grid is handed out.
+func (s *Scheduler) SetTileRadius(radiusKm float64) {
+	s.grid = NewGridForRadius(radiusKm)
+}
+
+// SetHeadingCone replaces the cone used to pick tiles while airborne. A zero
+// half-angle keeps the current one.
+func (s *Scheduler) SetHeadingCone(c config.HeadingConeConfig) {
+	if c.HalfAngle <= 0 {
+		c.HalfAngle = s.cone.HalfAngle
 	}
+	s.cone = c
+}
+
+// coneHalfAngle returns the cone half-angle at the given ground speed. Below
+// the slow speed it widens linearly towards the slow angle, so a slow orbit
+// keeps fetching tiles all around rather than only straight ahead.
+func (s *Scheduler) coneHalfAngle(groundSpeedKts float64) float64 {
+	half := s.cone.HalfAngle
+	if s.cone.SlowSpeed > 0 && groundSpeedKts < s.cone.SlowSpeed && s.cone.SlowAngle > half {
+		f := 1 - math.Max(groundSpeedKts, 0)/s.cone.SlowSpeed
+		half += (s.cone.SlowAngle - half) * f
+	}
+	return math.Min(half, 180)
+}
+
+// coneReachKm returns how far ahead the cone looks for tiles.
+func (s *Scheduler) coneReachKm() float64 {
+	if reach := float64(s.cone.LookAhead) / 1000.0; reach > 0 {
+		return math.Min(reach, s.maxDistKm)
+	}
+	return s.maxDistKm
 }
 
 // Candidate represents a potential tile to fetch.
@@ -48,7 +86,7 @@ func (s *Scheduler) GetCandidates(lat, lon, heading, groundSpeedKts float64, isA
 	var candidates []Candidate
 
 	// Pre-calculate limit
-	limitDist := s.maxDistKm + spacingKm
+	limitDist := s.maxDistKm + s.grid.SpacingKm()
 
 	// We use a simple BFS for spiral
 	head := 0
@@ -93,14 +131,17 @@ func (s *Scheduler) processCandidate(curr HexTile, cLat, cLon, dist, lat, lon, h
 
 	deviation := 0.0
 	if isAirborne {
-		// Cone Check: +/- 60 degrees (Total 120)
+		// Cone Check: the start tile and the near field are always eligible
 		if curr != startTile && dist > 5.0 {
+			if dist > s.coneReachKm() {
+				return Candidate{}, false
+			}
 			bearing := calculateBearing(lat, lon, cLat, cLon)
 			diff := math.Abs(bearing - heading)
 			if diff > 180 {
 				diff = 360 - diff
 			}
-			if diff > 60.0 { // 60 degrees half-arc for 120 degree cone
+			if diff > s.coneHalfAngle(groundSpeedKts) {
 				return Candidate{}, false
 			}
 			deviation = diff
@@ -3,6 +3,8 @@ package wikidata
 import (
 	"math"
 	"testing"
+
+	"phileasgo/pkg/config"
 )
 
 func TestCalculateBearing(t *testing.T) {
@@ -123,7 +125,7 @@ func TestGetCandidates(t *testing.T) {
 			// 3. Max Distance Check
 			if tt.checkDistance {
 				for _, c := range candidates {
-					if c.Dist > 100.0+spacingKm { // allow small margin for center vs edge
+					if c.Dist > 100.0+NewGrid().SpacingKm() { // allow small margin for center vs edge
 						t.Errorf("Candidate too far: %.2f km > 100km (limit)", c.Dist)
 					}
 				}
@@ -156,3 +158,76 @@ func TestGetCandidates(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCandidates_HeadingCone(t *testing.T) {
+	const lat, lon, heading = 50.0, 14.0, 0.0
+	deviation := func(c Candidate) float64 {
+		diff := math.Abs(calculateBearing(lat, lon, c.Lat, c.Lon) - heading)
+		if diff > 180 {
+			diff = 360 - diff
+		}
+		return diff
+	}
+
+	t.Run("Narrow cone deprioritizes off-heading tiles", func(t *testing.T) {
+		wide := NewScheduler(60.0)
+		narrow := NewScheduler(60.0)
+		narrow.SetHeadingCone(config.HeadingConeConfig{HalfAngle: 15})
+
+		offHeading := func(cands []Candidate) int {
+			n := 0
+			for _, c := range cands {
+				if c.Dist > 5.0 && deviation(c) > 15.5 {
+					n++
+				}
+			}
+			return n
+		}
+
+		wideCands := wide.GetCandidates(lat, lon, heading, 200, true, map[string]bool{})
+		narrowCands := narrow.GetCandidates(lat, lon, heading, 200, true, map[string]bool{})
+		if offHeading(wideCands) == 0 {
+			t.Fatal("expected the default cone to include tiles beyond 15 degrees")
+		}
+		if n := offHeading(narrowCands); n != 0 {
+			t.Errorf("expected no tiles beyond 15 degrees in the narrow cone, got %d", n)
+		}
+		for _, c := range narrowCands {
+			if c.Dist > 5.0 {
+				if d := deviation(c); d > 15.5 {
+					t.Errorf("first far candidate is off-heading by %.1f", d)
+				}
+				break
+			}
+		}
+	})
+
+	t.Run("Look-ahead limits the reach", func(t *testing.T) {
+		s := NewScheduler(60.0)
+		s.SetHeadingCone(config.HeadingConeConfig{HalfAngle: 60, LookAhead: config.Distance(20000)})
+		for _, c := range s.GetCandidates(lat, lon, heading, 120, true, map[string]bool{}) {
+			if c.Dist > 20.0 {
+				t.Errorf("candidate at %.1fkm beyond the 20km look-ahead", c.Dist)
+			}
+		}
+	})
+
+	t.Run("Slow speed widens the cone", func(t *testing.T) {
+		s := NewScheduler(60.0)
+		s.SetHeadingCone(config.HeadingConeConfig{HalfAngle: 30, SlowSpeed: 100, SlowAngle: 180})
+		tests := []struct {
+			speed float64
+			want  float64
+		}{
+			{200, 30},
+			{100, 30},
+			{50, 105},
+			{0, 180},
+		}
+		for _, tt := range tests {
+			if got := s.coneHalfAngle(tt.speed); math.Abs(got-tt.want) > 0.01 {
+				t.Errorf("at %.0fkts expected %.1f degrees, got %.1f", tt.speed, tt.want, got)
+			}
+		}
+	})
+}
@@ -1,12 +1,16 @@
 package wikidata
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"phileasgo/pkg/config"
@@ -22,6 +26,10 @@ import (
 	"phileasgo/pkg/wikipedia"
 )
 
+// partialTilePrefix marks tiles cached from a reduced-limit query; the value
+// is the limit that succeeded.
+const partialTilePrefix = "partial_tile_"
+
 // SimStateProvider defines what we need from the sim package.
 type SimStateProvider interface {
 	GetTelemetry(ctx context.Context) (sim.Telemetry, error)
@@ -50,7 +58,10 @@ type Service struct {
 	// Spatial Deduplication
 	inflightMu    sync.Mutex
 	inflightTiles map[string]bool
-	mapper        *LanguageMapper
+	// Partial tiles already given their full-fetch retry this run
+	partialRetried map[string]bool
+	mapper         *LanguageMapper
+	warmupStarted  atomic.Bool
 
 	// Configuration
 
@@ -81,28 +92,31 @@ func NewService(st store.Store, sim SimStateProvider, tr *tracker.Tracker, cl Cl
 	client := NewClient(rc, slog.With("component", "wikidata_client"))
 	wiki := wikipedia.NewClient(rc)
 	sched := NewScheduler(float64(cfgProv.AppConfig().Wikidata.Area.MaxDist) / 1000.0) // Config is meters, Scheduler wants KM
+	sched.SetHeadingCone(cfgProv.AppConfig().Wikidata.HeadingCone)
+	sched.SetTileRadius(cfgProv.AppConfig().Wikidata.TileRadiusKm)
 	logger := slog.With("component", "wikidata")
 	mapper := NewLanguageMapper(st, rc, slog.With("component", "mapper"))
 
 	pipeline := NewPipeline(st, client, wiki, geoSvc, poiMgr, sched.grid, mapper, cl, dm, cfgProv, logger)
 
 	svc := &Service{
-		pipeline:      pipeline,
-		store:         st,
-		sim:           sim,
-		client:        client,
-		wiki:          wiki,
-		geo:           geoSvc,
-		poi:           poiMgr,
-		scheduler:     sched,
-		tracker:       tr,
-		classifier:    cl,
-		density:       dm,
-		cfgProv:       cfgProv,
-		logger:        logger,
-		recentTiles:   make(map[string]TileWrapper),
-		inflightTiles: make(map[string]bool),
-		mapper:        mapper,
+		pipeline:       pipeline,
+		store:          st,
+		sim:            sim,
+		client:         client,
+		wiki:           wiki,
+		geo:            geoSvc,
+		poi:            poiMgr,
+		scheduler:      sched,
+		tracker:        tr,
+		classifier:     cl,
+		density:        dm,
+		cfgProv:        cfgProv,
+		logger:         logger,
+		recentTiles:    make(map[string]TileWrapper),
+		inflightTiles:  make(map[string]bool),
+		partialRetried: make(map[string]bool),
+		mapper:         mapper,
 	}
 	return svc
 }
@@ -156,13 +170,22 @@ func (s *Service) GeoService() *geo.Service {
 
 // GetLanguageInfo returns primary language details for a country code (implements LanguageResolver).
 func (s *Service) GetLanguageInfo(countryCode string) model.LanguageInfo {
-	langs := s.mapper.GetLanguages(countryCode)
-	if len(langs) > 0 {
-		return langs[0]
+	if info, ok := s.PrimaryLanguage(countryCode); ok {
+		return info
 	}
 	return model.LanguageInfo{Code: "en", Name: "English"}
 }
 
+// PrimaryLanguage returns the primary language of a country; ok is false when
+// none is mapped (implements prompt.CountryLanguageResolver).
+func (s *Service) PrimaryLanguage(countryCode string) (model.LanguageInfo, bool) {
+	langs := s.mapper.GetLanguages(countryCode)
+	if len(langs) == 0 {
+		return model.LanguageInfo{}, false
+	}
+	return langs[0], true
+}
+
 func (s *Service) processTick(ctx context.Context) {
 	// 1. Check Sim State - only proceed if actively flying
 	if s.sim.GetState() != sim.StateActive {
@@ -191,6 +214,8 @@ func (s *Service) processTick(ctx context.Context) {
 		lon = telemetry.Longitude
 	}
 
+	s.maybeWarmup(ctx, lat, lon)
+
 	hdg := telemetry.Heading
 	isAirborne := !telemetry.IsOnGround
 
@@ -260,8 +285,9 @@ func (s *Service) fetchTile(ctx context.Context, c Candidate, medians rescue.Med
 	centerLat, centerLon := s.gridCenter(c.Tile)
 
 	cachedBody, _, ok := s.store.GetGeodataCache(ctx, key)
-	if ok && len(cachedBody) > 0 {
+	if ok && len(cachedBody) > 0 && !s.retryPartialTile(ctx, key) {
 		logging.Trace(s.logger, "Cache Hit (Optimized)", "tile", key)
+		s.trackEvent(tracker.EventTileCacheHits)
 		// Pass medians to pipeline
 		processed, rawArticles, rescued, err := s.pipeline.ProcessTileData(ctx, cachedBody, centerLat, centerLon, false, medians)
 		if err == nil {
@@ -279,28 +305,20 @@ func (s *Service) fetchTile(ctx context.Context, c Candidate, medians rescue.Med
 	}
 
 	// 3. Construct Query (Network Path)
-	// Calculate precise radius in meters for this specific tile geometry
-	// Round up to the next 10m (User Request), remove fixed 50m buffer
-	rawRadius := s.scheduler.grid.TileRadius(c.Tile) * 1000
-	radiusMeters := int(math.Ceil(rawRadius/10.0) * 10)
-
-	// STRICT CAP: 10km (Wikidata API Limit)
-	if radiusMeters > 10000 {
-		radiusMeters = 10000
-	}
+	radiusMeters := s.queryRadiusMeters(c.Tile)
 
 	// Create formatted string for SPARQL (e.g. "9.810") - query expects KM
 	radiusStr := fmt.Sprintf("%.3f", float64(radiusMeters)/1000.0)
 
-	query := buildCheapQuery(centerLat, centerLon, radiusStr)
-
 	// 4. Execute
-	articles, rawJSON, err := s.client.QuerySPARQL(ctx, query, c.Tile.Key(), radiusMeters, centerLat, centerLon)
+	articles, rawJSON, limit, err := s.queryTile(ctx, centerLat, centerLon, radiusStr, radiusMeters)
 	if err != nil {
 		s.logger.Error("SPARQL Failed", "error", err)
+		s.trackEvent(tracker.EventSPARQLFailures)
 		return false // Network attempt failed, but consumed quota/time
 	}
-	_ = rawJSON // rawJSON no longer needed here; caching is internal
+	s.trackEvent(tracker.EventTilesCached)
+	s.cacheTile(ctx, key, rawJSON, limit, radiusMeters, centerLat, centerLon)
 
 	processed, rawArticles, rescued, err := s.pipeline.ProcessTileData(ctx, []byte(rawJSON), centerLat, centerLon, false, medians)
 	if err == nil {
@@ -314,6 +332,90 @@ func (s *Service) fetchTile(ctx context.Context, c Candidate, medians rescue.Med
 	return false // Network request made = Slow
 }
 
+// queryRadiusMeters returns the SPARQL radius that covers the tile, which
+// follows the configured tile size.
+func (s *Service) queryRadiusMeters(t HexTile) int {
+	// Calculate precise radius in meters for this specific tile geometry
+	// Round up to the next 10m (User Request), remove fixed 50m buffer
+	rawRadius := s.scheduler.grid.TileRadius(t) * 1000
+	radiusMeters := int(math.Ceil(rawRadius/10.0) * 10)
+
+	// STRICT CAP: 10km (Wikidata API Limit)
+	if radiusMeters > 10000 {
+		radiusMeters = 10000
+	}
+	return radiusMeters
+}
+
+// queryTile runs the tile query, halving the LIMIT after each timeout so a
+// dense tile still yields its most linked items. It returns the limit that
+// finally succeeded. The client cache is bypassed: a timed-out response must
+// never be stored, and fetchTile decides how the result is cached.
+func (s *Service) queryTile(ctx context.Context, lat, lon float64, radiusStr string, radiusM int) (articles []Article, raw string, limit int, err error) {
+	cfg := s.cfgProv.AppConfig().Wikidata
+	limit = cfg.Area.MaxArticles
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+
+	for attempt := 0; ; attempt++ {
+		query := buildCheapQuery(lat, lon, radiusStr, limit)
+		articles, raw, err = s.client.QuerySPARQL(ctx, query, "", radiusM, lat, lon)
+		if err == nil || !errors.Is(err, ErrTimeout) || attempt >= cfg.TimeoutRetries || limit < 2 {
+			return articles, raw, limit, err
+		}
+		s.logger.Warn("SPARQL timed out, retrying with a lower limit", "limit", limit, "next", limit/2, "error", err)
+		limit /= 2
+	}
+}
+
+// cacheTile stores a fetched tile. A tile fetched with a reduced limit is
+// marked partial so a later run tries the full query again.
+func (s *Service) cacheTile(ctx context.Context, key, raw string, limit, radiusM int, lat, lon float64) {
+	if err := s.store.SetGeodataCache(ctx, key, []byte(raw), radiusM, lat, lon); err != nil {
+		s.logger.Error("Failed to cache tile", "tile", key, "error", err)
+	}
+
+	full := s.cfgProv.AppConfig().Wikidata.Area.MaxArticles
+	if full <= 0 {
+		full = defaultQueryLimit
+	}
+	marker := partialTilePrefix + key
+	if limit < full {
+		if err := s.store.SetState(ctx, marker, strconv.Itoa(limit)); err != nil {
+			s.logger.Warn("Failed to mark partial tile", "tile", key, "error", err)
+		}
+		return
+	}
+	if _, ok := s.store.GetState(ctx, marker); ok {
+		_ = s.store.DeleteState(ctx, marker)
+	}
+}
+
+// retryPartialTile reports whether a cached tile was fetched with a reduced
+// limit and should be queried in full instead. Each partial tile gets one
+// such attempt per run, so WDQS is not hammered with a query that keeps
+// timing out; afterwards the partial cache is used as is.
+func (s *Service) retryPartialTile(ctx context.Context, key string) bool {
+	if _, ok := s.store.GetState(ctx, partialTilePrefix+key); !ok {
+		return false
+	}
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+	if s.partialRetried[key] {
+		return false
+	}
+	s.partialRetried[key] = true
+	logging.Trace(s.logger, "Retrying full fetch of partial tile", "tile", key)
+	return true
+}
+
+func (s *Service) trackEvent(name string) {
+	if s.tracker != nil {
+		s.tracker.TrackEvent(name)
+	}
+}
+
 func (s *Service) gridCenter(t HexTile) (lat, lon float64) {
 	// Expose grid center via scheduler -> grid
 	return s.scheduler.grid.TileCenter(t)
@@ -346,6 +448,37 @@ func (s *Service) GetCachedTiles(ctx context.Context, minLat, maxLat, minLon, ma
 	return results, nil
 }
 
+// CachedTileInfo describes one cached tile for cache inspection.
+type CachedTileInfo struct {
+	Key      string    `json:"key"`
+	Lat      float64   `json:"lat"`
+	Lon      float64   `json:"lon"`
+	Radius   int       `json:"radius"`
+	POICount int       `json:"poi_count"` // Articles in the cached SPARQL response
+	CachedAt time.Time `json:"cached_at"`
+}
+
+// ListCachedTiles returns one page of cached tiles and the total count matching
+// the query. Only the tiles on the page are decompressed to count their POIs.
+func (s *Service) ListCachedTiles(ctx context.Context, q store.GeodataQuery) ([]CachedTileInfo, int, error) {
+	records, total, err := s.store.ListGeodata(ctx, q)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list cached tiles: %w", err)
+	}
+
+	tiles := make([]CachedTileInfo, 0, len(records))
+	for _, r := range records {
+		info := CachedTileInfo{Key: r.Key, Lat: r.Lat, Lon: r.Lon, Radius: r.Radius, CachedAt: r.CreatedAt}
+		if data, _, found := s.store.GetGeodataCache(ctx, r.Key); found && len(data) > 0 {
+			if n, err := CountSPARQLBindings(bytes.NewReader(data)); err == nil {
+				info.POICount = n
+			}
+		}
+		tiles = append(tiles, info)
+	}
+	return tiles, total, nil
+}
+
 // GetGlobalCoverage returns aggregated coverage data (Res 4 tiles) for the world map.
 func (s *Service) GetGlobalCoverage(ctx context.Context) ([]CachedTile, error) {
 	keys, err := s.store.ListGeodataCacheKeys(ctx, "wd_h3_")
@@ -507,6 +640,35 @@ func (s *Service) ScavengeArea(ctx context.Context, lat, lon, radiusKm, heading,
 	return nil
 }
 
+// PurgeTiles deletes the cached tiles centered within radiusKm of lat/lon and
+// evicts them from recentTiles, so the scheduler fetches them again.
+// Returns the number of tiles deleted from the store.
+func (s *Service) PurgeTiles(ctx context.Context, lat, lon, radiusKm float64) (int, error) {
+	offsetLat := radiusKm / 111.0
+	offsetLon := radiusKm / (111.0 * math.Cos(lat*math.Pi/180.0))
+	minLat, maxLat := lat-offsetLat, lat+offsetLat
+	minLon, maxLon := lon-offsetLon, lon+offsetLon
+
+	// The keys are needed for the eviction, the delete only reports a count
+	records, err := s.store.GetGeodataInBounds(ctx, minLat, maxLat, minLon, maxLon)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get geodata bounds: %w", err)
+	}
+	removed, err := s.store.DeleteGeodataInBounds(ctx, minLat, maxLat, minLon, maxLon)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete geodata: %w", err)
+	}
+
+	s.recentMu.Lock()
+	for _, r := range records {
+		delete(s.recentTiles, r.Key)
+	}
+	s.recentMu.Unlock()
+
+	s.logger.Info("Purged cached tiles", "lat", lat, "lon", lon, "radius_km", radiusKm, "tiles", removed)
+	return removed, nil
+}
+
 func (s *Service) updateTileStats(key string, lat, lon float64, articles []Article) {
 	// Map non-Ignored wikidata.Article to rescue.Article for processing
 	var rescueArticles []rescue.Article
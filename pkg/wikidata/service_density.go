@@ -0,0 +1,67 @@
+package wikidata
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"phileasgo/pkg/geo"
+	"phileasgo/pkg/store"
+)
+
+// maxDensityCells caps the heatmap for very large boxes. The cap is applied in
+// the query, largest stored tile first, so at most this many tiles are ever
+// decoded; payload size tracks the article count closely enough to rank by.
+const maxDensityCells = 500
+
+// densityTilePrefix is the cache key prefix of Wikidata hex tiles.
+const densityTilePrefix = "wd_h3_"
+
+// DensityCell is one hex tile of the POI density heatmap.
+type DensityCell struct {
+	Index   string
+	Lat     float64
+	Lon     float64
+	Weight  int // Number of articles in the cached tile
+	Corners []geo.Point
+}
+
+// GetDensityCells counts the articles of every cached tile within the bounding box.
+// Tiles are only counted, not parsed or classified, so the heatmap reflects raw
+// Wikidata coverage rather than what the classifier keeps.
+func (s *Service) GetDensityCells(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]DensityCell, error) {
+	records, _, err := s.store.ListGeodata(ctx, store.GeodataQuery{
+		Bounds:    &store.Bounds{MinLat: minLat, MaxLat: maxLat, MinLon: minLon, MaxLon: maxLon},
+		KeyPrefix: densityTilePrefix,
+		Sort:      store.GeodataSortSize,
+		Limit:     maxDensityCells,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cached tiles: %w", err)
+	}
+
+	var cells []DensityCell
+	for _, rec := range records {
+		data, _, found := s.store.GetGeodataCache(ctx, rec.Key)
+		if !found || len(data) == 0 {
+			continue
+		}
+		count, err := CountSPARQLBindings(bytes.NewReader(data))
+		if err != nil || count == 0 {
+			continue
+		}
+
+		tile := HexTile{Index: strings.TrimPrefix(rec.Key, densityTilePrefix)}
+		lat, lon := s.scheduler.grid.TileCenter(tile)
+		cells = append(cells, DensityCell{
+			Index:   tile.Index,
+			Lat:     lat,
+			Lon:     lon,
+			Weight:  count,
+			Corners: s.scheduler.grid.TileCorners(tile),
+		})
+	}
+
+	return cells, nil
+}
@@ -0,0 +1,127 @@
+package wikidata
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/store"
+)
+
+// densityStore serves raw tile payloads from memory.
+type densityStore struct {
+	MockStoreMinimal
+	tiles     map[string]string
+	lastQuery store.GeodataQuery
+}
+
+func (m *densityStore) GetGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]store.GeodataRecord, error) {
+	var recs []store.GeodataRecord
+	for k := range m.tiles {
+		recs = append(recs, store.GeodataRecord{Key: k})
+	}
+	return recs, nil
+}
+func (m *densityStore) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	return 0, nil
+}
+func (m *densityStore) ListGeodata(ctx context.Context, q store.GeodataQuery) ([]store.GeodataRecord, int, error) {
+	m.lastQuery = q
+	var recs []store.GeodataRecord
+	for k := range m.tiles {
+		if strings.HasPrefix(k, q.KeyPrefix) {
+			recs = append(recs, store.GeodataRecord{Key: k})
+		}
+	}
+	return recs, len(recs), nil
+}
+
+func (m *densityStore) AddNarrationLog(ctx context.Context, e store.NarrationLogEntry, keep int) error {
+	return nil
+}
+
+func (m *densityStore) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	return nil, nil
+}
+
+func (m *densityStore) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	return nil
+}
+
+func (m *densityStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return nil, nil
+}
+
+func (m *densityStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	return nil
+}
+
+func (m *densityStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return nil, nil
+}
+
+func (m *densityStore) GetGeodataCache(ctx context.Context, key string) ([]byte, int, bool) {
+	v, ok := m.tiles[key]
+	return []byte(v), 9800, ok
+}
+
+func sparqlBody(n int) string {
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = `{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q1"}, "lat": {"value": "1"}}`
+	}
+	return `{"head": {"vars": ["item", "lat"]}, "results": {"bindings": [` + strings.Join(rows, ",") + `]}}`
+}
+
+func TestCountSPARQLBindings(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{name: "Rows", body: sparqlBody(3), want: 3},
+		{name: "Empty bindings", body: sparqlBody(0), want: 0},
+		{name: "No bindings key", body: `{"head": {}}`, want: 0},
+		{name: "Truncated", body: `{"results": {"bindings": [{"item": `, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CountSPARQLBindings(strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_GetDensityCells(t *testing.T) {
+	st := &densityStore{tiles: map[string]string{
+		"wd_h3_8928308280fffff": sparqlBody(4),
+		"wd_h3_89283082807ffff": sparqlBody(0), // Empty tiles are not part of the heatmap
+		"wd_entities_abc":       sparqlBody(2), // Not a tile
+	}}
+	svc := &Service{store: st, scheduler: &Scheduler{grid: NewGrid()}}
+
+	cells, err := svc.GetDensityCells(context.Background(), 30, 50, -130, -110)
+	if err != nil {
+		t.Fatalf("GetDensityCells failed: %v", err)
+	}
+	if len(cells) != 1 {
+		t.Fatalf("got %d cells, want 1", len(cells))
+	}
+	c := cells[0]
+	if c.Index != "8928308280fffff" || c.Weight != 4 {
+		t.Errorf("unexpected cell %+v", c)
+	}
+	if len(c.Corners) != 6 || c.Lat == 0 {
+		t.Errorf("expected hex center and corners, got lat=%f corners=%d", c.Lat, len(c.Corners))
+	}
+	if q := st.lastQuery; q.Limit != maxDensityCells || q.Sort != store.GeodataSortSize || q.Bounds == nil {
+		t.Errorf("expected the cap applied in the query, got %+v", q)
+	}
+}
@@ -1,7 +1,12 @@
 package wikidata
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
 )
 
 func TestDetermineBestArticle(t *testing.T) {
@@ -168,3 +173,53 @@ func TestConstructPOI(t *testing.T) {
 		})
 	}
 }
+
+func TestEnrichAndSave_ConcurrentLengthLookups(t *testing.T) {
+	const delay = 200 * time.Millisecond
+
+	pipeline := newTestPipeline(&mockStore{})
+	cfg := config.DefaultConfig()
+	pipeline.cfgProv = config.NewProvider(cfg, nil)
+	pipeline.wiki = &MockWikipediaProvider{
+		GetArticleLengthsFunc: func(ctx context.Context, titles []string, lang string) (map[string]int, error) {
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+			if lang == "de" {
+				return nil, errors.New("wiki unavailable")
+			}
+			res := make(map[string]int)
+			for _, title := range titles {
+				res[title] = 1000
+			}
+			return res, nil
+		},
+	}
+
+	articles := []Article{
+		{QID: "Q1", Category: "City", Lat: 48.0, Lon: 2.0, Sitelinks: 10, TitleEn: "Tower", LocalTitles: map[string]string{"fr": "Tour"}},
+		{QID: "Q2", Category: "City", Lat: 52.0, Lon: 13.0, Sitelinks: 10, TitleEn: "Gate", LocalTitles: map[string]string{"de": "Tor"}},
+	}
+
+	start := time.Now()
+	if err := pipeline.enrichAndSave(context.Background(), articles, []string{"fr", "de"}, "en"); err != nil {
+		t.Fatalf("enrichAndSave failed: %v", err)
+	}
+	// Three languages at one delay each; serial lookups would take 3x.
+	if elapsed := time.Since(start); elapsed >= 2*delay {
+		t.Errorf("lookups took %v, expected them to run concurrently", elapsed)
+	}
+
+	saved := make(map[string]int)
+	for _, p := range pipeline.poi.GetTrackedPOIs() {
+		saved[p.WikidataID] = p.WPArticleLength
+	}
+	if len(saved) != 2 {
+		t.Fatalf("got %d saved POIs, want 2 despite the failed language", len(saved))
+	}
+	if saved["Q1"] != 1000 {
+		t.Errorf("Q1 length = %d, want 1000", saved["Q1"])
+	}
+}
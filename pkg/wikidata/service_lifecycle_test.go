@@ -34,6 +34,36 @@ func (m *MockStoreMinimal) SetCache(ctx context.Context, key string, val []byte)
 func (m *MockStoreMinimal) GetGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) ([]store.GeodataRecord, error) {
 	return []store.GeodataRecord{{Lat: 40.0, Lon: -74.0, Radius: 9800}}, nil
 }
+func (m *MockStoreMinimal) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	return 0, nil
+}
+func (m *MockStoreMinimal) ListGeodata(ctx context.Context, q store.GeodataQuery) ([]store.GeodataRecord, int, error) {
+	return nil, 0, nil
+}
+
+func (m *MockStoreMinimal) AddNarrationLog(ctx context.Context, e store.NarrationLogEntry, keep int) error {
+	return nil
+}
+
+func (m *MockStoreMinimal) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	return nil, nil
+}
+
+func (m *MockStoreMinimal) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	return nil
+}
+
+func (m *MockStoreMinimal) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return nil, nil
+}
+
+func (m *MockStoreMinimal) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	return nil
+}
+
+func (m *MockStoreMinimal) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return nil, nil
+}
 func (m *MockStoreMinimal) ListGeodataCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return []string{"wd_h3_8928308280fffff"}, nil
 }
@@ -27,15 +27,17 @@ func (s *StubClassifier) ClassifyBatch(ctx context.Context, entities map[string]
 func TestGetSitelinksMin(t *testing.T) {
 	mockCfg := &config.CategoriesConfig{
 		Categories: map[string]config.Category{
-			"city": {SitelinksMin: 10},
+			"city":   {SitelinksMin: 10},
+			"castle": {},
 		},
 	}
 	stub := &StubClassifier{cfg: mockCfg}
-	pl := &Pipeline{classifier: stub}
 
 	tests := []struct {
 		name     string
 		category string
+		floor    int
+		ceiling  int
 		want     int
 	}{
 		{
@@ -48,10 +50,43 @@ func TestGetSitelinksMin(t *testing.T) {
 			category: "unknown",
 			want:     0,
 		},
+		{
+			name:     "Category value wins over floor",
+			category: "city",
+			floor:    3,
+			want:     10,
+		},
+		{
+			name:     "Category without value uses floor",
+			category: "castle",
+			floor:    3,
+			want:     3,
+		},
+		{
+			name:     "Unknown category uses floor",
+			category: "unknown",
+			floor:    3,
+			want:     3,
+		},
+		{
+			name:     "Ceiling clamps category value",
+			category: "city",
+			ceiling:  8,
+			want:     8,
+		},
+		{
+			name:     "Ceiling clamps floor",
+			category: "castle",
+			floor:    12,
+			ceiling:  8,
+			want:     8,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			appCfg := &config.Config{Wikidata: config.WikidataConfig{DefaultSitelinksMin: tt.floor, MaxSitelinksMin: tt.ceiling}}
+			pl := &Pipeline{classifier: stub, cfgProv: config.NewProvider(appCfg, nil)}
 			got := pl.getSitelinksMin(tt.category)
 			if got != tt.want {
 				t.Errorf("getSitelinksMin() = %d, want %d", got, tt.want)
@@ -118,11 +153,17 @@ func TestGetArticleDimensions(t *testing.T) {
 	}
 }
 
-// TestGetIcon covers getIcon logic (case insensitive lookup)
+// TestGetIcon covers getIcon logic (case insensitive lookup, icon sets)
 func TestGetIcon(t *testing.T) {
 	mockCfg := &config.CategoriesConfig{
 		Categories: map[string]config.Category{
 			"city": {Icon: "city-hall"},
+			"town": {Icon: "town-hall"},
+			"peak": {Icon: "mountain"},
+		},
+		GroupLookup: map[string]string{"city": "Settlements", "town": "Settlements"},
+		IconSets: map[string]map[string]string{
+			"minimal": {"settlements": "circle", "town": "square"},
 		},
 	}
 	stub := &StubClassifier{cfg: mockCfg}
@@ -131,6 +172,7 @@ func TestGetIcon(t *testing.T) {
 	tests := []struct {
 		name     string
 		category string
+		set      string
 		want     string
 	}{
 		{
@@ -148,13 +190,37 @@ func TestGetIcon(t *testing.T) {
 			category: "alien_base",
 			want:     "",
 		},
+		{
+			name:     "Set By Group",
+			category: "City",
+			set:      "minimal",
+			want:     "circle",
+		},
+		{
+			name:     "Set By Category Beats Group",
+			category: "Town",
+			set:      "minimal",
+			want:     "square",
+		},
+		{
+			name:     "Set Without Entry Uses Default Icon",
+			category: "Peak",
+			set:      "minimal",
+			want:     "mountain",
+		},
+		{
+			name:     "Unknown Set Uses Default Icon",
+			category: "City",
+			set:      "emoji",
+			want:     "city-hall",
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := pl.getIcon(tt.category)
+			got := pl.getIcon(tt.category, tt.set)
 			if got != tt.want {
-				t.Errorf("getIcon(%q) = %q, want %q", tt.category, got, tt.want)
+				t.Errorf("getIcon(%q, %q) = %q, want %q", tt.category, tt.set, got, tt.want)
 			}
 		})
 	}
@@ -4,16 +4,23 @@ import (
 	"fmt"
 )
 
-func buildCheapQuery(lat, lon float64, radius string) string {
+const defaultQueryLimit = 500
+
+func buildCheapQuery(lat, lon float64, radius string, limit int) string {
 	// Radius passed dynamically
 	if radius == "" {
 		radius = "9.8" // Fallback
 	}
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
 
 	// CHEAP QUERY:
 	// - No Labels
 	// - No Subquery for titles
 	// - Just QID, Sitelinks, Dimensions, Instances
+	// - Dimensions are normalized (psn:) to m and m²: truthy wdt: amounts
+	//   are in whatever unit the editor chose, km² and ft included
 	return fmt.Sprintf(`SELECT DISTINCT ?item ?lat ?lon ?sitelinks 
             (GROUP_CONCAT(DISTINCT ?instance_of_uri; separator=",") AS ?instances) 
             ?area ?height ?length ?width
@@ -27,14 +34,14 @@ func buildCheapQuery(lat, lon float64, radius string) string {
             
             OPTIONAL { ?item wdt:P31 ?instance_of_uri . } 
             OPTIONAL { ?item wikibase:sitelinks ?sitelinks . } 
-            OPTIONAL { ?item wdt:P2046 ?area . }
-            OPTIONAL { ?item wdt:P2048 ?height . }
-            OPTIONAL { ?item wdt:P2043 ?length . }
-            OPTIONAL { ?item wdt:P2049 ?width . }
+            OPTIONAL { ?item p:P2046 ?areaSt . ?areaSt a wikibase:BestRank ; psn:P2046/wikibase:quantityAmount ?area . }
+            OPTIONAL { ?item p:P2048 ?heightSt . ?heightSt a wikibase:BestRank ; psn:P2048/wikibase:quantityAmount ?height . }
+            OPTIONAL { ?item p:P2043 ?lengthSt . ?lengthSt a wikibase:BestRank ; psn:P2043/wikibase:quantityAmount ?length . }
+            OPTIONAL { ?item p:P2049 ?widthSt . ?widthSt a wikibase:BestRank ; psn:P2049/wikibase:quantityAmount ?width . }
             
             FILTER(?sitelinks > 0)
         } 
         GROUP BY ?item ?lat ?lon ?sitelinks ?area ?height ?length ?width
         ORDER BY DESC(?sitelinks) 
-        LIMIT 500`, lon, lat, radius)
+        LIMIT %d`, lon, lat, radius, limit)
 }
@@ -2,7 +2,13 @@ package wikidata
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -24,6 +30,7 @@ type mockStore struct {
 	geodataCache  map[string][]byte
 	geodataCacheR map[string]int
 	deletedSeen   []string
+	state         map[string]string
 }
 
 func (m *mockStore) GetPOI(ctx context.Context, id string) (*model.POI, error) {
@@ -44,9 +51,12 @@ func (m *mockStore) GetRecentlyPlayedPOIs(ctx context.Context, since time.Time)
 }
 func (m *mockStore) SaveLastPlayed(ctx context.Context, poiID string, t time.Time) error { return nil }
 func (m *mockStore) ResetLastPlayed(ctx context.Context, lat, lon, radius float64) error { return nil }
-func (m *mockStore) GetCache(ctx context.Context, key string) ([]byte, bool)             { return nil, false }
-func (m *mockStore) HasCache(ctx context.Context, key string) (bool, error)              { return false, nil }
-func (m *mockStore) SetCache(ctx context.Context, key string, val []byte) error          { return nil }
+func (m *mockStore) DeletePOIsInRadius(ctx context.Context, lat, lon, radius float64) ([]string, error) {
+	return nil, nil
+}
+func (m *mockStore) GetCache(ctx context.Context, key string) ([]byte, bool)    { return nil, false }
+func (m *mockStore) HasCache(ctx context.Context, key string) (bool, error)     { return false, nil }
+func (m *mockStore) SetCache(ctx context.Context, key string, val []byte) error { return nil }
 func (m *mockStore) ListCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
@@ -77,12 +87,58 @@ func (m *mockStore) GetGeodataInBounds(ctx context.Context, minLat, maxLat, minL
 	}
 	return results, nil
 }
+func (m *mockStore) DeleteGeodataInBounds(ctx context.Context, minLat, maxLat, minLon, maxLon float64) (int, error) {
+	recs, _ := m.GetGeodataInBounds(ctx, minLat, maxLat, minLon, maxLon)
+	for _, r := range recs {
+		delete(m.geodataCache, r.Key)
+	}
+	return len(recs), nil
+}
+func (m *mockStore) ListGeodata(ctx context.Context, q store.GeodataQuery) ([]store.GeodataRecord, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockStore) AddNarrationLog(ctx context.Context, e store.NarrationLogEntry, keep int) error {
+	return nil
+}
+
+func (m *mockStore) ListNarrationLog(ctx context.Context, limit int) ([]store.NarrationLogEntry, error) {
+	return nil, nil
+}
+
+func (m *mockStore) AddTelemetrySample(ctx context.Context, s store.TelemetrySample, keep int) error {
+	return nil
+}
+
+func (m *mockStore) ListTelemetrySamples(ctx context.Context, since time.Time, limit int) ([]store.TelemetrySample, error) {
+	return nil, nil
+}
+
+func (m *mockStore) SaveRegionVisit(ctx context.Context, region string, t time.Time) error {
+	return nil
+}
+
+func (m *mockStore) ListRegionVisits(ctx context.Context) (map[string]time.Time, error) {
+	return nil, nil
+}
 func (m *mockStore) ListGeodataCacheKeys(ctx context.Context, prefix string) ([]string, error) {
 	return nil, nil
 }
-func (m *mockStore) GetState(ctx context.Context, key string) (string, bool) { return "", false }
-func (m *mockStore) SetState(ctx context.Context, key, val string) error     { return nil }
-func (m *mockStore) DeleteState(ctx context.Context, key string) error       { return nil }
+func (m *mockStore) GetState(ctx context.Context, key string) (string, bool) {
+	v, ok := m.state[key]
+	return v, ok
+}
+func (m *mockStore) SetState(ctx context.Context, key, val string) error {
+	if m.state == nil {
+		m.state = make(map[string]string)
+	}
+	m.state[key] = val
+	return nil
+}
+func (m *mockStore) DeleteState(ctx context.Context, key string) error {
+	delete(m.state, key)
+	return nil
+}
 func (m *mockStore) SaveMSFSPOI(ctx context.Context, p *model.MSFSPOI) error { return nil }
 func (m *mockStore) GetMSFSPOI(ctx context.Context, id int64) (*model.MSFSPOI, error) {
 	return nil, nil
@@ -518,7 +574,7 @@ func TestProcessTileData(t *testing.T) {
 }
 
 func TestBuildCheapQuery(t *testing.T) {
-	got := buildCheapQuery(52.5, 13.4, "10.0")
+	got := buildCheapQuery(52.5, 13.4, "10.0", 200)
 
 	// Verify core components of the Cheap Query
 	if !strings.Contains(got, `?item wdt:P625 ?location`) {
@@ -528,6 +584,10 @@ func TestBuildCheapQuery(t *testing.T) {
 		t.Errorf("Query missing correct radius")
 	}
 
+	if !strings.Contains(got, "LIMIT 200") {
+		t.Errorf("Query missing requested limit")
+	}
+
 	// Verify ABSENCE of expensive fields
 	if strings.Contains(got, `SERVICE wikibase:label`) {
 		t.Errorf("Cheap Query should NOT contain label service")
@@ -542,10 +602,13 @@ func TestBuildCheapQuery(t *testing.T) {
 	}
 
 	// Case 2: Default Radius
-	gotDefault := buildCheapQuery(52.5, 13.4, "")
+	gotDefault := buildCheapQuery(52.5, 13.4, "", 0)
 	if !strings.Contains(gotDefault, `wikibase:radius "9.8"`) {
 		t.Errorf("Cheap Query fallback radius expected 9.8, got %s", gotDefault)
 	}
+	if !strings.Contains(gotDefault, "LIMIT 500") {
+		t.Errorf("Cheap Query fallback limit expected 500, got %s", gotDefault)
+	}
 }
 func TestGetNeighborhoodStats(t *testing.T) {
 	svc := &Service{
@@ -607,6 +670,33 @@ func TestUpdateTileStats(t *testing.T) {
 	}
 }
 
+func TestPurgeTiles(t *testing.T) {
+	ms := &mockStore{}
+	svc := &Service{
+		store:       ms,
+		logger:      slog.Default(),
+		recentTiles: map[string]TileWrapper{"wd_h3_test1": {}, "wd_h3_other": {}},
+	}
+	_ = ms.SetGeodataCache(context.Background(), "wd_h3_test1", []byte("{}"), 9800, 50.0, 10.0)
+
+	n, err := svc.PurgeTiles(context.Background(), 50.0, 10.0, 25.0)
+	if err != nil {
+		t.Fatalf("PurgeTiles failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Expected 1 tile purged, got %d", n)
+	}
+	if _, ok := ms.geodataCache["wd_h3_test1"]; ok {
+		t.Error("Expected wd_h3_test1 deleted from the store")
+	}
+	if _, ok := svc.recentTiles["wd_h3_test1"]; ok {
+		t.Error("Expected wd_h3_test1 evicted from recentTiles")
+	}
+	if _, ok := svc.recentTiles["wd_h3_other"]; !ok {
+		t.Error("Expected unrelated tiles to stay in recentTiles")
+	}
+}
+
 func TestScavengeArea(t *testing.T) {
 	ms := &mockStore{
 		geodataCache:  make(map[string][]byte),
@@ -653,3 +743,100 @@ func TestScavengeArea(t *testing.T) {
 		t.Errorf("Expected wd_h3_test1 to be evicted from recentTiles")
 	}
 }
+
+func TestQueryTile_TimeoutRetry(t *testing.T) {
+	limitRe := regexp.MustCompile(`LIMIT (\d+)`)
+	const okBody = `{"results":{"bindings":[{"item":{"value":"http://www.wikidata.org/entity/Q1"},"lat":{"value":"50.5"},"lon":{"value":"14.5"},"sitelinks":{"value":"10"}}]}}`
+
+	tests := []struct {
+		name      string
+		maxLimit  int // Highest LIMIT the endpoint answers without timing out
+		retries   int
+		wantErr   bool
+		wantLimit int
+	}{
+		{name: "Full limit succeeds", maxLimit: 500, retries: 2, wantLimit: 500},
+		{name: "Halves until it fits", maxLimit: 200, retries: 2, wantLimit: 125},
+		{name: "Gives up after the configured retries", maxLimit: 100, retries: 2, wantErr: true},
+		{name: "No retries configured", maxLimit: 250, retries: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var limits []int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_ = r.ParseForm()
+				m := limitRe.FindStringSubmatch(r.PostForm.Get("query"))
+				limit, _ := strconv.Atoi(m[1])
+				limits = append(limits, limit)
+				if limit > tt.maxLimit {
+					w.WriteHeader(http.StatusInternalServerError)
+					fmt.Fprint(w, "java.util.concurrent.TimeoutException")
+					return
+				}
+				fmt.Fprint(w, okBody)
+			}))
+			defer server.Close()
+
+			reqClient := request.New(&mockCache{}, tracker.New(), request.ClientConfig{Retries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+			client := NewClient(reqClient, slog.Default())
+			client.SPARQLEndpoint = server.URL
+
+			cfg := config.DefaultConfig()
+			cfg.Wikidata.TimeoutRetries = tt.retries
+			svc := &Service{client: client, cfgProv: config.NewProvider(cfg, nil), logger: slog.Default()}
+
+			articles, _, limit, err := svc.queryTile(context.Background(), 50.5, 14.5, "9.800", 9800)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("queryTile() error = %v, wantErr %v (limits tried %v)", err, tt.wantErr, limits)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, ErrTimeout) {
+					t.Errorf("expected ErrTimeout, got %v", err)
+				}
+				if len(limits) != tt.retries+1 {
+					t.Errorf("expected %d attempts, got %v", tt.retries+1, limits)
+				}
+				return
+			}
+			if limit != tt.wantLimit || len(articles) != 1 {
+				t.Errorf("got limit %d with %d articles, want limit %d with 1 (limits tried %v)", limit, len(articles), tt.wantLimit, limits)
+			}
+		})
+	}
+}
+
+func TestPartialTileRetry(t *testing.T) {
+	st := &mockStore{}
+	svc := &Service{
+		store:          st,
+		cfgProv:        config.NewProvider(config.DefaultConfig(), nil),
+		logger:         slog.Default(),
+		partialRetried: make(map[string]bool),
+	}
+	ctx := context.Background()
+	const key = "wd_h3_test"
+
+	svc.cacheTile(ctx, key, "{}", 250, 9800, 50, 10)
+	if _, _, ok := st.GetGeodataCache(ctx, key); !ok {
+		t.Fatal("expected the partial result to be cached")
+	}
+	if v, _ := st.GetState(ctx, partialTilePrefix+key); v != "250" {
+		t.Fatalf("expected the tile to be marked partial at 250, got %q", v)
+	}
+
+	if !svc.retryPartialTile(ctx, key) {
+		t.Error("expected a full fetch of the partial tile")
+	}
+	if svc.retryPartialTile(ctx, key) {
+		t.Error("expected only one full fetch per run")
+	}
+
+	svc.cacheTile(ctx, key, "{}", 500, 9800, 50, 10)
+	if _, ok := st.GetState(ctx, partialTilePrefix+key); ok {
+		t.Error("expected a full fetch to clear the partial marker")
+	}
+	if svc.retryPartialTile(ctx, key) {
+		t.Error("expected no retry for a complete tile")
+	}
+}
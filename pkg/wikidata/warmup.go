@@ -0,0 +1,128 @@
+package wikidata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"phileasgo/pkg/config"
+)
+
+// typeWarmer is implemented by classifiers that can classify instance types
+// ahead of the articles that use them.
+type typeWarmer interface {
+	Warm(ctx context.Context, types []string) (cachedBefore, cachedAfter int)
+}
+
+// instanceCounter is implemented by clients that can count the instance
+// types around a position.
+type instanceCounter interface {
+	QueryInstanceCounts(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]string, error)
+}
+
+// buildInstanceCountQuery counts the P31 types of the items around a point.
+// Unlike the tile query it returns one row per type, which keeps it quick
+// even for a wide radius.
+func buildInstanceCountQuery(lat, lon, radiusKm float64, limit int) string {
+	return fmt.Sprintf(`SELECT ?type (COUNT(?item) AS ?count)
+        WHERE {
+            SERVICE wikibase:around {
+                ?item wdt:P625 ?location .
+                bd:serviceParam wikibase:center "Point(%f %f)"^^geo:wktLiteral .
+                bd:serviceParam wikibase:radius "%.1f" .
+            }
+            ?item wdt:P31 ?type .
+        }
+        GROUP BY ?type
+        ORDER BY DESC(?count)
+        LIMIT %d`, lon, lat, radiusKm, limit)
+}
+
+// QueryInstanceCounts returns the QIDs of the most common instance types
+// around a point, most common first. The result is not cached: it only
+// matters once per region.
+func (c *Client) QueryInstanceCounts(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]string, error) {
+	data := url.Values{}
+	data.Set("query", buildInstanceCountQuery(lat, lon, radiusKm, limit))
+	data.Set("format", "json")
+
+	body, err := c.request.PostWithHeaders(ctx, c.SPARQLEndpoint, []byte(data.Encode()), map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+		"Accept":       "application/sparql-results+json",
+	})
+	if err != nil {
+		if isQueryTimeout(err) {
+			return nil, fmt.Errorf("%w: %v", ErrTimeout, err)
+		}
+		return nil, fmt.Errorf("%w: %v", ErrNetwork, err)
+	}
+
+	var resp struct {
+		Results struct {
+			Bindings []map[string]sparqlValue `json:"bindings"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse instance counts: %w", err)
+	}
+
+	types := make([]string, 0, len(resp.Results.Bindings))
+	for _, b := range resp.Results.Bindings {
+		uri := val(b, "type")
+		if idx := strings.LastIndex(uri, "/"); idx != -1 && idx < len(uri)-1 {
+			uri = uri[idx+1:]
+		}
+		if strings.HasPrefix(uri, "Q") {
+			types = append(types, uri)
+		}
+	}
+	return types, nil
+}
+
+// maybeWarmup starts the cold start warmup once per run, at the first
+// position the service sees. It runs in the background so tile fetching and
+// narration carry on meanwhile.
+func (s *Service) maybeWarmup(ctx context.Context, lat, lon float64) {
+	cfg := s.cfgProv.AppConfig().Wikidata.Warmup
+	if !cfg.Enabled || !s.warmupStarted.CompareAndSwap(false, true) {
+		return
+	}
+	go s.warmup(ctx, lat, lon, cfg)
+}
+
+// warmup classifies the instance types most common around the position and
+// logs how many of them now resolve from the cache.
+func (s *Service) warmup(ctx context.Context, lat, lon float64, cfg config.WarmupConfig) {
+	warmer, ok := s.classifier.(typeWarmer)
+	counter, ok2 := s.client.(instanceCounter)
+	if !ok || !ok2 {
+		return
+	}
+	if cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.Timeout))
+		defer cancel()
+	}
+
+	start := time.Now()
+	types, err := counter.QueryInstanceCounts(ctx, lat, lon, float64(cfg.Radius)/1000.0, cfg.MaxTypes)
+	if err != nil {
+		s.logger.Warn("Warmup: Failed to count instance types", "error", err)
+		return
+	}
+	if len(types) == 0 {
+		return
+	}
+
+	before, after := warmer.Warm(ctx, types)
+	s.logger.Info("Warmup: Classified common instance types",
+		"types", len(types),
+		"cache_hits_before", before,
+		"cache_hits_after", after,
+		"hit_rate", fmt.Sprintf("%d%% -> %d%%", before*100/len(types), after*100/len(types)),
+		"duration", time.Since(start).Round(time.Millisecond),
+		"timed_out", ctx.Err() != nil)
+}
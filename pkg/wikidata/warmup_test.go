@@ -0,0 +1,105 @@
+package wikidata
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"phileasgo/pkg/config"
+	"phileasgo/pkg/request"
+	"phileasgo/pkg/tracker"
+)
+
+func TestQueryInstanceCounts(t *testing.T) {
+	mockResp := `{"results": {"bindings": [
+		{"type": {"type": "uri", "value": "http://www.wikidata.org/entity/Q16970"}, "count": {"type": "literal", "value": "42"}},
+		{"type": {"type": "uri", "value": "http://www.wikidata.org/entity/Q532"}, "count": {"type": "literal", "value": "17"}}
+	]}}`
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		query = r.Form.Get("query")
+		fmt.Fprint(w, mockResp)
+	}))
+	defer server.Close()
+
+	reqClient := request.New(&mockCache{}, tracker.New(), request.ClientConfig{Retries: 1, BaseDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond})
+	client := NewClient(reqClient, slog.Default())
+	client.SPARQLEndpoint = server.URL
+
+	types, err := client.QueryInstanceCounts(context.Background(), 48.0, 11.0, 50, 10)
+	if err != nil {
+		t.Fatalf("QueryInstanceCounts failed: %v", err)
+	}
+	if want := []string{"Q16970", "Q532"}; !reflect.DeepEqual(types, want) {
+		t.Errorf("got %v, want %v", types, want)
+	}
+	if !strings.Contains(query, "COUNT(?item)") || !strings.Contains(query, "LIMIT 10") {
+		t.Errorf("unexpected query: %s", query)
+	}
+}
+
+type warmingClassifier struct {
+	MockClassifier
+	warmed chan []string
+}
+
+func (m *warmingClassifier) Warm(ctx context.Context, types []string) (cachedBefore, cachedAfter int) {
+	m.warmed <- types
+	return 0, len(types)
+}
+
+type countingClient struct {
+	MockWikidataClient
+}
+
+func (m *countingClient) QueryInstanceCounts(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]string, error) {
+	return []string{"Q16970", "Q532"}[:min(limit, 2)], nil
+}
+
+func TestService_Warmup(t *testing.T) {
+	tests := []struct {
+		name    string
+		enabled bool
+		want    []string
+	}{
+		{"Enabled warms the common types once", true, []string{"Q16970", "Q532"}},
+		{"Disabled", false, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.DefaultConfig()
+			cfg.Wikidata.Warmup.Enabled = tt.enabled
+			cl := &warmingClassifier{warmed: make(chan []string, 2)}
+			client := &countingClient{}
+			s := &Service{classifier: cl, client: client, cfgProv: config.NewProvider(cfg, nil), logger: slog.Default()}
+
+			s.maybeWarmup(context.Background(), 48.0, 11.0)
+			s.maybeWarmup(context.Background(), 48.1, 11.0)
+
+			select {
+			case got := <-cl.warmed:
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("warmed %v, want %v", got, tt.want)
+				}
+			case <-time.After(100 * time.Millisecond):
+				if tt.want != nil {
+					t.Fatal("expected a warmup")
+				}
+			}
+			select {
+			case <-cl.warmed:
+				t.Error("expected a single warmup per run")
+			case <-time.After(20 * time.Millisecond):
+			}
+		})
+	}
+}
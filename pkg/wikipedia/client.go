@@ -3,17 +3,36 @@ package wikipedia
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
 	"phileasgo/pkg/request"
 )
 
-// Client handles Wikipedia API interactions.
+// ErrArticleNotFound is returned for articles that don't exist, including
+// ones remembered as missing.
+var ErrArticleNotFound = errors.New("article not found")
+
+// MissingCache remembers articles that don't exist; the store implements it.
+type MissingCache interface {
+	GetCache(ctx context.Context, key string) ([]byte, bool)
+	SetCache(ctx context.Context, key string, val []byte) error
+}
+
+// Client handles Wikipedia API interactions. Transient failures (network
+// errors, 429 and 5xx) are retried with backoff by the request client.
 type Client struct {
 	request     *request.Client
 	APIEndpoint string // Optional override for testing
+
+	// Missing articles (optional, nil asks every time)
+	missing    MissingCache
+	missingTTL time.Duration
 }
 
 // NewClient creates a new Wikipedia client.
@@ -21,6 +40,43 @@ func NewClient(r *request.Client) *Client {
 	return &Client{request: r}
 }
 
+// SetMissingCache remembers missing articles for ttl, so an article that was
+// deleted or renamed isn't requested again on every flight.
+func (c *Client) SetMissingCache(mc MissingCache, ttl time.Duration) {
+	c.missing = mc
+	c.missingTTL = ttl
+}
+
+func missingKey(title, lang string) string {
+	return fmt.Sprintf("wp_missing_%s_%s", lang, title)
+}
+
+// knownMissing reports whether title was found missing within the TTL.
+func (c *Client) knownMissing(ctx context.Context, title, lang string) bool {
+	if c.missing == nil || c.missingTTL <= 0 {
+		return false
+	}
+	val, ok := c.missing.GetCache(ctx, missingKey(title, lang))
+	if !ok {
+		return false
+	}
+	at, err := strconv.ParseInt(string(val), 10, 64)
+	return err == nil && time.Since(time.Unix(at, 0)) < c.missingTTL
+}
+
+// notFound remembers title as missing and returns the error for it.
+func (c *Client) notFound(ctx context.Context, title, lang string) error {
+	if c.missing != nil && c.missingTTL > 0 {
+		_ = c.missing.SetCache(ctx, missingKey(title, lang), []byte(strconv.FormatInt(time.Now().Unix(), 10)))
+	}
+	return fmt.Errorf("%w: %s", ErrArticleNotFound, title)
+}
+
+func isHTTPNotFound(err error) bool {
+	var se *request.StatusError
+	return errors.As(err, &se) && se.Code == http.StatusNotFound
+}
+
 // GetArticleLengths fetches the length (in bytes) of multiple articles in a specific language.
 // Returns a map of Title -> Length.
 func (c *Client) GetArticleLengths(ctx context.Context, titles []string, lang string) (map[string]int, error) {
@@ -114,7 +170,13 @@ func (c *Client) GetArticleContent(ctx context.Context, title, lang string) (str
 	q.Add("redirects", "1")
 	u.RawQuery = q.Encode()
 
+	if c.knownMissing(ctx, title, lang) {
+		return "", fmt.Errorf("%w: %s", ErrArticleNotFound, title)
+	}
 	body, err := c.request.Get(ctx, u.String(), "")
+	if isHTTPNotFound(err) {
+		return "", c.notFound(ctx, title, lang)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -122,7 +184,8 @@ func (c *Client) GetArticleContent(ctx context.Context, title, lang string) (str
 	var apiResp struct {
 		Query struct {
 			Pages map[string]struct {
-				Extract string `json:"extract"`
+				Extract string  `json:"extract"`
+				Missing *string `json:"missing"`
 			} `json:"pages"`
 		} `json:"query"`
 	}
@@ -131,10 +194,13 @@ func (c *Client) GetArticleContent(ctx context.Context, title, lang string) (str
 	}
 
 	for _, page := range apiResp.Query.Pages {
+		if page.Missing != nil {
+			return "", c.notFound(ctx, title, lang)
+		}
 		return page.Extract, nil
 	}
 
-	return "", fmt.Errorf("article not found: %s", title)
+	return "", c.notFound(ctx, title, lang)
 }
 
 // GetArticleHTML fetches the parsed HTML content for a single article.
@@ -160,7 +226,13 @@ func (c *Client) GetArticleHTML(ctx context.Context, title, lang string) (string
 	q.Add("disableeditsection", "1")
 	u.RawQuery = q.Encode()
 
+	if c.knownMissing(ctx, title, lang) {
+		return "", fmt.Errorf("%w: %s", ErrArticleNotFound, title)
+	}
 	body, err := c.request.Get(ctx, u.String(), "")
+	if isHTTPNotFound(err) {
+		return "", c.notFound(ctx, title, lang)
+	}
 	if err != nil {
 		return "", err
 	}
@@ -180,6 +252,9 @@ func (c *Client) GetArticleHTML(ctx context.Context, title, lang string) (string
 		return "", fmt.Errorf("failed to decode json: %w", err)
 	}
 
+	if apiResp.Error.Code == "missingtitle" {
+		return "", c.notFound(ctx, title, lang)
+	}
 	if apiResp.Error.Code != "" {
 		return "", fmt.Errorf("wikipedia api error: %s - %s", apiResp.Error.Code, apiResp.Error.Info)
 	}
@@ -3,8 +3,10 @@ package wikipedia
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -119,3 +121,122 @@ func TestGetArticleLengths(t *testing.T) {
 		t.Errorf("Expected Paname (redirect) length 1000, got %d", lengths["Paname"])
 	}
 }
+
+// memCache is a cache.Cacher that keeps entries in memory.
+type memCache struct {
+	mockCacher
+	data map[string][]byte
+}
+
+func (m *memCache) GetCache(ctx context.Context, key string) ([]byte, bool) {
+	v, ok := m.data[key]
+	return v, ok
+}
+
+func (m *memCache) SetCache(ctx context.Context, key string, val []byte) error {
+	m.data[key] = val
+	return nil
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+
+	reqClient := request.New(&mockCacher{}, tracker.New(), request.ClientConfig{
+		Retries:   3,
+		BaseDelay: 1 * time.Millisecond,
+		MaxDelay:  5 * time.Millisecond,
+	})
+	client := NewClient(reqClient)
+	client.APIEndpoint = ts.URL
+	return client
+}
+
+func TestGetArticleHTML_RetriesTransientFailure(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"parse": {"text": {"*": "<p>Prose</p>"}}}`))
+	})
+
+	html, err := client.GetArticleHTML(context.Background(), "Paris", "en")
+	if err != nil {
+		t.Fatalf("GetArticleHTML failed: %v", err)
+	}
+	if html != "<p>Prose</p>" {
+		t.Errorf("got %q", html)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+}
+
+func TestGetArticle_MissingIsCached(t *testing.T) {
+	tests := []struct {
+		name  string
+		fetch func(c *Client) error
+		reply func(w http.ResponseWriter)
+	}{
+		{
+			name: "HTTP 404",
+			fetch: func(c *Client) error {
+				_, err := c.GetArticleHTML(context.Background(), "Gone", "en")
+				return err
+			},
+			reply: func(w http.ResponseWriter) { w.WriteHeader(http.StatusNotFound) },
+		},
+		{
+			name: "Missing title in parse",
+			fetch: func(c *Client) error {
+				_, err := c.GetArticleHTML(context.Background(), "Gone", "en")
+				return err
+			},
+			reply: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"error": {"code": "missingtitle", "info": "The page you specified doesn't exist."}}`))
+			},
+		},
+		{
+			name: "Missing page in extracts",
+			fetch: func(c *Client) error {
+				_, err := c.GetArticleContent(context.Background(), "Gone", "en")
+				return err
+			},
+			reply: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`{"query": {"pages": {"-1": {"title": "Gone", "missing": ""}}}}`))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				tt.reply(w)
+			})
+			cache := &memCache{data: make(map[string][]byte)}
+			client.SetMissingCache(cache, time.Hour)
+
+			for i := 0; i < 2; i++ {
+				if err := tt.fetch(client); !errors.Is(err, ErrArticleNotFound) {
+					t.Fatalf("fetch %d: expected ErrArticleNotFound, got %v", i+1, err)
+				}
+			}
+			if calls != 1 {
+				t.Errorf("expected the missing article to be requested once, got %d", calls)
+			}
+
+			// Past the TTL the article is asked for again
+			cache.data[missingKey("Gone", "en")] = []byte(strconv.FormatInt(time.Now().Add(-2*time.Hour).Unix(), 10))
+			_ = tt.fetch(client)
+			if calls != 2 {
+				t.Errorf("expected a new request after the TTL, got %d", calls)
+			}
+		})
+	}
+}